@@ -0,0 +1,594 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/madmin/adminpb"
+	"github.com/minio/minio/pkg/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// Profiling data is streamed in chunks of at most this size, well
+// below the default maximum gRPC message size.
+const adminGRPCChunkSize = 1 << 20
+
+// adminGRPCServer serves the admin API over gRPC, callers are
+// authenticated by adminGRPCAuthenticate before any method runs.
+type adminGRPCServer struct{}
+
+type adminGRPCAuthKey struct{}
+
+// adminGRPCAuth - the authenticated caller of a gRPC admin call.
+type adminGRPCAuth struct {
+	claims jwtgo.StandardClaims
+	owner  bool
+}
+
+// newAdminGRPCServer - returns a gRPC server offering the admin API,
+// with the server certificates when TLS is configured.
+func newAdminGRPCServer() *grpc.Server {
+	opts := []grpc.ServerOption{
+		grpc.UnaryInterceptor(adminGRPCUnaryInterceptor),
+		grpc.StreamInterceptor(adminGRPCStreamInterceptor),
+	}
+	if globalIsSSL && globalTLSCerts != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			GetCertificate: globalTLSCerts.GetCertificate,
+			MinVersion:     tls.VersionTLS12,
+		})))
+	}
+
+	s := grpc.NewServer(opts...)
+	adminpb.RegisterAdminServiceServer(s, adminGRPCServer{})
+	return s
+}
+
+// startAdminGRPCServer - serves the admin API over gRPC on the given address.
+func startAdminGRPCServer(addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	globalAdminGRPCServer = newAdminGRPCServer()
+	go func() {
+		logger.LogIf(context.Background(), globalAdminGRPCServer.Serve(l))
+	}()
+	return nil
+}
+
+// adminGRPCAuthenticate - validates the bearer token sent in the
+// authorization metadata, the caller is recorded in the context.
+func adminGRPCAuthenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, errNoAuthToken.Error())
+	}
+	token := strings.TrimPrefix(values[0], jwtAlgorithm+" ")
+	claims, owner, err := webTokenAuthenticate(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return context.WithValue(ctx, adminGRPCAuthKey{}, adminGRPCAuth{claims, owner}), nil
+}
+
+func adminGRPCUnaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := adminGRPCAuthenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+// adminGRPCServerStream - a server stream with the authenticated context.
+type adminGRPCServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *adminGRPCServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func adminGRPCStreamInterceptor(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := adminGRPCAuthenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &adminGRPCServerStream{ss, ctx})
+}
+
+// adminGRPCClientAddr - returns the address of the caller.
+func adminGRPCClientAddr(ctx context.Context) string {
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// validateAdminGRPCReq - returns the object layer if the caller is
+// allowed the given admin action, a gRPC status error otherwise.
+func validateAdminGRPCReq(ctx context.Context, action iampolicy.AdminAction) (ObjectLayer, error) {
+	caller, ok := ctx.Value(adminGRPCAuthKey{}).(adminGRPCAuth)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, errAuthentication.Error())
+	}
+
+	objectAPI := newObjectLayerFn()
+	if objectAPI == nil || globalNotificationSys == nil || globalIAMSys == nil {
+		return nil, status.Error(codes.Unavailable, errServerNotInitialized.Error())
+	}
+
+	if !caller.owner {
+		// Condition values are computed from a request carrying
+		// only what is known of a gRPC call, the caller address
+		// and whether the connection is secure.
+		r := &http.Request{
+			RemoteAddr: adminGRPCClientAddr(ctx) + ":0",
+			Header:     http.Header{},
+			URL:        &url.URL{},
+		}
+		if p, ok := peer.FromContext(ctx); ok {
+			if tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo); ok {
+				r.TLS = &tlsInfo.State
+			}
+		}
+		if !globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     caller.claims.Subject,
+			Action:          iampolicy.Action(action),
+			ConditionValues: getConditionValues(r, "", caller.claims.Subject),
+			IsOwner:         caller.owner,
+		}) {
+			return nil, adminGRPCError(errorCodes.ToAPIErr(ErrAccessDenied), "")
+		}
+	}
+	return objectAPI, nil
+}
+
+// adminGRPCCredential - returns the credential of the caller, whose
+// secret key encrypts the server configuration like in the REST API.
+func adminGRPCCredential(ctx context.Context) (auth.Credentials, error) {
+	caller, ok := ctx.Value(adminGRPCAuthKey{}).(adminGRPCAuth)
+	if !ok {
+		return auth.Credentials{}, status.Error(codes.Unauthenticated, errAuthentication.Error())
+	}
+	if caller.owner {
+		return globalServerConfig.GetCredential(), nil
+	}
+	cred, ok := globalIAMSys.GetUser(caller.claims.Subject)
+	if !ok {
+		return auth.Credentials{}, status.Error(codes.Unauthenticated, errInvalidAccessKeyID.Error())
+	}
+	return cred, nil
+}
+
+// adminGRPCError - converts an API error to a gRPC status error, the
+// code follows the HTTP status of the API error.
+func adminGRPCError(apiErr APIError, detail string) error {
+	if detail == "" {
+		detail = apiErr.Description
+	}
+	var code codes.Code
+	switch apiErr.HTTPStatusCode {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.FailedPrecondition
+	case http.StatusRequestEntityTooLarge:
+		code = codes.ResourceExhausted
+	case http.StatusNotImplemented:
+		code = codes.Unimplemented
+	case http.StatusServiceUnavailable:
+		code = codes.Unavailable
+	default:
+		code = codes.Internal
+	}
+	return status.Error(code, apiErr.Code+": "+detail)
+}
+
+// ServerInfo - returns the information of all the servers.
+func (s adminGRPCServer) ServerInfo(ctx context.Context, req *adminpb.ServerInfoRequest) (*adminpb.ServerInfoResponse, error) {
+	objectAPI, err := validateAdminGRPCReq(ctx, iampolicy.ServerInfoAdminAction)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &adminpb.ServerInfoResponse{}
+	for _, info := range getAllServerInfo(ctx, objectAPI, GetLocalPeer(globalEndpoints)) {
+		server := &adminpb.ServerInfo{
+			Addr:  info.Addr,
+			Error: info.Error,
+		}
+		if data := info.Data; data != nil {
+			server.UptimeSeconds = int64(data.Properties.Uptime / time.Second)
+			server.Version = data.Properties.Version
+			server.CommitId = data.Properties.CommitID
+			server.DeploymentId = data.Properties.DeploymentID
+			server.Region = data.Properties.Region
+			server.SqsArns = data.Properties.SQSARN
+			server.UsedBytes = data.StorageInfo.Used
+			server.TotalBytes = data.StorageInfo.Total
+			server.AvailableBytes = data.StorageInfo.Available
+			server.OnlineDisks = int32(data.StorageInfo.Backend.OnlineDisks)
+			server.OfflineDisks = int32(data.StorageInfo.Backend.OfflineDisks)
+			server.TotalInputBytes = data.ConnStats.TotalInputBytes
+			server.TotalOutputBytes = data.ConnStats.TotalOutputBytes
+		}
+		resp.Servers = append(resp.Servers, server)
+	}
+	return resp, nil
+}
+
+// ServiceAction - restarts or stops all the servers.
+func (s adminGRPCServer) ServiceAction(ctx context.Context, req *adminpb.ServiceActionRequest) (*adminpb.ServiceActionResponse, error) {
+	var serviceSig serviceSignal
+	var adminAction iampolicy.AdminAction
+	switch req.Action {
+	case adminpb.ServiceActionRequest_RESTART:
+		serviceSig = serviceRestart
+		adminAction = iampolicy.ServiceRestartAdminAction
+	case adminpb.ServiceActionRequest_STOP:
+		serviceSig = serviceStop
+		adminAction = iampolicy.ServiceStopAdminAction
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "Unrecognized service action %s requested", req.Action)
+	}
+
+	if _, err := validateAdminGRPCReq(ctx, adminAction); err != nil {
+		return nil, err
+	}
+
+	// Notify all other MinIO peers signal service.
+	for _, nerr := range globalNotificationSys.SignalService(serviceSig) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	// Signal the local server once the reply is on its way.
+	go func() {
+		globalServiceSignalCh <- serviceSig
+	}()
+	return &adminpb.ServiceActionResponse{}, nil
+}
+
+// GetConfig - returns the server configuration encrypted with the
+// secret key of the caller, like the REST API the configuration is
+// never sent in the clear, even over a connection without TLS.
+func (s adminGRPCServer) GetConfig(ctx context.Context, req *adminpb.GetConfigRequest) (*adminpb.Config, error) {
+	objectAPI, err := validateAdminGRPCReq(ctx, iampolicy.ConfigUpdateAdminAction)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := adminGRPCCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := readServerConfig(ctx, objectAPI)
+	if err != nil {
+		return nil, adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+
+	configData, err := json.MarshalIndent(config, "", "\t")
+	if err != nil {
+		return nil, adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+	econfigData, err := madmin.EncryptData(cred.SecretKey, configData)
+	if err != nil {
+		return nil, adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+	return &adminpb.Config{Data: econfigData}, nil
+}
+
+// SetConfig - validates and saves the given server configuration,
+// encrypted with the secret key of the caller.
+func (s adminGRPCServer) SetConfig(ctx context.Context, req *adminpb.Config) (*adminpb.SetConfigResponse, error) {
+	objectAPI, err := validateAdminGRPCReq(ctx, iampolicy.ConfigUpdateAdminAction)
+	if err != nil {
+		return nil, err
+	}
+	cred, err := adminGRPCCredential(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		return nil, adminGRPCError(errorCodes.ToAPIErr(ErrMethodNotAllowed), "")
+	}
+
+	if int64(len(req.Data)) > maxEConfigJSONSize {
+		return nil, adminGRPCError(errorCodes.ToAPIErr(ErrAdminConfigTooLarge), "")
+	}
+
+	configData, err := madmin.DecryptData(cred.SecretKey, bytes.NewReader(req.Data))
+	if err != nil {
+		logger.LogIf(ctx, err, logger.Application)
+		return nil, adminGRPCError(errorCodes.ToAPIErr(ErrAdminConfigBadJSON), "")
+	}
+
+	errCode, err := setServerConfig(ctx, objectAPI, configData)
+	switch {
+	case errCode != ErrNone && err != nil:
+		return nil, adminGRPCError(errorCodes.ToAPIErr(errCode), err.Error())
+	case errCode != ErrNone:
+		return nil, adminGRPCError(errorCodes.ToAPIErr(errCode), "")
+	case err != nil:
+		return nil, adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+	return &adminpb.SetConfigResponse{}, nil
+}
+
+// Heal - starts a heal sequence and streams its results until it
+// has finished, the heal sequence is stopped when the caller goes away.
+func (s adminGRPCServer) Heal(req *adminpb.HealRequest, stream adminpb.AdminService_HealServer) error {
+	ctx := stream.Context()
+	objectAPI, err := validateAdminGRPCReq(ctx, iampolicy.HealAdminAction)
+	if err != nil {
+		return err
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		return adminGRPCError(errorCodes.ToAPIErr(ErrHealNotImplemented), "")
+	}
+
+	if req.Bucket == "" {
+		if req.Prefix != "" {
+			// Bucket is required if object-prefix is given
+			return adminGRPCError(errorCodes.ToAPIErr(ErrHealMissingBucket), "")
+		}
+	} else if isReservedOrInvalidBucket(req.Bucket, false) {
+		return adminGRPCError(errorCodes.ToAPIErr(ErrInvalidBucketName), "")
+	}
+	if !IsValidObjectPrefix(req.Prefix) {
+		return adminGRPCError(errorCodes.ToAPIErr(ErrInvalidObjectName), "")
+	}
+
+	opts := madmin.HealOpts{
+		Recursive: req.Recursive,
+		DryRun:    req.DryRun,
+		Remove:    req.Remove,
+		ScanMode:  madmin.HealNormalScan,
+	}
+	if req.DeepScan {
+		opts.ScanMode = madmin.HealDeepScan
+	}
+
+	// find number of disks in the setup
+	info := objectAPI.StorageInfo(ctx)
+	numDisks := info.Backend.OfflineDisks + info.Backend.OnlineDisks
+
+	nh := newHealSequence(req.Bucket, req.Prefix, adminGRPCClientAddr(ctx), numDisks, opts, req.ForceStart)
+	respBytes, apiErr, errMsg := globalAllHealState.LaunchNewHealSequence(nh)
+	if apiErr != noError {
+		return adminGRPCError(apiErr, errMsg)
+	}
+	var started madmin.HealStartSuccess
+	if err = json.Unmarshal(respBytes, &started); err != nil {
+		return adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+
+	healPath := pathJoin(req.Bucket, req.Prefix)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		statusBytes, errCode := globalAllHealState.PopHealStatusJSON(healPath, started.ClientToken)
+		if errCode != ErrNone {
+			return adminGRPCError(errorCodes.ToAPIErr(errCode), "")
+		}
+		var taskStatus madmin.HealTaskStatus
+		if err = json.Unmarshal(statusBytes, &taskStatus); err != nil {
+			return adminGRPCError(toAdminAPIErr(ctx, err), "")
+		}
+		for _, item := range taskStatus.Items {
+			if err = stream.Send(toAdminPBHealResultItem(item)); err != nil {
+				globalAllHealState.stopHealSequence(healPath)
+				return err
+			}
+		}
+
+		switch taskStatus.Summary {
+		case healFinishedStatus:
+			return nil
+		case healStoppedStatus:
+			return status.Error(codes.Aborted, taskStatus.FailureDetail)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			globalAllHealState.stopHealSequence(healPath)
+			return ctx.Err()
+		case <-GlobalServiceDoneCh:
+			return status.Error(codes.Unavailable, "server is shutting down")
+		}
+	}
+}
+
+func toAdminPBHealDrives(drives []madmin.HealDriveInfo) []*adminpb.HealDriveInfo {
+	var pbDrives []*adminpb.HealDriveInfo
+	for _, drive := range drives {
+		pbDrives = append(pbDrives, &adminpb.HealDriveInfo{
+			Uuid:     drive.UUID,
+			Endpoint: drive.Endpoint,
+			State:    drive.State,
+			Block:    int32(drive.Block),
+		})
+	}
+	return pbDrives
+}
+
+func toAdminPBHealResultItem(item madmin.HealResultItem) *adminpb.HealResultItem {
+	return &adminpb.HealResultItem{
+		ResultIndex:  item.ResultIndex,
+		Type:         string(item.Type),
+		Bucket:       item.Bucket,
+		Object:       item.Object,
+		Detail:       item.Detail,
+		ParityBlocks: int32(item.ParityBlocks),
+		DataBlocks:   int32(item.DataBlocks),
+		DiskCount:    int32(item.DiskCount),
+		SetCount:     int32(item.SetCount),
+		ObjectSize:   item.ObjectSize,
+		Before:       toAdminPBHealDrives(item.Before.Drives),
+		After:        toAdminPBHealDrives(item.After.Drives),
+	}
+}
+
+// Trace - streams the HTTP trace of all the servers.
+func (s adminGRPCServer) Trace(req *adminpb.TraceRequest, stream adminpb.AdminService_TraceServer) error {
+	ctx := stream.Context()
+	if _, err := validateAdminGRPCReq(ctx, iampolicy.TraceAdminAction); err != nil {
+		return err
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Trace Publisher and peer-trace-client uses nonblocking send and hence does not wait for slow receivers.
+	// Use buffered channel to take care of burst sends or slow stream.Send()
+	traceCh := make(chan interface{}, 4000)
+
+	peers, err := getRestClients(getRemoteHosts(globalEndpoints))
+	if err != nil {
+		return adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+
+	globalHTTPTrace.Subscribe(traceCh, doneCh, func(entry interface{}) bool {
+		return mustTrace(entry, req.All, req.ErrorsOnly)
+	})
+
+	for _, client := range peers {
+		client.Trace(traceCh, doneCh, req.All, req.ErrorsOnly)
+	}
+
+	for {
+		select {
+		case entry := <-traceCh:
+			info, ok := entry.(trace.Info)
+			if !ok {
+				continue
+			}
+			if err := stream.Send(&adminpb.TraceInfo{
+				NodeName:     info.NodeName,
+				FuncName:     info.FuncName,
+				TimeUnixNano: info.ReqInfo.Time.UnixNano(),
+				Method:       info.ReqInfo.Method,
+				Path:         info.ReqInfo.Path,
+				RawQuery:     info.ReqInfo.RawQuery,
+				Client:       info.ReqInfo.Client,
+				StatusCode:   int32(info.RespInfo.StatusCode),
+				InputBytes:   int64(info.CallStats.InputBytes),
+				OutputBytes:  int64(info.CallStats.OutputBytes),
+				LatencyNanos: int64(info.CallStats.Latency),
+				TtfbNanos:    int64(info.CallStats.TimeToFirstByte),
+			}); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return nil
+		case <-GlobalServiceDoneCh:
+			return nil
+		}
+	}
+}
+
+// StartProfiling - starts the given profiler on all the servers.
+func (s adminGRPCServer) StartProfiling(ctx context.Context, req *adminpb.StartProfilingRequest) (*adminpb.StartProfilingResponse, error) {
+	if _, err := validateAdminGRPCReq(ctx, iampolicy.ProfilingAdminAction); err != nil {
+		return nil, err
+	}
+
+	results, err := startProfiling(req.Profiler)
+	if err != nil {
+		return nil, adminGRPCError(toAdminAPIErr(ctx, err), "")
+	}
+
+	resp := &adminpb.StartProfilingResponse{}
+	for _, result := range results {
+		resp.Results = append(resp.Results, &adminpb.StartProfilingResponse_Result{
+			NodeName: result.NodeName,
+			Success:  result.Success,
+			Error:    result.Error,
+		})
+	}
+	return resp, nil
+}
+
+// adminGRPCProfilingWriter - sends the written profiling data in chunks.
+type adminGRPCProfilingWriter struct {
+	stream adminpb.AdminService_DownloadProfilingDataServer
+}
+
+func (w adminGRPCProfilingWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := p
+		if len(chunk) > adminGRPCChunkSize {
+			chunk = chunk[:adminGRPCChunkSize]
+		}
+		if err = w.stream.Send(&adminpb.ProfilingData{Data: chunk}); err != nil {
+			return n, err
+		}
+		n += len(chunk)
+		p = p[len(chunk):]
+	}
+	return n, nil
+}
+
+// DownloadProfilingData - streams the zipped profiling data of all the servers.
+func (s adminGRPCServer) DownloadProfilingData(req *adminpb.DownloadProfilingDataRequest, stream adminpb.AdminService_DownloadProfilingDataServer) error {
+	ctx := stream.Context()
+	if _, err := validateAdminGRPCReq(ctx, iampolicy.ProfilingAdminAction); err != nil {
+		return err
+	}
+
+	if !globalNotificationSys.DownloadProfilingData(ctx, adminGRPCProfilingWriter{stream}) {
+		return adminGRPCError(errorCodes.ToAPIErr(ErrAdminProfilerNotEnabled), "")
+	}
+	return nil
+}
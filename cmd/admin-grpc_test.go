@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/madmin/adminpb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestAdminGRPC(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin gRPC tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newAdminGRPCServer()
+	go s.Serve(l)
+	defer s.Stop()
+
+	var conns []*grpc.ClientConn
+	defer func() {
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}()
+	dial := func(opts ...grpc.DialOption) adminpb.AdminServiceClient {
+		conn, err := grpc.Dial(l.Addr().String(), append(opts, grpc.WithInsecure())...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		conns = append(conns, conn)
+		return adminpb.NewAdminServiceClient(conn)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// Calls without a token, or with a token signed with
+	// the wrong secret key, must be rejected.
+	cred := globalServerConfig.GetCredential()
+	for _, client := range []adminpb.AdminServiceClient{
+		dial(),
+		dial(grpc.WithPerRPCCredentials(adminpb.Credentials{AccessKey: cred.AccessKey, SecretKey: "wrong-secret-key", Insecure: true})),
+	} {
+		if _, err = client.ServerInfo(ctx, &adminpb.ServerInfoRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("Expected %v, got %v", codes.Unauthenticated, err)
+		}
+	}
+
+	client := dial(grpc.WithPerRPCCredentials(adminpb.Credentials{AccessKey: cred.AccessKey, SecretKey: cred.SecretKey, Insecure: true}))
+
+	info, err := client.ServerInfo(ctx, &adminpb.ServerInfoRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info.Servers) == 0 {
+		t.Fatal("Expected at least one server info result")
+	}
+	for _, server := range info.Servers {
+		if server.Error != "" {
+			t.Errorf("Unexpected error = %v", server.Error)
+		}
+		if server.Region != globalMinioDefaultRegion {
+			t.Errorf("Expected %s, got %s", globalMinioDefaultRegion, server.Region)
+		}
+	}
+
+	// The configuration is encrypted with the secret key of the caller.
+	config, err := client.GetConfig(ctx, &adminpb.GetConfigRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(config.Data, []byte(cred.SecretKey)) {
+		t.Fatal("Expected the configuration to be encrypted")
+	}
+	configData, err := madmin.DecryptData(cred.SecretKey, bytes.NewReader(config.Data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(configData, []byte(cred.SecretKey)) {
+		t.Fatal("Expected the decrypted configuration to hold the credential")
+	}
+	if _, err = client.SetConfig(ctx, config); err != nil {
+		t.Fatal(err)
+	}
+	badConfigData, err := madmin.EncryptData(cred.SecretKey, []byte(`{"version":`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Plain text and bad JSON are rejected.
+	for _, data := range [][]byte{[]byte(`{"version":"33"}`), badConfigData} {
+		if _, err = client.SetConfig(ctx, &adminpb.Config{Data: data}); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("Expected %v, got %v", codes.InvalidArgument, err)
+		}
+	}
+
+	if _, err = client.ServiceAction(ctx, &adminpb.ServiceActionRequest{}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("Expected %v, got %v", codes.InvalidArgument, err)
+	}
+
+	// Heal the whole setup, the stream ends once the heal has finished.
+	initBackgroundHealing()
+	stream, err := client.Heal(ctx, &adminpb.HealRequest{Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items int
+	for {
+		if _, err = stream.Recv(); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		items++
+	}
+	if items == 0 {
+		t.Fatal("Expected at least one heal result item")
+	}
+}
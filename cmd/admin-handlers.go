@@ -35,11 +35,15 @@ import (
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
 
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucketdefaults"
 	"github.com/minio/minio/pkg/cpu"
+	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/handlers"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/madmin"
@@ -94,13 +98,34 @@ func updateServer(updateURL, sha256Hex string, latestReleaseTime time.Time) (us
 	return us, nil
 }
 
-// ServerUpdateHandler - POST /minio/admin/v1/update?updateURL={updateURL}
+// checkServerUpdate - returns the version updateServer would update to,
+// without updating.
+func checkServerUpdate(updateURL string, latestReleaseTime time.Time) (us madmin.ServerUpdateStatus, err error) {
+	us.CurrentVersion = Version
+	us.UpdatedVersion = Version
+	us.DryRun = true
+	if updateURL == "" && latestReleaseTime.IsZero() {
+		var updateMsg string
+		updateMsg, _, _, latestReleaseTime, err = getUpdateInfo(updateTimeout, getMinioMode())
+		if err != nil {
+			return us, err
+		}
+		if updateMsg == "" {
+			return us, nil
+		}
+	}
+	us.UpdatedVersion = latestReleaseTime.Format(minioReleaseTagTimeLayout)
+	return us, nil
+}
+
+// ServerUpdateHandler - POST /minio/admin/v1/update?updateURL={updateURL}&dryRun={true|false}
 // ----------
-// updates all minio servers and restarts them gracefully.
+// updates all minio servers and restarts them gracefully, with dryRun
+// only reports the version the servers would be updated to.
 func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ServerUpdate")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerUpdateAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -145,6 +170,22 @@ func (a adminAPIHandlers) ServerUpdateHandler(w http.ResponseWriter, r *http.Req
 		updateURL = u.String()
 	}
 
+	if r.URL.Query().Get("dryRun") == "true" {
+		updateStatus, err := checkServerUpdate(updateURL, latestReleaseTime)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+
+		jsonBytes, err := json.Marshal(updateStatus)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		writeSuccessResponseJSON(w, jsonBytes)
+		return
+	}
+
 	for _, nerr := range globalNotificationSys.ServerUpdate(updateURL, sha256Hex, latestReleaseTime) {
 		if nerr.Err != nil {
 			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
@@ -182,23 +223,26 @@ func (a adminAPIHandlers) ServiceActionHandler(w http.ResponseWriter, r *http.Re
 	vars := mux.Vars(r)
 	action := vars["action"]
 
-	objectAPI := validateAdminReq(ctx, w, r)
-	if objectAPI == nil {
-		return
-	}
-
 	var serviceSig serviceSignal
+	var adminAction iampolicy.AdminAction
 	switch madmin.ServiceAction(action) {
 	case madmin.ServiceActionRestart:
 		serviceSig = serviceRestart
+		adminAction = iampolicy.ServiceRestartAdminAction
 	case madmin.ServiceActionStop:
 		serviceSig = serviceStop
+		adminAction = iampolicy.ServiceStopAdminAction
 	default:
 		logger.LogIf(ctx, fmt.Errorf("Unrecognized service action %s requested", action), logger.Application)
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMalformedPOSTRequest), r.URL)
 		return
 	}
 
+	objectAPI, _ := validateAdminReq(ctx, w, r, adminAction)
+	if objectAPI == nil {
+		return
+	}
+
 	// Notify all other MinIO peers signal service.
 	for _, nerr := range globalNotificationSys.SignalService(serviceSig) {
 		if nerr.Err != nil {
@@ -269,22 +313,14 @@ type ServerInfo struct {
 	Data  *ServerInfoData `json:"data"`
 }
 
-// ServerInfoHandler - GET /minio/admin/v1/info
-// ----------
-// Get server information
-func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
-	ctx := newContext(r, w, "ServerInfo")
-
-	objectAPI := validateAdminReq(ctx, w, r)
-	if objectAPI == nil {
-		return
-	}
-
+// getAllServerInfo - returns the server information of all the peers
+// followed by the local server, reported under the given address.
+func getAllServerInfo(ctx context.Context, objectAPI ObjectLayer, addr string) []ServerInfo {
 	serverInfo := globalNotificationSys.ServerInfo(ctx)
 	// Once we have received all the ServerInfo from peers
 	// add the local peer server info as well.
-	serverInfo = append(serverInfo, ServerInfo{
-		Addr: getHostName(r),
+	return append(serverInfo, ServerInfo{
+		Addr: addr,
 		Data: &ServerInfoData{
 			StorageInfo: objectAPI.StorageInfo(ctx),
 			ConnStats:   globalConnStats.toServerConnStats(),
@@ -299,9 +335,21 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 			},
 		},
 	})
+}
+
+// ServerInfoHandler - GET /minio/admin/v1/info
+// ----------
+// Get server information
+func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ServerInfo")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
 
 	// Marshal API response
-	jsonBytes, err := json.Marshal(serverInfo)
+	jsonBytes, err := json.Marshal(getAllServerInfo(ctx, objectAPI, getHostName(r)))
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -312,6 +360,32 @@ func (a adminAPIHandlers) ServerInfoHandler(w http.ResponseWriter, r *http.Reque
 	writeSuccessResponseJSON(w, jsonBytes)
 }
 
+// DataUsageInfoHandler - GET /minio/admin/v1/datausageinfo
+// ----------
+// Get the data usage report of the last background crawl of all buckets
+func (a adminAPIHandlers) DataUsageInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DataUsageInfo")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	dataUsageInfoJSON, err := json.Marshal(dataUsageInfo)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, dataUsageInfoJSON)
+}
+
 // ServerCPULoadInfo holds informantion about cpu utilization
 // of one minio node. It also reports any errors if encountered
 // while trying to reach this server.
@@ -346,7 +420,7 @@ type ServerNetReadPerfInfo struct {
 func (a adminAPIHandlers) PerfInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "PerfInfo")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.PerfInfoAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -471,7 +545,9 @@ func newLockEntry(l lockRequesterInfo, resource, server string) *madmin.LockEntr
 	return entry
 }
 
-func topLockEntries(peerLocks []*PeerLocks) madmin.LockEntries {
+// lockEntries - merges the locks reported by all peers, locks
+// held on multiple servers are reported once.
+func lockEntries(peerLocks []*PeerLocks) madmin.LockEntries {
 	entryMap := make(map[string]*madmin.LockEntry)
 	for _, peerLock := range peerLocks {
 		if peerLock == nil {
@@ -487,10 +563,15 @@ func topLockEntries(peerLocks []*PeerLocks) madmin.LockEntries {
 			}
 		}
 	}
-	var lockEntries = make(madmin.LockEntries, 0)
+	var entries = make(madmin.LockEntries, 0)
 	for _, v := range entryMap {
-		lockEntries = append(lockEntries, *v)
+		entries = append(entries, *v)
 	}
+	return entries
+}
+
+func topLockEntries(peerLocks []*PeerLocks) madmin.LockEntries {
+	lockEntries := lockEntries(peerLocks)
 	sort.Sort(lockEntries)
 	const listCount int = 10
 	if len(lockEntries) > listCount {
@@ -509,38 +590,75 @@ type PeerLocks struct {
 func (a adminAPIHandlers) TopLocksHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "TopLocks")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TopLocksAdminAction)
 	if objectAPI == nil {
 		return
 	}
 
-	// Method only allowed in Distributed XL mode.
-	if !globalIsDistXL {
-		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+	topLocks := topLockEntries(getPeerLocks(ctx, r))
+
+	// Marshal API response
+	jsonBytes, err := json.Marshal(topLocks)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	// Reply with storage information (across nodes in a
+	// distributed setup) as json.
+	writeSuccessResponseJSON(w, jsonBytes)
+}
+
+// getPeerLocks - returns the locks held on all servers, in
+// non distributed setups only the local namespace locks.
+func getPeerLocks(ctx context.Context, r *http.Request) []*PeerLocks {
+	if !globalIsDistXL {
+		return []*PeerLocks{{
+			Addr:  getHostName(r),
+			Locks: globalNSMutex.DupLockMap(),
+		}}
+	}
+
 	peerLocks := globalNotificationSys.GetLocks(ctx)
 	// Once we have received all the locks currently used from peers
 	// add the local peer locks list as well.
 	localLocks := globalLockServer.ll.DupLockMap()
-	peerLocks = append(peerLocks, &PeerLocks{
+	return append(peerLocks, &PeerLocks{
 		Addr:  getHostName(r),
 		Locks: localLocks,
 	})
+}
 
-	topLocks := topLockEntries(peerLocks)
+// ListLocksHandler - GET /minio/admin/v1/locks?marker={marker}&max-items={max-items}&filter={prefix}&format={json|csv}
+// ----------
+// Lists all the locks in use, locks are ordered by resource and
+// filtered by resource prefix.
+func (a adminAPIHandlers) ListLocksHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListLocks")
 
-	// Marshal API response
-	jsonBytes, err := json.Marshal(topLocks)
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.TopLocksAdminAction)
+	if objectAPI == nil {
 		return
 	}
 
-	// Reply with storage information (across nodes in a
-	// distributed setup) as json.
-	writeSuccessResponseJSON(w, jsonBytes)
+	opts, _, errCode := getAdminListOpts(r.URL.Query())
+	if errCode != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
+	var entries []adminListEntry
+	for _, l := range lockEntries(getPeerLocks(ctx, r)) {
+		entries = append(entries, adminListEntry{
+			key:  pathJoin(l.Resource, l.ID),
+			item: l,
+			record: []string{l.Resource, l.ID, l.Type, l.Timestamp.Format(time.RFC3339),
+				l.Source, l.Owner, strings.Join(l.ServerList, " ")},
+		})
+	}
+
+	header := []string{"resource", "id", "type", "time", "source", "owner", "servers"}
+	writeAdminListResponse(ctx, w, r, opts, header, entries)
 }
 
 // StartProfilingResult contains the status of the starting
@@ -557,7 +675,7 @@ type StartProfilingResult struct {
 func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "StartProfiling")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ProfilingAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -565,12 +683,30 @@ func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.R
 	vars := mux.Vars(r)
 	profiler := vars["profilerType"]
 
-	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
+	startProfilingResult, err := startProfiling(profiler)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Create JSON result and send it to the client
+	startProfilingResultInBytes, err := json.Marshal(startProfilingResult)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	writeSuccessResponseJSON(w, []byte(startProfilingResultInBytes))
+}
+
+// startProfiling - starts the given profiler on all the peers
+// and locally, returns the result of every server.
+func startProfiling(profiler string) ([]StartProfilingResult, error) {
+	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
+	if err != nil {
+		return nil, err
+	}
+
 	// Start profiling on remote servers.
 	hostErrs := globalNotificationSys.StartProfiling(profiler)
 
@@ -604,15 +740,7 @@ func (a adminAPIHandlers) StartProfilingHandler(w http.ResponseWriter, r *http.R
 		}
 		startProfilingResult = append(startProfilingResult, result)
 	}
-
-	// Create JSON result and send it to the client
-	startProfilingResultInBytes, err := json.Marshal(startProfilingResult)
-	if err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
-	}
-
-	writeSuccessResponseJSON(w, []byte(startProfilingResultInBytes))
+	return startProfilingResult, nil
 }
 
 // dummyFileInfo represents a dummy representation of a profile data file
@@ -639,7 +767,7 @@ func (f dummyFileInfo) Sys() interface{}   { return f.sys }
 func (a adminAPIHandlers) DownloadProfilingHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DownloadProfiling")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ProfilingAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -731,7 +859,7 @@ func extractHealInitParams(vars map[string]string, qParms url.Values, r io.Reade
 func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "Heal")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -875,7 +1003,7 @@ func (a adminAPIHandlers) HealHandler(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) BackgroundHealStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HealBackgroundStatus")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -915,12 +1043,43 @@ func (a adminAPIHandlers) BackgroundHealStatusHandler(w http.ResponseWriter, r *
 	w.(http.Flusher).Flush()
 }
 
+// ScrubStatusHandler - GET /minio/admin/v1/scrub/status
+// Returns the background scrub status of the disks of all servers.
+func (a adminAPIHandlers) ScrubStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubStatus")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	// Check if this setup has an erasure coded backend.
+	if !globalIsXL {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrHealNotImplemented), r.URL)
+		return
+	}
+
+	status := madmin.ScrubStatus{Disks: globalBackgroundScrub.Status()}
+	if globalIsDistXL {
+		status.Disks = append(status.Disks, globalNotificationSys.ScrubStatus()...)
+	}
+	status.Enabled = len(status.Disks) > 0
+
+	statusJSON, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, statusJSON)
+}
+
 // GetConfigHandler - GET /minio/admin/v1/config
 // Get config.json of this minio setup.
 func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetConfigHandler")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -937,7 +1096,7 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	password := config.GetCredential().SecretKey
+	password := cred.SecretKey
 	econfigData, err := madmin.EncryptData(password, configData)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -947,22 +1106,23 @@ func (a adminAPIHandlers) GetConfigHandler(w http.ResponseWriter, r *http.Reques
 	writeSuccessResponseJSON(w, econfigData)
 }
 
-func validateAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request) ObjectLayer {
+func validateAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request, action iampolicy.AdminAction) (ObjectLayer, auth.Credentials) {
+	var cred auth.Credentials
 	// Get current object layer instance.
 	objectAPI := newObjectLayerFn()
 	if objectAPI == nil || globalNotificationSys == nil || globalIAMSys == nil {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
-		return nil
+		return nil, cred
 	}
 
-	// Validate request signature.
-	adminAPIErr := checkAdminRequestAuthType(ctx, r, "")
+	// Validate request signature and admin policy.
+	cred, adminAPIErr := checkAdminRequestAuthType(ctx, r, action, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
-		return nil
+		return nil, cred
 	}
 
-	return objectAPI
+	return objectAPI, cred
 }
 
 // AdminError - is a generic error for all admin APIs.
@@ -981,6 +1141,12 @@ const (
 	AdminUpdateUnexpectedFailure = "XMinioAdminUpdateUnexpectedFailure"
 	AdminUpdateURLNotReachable   = "XMinioAdminUpdateURLNotReachable"
 	AdminUpdateApplyFailure      = "XMinioAdminUpdateApplyFailure"
+	AdminInvalidBucketDefaults   = "XMinioAdminInvalidBucketDefaults"
+	AdminNoSuchBucketDefaults    = "XMinioAdminNoSuchBucketDefaults"
+	AdminInvalidBucketMetadata   = "XMinioAdminInvalidBucketMetadata"
+	AdminInvalidBucketSnapshot   = "XMinioAdminInvalidBucketSnapshotName"
+	AdminBucketSnapshotExists    = "XMinioAdminBucketSnapshotExists"
+	AdminNoSuchBucketSnapshot    = "XMinioAdminNoSuchBucketSnapshot"
 )
 
 // toAdminAPIErrCode - converts errXLWriteQuorum error to admin API
@@ -1016,7 +1182,7 @@ func toAdminAPIErr(ctx context.Context, err error) APIError {
 func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "RemoveUser")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DeleteUserAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1048,24 +1214,51 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListUsers")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ListUsersAdminAction)
 	if objectAPI == nil {
 		return
 	}
 
+	opts, paginated, errCode := getAdminListOpts(r.URL.Query())
+	if errCode != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	allCredentials, err := globalIAMSys.ListUsers()
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
-	data, err := json.Marshal(allCredentials)
+	var data []byte
+	if paginated {
+		var entries []adminListEntry
+		for accessKey, info := range allCredentials {
+			entries = append(entries, adminListEntry{
+				key: accessKey,
+				item: struct {
+					AccessKey string `json:"accessKey"`
+					madmin.UserInfo
+				}{accessKey, info},
+				record: []string{accessKey, info.PolicyName, string(info.Status), strings.Join(info.MemberOf, " ")},
+			})
+		}
+		header := []string{"accessKey", "policyName", "status", "memberOf"}
+		var nextMarker string
+		data, _, nextMarker, err = encodeAdminList(opts, header, entries)
+		if nextMarker != "" {
+			w.Header().Set(adminListNextMarkerHeader, nextMarker)
+		}
+	} else {
+		data, err = json.Marshal(allCredentials)
+	}
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
-	password := globalServerConfig.GetCredential().SecretKey
+	password := cred.SecretKey
 	econfigData, err := madmin.EncryptData(password, data)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -1079,7 +1272,7 @@ func (a adminAPIHandlers) ListUsers(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetUserInfo")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetUserAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1106,7 +1299,7 @@ func (a adminAPIHandlers) GetUserInfo(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "UpdateGroupMembers")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AddUserToGroupAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1149,7 +1342,7 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 func (a adminAPIHandlers) GetGroup(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "GetGroup")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetGroupAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1176,17 +1369,32 @@ func (a adminAPIHandlers) GetGroup(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) ListGroups(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListGroups")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListGroupsAdminAction)
 	if objectAPI == nil {
 		return
 	}
 
+	opts, paginated, errCode := getAdminListOpts(r.URL.Query())
+	if errCode != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	groups, err := globalIAMSys.ListGroups()
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	if paginated {
+		var entries []adminListEntry
+		for _, group := range groups {
+			entries = append(entries, adminListEntry{key: group, item: group, record: []string{group}})
+		}
+		writeAdminListResponse(ctx, w, r, opts, []string{"group"}, entries)
+		return
+	}
+
 	body, err := json.Marshal(groups)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -1200,15 +1408,20 @@ func (a adminAPIHandlers) ListGroups(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetGroupStatus")
 
-	objectAPI := validateAdminReq(ctx, w, r)
-	if objectAPI == nil {
-		return
-	}
-
 	vars := mux.Vars(r)
 	group := vars["group"]
 	status := vars["status"]
 
+	adminAction := iampolicy.AdminAction(iampolicy.DisableGroupAdminAction)
+	if status == statusEnabled {
+		adminAction = iampolicy.EnableGroupAdminAction
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, adminAction)
+	if objectAPI == nil {
+		return
+	}
+
 	var err error
 	if status == statusEnabled {
 		err = globalIAMSys.SetGroupStatus(group, true)
@@ -1235,7 +1448,12 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetUserStatus")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	adminAction := iampolicy.AdminAction(iampolicy.DisableUserAdminAction)
+	if madmin.AccountStatus(mux.Vars(r)["status"]) == madmin.AccountEnabled {
+		adminAction = iampolicy.EnableUserAdminAction
+	}
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, adminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1274,7 +1492,7 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddUser")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.CreateUserAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1300,7 +1518,7 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	password := globalServerConfig.GetCredential().SecretKey
+	password := cred.SecretKey
 	configBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -1333,7 +1551,7 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) InfoCannedPolicy(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "InfoCannedPolicy")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GetPolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1352,17 +1570,39 @@ func (a adminAPIHandlers) InfoCannedPolicy(w http.ResponseWriter, r *http.Reques
 func (a adminAPIHandlers) ListCannedPolicies(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ListCannedPolicies")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListUserPoliciesAdminAction)
 	if objectAPI == nil {
 		return
 	}
 
+	opts, paginated, errCode := getAdminListOpts(r.URL.Query())
+	if errCode != ErrNone {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	}
+
 	policies, err := globalIAMSys.ListPolicies()
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
 
+	if paginated {
+		var entries []adminListEntry
+		for name, policy := range policies {
+			entries = append(entries, adminListEntry{
+				key: name,
+				item: struct {
+					Name   string          `json:"name"`
+					Policy json.RawMessage `json:"policy"`
+				}{name, policy},
+				record: []string{name, string(policy)},
+			})
+		}
+		writeAdminListResponse(ctx, w, r, opts, []string{"name", "policy"}, entries)
+		return
+	}
+
 	if err = json.NewEncoder(w).Encode(policies); err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -1375,7 +1615,7 @@ func (a adminAPIHandlers) ListCannedPolicies(w http.ResponseWriter, r *http.Requ
 func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "RemoveCannedPolicy")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DeletePolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1407,7 +1647,7 @@ func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Requ
 func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "AddCannedPolicy")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.CreatePolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1463,7 +1703,7 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetPolicyForUserOrGroup")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.AttachPolicyAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1497,7 +1737,7 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "SetConfigHandler")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1514,7 +1754,7 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	password := globalServerConfig.GetCredential().SecretKey
+	password := cred.SecretKey
 	configBytes, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
 	if err != nil {
 		logger.LogIf(ctx, err, logger.Application)
@@ -1522,47 +1762,57 @@ func (a adminAPIHandlers) SetConfigHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	errCode, err := setServerConfig(ctx, objectAPI, configBytes)
+	switch {
+	case errCode != ErrNone && err != nil:
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), err.Error(), r.URL)
+		return
+	case errCode != ErrNone:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(errCode), r.URL)
+		return
+	case err != nil:
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Reply to the client before restarting minio server.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// setServerConfig - validates and saves the given configuration. Bad
+// configurations are reported by their error code, along with the
+// error detail when there is one; an error with ErrNone is internal.
+func setServerConfig(ctx context.Context, objectAPI ObjectLayer, configBytes []byte) (APIErrorCode, error) {
 	// Validate JSON provided in the request body: check the
 	// client has not sent JSON objects with duplicate keys.
-	if err = quick.CheckDuplicateKeys(string(configBytes)); err != nil {
+	if err := quick.CheckDuplicateKeys(string(configBytes)); err != nil {
 		logger.LogIf(ctx, err, logger.Application)
-		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
-		return
+		return ErrAdminConfigBadJSON, nil
 	}
 
 	var config serverConfig
-	if err = json.Unmarshal(configBytes, &config); err != nil {
+	if err := json.Unmarshal(configBytes, &config); err != nil {
 		logger.LogIf(ctx, err)
-		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
-		return
+		return ErrAdminConfigBadJSON, err
 	}
 
 	// If credentials for the server are provided via environment,
 	// then credentials in the provided configuration must match.
 	if globalIsEnvCreds {
 		if !globalServerConfig.GetCredential().Equal(config.Credential) {
-			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminCredentialsMismatch), r.URL)
-			return
+			return ErrAdminCredentialsMismatch, nil
 		}
 	}
 
-	if err = config.Validate(); err != nil {
-		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
-		return
-	}
-
-	if err = config.TestNotificationTargets(); err != nil {
-		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), err.Error(), r.URL)
-		return
+	if err := config.Validate(); err != nil {
+		return ErrAdminConfigBadJSON, err
 	}
 
-	if err = saveServerConfig(ctx, objectAPI, &config); err != nil {
-		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
-		return
+	if err := config.TestNotificationTargets(); err != nil {
+		return ErrAdminConfigBadJSON, err
 	}
 
-	// Reply to the client before restarting minio server.
-	writeSuccessResponseHeadersOnly(w)
+	return ErrNone, saveServerConfig(ctx, objectAPI, &config)
 }
 
 // Returns true if the trace.Info should be traced,
@@ -1591,7 +1841,7 @@ func (a adminAPIHandlers) TraceHandler(w http.ResponseWriter, r *http.Request) {
 	trcErr := r.URL.Query().Get("err") == "true"
 
 	// Validate request signature.
-	adminAPIErr := checkAdminRequestAuthType(ctx, r, "")
+	_, adminAPIErr := checkAdminRequestAuthType(ctx, r, iampolicy.TraceAdminAction, "")
 	if adminAPIErr != ErrNone {
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(adminAPIErr), r.URL)
 		return
@@ -1645,7 +1895,7 @@ func (a adminAPIHandlers) TraceHandler(w http.ResponseWriter, r *http.Request) {
 func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "ConsoleLog")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConsoleLogAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1717,7 +1967,7 @@ func (a adminAPIHandlers) ConsoleLogHandler(w http.ResponseWriter, r *http.Reque
 func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "KMSKeyStatusHandler")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.KMSKeyStatusAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1802,7 +2052,7 @@ func (a adminAPIHandlers) KMSKeyStatusHandler(w http.ResponseWriter, r *http.Req
 func (a adminAPIHandlers) ServerHardwareInfoHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "HardwareInfo")
 
-	objectAPI := validateAdminReq(ctx, w, r)
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
 	if objectAPI == nil {
 		return
 	}
@@ -1833,3 +2083,791 @@ func (a adminAPIHandlers) ServerHardwareInfoHandler(w http.ResponseWriter, r *ht
 		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
 	}
 }
+
+// PresignHandler - POST /minio/admin/v1/presign
+// ----------
+// Returns a presigned URL signed with the credentials of the caller,
+// for POST the form fields of a browser based upload are returned as
+// well. Allows applications without an S3 SDK to hand out download
+// and upload URLs.
+func (a adminAPIHandlers) PresignHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Presign")
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.PresignAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	defer r.Body.Close()
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	var req madmin.PresignRequest
+	if err = json.Unmarshal(data, &req); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if isReservedOrInvalidBucket(req.Bucket, false) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL)
+		return
+	}
+	if !IsValidObjectName(req.Object) {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectName), r.URL)
+		return
+	}
+
+	// Content type only applies to uploads, the size of an
+	// upload can only be restricted by a POST policy.
+	if (req.Method == http.MethodGet && req.ContentType != "") ||
+		(req.Method != http.MethodPost && req.MaxSize != 0) || req.MaxSize < 0 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	region := globalServerConfig.GetRegion()
+	scheme := getURLScheme(globalIsSSL) + "://"
+
+	var resp madmin.PresignResponse
+	switch req.Method {
+	case http.MethodGet, http.MethodPut:
+		headers := make(http.Header)
+		if req.ContentType != "" {
+			headers.Set(xhttp.ContentType, req.ContentType)
+		}
+		resp.URL = scheme + presignedURL(req.Method, r.Host, req.Bucket, req.Object, req.Expiry, headers, cred, region)
+	case http.MethodPost:
+		resp.FormData, err = presignedPostPolicy(req.Bucket, req.Object, req.Expiry, req.ContentType, req.MaxSize, cred, region)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		resp.URL = scheme + r.Host + s3utils.EncodePath(SlashSeparator+req.Bucket)
+	default:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	respBytes, err := json.Marshal(resp)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, respBytes)
+}
+
+// getDecommissionPools - returns the server pools of an expanded
+// deployment, writes an error response if there are none.
+func getDecommissionPools(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer) *xlServerPools {
+	z, ok := objectAPI.(*xlServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+	return z
+}
+
+// DecommissionHandler - POST /minio/admin/v1/pools/decommission?pool=<pool>
+// ----------
+// Starts decommissioning a server pool, new objects are no longer written
+// to the pool and all its objects are moved to the other server pools.
+func (a adminAPIHandlers) DecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Decommission")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z := getDecommissionPools(ctx, w, r, objectAPI)
+	if z == nil {
+		return
+	}
+
+	pool, err := strconv.Atoi(mux.Vars(r)["pool"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if err = z.Decommission(ctx, pool); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// CancelDecommissionHandler - POST /minio/admin/v1/pools/decommission/cancel?pool=<pool>
+// ----------
+// Stops decommissioning a server pool, objects already moved
+// are kept on the other server pools.
+func (a adminAPIHandlers) CancelDecommissionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z := getDecommissionPools(ctx, w, r, objectAPI)
+	if z == nil {
+		return
+	}
+
+	pool, err := strconv.Atoi(mux.Vars(r)["pool"])
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL)
+		return
+	}
+
+	if err = z.CancelDecommission(ctx, pool); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// DecommissionStatusHandler - GET /minio/admin/v1/pools/decommission/status
+// ----------
+// Returns the status of all server pools which are or were decommissioned.
+func (a adminAPIHandlers) DecommissionStatusHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DecommissionStatus")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	z := getDecommissionPools(ctx, w, r, objectAPI)
+	if z == nil {
+		return
+	}
+
+	status, err := z.DecommissionStatus(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// PlacementInfoHandler - GET /minio/admin/v1/placement
+// ----------
+// Returns the erasure sets the prefixes pinned by the placement rules
+// are stored on, in every server pool.
+func (a adminAPIHandlers) PlacementInfoHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PlacementInfo")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var placements []madmin.PrefixPlacement
+	switch z := objectAPI.(type) {
+	case *xlSets:
+		placements = z.prefixPlacements(1)
+	case *xlServerPools:
+		for i, pool := range z.pools {
+			if s, ok := pool.(*xlSets); ok {
+				placements = append(placements, s.prefixPlacements(i+1)...)
+			}
+		}
+	default:
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(placements)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// NotificationStatsHandler - GET /minio/admin/v1/notification/stats
+// ----------
+// Returns the delivery statistics of the notification targets of the
+// node serving the request.
+func (a adminAPIHandlers) NotificationStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NotificationStats")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	if globalNotificationSys == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(globalNotificationSys.TargetStats())
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// notificationTestResult - result of a test event sent to a
+// notification target.
+type notificationTestResult struct {
+	ARN     string `json:"arn"`
+	Success bool   `json:"success"`
+	Error   string `json:"error,omitempty"`
+	// Latency to connect to the target and deliver the event.
+	Latency string `json:"latency"`
+}
+
+// NotificationTestHandler - POST /minio/admin/v1/notification/test?arn=<arn>&bucket=<bucket>
+// ----------
+// Connects to the configured notification target and sends it a
+// synthetic s3:ObjectRemoved:Delete event for the object
+// minio-notification-test of the optional bucket. The event is not
+// queued, the response reports whether it was delivered.
+func (a adminAPIHandlers) NotificationTestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "NotificationTest")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.NotificationTestAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	arn, err := event.ParseARN(r.URL.Query().Get("arn"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrARNNotification), r.URL)
+		return
+	}
+
+	bucket := r.URL.Query().Get("bucket")
+	if bucket == "" {
+		bucket = "minio-notification-test"
+	}
+	eventData := eventArgs{
+		EventName:    event.ObjectRemovedDelete,
+		BucketName:   bucket,
+		Object:       ObjectInfo{Bucket: bucket, Name: "minio-notification-test"},
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	}.ToEvent()
+
+	globalServerConfigMu.RLock()
+	config := globalServerConfig
+	globalServerConfigMu.RUnlock()
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	start := time.Now()
+	t, err := config.newTestNotificationTarget(arn.TargetID, doneCh)
+	if err == nil {
+		if t == nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrARNNotification), r.URL)
+			return
+		}
+		err = t.Save(eventData)
+		if cerr := t.Close(); cerr != nil {
+			logger.LogIf(ctx, cerr)
+		}
+	}
+
+	result := notificationTestResult{
+		ARN:     arn.String(),
+		Success: err == nil,
+		Latency: time.Since(start).String(),
+	}
+	if err != nil {
+		result.Error = err.Error()
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseJSON(w, data)
+}
+
+// GatewayCleanupHandler - POST /minio/admin/v1/gateway/cleanup
+// ----------
+// Removes stale multipart uploads from the gateway backend without
+// waiting for the next scheduled cleanup, the cleanup runs in the
+// background.
+func (a adminAPIHandlers) GatewayCleanupHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GatewayCleanup")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.GatewayCleanupAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	c, ok := objectAPI.(gatewayMultipartCleaner)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	if err := c.TriggerMultipartCleanup(); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketDefaultsHandler - GET /minio/admin/v1/bucket-defaults?bucket={bucket}
+// ----------
+// Returns the default object headers of a bucket
+func (a adminAPIHandlers) GetBucketDefaultsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketDefaults")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketDefaultsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	config, err := getBucketDefaultsConfig(objectAPI, bucket)
+	if err != nil {
+		if _, ok := err.(BucketDefaultsNotFound); ok {
+			err = AdminError{
+				Code:       AdminNoSuchBucketDefaults,
+				Message:    err.Error(),
+				StatusCode: http.StatusNotFound,
+			}
+		}
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := json.Marshal(config)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, configData)
+}
+
+// SetBucketDefaultsHandler - PUT /minio/admin/v1/bucket-defaults?bucket={bucket}
+// ----------
+// Sets the default object headers of a bucket, applied to objects stored
+// without them when they are downloaded
+func (a adminAPIHandlers) SetBucketDefaultsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetBucketDefaults")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketDefaultsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxBucketDefaultsSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	config, err := bucketdefaults.ParseConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+			Code:       AdminInvalidBucketDefaults,
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}), r.URL)
+		return
+	}
+
+	if err = saveBucketDefaultsConfig(ctx, objectAPI, bucket, config); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	globalBucketDefaultsSys.Set(bucket, *config)
+	globalNotificationSys.SetBucketDefaults(ctx, bucket, config)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RemoveBucketDefaultsHandler - DELETE /minio/admin/v1/bucket-defaults?bucket={bucket}
+// ----------
+// Removes the default object headers of a bucket
+func (a adminAPIHandlers) RemoveBucketDefaultsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveBucketDefaults")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketDefaultsAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if err := removeBucketDefaultsConfig(ctx, objectAPI, bucket); err != nil {
+		if _, ok := err.(BucketDefaultsNotFound); !ok {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+	}
+
+	globalBucketDefaultsSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketDefaults(ctx, bucket)
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ExportBucketMetadataHandler - GET /minio/admin/v1/bucket-metadata?bucket={bucket}
+// ----------
+// Returns the policy, notification, lifecycle, encryption, website and
+// defaults configurations of a bucket as a gzipped tarball
+func (a adminAPIHandlers) ExportBucketMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ExportBucketMetadata")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketMetadataAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	bundle, err := exportBucketMetadata(ctx, objectAPI, bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentType, "application/x-gzip")
+	writeResponse(w, http.StatusOK, bundle, mimeNone)
+}
+
+// ImportBucketMetadataHandler - PUT /minio/admin/v1/bucket-metadata?bucket={bucket}
+// ----------
+// Sets the configurations of a bucket from a bundle returned by
+// ExportBucketMetadataHandler, the bundle is validated entirely
+// before any configuration is set
+func (a adminAPIHandlers) ImportBucketMetadataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ImportBucketMetadata")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketMetadataAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	if r.ContentLength > maxBucketMetadataBundleSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	bundle, err := parseBucketMetadataBundle(objectAPI, bucket, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, AdminError{
+			Code:       AdminInvalidBucketMetadata,
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}), r.URL)
+		return
+	}
+
+	if err = bundle.apply(ctx, objectAPI, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// validateBucketSnapshotReq - validates a request managing the snapshots
+// of a bucket, which are only supported by the FS backend.
+func validateBucketSnapshotReq(ctx context.Context, w http.ResponseWriter, r *http.Request) *FSObjects {
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.BucketSnapshotAdminAction)
+	if objectAPI == nil {
+		return nil
+	}
+	fs, ok := objectAPI.(*FSObjects)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+	return fs
+}
+
+func toBucketSnapshotAdminErr(ctx context.Context, err error) APIError {
+	switch err {
+	case errInvalidBucketSnapshotName:
+		err = AdminError{
+			Code:       AdminInvalidBucketSnapshot,
+			Message:    err.Error(),
+			StatusCode: http.StatusBadRequest,
+		}
+	case errBucketSnapshotExists:
+		err = AdminError{
+			Code:       AdminBucketSnapshotExists,
+			Message:    err.Error(),
+			StatusCode: http.StatusConflict,
+		}
+	case errBucketSnapshotNotFound:
+		err = AdminError{
+			Code:       AdminNoSuchBucketSnapshot,
+			Message:    err.Error(),
+			StatusCode: http.StatusNotFound,
+		}
+	}
+	return toAdminAPIErr(ctx, err)
+}
+
+// CreateBucketSnapshotHandler - PUT /minio/admin/v1/bucket-snapshot?bucket={bucket}&name={name}
+// ----------
+// Saves the objects of a bucket as a snapshot, data files are
+// hardlinked into the snapshot
+func (a adminAPIHandlers) CreateBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CreateBucketSnapshot")
+
+	fs := validateBucketSnapshotReq(ctx, w, r)
+	if fs == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	snapshot, err := fs.CreateBucketSnapshot(ctx, bucket, r.URL.Query().Get("name"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAdminErr(ctx, err), r.URL)
+		return
+	}
+
+	snapshotData, err := json.Marshal(snapshot)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, snapshotData)
+}
+
+// ListBucketSnapshotsHandler - GET /minio/admin/v1/bucket-snapshots?bucket={bucket}
+// ----------
+// Returns the snapshots of a bucket
+func (a adminAPIHandlers) ListBucketSnapshotsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListBucketSnapshots")
+
+	fs := validateBucketSnapshotReq(ctx, w, r)
+	if fs == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	snapshots, err := fs.ListBucketSnapshots(ctx, bucket)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	snapshotsData, err := json.Marshal(snapshots)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, snapshotsData)
+}
+
+// RemoveBucketSnapshotHandler - DELETE /minio/admin/v1/bucket-snapshot?bucket={bucket}&name={name}
+// ----------
+// Removes a snapshot of a bucket
+func (a adminAPIHandlers) RemoveBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveBucketSnapshot")
+
+	fs := validateBucketSnapshotReq(ctx, w, r)
+	if fs == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := fs.DeleteBucketSnapshot(ctx, bucket, r.URL.Query().Get("name")); err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAdminErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RestoreBucketSnapshotHandler - POST /minio/admin/v1/bucket-snapshot/restore?bucket={bucket}&name={name}
+// ----------
+// Replaces the objects of a bucket by those of one of its snapshots
+func (a adminAPIHandlers) RestoreBucketSnapshotHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestoreBucketSnapshot")
+
+	fs := validateBucketSnapshotReq(ctx, w, r)
+	if fs == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := fs.RestoreBucketSnapshot(ctx, bucket, r.URL.Query().Get("name")); err != nil {
+		writeErrorResponseJSON(ctx, w, toBucketSnapshotAdminErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// ForceRemoveBucketHandler - DELETE /minio/admin/v1/bucket?bucket={bucket}
+// ----------
+// Deletes a bucket along with all of its objects
+func (a adminAPIHandlers) ForceRemoveBucketHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ForceRemoveBucket")
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ForceDeleteBucketAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	bucket := r.URL.Query().Get(string(mgmtBucket))
+	if err := removeBucket(ctx, objectAPI, bucket, true); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// validateOldCredentialReq - validates a request managing the old root
+// credential, which only the current root credential may do.
+func validateOldCredentialReq(ctx context.Context, w http.ResponseWriter, r *http.Request) (ObjectLayer, auth.Credentials) {
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return nil, cred
+	}
+	if cred.AccessKey != globalServerConfig.GetCredential().AccessKey {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL)
+		return nil, cred
+	}
+	return objectAPI, cred
+}
+
+// SetOldCredentialHandler - PUT /minio/admin/v1/old-credential
+// ----------
+// Sets the previous root credential, which remains valid until the
+// given expiry so that clients can migrate to the new root credential.
+func (a adminAPIHandlers) SetOldCredentialHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SetOldCredential")
+
+	objectAPI, cred := validateOldCredentialReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		// More than maxConfigSize bytes were available
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return
+	}
+
+	credBytes, err := madmin.DecryptData(cred.SecretKey, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	var oldCredInfo madmin.OldCredential
+	if err = json.Unmarshal(credBytes, &oldCredInfo); err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigBadJSON), r.URL)
+		return
+	}
+
+	oldCred, err := auth.CreateCredentials(oldCredInfo.AccessKey, oldCredInfo.SecretKey)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if oldCredInfo.Expiry <= 0 {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), "Expiry must be positive", r.URL)
+		return
+	}
+	oldCred.Expiration = UTCNow().Add(oldCredInfo.Expiry)
+
+	if err = globalOldCredSys.Set(oldCred); err != nil {
+		writeCustomErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), err.Error(), r.URL)
+		return
+	}
+
+	// Persist the old credential for restarts and new nodes.
+	if err = globalOldCredSys.Save(ctx, objectAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to set the old credential
+	for _, nerr := range globalNotificationSys.SetOldCredential(oldCred) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// RemoveOldCredentialHandler - DELETE /minio/admin/v1/old-credential
+// ----------
+// Removes the previous root credential before it expires.
+func (a adminAPIHandlers) RemoveOldCredentialHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveOldCredential")
+
+	objectAPI, _ := validateOldCredentialReq(ctx, w, r)
+	if objectAPI == nil {
+		return
+	}
+
+	globalOldCredSys.Remove()
+
+	if err := globalOldCredSys.Delete(ctx, objectAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	// Notify all other MinIO peers to remove the old credential
+	for _, nerr := range globalNotificationSys.RemoveOldCredential() {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
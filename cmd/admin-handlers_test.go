@@ -32,7 +32,10 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/event/target"
 	"github.com/minio/minio/pkg/madmin"
+	xnet "github.com/minio/minio/pkg/net"
 )
 
 var (
@@ -625,6 +628,86 @@ func TestAdminServerInfo(t *testing.T) {
 	}
 }
 
+// TestNotificationTestHandler - test for NotificationTestHandler.
+func TestNotificationTestHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	logs := make(chan event.Log, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Ignore the empty request probing the endpoint.
+		if r.ContentLength == 0 {
+			return
+		}
+		var eventLog event.Log
+		if err := json.NewDecoder(r.Body).Decode(&eventLog); err != nil {
+			t.Error(err)
+		}
+		logs <- eventLog
+	}))
+	defer server.Close()
+
+	endpoint, err := xnet.ParseURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	globalServerConfig.Notify.Webhook["1"] = target.WebhookArgs{Enable: true, Endpoint: *endpoint}
+	// Nothing listens on the endpoint of the second webhook.
+	globalServerConfig.Notify.Webhook["2"] = target.WebhookArgs{Enable: true, Endpoint: xnet.URL{Scheme: "http", Host: "127.0.0.1:1"}}
+
+	testCases := []struct {
+		arn        string
+		statusCode int
+		success    bool
+	}{
+		{arn: "arn:minio:sqs::1:webhook", statusCode: http.StatusOK, success: true},
+		{arn: "arn:minio:sqs::2:webhook", statusCode: http.StatusOK},
+		{arn: "arn:minio:sqs::3:webhook", statusCode: http.StatusBadRequest},
+		{arn: "webhook", statusCode: http.StatusBadRequest},
+	}
+
+	for i, testCase := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("arn", testCase.arn)
+		queryVal.Set("bucket", "images")
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/notification/test", 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: failed to construct notification test request - %v", i+1, err)
+		}
+
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != testCase.statusCode {
+			t.Fatalf("Test %d: expected status %d, got %d, body: %s", i+1, testCase.statusCode, rec.Code, rec.Body)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		var result notificationTestResult
+		if err = json.NewDecoder(rec.Body).Decode(&result); err != nil {
+			t.Fatalf("Test %d: failed to decode result - %v", i+1, err)
+		}
+		if result.Success != testCase.success || result.ARN != testCase.arn || result.Latency == "" {
+			t.Fatalf("Test %d: unexpected result %+v", i+1, result)
+		}
+		if !testCase.success {
+			if result.Error == "" {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+
+		eventLog := <-logs
+		if eventLog.EventName != event.ObjectRemovedDelete || eventLog.Key != "images/minio-notification-test" {
+			t.Fatalf("Test %d: unexpected event %s for %s", i+1, eventLog.EventName, eventLog.Key)
+		}
+	}
+}
+
 // TestToAdminAPIErrCode - test for toAdminAPIErrCode helper function.
 func TestToAdminAPIErrCode(t *testing.T) {
 	testCases := []struct {
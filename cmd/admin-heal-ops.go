@@ -567,6 +567,8 @@ func (h *healSequence) queueHealTask(path string, healType madmin.HealItemType)
 		}
 		res.result.Detail = res.err.Error()
 	}
+	// A dry run which failed does not project any healing.
+	res.result.Diff = res.result.GetDiff(h.settings.DryRun && res.err == nil)
 	return h.pushHealResultItem(res.result)
 }
 
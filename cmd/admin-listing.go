@@ -0,0 +1,167 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Query parameters accepted by admin list APIs.
+const (
+	adminListMarker   = "marker"
+	adminListMaxItems = "max-items"
+	adminListFilter   = "filter"
+	adminListFormat   = "format"
+)
+
+// Maximum number of entries returned in a single admin list page.
+const adminListMaxItemsLimit = 10000
+
+// Means response type is CSV.
+const mimeCSV mimeType = "text/csv"
+
+// Response header carrying the marker of the next page.
+const adminListNextMarkerHeader = "X-Minio-Next-Marker"
+
+// adminListOpts - pagination, filtering and output options of
+// an admin list request.
+type adminListOpts struct {
+	marker   string
+	maxItems int
+	filter   string
+	format   string
+}
+
+// getAdminListOpts - parses the admin list query parameters, ok is
+// false if none of them is present, APIs which predate pagination
+// reply with their legacy response in that case.
+func getAdminListOpts(values url.Values) (opts adminListOpts, ok bool, errCode APIErrorCode) {
+	opts = adminListOpts{
+		marker:   values.Get(adminListMarker),
+		maxItems: adminListMaxItemsLimit,
+		filter:   values.Get(adminListFilter),
+		format:   madmin.ListFormatJSON,
+	}
+	for _, param := range []string{adminListMarker, adminListMaxItems, adminListFilter, adminListFormat} {
+		if _, found := values[param]; found {
+			ok = true
+		}
+	}
+
+	if v := values.Get(adminListMaxItems); v != "" {
+		maxItems, err := strconv.Atoi(v)
+		if err != nil || maxItems <= 0 {
+			return opts, ok, ErrAdminInvalidArgument
+		}
+		if maxItems < adminListMaxItemsLimit {
+			opts.maxItems = maxItems
+		}
+	}
+
+	if v := values.Get(adminListFormat); v != "" {
+		switch opts.format = strings.ToLower(v); opts.format {
+		case madmin.ListFormatJSON, madmin.ListFormatCSV:
+		default:
+			return opts, ok, ErrAdminInvalidArgument
+		}
+	}
+	return opts, ok, ErrNone
+}
+
+// adminListEntry - single entry of an admin list response.
+type adminListEntry struct {
+	// key used for ordering, markers and filtering.
+	key string
+	// item is the JSON representation of the entry.
+	item interface{}
+	// record is the CSV representation of the entry.
+	record []string
+}
+
+// adminListResponse - paginated response envelope, see madmin.ListResult.
+type adminListResponse struct {
+	Items       []interface{} `json:"items"`
+	IsTruncated bool          `json:"isTruncated"`
+	NextMarker  string        `json:"nextMarker,omitempty"`
+}
+
+// encodeAdminList - applies opts to the entries and returns the
+// encoded page, its mime type and the marker of the next page if
+// the listing is truncated. CSV output starts with the header record.
+func encodeAdminList(opts adminListOpts, header []string, entries []adminListEntry) ([]byte, mimeType, string, error) {
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].key < entries[j].key
+	})
+
+	resp := adminListResponse{Items: []interface{}{}}
+	var page []adminListEntry
+	for _, entry := range entries {
+		if opts.marker != "" && entry.key <= opts.marker {
+			continue
+		}
+		if !strings.HasPrefix(entry.key, opts.filter) {
+			continue
+		}
+		if len(page) == opts.maxItems {
+			resp.IsTruncated = true
+			resp.NextMarker = page[len(page)-1].key
+			break
+		}
+		page = append(page, entry)
+	}
+
+	if opts.format == madmin.ListFormatCSV {
+		var buf bytes.Buffer
+		csvWriter := csv.NewWriter(&buf)
+		csvWriter.Write(header)
+		for _, entry := range page {
+			csvWriter.Write(entry.record)
+		}
+		csvWriter.Flush()
+		return buf.Bytes(), mimeCSV, resp.NextMarker, csvWriter.Error()
+	}
+
+	for _, entry := range page {
+		resp.Items = append(resp.Items, entry.item)
+	}
+	data, err := json.Marshal(resp)
+	return data, mimeJSON, resp.NextMarker, err
+}
+
+// writeAdminListResponse - encodes and writes a page of entries
+// as requested by opts.
+func writeAdminListResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, opts adminListOpts, header []string, entries []adminListEntry) {
+	data, mType, nextMarker, err := encodeAdminList(opts, header, entries)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if nextMarker != "" {
+		w.Header().Set(adminListNextMarkerHeader, nextMarker)
+	}
+	writeResponse(w, http.StatusOK, data, mType)
+}
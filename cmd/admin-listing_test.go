@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+func TestGetAdminListOpts(t *testing.T) {
+	testCases := []struct {
+		query     string
+		expectOk  bool
+		expectErr APIErrorCode
+		maxItems  int
+		format    string
+	}{
+		{"", false, ErrNone, adminListMaxItemsLimit, madmin.ListFormatJSON},
+		{"marker=a&filter=b", true, ErrNone, adminListMaxItemsLimit, madmin.ListFormatJSON},
+		{"max-items=10&format=CSV", true, ErrNone, 10, madmin.ListFormatCSV},
+		{"max-items=100000", true, ErrNone, adminListMaxItemsLimit, madmin.ListFormatJSON},
+		{"max-items=-1", true, ErrAdminInvalidArgument, 0, ""},
+		{"max-items=abc", true, ErrAdminInvalidArgument, 0, ""},
+		{"format=xml", true, ErrAdminInvalidArgument, 0, ""},
+	}
+
+	for i, testCase := range testCases {
+		values, err := url.ParseQuery(testCase.query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		opts, ok, errCode := getAdminListOpts(values)
+		if ok != testCase.expectOk || errCode != testCase.expectErr {
+			t.Errorf("Test %d: expected (%v, %v), got (%v, %v)", i+1, testCase.expectOk, testCase.expectErr, ok, errCode)
+			continue
+		}
+		if errCode != ErrNone {
+			continue
+		}
+		if opts.maxItems != testCase.maxItems || opts.format != testCase.format {
+			t.Errorf("Test %d: expected (%d, %s), got (%d, %s)", i+1, testCase.maxItems, testCase.format, opts.maxItems, opts.format)
+		}
+	}
+}
+
+func TestEncodeAdminList(t *testing.T) {
+	var entries []adminListEntry
+	for _, key := range []string{"c", "a", "bb", "b", "ba"} {
+		entries = append(entries, adminListEntry{key: key, item: key, record: []string{key}})
+	}
+
+	testCases := []struct {
+		opts       adminListOpts
+		items      []string
+		nextMarker string
+	}{
+		{adminListOpts{maxItems: 10}, []string{"a", "b", "ba", "bb", "c"}, ""},
+		{adminListOpts{maxItems: 2}, []string{"a", "b"}, "b"},
+		{adminListOpts{marker: "b", maxItems: 2}, []string{"ba", "bb"}, "bb"},
+		{adminListOpts{marker: "bb", maxItems: 2}, []string{"c"}, ""},
+		{adminListOpts{filter: "b", maxItems: 10}, []string{"b", "ba", "bb"}, ""},
+		{adminListOpts{filter: "x", maxItems: 10}, []string{}, ""},
+	}
+
+	for i, testCase := range testCases {
+		testCase.opts.format = madmin.ListFormatJSON
+		data, mType, nextMarker, err := encodeAdminList(testCase.opts, []string{"key"}, entries)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if mType != mimeJSON {
+			t.Errorf("Test %d: expected %s, got %s", i+1, mimeJSON, mType)
+		}
+		var result madmin.ListResult
+		if err = json.Unmarshal(data, &result); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		var items []string
+		if err = json.Unmarshal(result.Items, &items); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if len(items) != len(testCase.items) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.items, items)
+		}
+		for j := range items {
+			if items[j] != testCase.items[j] {
+				t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.items, items)
+			}
+		}
+		if nextMarker != testCase.nextMarker || result.NextMarker != testCase.nextMarker {
+			t.Errorf("Test %d: expected next marker %s, got %s", i+1, testCase.nextMarker, nextMarker)
+		}
+		if result.IsTruncated != (testCase.nextMarker != "") {
+			t.Errorf("Test %d: unexpected truncation %v", i+1, result.IsTruncated)
+		}
+	}
+
+	data, mType, _, err := encodeAdminList(adminListOpts{maxItems: 2, format: madmin.ListFormatCSV}, []string{"key"}, entries)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mType != mimeCSV {
+		t.Errorf("expected %s, got %s", mimeCSV, mType)
+	}
+	if string(data) != "key\na\nb\n" {
+		t.Errorf("unexpected CSV output %q", string(data))
+	}
+}
@@ -0,0 +1,243 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// adminOpenAPIPath - the OpenAPI description of the admin API is
+// served under this path of every admin API version.
+const adminOpenAPIPath = "/openapi.json"
+
+// Matches the variables of a route template, such as `{bucket}`
+// or `{prefix:.*}`, capturing the name and the optional pattern.
+var adminRouteVarRegexp = regexp.MustCompile(`{([^{}:]+)(?::([^{}]*))?}`)
+
+// openAPISpec - OpenAPI 3.0 description of the admin API, only the
+// subset of the specification used by the admin API is modelled.
+type openAPISpec struct {
+	OpenAPI    string                                 `json:"openapi"`
+	Info       openAPIInfo                            `json:"info"`
+	Servers    []openAPIServer                        `json:"servers"`
+	Paths      map[string]map[string]openAPIOperation `json:"paths"`
+	Components openAPIComponents                      `json:"components"`
+	Security   []map[string][]string                  `json:"security"`
+}
+
+type openAPIInfo struct {
+	Title         string `json:"title"`
+	Version       string `json:"version"`
+	ServerVersion string `json:"x-minio-server-version"`
+}
+
+type openAPIServer struct {
+	URL string `json:"url"`
+}
+
+type openAPIOperation struct {
+	OperationID string                     `json:"operationId"`
+	Parameters  []openAPIParameter         `json:"parameters,omitempty"`
+	Responses   map[string]openAPIResponse `json:"responses"`
+}
+
+type openAPIParameter struct {
+	Name     string        `json:"name"`
+	In       string        `json:"in"`
+	Required bool          `json:"required"`
+	Schema   openAPISchema `json:"schema"`
+}
+
+type openAPISchema struct {
+	Ref        string                   `json:"$ref,omitempty"`
+	Type       string                   `json:"type,omitempty"`
+	Pattern    string                   `json:"pattern,omitempty"`
+	Properties map[string]openAPISchema `json:"properties,omitempty"`
+}
+
+type openAPIResponse struct {
+	Description string                      `json:"description"`
+	Content     map[string]openAPIMediaType `json:"content,omitempty"`
+}
+
+type openAPIMediaType struct {
+	Schema openAPISchema `json:"schema"`
+}
+
+type openAPIComponents struct {
+	Schemas         map[string]openAPISchema         `json:"schemas"`
+	SecuritySchemes map[string]openAPISecurityScheme `json:"securitySchemes"`
+}
+
+type openAPISecurityScheme struct {
+	Type        string `json:"type"`
+	In          string `json:"in"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// openAPIRouteVars - returns the variables of a route template as
+// parameters found in the given location, with their pattern unless
+// it matches anything.
+func openAPIRouteVars(template, in string) (params []openAPIParameter) {
+	for _, match := range adminRouteVarRegexp.FindAllStringSubmatch(template, -1) {
+		param := openAPIParameter{
+			Name:     match[1],
+			In:       in,
+			Required: true,
+			Schema:   openAPISchema{Type: "string"},
+		}
+		if pattern := match[2]; pattern != "" && pattern != ".*" {
+			param.Schema.Pattern = "^(" + pattern + ")$"
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// openAPIOperationID - derives the operation id from the method and
+// the path, e.g. `POST /heal/{bucket}` becomes `postHealBucket`.
+func openAPIOperationID(method, path string) string {
+	id := strings.ToLower(method)
+	for _, word := range strings.FieldsFunc(path, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	}) {
+		id += strings.ToUpper(word[:1]) + word[1:]
+	}
+	return id
+}
+
+// getAdminOpenAPISpec - describes the routes registered with the given
+// admin router for the given admin API version, so the description
+// always matches the admin API offered by this server.
+func getAdminOpenAPISpec(router *mux.Router, version string) (openAPISpec, error) {
+	versionPrefix := adminAPIPathPrefix + SlashSeparator + version
+	spec := openAPISpec{
+		OpenAPI: "3.0.0",
+		Info: openAPIInfo{
+			Title:         "MinIO Admin API",
+			Version:       version,
+			ServerVersion: Version,
+		},
+		Servers: []openAPIServer{{URL: versionPrefix}},
+		Paths:   make(map[string]map[string]openAPIOperation),
+		Components: openAPIComponents{
+			Schemas: map[string]openAPISchema{
+				"Error": {
+					Type: "object",
+					Properties: map[string]openAPISchema{
+						"Code":      {Type: "string"},
+						"Message":   {Type: "string"},
+						"Resource":  {Type: "string"},
+						"RequestId": {Type: "string"},
+						"HostId":    {Type: "string"},
+					},
+				},
+			},
+			SecuritySchemes: map[string]openAPISecurityScheme{
+				"sigv4": {
+					Type:        "apiKey",
+					In:          "header",
+					Name:        "Authorization",
+					Description: "AWS Signature Version 4 of the `s3` service, signed with the access and secret key.",
+				},
+			},
+		},
+		Security: []map[string][]string{{"sigv4": {}}},
+	}
+
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Routes without methods, like path prefixes of
+			// sub-routers, are no operations.
+			return nil
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(template, versionPrefix+SlashSeparator) {
+			return nil
+		}
+		path := adminRouteVarRegexp.ReplaceAllString(strings.TrimPrefix(template, versionPrefix), "{$1}")
+
+		params := openAPIRouteVars(template, "path")
+		queries, _ := route.GetQueriesTemplates()
+		for _, query := range queries {
+			// Queries are templated as `key={name:pattern}`, the
+			// key is the name of the query parameter.
+			key := strings.SplitN(query, "=", 2)[0]
+			for _, param := range openAPIRouteVars(query, "query") {
+				param.Name = key
+				params = append(params, param)
+			}
+		}
+		sort.SliceStable(params, func(i, j int) bool {
+			return params[i].In < params[j].In
+		})
+
+		operations, ok := spec.Paths[path]
+		if !ok {
+			operations = make(map[string]openAPIOperation)
+			spec.Paths[path] = operations
+		}
+		for _, method := range methods {
+			operations[strings.ToLower(method)] = openAPIOperation{
+				OperationID: openAPIOperationID(method, path),
+				Parameters:  params,
+				Responses: map[string]openAPIResponse{
+					"200": {Description: "Success"},
+					"default": {
+						Description: "Error",
+						Content: map[string]openAPIMediaType{
+							string(mimeJSON): {Schema: openAPISchema{Ref: "#/components/schemas/Error"}},
+						},
+					},
+				},
+			}
+		}
+		return nil
+	})
+	return spec, err
+}
+
+// adminOpenAPIHandler - GET /minio/admin/{version}/openapi.json
+// ----------
+// Returns the OpenAPI description of the admin API, the description
+// holds no secrets and may be fetched without credentials.
+func adminOpenAPIHandler(router *mux.Router, version string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := newContext(r, w, "AdminOpenAPI")
+
+		spec, err := getAdminOpenAPISpec(router, version)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+
+		specBytes, err := json.Marshal(spec)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		writeSuccessResponseJSON(w, specBytes)
+	}
+}
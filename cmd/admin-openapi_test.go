@@ -0,0 +1,87 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestAdminOpenAPIHandler(t *testing.T) {
+	adminTestBed, err := prepareAdminXLTestBed()
+	if err != nil {
+		t.Fatal("Failed to initialize a single node XL backend for admin handler tests.")
+	}
+	defer adminTestBed.TearDown()
+
+	// The description is served without credentials.
+	req, err := http.NewRequest(http.MethodGet, adminAPIPathPrefix+adminAPIVersionPrefix+adminOpenAPIPath, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected to succeed but failed with %d", rec.Code)
+	}
+
+	var spec openAPISpec
+	if err = json.NewDecoder(rec.Body).Decode(&spec); err != nil {
+		t.Fatal(err)
+	}
+	if spec.Info.Version != adminAPIVersion {
+		t.Errorf("Expected version %s, got %s", adminAPIVersion, spec.Info.Version)
+	}
+	if len(spec.Servers) != 1 || spec.Servers[0].URL != "/minio/admin/v1" {
+		t.Errorf("Unexpected servers %v", spec.Servers)
+	}
+
+	testCases := []struct {
+		path, method string
+		operationID  string
+		params       []openAPIParameter
+	}{
+		{"/info", "get", "getInfo", nil},
+		{"/service", "post", "postService", []openAPIParameter{
+			{Name: "action", In: "query", Required: true, Schema: openAPISchema{Type: "string"}},
+		}},
+		{"/heal/{bucket}/{prefix}", "post", "postHealBucketPrefix", []openAPIParameter{
+			{Name: "bucket", In: "path", Required: true, Schema: openAPISchema{Type: "string"}},
+			{Name: "prefix", In: "path", Required: true, Schema: openAPISchema{Type: "string"}},
+		}},
+		{"/pools/decommission", "post", "postPoolsDecommission", []openAPIParameter{
+			{Name: "pool", In: "query", Required: true, Schema: openAPISchema{Type: "string", Pattern: "^([0-9]+)$"}},
+		}},
+		{"/config", "put", "putConfig", nil},
+	}
+	for i, testCase := range testCases {
+		op, ok := spec.Paths[testCase.path][testCase.method]
+		if !ok {
+			t.Errorf("Test %d: %s %s is not described", i+1, testCase.method, testCase.path)
+			continue
+		}
+		if op.OperationID != testCase.operationID {
+			t.Errorf("Test %d: expected operation id %s, got %s", i+1, testCase.operationID, op.OperationID)
+		}
+		if !reflect.DeepEqual(op.Parameters, testCase.params) {
+			t.Errorf("Test %d: expected parameters %v, got %v", i+1, testCase.params, op.Parameters)
+		}
+	}
+}
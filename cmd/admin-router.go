@@ -24,6 +24,10 @@ import (
 
 const (
 	adminAPIPathPrefix = "/minio/admin"
+
+	// Version of the admin API, part of the path of every admin API.
+	adminAPIVersion       = "v1"
+	adminAPIVersionPrefix = SlashSeparator + adminAPIVersion
 )
 
 // adminAPIHandlers provides HTTP handlers for MinIO admin API.
@@ -38,7 +42,10 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 	adminRouter := router.PathPrefix(adminAPIPathPrefix).Subrouter()
 
 	// Version handler
-	adminV1Router := adminRouter.PathPrefix("/v1").Subrouter()
+	adminV1Router := adminRouter.PathPrefix(adminAPIVersionPrefix).Subrouter()
+
+	// Machine readable description of the admin API.
+	adminV1Router.Methods(http.MethodGet).Path(adminOpenAPIPath).HandlerFunc(httpTraceAll(adminOpenAPIHandler(adminRouter, adminAPIVersion)))
 
 	/// Service operations
 
@@ -49,6 +56,28 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 	// Info operations
 	adminV1Router.Methods(http.MethodGet).Path("/info").HandlerFunc(httpTraceAll(adminAPI.ServerInfoHandler))
+	if !globalIsGateway {
+		// Data usage info
+		adminV1Router.Methods(http.MethodGet).Path("/datausageinfo").HandlerFunc(httpTraceAll(adminAPI.DataUsageInfoHandler))
+
+		// Bucket defaults operations
+		adminV1Router.Methods(http.MethodGet).Path("/bucket-defaults").HandlerFunc(httpTraceAll(adminAPI.GetBucketDefaultsHandler)).Queries("bucket", "{bucket:.*}")
+		adminV1Router.Methods(http.MethodPut).Path("/bucket-defaults").HandlerFunc(httpTraceHdrs(adminAPI.SetBucketDefaultsHandler)).Queries("bucket", "{bucket:.*}")
+		adminV1Router.Methods(http.MethodDelete).Path("/bucket-defaults").HandlerFunc(httpTraceAll(adminAPI.RemoveBucketDefaultsHandler)).Queries("bucket", "{bucket:.*}")
+
+		// Bucket metadata operations
+		adminV1Router.Methods(http.MethodGet).Path("/bucket-metadata").HandlerFunc(httpTraceAll(adminAPI.ExportBucketMetadataHandler)).Queries("bucket", "{bucket:.*}")
+		adminV1Router.Methods(http.MethodPut).Path("/bucket-metadata").HandlerFunc(httpTraceHdrs(adminAPI.ImportBucketMetadataHandler)).Queries("bucket", "{bucket:.*}")
+
+		// Bucket snapshot operations, only supported by the FS backend
+		adminV1Router.Methods(http.MethodPut).Path("/bucket-snapshot").HandlerFunc(httpTraceAll(adminAPI.CreateBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}", "name", "{name:.*}")
+		adminV1Router.Methods(http.MethodGet).Path("/bucket-snapshots").HandlerFunc(httpTraceAll(adminAPI.ListBucketSnapshotsHandler)).Queries("bucket", "{bucket:.*}")
+		adminV1Router.Methods(http.MethodDelete).Path("/bucket-snapshot").HandlerFunc(httpTraceAll(adminAPI.RemoveBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}", "name", "{name:.*}")
+		adminV1Router.Methods(http.MethodPost).Path("/bucket-snapshot/restore").HandlerFunc(httpTraceAll(adminAPI.RestoreBucketSnapshotHandler)).Queries("bucket", "{bucket:.*}", "name", "{name:.*}")
+
+		// Bucket operations
+		adminV1Router.Methods(http.MethodDelete).Path("/bucket").HandlerFunc(httpTraceAll(adminAPI.ForceRemoveBucketHandler)).Queries("bucket", "{bucket:.*}")
+	}
 	// Harware Info operations
 	adminV1Router.Methods(http.MethodGet).Path("/hardware").HandlerFunc(httpTraceAll(adminAPI.ServerHardwareInfoHandler)).Queries("hwType", "{hwType:.*}")
 
@@ -62,9 +91,29 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 
 		adminV1Router.Methods(http.MethodPost).Path("/background-heal/status").HandlerFunc(httpTraceAll(adminAPI.BackgroundHealStatusHandler))
 
+		// Background scrub status.
+		adminV1Router.Methods(http.MethodGet).Path("/scrub/status").HandlerFunc(httpTraceAll(adminAPI.ScrubStatusHandler))
+
+		/// Decommission operations
+
+		adminV1Router.Methods(http.MethodPost).Path("/pools/decommission").HandlerFunc(httpTraceAll(adminAPI.DecommissionHandler)).Queries("pool", "{pool:[0-9]+}")
+		adminV1Router.Methods(http.MethodPost).Path("/pools/decommission/cancel").HandlerFunc(httpTraceAll(adminAPI.CancelDecommissionHandler)).Queries("pool", "{pool:[0-9]+}")
+		adminV1Router.Methods(http.MethodGet).Path("/pools/decommission/status").HandlerFunc(httpTraceAll(adminAPI.DecommissionStatusHandler))
+
+		/// Placement operations
+
+		adminV1Router.Methods(http.MethodGet).Path("/placement").HandlerFunc(httpTraceAll(adminAPI.PlacementInfoHandler))
+
 		/// Health operations
 
 	}
+
+	if globalIsGateway {
+		/// Gateway operations
+
+		// Remove stale multipart uploads from the gateway backend.
+		adminV1Router.Methods(http.MethodPost).Path("/gateway/cleanup").HandlerFunc(httpTraceAll(adminAPI.GatewayCleanupHandler))
+	}
 	// Performance command - return performance details based on input type
 	adminV1Router.Methods(http.MethodGet).Path("/performance").HandlerFunc(httpTraceAll(adminAPI.PerfInfoHandler)).Queries("perfType", "{perfType:.*}")
 
@@ -73,6 +122,12 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 		Queries("profilerType", "{profilerType:.*}")
 	adminV1Router.Methods(http.MethodGet).Path("/profiling/download").HandlerFunc(httpTraceAll(adminAPI.DownloadProfilingHandler))
 
+	/// Root credential operations
+
+	// Set or remove the old root credential valid during rotation.
+	adminV1Router.Methods(http.MethodPut).Path("/old-credential").HandlerFunc(httpTraceHdrs(adminAPI.SetOldCredentialHandler))
+	adminV1Router.Methods(http.MethodDelete).Path("/old-credential").HandlerFunc(httpTraceAll(adminAPI.RemoveOldCredentialHandler))
+
 	/// Config operations
 	if enableConfigOps {
 		// Get config
@@ -132,6 +187,8 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 	// -- Top APIs --
 	// Top locks
 	adminV1Router.Methods(http.MethodGet).Path("/top/locks").HandlerFunc(httpTraceHdrs(adminAPI.TopLocksHandler))
+	// List locks
+	adminV1Router.Methods(http.MethodGet).Path("/locks").HandlerFunc(httpTraceHdrs(adminAPI.ListLocksHandler))
 
 	// HTTP Trace
 	adminV1Router.Methods(http.MethodGet).Path("/trace").HandlerFunc(adminAPI.TraceHandler)
@@ -139,6 +196,14 @@ func registerAdminRouter(router *mux.Router, enableConfigOps, enableIAMOps bool)
 	// Console Logs
 	adminV1Router.Methods(http.MethodGet).Path("/log").HandlerFunc(httpTraceAll(adminAPI.ConsoleLogHandler))
 
+	// -- Presign APIs --
+	adminV1Router.Methods(http.MethodPost).Path("/presign").HandlerFunc(httpTraceHdrs(adminAPI.PresignHandler))
+
+	// Notification target delivery statistics
+	adminV1Router.Methods(http.MethodGet).Path("/notification/stats").HandlerFunc(httpTraceAll(adminAPI.NotificationStatsHandler))
+	// Send a test event to a notification target
+	adminV1Router.Methods(http.MethodPost).Path("/notification/test").HandlerFunc(httpTraceAll(adminAPI.NotificationTestHandler)).Queries("arn", "{arn:.*}")
+
 	// -- KMS APIs --
 	//
 	adminV1Router.Methods(http.MethodGet).Path("/kms/key/status").HandlerFunc(httpTraceAll(adminAPI.KMSKeyStatusHandler))
@@ -64,6 +64,10 @@ const (
 	ErrNone APIErrorCode = iota
 	ErrAccessDenied
 	ErrBadDigest
+	ErrChecksumMismatch
+	ErrInvalidTrailer
+	ErrInvalidChecksum
+	ErrInvalidObjectAttributes
 	ErrEntityTooSmall
 	ErrEntityTooLarge
 	ErrPolicyTooLarge
@@ -83,9 +87,13 @@ const (
 	ErrInvalidRequestBody
 	ErrInvalidCopySource
 	ErrInvalidMetadataDirective
+	ErrInvalidTagDirective
+	ErrInvalidTag
+	ErrInvalidCannedACL
 	ErrInvalidCopyDest
 	ErrInvalidPolicyDocument
 	ErrInvalidObjectState
+	ErrObjectRestoreAlreadyInProgress
 	ErrMalformedXML
 	ErrMissingContentLength
 	ErrMissingContentMD5
@@ -93,6 +101,8 @@ const (
 	ErrNoSuchBucket
 	ErrNoSuchBucketPolicy
 	ErrNoSuchBucketLifecycle
+	ErrNoSuchWebsiteConfiguration
+	ErrNoSuchBucketSSEConfig
 	ErrNoSuchKey
 	ErrNoSuchUpload
 	ErrNoSuchVersion
@@ -164,6 +174,7 @@ const (
 
 	ErrNoAccessKey
 	ErrInvalidToken
+	ErrExpiredToken
 
 	// Bucket notification related errors.
 	ErrEventNotification
@@ -189,11 +200,15 @@ const (
 	ErrStorageFull
 	ErrRequestBodyParse
 	ErrObjectExistsAsDirectory
+	ErrObjectNotAppendable
+	ErrInvalidAppendPosition
+	ErrObjectNotTransformable
 	ErrInvalidObjectName
 	ErrInvalidObjectNamePrefixSlash
 	ErrInvalidResourceName
 	ErrServerNotInitialized
 	ErrOperationTimedOut
+	ErrOperationMaxedOut
 	ErrInvalidRequest
 	// MinIO storage class error codes
 	ErrInvalidStorageClass
@@ -347,6 +362,21 @@ var errorCodes = errorCodeMap{
 		Description:    "Unknown metadata directive.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrInvalidTagDirective: {
+		Code:           "InvalidArgument",
+		Description:    "Unknown tag directive.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The TagKey or TagValue you have provided is invalid, or a tag set has more than 10 tags.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidCannedACL: {
+		Code:           "InvalidArgument",
+		Description:    "The canned ACL you have provided is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidStorageClass: {
 		Code:           "InvalidStorageClass",
 		Description:    "Invalid storage class.",
@@ -397,6 +427,26 @@ var errorCodes = errorCodeMap{
 		Description:    "The Content-Md5 you specified did not match what we received.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrChecksumMismatch: {
+		Code:           "XAmzContentChecksumMismatch",
+		Description:    "The provided 'x-amz-checksum' header does not match what was computed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidTrailer: {
+		Code:           "InvalidRequest",
+		Description:    "The value specified in the x-amz-trailer header is not supported.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidRequest",
+		Description:    "The x-amz-checksum header is invalid, only one checksum of a supported algorithm can be sent.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidObjectAttributes: {
+		Code:           "InvalidArgument",
+		Description:    "The x-amz-object-attributes header is missing or lists an unknown attribute.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrEntityTooSmall: {
 		Code:           "EntityTooSmall",
 		Description:    "Your proposed upload is smaller than the minimum allowed object size.",
@@ -477,6 +527,16 @@ var errorCodes = errorCodeMap{
 		Description:    "The bucket lifecycle configuration does not exist",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrNoSuchWebsiteConfiguration: {
+		Code:           "NoSuchWebsiteConfiguration",
+		Description:    "The specified bucket does not have a website configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchBucketSSEConfig: {
+		Code:           "ServerSideEncryptionConfigurationNotFoundError",
+		Description:    "The server side encryption configuration was not found",
+		HTTPStatusCode: http.StatusNotFound,
+	},
 	ErrNoSuchKey: {
 		Code:           "NoSuchKey",
 		Description:    "The specified key does not exist.",
@@ -532,6 +592,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The operation is not valid for the current state of the object.",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrObjectRestoreAlreadyInProgress: {
+		Code:           "RestoreAlreadyInProgress",
+		Description:    "Object restore is already in progress.",
+		HTTPStatusCode: http.StatusConflict,
+	},
 	ErrAuthorizationHeaderMalformed: {
 		Code:           "AuthorizationHeaderMalformed",
 		Description:    "The authorization header is malformed; the region is wrong; expecting 'us-east-1'.",
@@ -868,6 +933,11 @@ var errorCodes = errorCodeMap{
 		Description:    "The security token included in the request is invalid",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The provided token has expired.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	/// S3 extensions.
 	ErrContentSHA256Mismatch: {
@@ -897,6 +967,21 @@ var errorCodes = errorCodeMap{
 		Description:    "Object name already exists as a directory.",
 		HTTPStatusCode: http.StatusConflict,
 	},
+	ErrObjectNotAppendable: {
+		Code:           "XMinioObjectNotAppendable",
+		Description:    "The object is encrypted, compressed or has reached the maximum number of appends.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrInvalidAppendPosition: {
+		Code:           "XMinioInvalidAppendPosition",
+		Description:    "The append position is not the current size of the object.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrObjectNotTransformable: {
+		Code:           "XMinioObjectNotTransformable",
+		Description:    "The object is not an image that can be resized or is too large to be transformed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrInvalidObjectName: {
 		Code:           "XMinioInvalidObjectName",
 		Description:    "Object name contains unsupported characters.",
@@ -1003,6 +1088,11 @@ var errorCodes = errorCodeMap{
 		Description:    "A timeout occurred while trying to lock a resource",
 		HTTPStatusCode: http.StatusRequestTimeout,
 	},
+	ErrOperationMaxedOut: {
+		Code:           "XMinioServerTimedOut",
+		Description:    "A timeout exceeded while waiting to proceed with the request, please reduce your request rate",
+		HTTPStatusCode: http.StatusServiceUnavailable,
+	},
 	ErrUnsupportedMetadata: {
 		Code:           "InvalidArgument",
 		Description:    "Your metadata headers are not supported.",
@@ -1532,6 +1622,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrAdminNoSuchPolicy
 	case errSignatureMismatch:
 		apiErr = ErrSignatureDoesNotMatch
+	case errChecksumMismatch:
+		apiErr = ErrChecksumMismatch
+	case errInvalidTag:
+		apiErr = ErrInvalidTag
+	case errInvalidCannedACL:
+		apiErr = ErrInvalidCannedACL
 	case errInvalidRange:
 		apiErr = ErrInvalidRange
 	case errDataTooLarge:
@@ -1540,6 +1636,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrEntityTooSmall
 	case errAuthentication:
 		apiErr = ErrAccessDenied
+	case errSessionTokenExpired:
+		apiErr = ErrExpiredToken
 	case auth.ErrInvalidAccessKeyLength:
 		apiErr = ErrAdminInvalidAccessKey
 	case auth.ErrInvalidSecretKeyLength:
@@ -1605,6 +1703,14 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrIncompleteBody
 	case ObjectExistsAsDirectory:
 		apiErr = ErrObjectExistsAsDirectory
+	case ObjectNotAppendable:
+		apiErr = ErrObjectNotAppendable
+	case InvalidAppendPosition:
+		apiErr = ErrInvalidAppendPosition
+	case ObjectArchived:
+		apiErr = ErrInvalidObjectState
+	case ObjectRestoreInProgress:
+		apiErr = ErrObjectRestoreAlreadyInProgress
 	case PrefixAccessDenied:
 		apiErr = ErrAccessDenied
 	case ParentIsObject:
@@ -1623,6 +1729,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrBucketAlreadyOwnedByYou
 	case ObjectNotFound:
 		apiErr = ErrNoSuchKey
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -1665,6 +1773,10 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketPolicy
 	case BucketLifecycleNotFound:
 		apiErr = ErrNoSuchBucketLifecycle
+	case BucketWebsiteNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
+	case BucketSSEConfigNotFound:
+		apiErr = ErrNoSuchBucketSSEConfig
 	case *event.ErrInvalidEventName:
 		apiErr = ErrEventNotification
 	case *event.ErrInvalidARN:
@@ -22,6 +22,7 @@ import (
 	"encoding/xml"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
@@ -92,6 +93,21 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header().Set(xhttp.Expires, objInfo.Expires.UTC().Format(http.TimeFormat))
 	}
 
+	// Set the number of tags, the tag set itself is internal metadata.
+	if tags, ok := objInfo.UserDefined[ObjectTagsKey]; ok {
+		if values, err := url.ParseQuery(tags); err == nil {
+			w.Header().Set(xhttp.AmzTagCount, strconv.Itoa(len(values)))
+		}
+	}
+
+	if objInfo.RestoreOngoing {
+		w.Header().Set(xhttp.AmzRestore, `ongoing-request="true"`)
+	}
+
+	if objInfo.DegradedRead {
+		w.Header().Set(xhttp.MinIODegradedRead, "true")
+	}
+
 	// Set all other user defined metadata.
 	for k, v := range objInfo.UserDefined {
 		if hasPrefix(k, ReservedMetadataPrefix) {
@@ -102,20 +118,9 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 		w.Header().Set(k, v)
 	}
 
-	var totalObjectSize int64
-	switch {
-	case crypto.IsEncrypted(objInfo.UserDefined):
-		totalObjectSize, err = objInfo.DecryptedSize()
-		if err != nil {
-			return err
-		}
-	case objInfo.IsCompressed():
-		totalObjectSize = objInfo.GetActualSize()
-		if totalObjectSize < 0 {
-			return errInvalidDecompressedSize
-		}
-	default:
-		totalObjectSize = objInfo.Size
+	totalObjectSize, err := getObjectContentSize(objInfo)
+	if err != nil {
+		return err
 	}
 
 	// for providing ranged content
@@ -133,3 +138,20 @@ func setObjectHeaders(w http.ResponseWriter, objInfo ObjectInfo, rs *HTTPRangeSp
 
 	return nil
 }
+
+// Returns the size of the content of an object, the size of the
+// data before it was encrypted or compressed.
+func getObjectContentSize(objInfo ObjectInfo) (int64, error) {
+	switch {
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		return objInfo.DecryptedSize()
+	case objInfo.IsCompressed():
+		size := objInfo.GetActualSize()
+		if size < 0 {
+			return 0, errInvalidDecompressedSize
+		}
+		return size, nil
+	default:
+		return objInfo.Size, nil
+	}
+}
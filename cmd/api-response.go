@@ -27,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/minio/minio-go/v6/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/handlers"
@@ -267,6 +268,31 @@ type CopyObjectPartResponse struct {
 	ETag         string   // md5sum of the copied object part.
 }
 
+// GetObjectAttributesResponse container for the attributes of an
+// object, only the attributes requested are set.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                    `xml:",omitempty"`
+	Checksum     *ObjectAttributesChecksum `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts    `xml:",omitempty"`
+	StorageClass string                    `xml:",omitempty"`
+	ObjectSize   *int64                    `xml:",omitempty"`
+}
+
+// ObjectAttributesChecksum - checksum sent on the upload of an object.
+type ObjectAttributesChecksum struct {
+	ChecksumCRC32  string `xml:",omitempty"`
+	ChecksumCRC32C string `xml:",omitempty"`
+	ChecksumSHA1   string `xml:",omitempty"`
+	ChecksumSHA256 string `xml:",omitempty"`
+}
+
+// ObjectAttributesParts - number of parts of a multipart object.
+type ObjectAttributesParts struct {
+	PartsCount int
+}
+
 // Initiator inherit from Owner struct, fields are same
 type Initiator Owner
 
@@ -520,6 +546,43 @@ func generateCopyObjectResponse(etag string, lastModified time.Time) CopyObjectR
 	}
 }
 
+// generates GetObjectAttributesResponse with the requested attributes of an object.
+func generateGetObjectAttributesResponse(objInfo ObjectInfo, size int64, attributes set.StringSet) GetObjectAttributesResponse {
+	var data GetObjectAttributesResponse
+	if attributes.Contains("ETag") {
+		data.ETag = objInfo.ETag
+	}
+	if attributes.Contains("Checksum") {
+		if c, ok := getObjectChecksum(objInfo.UserDefined); ok {
+			data.Checksum = &ObjectAttributesChecksum{}
+			switch c.Algorithm {
+			case "CRC32":
+				data.Checksum.ChecksumCRC32 = c.Value
+			case "CRC32C":
+				data.Checksum.ChecksumCRC32C = c.Value
+			case "SHA1":
+				data.Checksum.ChecksumSHA1 = c.Value
+			case "SHA256":
+				data.Checksum.ChecksumSHA256 = c.Value
+			}
+		}
+	}
+	// Only multipart objects have parts.
+	if attributes.Contains("ObjectParts") && strings.Contains(objInfo.ETag, "-") && len(objInfo.Parts) > 0 {
+		data.ObjectParts = &ObjectAttributesParts{PartsCount: len(objInfo.Parts)}
+	}
+	if attributes.Contains("StorageClass") {
+		data.StorageClass = objInfo.StorageClass
+		if data.StorageClass == "" {
+			data.StorageClass = globalMinioDefaultStorageClass
+		}
+	}
+	if attributes.Contains("ObjectSize") {
+		data.ObjectSize = &size
+	}
+	return data
+}
+
 // generates CopyObjectPartResponse from etag and lastModified time.
 func generateCopyObjectPartResponse(etag string, lastModified time.Time) CopyObjectPartResponse {
 	return CopyObjectPartResponse{
@@ -73,7 +73,8 @@ func isRequestPostPolicySignatureV4(r *http.Request) bool {
 
 // Verify if the request has AWS Streaming Signature Version '4'. This is only valid for 'PUT' operation.
 func isRequestSignStreamingV4(r *http.Request) bool {
-	return r.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256 &&
+	payload := r.Header.Get(xhttp.AmzContentSha256)
+	return (payload == streamingContentSHA256 || payload == streamingContentSHA256Trailer) &&
 		r.Method == http.MethodPut
 }
 
@@ -118,33 +119,43 @@ func getRequestAuthType(r *http.Request) authType {
 	return authTypeUnknown
 }
 
-// checkAdminRequestAuthType checks whether the request is a valid signature V2 or V4 request.
-// It does not accept presigned or JWT or anonymous requests.
-func checkAdminRequestAuthType(ctx context.Context, r *http.Request, region string) APIErrorCode {
+// checkAdminRequestAuthType checks whether the request is a valid signature V4
+// request and whether the requesting credential is allowed the admin action.
+func checkAdminRequestAuthType(ctx context.Context, r *http.Request, action iampolicy.AdminAction, region string) (auth.Credentials, APIErrorCode) {
+	var cred auth.Credentials
 	s3Err := ErrAccessDenied
 	if _, ok := r.Header[xhttp.AmzContentSha256]; ok &&
 		getRequestAuthType(r) == authTypeSigned && !skipContentSha256Cksum(r) {
-		// We only support admin credentials to access admin APIs.
-
 		var owner bool
-		_, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
+		cred, owner, s3Err = getReqAccessKeyV4(r, region, serviceS3)
 		if s3Err != ErrNone {
-			return s3Err
-		}
-
-		if !owner {
-			return ErrAccessDenied
+			return cred, s3Err
 		}
 
 		// we only support V4 (no presign) with auth body
 		s3Err = isReqAuthenticated(ctx, r, region, serviceS3)
+		if s3Err == ErrNone && !owner {
+			// Non admin credentials are allowed only the admin
+			// actions granted by their IAM policies.
+			var claims map[string]interface{}
+			claims, s3Err = checkClaimsFromToken(r, cred)
+			if s3Err == ErrNone && !globalIAMSys.IsAllowed(iampolicy.Args{
+				AccountName:     cred.AccessKey,
+				Action:          iampolicy.Action(action),
+				ConditionValues: getConditionValues(r, "", cred.AccessKey),
+				IsOwner:         owner,
+				Claims:          claims,
+			}) {
+				s3Err = ErrAccessDenied
+			}
+		}
 	}
 	if s3Err != ErrNone {
 		reqInfo := (&logger.ReqInfo{}).AppendTags("requestHeaders", dumpRequest(r))
 		ctx := logger.SetReqInfo(ctx, reqInfo)
 		logger.LogIf(ctx, errors.New(getAPIError(s3Err).Description), logger.Application)
 	}
-	return s3Err
+	return cred, s3Err
 }
 
 // Fetch the security token set by the client.
@@ -165,11 +176,31 @@ func mustGetClaimsFromToken(r *http.Request) map[string]interface{} {
 
 // Fetch claims in the security token returned by the client.
 func getClaimsFromToken(r *http.Request) (map[string]interface{}, error) {
-	claims := make(map[string]interface{})
-	token := getSessionToken(r)
+	return getClaimsFromSessionToken(getSessionToken(r))
+}
+
+// Fetch claims in a security token, the claims of verified tokens
+// are cached until they expire.
+func getClaimsFromSessionToken(token string) (map[string]interface{}, error) {
 	if token == "" {
+		return make(map[string]interface{}), nil
+	}
+
+	secretKey := globalServerConfig.GetCredential().SecretKey
+	if claims, ok := globalSTSClaimsCache.Get(token, secretKey); ok {
 		return claims, nil
 	}
+	claims, err := parseClaimsFromToken(token, secretKey)
+	if err != nil {
+		return nil, err
+	}
+	globalSTSClaimsCache.Set(token, secretKey, claims, claimsExpiry(claims))
+	return claims, nil
+}
+
+// Verify the signature of the session token and parse its claims.
+func parseClaimsFromToken(token, secretKey string) (map[string]interface{}, error) {
+	claims := make(map[string]interface{})
 	stsTokenCallback := func(jwtToken *jwtgo.Token) (interface{}, error) {
 		// JWT token for x-amz-security-token is signed with admin
 		// secret key, temporary credentials become invalid if
@@ -179,7 +210,7 @@ func getClaimsFromToken(r *http.Request) (map[string]interface{}, error) {
 		// hijacking the policies. We need to make sure that this is
 		// based an admin credential such that token cannot be decoded
 		// on the client side and is treated like an opaque value.
-		return []byte(globalServerConfig.GetCredential().SecretKey), nil
+		return []byte(secretKey), nil
 	}
 	p := &jwtgo.Parser{
 		ValidMethods: []string{
@@ -189,6 +220,9 @@ func getClaimsFromToken(r *http.Request) (map[string]interface{}, error) {
 	}
 	jtoken, err := p.ParseWithClaims(token, jwtgo.MapClaims(claims), stsTokenCallback)
 	if err != nil {
+		if verr, ok := err.(*jwtgo.ValidationError); ok && verr.Errors&jwtgo.ValidationErrorExpired != 0 {
+			return nil, errSessionTokenExpired
+		}
 		return nil, err
 	}
 	if !jtoken.Valid {
@@ -259,6 +293,17 @@ func checkClaimsFromToken(r *http.Request, cred auth.Credentials) (map[string]in
 	return claims, ErrNone
 }
 
+// Returns true if the access to the resource of a request denied by
+// checkRequestAuthType was denied by the policies, false if the
+// request was denied before, for its unsupported authentication.
+func isRequestPolicyEvaluated(r *http.Request) bool {
+	switch getRequestAuthType(r) {
+	case authTypeUnknown, authTypeStreamingSigned:
+		return false
+	}
+	return true
+}
+
 // Check request auth type verifies the incoming http request
 // - validates the request signature
 // - validates the policy action if anonymous tests bucket policies if any,
@@ -274,7 +319,8 @@ func checkRequestAuthType(ctx context.Context, r *http.Request, action policy.Ac
 // - validates the policy action if anonymous tests bucket policies if any,
 //   for authenticated requests validates IAM policies.
 // returns APIErrorCode if any to be replied to the client.
-// Additionally returns the accessKey used in the request, and if this request is by an admin.
+// Additionally returns the accessKey used in the request, also when it is denied by
+// the IAM policies, and if this request is by an admin.
 func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, action policy.Action, bucketName, objectName string) (accessKey string, owner bool, s3Err APIErrorCode) {
 	var cred auth.Credentials
 	switch getRequestAuthType(r) {
@@ -356,7 +402,7 @@ func checkRequestAuthTypeToAccessKey(ctx context.Context, r *http.Request, actio
 		// Request is allowed return the appropriate access key.
 		return cred.AccessKey, owner, ErrNone
 	}
-	return accessKey, owner, ErrAccessDenied
+	return cred.AccessKey, owner, ErrAccessDenied
 }
 
 // Verify if request has valid AWS Signature Version '2'.
@@ -0,0 +1,282 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/ratelimit"
+)
+
+// Interval between two scrubs of the local disks.
+const scrubPassInterval = 24 * time.Hour
+
+// backgroundScrub verifies the checksums of the parts stored on
+// the local disks, reading at most a fixed number of bytes per
+// second from each disk, and schedules the healing of objects
+// with corrupted parts.
+type backgroundScrub struct {
+	// Bytes per second read from each disk.
+	bandwidth uint64
+
+	mu       sync.Mutex
+	limiters map[string]*ratelimit.Limiter
+	status   map[string]*madmin.ScrubDiskStatus
+}
+
+func newBackgroundScrub(bandwidth uint64) *backgroundScrub {
+	return &backgroundScrub{
+		bandwidth: bandwidth,
+		limiters:  make(map[string]*ratelimit.Limiter),
+		status:    make(map[string]*madmin.ScrubDiskStatus),
+	}
+}
+
+// limiter - returns the limiter of the reads of a disk, created
+// on first use. Allows bursts of up to one second worth of data.
+func (s *backgroundScrub) limiter(endpoint string) *ratelimit.Limiter {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.limiters[endpoint]
+	if !ok {
+		l = ratelimit.NewLimiter(float64(s.bandwidth), int(s.bandwidth))
+		s.limiters[endpoint] = l
+	}
+	return l
+}
+
+// updateStatus - applies fn to the status of a disk.
+func (s *backgroundScrub) updateStatus(endpoint string, fn func(st *madmin.ScrubDiskStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st, ok := s.status[endpoint]
+	if !ok {
+		st = &madmin.ScrubDiskStatus{Endpoint: endpoint}
+		s.status[endpoint] = st
+	}
+	fn(st)
+}
+
+// Status - returns the scrub status of the local disks, sorted
+// by endpoint.
+func (s *backgroundScrub) Status() []madmin.ScrubDiskStatus {
+	if s == nil {
+		return nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := make([]madmin.ScrubDiskStatus, 0, len(s.status))
+	for _, st := range s.status {
+		status = append(status, *st)
+	}
+	sort.Slice(status, func(i, j int) bool {
+		return status[i].Endpoint < status[j].Endpoint
+	})
+	return status
+}
+
+// localScrubDisks - returns the online local disks of the object
+// layer, nil unless it is erasure coded.
+func localScrubDisks(objAPI ObjectLayer) (disks []StorageAPI) {
+	var pools []*xlSets
+	switch z := objAPI.(type) {
+	case *xlServerPools:
+		for _, pool := range z.pools {
+			if sets, ok := pool.(*xlSets); ok {
+				pools = append(pools, sets)
+			}
+		}
+	case *xlSets:
+		pools = append(pools, z)
+	}
+	for _, sets := range pools {
+		for i := 0; i < sets.setCount; i++ {
+			for _, disk := range sets.GetDisks(i)() {
+				if _, ok := disk.(*posix); ok && disk.IsOnline() {
+					disks = append(disks, disk)
+				}
+			}
+		}
+	}
+	return disks
+}
+
+// scrubDisk - verifies all objects stored on disk once.
+func (s *backgroundScrub) scrubDisk(ctx context.Context, disk StorageAPI) error {
+	endpoint := disk.String()
+	s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+		st.PassStartTime = UTCNow()
+	})
+
+	vols, err := disk.ListVols()
+	if err != nil {
+		return err
+	}
+	for _, vol := range vols {
+		if isMinioMetaBucketName(vol.Name) {
+			continue
+		}
+		endWalkCh := make(chan struct{})
+		entries, err := disk.Walk(vol.Name, "", "", true, xlMetaJSONFile, readMetadata, endWalkCh)
+		if err != nil {
+			if err == errVolumeNotFound {
+				continue
+			}
+			return err
+		}
+		for entry := range entries {
+			if entry.Name == "" || hasSuffix(entry.Name, SlashSeparator) {
+				continue
+			}
+			if err = s.scrubObject(ctx, disk, vol.Name, entry.Name); err != nil {
+				close(endWalkCh)
+				return err
+			}
+		}
+	}
+
+	s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+		st.Passes++
+		st.PassEndTime = UTCNow()
+		st.Current = ""
+	})
+	return nil
+}
+
+// scrubObject - verifies the parts of an object stored on disk
+// against the checksums of the `xl.json` of the disk, schedules
+// the healing of the object if any part is corrupted or missing.
+func (s *backgroundScrub) scrubObject(ctx context.Context, disk StorageAPI, bucket, object string) error {
+	endpoint := disk.String()
+	s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+		st.Current = pathJoin(bucket, object)
+	})
+
+	buf, err := disk.ReadAll(bucket, pathJoin(object, xlMetaJSONFile))
+	if err != nil {
+		if err == errFileNotFound {
+			// Deleted since it was listed.
+			return nil
+		}
+		return err
+	}
+	xlMeta, err := xlMetaV1Unmarshal(ctx, buf)
+	if err != nil {
+		// Unreadable metadata is found by the healing.
+		return nil
+	}
+	erasure, err := NewErasure(ctx, xlMeta.Erasure.DataBlocks, xlMeta.Erasure.ParityBlocks, xlMeta.Erasure.BlockSize)
+	if err != nil {
+		return nil
+	}
+
+	limiter := s.limiter(endpoint)
+	var corrupted int64
+	for _, part := range xlMeta.Parts {
+		checksumInfo := xlMeta.Erasure.GetChecksumInfo(part.Name)
+		size := erasure.ShardFileSize(part.Size)
+		diskSize := bitrotShardFileSize(size, erasure.ShardSize(), checksumInfo.Algorithm)
+		if err = limiter.WaitN(ctx, int(diskSize)); err != nil {
+			return err
+		}
+		err = disk.VerifyFile(bucket, pathJoin(object, part.Name), size, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
+		switch err {
+		case nil:
+		case errFileCorrupt, errFileNotFound:
+			corrupted++
+		default:
+			return err
+		}
+		s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+			st.BytesVerified += diskSize
+		})
+	}
+
+	s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+		st.ObjectsScanned++
+		st.CorruptedParts += corrupted
+	})
+	if corrupted == 0 {
+		return nil
+	}
+
+	// Heal the object with a deep scan, which rewrites the parts
+	// failing their checksums on all disks.
+	respCh := make(chan healResult)
+	defer close(respCh)
+	globalBackgroundHealing.queueHealTask(healTask{
+		path:       pathJoin(bucket, object),
+		opts:       madmin.HealOpts{ScanMode: madmin.HealDeepScan},
+		responseCh: respCh,
+	})
+	s.updateStatus(endpoint, func(st *madmin.ScrubDiskStatus) {
+		st.HealsScheduled++
+	})
+	if res := <-respCh; res.err != nil && !isErrObjectNotFound(res.err) {
+		logger.GetReqInfo(ctx).AppendTags("disk", endpoint)
+		logger.LogIf(ctx, res.err)
+	}
+	return nil
+}
+
+// run - scrubs the local disks once every scrubPassInterval.
+func (s *backgroundScrub) run() {
+	reqInfo := &logger.ReqInfo{API: "BackgroundScrub"}
+	ctx := logger.SetReqInfo(context.Background(), reqInfo)
+
+	var objAPI ObjectLayer
+	// Wait until the object layer is ready
+	for {
+		objAPI = newObjectLayerFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	for {
+		var wg sync.WaitGroup
+		for _, disk := range localScrubDisks(objAPI) {
+			wg.Add(1)
+			go func(disk StorageAPI) {
+				defer wg.Done()
+				if err := s.scrubDisk(ctx, disk); err != nil {
+					logger.GetReqInfo(ctx).AppendTags("disk", disk.String())
+					logger.LogIf(ctx, err)
+				}
+			}(disk)
+		}
+		wg.Wait()
+
+		select {
+		case <-time.After(scrubPassInterval):
+		case <-GlobalServiceDoneCh:
+			return
+		}
+	}
+}
+
+func initBackgroundScrub() {
+	globalBackgroundScrub = newBackgroundScrub(globalXLScrubBandwidth)
+	go globalBackgroundScrub.run()
+}
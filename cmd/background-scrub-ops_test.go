@@ -0,0 +1,94 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// Tests that the scrub of a disk finds a corrupted part and has the
+// object healed.
+func TestBackgroundScrubDisk(t *testing.T) {
+	isDistXL := false
+	initNSLock(isDistXL)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	defer func(objAPI ObjectLayer, healing *healRoutine) {
+		globalObjectAPI = objAPI
+		globalBackgroundHealing = healing
+	}(globalObjectAPI, globalBackgroundHealing)
+	globalObjectAPI = obj
+	initBackgroundHealing()
+
+	xl := obj.(*xlObjects)
+	ctx := context.Background()
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), humanize.MiByte)
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	disk := xl.storageDisks[0]
+	partPath := pathJoin(disk.String(), bucket, object, "part.1")
+	part, err := ioutil.ReadFile(partPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	part[len(part)-1] ^= 0xff
+	if err = ioutil.WriteFile(partPath, part, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newBackgroundScrub(100 * humanize.MiByte)
+	if err = s.scrubDisk(ctx, disk); err != nil {
+		t.Fatal(err)
+	}
+	status := s.Status()
+	if len(status) != 1 {
+		t.Fatalf("Expected the status of 1 disk, got %d", len(status))
+	}
+	st := status[0]
+	if st.Endpoint != disk.String() || st.Passes != 1 || st.ObjectsScanned != 1 || st.CorruptedParts != 1 || st.HealsScheduled != 1 {
+		t.Fatalf("Unexpected scrub status %+v", st)
+	}
+	if st.BytesVerified < int64(len(part)) {
+		t.Fatalf("Expected at least %d bytes verified, got %d", len(part), st.BytesVerified)
+	}
+
+	// The part was healed, scrubbing again finds no corruption.
+	if err = s.scrubDisk(ctx, disk); err != nil {
+		t.Fatal(err)
+	}
+	st = s.Status()[0]
+	if st.Passes != 2 || st.ObjectsScanned != 2 || st.CorruptedParts != 1 || st.HealsScheduled != 1 {
+		t.Fatalf("Unexpected scrub status after healing %+v", st)
+	}
+}
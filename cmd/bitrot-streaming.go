@@ -91,6 +91,7 @@ type streamingBitrotReader struct {
 	h          hash.Hash
 	shardSize  int64
 	hashBytes  []byte
+	skipVerify bool // Reads the data without checking it against the hash
 }
 
 func (b *streamingBitrotReader) Close() error {
@@ -131,7 +132,7 @@ func (b *streamingBitrotReader) ReadAt(buf []byte, offset int64) (int, error) {
 	}
 	b.h.Write(buf)
 
-	if !bytes.Equal(b.h.Sum(nil), b.hashBytes) {
+	if !b.skipVerify && !bytes.Equal(b.h.Sum(nil), b.hashBytes) {
 		err = fmt.Errorf("hashes do not match expected %s, got %s",
 			hex.EncodeToString(b.hashBytes), hex.EncodeToString(b.h.Sum(nil)))
 		logger.LogIf(context.Background(), err)
@@ -154,5 +155,6 @@ func newStreamingBitrotReader(disk StorageAPI, volume, filePath string, tillOffs
 		h,
 		shardSize,
 		make([]byte, h.Size()),
+		false,
 	}
 }
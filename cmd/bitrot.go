@@ -130,6 +130,19 @@ func newBitrotReader(disk StorageAPI, bucket string, filePath string, tillOffset
 	return newWholeBitrotReader(disk, bucket, filePath, algo, tillOffset, sum)
 }
 
+// Returns a bitrot reader which reads the data without verifying
+// it, used to serve objects which have no parity shards left.
+func newDegradedBitrotReader(disk StorageAPI, bucket string, filePath string, tillOffset int64, algo BitrotAlgorithm, shardSize int64) io.ReaderAt {
+	if algo == HighwayHash256S {
+		r := newStreamingBitrotReader(disk, bucket, filePath, tillOffset, algo, shardSize)
+		r.skipVerify = true
+		return r
+	}
+	r := newWholeBitrotReader(disk, bucket, filePath, algo, tillOffset, nil)
+	r.verifier = nil
+	return r
+}
+
 // Close all the readers.
 func closeBitrotReaders(rs []io.ReaderAt) {
 	for _, r := range rs {
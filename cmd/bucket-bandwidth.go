@@ -0,0 +1,74 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+
+	"github.com/minio/minio/cmd/config/bandwidth"
+	"github.com/minio/minio/pkg/ratelimit"
+)
+
+// bucketBandwidth - limits the aggregate upload and download
+// throughput of buckets, all requests to a bucket share its limit.
+type bucketBandwidth struct {
+	ingress map[string]*ratelimit.Limiter
+	egress  map[string]*ratelimit.Limiter
+}
+
+// newBucketBandwidth - returns the bucket bandwidth limits for
+// the given config, nil if no bucket is limited.
+func newBucketBandwidth(cfg bandwidth.Config) *bucketBandwidth {
+	if len(cfg.Ingress) == 0 && len(cfg.Egress) == 0 {
+		return nil
+	}
+	newLimiters := func(limits map[string]uint64) map[string]*ratelimit.Limiter {
+		limiters := make(map[string]*ratelimit.Limiter, len(limits))
+		for bucket, rate := range limits {
+			// Allow bursts of up to one second worth of data.
+			limiters[bucket] = ratelimit.NewLimiter(float64(rate), int(rate))
+		}
+		return limiters
+	}
+	return &bucketBandwidth{
+		ingress: newLimiters(cfg.Ingress),
+		egress:  newLimiters(cfg.Egress),
+	}
+}
+
+// ingressReader - returns r throttled to the upload limit of bucket.
+func (b *bucketBandwidth) ingressReader(ctx context.Context, bucket string, r io.Reader) io.Reader {
+	if b == nil {
+		return r
+	}
+	if l, ok := b.ingress[bucket]; ok {
+		return ratelimit.NewReader(ctx, r, l)
+	}
+	return r
+}
+
+// egressReader - returns r throttled to the download limit of bucket.
+func (b *bucketBandwidth) egressReader(ctx context.Context, bucket string, r io.Reader) io.Reader {
+	if b == nil {
+		return r
+	}
+	if l, ok := b.egress[bucket]; ok {
+		return ratelimit.NewReader(ctx, r, l)
+	}
+	return r
+}
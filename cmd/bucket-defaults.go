@@ -0,0 +1,215 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucketdefaults"
+)
+
+const (
+	// Bucket defaults configuration file.
+	bucketDefaultsConfig = "defaults.json"
+
+	maxBucketDefaultsSize = 20 * humanize.KiByte
+)
+
+// BucketDefaultsSys - Bucket default object headers subsystem.
+type BucketDefaultsSys struct {
+	sync.RWMutex
+	bucketDefaultsMap map[string]bucketdefaults.Config
+}
+
+// Set - sets bucket defaults to given bucket name.
+func (sys *BucketDefaultsSys) Set(bucketName string, config bucketdefaults.Config) {
+	if globalIsGateway {
+		// no-op
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketDefaultsMap[bucketName] = config
+}
+
+// Get - gets bucket defaults associated to a given bucket name.
+func (sys *BucketDefaultsSys) Get(bucketName string) (config bucketdefaults.Config, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketDefaultsMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes bucket defaults for given bucket name.
+func (sys *BucketDefaultsSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketDefaultsMap, bucketName)
+}
+
+// Apply - returns objInfo of object with the default headers of bucket
+// set where the object was stored without them. Objects stored without
+// a content type of their own have the generic application/octet-stream.
+func (sys *BucketDefaultsSys) Apply(bucket, object string, objInfo ObjectInfo) ObjectInfo {
+	if sys == nil {
+		return objInfo
+	}
+	config, ok := sys.Get(bucket)
+	if !ok {
+		return objInfo
+	}
+
+	if objInfo.ContentType == "" || objInfo.ContentType == "application/octet-stream" {
+		if contentType, ok := config.ContentType(object); ok {
+			objInfo.ContentType = contentType
+		}
+	}
+
+	userDefined := make(map[string]string, len(objInfo.UserDefined)+2)
+	for k, v := range objInfo.UserDefined {
+		userDefined[k] = v
+	}
+	setDefault := func(key, value string) {
+		if value == "" {
+			return
+		}
+		for k := range userDefined {
+			if strings.EqualFold(k, key) {
+				return
+			}
+		}
+		userDefined[key] = value
+	}
+	setDefault("cache-control", config.CacheControl)
+	setDefault("content-disposition", config.ContentDisposition)
+	objInfo.UserDefined = userDefined
+	return objInfo
+}
+
+func saveBucketDefaultsConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *bucketdefaults.Config) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to defaults.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketDefaultsConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketDefaultsConfig - get bucket defaults for given bucket name.
+func getBucketDefaultsConfig(objAPI ObjectLayer, bucketName string) (*bucketdefaults.Config, error) {
+	// Construct path to defaults.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketDefaultsConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketDefaultsNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return bucketdefaults.ParseConfig(bytes.NewReader(configData))
+}
+
+func removeBucketDefaultsConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to defaults.json for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketDefaultsConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketDefaultsNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketDefaultsSys - creates new bucket defaults system.
+func NewBucketDefaultsSys() *BucketDefaultsSys {
+	return &BucketDefaultsSys{
+		bucketDefaultsMap: make(map[string]bucketdefaults.Config),
+	}
+}
+
+// Init - initializes bucket defaults system from defaults.json of all buckets.
+func (sys *BucketDefaultsSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Bucket defaults are not supported in gateway mode.
+	if globalIsGateway {
+		return nil
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing bucket defaults needs a retry mechanism
+	// for the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case <-retryTimerCh:
+			// Load BucketDefaultsSys once during boot.
+			if err := sys.load(buckets, objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for bucket defaults subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		case <-globalOSSignalCh:
+			return fmt.Errorf("Initializing bucket defaults sub-system gracefully stopped")
+		}
+	}
+}
+
+// Loads bucket defaults of all buckets into BucketDefaultsSys.
+func (sys *BucketDefaultsSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := getBucketDefaultsConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketDefaultsNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/bucketdefaults"
+)
+
+func TestBucketDefaultsSysApply(t *testing.T) {
+	sys := NewBucketDefaultsSys()
+	sys.Set("bucket", bucketdefaults.Config{
+		CacheControl:       "max-age=3600",
+		ContentDisposition: "attachment",
+		ContentTypes:       map[string]string{".wasm": "application/wasm"},
+	})
+
+	testCases := []struct {
+		bucket                     string
+		object                     string
+		objInfo                    ObjectInfo
+		expectedContentType        string
+		expectedCacheControl       string
+		expectedContentDisposition string
+	}{
+		// No defaults for the bucket.
+		{"other", "a.wasm", ObjectInfo{ContentType: "application/octet-stream"}, "application/octet-stream", "", ""},
+		// Defaults applied to an object stored without them.
+		{"bucket", "a.wasm", ObjectInfo{ContentType: "application/octet-stream"}, "application/wasm", "max-age=3600", "attachment"},
+		{"bucket", "dir/A.WASM", ObjectInfo{}, "application/wasm", "max-age=3600", "attachment"},
+		// Headers stored with the object take precedence.
+		{"bucket", "a.wasm", ObjectInfo{
+			ContentType: "text/plain",
+			UserDefined: map[string]string{"Cache-Control": "no-cache", "content-disposition": "inline"},
+		}, "text/plain", "no-cache", "inline"},
+		// No content type mapping for the extension.
+		{"bucket", "a.txt", ObjectInfo{ContentType: "application/octet-stream"}, "application/octet-stream", "max-age=3600", "attachment"},
+	}
+
+	for i, testCase := range testCases {
+		objInfo := sys.Apply(testCase.bucket, testCase.object, testCase.objInfo)
+		if objInfo.ContentType != testCase.expectedContentType {
+			t.Errorf("Test %d: expected content type %q, got %q", i+1, testCase.expectedContentType, objInfo.ContentType)
+		}
+		cacheControl, contentDisposition := objInfo.UserDefined["cache-control"], objInfo.UserDefined["content-disposition"]
+		if v, ok := objInfo.UserDefined["Cache-Control"]; ok {
+			cacheControl = v
+		}
+		if cacheControl != testCase.expectedCacheControl {
+			t.Errorf("Test %d: expected cache control %q, got %q", i+1, testCase.expectedCacheControl, cacheControl)
+		}
+		if contentDisposition != testCase.expectedContentDisposition {
+			t.Errorf("Test %d: expected content disposition %q, got %q", i+1, testCase.expectedContentDisposition, contentDisposition)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/bucketsse"
+)
+
+const (
+	// Bucket encryption configuration file.
+	bucketSSEConfig = "bucket-encryption.xml"
+)
+
+// BucketSSEConfigSys - Bucket encryption subsystem.
+type BucketSSEConfigSys struct {
+	sync.RWMutex
+	bucketSSEConfigMap map[string]bucketsse.BucketSSEConfig
+}
+
+// Set - sets encryption config to given bucket name.
+func (sys *BucketSSEConfigSys) Set(bucketName string, config bucketsse.BucketSSEConfig) {
+	if globalIsGateway {
+		// no-op
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketSSEConfigMap[bucketName] = config
+}
+
+// Get - gets encryption config associated to a given bucket name.
+func (sys *BucketSSEConfigSys) Get(bucketName string) (config bucketsse.BucketSSEConfig, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketSSEConfigMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes encryption config for given bucket name.
+func (sys *BucketSSEConfigSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketSSEConfigMap, bucketName)
+}
+
+// IsEnabled - returns true if objects uploaded to the given bucket
+// are encrypted by default.
+func (sys *BucketSSEConfigSys) IsEnabled(bucketName string) bool {
+	_, ok := sys.Get(bucketName)
+	return ok
+}
+
+// isBucketAutoEncrypted - returns true if objects uploaded to the given
+// bucket are encrypted with SSE-S3 when the client does not request
+// encryption, either for all buckets or by the encryption configuration
+// of the bucket.
+func isBucketAutoEncrypted(bucketName string) bool {
+	if globalAutoEncryption {
+		return true
+	}
+	return globalBucketSSEConfigSys != nil && globalBucketSSEConfigSys.IsEnabled(bucketName)
+}
+
+// getBucketSSEKeyID - returns the KMS key the SSE-S3 object keys of the
+// given bucket are sealed with, the encryption configuration of the bucket
+// may name a key other than the default key of the KMS.
+func getBucketSSEKeyID(bucketName string) string {
+	if globalBucketSSEConfigSys != nil {
+		if config, ok := globalBucketSSEConfigSys.Get(bucketName); ok && config.KeyID() != "" {
+			return config.KeyID()
+		}
+	}
+	return GlobalKMS.KeyID()
+}
+
+func saveBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *bucketsse.BucketSSEConfig) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getBucketSSEConfig - get encryption config for given bucket name.
+func getBucketSSEConfig(objAPI ObjectLayer, bucketName string) (*bucketsse.BucketSSEConfig, error) {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return bucketsse.ParseBucketSSEConfig(bytes.NewReader(configData))
+}
+
+func removeBucketSSEConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to bucket-encryption.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketSSEConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketSSEConfigNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewBucketSSEConfigSys - creates new bucket encryption system.
+func NewBucketSSEConfigSys() *BucketSSEConfigSys {
+	return &BucketSSEConfigSys{
+		bucketSSEConfigMap: make(map[string]bucketsse.BucketSSEConfig),
+	}
+}
+
+// Init - initializes bucket encryption system from bucket-encryption.xml of all buckets.
+func (sys *BucketSSEConfigSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Bucket encryption configuration is not supported in gateway mode.
+	if globalIsGateway {
+		return nil
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing bucket encryption configuration needs a retry mechanism
+	// for the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case <-retryTimerCh:
+			// Load BucketSSEConfigSys once during boot.
+			if err := sys.load(buckets, objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for bucket encryption subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		case <-globalOSSignalCh:
+			return fmt.Errorf("Initializing bucket encryption sub-system gracefully stopped")
+		}
+	}
+}
+
+// Loads encryption configuration for all buckets into BucketSSEConfigSys.
+func (sys *BucketSSEConfigSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := getBucketSSEConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketSSEConfigNotFound); ok {
+				sys.Remove(bucket.Name)
+			}
+			continue
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
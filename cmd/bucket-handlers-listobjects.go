@@ -215,6 +215,13 @@ func (api objectAPIHandlers) ListObjectsV2Handler(w http.ResponseWriter, r *http
 // criteria to return a subset of the objects in a bucket.
 //
 func (api objectAPIHandlers) ListObjectsV1Handler(w http.ResponseWriter, r *http.Request) {
+	// Anonymous requests on the root of a bucket hosting
+	// a website serve its index document.
+	if bucket := mux.Vars(r)["bucket"]; isWebsiteRootRequest(r, bucket) {
+		api.GetObjectHandler(w, websiteObjectRequest(r, bucket))
+		return
+	}
+
 	ctx := newContext(r, w, "ListObjectsV1")
 
 	defer logger.AuditLog(w, r, "ListObjectsV1", mustGetClaimsFromToken(r))
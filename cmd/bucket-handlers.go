@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/gorilla/mux"
@@ -246,7 +247,22 @@ func (api objectAPIHandlers) ListBucketsHandler(w http.ResponseWriter, r *http.R
 
 	listBuckets := objectAPI.ListBuckets
 
+	// Users not allowed s3:ListAllMyBuckets by their IAM policies
+	// still list the buckets they have access to, unless it is denied
+	// to them explicitly. Anonymous requests never list buckets.
 	accessKey, owner, s3Error := checkRequestAuthTypeToAccessKey(ctx, r, policy.ListAllMyBucketsAction, "", "")
+	if s3Error == ErrAccessDenied && accessKey != "" {
+		claims, _ := getClaimsFromToken(r)
+		if !globalIAMSys.IsDenied(iampolicy.Args{
+			AccountName:     accessKey,
+			Action:          iampolicy.ListAllMyBucketsAction,
+			ConditionValues: getConditionValues(r, "", accessKey),
+			IsOwner:         owner,
+			Claims:          claims,
+		}) {
+			s3Error = ErrNone
+		}
+	}
 	if s3Error != ErrNone {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
 		return
@@ -290,17 +306,16 @@ func (api objectAPIHandlers) ListBucketsHandler(w http.ResponseWriter, r *http.R
 	// err will be nil here as we already called this function
 	// earlier in this request.
 	claims, _ := getClaimsFromToken(r)
+	isAllowed := globalIAMSys.BucketMatcher(iampolicy.Args{
+		AccountName:     accessKey,
+		Action:          iampolicy.ListBucketAction,
+		ConditionValues: getConditionValues(r, "", accessKey),
+		IsOwner:         owner,
+		Claims:          claims,
+	})
 	var newBucketsInfo []BucketInfo
 	for _, bucketInfo := range bucketsInfo {
-		if globalIAMSys.IsAllowed(iampolicy.Args{
-			AccountName:     accessKey,
-			Action:          iampolicy.ListBucketAction,
-			BucketName:      bucketInfo.Name,
-			ConditionValues: getConditionValues(r, "", accessKey),
-			IsOwner:         owner,
-			ObjectName:      "",
-			Claims:          claims,
-		}) {
+		if isAllowed(bucketInfo.Name) {
 			newBucketsInfo = append(newBucketsInfo, bucketInfo)
 		}
 	}
@@ -504,7 +519,7 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 					return
 				}
 				if err = globalDNSConfig.Put(bucket); err != nil {
-					objectAPI.DeleteBucket(ctx, bucket)
+					objectAPI.DeleteBucket(ctx, bucket, false)
 					writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 					return
 				}
@@ -668,8 +683,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 			return
 		}
 
-		// Ensure that the object size is within expected range, also the file size
-		// should not exceed the maximum single Put size (5 GiB)
+		// Ensure that the object size is within expected range.
 		lengthRange := postPolicyForm.Conditions.ContentLengthRange
 		if lengthRange.Valid {
 			if fileSize < lengthRange.Min {
@@ -677,13 +691,20 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 				return
 			}
 
-			if fileSize > lengthRange.Max || isMaxObjectSize(fileSize) {
+			if fileSize > lengthRange.Max {
 				writeErrorResponse(ctx, w, toAPIError(ctx, errDataTooLarge), r.URL, guessIsBrowserReq(r))
 				return
 			}
 		}
 	}
 
+	// The file size should never exceed the maximum single Put size,
+	// regardless of the policy carrying a content-length-range.
+	if isMaxObjectSize(fileSize) {
+		writeErrorResponse(ctx, w, toAPIError(ctx, errDataTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Extract metadata to be saved from received Form.
 	metadata := make(map[string]string)
 	err = extractMetadataFromMap(ctx, formValues, metadata)
@@ -692,7 +713,8 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 		return
 	}
 
-	hashReader, err := hash.NewReader(fileBody, fileSize, "", "", fileSize, globalCLIContext.StrictS3Compat)
+	fileReader := globalBucketBandwidth.ingressReader(ctx, bucket, fileBody)
+	hashReader, err := hash.NewReader(fileReader, fileSize, "", "", fileSize, globalCLIContext.StrictS3Compat)
 	if err != nil {
 		logger.LogIf(ctx, err)
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -703,7 +725,7 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 	var objectEncryptionKey []byte
 
 	// This request header needs to be set prior to setting ObjectOptions
-	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
+	if isBucketAutoEncrypted(bucket) && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 	// get gateway encryption options
@@ -796,6 +818,13 @@ func (api objectAPIHandlers) PostPolicyBucketHandler(w http.ResponseWriter, r *h
 // have permission to access it. Otherwise, the operation might
 // return responses such as 404 Not Found and 403 Forbidden.
 func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Request) {
+	// Anonymous requests on the root of a bucket hosting
+	// a website serve its index document.
+	if bucket := mux.Vars(r)["bucket"]; isWebsiteRootRequest(r, bucket) {
+		api.HeadObjectHandler(w, websiteObjectRequest(r, bucket))
+		return
+	}
+
 	ctx := newContext(r, w, "HeadBucket")
 
 	defer logger.AuditLog(w, r, "HeadBucket", mustGetClaimsFromToken(r))
@@ -810,20 +839,48 @@ func (api objectAPIHandlers) HeadBucketHandler(w http.ResponseWriter, r *http.Re
 	}
 
 	if s3Error := checkRequestAuthType(ctx, r, policy.ListBucketAction, bucket, ""); s3Error != ErrNone {
+		// Like S3, requests denied by the bucket policy or the IAM
+		// policies of the user learn whether the bucket exists, 404
+		// for a missing bucket and 403 along with its region otherwise.
+		if s3Error == ErrAccessDenied && isRequestPolicyEvaluated(r) {
+			exists, err := bucketExists(ctx, objectAPI, bucket)
+			switch {
+			case err != nil:
+				writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
+				return
+			case !exists:
+				s3Error = ErrNoSuchBucket
+			default:
+				w.Header().Set(xhttp.AmzBucketRegion, getBucketRegion())
+			}
+		}
 		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(s3Error))
 		return
 	}
 
-	getBucketInfo := objectAPI.GetBucketInfo
-
-	if _, err := getBucketInfo(ctx, bucket); err != nil {
+	exists, err := bucketExists(ctx, objectAPI, bucket)
+	if err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	if !exists {
+		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(ErrNoSuchBucket))
+		return
+	}
 
+	w.Header().Set(xhttp.AmzBucketRegion, getBucketRegion())
 	writeSuccessResponseHeadersOnly(w)
 }
 
+// Returns the region of the buckets, which is the region of the server
+// or us-east-1 when none is set, like S3 clients expect.
+func getBucketRegion() string {
+	if region := globalServerConfig.GetRegion(); region != globalMinioDefaultRegion {
+		return region
+	}
+	return "us-east-1"
+}
+
 // DeleteBucketHandler - Delete bucket
 func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "DeleteBucket")
@@ -844,20 +901,46 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 		return
 	}
 
-	deleteBucket := objectAPI.DeleteBucket
+	forceDelete := false
+	if value := r.Header.Get(xhttp.MinIOForceDelete); value != "" {
+		var err error
+		if forceDelete, err = strconv.ParseBool(value); err != nil {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRequest), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+
+	if forceDelete {
+		// Deleting the objects of the bucket requires the
+		// permission to delete objects as well.
+		if s3Error := checkRequestAuthType(ctx, r, policy.DeleteObjectAction, bucket, ""); s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
 
 	// Attempt to delete bucket.
-	if err := deleteBucket(ctx, bucket); err != nil {
+	if err := removeBucket(ctx, objectAPI, bucket, forceDelete); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
 
+	// Write success response.
+	writeSuccessNoContent(w)
+}
+
+// removeBucket - deletes the bucket along with its DNS entry and
+// removes the bucket configuration from all the servers.
+func removeBucket(ctx context.Context, objectAPI ObjectLayer, bucket string, forceDelete bool) error {
+	if err := objectAPI.DeleteBucket(ctx, bucket, forceDelete); err != nil {
+		return err
+	}
+
 	if globalDNSConfig != nil {
 		if err := globalDNSConfig.Delete(bucket); err != nil {
 			// Deleting DNS entry failed, attempt to create the bucket again.
 			objectAPI.MakeBucketWithLocation(ctx, bucket, "")
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-			return
+			return err
 		}
 	}
 
@@ -866,7 +949,11 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 	globalNotificationSys.DeleteBucket(ctx, bucket)
 	globalLifecycleSys.Remove(bucket)
 	globalNotificationSys.RemoveBucketLifecycle(ctx, bucket)
-
-	// Write success response.
-	writeSuccessNoContent(w)
+	globalWebsiteSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketWebsite(ctx, bucket)
+	globalBucketDefaultsSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketDefaults(ctx, bucket)
+	globalBucketSSEConfigSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketSSEConfig(ctx, bucket)
+	return nil
 }
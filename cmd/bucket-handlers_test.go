@@ -24,9 +24,13 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strconv"
+	"strings"
 	"testing"
 
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/madmin"
 )
 
 // Wrapper for calling GetBucketPolicy HTTP handler tests for both XL multiple disks and single node setup.
@@ -230,6 +234,9 @@ func testHeadBucketHandler(obj ObjectLayer, instanceType, bucketName string, api
 		if rec.Code != testCase.expectedRespStatus {
 			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
 		}
+		if rec.Code == http.StatusOK && rec.Header().Get(xhttp.AmzBucketRegion) != "us-east-1" {
+			t.Errorf("Test %d: %s: Expected the bucket region `us-east-1`, but instead found `%s`", i+1, instanceType, rec.Header().Get(xhttp.AmzBucketRegion))
+		}
 
 		// Verify response the V2 signed HTTP request.
 		// initialize HTTP NewRecorder, this records any mutations to response writer inside the handler.
@@ -249,6 +256,31 @@ func testHeadBucketHandler(obj ObjectLayer, instanceType, bucketName string, api
 
 	}
 
+	// Anonymous requests denied by the bucket policy get 404 for
+	// missing buckets and 403 with the bucket region otherwise.
+	for _, testCase := range []struct {
+		bucketName         string
+		expectedRespStatus int
+		expectedRegion     string
+	}{
+		{"2333", http.StatusNotFound, ""},
+		{bucketName, http.StatusForbidden, "us-east-1"},
+	} {
+		anonReq, err := newTestRequest("HEAD", getHEADBucketURL("", testCase.bucketName), 0, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create an anonymous request for bucket \"%s\": <ERROR> %v",
+				instanceType, testCase.bucketName, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, anonReq)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("%s: bucket %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, testCase.bucketName, testCase.expectedRespStatus, rec.Code)
+		}
+		if region := rec.Header().Get(xhttp.AmzBucketRegion); region != testCase.expectedRegion {
+			t.Errorf("%s: bucket %s: Expected the bucket region `%s`, but instead found `%s`", instanceType, testCase.bucketName, testCase.expectedRegion, region)
+		}
+	}
+
 	// Test for Anonymous/unsigned http request.
 	anonReq, err := newTestRequest("HEAD", getHEADBucketURL("", bucketName), 0, nil)
 
@@ -278,6 +310,91 @@ func testHeadBucketHandler(obj ObjectLayer, instanceType, bucketName string, api
 	ExecObjectLayerAPINilTest(t, nilBucket, "", instanceType, apiRouter, nilReq)
 }
 
+// Wrapper for calling DeleteBucket HTTP handler tests for both XL multiple disks and single node setup.
+func TestDeleteBucketHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testDeleteBucketHandler, []string{"DeleteBucket"})
+}
+
+func testDeleteBucketHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	// Deleting a bucket removes its configuration from all the subsystems.
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	globalLifecycleSys = NewLifecycleSys()
+	globalWebsiteSys = NewWebsiteSys()
+	globalBucketDefaultsSys = NewBucketDefaultsSys()
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	for _, object := range []string{"object", "dir/object"} {
+		_, err := obj.PutObject(context.Background(), bucketName, object, mustGetPutObjReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+		}
+	}
+
+	// test cases with sample input and expected output.
+	testCases := []struct {
+		forceDelete string
+		// expected Response.
+		expectedRespStatus int
+	}{
+		// Test case - 1.
+		// Bucket is not empty.
+		{
+			forceDelete:        "",
+			expectedRespStatus: http.StatusConflict,
+		},
+		// Test case - 2.
+		// Invalid force delete header.
+		{
+			forceDelete:        "yes-please",
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 3.
+		// Bucket is deleted along with its objects.
+		{
+			forceDelete:        "true",
+			expectedRespStatus: http.StatusNoContent,
+		},
+		// Test case - 4.
+		// Bucket does not exist anymore.
+		{
+			forceDelete:        "true",
+			expectedRespStatus: http.StatusNotFound,
+		},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestRequest("DELETE", getDeleteBucketURL("", bucketName), 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for DeleteBucketHandler: <ERROR> %v", i+1, instanceType, err)
+		}
+		if testCase.forceDelete != "" {
+			req.Header.Set(xhttp.MinIOForceDelete, testCase.forceDelete)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("Test %d: %s: Failed to sign HTTP request for DeleteBucketHandler: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+	}
+
+	// The objects must not come back when the bucket is created again.
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s: Failed to create bucket: <ERROR> %v", instanceType, err)
+	}
+	loi, err := obj.ListObjects(context.Background(), bucketName, "", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: Failed to list objects: <ERROR> %v", instanceType, err)
+	}
+	if len(loi.Objects) != 0 || len(loi.Prefixes) != 0 {
+		t.Errorf("%s: Expected the recreated bucket to be empty, found %+v", instanceType, loi)
+	}
+}
+
 // Wrapper for calling TestListMultipartUploadsHandler tests for both XL multiple disks and single node setup.
 func TestListMultipartUploadsHandler(t *testing.T) {
 	ExecObjectLayerAPITest(t, testListMultipartUploadsHandler, []string{"ListMultipartUploads"})
@@ -594,6 +711,66 @@ func testListBucketsHandler(obj ObjectLayer, instanceType, bucketName string, ap
 	// unsigned request goes through and its validated again.
 	ExecObjectLayerAPIAnonTest(t, obj, "ListBucketsHandler", "", "", instanceType, apiRouter, anonReq, getAnonWriteOnlyBucketPolicy("*"))
 
+	// Users not allowed to list all buckets only get the buckets
+	// they are allowed to list the objects of.
+	otherBucket := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), otherBucket, ""); err != nil {
+		t.Fatalf("MinIO %s: Failed to create bucket %s: <ERROR> %v", instanceType, otherBucket, err)
+	}
+	userPolicy, err := iampolicy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::` + bucketName + `"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("listbucket", *userPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetUser("listuser", madmin.UserInfo{
+		SecretKey: "listuser-secret",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("listuser", "listbucket", false); err != nil {
+		t.Fatal(err)
+	}
+	userReq, err := newTestSignedRequestV4("GET", getListBucketURL(""), 0, nil, "listuser", "listuser-secret", nil)
+	if err != nil {
+		t.Fatalf("MinIO %s: Failed to create HTTP request for ListBucketsHandler: <ERROR> %v", instanceType, err)
+	}
+	userRec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(userRec, userReq)
+	if userRec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, userRec.Code)
+	}
+	var listResp ListBucketsResponse
+	if err = xml.Unmarshal(userRec.Body.Bytes(), &listResp); err != nil {
+		t.Fatalf("MinIO %s: Failed to parse the ListBuckets response: <ERROR> %v", instanceType, err)
+	}
+	if len(listResp.Buckets.Buckets) != 1 || listResp.Buckets.Buckets[0].Name != bucketName {
+		t.Errorf("MinIO %s: Expected only bucket %s to be listed, got %v", instanceType, bucketName, listResp.Buckets.Buckets)
+	}
+
+	// Users explicitly denied to list all buckets get no buckets.
+	denyPolicy, err := iampolicy.ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["s3:ListBucket"],"Resource":["arn:aws:s3:::` + bucketName + `"]},{"Effect":"Deny","Action":["s3:ListAllMyBuckets"],"Resource":["arn:aws:s3:::*"]}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.SetPolicy("denylistbuckets", *denyPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("listuser", "denylistbuckets", false); err != nil {
+		t.Fatal(err)
+	}
+	userReq, err = newTestSignedRequestV4("GET", getListBucketURL(""), 0, nil, "listuser", "listuser-secret", nil)
+	if err != nil {
+		t.Fatalf("MinIO %s: Failed to create HTTP request for ListBucketsHandler: <ERROR> %v", instanceType, err)
+	}
+	userRec = httptest.NewRecorder()
+	apiRouter.ServeHTTP(userRec, userReq)
+	if userRec.Code != http.StatusForbidden {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, userRec.Code)
+	}
+
 	// HTTP request for testing when `objectLayer` is set to `nil`.
 	// There is no need to use an existing bucket and valid input for creating the request
 	// since the `objectLayer==nil`  check is performed before any other checks inside the handlers.
@@ -0,0 +1,205 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"path"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/bucketdefaults"
+	"github.com/minio/minio/pkg/bucketsse"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+const maxBucketMetadataBundleSize = 1 * humanize.MiByte
+
+// Configuration files of a bucket in a metadata bundle, a gzipped
+// tarball holding one entry per file set on the bucket.
+var bucketMetadataBundleFiles = []string{
+	bucketPolicyConfig,
+	bucketNotificationConfig,
+	bucketLifecycleConfig,
+	bucketSSEConfig,
+	bucketWebsiteConfig,
+	bucketDefaultsConfig,
+}
+
+// exportBucketMetadata - returns the metadata bundle of a bucket.
+func exportBucketMetadata(ctx context.Context, objAPI ObjectLayer, bucket string) ([]byte, error) {
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, name := range bucketMetadataBundleFiles {
+		data, err := readConfig(ctx, objAPI, path.Join(bucketConfigPrefix, bucket, name))
+		if err != nil {
+			if err == errConfigNotFound {
+				continue
+			}
+			return nil, err
+		}
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0644,
+			Size:    int64(len(data)),
+			ModTime: UTCNow(),
+		}
+		if err = tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err = tw.Write(data); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// bucketMetadataBundle - configurations read from a metadata bundle,
+// nil for the files absent from the bundle.
+type bucketMetadataBundle struct {
+	policy       *policy.Policy
+	notification *event.Config
+	lifecycle    *lifecycle.Lifecycle
+	sse          *bucketsse.BucketSSEConfig
+	website      *website.Website
+	defaults     *bucketdefaults.Config
+}
+
+// parseBucketMetadataBundle - reads and validates all files of the
+// metadata bundle of bucket.
+func parseBucketMetadataBundle(objAPI ObjectLayer, bucket string, r io.Reader) (*bucketMetadataBundle, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bundle: %v", err)
+	}
+	defer gzr.Close()
+
+	b := &bucketMetadataBundle{}
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return b, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle: %v", err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid bundle: %v", err)
+		}
+		if err = b.parseFile(objAPI, bucket, hdr.Name, data); err != nil {
+			return nil, fmt.Errorf("invalid %s: %v", hdr.Name, err)
+		}
+	}
+}
+
+func (b *bucketMetadataBundle) parseFile(objAPI ObjectLayer, bucket, name string, data []byte) (err error) {
+	switch name {
+	case bucketPolicyConfig:
+		b.policy, err = policy.ParseConfig(bytes.NewReader(data), bucket)
+	case bucketNotificationConfig:
+		if !objAPI.IsNotificationSupported() {
+			return fmt.Errorf("notifications are not supported")
+		}
+		b.notification, err = event.ParseConfig(bytes.NewReader(data), globalServerConfig.GetRegion(), globalNotificationSys.targetList)
+		// As with PutBucketNotification, targets which are not
+		// configured yet are accepted.
+		if _, ok := err.(*event.ErrARNNotFound); ok {
+			err = nil
+		}
+	case bucketLifecycleConfig:
+		b.lifecycle, err = lifecycle.ParseLifecycleConfig(bytes.NewReader(data))
+	case bucketSSEConfig:
+		if !objAPI.IsEncryptionSupported() || GlobalKMS == nil {
+			return fmt.Errorf("KMS is not configured")
+		}
+		b.sse, err = bucketsse.ParseBucketSSEConfig(bytes.NewReader(data))
+	case bucketWebsiteConfig:
+		b.website, err = website.ParseWebsiteConfig(bytes.NewReader(data))
+	case bucketDefaultsConfig:
+		b.defaults, err = bucketdefaults.ParseConfig(bytes.NewReader(data))
+	default:
+		return fmt.Errorf("unknown file")
+	}
+	return err
+}
+
+// apply - saves the configurations of the bundle for bucket and
+// loads them on all servers, the configurations of the bucket
+// absent from the bundle are left unchanged.
+func (b *bucketMetadataBundle) apply(ctx context.Context, objAPI ObjectLayer, bucket string) error {
+	if b.policy != nil {
+		if err := objAPI.SetBucketPolicy(ctx, bucket, b.policy); err != nil {
+			return err
+		}
+		globalPolicySys.Set(bucket, *b.policy)
+		globalNotificationSys.SetBucketPolicy(ctx, bucket, b.policy)
+	}
+	if b.notification != nil {
+		if err := saveNotificationConfig(ctx, objAPI, bucket, b.notification); err != nil {
+			return err
+		}
+		rulesMap := b.notification.ToRulesMap()
+		globalNotificationSys.AddRulesMap(bucket, rulesMap)
+		globalNotificationSys.PutBucketNotification(ctx, bucket, rulesMap)
+	}
+	if b.lifecycle != nil {
+		if err := objAPI.SetBucketLifecycle(ctx, bucket, b.lifecycle); err != nil {
+			return err
+		}
+		globalLifecycleSys.Set(bucket, *b.lifecycle)
+		globalNotificationSys.SetBucketLifecycle(ctx, bucket, b.lifecycle)
+	}
+	if b.sse != nil {
+		if err := saveBucketSSEConfig(ctx, objAPI, bucket, b.sse); err != nil {
+			return err
+		}
+		globalBucketSSEConfigSys.Set(bucket, *b.sse)
+		globalNotificationSys.SetBucketSSEConfig(ctx, bucket, b.sse)
+	}
+	if b.website != nil {
+		if err := saveWebsiteConfig(ctx, objAPI, bucket, b.website); err != nil {
+			return err
+		}
+		globalWebsiteSys.Set(bucket, *b.website)
+		globalNotificationSys.SetBucketWebsite(ctx, bucket, b.website)
+	}
+	if b.defaults != nil {
+		if err := saveBucketDefaultsConfig(ctx, objAPI, bucket, b.defaults); err != nil {
+			return err
+		}
+		globalBucketDefaultsSys.Set(bucket, *b.defaults)
+		globalNotificationSys.SetBucketDefaults(ctx, bucket, b.defaults)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/bucketdefaults"
+	"github.com/minio/minio/pkg/lifecycle"
+)
+
+func TestBucketMetadataBundle(t *testing.T) {
+	testServer := StartTestServer(t, "FS")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	objAPI := testServer.Obj
+	bucket := "bucket"
+	if err := objAPI.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	bucketPolicy := getAnonReadOnlyBucketPolicy(bucket)
+	if err := objAPI.SetBucketPolicy(ctx, bucket, bucketPolicy); err != nil {
+		t.Fatal(err)
+	}
+	bucketLifecycle, err := lifecycle.ParseLifecycleConfig(strings.NewReader(`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = objAPI.SetBucketLifecycle(ctx, bucket, bucketLifecycle); err != nil {
+		t.Fatal(err)
+	}
+	defaults := &bucketdefaults.Config{CacheControl: "max-age=3600"}
+	if err = saveBucketDefaultsConfig(ctx, objAPI, bucket, defaults); err != nil {
+		t.Fatal(err)
+	}
+
+	bundle, err := exportBucketMetadata(ctx, objAPI, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the configurations set on the bucket are exported.
+	gzr, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	tr := tar.NewReader(gzr)
+	for {
+		hdr, err := tr.Next()
+		if err != nil {
+			break
+		}
+		names = append(names, hdr.Name)
+	}
+	expectedNames := []string{bucketPolicyConfig, bucketLifecycleConfig, bucketDefaultsConfig}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("Expected bundle files %v, got %v", expectedNames, names)
+	}
+
+	if err = objAPI.DeleteBucketPolicy(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+	if err = objAPI.DeleteBucketLifecycle(ctx, bucket); err != nil {
+		t.Fatal(err)
+	}
+	if err = removeBucketDefaultsConfig(ctx, objAPI, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	// A bundle with an invalid file is rejected as a whole.
+	var buf bytes.Buffer
+	gzw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gzw)
+	for _, file := range []struct{ name, data string }{
+		{bucketDefaultsConfig, `{"cacheControl":"no-cache"}`},
+		{"tags.xml", "<Tagging/>"},
+	} {
+		if err = tw.WriteHeader(&tar.Header{Name: file.name, Mode: 0644, Size: int64(len(file.data))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = tw.Write([]byte(file.data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gzw.Close()
+	if _, err = parseBucketMetadataBundle(objAPI, bucket, &buf); err == nil {
+		t.Fatal("Expected a bundle with an unknown file to be rejected")
+	}
+
+	b, err := parseBucketMetadataBundle(objAPI, bucket, bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = b.apply(ctx, objAPI, bucket); err != nil {
+		t.Fatal(err)
+	}
+
+	importedPolicy, err := objAPI.GetBucketPolicy(ctx, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(importedPolicy, bucketPolicy) {
+		t.Errorf("Expected policy %v, got %v", bucketPolicy, importedPolicy)
+	}
+	importedLifecycle, err := objAPI.GetBucketLifecycle(ctx, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(importedLifecycle, bucketLifecycle) {
+		t.Errorf("Expected lifecycle %v, got %v", bucketLifecycle, importedLifecycle)
+	}
+	if _, ok := globalLifecycleSys.Get(bucket); !ok {
+		t.Errorf("Expected the lifecycle to be loaded")
+	}
+	importedDefaults, ok := globalBucketDefaultsSys.Get(bucket)
+	if !ok || importedDefaults.CacheControl != defaults.CacheControl {
+		t.Errorf("Expected defaults %v, got %v", *defaults, importedDefaults)
+	}
+}
@@ -18,9 +18,11 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"encoding/xml"
 	"errors"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"path"
 
@@ -202,6 +204,11 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
+	if isReservedOrInvalidBucket(bucketName, false) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidBucketName), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	values := r.URL.Query()
 
 	var prefix string
@@ -252,6 +259,13 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
+	// The request body is empty, read it to verify the signed
+	// payload checksum before the response is streamed.
+	if _, err := io.Copy(ioutil.Discard, r.Body); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	host, err := xnet.ParseHost(r.RemoteAddr)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
@@ -266,35 +280,57 @@ func (api objectAPIHandlers) ListenBucketNotificationHandler(w http.ResponseWrit
 		return
 	}
 
-	rulesMap := event.NewRulesMap(eventNames, pattern, target.ID())
-
-	if err = globalNotificationSys.AddRemoteTarget(bucketName, target, rulesMap); err != nil {
-		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
+	if err = listenBucketNotification(ctx, objAPI, bucketName, eventNames, pattern, target); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
-	defer globalNotificationSys.RemoveRemoteTarget(bucketName, target.ID())
+}
+
+// listenBucketNotification - sends the events of the bucket matching
+// eventNames and pattern to clientTarget until the client is gone.
+func listenBucketNotification(ctx context.Context, objAPI ObjectLayer, bucketName string, eventNames []event.Name,
+	pattern string, clientTarget *target.HTTPClientTarget) error {
+	rulesMap := event.NewRulesMap(eventNames, pattern, clientTarget.ID())
+
+	if err := globalNotificationSys.AddRemoteTarget(bucketName, clientTarget, rulesMap); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", clientTarget.ID().Name)
+		return err
+	}
+	defer globalNotificationSys.RemoveRemoteTarget(bucketName, clientTarget.ID())
 	defer globalNotificationSys.RemoveRulesMap(bucketName, rulesMap)
 
 	thisAddr, err := xnet.ParseHost(GetLocalPeer(globalEndpoints))
 	if err != nil {
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-		return
+		return err
 	}
 
-	if err = SaveListener(objAPI, bucketName, eventNames, pattern, target.ID(), *thisAddr); err != nil {
-		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-		return
+	// Events of the other nodes arrive through the event bus
+	// when it is enabled, no need to register with the peers.
+	if globalNotificationSys.EventBusEnabled() {
+		<-clientTarget.DoneCh
+		return nil
 	}
 
-	globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, pattern, target.ID(), *thisAddr)
+	if err = SaveListener(objAPI, bucketName, eventNames, pattern, clientTarget.ID(), *thisAddr); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", clientTarget.ID().Name)
+		return err
+	}
 
-	<-target.DoneCh
+	// Peers which could not be reached do not send their events, the
+	// client still gets the events of the others.
+	for _, nerr := range globalNotificationSys.ListenBucketNotification(ctx, bucketName, eventNames, pattern, clientTarget.ID(), *thisAddr) {
+		if nerr.Err != nil {
+			reqInfo := (&logger.ReqInfo{}).AppendTags("remotePeer", nerr.Host.String())
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
 
-	if err = RemoveListener(objAPI, bucketName, target.ID(), *thisAddr); err != nil {
-		logger.GetReqInfo(ctx).AppendTags("target", target.ID().Name)
-		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
-		return
+	<-clientTarget.DoneCh
+
+	if err = RemoveListener(objAPI, bucketName, clientTarget.ID(), *thisAddr); err != nil {
+		logger.GetReqInfo(ctx).AppendTags("target", clientTarget.ID().Name)
+		return err
 	}
+	return nil
 }
@@ -0,0 +1,125 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/cmd/logger/message/log"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// testLoggerTarget - collects the entries logged during a test.
+type testLoggerTarget struct {
+	sync.Mutex
+	entries []log.Entry
+}
+
+func (t *testLoggerTarget) Send(entry interface{}, errKind string) error {
+	t.Lock()
+	defer t.Unlock()
+	if e, ok := entry.(log.Entry); ok {
+		t.entries = append(t.entries, e)
+	}
+	return nil
+}
+
+func (t *testLoggerTarget) remotePeers() []string {
+	t.Lock()
+	defer t.Unlock()
+	var peers []string
+	for _, e := range t.entries {
+		if peer, ok := e.Trace.Variables["remotePeer"]; ok {
+			peers = append(peers, peer)
+		}
+	}
+	return peers
+}
+
+func TestListenBucketNotificationPeerErrors(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// A peer which refuses connections.
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	peerAddr := l.Addr().String()
+	l.Close()
+	peerHost, err := xnet.ParseHost(peerAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	peer, err := newPeerRESTClient(peerHost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = notificationSys }()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+	globalNotificationSys.peerClients = []*peerRESTClient{peer}
+
+	loggerTarget := &testLoggerTarget{}
+	targets := logger.Targets
+	logger.AddTarget(loggerTarget)
+	defer func() { logger.Targets = targets }()
+
+	clientTarget, err := target.NewHTTPClientTarget(xnet.Host{Name: "127.0.0.1", Port: 9000}, httptest.NewRecorder())
+	if err != nil {
+		t.Fatal(err)
+	}
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- listenBucketNotification(ctx, obj, "bucket", []event.Name{event.ObjectCreatedAll}, event.NewPattern("", ""), clientTarget)
+	}()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for len(loggerTarget.remotePeers()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the error of the unreachable peer to be logged")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if peers := loggerTarget.remotePeers(); len(peers) != 1 || peers[0] != peerAddr {
+		t.Fatalf("Expected the error of %s to be logged, got %v", peerAddr, peers)
+	}
+
+	clientTarget.Close()
+	if err = <-errCh; err != nil {
+		t.Fatal(err)
+	}
+}
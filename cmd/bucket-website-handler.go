@@ -0,0 +1,333 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/website"
+)
+
+// PutBucketWebsiteHandler - This HTTP handler stores given bucket website configuration as per
+// https://docs.aws.amazon.com/AmazonS3/latest/dev/WebsiteHosting.html
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(w, r, "PutBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	bucketWebsite, err := website.ParseWebsiteConfig(io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = saveWebsiteConfig(ctx, objAPI, bucket, bucketWebsite); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	globalWebsiteSys.Set(bucket, *bucketWebsite)
+	globalNotificationSys.SetBucketWebsite(ctx, bucket, bucketWebsite)
+
+	// Success.
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - This HTTP handler returns bucket website configuration.
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(w, r, "GetBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, toAPIError(ctx, BucketWebsiteNotFound{Bucket: bucket}), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	bucketWebsite, err := getWebsiteConfig(objAPI, bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	websiteData, err := xml.Marshal(bucketWebsite)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Write website configuration to client.
+	writeSuccessResponseXML(w, websiteData)
+}
+
+// DeleteBucketWebsiteHandler - This HTTP handler removes bucket website configuration.
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(w, r, "DeleteBucketWebsite", mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.DeleteBucketWebsiteAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if !globalIsGateway {
+		// Deleting a missing website configuration is not an error.
+		if err := removeWebsiteConfig(ctx, objAPI, bucket); err != nil {
+			if _, ok := err.(BucketWebsiteNotFound); !ok {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+
+	globalWebsiteSys.Remove(bucket)
+	globalNotificationSys.RemoveBucketWebsite(ctx, bucket)
+
+	// Success.
+	writeSuccessNoContent(w)
+}
+
+// getWebsiteRequest - returns the website configuration to serve
+// an anonymous request on bucket with, authenticated requests are
+// always served by the regular S3 API.
+func getWebsiteRequest(r *http.Request, bucket string) (website.Website, bool) {
+	if globalWebsiteSys == nil || getRequestAuthType(r) != authTypeAnonymous {
+		return website.Website{}, false
+	}
+	return globalWebsiteSys.Get(bucket)
+}
+
+// isWebsiteRootRequest - returns true for anonymous requests on the
+// root of a bucket hosting a website, which serve its index document
+// instead of listing the bucket.
+func isWebsiteRootRequest(r *http.Request, bucket string) bool {
+	if len(r.URL.Query()) > 0 {
+		return false
+	}
+	_, ok := getWebsiteRequest(r, bucket)
+	return ok
+}
+
+// websiteObjectRequest - returns r routed to object of bucket.
+func websiteObjectRequest(r *http.Request, bucket string) *http.Request {
+	return mux.SetURLVars(r, map[string]string{"bucket": bucket, "object": ""})
+}
+
+// isWebsiteObjectAllowed - checks if bucket policy allows anonymous
+// access to object.
+func isWebsiteObjectAllowed(r *http.Request, bucket, object string) bool {
+	return globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", ""),
+		IsOwner:         false,
+		ObjectName:      object,
+	})
+}
+
+// writeWebsiteRedirect - redirects the request for object of bucket.
+func writeWebsiteRedirect(w http.ResponseWriter, r *http.Request, object string, redirect website.Redirection) {
+	var location string
+	if redirect.HostName != "" {
+		protocol := redirect.Protocol
+		if protocol == "" {
+			protocol = getURLScheme(globalIsSSL)
+		}
+		location = protocol + "://" + redirect.HostName + s3utils.EncodePath(SlashSeparator+redirect.Key)
+	} else {
+		// Redirect on the same host keeping path or
+		// virtual host style of the request.
+		prefix := strings.TrimSuffix(r.URL.Path, object)
+		if !strings.HasSuffix(prefix, SlashSeparator) {
+			prefix += SlashSeparator
+		}
+		location = s3utils.EncodePath(prefix + redirect.Key)
+	}
+	w.Header().Set("Location", location)
+	w.WriteHeader(redirect.StatusCode)
+}
+
+// resolveWebsiteObject - resolves the object served for an anonymous
+// request on object of a bucket hosting a website. Requests on a
+// directory serve its index document, requests on a directory without
+// trailing slash are redirected to it. Returns true when the request
+// was already answered with a redirect or an error.
+func (api objectAPIHandlers) resolveWebsiteObject(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, config website.Website, bucket, object string) (string, bool) {
+	if redirect, ok := config.Redirect(object, 0); ok {
+		writeWebsiteRedirect(w, r, object, redirect)
+		return "", true
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	key := config.IndexKey(object)
+	if !isWebsiteObjectAllowed(r, bucket, key) {
+		api.writeWebsiteError(ctx, w, r, objectAPI, config, bucket, object, ErrAccessDenied)
+		return "", true
+	}
+
+	_, err := getObjectInfo(ctx, bucket, key, ObjectOptions{})
+	if err == nil {
+		return key, false
+	}
+	if toAPIError(ctx, err).Code != "NoSuchKey" {
+		if r.Method == http.MethodHead {
+			writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
+		} else {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		}
+		return "", true
+	}
+
+	// Pretty URLs, object is a directory holding an index document.
+	if key == object {
+		dirKey := config.IndexKey(object + SlashSeparator)
+		if isWebsiteObjectAllowed(r, bucket, dirKey) {
+			if _, err = getObjectInfo(ctx, bucket, dirKey, ObjectOptions{}); err == nil {
+				writeWebsiteRedirect(w, r, object, website.Redirection{
+					Key:        object + SlashSeparator,
+					StatusCode: http.StatusFound,
+				})
+				return "", true
+			}
+		}
+	}
+
+	api.writeWebsiteError(ctx, w, r, objectAPI, config, bucket, object, ErrNoSuchKey)
+	return "", true
+}
+
+// writeWebsiteError - answers an anonymous website request failing
+// with errCode, either redirecting it as per the routing rules or
+// serving the error document of the bucket.
+func (api objectAPIHandlers) writeWebsiteError(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, config website.Website, bucket, object string, errCode APIErrorCode) {
+	apiErr := errorCodes.ToAPIErr(errCode)
+	if redirect, ok := config.Redirect(object, apiErr.HTTPStatusCode); ok {
+		writeWebsiteRedirect(w, r, object, redirect)
+		return
+	}
+
+	writeErr := func() {
+		if r.Method == http.MethodHead {
+			writeErrorResponseHeadersOnly(w, apiErr)
+			return
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL, guessIsBrowserReq(r))
+	}
+
+	errorKey, ok := config.ErrorKey()
+	if !ok || !isWebsiteObjectAllowed(r, bucket, errorKey) {
+		writeErr()
+		return
+	}
+
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	if api.CacheAPI() != nil {
+		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	}
+
+	gr, err := getObjectNInfo(ctx, bucket, errorKey, nil, r.Header, readLock, ObjectOptions{})
+	if err != nil {
+		writeErr()
+		return
+	}
+	defer gr.Close()
+
+	if err = setObjectHeaders(w, globalBucketDefaultsSys.Apply(bucket, errorKey, gr.ObjInfo), nil); err != nil {
+		writeErr()
+		return
+	}
+	w.WriteHeader(apiErr.HTTPStatusCode)
+	if r.Method == http.MethodHead {
+		return
+	}
+	if _, err = io.Copy(w, gr); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
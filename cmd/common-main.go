@@ -19,16 +19,28 @@ package cmd
 import (
 	"crypto/x509"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	dns2 "github.com/miekg/dns"
 	"github.com/minio/cli"
 	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/api"
+	"github.com/minio/minio/cmd/config/bandwidth"
+	"github.com/minio/minio/cmd/config/directio"
 	"github.com/minio/minio/cmd/config/etcd"
+	"github.com/minio/minio/cmd/config/eventbus"
+	"github.com/minio/minio/cmd/config/placement"
+	"github.com/minio/minio/cmd/config/ratelimit"
+	"github.com/minio/minio/cmd/config/transform"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/certs"
@@ -173,6 +185,43 @@ func handleCommonEnvVars() {
 		globalActiveCred = cred
 	}
 
+	oldAccessKey := env.Get(config.EnvAccessKeyOld, "")
+	oldSecretKey := env.Get(config.EnvSecretKeyOld, "")
+	if oldAccessKey != "" || oldSecretKey != "" {
+		cred, err := auth.CreateCredentials(oldAccessKey, oldSecretKey)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidOldCredentials(err), "Unable to validate old credentials inherited from the shell environment")
+		}
+		if cred.AccessKey == globalActiveCred.AccessKey {
+			logger.Fatal(config.ErrInvalidOldCredentials(nil).Msg("Old access key must differ from the current access key"), "Unable to validate old credentials inherited from the shell environment")
+		}
+		expiry := defaultOldCredentialExpiry
+		if v := env.Get(config.EnvOldCredentialExpiry, ""); v != "" {
+			expiry, err = time.ParseDuration(v)
+			if err != nil || expiry <= 0 {
+				logger.Fatal(config.ErrInvalidOldCredentials(err).Msg("Invalid expiry `%s`", v), "Invalid MINIO_OLD_CREDENTIAL_EXPIRY value in environment variable")
+			}
+		}
+		cred.Expiration = UTCNow().Add(expiry)
+		logger.FatalIf(globalOldCredSys.Set(cred), "Unable to set old credentials")
+	}
+
+	if v := env.Get(config.EnvSignatureV2, "on"); v != "" {
+		enabled, err := config.ParseBoolFlag(v)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidSignatureV2Value(nil).Msg("Unknown value `%s`", v), "Invalid MINIO_SIGNATURE_V2 value in environment variable")
+		}
+		var allowedKeys []string
+		if keys := env.Get(config.EnvSignatureV2AllowedKeys, ""); keys != "" {
+			for _, key := range strings.Split(keys, config.ValueSeparator) {
+				if key = strings.TrimSpace(key); key != "" {
+					allowedKeys = append(allowedKeys, key)
+				}
+			}
+		}
+		globalSignatureV2Sys = NewSignatureV2Sys(bool(enabled), allowedKeys)
+	}
+
 	if browser := env.Get(config.EnvBrowser, "on"); browser != "" {
 		browserFlag, err := config.ParseBoolFlag(browser)
 		if err != nil {
@@ -191,14 +240,9 @@ func handleCommonEnvVars() {
 		logger.FatalIf(err, "Unable to initialize etcd config")
 	}
 
-	for _, domainName := range strings.Split(env.Get(config.EnvDomain, ""), ",") {
-		if domainName != "" {
-			if _, ok := dns2.IsDomainName(domainName); !ok {
-				logger.Fatal(config.ErrInvalidDomainValue(nil).Msg("Unknown value `%s`", domainName),
-					"Invalid MINIO_DOMAIN value in environment variable")
-			}
-			globalDomainNames = append(globalDomainNames, domainName)
-		}
+	globalDomainNames, err = parseDomainNames(env.Get(config.EnvDomain, ""))
+	if err != nil {
+		logger.Fatal(config.ErrInvalidDomainValue(err), "Invalid MINIO_DOMAIN value in environment variable")
 	}
 
 	minioEndpointsEnv, ok := env.Lookup(config.EnvPublicIPs)
@@ -228,7 +272,13 @@ func handleCommonEnvVars() {
 
 	if len(globalDomainNames) != 0 && !globalDomainIPs.IsEmpty() && globalEtcdClient != nil {
 		var err error
-		globalDNSConfig, err = dns.NewCoreDNS(globalDomainNames, globalDomainIPs, globalMinioPort, globalEtcdClient)
+		corednsPath := env.Get(etcd.EnvEtcdCoreDNSPath, "")
+		if corednsPath != "" && !strings.HasPrefix(corednsPath, "/") {
+			logger.Fatal(config.ErrInvalidCoreDNSPathValue(nil).Msg("Unknown value `%s`", corednsPath),
+				"Invalid MINIO_ETCD_COREDNS_PATH value in environment variable")
+		}
+		globalDNSConfig, err = dns.NewCoreDNS(globalDomainNames, globalDomainIPs, globalMinioPort, globalEtcdClient,
+			dns.CoreDNSPath(strings.TrimSuffix(corednsPath, "/")))
 		logger.FatalIf(err, "Unable to initialize DNS config for %s.", globalDomainNames)
 	}
 
@@ -237,6 +287,26 @@ func handleCommonEnvVars() {
 	// in-place update is off.
 	globalInplaceUpdateDisabled = strings.EqualFold(env.Get(config.EnvUpdate, "off"), "off")
 
+	if publicKeyFile := env.Get(config.EnvUpdatePublicKey, ""); publicKeyFile != "" {
+		pemBytes, err := ioutil.ReadFile(publicKeyFile)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidUpdatePublicKey(err), "Unable to read the update public key")
+		}
+		globalUpdatePublicKey, err = parseUpdatePublicKey(pemBytes)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidUpdatePublicKey(err), "Unable to parse the update public key")
+		}
+	}
+
+	// Get the reverse proxies trusted for the source IP of requests.
+	if proxies := env.Get(config.EnvTrustedProxies, ""); proxies != "" {
+		trustedProxies, err := parseTrustedProxies(proxies)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidTrustedProxiesValue(err).Msg("Invalid value `%s`", proxies), "Invalid MINIO_TRUSTED_PROXIES value in environment variable")
+		}
+		globalTrustedProxies = trustedProxies
+	}
+
 	// Get WORM environment variable.
 	if worm := env.Get(config.EnvWorm, "off"); worm != "" {
 		wormFlag, err := config.ParseBoolFlag(worm)
@@ -250,6 +320,140 @@ func handleCommonEnvVars() {
 		globalWORMEnabled = bool(wormFlag)
 	}
 
+	// Get the temporary directory used to stage uploads in FS mode.
+	if tmpDir := env.Get(config.EnvFSTmpDir, ""); tmpDir != "" {
+		if !filepath.IsAbs(tmpDir) {
+			logger.Fatal(config.ErrInvalidFSTmpDirValue(nil).Msg("`%s` is not an absolute path", tmpDir), "Invalid MINIO_FS_TMP_DIR value in environment variable")
+		}
+		globalFSTmpDir = filepath.Clean(tmpDir)
+	}
+
+	// Get the size below which objects are packed in FS mode.
+	if threshold := env.Get(config.EnvFSPackThreshold, ""); threshold != "" {
+		size, err := humanize.ParseBytes(threshold)
+		if err != nil || size == 0 || size > fsPackMaxThreshold {
+			logger.Fatal(config.ErrInvalidFSPackThresholdValue(err).Msg("Invalid size `%s`", threshold), "Invalid MINIO_FS_PACK_THRESHOLD value in environment variable")
+		}
+		globalFSPackThreshold = int64(size)
+	}
+
+	// Get the size below which objects keep their metadata inline in FS mode.
+	if threshold := env.Get(config.EnvFSInlineMetaThreshold, ""); threshold != "" {
+		size, err := humanize.ParseBytes(threshold)
+		if err != nil || size == 0 || size > fsInlineMetaMaxThreshold {
+			logger.Fatal(config.ErrInvalidFSInlineMetaThresholdValue(err).Msg("Invalid size `%s`", threshold), "Invalid MINIO_FS_INLINE_META_THRESHOLD value in environment variable")
+		}
+		globalFSInlineMetaThreshold = int64(size)
+	}
+
+	// Get whether legacy bucket directories are aliased in FS mode.
+	if aliases := env.Get(config.EnvFSBucketAliases, "off"); aliases != "" {
+		aliasesFlag, err := config.ParseBoolFlag(aliases)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidFSBucketAliasesValue(err).Msg("Unknown value `%s`", aliases), "Invalid MINIO_FS_BUCKET_ALIASES value in environment variable")
+		}
+		globalFSBucketAliases = bool(aliasesFlag)
+	}
+
+	// Get whether the entries of large directories are indexed in FS mode.
+	if listingCache := env.Get(config.EnvFSListingCache, "off"); listingCache != "" {
+		listingCacheFlag, err := config.ParseBoolFlag(listingCache)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidFSListingCacheValue(err).Msg("Unknown value `%s`", listingCache), "Invalid MINIO_FS_LISTING_CACHE value in environment variable")
+		}
+		globalFSListingCache = bool(listingCacheFlag)
+	}
+
+	// Get the number of erasure blocks read ahead by GETs in XL mode.
+	if readAhead := env.Get(config.EnvXLReadAhead, ""); readAhead != "" {
+		n, err := strconv.Atoi(readAhead)
+		if err != nil || n < 0 || n > xlMaxReadAhead {
+			logger.Fatal(config.ErrInvalidXLReadAheadValue(err).Msg("Invalid number of blocks `%s`", readAhead), "Invalid MINIO_XL_READ_AHEAD value in environment variable")
+		}
+		globalXLReadAhead = n
+	}
+
+	// Get the format of the metadata written in XL mode.
+	if format := env.Get(config.EnvXLMetaFormat, ""); format != "" {
+		if format != xlMetaFormatV1 && format != xlMetaFormatV2 {
+			logger.Fatal(config.ErrInvalidXLMetaFormatValue(nil).Msg("Unknown format `%s`", format), "Invalid MINIO_XL_META_FORMAT value in environment variable")
+		}
+		globalXLMetaFormat = format
+	}
+
+	// Get whether reads are served without bitrot verification
+	// when no parity shard of an object is left in XL mode.
+	if degraded := env.Get(config.EnvXLDegradedReads, ""); degraded != "" {
+		degradedFlag, err := config.ParseBoolFlag(degraded)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidXLDegradedReadsValue(nil).Msg("Unknown value `%s`", degraded), "Invalid MINIO_XL_DEGRADED_READS value in environment variable")
+		}
+		globalXLDegradedReads = bool(degradedFlag)
+	}
+
+	// Get the bytes per second read from each disk by the
+	// background scrub in XL mode.
+	if bandwidth := env.Get(config.EnvXLScrubBandwidth, ""); bandwidth != "" {
+		rate, err := humanize.ParseBytes(bandwidth)
+		if err != nil || rate == 0 {
+			logger.Fatal(config.ErrInvalidXLScrubBandwidthValue(err).Msg("Invalid rate `%s`", bandwidth), "Invalid MINIO_XL_SCRUB_BANDWIDTH value in environment variable")
+		}
+		globalXLScrubBandwidth = rate
+	}
+
+	// Get the address on which the admin API is served over gRPC.
+	if addr := env.Get(config.EnvAdminGRPCAddress, ""); addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			logger.Fatal(config.ErrInvalidAdminGRPCAddress(err), "Invalid MINIO_ADMIN_GRPC_ADDRESS value in environment variable")
+		}
+		globalAdminGRPCAddr = addr
+	}
+
+	// Get the address on which buckets and objects are served over SFTP.
+	if addr := env.Get(config.EnvSFTPAddress, ""); addr != "" {
+		if _, _, err := net.SplitHostPort(addr); err != nil {
+			logger.Fatal(config.ErrInvalidSFTPAddress(err), "Invalid MINIO_SFTP_ADDRESS value in environment variable")
+		}
+		globalSFTPAddr = addr
+	}
+
+	rateLimitCfg, err := ratelimit.LookupConfig(ratelimit.Config{})
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_RATELIMIT value in environment variable")
+	}
+	globalAPIRateLimiter = newAPIRateLimiter(rateLimitCfg)
+
+	apiCfg, err := api.LookupConfig(api.Config{})
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_API value in environment variable")
+	}
+	globalAPIRequestsPool = newRequestsPool(apiCfg)
+
+	bandwidthCfg, err := bandwidth.LookupConfig()
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_BUCKET_BANDWIDTH value in environment variable")
+	}
+	globalBucketBandwidth = newBucketBandwidth(bandwidthCfg)
+
+	globalDirectIO, err = directio.LookupConfig()
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_DIRECTIO value in environment variable")
+	}
+
+	globalXLPlacement, err = placement.LookupConfig()
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_XL_PLACEMENT value in environment variable")
+	}
+
+	globalEventBusConfig, err = eventbus.LookupConfig(eventbus.Config{})
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_EVENT_BUS_NATS value in environment variable")
+	}
+
+	globalTransformConfig, err = transform.LookupConfig(transform.Config{})
+	if err != nil {
+		logger.Fatal(err, "Invalid MINIO_TRANSFORM value in environment variable")
+	}
 }
 
 func logStartupMessage(msg string, data ...interface{}) {
@@ -259,6 +463,60 @@ func logStartupMessage(msg string, data ...interface{}) {
 	logger.StartupMessage(msg, data...)
 }
 
+// parseDomainNames - parses comma separated domain names, duplicates
+// are removed and the longest domains are returned first such that
+// the most specific domain is matched first for virtual-host-style
+// requests, e.g. bucket.s3.example.com matches s3.example.com before
+// example.com.
+func parseDomainNames(value string) ([]string, error) {
+	var domainNames []string
+	seen := set.NewStringSet()
+	for _, domainName := range strings.Split(value, ",") {
+		domainName = strings.TrimSpace(domainName)
+		if domainName == "" || seen.Contains(domainName) {
+			continue
+		}
+		if _, ok := dns2.IsDomainName(domainName); !ok {
+			return nil, fmt.Errorf("Unknown value `%s`", domainName)
+		}
+		seen.Add(domainName)
+		domainNames = append(domainNames, domainName)
+	}
+	sort.SliceStable(domainNames, func(i, j int) bool {
+		return len(domainNames[i]) > len(domainNames[j])
+	})
+	return domainNames, nil
+}
+
+// addDomainCerts - adds the certificates found in sub-directories of
+// the certs directory, e.g. certs/example.com/public.crt, they are
+// served to clients requesting one of their DNS names using SNI.
+func addDomainCerts(c *certs.Certs) (x509Certs []*x509.Certificate, err error) {
+	entries, err := ioutil.ReadDir(globalCertsDir.Get())
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || entry.Name() == certsCADir {
+			continue
+		}
+		certFile := filepath.Join(globalCertsDir.Get(), entry.Name(), publicCertFile)
+		keyFile := filepath.Join(globalCertsDir.Get(), entry.Name(), privateKeyFile)
+		if !(isFile(certFile) && isFile(keyFile)) {
+			continue
+		}
+		domainCerts, err := config.ParsePublicCertFile(certFile)
+		if err != nil {
+			return nil, err
+		}
+		if err = c.AddCertificate(certFile, keyFile); err != nil {
+			return nil, fmt.Errorf("Unable to load the certificate for %s: %w", entry.Name(), err)
+		}
+		x509Certs = append(x509Certs, domainCerts...)
+	}
+	return x509Certs, nil
+}
+
 func getTLSConfig() (x509Certs []*x509.Certificate, c *certs.Certs, secureConn bool, err error) {
 	if !(isFile(getPublicCertFile()) && isFile(getPrivateKeyFile())) {
 		return nil, nil, false, nil
@@ -273,6 +531,13 @@ func getTLSConfig() (x509Certs []*x509.Certificate, c *certs.Certs, secureConn b
 		return nil, nil, false, err
 	}
 
+	domainCerts, err := addDomainCerts(c)
+	if err != nil {
+		c.Stop()
+		return nil, nil, false, err
+	}
+	x509Certs = append(x509Certs, domainCerts...)
+
 	secureConn = true
 	return x509Certs, c, secureConn, nil
 }
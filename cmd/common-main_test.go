@@ -0,0 +1,45 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDomainNames(t *testing.T) {
+	testCases := []struct {
+		value               string
+		expectedDomainNames []string
+		expectedErr         bool
+	}{
+		{"", nil, false},
+		{"mydomain.com", []string{"mydomain.com"}, false},
+		{"mydomain.com, s3.mydomain.com,", []string{"s3.mydomain.com", "mydomain.com"}, false},
+		{"mydomain.com,mydomain.org,mydomain.com", []string{"mydomain.com", "mydomain.org"}, false},
+		{"mydomain.com,my..domain.com", nil, true},
+	}
+	for i, testCase := range testCases {
+		domainNames, err := parseDomainNames(testCase.value)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if !reflect.DeepEqual(domainNames, testCase.expectedDomainNames) {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expectedDomainNames, domainNames)
+		}
+	}
+}
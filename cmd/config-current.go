@@ -175,6 +175,12 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	for _, v := range s.Notify.AMQP1 {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("amqp1: %s", err)
+		}
+	}
+
 	for _, v := range s.Notify.Elasticsearch {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("elasticsearch: %s", err)
@@ -217,12 +223,30 @@ func (s *serverConfig) Validate() error {
 		}
 	}
 
+	for _, v := range s.Notify.PubSub {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("pubsub: %s", err)
+		}
+	}
+
 	for _, v := range s.Notify.Redis {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("redis: %s", err)
 		}
 	}
 
+	for _, v := range s.Notify.SNS {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("sns: %s", err)
+		}
+	}
+
+	for _, v := range s.Notify.SQS {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("sqs: %s", err)
+		}
+	}
+
 	for _, v := range s.Notify.Webhook {
 		if err := v.Validate(); err != nil {
 			return fmt.Errorf("webhook: %s", err)
@@ -325,6 +349,20 @@ func (s *serverConfig) lookupConfigs() {
 		logger.FatalIf(err, "Unable to parse LDAP configuration from env")
 	}
 
+	// Gateways other than NAS have no config.json, the environment is
+	// the only way to give the events of their backends a target. Like
+	// the other subsystems above, it overrides config.json on servers.
+	s.Notify, err = notify.LookupConfig(s.Notify)
+	if err != nil {
+		logger.FatalIf(err, "Unable to parse notification targets from env")
+	}
+
+	globalNotifyEventMetadata, err = notify.LookupEventMetadata()
+	if err != nil {
+		logger.FatalIf(err, "Unable to parse notification event metadata from env")
+	}
+	globalNotifyDeadLetterDir = env.Get(notify.EnvDeadLetterDir, "")
+
 	// Load logger targets based on user's configuration
 	loggerUserAgent := getUserAgent(getMinioMode())
 
@@ -354,6 +392,124 @@ func (s *serverConfig) lookupConfigs() {
 
 }
 
+// newTestNotificationTarget - creates the enabled notification target
+// identified by targetID without its queue store, saving an event to
+// it reports whether the event was delivered. It returns nil if no
+// such target is configured.
+func (s *serverConfig) newTestNotificationTarget(targetID event.TargetID, doneCh <-chan struct{}) (event.Target, error) {
+	switch targetID.Name {
+	case "amqp":
+		args, ok := s.Notify.AMQP[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewAMQPTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "amqp1":
+		args, ok := s.Notify.AMQP1[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		args.TLS.RootCAs = globalRootCAs
+		return target.NewAMQP1Target(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "elasticsearch":
+		args, ok := s.Notify.Elasticsearch[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		args.RootCAs = globalRootCAs
+		return target.NewElasticsearchTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "kafka":
+		args, ok := s.Notify.Kafka[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		if args.TLS.Enable {
+			args.TLS.RootCAs = globalRootCAs
+		}
+		return target.NewKafkaTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "mqtt":
+		args, ok := s.Notify.MQTT[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		args.RootCAs = globalRootCAs
+		// Do not take over the session of the running target.
+		args.ClientID = ""
+		args.PersistentSession = false
+		return target.NewMQTTTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "mysql":
+		args, ok := s.Notify.MySQL[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewMySQLTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "nats":
+		args, ok := s.Notify.NATS[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewNATSTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "nsq":
+		args, ok := s.Notify.NSQ[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewNSQTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "postgresql":
+		args, ok := s.Notify.PostgreSQL[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewPostgreSQLTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "pubsub":
+		args, ok := s.Notify.PubSub[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewPubSubTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "redis":
+		args, ok := s.Notify.Redis[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewRedisTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "sns":
+		args, ok := s.Notify.SNS[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewSNSTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "sqs":
+		args, ok := s.Notify.SQS[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		return target.NewSQSTarget(targetID.ID, args, doneCh, logger.LogOnceIf)
+	case "webhook":
+		args, ok := s.Notify.Webhook[targetID.ID]
+		if !ok || !args.Enable {
+			return nil, nil
+		}
+		args.QueueDir = ""
+		args.RootCAs = globalRootCAs
+		return target.NewWebhookTarget(targetID.ID, args, doneCh, logger.LogOnceIf), nil
+	}
+	return nil, nil
+}
+
 // TestNotificationTargets tries to establish connections to all notification
 // targets when enabled. This is a good way to make sure all configurations
 // set by the user can work.
@@ -369,10 +525,23 @@ func (s *serverConfig) TestNotificationTargets() error {
 		t.Close()
 	}
 
+	for k, v := range s.Notify.AMQP1 {
+		if !v.Enable {
+			continue
+		}
+		v.TLS.RootCAs = globalRootCAs
+		t, err := target.NewAMQP1Target(k, v, GlobalServiceDoneCh, logger.LogOnceIf)
+		if err != nil {
+			return fmt.Errorf("amqp1(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
 	for k, v := range s.Notify.Elasticsearch {
 		if !v.Enable {
 			continue
 		}
+		v.RootCAs = globalRootCAs
 		t, err := target.NewElasticsearchTarget(k, v, GlobalServiceDoneCh, logger.LogOnceIf)
 		if err != nil {
 			return fmt.Errorf("elasticsearch(%s): %s", k, err.Error())
@@ -450,6 +619,17 @@ func (s *serverConfig) TestNotificationTargets() error {
 		t.Close()
 	}
 
+	for k, v := range s.Notify.PubSub {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewPubSubTarget(k, v, GlobalServiceDoneCh, logger.LogOnceIf)
+		if err != nil {
+			return fmt.Errorf("pubsub(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
 	for k, v := range s.Notify.Redis {
 		if !v.Enable {
 			continue
@@ -462,6 +642,28 @@ func (s *serverConfig) TestNotificationTargets() error {
 
 	}
 
+	for k, v := range s.Notify.SNS {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewSNSTarget(k, v, GlobalServiceDoneCh, logger.LogOnceIf)
+		if err != nil {
+			return fmt.Errorf("sns(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
+	for k, v := range s.Notify.SQS {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewSQSTarget(k, v, GlobalServiceDoneCh, logger.LogOnceIf)
+		if err != nil {
+			return fmt.Errorf("sqs(%s): %s", k, err.Error())
+		}
+		t.Close()
+	}
+
 	return nil
 }
 
@@ -581,8 +783,24 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		}
 	}
 
+	for id, args := range config.Notify.AMQP1 {
+		if args.Enable {
+			args.TLS.RootCAs = globalRootCAs
+			newTarget, err := target.NewAMQP1Target(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
 	for id, args := range config.Notify.Elasticsearch {
 		if args.Enable {
+			args.RootCAs = globalRootCAs
 			newTarget, err := target.NewElasticsearchTarget(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
 			if err != nil {
 				logger.LogIf(context.Background(), err)
@@ -685,6 +903,20 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		}
 	}
 
+	for id, args := range config.Notify.PubSub {
+		if args.Enable {
+			newTarget, err := target.NewPubSubTarget(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
 	for id, args := range config.Notify.Redis {
 		if args.Enable {
 			newTarget, err := target.NewRedisTarget(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
@@ -699,6 +931,34 @@ func getNotificationTargets(config *serverConfig) *event.TargetList {
 		}
 	}
 
+	for id, args := range config.Notify.SNS {
+		if args.Enable {
+			newTarget, err := target.NewSNSTarget(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
+	for id, args := range config.Notify.SQS {
+		if args.Enable {
+			newTarget, err := target.NewSQSTarget(id, args, GlobalServiceDoneCh, logger.LogOnceIf)
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+			if err = targetList.Add(newTarget); err != nil {
+				logger.LogIf(context.Background(), err)
+				continue
+			}
+		}
+	}
+
 	for id, args := range config.Notify.Webhook {
 		if args.Enable {
 			args.RootCAs = globalRootCAs
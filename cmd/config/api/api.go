@@ -0,0 +1,68 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package api
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// API environment variables
+const (
+	EnvAPIRequestsMax      = "MINIO_API_REQUESTS_MAX"
+	EnvAPIRequestsDeadline = "MINIO_API_REQUESTS_DEADLINE"
+)
+
+// DefaultRequestsDeadline - default duration a request waits
+// for a free slot before it is rejected.
+const DefaultRequestsDeadline = 10 * time.Second
+
+// Config represents the S3 API concurrency settings.
+type Config struct {
+	// RequestsMax - maximum number of S3 API requests served
+	// concurrently, 0 means unlimited.
+	RequestsMax int `json:"requests_max"`
+	// RequestsDeadline - maximum duration a request waits
+	// for a free slot.
+	RequestsDeadline time.Duration `json:"requests_deadline"`
+}
+
+// LookupConfig - lookup API config.
+func LookupConfig(cfg Config) (Config, error) {
+	if cfg.RequestsDeadline == 0 {
+		cfg.RequestsDeadline = DefaultRequestsDeadline
+	}
+
+	requestsMax := env.Get(EnvAPIRequestsMax, strconv.Itoa(cfg.RequestsMax))
+	max, err := strconv.Atoi(requestsMax)
+	if err != nil || max < 0 {
+		return cfg, config.ErrInvalidAPIRequestsMaxValue(err).Msg("Unknown value `%s`", requestsMax)
+	}
+	cfg.RequestsMax = max
+
+	requestsDeadline := env.Get(EnvAPIRequestsDeadline, cfg.RequestsDeadline.String())
+	deadline, err := time.ParseDuration(requestsDeadline)
+	if err != nil || deadline <= 0 {
+		return cfg, config.ErrInvalidAPIRequestsDeadlineValue(err).Msg("Unknown value `%s`", requestsDeadline)
+	}
+	cfg.RequestsDeadline = deadline
+
+	return cfg, nil
+}
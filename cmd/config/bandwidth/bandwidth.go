@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bandwidth
+
+import (
+	"strings"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Bucket bandwidth environment variables
+const (
+	EnvBucketBandwidthIngress = "MINIO_BUCKET_BANDWIDTH_INGRESS"
+	EnvBucketBandwidthEgress  = "MINIO_BUCKET_BANDWIDTH_EGRESS"
+)
+
+// Config represents the per bucket bandwidth limits in bytes
+// per second, buckets without an entry are not limited.
+type Config struct {
+	// Ingress - limits on data uploaded to a bucket.
+	Ingress map[string]uint64 `json:"ingress"`
+	// Egress - limits on data downloaded from a bucket.
+	Egress map[string]uint64 `json:"egress"`
+}
+
+// parseLimits - parses `bucket=rate` pairs such as
+// `backup=10MiB,logs=512KiB`.
+func parseLimits(envName, value string) (map[string]uint64, error) {
+	limits := make(map[string]uint64)
+	for _, pair := range strings.Split(value, config.ValueSeparator) {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 || kv[0] == "" {
+			return nil, config.ErrInvalidBucketBandwidthValue(nil).Msg("%s: invalid entry `%s`", envName, pair)
+		}
+		rate, err := humanize.ParseBytes(kv[1])
+		if err != nil || rate == 0 {
+			return nil, config.ErrInvalidBucketBandwidthValue(err).Msg("%s: invalid rate `%s` for bucket %s", envName, kv[1], kv[0])
+		}
+		limits[kv[0]] = rate
+	}
+	return limits, nil
+}
+
+// LookupConfig - lookup bucket bandwidth config.
+func LookupConfig() (cfg Config, err error) {
+	if cfg.Ingress, err = parseLimits(EnvBucketBandwidthIngress, env.Get(EnvBucketBandwidthIngress, "")); err != nil {
+		return cfg, err
+	}
+	if cfg.Egress, err = parseLimits(EnvBucketBandwidthEgress, env.Get(EnvBucketBandwidthEgress, "")); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
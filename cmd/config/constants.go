@@ -28,8 +28,33 @@ const (
 	EnvBrowser   = "MINIO_BROWSER"
 	EnvDomain    = "MINIO_DOMAIN"
 	EnvPublicIPs = "MINIO_PUBLIC_IPS"
+
+	EnvTrustedProxies = "MINIO_TRUSTED_PROXIES"
 	EnvEndpoints = "MINIO_ENDPOINTS"
 
-	EnvUpdate = "MINIO_UPDATE"
-	EnvWorm   = "MINIO_WORM"
+	EnvSignatureV2            = "MINIO_SIGNATURE_V2"
+	EnvSignatureV2AllowedKeys = "MINIO_SIGNATURE_V2_ALLOWED_KEYS"
+
+	EnvAccessKeyOld        = "MINIO_ACCESS_KEY_OLD"
+	EnvSecretKeyOld        = "MINIO_SECRET_KEY_OLD"
+	EnvOldCredentialExpiry = "MINIO_OLD_CREDENTIAL_EXPIRY"
+
+	EnvUpdate          = "MINIO_UPDATE"
+	EnvUpdatePublicKey = "MINIO_UPDATE_PUBLIC_KEY"
+	EnvWorm            = "MINIO_WORM"
+
+	EnvFSTmpDir              = "MINIO_FS_TMP_DIR"
+	EnvFSPackThreshold       = "MINIO_FS_PACK_THRESHOLD"
+	EnvFSInlineMetaThreshold = "MINIO_FS_INLINE_META_THRESHOLD"
+	EnvFSBucketAliases       = "MINIO_FS_BUCKET_ALIASES"
+	EnvFSListingCache        = "MINIO_FS_LISTING_CACHE"
+
+	EnvXLReadAhead      = "MINIO_XL_READ_AHEAD"
+	EnvXLMetaFormat     = "MINIO_XL_META_FORMAT"
+	EnvXLDegradedReads  = "MINIO_XL_DEGRADED_READS"
+	EnvXLScrubBandwidth = "MINIO_XL_SCRUB_BANDWIDTH"
+
+	EnvAdminGRPCAddress = "MINIO_ADMIN_GRPC_ADDRESS"
+
+	EnvSFTPAddress = "MINIO_SFTP_ADDRESS"
 )
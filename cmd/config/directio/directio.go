@@ -0,0 +1,59 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package directio
+
+import (
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Direct I/O environment variables
+const (
+	EnvDirectIOThreshold = "MINIO_DIRECTIO_THRESHOLD"
+	EnvDirectIODropCache = "MINIO_DIRECTIO_DROP_CACHE"
+)
+
+// Config represents the hints used to keep large sequential
+// I/O from evicting the rest of the page cache.
+type Config struct {
+	// Threshold - objects of at least this many bytes are written
+	// with O_DIRECT in FS mode, zero disables direct writes.
+	Threshold int64 `json:"threshold"`
+	// DropCache - drop the cached pages of an object once it
+	// has been read.
+	DropCache bool `json:"dropCache"`
+}
+
+// LookupConfig - lookup direct I/O config.
+func LookupConfig() (cfg Config, err error) {
+	if v := env.Get(EnvDirectIOThreshold, ""); v != "" {
+		threshold, err := humanize.ParseBytes(v)
+		if err != nil || threshold == 0 {
+			return cfg, config.ErrInvalidDirectIOValue(err).Msg("%s: invalid size `%s`", EnvDirectIOThreshold, v)
+		}
+		cfg.Threshold = int64(threshold)
+	}
+	if v := env.Get(EnvDirectIODropCache, "off"); v != "" {
+		dropCache, err := config.ParseBoolFlag(v)
+		if err != nil {
+			return cfg, config.ErrInvalidDirectIOValue(err).Msg("%s: unknown value `%s`", EnvDirectIODropCache, v)
+		}
+		cfg.DropCache = bool(dropCache)
+	}
+	return cfg, nil
+}
@@ -24,6 +24,18 @@ var (
 		"For more details, refer to https://docs.min.io/docs/minio-server-configuration-guide",
 	)
 
+	ErrInvalidOldCredentials = newErrFn(
+		"Invalid old credentials",
+		"Please provide a valid old access key and secret key which differ from the current credentials",
+		"MINIO_ACCESS_KEY_OLD and MINIO_SECRET_KEY_OLD should be set to the previous root credentials, MINIO_OLD_CREDENTIAL_EXPIRY to a positive duration like `24h`",
+	)
+
+	ErrInvalidSignatureV2Value = newErrFn(
+		"Invalid signature V2 value",
+		"Please check the passed value",
+		"Signature V2 can only accept `on` and `off` values. To reject signature V2 requests, set this value to `off`",
+	)
+
 	ErrInvalidBrowserValue = newErrFn(
 		"Invalid browser value",
 		"Please check the passed value",
@@ -36,18 +48,108 @@ var (
 		"Domain can only accept DNS compatible values",
 	)
 
+	ErrInvalidCoreDNSPathValue = newErrFn(
+		"Invalid CoreDNS path value",
+		"Please check the passed value",
+		"MINIO_ETCD_COREDNS_PATH should be an absolute etcd path, for example '/skydns'",
+	)
+
 	ErrInvalidErasureSetSize = newErrFn(
 		"Invalid erasure set size",
 		"Please check the passed value",
 		"Erasure set can only accept any of [4, 6, 8, 10, 12, 14, 16] values",
 	)
 
+	ErrInvalidUpdatePublicKey = newErrFn(
+		"Invalid update public key",
+		"Please check the passed value",
+		"MINIO_UPDATE_PUBLIC_KEY should be the path of a PEM encoded ECDSA or RSA public key",
+	)
+
+	ErrInvalidTrustedProxiesValue = newErrFn(
+		"Invalid trusted proxies value",
+		"Please check the passed value",
+		"MINIO_TRUSTED_PROXIES should be a comma separated list of IP addresses or CIDR networks, e.g. `10.0.0.1,192.168.0.0/16`",
+	)
+
 	ErrInvalidWormValue = newErrFn(
 		"Invalid WORM value",
 		"Please check the passed value",
 		"WORM can only accept `on` and `off` values. To enable WORM, set this value to `on`",
 	)
 
+	ErrInvalidFSTmpDirValue = newErrFn(
+		"Invalid FS temporary directory value",
+		"Please check the passed value",
+		"MINIO_FS_TMP_DIR should be an absolute path to a directory writable by MinIO",
+	)
+
+	ErrInvalidFSPackThresholdValue = newErrFn(
+		"Invalid FS pack threshold value",
+		"Please check the passed value",
+		"MINIO_FS_PACK_THRESHOLD should be an object size of at most 1MiB, e.g. `64KiB`",
+	)
+
+	ErrInvalidFSInlineMetaThresholdValue = newErrFn(
+		"Invalid FS inline metadata threshold value",
+		"Please check the passed value",
+		"MINIO_FS_INLINE_META_THRESHOLD should be an object size of at most 1MiB, e.g. `128KiB`",
+	)
+
+	ErrInvalidFSBucketAliasesValue = newErrFn(
+		"Invalid FS bucket aliases value",
+		"Please check the passed value",
+		"MINIO_FS_BUCKET_ALIASES can only accept `on` and `off` values. To serve legacy bucket directories under lowercase aliases, set this value to `on`",
+	)
+
+	ErrInvalidFSListingCacheValue = newErrFn(
+		"Invalid FS listing cache value",
+		"Please check the passed value",
+		"MINIO_FS_LISTING_CACHE can only accept `on` and `off` values. To index the entries of large directories, set this value to `on`",
+	)
+
+	ErrInvalidXLReadAheadValue = newErrFn(
+		"Invalid XL read-ahead value",
+		"Please check the passed value",
+		"MINIO_XL_READ_AHEAD should be a number of erasure blocks between 0 and 16, e.g. `2`",
+	)
+
+	ErrInvalidXLMetaFormatValue = newErrFn(
+		"Invalid XL metadata format value",
+		"Please check the passed value",
+		"MINIO_XL_META_FORMAT can only accept `v1` (JSON) and `v2` (msgpack) values",
+	)
+
+	ErrInvalidXLDegradedReadsValue = newErrFn(
+		"Invalid XL degraded reads value",
+		"Please check the passed value",
+		"MINIO_XL_DEGRADED_READS can only accept `on` and `off` values. To serve reads without bitrot verification when no parity is left, set this value to `on`",
+	)
+
+	ErrInvalidXLScrubBandwidthValue = newErrFn(
+		"Invalid XL scrub bandwidth value",
+		"Please check the passed value",
+		"MINIO_XL_SCRUB_BANDWIDTH should be a number of bytes read per second from each disk, e.g. `10MiB`",
+	)
+
+	ErrInvalidXLPlacementValue = newErrFn(
+		"Invalid XL placement value",
+		"Please check the passed value",
+		"MINIO_XL_PLACEMENT: Placement rules are `bucket/prefix=set` pairs delimited by `,`, sets are numbered from 1, e.g. `finance/eu/=1`",
+	)
+
+	ErrXLPlacementMismatch = newErrFn(
+		"XL placement rules differ between servers",
+		"Please set the same MINIO_XL_PLACEMENT value on all servers",
+		"MINIO_XL_PLACEMENT: All servers must place objects on the same sets",
+	)
+
+	ErrXLPlacementMoved = newErrFn(
+		"XL placement rules move existing objects",
+		"Please restore the previous MINIO_XL_PLACEMENT value",
+		"MINIO_XL_PLACEMENT: Rules of prefixes holding objects cannot be removed or pinned to another set",
+	)
+
 	ErrInvalidCacheDrivesValue = newErrFn(
 		"Invalid cache drive value",
 		"Please check the value in this ENV variable",
@@ -114,6 +216,7 @@ Secret key should be in between 8 and 40 characters`,
 		"Please check the value",
 		`MINIO_STORAGE_CLASS_STANDARD: Format "EC:<Default_Parity_Standard_Class>" (e.g. "EC:3"). This sets the number of parity disks for MinIO server in Standard mode. Objects are stored in Standard mode, if storage class is not defined in Put request
 MINIO_STORAGE_CLASS_RRS: Format "EC:<Default_Parity_Reduced_Redundancy_Class>" (e.g. "EC:3"). This sets the number of parity disks for MinIO server in Reduced Redundancy mode. Objects are stored in Reduced Redundancy mode, if Put request specifies RRS storage class
+MINIO_STORAGE_CLASS_STANDARD_BLOCK_SIZE, MINIO_STORAGE_CLASS_RRS_BLOCK_SIZE: Erasure block size of new objects of the storage class between 64KiB and 10MiB (e.g. "1MiB")
 Refer to the link https://github.com/minio/minio/tree/master/docs/erasure/storage-class for more information`,
 	)
 
@@ -229,4 +332,82 @@ Example 1:
 		"",
 		"Refer to https://docs.min.io/docs/minio-kms-quickstart-guide.html for setting up SSE",
 	)
+
+	ErrInvalidRateLimitValue = newErrFn(
+		"Invalid rate limit value",
+		"Please check the passed value",
+		"MINIO_RATELIMIT_*: Rate limits are expressed in requests per second, 0 disables the limit",
+	)
+
+	ErrInvalidAPIRequestsMaxValue = newErrFn(
+		"Invalid API requests max value",
+		"Please check the passed value",
+		"MINIO_API_REQUESTS_MAX: Maximum number of concurrent S3 API requests, 0 disables the limit",
+	)
+
+	ErrInvalidAPIRequestsDeadlineValue = newErrFn(
+		"Invalid API requests deadline value",
+		"Please check the passed value",
+		"MINIO_API_REQUESTS_DEADLINE: Duration to wait for a free request slot, e.g. `10s`",
+	)
+
+	ErrInvalidBucketBandwidthValue = newErrFn(
+		"Invalid bucket bandwidth value",
+		"Please check the passed value",
+		"MINIO_BUCKET_BANDWIDTH_*: Bandwidth limits are `bucket=rate` pairs delimited by `,`, rate is in bytes per second, e.g. `backup=10MiB`",
+	)
+
+	ErrInvalidDirectIOValue = newErrFn(
+		"Invalid direct I/O value",
+		"Please check the passed value",
+		"MINIO_DIRECTIO_THRESHOLD should be a size such as `64MiB`, MINIO_DIRECTIO_DROP_CACHE can only accept `on` and `off` values",
+	)
+
+	ErrInvalidMemoryValue = newErrFn(
+		"Invalid memory size",
+		"Please check the passed value",
+		"--memory: Maximum size of objects held in memory, e.g. `4GiB`, 0 disables the limit",
+	)
+
+	ErrMemoryWithEndpoints = newErrFn(
+		"Endpoints cannot be used in memory mode",
+		"Please remove the DIR arguments or the --memory flag",
+		"--memory: Objects are held in memory only, no directories are required",
+	)
+
+	ErrInvalidNotifyWebhookValue = newErrFn(
+		"Invalid webhook notification target value",
+		"Please check the passed value",
+		"MINIO_NOTIFY_WEBHOOK_*: Set MINIO_NOTIFY_WEBHOOK_ENABLE to `on` and MINIO_NOTIFY_WEBHOOK_ENDPOINT to the URL receiving the events",
+	)
+
+	ErrInvalidNotifyEventMetadataValue = newErrFn(
+		"Invalid notification event metadata value",
+		"Please check the passed value",
+		"MINIO_NOTIFY_EVENT_METADATA: Set to `on`, `off` or a comma separated list of metadata keys",
+	)
+
+	ErrInvalidEventBusValue = newErrFn(
+		"Invalid event bus value",
+		"Please check the passed value",
+		"MINIO_EVENT_BUS_NATS_*: NATS server `host:port` and credentials used to exchange bucket events between nodes",
+	)
+
+	ErrInvalidTransformValue = newErrFn(
+		"Invalid image transform value",
+		"Please check the passed value",
+		"MINIO_TRANSFORM: Set to `on` or `off`, MINIO_TRANSFORM_ENDPOINT: URL of an external transform service",
+	)
+
+	ErrInvalidAdminGRPCAddress = newErrFn(
+		"Invalid admin gRPC address",
+		"Please check the passed value",
+		"MINIO_ADMIN_GRPC_ADDRESS: `host:port` on which the admin API is served over gRPC, e.g. `:9001`",
+	)
+
+	ErrInvalidSFTPAddress = newErrFn(
+		"Invalid SFTP address",
+		"Please check the passed value",
+		"MINIO_SFTP_ADDRESS: `host:port` on which buckets and objects are served over SFTP, e.g. `:8022`",
+	)
 )
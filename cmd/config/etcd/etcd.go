@@ -39,6 +39,7 @@ const (
 	EnvEtcdEndpoints     = "MINIO_ETCD_ENDPOINTS"
 	EnvEtcdClientCert    = "MINIO_ETCD_CLIENT_CERT"
 	EnvEtcdClientCertKey = "MINIO_ETCD_CLIENT_CERT_KEY"
+	EnvEtcdCoreDNSPath   = "MINIO_ETCD_COREDNS_PATH"
 )
 
 // New - Initialize new etcd client
@@ -0,0 +1,87 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package eventbus
+
+import (
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// Config represents the NATS server used as the internal event
+// bus between the nodes of a distributed setup.
+type Config struct {
+	// Address - host:port of the NATS server, empty disables the bus.
+	Address string `json:"address"`
+	// Subject - subject on which the nodes exchange events.
+	Subject string `json:"subject"`
+	// Username, Password and Token - NATS credentials.
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Token    string `json:"token"`
+	// Secure - connect to the NATS server using TLS.
+	Secure bool `json:"secure"`
+}
+
+// Event bus environment variables
+const (
+	EnvEventBusAddress  = "MINIO_EVENT_BUS_NATS_ADDRESS"
+	EnvEventBusSubject  = "MINIO_EVENT_BUS_NATS_SUBJECT"
+	EnvEventBusUsername = "MINIO_EVENT_BUS_NATS_USERNAME"
+	EnvEventBusPassword = "MINIO_EVENT_BUS_NATS_PASSWORD"
+	EnvEventBusToken    = "MINIO_EVENT_BUS_NATS_TOKEN"
+	EnvEventBusSecure   = "MINIO_EVENT_BUS_NATS_SECURE"
+)
+
+// DefaultSubject - subject used when none is configured.
+const DefaultSubject = "minio.internal.events"
+
+// Enabled - returns true if a NATS server is configured.
+func (cfg Config) Enabled() bool {
+	return cfg.Address != ""
+}
+
+// LookupConfig - lookup event bus config.
+func LookupConfig(cfg Config) (Config, error) {
+	cfg.Address = env.Get(EnvEventBusAddress, cfg.Address)
+	if cfg.Address != "" {
+		if _, err := xnet.ParseHost(cfg.Address); err != nil {
+			return cfg, config.ErrInvalidEventBusValue(err).Msg("%s: unable to parse `%s`", EnvEventBusAddress, cfg.Address)
+		}
+	}
+
+	if cfg.Subject == "" {
+		cfg.Subject = DefaultSubject
+	}
+	cfg.Subject = env.Get(EnvEventBusSubject, cfg.Subject)
+	if cfg.Subject == "" {
+		return cfg, config.ErrInvalidEventBusValue(nil).Msg("%s: subject cannot be empty", EnvEventBusSubject)
+	}
+
+	cfg.Username = env.Get(EnvEventBusUsername, cfg.Username)
+	cfg.Password = env.Get(EnvEventBusPassword, cfg.Password)
+	cfg.Token = env.Get(EnvEventBusToken, cfg.Token)
+
+	if v := env.Get(EnvEventBusSecure, ""); v != "" {
+		secure, err := config.ParseBoolFlag(v)
+		if err != nil {
+			return cfg, config.ErrInvalidEventBusValue(err).Msg("%s: unknown value `%s`", EnvEventBusSecure, v)
+		}
+		cfg.Secure = bool(secure)
+	}
+	return cfg, nil
+}
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
+	"strings"
 	"time"
 
 	"github.com/minio/minio/pkg/env"
@@ -199,3 +200,24 @@ func (s *Substituter) Substitute(t string) (string, error) {
 	}
 	return t, nil
 }
+
+// EscapeDN escapes the special characters of an attribute value, so
+// that a user provided value cannot change the structure of a DN when
+// it is substituted in the username format or the group search base DN
+// (see RFC 4514, section 2.4).
+func EscapeDN(value string) string {
+	var b strings.Builder
+	for i, c := range value {
+		switch {
+		case c == 0:
+			b.WriteString(`\00`)
+			continue
+		case strings.ContainsRune(`"+,;<>\=`, c),
+			(c == ' ' || c == '#') && i == 0,
+			c == ' ' && i == len(value)-1:
+			b.WriteByte('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
@@ -63,3 +63,23 @@ func TestSubstituter(t *testing.T) {
 		})
 	}
 }
+
+func TestEscapeDN(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"john", "john"},
+		{"john,ou=admins", `john\,ou\=admins`},
+		{` #john+doe `, `\ #john\+doe\ `},
+		{"#john", `\#john`},
+		{`"a"<b>;c\d`, `\"a\"\<b\>\;c\\d`},
+		{"a\x00b", `a\00b`},
+	}
+
+	for i, test := range tests {
+		if got := EscapeDN(test.value); got != test.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, test.expected, got)
+		}
+	}
+}
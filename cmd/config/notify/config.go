@@ -16,12 +16,21 @@
 
 package notify
 
-import "github.com/minio/minio/pkg/event/target"
+import (
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	"github.com/minio/minio/pkg/event/target"
+	xnet "github.com/minio/minio/pkg/net"
+)
 
 // Config - notification target configuration structure, holds
 // information about various notification targets.
 type Config struct {
 	AMQP          map[string]target.AMQPArgs          `json:"amqp"`
+	AMQP1         map[string]target.AMQP1Args         `json:"amqp1"`
 	Elasticsearch map[string]target.ElasticsearchArgs `json:"elasticsearch"`
 	Kafka         map[string]target.KafkaArgs         `json:"kafka"`
 	MQTT          map[string]target.MQTTArgs          `json:"mqtt"`
@@ -29,7 +38,10 @@ type Config struct {
 	NATS          map[string]target.NATSArgs          `json:"nats"`
 	NSQ           map[string]target.NSQArgs           `json:"nsq"`
 	PostgreSQL    map[string]target.PostgreSQLArgs    `json:"postgresql"`
+	PubSub        map[string]target.PubSubArgs        `json:"pubsub"`
 	Redis         map[string]target.RedisArgs         `json:"redis"`
+	SNS           map[string]target.SNSArgs           `json:"sns"`
+	SQS           map[string]target.SQSArgs           `json:"sqs"`
 	Webhook       map[string]target.WebhookArgs       `json:"webhook"`
 }
 
@@ -37,30 +49,152 @@ const (
 	defaultTarget = "1"
 )
 
+// Webhook target environment variables, they override the
+// configuration of the webhook target "1".
+const (
+	EnvWebhookEnable     = "MINIO_NOTIFY_WEBHOOK_ENABLE"
+	EnvWebhookEndpoint   = "MINIO_NOTIFY_WEBHOOK_ENDPOINT"
+	EnvWebhookQueueDir   = "MINIO_NOTIFY_WEBHOOK_QUEUE_DIR"
+	EnvWebhookQueueLimit = "MINIO_NOTIFY_WEBHOOK_QUEUE_LIMIT"
+)
+
+// EnvEventMetadata controls which object metadata is embedded in the
+// records of published events, it is one of "on" (default), "off" or
+// a comma separated list of the metadata keys to include.
+const EnvEventMetadata = "MINIO_NOTIFY_EVENT_METADATA"
+
+// EnvDeadLetterDir is the directory where events which could neither
+// be delivered nor queued by a target are written as JSON.
+const EnvDeadLetterDir = "MINIO_NOTIFY_DEAD_LETTER_DIR"
+
+// EventMetadata - settings for object metadata included in events.
+type EventMetadata struct {
+	// Disabled drops user metadata and tags from the event records.
+	Disabled bool
+	// Keys restricts the user metadata to these keys, compared
+	// case-insensitively, all keys are included when empty.
+	Keys []string
+}
+
+// LookupEventMetadata - lookup event metadata settings from the environment.
+func LookupEventMetadata() (EventMetadata, error) {
+	var m EventMetadata
+	v := strings.TrimSpace(env.Get(EnvEventMetadata, "on"))
+	switch v {
+	case "on":
+		return m, nil
+	case "off":
+		m.Disabled = true
+		return m, nil
+	}
+	for _, key := range strings.Split(v, config.ValueSeparator) {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			return m, config.ErrInvalidNotifyEventMetadataValue(nil).Msg("%s: empty metadata key in `%s`", EnvEventMetadata, v)
+		}
+		m.Keys = append(m.Keys, key)
+	}
+	return m, nil
+}
+
+// Filter returns the user metadata to include in an event record.
+func (m EventMetadata) Filter(metadata map[string]string) map[string]string {
+	if m.Disabled || len(metadata) == 0 {
+		return nil
+	}
+	if len(m.Keys) == 0 {
+		return metadata
+	}
+	filtered := make(map[string]string)
+	for k, v := range metadata {
+		for _, key := range m.Keys {
+			if strings.EqualFold(k, key) {
+				filtered[k] = v
+				break
+			}
+		}
+	}
+	if len(filtered) == 0 {
+		return nil
+	}
+	return filtered
+}
+
 // NewConfig - initialize notification config.
 func NewConfig() Config {
 	// Make sure to initialize notification targets
 	cfg := Config{
 		NSQ:           make(map[string]target.NSQArgs),
 		AMQP:          make(map[string]target.AMQPArgs),
+		AMQP1:         make(map[string]target.AMQP1Args),
 		MQTT:          make(map[string]target.MQTTArgs),
 		NATS:          make(map[string]target.NATSArgs),
 		Redis:         make(map[string]target.RedisArgs),
+		SNS:           make(map[string]target.SNSArgs),
+		SQS:           make(map[string]target.SQSArgs),
 		MySQL:         make(map[string]target.MySQLArgs),
 		Kafka:         make(map[string]target.KafkaArgs),
 		Webhook:       make(map[string]target.WebhookArgs),
 		PostgreSQL:    make(map[string]target.PostgreSQLArgs),
+		PubSub:        make(map[string]target.PubSubArgs),
 		Elasticsearch: make(map[string]target.ElasticsearchArgs),
 	}
 	cfg.NSQ[defaultTarget] = target.NSQArgs{}
 	cfg.AMQP[defaultTarget] = target.AMQPArgs{}
+	cfg.AMQP1[defaultTarget] = target.AMQP1Args{}
 	cfg.MQTT[defaultTarget] = target.MQTTArgs{}
 	cfg.NATS[defaultTarget] = target.NATSArgs{}
 	cfg.Redis[defaultTarget] = target.RedisArgs{}
+	cfg.SNS[defaultTarget] = target.SNSArgs{}
+	cfg.SQS[defaultTarget] = target.SQSArgs{}
 	cfg.MySQL[defaultTarget] = target.MySQLArgs{}
 	cfg.Kafka[defaultTarget] = target.KafkaArgs{}
 	cfg.Webhook[defaultTarget] = target.WebhookArgs{}
 	cfg.PostgreSQL[defaultTarget] = target.PostgreSQLArgs{}
+	cfg.PubSub[defaultTarget] = target.PubSubArgs{}
 	cfg.Elasticsearch[defaultTarget] = target.ElasticsearchArgs{}
 	return cfg
 }
+
+// LookupConfig - lookup notification targets config from the
+// environment, only the webhook target can be configured this way.
+func LookupConfig(cfg Config) (Config, error) {
+	enable, ok := env.Lookup(EnvWebhookEnable)
+	if !ok {
+		return cfg, nil
+	}
+
+	webhooks := make(map[string]target.WebhookArgs, len(cfg.Webhook))
+	for id, args := range cfg.Webhook {
+		webhooks[id] = args
+	}
+	args := webhooks[defaultTarget]
+
+	flag, err := config.ParseBoolFlag(enable)
+	if err != nil {
+		return cfg, config.ErrInvalidNotifyWebhookValue(err).Msg("%s: unknown value `%s`", EnvWebhookEnable, enable)
+	}
+	args.Enable = bool(flag)
+
+	if v := env.Get(EnvWebhookEndpoint, ""); v != "" {
+		u, err := xnet.ParseURL(v)
+		if err != nil {
+			return cfg, config.ErrInvalidNotifyWebhookValue(err).Msg("%s: unable to parse `%s`", EnvWebhookEndpoint, v)
+		}
+		args.Endpoint = *u
+	}
+	args.QueueDir = env.Get(EnvWebhookQueueDir, args.QueueDir)
+	if v := env.Get(EnvWebhookQueueLimit, ""); v != "" {
+		if args.QueueLimit, err = strconv.ParseUint(v, 10, 64); err != nil {
+			return cfg, config.ErrInvalidNotifyWebhookValue(err).Msg("%s: unable to parse `%s`", EnvWebhookQueueLimit, v)
+		}
+	}
+
+	if err = args.Validate(); err != nil {
+		return cfg, config.ErrInvalidNotifyWebhookValue(err)
+	}
+
+	webhooks[defaultTarget] = args
+	cfg.Webhook = webhooks
+	return cfg, nil
+}
@@ -0,0 +1,126 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package notify
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLookupConfig(t *testing.T) {
+	testCases := []struct {
+		env      map[string]string
+		enable   bool
+		endpoint string
+		success  bool
+	}{
+		{env: map[string]string{}},
+		{
+			env:      map[string]string{EnvWebhookEnable: "on", EnvWebhookEndpoint: "http://localhost:3000/events"},
+			enable:   true,
+			endpoint: "http://localhost:3000/events",
+			success:  true,
+		},
+		{env: map[string]string{EnvWebhookEnable: "off"}, success: true},
+		{env: map[string]string{EnvWebhookEnable: "on"}},
+		{env: map[string]string{EnvWebhookEnable: "yes please", EnvWebhookEndpoint: "http://localhost:3000"}},
+		{env: map[string]string{EnvWebhookEnable: "on", EnvWebhookEndpoint: "http://localhost:3000", EnvWebhookQueueLimit: "-1"}},
+	}
+
+	for i, testCase := range testCases {
+		for _, name := range []string{EnvWebhookEnable, EnvWebhookEndpoint, EnvWebhookQueueDir, EnvWebhookQueueLimit} {
+			os.Unsetenv(name)
+		}
+		for name, value := range testCase.env {
+			os.Setenv(name, value)
+		}
+
+		cfg, err := LookupConfig(NewConfig())
+		if len(testCase.env) == 0 {
+			// Nothing set, the config is left unchanged.
+			if err != nil || cfg.Webhook[defaultTarget].Enable {
+				t.Errorf("Test %d: unexpected config %v, %v", i+1, cfg.Webhook, err)
+			}
+			continue
+		}
+		if testCase.success != (err == nil) {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+			continue
+		}
+		if !testCase.success {
+			continue
+		}
+		args := cfg.Webhook[defaultTarget]
+		if args.Enable != testCase.enable {
+			t.Errorf("Test %d: expected enable %v, got %v", i+1, testCase.enable, args.Enable)
+		}
+		if testCase.endpoint != "" && args.Endpoint.String() != testCase.endpoint {
+			t.Errorf("Test %d: expected endpoint %s, got %s", i+1, testCase.endpoint, args.Endpoint.String())
+		}
+	}
+
+	for _, name := range []string{EnvWebhookEnable, EnvWebhookEndpoint, EnvWebhookQueueDir, EnvWebhookQueueLimit} {
+		os.Unsetenv(name)
+	}
+}
+
+func TestLookupEventMetadata(t *testing.T) {
+	metadata := map[string]string{
+		"Content-Type":      "text/plain",
+		"X-Amz-Meta-Owner":  "alice",
+		"X-Amz-Meta-Source": "camera",
+	}
+	testCases := []struct {
+		value    string
+		success  bool
+		expected map[string]string
+	}{
+		{value: "", success: true, expected: metadata},
+		{value: "on", success: true, expected: metadata},
+		{value: "off", success: true},
+		{value: "x-amz-meta-owner", success: true, expected: map[string]string{"X-Amz-Meta-Owner": "alice"}},
+		{
+			value:    "X-Amz-Meta-Owner, content-type",
+			success:  true,
+			expected: map[string]string{"X-Amz-Meta-Owner": "alice", "Content-Type": "text/plain"},
+		},
+		{value: "x-amz-meta-missing", success: true},
+		{value: "x-amz-meta-owner,,content-type"},
+	}
+
+	for i, testCase := range testCases {
+		os.Unsetenv(EnvEventMetadata)
+		if testCase.value != "" {
+			os.Setenv(EnvEventMetadata, testCase.value)
+		}
+
+		m, err := LookupEventMetadata()
+		if testCase.success != (err == nil) {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+			continue
+		}
+		if !testCase.success {
+			continue
+		}
+		if got := m.Filter(metadata); !reflect.DeepEqual(got, testCase.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.expected, got)
+		}
+	}
+
+	os.Unsetenv(EnvEventMetadata)
+}
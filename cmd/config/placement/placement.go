@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package placement
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Placement environment variables
+const (
+	EnvXLPlacement = "MINIO_XL_PLACEMENT"
+)
+
+// Rule pins the objects of a bucket whose names start with
+// Prefix to the erasure set Set, numbered from 1.
+type Rule struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+	Set    int    `json:"set"`
+}
+
+// Config represents the placement rules of objects on erasure
+// sets, objects not matched by any rule are placed on the set
+// hashed from the object name.
+type Config struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Lookup - returns the set, numbered from 1, the object is pinned
+// to by the rule with the longest matching prefix.
+func (c Config) Lookup(bucket, object string) (set int, ok bool) {
+	match := -1
+	for _, rule := range c.Rules {
+		if rule.Bucket != bucket || !strings.HasPrefix(object, rule.Prefix) {
+			continue
+		}
+		if len(rule.Prefix) > match {
+			match = len(rule.Prefix)
+			set = rule.Set
+		}
+	}
+	return set, match >= 0
+}
+
+// Equal - returns true if both configs have the same rules.
+func (c Config) Equal(o Config) bool {
+	if len(c.Rules) != len(o.Rules) {
+		return false
+	}
+	for _, rule := range c.Rules {
+		if !o.has(rule) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c Config) has(rule Rule) bool {
+	for _, r := range c.Rules {
+		if r == rule {
+			return true
+		}
+	}
+	return false
+}
+
+// Moved - returns the rules of prev and c whose prefix c places on
+// another set than prev did. Objects written under prev below those
+// prefixes would not be found anymore, except the objects placed on
+// the set hashed from their name, which are still looked up there.
+func (c Config) Moved(prev Config) (rules []Rule) {
+	for _, rule := range prev.Rules {
+		if set, ok := c.Lookup(rule.Bucket, rule.Prefix); !ok || set != rule.Set {
+			rules = append(rules, rule)
+		}
+	}
+	for _, rule := range c.Rules {
+		if set, ok := prev.Lookup(rule.Bucket, rule.Prefix); ok && set != rule.Set {
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// Validate - checks that the sets of all rules exist in a
+// deployment of setCount erasure sets.
+func (c Config) Validate(setCount int) error {
+	for _, rule := range c.Rules {
+		if rule.Set > setCount {
+			return config.ErrInvalidXLPlacementValue(nil).Msg("%s: set %d of %s/%s does not exist, the deployment has %d sets",
+				EnvXLPlacement, rule.Set, rule.Bucket, rule.Prefix, setCount)
+		}
+	}
+	return nil
+}
+
+// LookupConfig - lookup placement config, rules are `bucket/prefix=set`
+// pairs such as `finance/eu/=1,finance/us/=2`.
+func LookupConfig() (cfg Config, err error) {
+	seen := make(map[string]struct{})
+	for _, pair := range strings.Split(env.Get(EnvXLPlacement, ""), config.ValueSeparator) {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		kv := strings.SplitN(pair, "=", 2)
+		if len(kv) != 2 {
+			return cfg, config.ErrInvalidXLPlacementValue(nil).Msg("%s: invalid entry `%s`", EnvXLPlacement, pair)
+		}
+		bucketPrefix := strings.SplitN(kv[0], "/", 2)
+		if bucketPrefix[0] == "" {
+			return cfg, config.ErrInvalidXLPlacementValue(nil).Msg("%s: missing bucket in `%s`", EnvXLPlacement, pair)
+		}
+		if _, ok := seen[kv[0]]; ok {
+			return cfg, config.ErrInvalidXLPlacementValue(nil).Msg("%s: duplicate entry for `%s`", EnvXLPlacement, kv[0])
+		}
+		seen[kv[0]] = struct{}{}
+		set, err := strconv.Atoi(kv[1])
+		if err != nil || set < 1 {
+			return cfg, config.ErrInvalidXLPlacementValue(err).Msg("%s: invalid set `%s` for %s", EnvXLPlacement, kv[1], kv[0])
+		}
+		rule := Rule{Bucket: bucketPrefix[0], Set: set}
+		if len(bucketPrefix) == 2 {
+			rule.Prefix = bucketPrefix[1]
+		}
+		cfg.Rules = append(cfg.Rules, rule)
+	}
+	return cfg, nil
+}
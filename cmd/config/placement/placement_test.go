@@ -0,0 +1,146 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package placement
+
+import (
+	"os"
+	"reflect"
+	"testing"
+)
+
+func TestLookupConfig(t *testing.T) {
+	defer os.Unsetenv(EnvXLPlacement)
+
+	testCases := []struct {
+		value       string
+		rules       []Rule
+		expectedErr bool
+	}{
+		{"", nil, false},
+		{"finance/eu/=1", []Rule{{Bucket: "finance", Prefix: "eu/", Set: 1}}, false},
+		{"finance=2, logs/2019/=3", []Rule{
+			{Bucket: "finance", Set: 2},
+			{Bucket: "logs", Prefix: "2019/", Set: 3},
+		}, false},
+		{"finance/eu/", nil, true},
+		{"/eu/=1", nil, true},
+		{"finance/eu/=0", nil, true},
+		{"finance/eu/=one", nil, true},
+		{"finance/eu/=1,finance/eu/=2", nil, true},
+	}
+	for i, testCase := range testCases {
+		os.Setenv(EnvXLPlacement, testCase.value)
+		cfg, err := LookupConfig()
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && !reflect.DeepEqual(cfg.Rules, testCase.rules) {
+			t.Fatalf("Test %d: expected rules %v, got %v", i+1, testCase.rules, cfg.Rules)
+		}
+	}
+}
+
+func TestConfigLookup(t *testing.T) {
+	cfg := Config{Rules: []Rule{
+		{Bucket: "finance", Set: 1},
+		{Bucket: "finance", Prefix: "eu/", Set: 2},
+		{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+	}}
+
+	testCases := []struct {
+		bucket, object string
+		set            int
+		ok             bool
+	}{
+		{"finance", "report.csv", 1, true},
+		{"finance", "eu/report.csv", 2, true},
+		{"finance", "eu/archive/2018.csv", 3, true},
+		{"finance", "eu", 1, true},
+		{"logs", "eu/report.csv", 0, false},
+	}
+	for i, testCase := range testCases {
+		set, ok := cfg.Lookup(testCase.bucket, testCase.object)
+		if set != testCase.set || ok != testCase.ok {
+			t.Errorf("Test %d: expected set %d (%v), got %d (%v)", i+1, testCase.set, testCase.ok, set, ok)
+		}
+	}
+
+	if err := cfg.Validate(3); err != nil {
+		t.Errorf("Expected rules to be valid for 3 sets, got %v", err)
+	}
+	if err := cfg.Validate(2); err == nil {
+		t.Error("Expected rules to be invalid for 2 sets")
+	}
+}
+
+func TestConfigMoved(t *testing.T) {
+	prev := Config{Rules: []Rule{
+		{Bucket: "finance", Prefix: "eu/", Set: 2},
+		{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+	}}
+
+	testCases := []struct {
+		rules []Rule
+		moved []Rule
+	}{
+		// Unchanged rules, in any order.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+		}, nil},
+		// Objects of new rules outside of pinned prefixes are
+		// still found on their hashed set.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+			{Bucket: "logs", Set: 1},
+		}, nil},
+		// Pinning a sub prefix to the same set.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+			{Bucket: "finance", Prefix: "eu/2019/", Set: 2},
+		}, nil},
+		// Pinning a sub prefix to another set.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+			{Bucket: "finance", Prefix: "eu/2019/", Set: 1},
+		}, []Rule{{Bucket: "finance", Prefix: "eu/2019/", Set: 1}}},
+		// Removing a rule.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+		}, []Rule{{Bucket: "finance", Prefix: "eu/archive/", Set: 3}}},
+		// Changing the set of a rule.
+		{[]Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 1},
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 3},
+		}, []Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 2},
+			{Bucket: "finance", Prefix: "eu/", Set: 1},
+		}},
+	}
+	for i, testCase := range testCases {
+		cfg := Config{Rules: testCase.rules}
+		if moved := cfg.Moved(prev); !reflect.DeepEqual(moved, testCase.moved) {
+			t.Errorf("Test %d: expected moved rules %v, got %v", i+1, testCase.moved, moved)
+		}
+		if equal := cfg.Equal(prev); equal != (i == 0) {
+			t.Errorf("Test %d: expected equal to be %v", i+1, i == 0)
+		}
+	}
+}
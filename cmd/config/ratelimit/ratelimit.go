@@ -0,0 +1,80 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"strconv"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+)
+
+// Config represents the API rate limits, all values are in
+// requests per second and a value of 0 disables the limit.
+type Config struct {
+	// Read - limit on object GET/HEAD requests.
+	Read float64 `json:"read"`
+	// Write - limit on PUT/POST/DELETE requests.
+	Write float64 `json:"write"`
+	// List - limit on service and bucket level GET requests.
+	List float64 `json:"list"`
+	// User - limit on all requests from a single access key.
+	User float64 `json:"user"`
+}
+
+// Rate limit environment variables
+const (
+	EnvRateLimitRead  = "MINIO_RATELIMIT_READ"
+	EnvRateLimitWrite = "MINIO_RATELIMIT_WRITE"
+	EnvRateLimitList  = "MINIO_RATELIMIT_LIST"
+	EnvRateLimitUser  = "MINIO_RATELIMIT_USER"
+)
+
+// Enabled - returns true if any of the limits is set.
+func (cfg Config) Enabled() bool {
+	return cfg.Read > 0 || cfg.Write > 0 || cfg.List > 0 || cfg.User > 0
+}
+
+func parseLimit(envName string, value float64) (float64, error) {
+	v := env.Get(envName, strconv.FormatFloat(value, 'f', -1, 64))
+	limit, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return value, config.ErrInvalidRateLimitValue(err).Msg("%s: unable to parse `%s`", envName, v)
+	}
+	if limit < 0 {
+		return value, config.ErrInvalidRateLimitValue(nil).Msg("%s: `%s` cannot be negative", envName, v)
+	}
+	return limit, nil
+}
+
+// LookupConfig - lookup rate limit config.
+func LookupConfig(cfg Config) (Config, error) {
+	var err error
+	if cfg.Read, err = parseLimit(EnvRateLimitRead, cfg.Read); err != nil {
+		return cfg, err
+	}
+	if cfg.Write, err = parseLimit(EnvRateLimitWrite, cfg.Write); err != nil {
+		return cfg, err
+	}
+	if cfg.List, err = parseLimit(EnvRateLimitList, cfg.List); err != nil {
+		return cfg, err
+	}
+	if cfg.User, err = parseLimit(EnvRateLimitUser, cfg.User); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/pkg/env"
 )
@@ -34,6 +35,22 @@ const (
 	STANDARD = "STANDARD"
 )
 
+// S3 storage classes of infrequently accessed and archived data,
+// only accepted in gateway mode for backends translating them to
+// their own storage classes.
+const (
+	// Standard infrequent access storage class
+	STANDARDIA = "STANDARD_IA"
+	// One zone infrequent access storage class
+	ONEZONEIA = "ONEZONE_IA"
+	// Intelligent tiering storage class
+	INTELLIGENTTIERING = "INTELLIGENT_TIERING"
+	// Glacier storage class
+	GLACIER = "GLACIER"
+	// Deep archive storage class
+	DEEPARCHIVE = "DEEP_ARCHIVE"
+)
+
 // Standard constats for config info storage class
 const (
 	// Reduced redundancy storage class environment variable
@@ -41,6 +58,11 @@ const (
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
 
+	// Reduced redundancy storage class erasure block size environment variable
+	RRSBlockSizeEnv = "MINIO_STORAGE_CLASS_RRS_BLOCK_SIZE"
+	// Standard storage class erasure block size environment variable
+	StandardBlockSizeEnv = "MINIO_STORAGE_CLASS_STANDARD_BLOCK_SIZE"
+
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
 
@@ -49,11 +71,20 @@ const (
 
 	// Default RRS parity is always minimum parity.
 	defaultRRSParity = minParityDisks
+
+	// Min and max erasure block size, the max is the
+	// default block size of the erasure coded objects.
+	minBlockSize = 64 * humanize.KiByte
+	maxBlockSize = 10 * humanize.MiByte
 )
 
 // StorageClass - holds storage class information
 type StorageClass struct {
 	Parity int
+	// BlockSize - erasure block size of new objects, zero
+	// for the default block size. Smaller blocks are decoded
+	// sooner, lowering the time to first byte of GETs.
+	BlockSize int64 `json:"-"`
 }
 
 // Config storage class configuration
@@ -79,6 +110,16 @@ func IsValid(sc string) bool {
 	return sc == RRS || sc == STANDARD
 }
 
+// IsValidGateway - returns true if input string is a valid
+// storage class kind supported in gateway mode.
+func IsValidGateway(sc string) bool {
+	switch sc {
+	case STANDARDIA, ONEZONEIA, INTELLIGENTTIERING, GLACIER, DEEPARCHIVE:
+		return true
+	}
+	return IsValid(sc)
+}
+
 // UnmarshalText unmarshals storage class from its textual form into
 // storageClass structure.
 func (sc *StorageClass) UnmarshalText(b []byte) error {
@@ -195,6 +236,32 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 	}
 }
 
+// GetBlockSizeForSC - returns the erasure block size of new objects of
+// the storage class, zero if the default block size is used.
+func (sCfg Config) GetBlockSizeForSC(sc string) int64 {
+	switch strings.TrimSpace(sc) {
+	case RRS:
+		return sCfg.RRS.BlockSize
+	default:
+		return sCfg.Standard.BlockSize
+	}
+}
+
+// parseBlockSize - parses the erasure block size set in the
+// environment variable envName, zero if it is not set.
+func parseBlockSize(envName string) (int64, error) {
+	v := env.Get(envName, "")
+	if v == "" {
+		return 0, nil
+	}
+	blockSize, err := humanize.ParseBytes(v)
+	if err != nil || blockSize < minBlockSize || blockSize > maxBlockSize {
+		return 0, config.ErrStorageClassValue(err).Msg("%s: block size `%s` should be between %s and %s",
+			envName, v, humanize.IBytes(minBlockSize), humanize.IBytes(maxBlockSize))
+	}
+	return int64(blockSize), nil
+}
+
 // LookupConfig - lookup storage class config and override with valid environment settings if any.
 func LookupConfig(cfg Config, drivesPerSet int) (Config, error) {
 	var err error
@@ -226,5 +293,12 @@ func LookupConfig(cfg Config, drivesPerSet int) (Config, error) {
 		return cfg, err
 	}
 
+	if cfg.Standard.BlockSize, err = parseBlockSize(StandardBlockSizeEnv); err != nil {
+		return cfg, err
+	}
+	if cfg.RRS.BlockSize, err = parseBlockSize(RRSBlockSizeEnv); err != nil {
+		return cfg, err
+	}
+
 	return cfg, nil
 }
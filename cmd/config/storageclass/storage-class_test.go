@@ -18,6 +18,7 @@ package storageclass
 
 import (
 	"errors"
+	"os"
 	"reflect"
 	"testing"
 )
@@ -161,3 +162,66 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+// Test IsValidGateway method with valid and invalid inputs
+func TestIsValidGatewayStorageClassKind(t *testing.T) {
+	tests := []struct {
+		sc   string
+		want bool
+	}{
+		{"STANDARD", true},
+		{"REDUCED_REDUNDANCY", true},
+		{"STANDARD_IA", true},
+		{"GLACIER", true},
+		{"DEEP_ARCHIVE", true},
+		{"", false},
+		{"NEARLINE", false},
+	}
+	for i, tt := range tests {
+		if got := IsValidGateway(tt.sc); got != tt.want {
+			t.Errorf("Test %d, Expected Storage Class to be %t, got %t", i+1, tt.want, got)
+		}
+	}
+}
+
+func TestLookupBlockSize(t *testing.T) {
+	defer os.Unsetenv(StandardBlockSizeEnv)
+	defer os.Unsetenv(RRSBlockSizeEnv)
+
+	tests := []struct {
+		standard, rrs     string
+		standardBlockSize int64
+		rrsBlockSize      int64
+		success           bool
+	}{
+		{"", "", 0, 0, true},
+		{"1MiB", "", 1048576, 0, true},
+		{"", "512KiB", 0, 524288, true},
+		{"10MiB", "64KiB", 10485760, 65536, true},
+		{"11MiB", "", 0, 0, false},
+		{"", "32KiB", 0, 0, false},
+		{"one", "", 0, 0, false},
+	}
+	for i, tt := range tests {
+		os.Setenv(StandardBlockSizeEnv, tt.standard)
+		os.Setenv(RRSBlockSizeEnv, tt.rrs)
+		cfg, err := LookupConfig(Config{}, 16)
+		if err != nil && tt.success {
+			t.Errorf("Test %d, Expected success, got %s", i+1, err)
+			continue
+		}
+		if err == nil && !tt.success {
+			t.Errorf("Test %d, Expected failure, got success", i+1)
+			continue
+		}
+		if !tt.success {
+			continue
+		}
+		if bs := cfg.GetBlockSizeForSC(STANDARD); bs != tt.standardBlockSize {
+			t.Errorf("Test %d, Expected standard block size %d, got %d", i+1, tt.standardBlockSize, bs)
+		}
+		if bs := cfg.GetBlockSizeForSC(RRS); bs != tt.rrsBlockSize {
+			t.Errorf("Test %d, Expected RRS block size %d, got %d", i+1, tt.rrsBlockSize, bs)
+		}
+	}
+}
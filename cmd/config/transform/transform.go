@@ -0,0 +1,66 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package transform
+
+import (
+	"fmt"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/pkg/env"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// Config represents the on-the-fly image transformation settings
+// used when serving GetObject requests with a resize query.
+type Config struct {
+	// Enabled - serve transformed objects, off by default.
+	Enabled bool `json:"enabled"`
+	// Endpoint - optional URL of an external transform service,
+	// when empty images are resized by the server itself.
+	Endpoint string `json:"endpoint"`
+}
+
+// Transform environment variables
+const (
+	EnvTransform         = "MINIO_TRANSFORM"
+	EnvTransformEndpoint = "MINIO_TRANSFORM_ENDPOINT"
+)
+
+// LookupConfig - lookup image transform config, configuring an
+// endpoint implicitly enables the transform.
+func LookupConfig(cfg Config) (Config, error) {
+	cfg.Endpoint = env.Get(EnvTransformEndpoint, cfg.Endpoint)
+	if cfg.Endpoint != "" {
+		u, err := xnet.ParseURL(cfg.Endpoint)
+		if err != nil {
+			return cfg, config.ErrInvalidTransformValue(err).Msg("%s: unable to parse `%s`", EnvTransformEndpoint, cfg.Endpoint)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return cfg, config.ErrInvalidTransformValue(fmt.Errorf("unsupported scheme %q", u.Scheme)).Msg("%s: `%s` must be an http(s) URL", EnvTransformEndpoint, cfg.Endpoint)
+		}
+		cfg.Enabled = true
+	}
+
+	if v := env.Get(EnvTransform, ""); v != "" {
+		enabled, err := config.ParseBoolFlag(v)
+		if err != nil {
+			return cfg, config.ErrInvalidTransformValue(err).Msg("%s: unknown value `%s`", EnvTransform, v)
+		}
+		cfg.Enabled = bool(enabled)
+	}
+	return cfg, nil
+}
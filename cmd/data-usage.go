@@ -0,0 +1,204 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"math"
+	"math/rand"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Data usage report of all buckets, stored in the meta bucket.
+	dataUsageObjName = "data-usage.json"
+
+	dataUsageCrawlInterval = 12 * time.Hour
+	dataUsageCrawlTick     = time.Hour
+)
+
+// objectSizeInterval - an interval of the object sizes histogram,
+// including start and end.
+type objectSizeInterval struct {
+	name       string
+	start, end int64
+}
+
+// Intervals of the object sizes histogram of the data usage report.
+var objectsHistogramIntervals = []objectSizeInterval{
+	{"LESS_THAN_1024_B", -1, humanize.KiByte - 1},
+	{"BETWEEN_1024_B_AND_1_MB", humanize.KiByte, humanize.MiByte - 1},
+	{"BETWEEN_1_MB_AND_10_MB", humanize.MiByte, humanize.MiByte*10 - 1},
+	{"BETWEEN_10_MB_AND_64_MB", humanize.MiByte * 10, humanize.MiByte*64 - 1},
+	{"BETWEEN_64_MB_AND_128_MB", humanize.MiByte * 64, humanize.MiByte*128 - 1},
+	{"BETWEEN_128_MB_AND_512_MB", humanize.MiByte * 128, humanize.MiByte*512 - 1},
+	{"GREATER_THAN_512_MB", humanize.MiByte * 512, math.MaxInt64},
+}
+
+// getObjectsHistogramInterval - returns the name of the histogram
+// interval the size belongs to.
+func getObjectsHistogramInterval(size int64) string {
+	for _, interval := range objectsHistogramIntervals {
+		if size >= interval.start && size <= interval.end {
+			return interval.name
+		}
+	}
+	return objectsHistogramIntervals[len(objectsHistogramIntervals)-1].name
+}
+
+// initDataUsageStats starts the routine which periodically crawls
+// all buckets and persists the data usage report in the backend.
+func initDataUsageStats() {
+	go runDataUsageInfoUpdateRoutine()
+}
+
+var dataUsageLockTimeout = newDynamicTimeout(60*time.Second, time.Second)
+
+func runDataUsageInfoUpdateRoutine() {
+	var objAPI ObjectLayer
+	var ctx = context.Background()
+
+	// Wait until the object layer is ready
+	for {
+		objAPI = newObjectLayerFn()
+		if objAPI == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+		break
+	}
+
+	// Start with random sleep time, so as to avoid "synchronous checks" between servers
+	time.Sleep(time.Duration(rand.Float64() * float64(time.Minute)))
+
+	for {
+		if err := updateDataUsageInfo(ctx, objAPI); err != nil {
+			logger.LogIf(ctx, err)
+		}
+		time.Sleep(dataUsageCrawlTick)
+	}
+}
+
+// updateDataUsageInfo crawls all buckets and saves a new data usage
+// report, unless the stored report is more recent than the crawl
+// interval. Only one server of the cluster crawls at a time.
+func updateDataUsageInfo(ctx context.Context, objAPI ObjectLayer) error {
+	crawlLock := globalNSMutex.NewNSLock(ctx, minioMetaBucket, dataUsageObjName)
+	if err := crawlLock.GetLock(dataUsageLockTimeout); err != nil {
+		if _, ok := err.(OperationTimedOut); ok {
+			// Another server is crawling.
+			return nil
+		}
+		return err
+	}
+	defer crawlLock.Unlock()
+
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	if time.Since(dataUsageInfo.LastUpdate) < dataUsageCrawlInterval {
+		return nil
+	}
+
+	dataUsageInfo, err = crawlDataUsage(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	return storeDataUsageInBackend(ctx, objAPI, dataUsageInfo)
+}
+
+// crawlDataUsage lists all objects of all buckets and returns their
+// count, total size and sizes histogram, per bucket and overall.
+func crawlDataUsage(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
+	dataUsageInfo := madmin.DataUsageInfo{
+		ObjectsSizesHistogram: make(map[string]uint64),
+		BucketsUsage:          make(map[string]madmin.BucketUsageInfo),
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return dataUsageInfo, err
+	}
+
+	for _, bucket := range buckets {
+		bucketUsage := madmin.BucketUsageInfo{
+			ObjectsSizesHistogram: make(map[string]uint64),
+		}
+
+		marker := ""
+		for {
+			res, err := objAPI.ListObjects(ctx, bucket.Name, "", marker, "", maxObjectList)
+			if err != nil {
+				return dataUsageInfo, err
+			}
+
+			for _, obj := range res.Objects {
+				interval := getObjectsHistogramInterval(obj.Size)
+				bucketUsage.ObjectsCount++
+				bucketUsage.ObjectsTotalSize += uint64(obj.Size)
+				bucketUsage.ObjectsSizesHistogram[interval]++
+				dataUsageInfo.ObjectsSizesHistogram[interval]++
+			}
+
+			if !res.IsTruncated {
+				break
+			}
+			marker = res.NextMarker
+		}
+
+		dataUsageInfo.BucketsCount++
+		dataUsageInfo.ObjectsCount += bucketUsage.ObjectsCount
+		dataUsageInfo.ObjectsTotalSize += bucketUsage.ObjectsTotalSize
+		dataUsageInfo.BucketsUsage[bucket.Name] = bucketUsage
+	}
+
+	dataUsageInfo.LastUpdate = UTCNow()
+	return dataUsageInfo, nil
+}
+
+func storeDataUsageInBackend(ctx context.Context, objAPI ObjectLayer, dataUsageInfo madmin.DataUsageInfo) error {
+	data, err := json.Marshal(dataUsageInfo)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, dataUsageObjName, data)
+}
+
+// loadDataUsageFromBackend returns the last stored data usage report,
+// or an empty report if the buckets were never crawled.
+func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
+	var dataUsageInfo madmin.DataUsageInfo
+
+	data, err := readConfig(ctx, objAPI, dataUsageObjName)
+	if err != nil {
+		if err == errConfigNotFound {
+			return dataUsageInfo, nil
+		}
+		return dataUsageInfo, err
+	}
+
+	if err = json.Unmarshal(data, &dataUsageInfo); err != nil {
+		return dataUsageInfo, err
+	}
+	return dataUsageInfo, nil
+}
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"strconv"
+	"testing"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestGetObjectsHistogramInterval(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		interval string
+	}{
+		{0, "LESS_THAN_1024_B"},
+		{humanize.KiByte - 1, "LESS_THAN_1024_B"},
+		{humanize.KiByte, "BETWEEN_1024_B_AND_1_MB"},
+		{humanize.MiByte, "BETWEEN_1_MB_AND_10_MB"},
+		{humanize.MiByte * 64, "BETWEEN_64_MB_AND_128_MB"},
+		{humanize.MiByte*512 - 1, "BETWEEN_128_MB_AND_512_MB"},
+		{humanize.TiByte, "GREATER_THAN_512_MB"},
+	}
+	for i, testCase := range testCases {
+		if interval := getObjectsHistogramInterval(testCase.size); interval != testCase.interval {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.interval, interval)
+		}
+	}
+}
+
+func TestDataUsageCrawl(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	objects := map[string][]int{
+		"bucket1": {10, 2 * humanize.KiByte},
+		"bucket2": {20},
+		"bucket3": {},
+	}
+	for bucket, sizes := range objects {
+		if err = objLayer.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+		for i, size := range sizes {
+			data := bytes.Repeat([]byte("a"), size)
+			_, err = objLayer.PutObject(ctx, bucket, "dir/object"+strconv.Itoa(i),
+				mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", ""), ObjectOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	// Nothing is stored before the first crawl.
+	dataUsageInfo, err := loadDataUsageFromBackend(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !dataUsageInfo.LastUpdate.IsZero() {
+		t.Fatalf("expected no data usage report, got %+v", dataUsageInfo)
+	}
+
+	if err = updateDataUsageInfo(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	dataUsageInfo, err = loadDataUsageFromBackend(ctx, objLayer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dataUsageInfo.LastUpdate.IsZero() {
+		t.Fatal("expected the last update time to be set")
+	}
+	if dataUsageInfo.BucketsCount != 3 {
+		t.Errorf("expected 3 buckets, got %d", dataUsageInfo.BucketsCount)
+	}
+	if dataUsageInfo.ObjectsCount != 3 {
+		t.Errorf("expected 3 objects, got %d", dataUsageInfo.ObjectsCount)
+	}
+	if dataUsageInfo.ObjectsTotalSize != 30+2*humanize.KiByte {
+		t.Errorf("expected total size %d, got %d", 30+2*humanize.KiByte, dataUsageInfo.ObjectsTotalSize)
+	}
+	if dataUsageInfo.ObjectsSizesHistogram["LESS_THAN_1024_B"] != 2 ||
+		dataUsageInfo.ObjectsSizesHistogram["BETWEEN_1024_B_AND_1_MB"] != 1 {
+		t.Errorf("unexpected objects sizes histogram %v", dataUsageInfo.ObjectsSizesHistogram)
+	}
+
+	bucketUsage := dataUsageInfo.BucketsUsage["bucket1"]
+	if bucketUsage.ObjectsCount != 2 || bucketUsage.ObjectsTotalSize != 10+2*humanize.KiByte {
+		t.Errorf("unexpected usage of bucket1 %+v", bucketUsage)
+	}
+	if bucketUsage.ObjectsSizesHistogram["BETWEEN_1024_B_AND_1_MB"] != 1 {
+		t.Errorf("unexpected objects sizes histogram of bucket1 %v", bucketUsage.ObjectsSizesHistogram)
+	}
+	if bucketUsage = dataUsageInfo.BucketsUsage["bucket3"]; bucketUsage.ObjectsCount != 0 {
+		t.Errorf("unexpected usage of bucket3 %+v", bucketUsage)
+	}
+
+	// A recent report is not crawled again.
+	lastUpdate := dataUsageInfo.LastUpdate
+	if err = updateDataUsageInfo(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if dataUsageInfo, err = loadDataUsageFromBackend(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if !dataUsageInfo.LastUpdate.Equal(lastUpdate) {
+		t.Errorf("expected the report of %s to be kept, got %s", lastUpdate, dataUsageInfo.LastUpdate)
+	}
+}
@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -39,6 +40,9 @@ type CacheObjectLayer interface {
 	DeleteObject(ctx context.Context, bucket, object string) error
 	DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error)
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	// Derived object variants, e.g. image thumbnails.
+	GetObjectVariant(ctx context.Context, bucket, object, variant, etag string) (gr *GetObjectReader, err error)
+	PutObjectVariant(ctx context.Context, bucket, object, variant, etag string, data []byte, contentType string) error
 	// Storage operations.
 	StorageInfo(ctx context.Context) CacheStorageInfo
 }
@@ -246,6 +250,59 @@ func (c *cacheObjects) GetObjectNInfo(ctx context.Context, bucket, object string
 	return NewGetObjectReaderFromReader(teeReader, bkReader.ObjInfo, opts.CheckCopyPrecondFn, cleanupBackend, cleanupPipe)
 }
 
+// Metadata key holding the ETag of the object a cached variant
+// was derived from.
+const cacheVariantSourceETag = ReservedMetadataPrefix + "variant-source-etag"
+
+// Cache entry name of an object variant, NUL never appears in
+// object names so variants cannot collide with cached objects.
+func cacheVariantName(object, variant string) string {
+	return object + "\x00" + variant
+}
+
+// GetObjectVariant returns the cached variant of an object, stale
+// variants derived from a different object ETag are removed.
+func (c *cacheObjects) GetObjectVariant(ctx context.Context, bucket, object, variant, etag string) (*GetObjectReader, error) {
+	if c.isCacheExclude(bucket, object) || c.skipCache() {
+		return nil, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	name := cacheVariantName(object, variant)
+	dcache, err := c.getCacheLoc(ctx, bucket, name)
+	if err != nil {
+		return nil, err
+	}
+	gr, err := c.get(ctx, dcache, bucket, name, nil, http.Header{}, ObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if gr.ObjInfo.UserDefined[cacheVariantSourceETag] != etag {
+		gr.Close()
+		c.delete(ctx, dcache, bucket, name)
+		return nil, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	return gr, nil
+}
+
+// PutObjectVariant caches a variant derived from the object with the given ETag.
+func (c *cacheObjects) PutObjectVariant(ctx context.Context, bucket, object, variant, etag string, data []byte, contentType string) error {
+	if c.isCacheExclude(bucket, object) || c.skipCache() {
+		return nil
+	}
+	name := cacheVariantName(object, variant)
+	dcache, err := c.getCacheLoc(ctx, bucket, name)
+	if err != nil {
+		return err
+	}
+	size := int64(len(data))
+	return c.put(ctx, dcache, bucket, name, bytes.NewReader(data), size, ObjectOptions{
+		UserDefined: map[string]string{
+			"etag":                 getMD5Hash(data),
+			"content-type":         contentType,
+			cacheVariantSourceETag: etag,
+		},
+	})
+}
+
 // Returns ObjectInfo from cache if available.
 func (c *cacheObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error) {
 	getObjectInfoFn := c.GetObjectInfoFn
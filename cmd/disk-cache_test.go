@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"context"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"testing"
 
@@ -254,6 +255,62 @@ func TestDiskCache(t *testing.T) {
 	}
 }
 
+// Test caching of derived object variants.
+func TestCacheObjectVariant(t *testing.T) {
+	fsDirs, err := getRandomDisks(1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d, err := initDiskCaches(fsDirs, 100, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := cacheObjects{cache: d, nsMutex: newNSLock(false)}
+
+	ctx := context.Background()
+	bucketName := "testbucket"
+	objectName := "testobject"
+	sourceETag := "061208c10af71a30c6dcd6cf5d89f0fe"
+	content := []byte("thumbnail")
+
+	if _, err = c.GetObjectVariant(ctx, bucketName, objectName, "16x", sourceETag); err == nil {
+		t.Fatal("Expected variant to be missing from cache")
+	}
+	if err = c.PutObjectVariant(ctx, bucketName, objectName, "16x", sourceETag, content, "image/png"); err != nil {
+		t.Fatal(err)
+	}
+	if d[0].Exists(ctx, bucketName, objectName) {
+		t.Fatal("Expected variant not to be cached as the object itself")
+	}
+
+	gr, err := c.GetObjectVariant(ctx, bucketName, objectName, "16x", sourceETag)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, content) {
+		t.Errorf("wrong cached variant content")
+	}
+	if gr.ObjInfo.ContentType != "image/png" {
+		t.Errorf("Expected content type image/png, got %s", gr.ObjInfo.ContentType)
+	}
+
+	if _, err = c.GetObjectVariant(ctx, bucketName, objectName, "32x", sourceETag); err == nil {
+		t.Fatal("Expected a different variant to be missing from cache")
+	}
+	// Variants of a replaced object are stale and removed.
+	if _, err = c.GetObjectVariant(ctx, bucketName, objectName, "16x", "new-etag"); err == nil {
+		t.Fatal("Expected stale variant not to be served")
+	}
+	if d[0].Exists(ctx, bucketName, cacheVariantName(objectName, "16x")) {
+		t.Fatal("Expected stale variant to be removed from cache")
+	}
+}
+
 // Test diskCache with upper bound on max cache use.
 func TestDiskCacheMaxUse(t *testing.T) {
 	fsDirs, err := getRandomDisks(1)
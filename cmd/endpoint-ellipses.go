@@ -35,6 +35,10 @@ const (
 	serverCommandLineArgsMax = 32
 )
 
+// Separates the endpoints of server pools on the command line, for example
+// `minio server http://host{1...4}/disk{1...4} + http://host{5...8}/disk{1...4}`
+const serverPoolSeparator = "+"
+
 // Endpoint set represents parsed ellipses values, also provides
 // methods to get the sets of endpoints.
 type endpointSet struct {
@@ -281,3 +285,106 @@ func createServerEndpoints(serverAddr string, args ...string) (string, EndpointL
 
 	return serverAddr, endpoints, setupType, len(setArgs), len(setArgs[0]), nil
 }
+
+// PoolEndpoints - endpoints of a server pool, a pool is formatted
+// independently and added to a deployment to expand its capacity.
+type PoolEndpoints struct {
+	SetCount     int
+	DrivesPerSet int
+	Endpoints    EndpointList
+}
+
+// splitServerPoolArgs - splits input args into the args of each server pool.
+func splitServerPoolArgs(args ...string) ([][]string, error) {
+	var poolArgs [][]string
+	var pool []string
+	for _, arg := range args {
+		if arg != serverPoolSeparator {
+			pool = append(pool, arg)
+			continue
+		}
+		if len(pool) == 0 {
+			return nil, config.ErrInvalidErasureEndpoints(nil).Msg("server pool has no endpoints")
+		}
+		poolArgs = append(poolArgs, pool)
+		pool = nil
+	}
+	if len(pool) == 0 {
+		return nil, config.ErrInvalidErasureEndpoints(nil).Msg("server pool has no endpoints")
+	}
+	return append(poolArgs, pool), nil
+}
+
+// createServerPoolEndpoints - validates and creates new endpoints of all
+// server pools from input args, pools are separated by a standalone `+`.
+// All endpoints are returned as well, in the order of the pools.
+func createServerPoolEndpoints(serverAddr string, args ...string) (string, []PoolEndpoints, EndpointList, SetupType, error) {
+	poolArgs, err := splitServerPoolArgs(args...)
+	if err != nil {
+		return serverAddr, nil, nil, -1, err
+	}
+
+	if len(poolArgs) == 1 {
+		var endpoints EndpointList
+		var setupType SetupType
+		var setCount, drivesPerSet int
+		serverAddr, endpoints, setupType, setCount, drivesPerSet, err = createServerEndpoints(serverAddr, poolArgs[0]...)
+		if err != nil {
+			return serverAddr, nil, nil, -1, err
+		}
+		pools := []PoolEndpoints{{
+			SetCount:     setCount,
+			DrivesPerSet: drivesPerSet,
+			Endpoints:    endpoints,
+		}}
+		return serverAddr, pools, endpoints, setupType, nil
+	}
+
+	var allSetArgs [][]string
+	var pools []PoolEndpoints
+	uniqueArgs := set.NewStringSet()
+	for _, pargs := range poolArgs {
+		setArgs, err := GetAllSets(pargs...)
+		if err != nil {
+			return serverAddr, nil, nil, -1, err
+		}
+		if len(setArgs) == 1 && len(setArgs[0]) == 1 {
+			return serverAddr, nil, nil, -1, config.ErrInvalidErasureEndpoints(nil).Msg("server pools are not supported in FS mode")
+		}
+		if len(pools) > 0 && len(setArgs[0]) != pools[0].DrivesPerSet {
+			return serverAddr, nil, nil, -1, config.ErrInvalidErasureEndpoints(nil).Msg(
+				fmt.Sprintf("all server pools should have the same number of drives per set (%d), found %d",
+					pools[0].DrivesPerSet, len(setArgs[0])))
+		}
+		for _, sargs := range setArgs {
+			for _, arg := range sargs {
+				if uniqueArgs.Contains(arg) {
+					return serverAddr, nil, nil, -1, config.ErrInvalidErasureEndpoints(nil).Msg(
+						fmt.Sprintf("Input args (%s) are used by more than one server pool", arg))
+				}
+				uniqueArgs.Add(arg)
+			}
+		}
+		pools = append(pools, PoolEndpoints{
+			SetCount:     len(setArgs),
+			DrivesPerSet: len(setArgs[0]),
+		})
+		allSetArgs = append(allSetArgs, setArgs...)
+	}
+
+	// Endpoints of all pools are validated together, such that
+	// the whole deployment shares the same setup type.
+	serverAddr, endpoints, setupType, err := CreateEndpoints(serverAddr, allSetArgs...)
+	if err != nil {
+		return serverAddr, nil, nil, -1, err
+	}
+
+	start := 0
+	for i := range pools {
+		end := start + pools[i].SetCount*pools[i].DrivesPerSet
+		pools[i].Endpoints = endpoints[start:end]
+		start = end
+	}
+
+	return serverAddr, pools, endpoints, setupType, nil
+}
@@ -65,6 +65,57 @@ func TestCreateServerEndpoints(t *testing.T) {
 	}
 }
 
+// Test tests calculating endpoints of server pools.
+func TestCreateServerPoolEndpoints(t *testing.T) {
+	testCases := []struct {
+		serverAddr      string
+		args            []string
+		expectedPools   []int
+		expectedSuccess bool
+	}{
+		// Missing endpoints of a server pool.
+		{":9000", []string{"+", "/export{1...16}"}, nil, false},
+		{":9000", []string{"/export{1...16}", "+"}, nil, false},
+		{":9000", []string{"/export{1...16}", "+", "+", "/export{17...32}"}, nil, false},
+		// Server pools are not supported in FS mode.
+		{":9000", []string{"/export1", "+", "/export{2...17}"}, nil, false},
+		// Server pools need the same number of drives per set.
+		{":9000", []string{"/export{1...16}", "+", "/export{17...24}"}, nil, false},
+		// Endpoints cannot be used by more than one server pool.
+		{":9000", []string{"/export{1...16}", "+", "/export{9...24}"}, nil, false},
+		// Valid inputs.
+		{":9000", []string{"/export{1...16}"}, []int{16}, true},
+		{":9000", []string{"/export{1...16}", "+", "/export{17...48}"}, []int{16, 32}, true},
+		{":9001", []string{"http://localhost:9001/export{1...16}", "+", "http://localhost:9001/export{17...32}"}, []int{16, 16}, true},
+	}
+
+	for i, testCase := range testCases {
+		_, pools, endpoints, _, err := createServerPoolEndpoints(testCase.serverAddr, testCase.args...)
+		if err != nil && testCase.expectedSuccess {
+			t.Errorf("Test %d: Expected success but failed instead %s", i+1, err)
+		}
+		if err == nil && !testCase.expectedSuccess {
+			t.Errorf("Test %d: Expected failure but passed instead", i+1)
+		}
+		if err != nil {
+			continue
+		}
+		if len(pools) != len(testCase.expectedPools) {
+			t.Fatalf("Test %d: Expected %d server pools, got %d", i+1, len(testCase.expectedPools), len(pools))
+		}
+		var total int
+		for j, pool := range pools {
+			if len(pool.Endpoints) != testCase.expectedPools[j] || pool.SetCount*pool.DrivesPerSet != testCase.expectedPools[j] {
+				t.Errorf("Test %d: Expected server pool %d with %d endpoints, got %d", i+1, j+1, testCase.expectedPools[j], len(pool.Endpoints))
+			}
+			total += len(pool.Endpoints)
+		}
+		if total != len(endpoints) {
+			t.Errorf("Test %d: Expected %d endpoints, got %d", i+1, total, len(endpoints))
+		}
+	}
+}
+
 func TestGetDivisibleSize(t *testing.T) {
 	testCases := []struct {
 		totalSizes []uint64
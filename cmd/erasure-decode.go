@@ -17,6 +17,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"sync"
@@ -132,26 +133,27 @@ func (p *parallelReader) Read() ([][]byte, error) {
 	return nil, errXLReadQuorum
 }
 
-// Decode reads from readers, reconstructs data if needed and writes the data to the writer.
-func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64) error {
-	if offset < 0 || length < 0 {
-		logger.LogIf(ctx, errInvalidArgument)
-		return errInvalidArgument
-	}
-	if offset+length > totalLength {
-		logger.LogIf(ctx, errInvalidArgument)
-		return errInvalidArgument
-	}
-	if length == 0 {
-		return nil
-	}
+// Default and maximum number of erasure blocks a GET reads from the
+// disks ahead of the block written to the client, every block read
+// ahead holds up to one erasure block size of memory. Reading ahead
+// is disabled by default.
+const (
+	xlDefaultReadAhead = 0
+	xlMaxReadAhead     = 16
+)
 
-	reader := newParallelReader(readers, e, offset, totalLength)
+// blockRange - range of the requested data within an erasure block.
+type blockRange struct {
+	offset, length int64
+}
 
+// blockRanges - returns the ranges of the erasure blocks holding length
+// bytes of data at offset, in order.
+func (e Erasure) blockRanges(offset, length int64) []blockRange {
 	startBlock := offset / e.blockSize
 	endBlock := (offset + length) / e.blockSize
 
-	var bytesWritten int64
+	var ranges []blockRange
 	for block := startBlock; block <= endBlock; block++ {
 		var blockOffset, blockLength int64
 		switch {
@@ -171,19 +173,136 @@ func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.Read
 		if blockLength == 0 {
 			break
 		}
-		bufs, err := reader.Read()
-		if err != nil {
-			return err
+		ranges = append(ranges, blockRange{blockOffset, blockLength})
+	}
+	return ranges
+}
+
+// decodeBlock reads the next block from reader, reconstructs data if
+// needed and writes the range r of the block to the writer.
+func (e Erasure) decodeBlock(ctx context.Context, writer io.Writer, reader *parallelReader, r blockRange) (int64, error) {
+	bufs, err := reader.Read()
+	if err != nil {
+		return 0, err
+	}
+	if err = e.DecodeDataBlocks(bufs); err != nil {
+		logger.LogIf(ctx, err)
+		return 0, err
+	}
+	return writeDataBlocks(ctx, writer, bufs, e.dataBlocks, r.offset, r.length)
+}
+
+// Buffers holding decoded blocks until they are written.
+var readAheadBufPool = sync.Pool{
+	New: func() interface{} {
+		return new(bytes.Buffer)
+	},
+}
+
+// decodeReadAhead decodes the blocks like Decode, but reads up to
+// readAhead blocks from the disks while earlier blocks are written,
+// so that slow writers and slow disks do not wait for each other.
+func (e Erasure) decodeReadAhead(ctx context.Context, writer io.Writer, reader *parallelReader, ranges []blockRange, readAhead int) (int64, error) {
+	type decodedBlock struct {
+		buf *bytes.Buffer
+		err error
+	}
+
+	// One buffer is written while the others are filled.
+	freeCh := make(chan *bytes.Buffer, readAhead+1)
+	for i := 0; i < readAhead+1; i++ {
+		freeCh <- readAheadBufPool.Get().(*bytes.Buffer)
+	}
+	decodedCh := make(chan decodedBlock, readAhead)
+	doneCh := make(chan struct{})
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer close(decodedCh)
+		for _, r := range ranges {
+			var buf *bytes.Buffer
+			select {
+			case buf = <-freeCh:
+			case <-doneCh:
+				return
+			}
+			buf.Reset()
+			_, err := e.decodeBlock(ctx, buf, reader, r)
+			select {
+			case decodedCh <- decodedBlock{buf, err}:
+			case <-doneCh:
+				return
+			}
+			if err != nil {
+				return
+			}
 		}
-		if err = e.DecodeDataBlocks(bufs); err != nil {
-			logger.LogIf(ctx, err)
-			return err
+	}()
+
+	// The readers must not be used anymore once Decode returns.
+	defer func() {
+		close(doneCh)
+		wg.Wait()
+		close(freeCh)
+		for buf := range freeCh {
+			readAheadBufPool.Put(buf)
+		}
+		for block := range decodedCh {
+			readAheadBufPool.Put(block.buf)
+		}
+	}()
+
+	var bytesWritten int64
+	for block := range decodedCh {
+		err := block.err
+		if err == nil {
+			var n int64
+			n, err = block.buf.WriteTo(writer)
+			bytesWritten += n
 		}
-		n, err := writeDataBlocks(ctx, writer, bufs, e.dataBlocks, blockOffset, blockLength)
+		// All buffers fit into freeCh, this never blocks.
+		freeCh <- block.buf
+		if err != nil {
+			return bytesWritten, err
+		}
+	}
+	return bytesWritten, nil
+}
+
+// Decode reads from readers, reconstructs data if needed and writes the data to the writer.
+func (e Erasure) Decode(ctx context.Context, writer io.Writer, readers []io.ReaderAt, offset, length, totalLength int64) error {
+	if offset < 0 || length < 0 {
+		logger.LogIf(ctx, errInvalidArgument)
+		return errInvalidArgument
+	}
+	if offset+length > totalLength {
+		logger.LogIf(ctx, errInvalidArgument)
+		return errInvalidArgument
+	}
+	if length == 0 {
+		return nil
+	}
+
+	reader := newParallelReader(readers, e, offset, totalLength)
+	ranges := e.blockRanges(offset, length)
+
+	var bytesWritten int64
+	if readAhead := globalXLReadAhead; readAhead > 0 && len(ranges) > 1 {
+		n, err := e.decodeReadAhead(ctx, writer, reader, ranges, readAhead)
 		if err != nil {
 			return err
 		}
-		bytesWritten += n
+		bytesWritten = n
+	} else {
+		for _, r := range ranges {
+			n, err := e.decodeBlock(ctx, writer, reader, r)
+			if err != nil {
+				return err
+			}
+			bytesWritten += n
+		}
 	}
 	if bytesWritten != length {
 		logger.LogIf(ctx, errLessData)
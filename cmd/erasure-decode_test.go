@@ -199,6 +199,82 @@ func TestErasureDecode(t *testing.T) {
 	}
 }
 
+// badWriter - fails all writes after the first n bytes.
+type badWriter struct {
+	n int
+}
+
+func (w *badWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		n := w.n
+		w.n = 0
+		return n, errUnexpected
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+// Test erasureDecode with and without reading blocks ahead.
+func TestErasureDecodeReadAhead(t *testing.T) {
+	defer func(readAhead int) { globalXLReadAhead = readAhead }(globalXLReadAhead)
+
+	dataBlocks, parityBlocks := 4, 4
+	blockSize := int64(64 * humanize.KiByte)
+	setup, err := newErasureTestSetup(dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer setup.Remove()
+	erasure, err := NewErasure(context.Background(), dataBlocks, parityBlocks, blockSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := make([]byte, 10*blockSize+7)
+	if _, err = io.ReadFull(crand.Reader, data); err != nil {
+		t.Fatal(err)
+	}
+	length := int64(len(data))
+	writers := make([]io.Writer, len(setup.disks))
+	for i, disk := range setup.disks {
+		writers[i] = newBitrotWriter(disk, "testbucket", "object", erasure.ShardFileSize(length), DefaultBitrotAlgorithm, erasure.ShardSize())
+	}
+	buffer := make([]byte, blockSize, 2*blockSize)
+	_, err = erasure.Encode(context.Background(), bytes.NewReader(data), writers, buffer, erasure.dataBlocks+1)
+	closeBitrotWriters(writers)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decode := func(writer io.Writer, offset, readLen int64) error {
+		bitrotReaders := make([]io.ReaderAt, len(setup.disks))
+		for i, disk := range setup.disks {
+			tillOffset := erasure.ShardFileTillOffset(offset, readLen, length)
+			bitrotReaders[i] = newStreamingBitrotReader(disk, "testbucket", "object", tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
+		}
+		defer closeBitrotReaders(bitrotReaders)
+		return erasure.Decode(context.Background(), writer, bitrotReaders, offset, readLen, length)
+	}
+
+	for _, readAhead := range []int{0, 1, 4, xlMaxReadAhead} {
+		globalXLReadAhead = readAhead
+		for _, offset := range []int64{0, 13, blockSize, 3*blockSize - 1} {
+			var buf bytes.Buffer
+			if err = decode(&buf, offset, length-offset); err != nil {
+				t.Fatalf("Read ahead %d, offset %d: %v", readAhead, offset, err)
+			}
+			if !bytes.Equal(buf.Bytes(), data[offset:]) {
+				t.Fatalf("Read ahead %d, offset %d: read returns wrong content", readAhead, offset)
+			}
+		}
+
+		// Failing writes stop the decoding.
+		if err = decode(&badWriter{n: int(2*blockSize + 1)}, 0, length); err != errUnexpected {
+			t.Fatalf("Read ahead %d: expected %v, got %v", readAhead, errUnexpected, err)
+		}
+	}
+}
+
 // Test erasureDecode with random offset and lengths.
 // This test is t.Skip()ed as it a long time to run, hence should be run
 // explicitly after commenting out t.Skip()
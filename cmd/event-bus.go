@@ -0,0 +1,225 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/minio/minio/cmd/config/eventbus"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/nats-io/nats.go"
+)
+
+// Number of event IDs remembered to drop events delivered twice
+// by the bus, e.g. when a publish is retried after a reconnect.
+const eventBusDedupSize = 10000
+
+// eventBusMessage - an event published on the internal event bus.
+type eventBusMessage struct {
+	Node   string      `json:"node"`
+	ID     string      `json:"id"`
+	Bucket string      `json:"bucket"`
+	Event  event.Event `json:"event"`
+}
+
+// eventBusDedup - remembers the IDs of the last received events.
+type eventBusDedup struct {
+	mu   sync.Mutex
+	ids  map[string]struct{}
+	ring []string
+	next int
+}
+
+func newEventBusDedup(size int) *eventBusDedup {
+	return &eventBusDedup{
+		ids:  make(map[string]struct{}, size),
+		ring: make([]string, size),
+	}
+}
+
+// Seen - records id, returns true if it was recorded before.
+func (d *eventBusDedup) Seen(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.ids[id]; ok {
+		return true
+	}
+	delete(d.ids, d.ring[d.next])
+	d.ring[d.next] = id
+	d.ids[id] = struct{}{}
+	d.next = (d.next + 1) % len(d.ring)
+	return false
+}
+
+// eventBus - fans out the bucket events of a node to all other nodes
+// through a NATS server, in place of registering every listener on
+// every peer.
+type eventBus struct {
+	conn    *nats.Conn
+	sub     *nats.Subscription
+	subject string
+	// node uniquely identifies this process on the bus.
+	node    string
+	seq     uint64
+	seen    *eventBusDedup
+	handler func(bucketName string, eventData event.Event)
+}
+
+// handle - passes an event published by another node to the handler.
+func (bus *eventBus) handle(data []byte) {
+	var msg eventBusMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		logger.LogIf(context.Background(), err)
+		return
+	}
+	// Events of this node are delivered locally by NotificationSys.Send.
+	if msg.Node == bus.node || bus.seen.Seen(msg.ID) {
+		return
+	}
+	bus.handler(msg.Bucket, msg.Event)
+}
+
+// Publish - sends an event of this node to all other nodes.
+func (bus *eventBus) Publish(bucketName string, eventData event.Event) error {
+	data, err := json.Marshal(eventBusMessage{
+		Node:   bus.node,
+		ID:     fmt.Sprintf("%s-%d", bus.node, atomic.AddUint64(&bus.seq, 1)),
+		Bucket: bucketName,
+		Event:  eventData,
+	})
+	if err != nil {
+		return err
+	}
+	return bus.conn.Publish(bus.subject, data)
+}
+
+// Close - unsubscribes and closes the connection to the NATS server.
+func (bus *eventBus) Close() {
+	if bus.sub != nil {
+		logger.LogIf(context.Background(), bus.sub.Unsubscribe())
+	}
+	bus.conn.Close()
+}
+
+// newEventBus - connects to the NATS server of cfg, handler is
+// called with the events published by the other nodes.
+func newEventBus(cfg eventbus.Config, handler func(bucketName string, eventData event.Event)) (*eventBus, error) {
+	options := nats.GetDefaultOptions()
+	options.Url = "nats://" + cfg.Address
+	options.User = cfg.Username
+	options.Password = cfg.Password
+	options.Token = cfg.Token
+	options.Secure = cfg.Secure
+	// Keep reconnecting for as long as the server runs.
+	options.MaxReconnect = -1
+
+	conn, err := options.Connect()
+	if err != nil {
+		return nil, err
+	}
+
+	bus := &eventBus{
+		conn:    conn,
+		subject: cfg.Subject,
+		node:    mustGetUUID(),
+		seen:    newEventBusDedup(eventBusDedupSize),
+		handler: handler,
+	}
+	bus.sub, err = conn.Subscribe(cfg.Subject, func(msg *nats.Msg) {
+		bus.handle(msg.Data)
+	})
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return bus, nil
+}
+
+// InitEventBus - connects to the event bus, ListenBucketNotification
+// clients of this node receive the events of all nodes through it.
+func (sys *NotificationSys) InitEventBus(cfg eventbus.Config) error {
+	bus, err := newEventBus(cfg, sys.sendListeners)
+	if err != nil {
+		return err
+	}
+
+	sys.Lock()
+	sys.bus = bus
+	sys.Unlock()
+	return nil
+}
+
+// EventBusEnabled - returns true if events are fanned out through
+// the event bus instead of peer listeners.
+func (sys *NotificationSys) EventBusEnabled() bool {
+	sys.RLock()
+	defer sys.RUnlock()
+	return sys.bus != nil
+}
+
+// CloseEventBus - disconnects from the event bus.
+func (sys *NotificationSys) CloseEventBus() {
+	sys.Lock()
+	bus := sys.bus
+	sys.bus = nil
+	sys.Unlock()
+
+	if bus != nil {
+		bus.Close()
+	}
+}
+
+// sendListeners - sends an event of another node to the matching
+// ListenBucketNotification clients of this node, the other targets
+// are notified by the node where the event happened.
+func (sys *NotificationSys) sendListeners(bucketName string, eventData event.Event) {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return
+	}
+
+	sys.RLock()
+	targetIDSet := sys.bucketRulesMap[bucketName].Match(eventData.EventName, objectName)
+	sys.RUnlock()
+
+	var targetIDs []event.TargetID
+	for targetID := range targetIDSet {
+		if strings.HasPrefix(targetID.ID, "httpclient+") {
+			targetIDs = append(targetIDs, targetID)
+		}
+	}
+	if len(targetIDs) == 0 {
+		return
+	}
+
+	for _, err := range sys.send(bucketName, eventData, targetIDs...) {
+		reqInfo := &logger.ReqInfo{BucketName: bucketName, ObjectName: objectName}
+		reqInfo.AppendTags("EventName", eventData.EventName.String())
+		reqInfo.AppendTags("targetID", err.ID.Name)
+		ctx := logger.SetReqInfo(context.Background(), reqInfo)
+		logger.LogOnceIf(ctx, err.Err, err.ID)
+	}
+}
@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestEventBusDedup(t *testing.T) {
+	d := newEventBusDedup(2)
+	for i, testCase := range []struct {
+		id   string
+		seen bool
+	}{
+		{"a", false},
+		{"a", true},
+		{"b", false},
+		{"c", false},
+		// "a" was evicted by "c".
+		{"a", false},
+		{"c", true},
+	} {
+		if seen := d.Seen(testCase.id); seen != testCase.seen {
+			t.Errorf("Test %d: expected %v for %s, got %v", i+1, testCase.seen, testCase.id, seen)
+		}
+	}
+}
+
+func TestEventBusHandle(t *testing.T) {
+	webhook := &gatewayEventTarget{id: event.TargetID{ID: "1", Name: "webhook"}, events: make(chan event.Event, 4)}
+	listener := &gatewayEventTarget{id: event.TargetID{ID: "httpclient+1", Name: "listener"}, events: make(chan event.Event, 4)}
+	targetList := event.NewTargetList()
+	sys := &NotificationSys{
+		targetList:                 targetList,
+		bucketRulesMap:             make(map[string]event.RulesMap),
+		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+	}
+	if err := targetList.Add(webhook); err != nil {
+		t.Fatal(err)
+	}
+	sys.AddRulesMap("bucket", event.NewRulesMap([]event.Name{event.ObjectCreatedAll}, "*", webhook.ID()))
+	if err := sys.AddRemoteTarget("bucket", listener, event.NewRulesMap([]event.Name{event.ObjectCreatedAll}, "dir/*", listener.ID())); err != nil {
+		t.Fatal(err)
+	}
+
+	bus := &eventBus{
+		node:    "node1",
+		seen:    newEventBusDedup(eventBusDedupSize),
+		handler: sys.sendListeners,
+	}
+	newMessage := func(node, id, key string) []byte {
+		data, err := json.Marshal(eventBusMessage{
+			Node:   node,
+			ID:     id,
+			Bucket: "bucket",
+			Event: event.Event{
+				EventName: event.ObjectCreatedPut,
+				S3: event.Metadata{
+					Bucket: event.Bucket{Name: "bucket"},
+					Object: event.Object{Key: key},
+				},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	// Published by this node.
+	bus.handle(newMessage("node1", "node1-1", "dir%2Fobject"))
+	// Delivered twice by the bus.
+	bus.handle(newMessage("node2", "node2-1", "dir%2Fobject"))
+	bus.handle(newMessage("node2", "node2-1", "dir%2Fobject"))
+	// Not matching the listener rules.
+	bus.handle(newMessage("node2", "node2-2", "object"))
+
+	if n := len(listener.events); n != 1 {
+		t.Fatalf("expected 1 event sent to the listener, got %d", n)
+	}
+	if ev := <-listener.events; ev.S3.Object.Key != "dir%2Fobject" {
+		t.Errorf("unexpected event %+v", ev)
+	}
+	if n := len(webhook.events); n != 0 {
+		t.Errorf("expected no events sent to other targets, got %d", n)
+	}
+}
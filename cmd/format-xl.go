@@ -725,9 +725,13 @@ func fixFormatXLV3(storageDisks []StorageAPI, endpoints EndpointList, formats []
 	return nil
 }
 
-// initFormatXL - save XL format configuration on all disks.
-func initFormatXL(ctx context.Context, storageDisks []StorageAPI, setCount, disksPerSet int) (format *formatXLV3, err error) {
+// initFormatXL - save XL format configuration on all disks, disks
+// added to an existing deployment are formatted with its deploymentID.
+func initFormatXL(ctx context.Context, storageDisks []StorageAPI, setCount, disksPerSet int, deploymentID string) (format *formatXLV3, err error) {
 	format = newFormatXLV3(setCount, disksPerSet)
+	if deploymentID != "" {
+		format.ID = deploymentID
+	}
 	formats := make([]*formatXLV3, len(storageDisks))
 
 	for i := 0; i < setCount; i++ {
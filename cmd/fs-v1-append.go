@@ -0,0 +1,190 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	mioutil "github.com/minio/minio/pkg/ioutil"
+)
+
+// objectAppender - implemented by object layers which can append
+// data to an existing object in place.
+type objectAppender interface {
+	AppendObject(ctx context.Context, bucket, object string, position int64, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
+}
+
+// AppendObject - appends data to the object at position, which has to
+// be the current size of the object. An object which does not exist yet
+// is created when position is 0.
+//
+// Like the background append of multipart uploads, the data is written
+// to a temporary file first and then appended to the object, each
+// append is recorded as a part of the object so that its ETag is
+// computed the same way as the ETag of a multipart object.
+func (fs *FSObjects) AppendObject(ctx context.Context, bucket, object string, position int64, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkPutObjectArgs(ctx, bucket, object, fs, r.Size()); err != nil {
+		return ObjectInfo{}, err
+	}
+	if hasSuffix(object, SlashSeparator) {
+		return ObjectInfo{}, ObjectNameInvalid{Bucket: bucket, Object: object}
+	}
+
+	// Lock the object.
+	objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
+	if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, err
+	}
+	defer objectLock.Unlock()
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		err = toObjectErr(err, bucket, object)
+		if !isErrObjectNotFound(err) {
+			return ObjectInfo{}, err
+		}
+		if position != 0 {
+			return ObjectInfo{}, InvalidAppendPosition{Bucket: bucket, Object: object, Position: position}
+		}
+		// The first append creates the object, it is never
+		// packed so that it can be appended to.
+		return fs.putObject(ctx, bucket, object, r, opts)
+	}
+
+	if position != oi.Size {
+		return ObjectInfo{}, InvalidAppendPosition{Bucket: bucket, Object: object, Position: position, Size: oi.Size}
+	}
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+	}
+	if crypto.IsEncrypted(oi.UserDefined) || oi.IsCompressed() {
+		return ObjectInfo{}, ObjectNotAppendable{Bucket: bucket, Object: object}
+	}
+	// Packed objects are immutable within their slab.
+	if _, ok := fs.pack.Get(bucket, object); ok {
+		return ObjectInfo{}, ObjectNotAppendable{Bucket: bucket, Object: object}
+	}
+
+	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	// This close will allow for locks to be synchronized on `fs.json`.
+	defer wlk.Close()
+
+	// Appended objects keep their metadata in `fs.json`.
+	fsObjPath := fs.bucketPath(bucket, object)
+	fsMeta, err := fsReadInlineMeta(fsObjPath)
+	inlineMeta := err == nil
+	if !inlineMeta {
+		fsMeta = newFSMetaV1()
+		if _, err = fsMeta.ReadFrom(ctx, wlk); err != nil {
+			// Pre-existing data without `fs.json`.
+			fsMeta = fs.defaultFsJSON(object)
+		}
+	}
+	if fsMeta.Meta == nil {
+		fsMeta.Meta = make(map[string]string)
+	}
+	if len(fsMeta.Parts) == 0 {
+		// The object as it is now becomes the first part.
+		fsMeta.Parts = []ObjectPartInfo{{
+			Number:     1,
+			ETag:       oi.ETag,
+			Size:       oi.Size,
+			ActualSize: oi.Size,
+		}}
+	}
+	partNumber := fsMeta.Parts[len(fsMeta.Parts)-1].Number + 1
+	if partNumber > globalMaxPartID {
+		return ObjectInfo{}, ObjectNotAppendable{Bucket: bucket, Object: object}
+	}
+
+	data := r.Reader
+
+	// Allocate a buffer to Read() from request body
+	bufSize := int64(readSizeV1)
+	if size := data.Size(); size > 0 && bufSize > size {
+		bufSize = size
+	}
+
+	buf := make([]byte, int(bufSize))
+	fsTmpObjPath := pathJoin(fs.fsTmpDir, mustGetUUID())
+	bytesWritten, err := fsCreateFile(ctx, fsTmpObjPath, data, buf, data.Size())
+	// Delete the temporary file once it is appended or on failure.
+	defer fsRemoveFile(ctx, fsTmpObjPath)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if bytesWritten < data.Size() {
+		return ObjectInfo{}, IncompleteBody{}
+	}
+
+	if err = fs.unshareObjectFile(ctx, bucket, fsObjPath); err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if err = mioutil.AppendFile(fsObjPath, fsTmpObjPath); err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	fsMeta.Parts = append(fsMeta.Parts, ObjectPartInfo{
+		Number:     partNumber,
+		ETag:       r.MD5CurrentHexString(),
+		Size:       bytesWritten,
+		ActualSize: bytesWritten,
+	})
+	completeParts := make([]CompletePart, len(fsMeta.Parts))
+	for i, part := range fsMeta.Parts {
+		completeParts[i] = CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+	fsMeta.Meta["etag"] = getCompleteMultipartMD5(completeParts)
+	// The checksum sent on upload does not match the appended data.
+	delete(fsMeta.Meta, ObjectChecksumKey)
+
+	if _, err = fsMeta.WriteTo(wlk); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if inlineMeta {
+		if err = fsRemoveMetaXattr(fsObjPath); err != nil {
+			logger.LogIf(ctx, err)
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+	}
+
+	// Stat the file to fetch timestamp, size.
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+}
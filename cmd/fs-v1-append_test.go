@@ -0,0 +1,93 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+)
+
+// TestFSAppendObject - tests appending to objects in FS mode.
+func TestFSAppendObject(t *testing.T) {
+	obj, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	fs := obj.(*FSObjects)
+	bucketName := "bucket"
+	objectName := "logs/app.log"
+	if err = fs.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	appendObject := func(position int64, data string) (ObjectInfo, error) {
+		return fs.AppendObject(context.Background(), bucketName, objectName, position,
+			mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), getMD5Hash([]byte(data)), ""), ObjectOptions{})
+	}
+
+	// Appending at a non-zero position does not create the object.
+	if _, err = appendObject(5, "hello"); err == nil {
+		t.Fatal("Expected append at position 5 of a missing object to fail")
+	} else if _, ok := err.(InvalidAppendPosition); !ok {
+		t.Fatalf("Expected InvalidAppendPosition, got %v", err)
+	}
+
+	objInfo, err := appendObject(0, "hello ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != 6 || objInfo.ETag != getMD5Hash([]byte("hello ")) {
+		t.Fatalf("Unexpected object after first append, size %d, etag %s", objInfo.Size, objInfo.ETag)
+	}
+
+	if objInfo, err = appendObject(6, "world"); err != nil {
+		t.Fatal(err)
+	}
+	expectedETag := getCompleteMultipartMD5([]CompletePart{
+		{PartNumber: 1, ETag: getMD5Hash([]byte("hello "))},
+		{PartNumber: 2, ETag: getMD5Hash([]byte("world"))},
+	})
+	if objInfo.Size != 11 || objInfo.ETag != expectedETag {
+		t.Fatalf("Unexpected object after second append, size %d, etag %s, expected etag %s", objInfo.Size, objInfo.ETag, expectedETag)
+	}
+
+	// Appending at a stale position fails and leaves the object as is.
+	_, err = appendObject(6, "again")
+	if e, ok := err.(InvalidAppendPosition); !ok || e.Size != 11 {
+		t.Fatalf("Expected InvalidAppendPosition with size 11, got %v", err)
+	}
+
+	var buf bytes.Buffer
+	if err = fs.GetObject(context.Background(), bucketName, objectName, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "hello world" {
+		t.Fatalf("Expected object content %q, got %q", "hello world", buf.String())
+	}
+
+	objInfo, err = fs.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.ETag != expectedETag {
+		t.Fatalf("Expected etag %s, got %s", expectedETag, objInfo.ETag)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// File under minioMetaBucket holding the aliases of the
+	// legacy bucket directories.
+	fsBucketAliasesFile = "bucket-aliases.json"
+
+	fsBucketAliasesVersionV1 = "1"
+)
+
+// fsBucketAliasesV1 - persisted aliases of the legacy bucket
+// directories, keyed by alias.
+type fsBucketAliasesV1 struct {
+	Version string            `json:"version"`
+	Aliases map[string]string `json:"aliases"`
+}
+
+// fsBucketAliases - lowercase bucket names under which directories
+// of a pre-existing FS tree are served when their names are not
+// valid S3 bucket names, e.g. `Photos` or `team_data`. Aliases are
+// persisted so that a bucket keeps its name across restarts even if
+// a conflicting directory is created later on.
+type fsBucketAliases struct {
+	mu   sync.RWMutex
+	file string
+	dirs map[string]string // alias -> directory
+}
+
+// fsBucketAliasName - returns the alias of a legacy bucket
+// directory, its name in lowercase with underscores replaced
+// by hyphens.
+func fsBucketAliasName(dir string) string {
+	return strings.Replace(strings.ToLower(dir), "_", "-", -1)
+}
+
+// loadFSBucketAliases - loads the persisted aliases and assigns an
+// alias to each directory of fsPath which is not a valid bucket name,
+// unless its alias is not a valid bucket name either or is already
+// the name of another bucket.
+func loadFSBucketAliases(fsPath string) (*fsBucketAliases, error) {
+	a := &fsBucketAliases{
+		file: pathJoin(fsPath, minioMetaBucket, fsBucketAliasesFile),
+		dirs: make(map[string]string),
+	}
+
+	data, err := ioutil.ReadFile(a.file)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	if err == nil {
+		var aliasesV1 fsBucketAliasesV1
+		if err = json.Unmarshal(data, &aliasesV1); err != nil {
+			return nil, err
+		}
+		if aliasesV1.Version != fsBucketAliasesVersionV1 {
+			return nil, errCorruptedFormat
+		}
+		a.dirs = aliasesV1.Aliases
+		if a.dirs == nil {
+			a.dirs = make(map[string]string)
+		}
+	}
+
+	entries, err := readDir(fsPath)
+	if err != nil {
+		return nil, err
+	}
+	dirs := make(map[string]bool)
+	for _, entry := range entries {
+		if hasSuffix(entry, SlashSeparator) {
+			dirs[strings.TrimSuffix(entry, SlashSeparator)] = true
+		}
+	}
+
+	changed := false
+	aliased := make(map[string]bool)
+	for alias, dir := range a.dirs {
+		if !dirs[dir] {
+			delete(a.dirs, alias)
+			changed = true
+			continue
+		}
+		aliased[dir] = true
+	}
+	for dir := range dirs {
+		if aliased[dir] || s3utils.CheckValidBucketNameStrict(dir) == nil || isMinioMetaBucket(dir) {
+			continue
+		}
+		alias := fsBucketAliasName(dir)
+		if s3utils.CheckValidBucketNameStrict(alias) != nil || isMinioReservedBucket(alias) {
+			logger.Info("Directory %s is not served as a bucket, %s is not a valid bucket name", dir, alias)
+			continue
+		}
+		if _, ok := a.dirs[alias]; ok || dirs[alias] {
+			logger.Info("Directory %s is not served as a bucket, bucket %s already exists", dir, alias)
+			continue
+		}
+		a.dirs[alias] = dir
+		changed = true
+	}
+
+	if changed {
+		if err = a.save(); err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// save - persists the aliases, the caller must hold the lock
+// when the aliases are shared.
+func (a *fsBucketAliases) save() error {
+	data, err := json.Marshal(fsBucketAliasesV1{
+		Version: fsBucketAliasesVersionV1,
+		Aliases: a.dirs,
+	})
+	if err != nil {
+		return err
+	}
+	tmpFile := a.file + ".tmp"
+	if err = ioutil.WriteFile(tmpFile, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, a.file)
+}
+
+// Dir - returns the directory of bucket, which is bucket itself
+// unless bucket is an alias.
+func (a *fsBucketAliases) Dir(bucket string) string {
+	if a == nil {
+		return bucket
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	if dir, ok := a.dirs[bucket]; ok {
+		return dir
+	}
+	return bucket
+}
+
+// Alias - returns the alias of a directory, if any.
+func (a *fsBucketAliases) Alias(dir string) (string, bool) {
+	if a == nil {
+		return "", false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for alias, d := range a.dirs {
+		if d == dir {
+			return alias, true
+		}
+	}
+	return "", false
+}
+
+// Remove - removes the alias of a deleted bucket, if any.
+func (a *fsBucketAliases) Remove(bucket string) error {
+	if a == nil {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, ok := a.dirs[bucket]; !ok {
+		return nil
+	}
+	delete(a.dirs, bucket)
+	return a.save()
+}
+
+// renameUsage - accounts the usage of the legacy bucket directories
+// found by the disk usage crawl to their aliases.
+func (a *fsBucketAliases) renameUsage(u *fsObjectsUsage) {
+	if a == nil {
+		return
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	for alias, dir := range a.dirs {
+		if usage, ok := u.BucketsUsage[dir]; ok {
+			u.BucketsUsage[alias] = usage
+			delete(u.BucketsUsage, dir)
+		}
+	}
+}
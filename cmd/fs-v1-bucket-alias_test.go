@@ -0,0 +1,103 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Tests serving legacy bucket directories of an FS tree under aliases.
+func TestFSBucketAliases(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	for _, dir := range []string{"Photos", "team_data", "My Files", "Data", "data"} {
+		if err := os.MkdirAll(filepath.Join(disk, dir), 0777); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := ioutil.WriteFile(filepath.Join(disk, "Photos", "cat.jpg"), []byte("meow"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	globalFSBucketAliases = true
+	defer func() { globalFSBucketAliases = false }()
+
+	obj := initFSObjects(disk, t)
+	ctx := context.Background()
+
+	listBuckets := func() (names []string) {
+		t.Helper()
+		buckets, err := obj.ListBuckets(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, bucket := range buckets {
+			names = append(names, bucket.Name)
+		}
+		return names
+	}
+
+	// `Data` conflicts with `data` and `my files` is not a valid
+	// bucket name, neither is aliased.
+	expected := []string{"Data", "data", "photos", "team-data"}
+	if names := listBuckets(); !reflect.DeepEqual(names, expected) {
+		t.Fatalf("Expected buckets %v, got %v", expected, names)
+	}
+
+	var buf bytes.Buffer
+	if err := obj.GetObject(ctx, "photos", "cat.jpg", 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "meow" {
+		t.Fatalf("Expected %q, got %q", "meow", buf.String())
+	}
+
+	data := []byte("report")
+	if _, err := obj.PutObject(ctx, "team-data", "report.txt", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(disk, "team_data", "report.txt")); err != nil {
+		t.Fatalf("Expected the object in the legacy directory, got %v", err)
+	}
+
+	if err := obj.MakeBucketWithLocation(ctx, "photos", ""); !isSameType(err, BucketExists{}) {
+		t.Fatalf("Expected BucketExists, got %v", err)
+	}
+
+	if err := obj.DeleteBucket(ctx, "team-data", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(disk, "team_data")); !os.IsNotExist(err) {
+		t.Fatalf("Expected the legacy directory to be removed, got %v", err)
+	}
+
+	// The aliases are persisted.
+	aliases, err := loadFSBucketAliases(disk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(aliases.dirs, map[string]string{"photos": "Photos"}) {
+		t.Fatalf("Unexpected aliases %v", aliases.dirs)
+	}
+}
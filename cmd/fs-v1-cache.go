@@ -0,0 +1,154 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// Maximum number of entries in the ObjectInfo cache.
+	fsObjInfoCacheSize = 10000
+
+	// Time an entry of the ObjectInfo cache is served for, this bounds
+	// how long changes made by other servers sharing the backend are
+	// not visible.
+	fsObjInfoCacheTTL = 5 * time.Second
+)
+
+type fsObjInfoCacheEntry struct {
+	key     nsParam
+	objInfo ObjectInfo
+	expiry  time.Time
+}
+
+// fsObjInfoCache - LRU of the ObjectInfo of recently read objects,
+// avoids opening and parsing `fs.json` for hot objects. Entries are
+// invalidated when the namespace write lock of the object or of its
+// bucket is released.
+type fsObjInfoCache struct {
+	mu      sync.Mutex
+	entries map[nsParam]*list.Element
+	lru     *list.List
+	size    int
+	ttl     time.Duration
+
+	// Incremented on every invalidation, entries read before an
+	// invalidation are not added.
+	gen uint64
+}
+
+func newFSObjInfoCache(size int, ttl time.Duration) *fsObjInfoCache {
+	return &fsObjInfoCache{
+		entries: make(map[nsParam]*list.Element),
+		lru:     list.New(),
+		size:    size,
+		ttl:     ttl,
+	}
+}
+
+// Generation - returns the current generation, must be called before
+// reading the ObjectInfo which is later passed to Set.
+func (c *fsObjInfoCache) Generation() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.gen
+}
+
+// Get - returns a copy of the cached ObjectInfo of bucket/object.
+func (c *fsObjInfoCache) Get(bucket, object string) (ObjectInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[nsParam{bucket, object}]
+	if !ok {
+		return ObjectInfo{}, false
+	}
+	entry := elem.Value.(*fsObjInfoCacheEntry)
+	if UTCNow().After(entry.expiry) {
+		c.remove(elem)
+		return ObjectInfo{}, false
+	}
+	c.lru.MoveToFront(elem)
+
+	// Callers are free to modify the metadata.
+	objInfo := entry.objInfo
+	objInfo.UserDefined = cloneUserDefined(entry.objInfo.UserDefined)
+	return objInfo, true
+}
+
+// Set - caches the ObjectInfo of bucket/object read at generation gen.
+func (c *fsObjInfoCache) Set(bucket, object string, objInfo ObjectInfo, gen uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if gen != c.gen {
+		return
+	}
+
+	objInfo.UserDefined = cloneUserDefined(objInfo.UserDefined)
+	key := nsParam{bucket, object}
+	if elem, ok := c.entries[key]; ok {
+		c.remove(elem)
+	}
+	c.entries[key] = c.lru.PushFront(&fsObjInfoCacheEntry{
+		key:     key,
+		objInfo: objInfo,
+		expiry:  UTCNow().Add(c.ttl),
+	})
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+// Invalidate - removes the entry of bucket/object, all the entries
+// of the bucket are removed when object is empty.
+func (c *fsObjInfoCache) Invalidate(bucket, object string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.gen++
+	if object != "" {
+		if elem, ok := c.entries[nsParam{bucket, object}]; ok {
+			c.remove(elem)
+		}
+		return
+	}
+	for key, elem := range c.entries {
+		if key.volume == bucket {
+			c.remove(elem)
+		}
+	}
+}
+
+func (c *fsObjInfoCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*fsObjInfoCacheEntry).key)
+}
+
+func cloneUserDefined(m map[string]string) map[string]string {
+	if m == nil {
+		return nil
+	}
+	clone := make(map[string]string, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
@@ -0,0 +1,132 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestFSObjInfoCache(t *testing.T) {
+	c := newFSObjInfoCache(2, time.Minute)
+
+	gen := c.Generation()
+	c.Set("bucket", "a", ObjectInfo{Name: "a", UserDefined: map[string]string{"etag": "1"}}, gen)
+	c.Set("bucket", "b", ObjectInfo{Name: "b"}, gen)
+
+	oi, ok := c.Get("bucket", "a")
+	if !ok || oi.Name != "a" {
+		t.Fatalf("expected a to be cached, got %v %v", oi, ok)
+	}
+	// Modifying the returned metadata must not change the cache.
+	oi.UserDefined["etag"] = "2"
+	if oi, _ = c.Get("bucket", "a"); oi.UserDefined["etag"] != "1" {
+		t.Fatalf("expected cached metadata to be unchanged, got %v", oi.UserDefined)
+	}
+
+	// b is the least recently used entry and gets evicted.
+	c.Set("bucket", "c", ObjectInfo{Name: "c"}, gen)
+	if _, ok = c.Get("bucket", "b"); ok {
+		t.Fatal("expected b to be evicted")
+	}
+
+	c.Invalidate("bucket", "a")
+	if _, ok = c.Get("bucket", "a"); ok {
+		t.Fatal("expected a to be invalidated")
+	}
+
+	// Entries read before an invalidation are not added.
+	c.Set("bucket", "a", ObjectInfo{Name: "a"}, gen)
+	if _, ok = c.Get("bucket", "a"); ok {
+		t.Fatal("expected a stale entry to be ignored")
+	}
+
+	c.Set("other", "c", ObjectInfo{Name: "c"}, c.Generation())
+	c.Invalidate("bucket", "")
+	if _, ok = c.Get("bucket", "c"); ok {
+		t.Fatal("expected bucket entries to be invalidated")
+	}
+	if _, ok = c.Get("other", "c"); !ok {
+		t.Fatal("expected entries of other buckets to be kept")
+	}
+
+	c = newFSObjInfoCache(2, time.Nanosecond)
+	c.Set("bucket", "a", ObjectInfo{Name: "a"}, c.Generation())
+	time.Sleep(time.Millisecond)
+	if _, ok = c.Get("bucket", "a"); ok {
+		t.Fatal("expected a to be expired")
+	}
+}
+
+// TestFSObjInfoCacheInvalidation - overwrites, metadata updates and deletes
+// are visible right away through GetObjectInfo.
+func TestFSObjInfoCacheInvalidation(t *testing.T) {
+	disk := pathJoin(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	bucketName, objectName := "bucket", "object"
+
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal(err)
+	}
+	put := func(data string) {
+		if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put("abc")
+	oi, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := fs.objInfoCache.Get(bucketName, objectName); !ok {
+		t.Fatal("expected object info to be cached")
+	}
+
+	put("abcdef")
+	if oi, err = obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if oi.Size != 6 {
+		t.Fatalf("expected size 6 after overwrite, got %d", oi.Size)
+	}
+
+	oi.UserDefined["X-Amz-Meta-Team"] = "storage"
+	oi.metadataOnly = true
+	if _, err = obj.CopyObject(context.Background(), bucketName, objectName, bucketName, objectName, oi, ObjectOptions{}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if oi, err = obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if oi.UserDefined["X-Amz-Meta-Team"] != "storage" {
+		t.Fatalf("expected updated metadata, got %v", oi.UserDefined)
+	}
+
+	if err = obj.DeleteObject(context.Background(), bucketName, objectName); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{}); err == nil {
+		t.Fatal("expected the deleted object to be missing")
+	}
+}
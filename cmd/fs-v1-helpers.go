@@ -24,7 +24,10 @@ import (
 	"runtime"
 
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/disk"
+	"github.com/minio/minio/pkg/ioutil"
 	"github.com/minio/minio/pkg/lock"
+	"github.com/ncw/directio"
 )
 
 // Removes only the file at given path does not remove
@@ -256,6 +259,16 @@ func fsIsFile(ctx context.Context, filePath string) bool {
 // Opens the file at given path, optionally from an offset. Upon success returns
 // a readable stream and the size of the readable stream.
 func fsOpenFile(ctx context.Context, readPath string, offset int64) (io.ReadCloser, int64, error) {
+	return fsOpenFileWithCache(ctx, readPath, offset, false)
+}
+
+// fsOpenObjectFile is like fsOpenFile, but drops the cached pages of the
+// object once the stream is closed when globalDirectIO.DropCache is set.
+func fsOpenObjectFile(ctx context.Context, readPath string, offset int64) (io.ReadCloser, int64, error) {
+	return fsOpenFileWithCache(ctx, readPath, offset, globalDirectIO.DropCache)
+}
+
+func fsOpenFileWithCache(ctx context.Context, readPath string, offset int64, dropCache bool) (io.ReadCloser, int64, error) {
 	if readPath == "" || offset < 0 {
 		logger.LogIf(ctx, errInvalidArgument)
 		return nil, 0, errInvalidArgument
@@ -295,8 +308,17 @@ func fsOpenFile(ctx context.Context, readPath string, offset int64) (io.ReadClos
 		}
 	}
 
-	// Success.
-	return fr, st.Size(), nil
+	var closer io.Closer = fr
+	if dropCache {
+		closer = dropCacheFile{fr}
+	}
+
+	// Stop reading once the request is canceled, for
+	// instance when the client disconnects.
+	return struct {
+		io.Reader
+		io.Closer
+	}{ioutil.NewContextReader(ctx, fr), closer}, st.Size(), nil
 }
 
 // Creates a file and copies data from incoming reader. Staging buffer is used by io.CopyBuffer.
@@ -321,9 +343,23 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, buf []
 		return 0, err
 	}
 
-	writer, err := lock.Open(filePath, os.O_CREATE|os.O_WRONLY, 0666)
-	if err != nil {
-		return 0, osErrToFSFileErr(err)
+	// Large objects are written with O_DIRECT, so that they
+	// don't evict the rest of the page cache.
+	directIO := globalDirectIO.Threshold > 0 && fallocSize >= globalDirectIO.Threshold
+
+	var writer *os.File
+	var err error
+	if directIO {
+		// Not all filesystems support O_DIRECT, tmpfs for instance,
+		// write through the page cache on those.
+		if writer, err = disk.OpenFileDirectIO(filePath, os.O_CREATE|os.O_WRONLY, 0666); err != nil {
+			directIO = false
+		}
+	}
+	if !directIO {
+		if writer, err = lock.Open(filePath, os.O_CREATE|os.O_WRONLY, 0666); err != nil {
+			return 0, osErrToFSFileErr(err)
+		}
 	}
 	defer writer.Close()
 
@@ -335,11 +371,23 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, buf []
 		}
 	}
 
+	// Abort the write once the request is canceled, for
+	// instance when the client disconnects.
+	reader = ioutil.NewContextReader(ctx, reader)
+
 	var bytesWritten int64
-	if buf != nil {
+	if directIO {
+		bytesWritten, err = ioutil.CopyAligned(writer, reader, directio.AlignedBlock(readSizeV1), fallocSize)
+		if err != nil {
+			if err != ctx.Err() {
+				logger.LogIf(ctx, err)
+			}
+			return 0, err
+		}
+	} else if buf != nil {
 		bytesWritten, err = io.CopyBuffer(writer, reader, buf)
 		if err != nil {
-			if err != io.ErrUnexpectedEOF {
+			if err != io.ErrUnexpectedEOF && err != ctx.Err() {
 				logger.LogIf(ctx, err)
 			}
 			return 0, err
@@ -347,7 +395,9 @@ func fsCreateFile(ctx context.Context, filePath string, reader io.Reader, buf []
 	} else {
 		bytesWritten, err = io.Copy(writer, reader)
 		if err != nil {
-			logger.LogIf(ctx, err)
+			if err != ctx.Err() {
+				logger.LogIf(ctx, err)
+			}
 			return 0, err
 		}
 	}
@@ -417,6 +467,30 @@ func fsRenameFile(ctx context.Context, sourcePath, destPath string) error {
 	return nil
 }
 
+// fsCopyFile copies the contents of sourcePath into a new file at destPath.
+func fsCopyFile(ctx context.Context, sourcePath, destPath string) error {
+	if err := checkPathLength(sourcePath); err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+
+	src, err := os.Open(sourcePath)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return osErrToFSFileErr(err)
+	}
+	defer src.Close()
+
+	fi, err := src.Stat()
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return osErrToFSFileErr(err)
+	}
+
+	_, err = fsCreateFile(ctx, destPath, src, nil, fi.Size())
+	return err
+}
+
 // fsDeleteFile is a wrapper for deleteFile(), after checking the path length.
 func fsDeleteFile(ctx context.Context, basePath, deletePath string) error {
 	if err := checkPathLength(basePath); err != nil {
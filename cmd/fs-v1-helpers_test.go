@@ -244,6 +244,73 @@ func TestFSCreateAndOpen(t *testing.T) {
 	if _, _, err = fsOpenFile(context.Background(), pathJoin(path), 0); err != errIsNotRegular {
 		t.Fatal("Unexpected error", err)
 	}
+
+	// Reads and writes stop once the context is canceled.
+	ctx, cancel := context.WithCancel(context.Background())
+	readCloser, _, err := fsOpenFile(ctx, pathJoin(path, "success-vol", "success-file"), 0)
+	if err != nil {
+		t.Fatal("Unexpected error", err)
+	}
+	defer readCloser.Close()
+	cancel()
+	if _, err = ioutil.ReadAll(readCloser); err != context.Canceled {
+		t.Fatal("Unexpected error", err)
+	}
+	if _, err = fsCreateFile(ctx, pathJoin(path, "success-vol", "canceled-file"), reader, nil, 0); err != context.Canceled {
+		t.Fatal("Unexpected error", err)
+	}
+}
+
+// TestFSCreateAndOpenDirectIO - tests writing large files with O_DIRECT
+// and dropping their cached pages after reading.
+func TestFSCreateAndOpenDirectIO(t *testing.T) {
+	_, path, err := newPosixTestSetup()
+	if err != nil {
+		t.Fatalf("Unable to create posix test setup, %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	globalDirectIO.Threshold = 4096
+	globalDirectIO.DropCache = true
+	defer func() {
+		globalDirectIO.Threshold = 0
+		globalDirectIO.DropCache = false
+	}()
+
+	// Sizes below, at and above the threshold, unaligned sizes are
+	// partially written without O_DIRECT.
+	for i, size := range []int{100, 4096, 3*4096 + 17} {
+		data := bytes.Repeat([]byte("a"), size)
+		filePath := pathJoin(path, "success-vol", "file"+mustGetUUID())
+		n, err := fsCreateFile(context.Background(), filePath, bytes.NewReader(data), make([]byte, 1024), int64(size))
+		if err != nil {
+			t.Fatalf("Test %d: Unable to create file, %s", i+1, err)
+		}
+		if n != int64(size) {
+			t.Fatalf("Test %d: Expected %d bytes written, got %d", i+1, size, n)
+		}
+
+		readCloser, _, err := fsOpenObjectFile(context.Background(), filePath, 0)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %s", i+1, err)
+		}
+		if _, ok := readCloser.(struct {
+			io.Reader
+			io.Closer
+		}).Closer.(dropCacheFile); !ok {
+			t.Fatalf("Test %d: Expected the page cache to be dropped on close", i+1)
+		}
+		got, err := ioutil.ReadAll(readCloser)
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %s", i+1, err)
+		}
+		if err = readCloser.Close(); err != nil {
+			t.Fatalf("Test %d: Unexpected error %s", i+1, err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Test %d: File content mismatch", i+1)
+		}
+	}
 }
 
 func TestFSDeletes(t *testing.T) {
@@ -0,0 +1,73 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// Largest object size accepted for MINIO_FS_INLINE_META_THRESHOLD, only
+// objects up to this size are looked up for inline metadata.
+const fsInlineMetaMaxThreshold = 1 * humanize.MiByte
+
+// canInlineMeta - returns true if the `fs.json` of an object of size
+// is stored in an extended attribute of its data file.
+func (fs *FSObjects) canInlineMeta(bucket string, size int64) bool {
+	return fs.inlineMetaThreshold > 0 && bucket != minioMetaBucket && size >= 0 && size <= fs.inlineMetaThreshold
+}
+
+// fsReadInlineMeta - reads the inline `fs.json` of the file at filePath,
+// returns errFileNotFound if the file has no inline metadata.
+func fsReadInlineMeta(filePath string) (fsMeta fsMetaV1, err error) {
+	buf, err := fsGetMetaXattr(filePath)
+	if err != nil {
+		return fsMeta, err
+	}
+	if err = json.Unmarshal(buf, &fsMeta); err != nil {
+		return fsMeta, err
+	}
+	if !isFSMetaValid(fsMeta.Version) {
+		return fsMeta, errCorruptedFormat
+	}
+	return fsMeta, nil
+}
+
+// fsWriteInlineMeta - stores fsMeta as the inline `fs.json` of the
+// file at filePath.
+func fsWriteInlineMeta(filePath string, fsMeta fsMetaV1) error {
+	buf, err := json.Marshal(fsMeta)
+	if err != nil {
+		return err
+	}
+	return fsSetMetaXattr(filePath, buf)
+}
+
+// fsInlineMetaSupported - returns true if the filesystem of dir
+// supports storing inline `fs.json`.
+func fsInlineMetaSupported(dir string) bool {
+	filePath := pathJoin(dir, mustGetUUID())
+	f, err := os.Create(filePath)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	defer os.Remove(filePath)
+	return fsWriteInlineMeta(filePath, newFSMetaV1()) == nil
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests keeping `fs.json` of small objects in an extended attribute.
+func TestFSInlineMeta(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+	if err := os.MkdirAll(disk, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if !fsInlineMetaSupported(disk) {
+		t.Skip("Extended attributes are not supported")
+	}
+
+	globalFSInlineMetaThreshold = 16
+	defer func() { globalFSInlineMetaThreshold = 0 }()
+
+	obj := initFSObjects(disk, t)
+	ctx := context.Background()
+	bucket, object := "bucket", "object"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	fsObjPath := pathJoin(disk, bucket, object)
+	fsMetaPath := pathJoin(disk, minioMetaBucket, bucketMetaPrefix, bucket, object, fsMetaJSONFile)
+	put := func(data string) ObjectInfo {
+		t.Helper()
+		oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	isInline := func() bool {
+		t.Helper()
+		_, err := fsReadInlineMeta(fsObjPath)
+		_, statErr := os.Stat(fsMetaPath)
+		if (err == nil) == (statErr == nil) {
+			t.Fatalf("Expected metadata either inline or in fs.json, got %v and %v", err, statErr)
+		}
+		return err == nil
+	}
+
+	put("data above the threshold")
+	if isInline() {
+		t.Fatal("Expected fs.json for a large object")
+	}
+
+	// Overwriting with a small object drops the previous `fs.json`.
+	oi := put("small")
+	if !isInline() {
+		t.Fatal("Expected inline metadata for a small object")
+	}
+	got, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.ETag != oi.ETag || got.Size != 5 {
+		t.Fatalf("Expected ETag %s and size 5, got %s and %d", oi.ETag, got.ETag, got.Size)
+	}
+
+	got.UserDefined["X-Amz-Meta-Team"] = "storage"
+	got.metadataOnly = true
+	if _, err = obj.CopyObject(ctx, bucket, object, bucket, object, got, ObjectOptions{}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !isInline() {
+		t.Fatal("Expected updated metadata to stay inline")
+	}
+	if got, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got.UserDefined["X-Amz-Meta-Team"] != "storage" {
+		t.Fatalf("Expected updated metadata, got %v", got.UserDefined)
+	}
+
+	// Appending moves the metadata to `fs.json`.
+	fs := obj.(*FSObjects)
+	if _, err = fs.AppendObject(ctx, bucket, object, 5, mustGetPutObjReader(t, bytes.NewReader([]byte("more")), 4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if isInline() {
+		t.Fatal("Expected fs.json for an appended object")
+	}
+	if got, err = obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if got.Size != 9 || got.UserDefined["X-Amz-Meta-Team"] != "storage" {
+		t.Fatalf("Unexpected object info after append %#v", got)
+	}
+}
@@ -0,0 +1,211 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Directory under minioMetaBucket holding the listing cache.
+	fsListingCachePrefix = "listing-cache"
+
+	fsListingCacheVersionV1 = "1"
+
+	// Only the entries of directories with at least this many
+	// entries are indexed, smaller directories are cheap to read.
+	fsListingCacheMinEntries = 256
+
+	// Interval between two refreshes of the listing cache of all
+	// buckets, only modified directories are read again.
+	fsListingCacheRefreshInterval = 1 * time.Hour
+
+	// A directory modified less than this before its entries were
+	// indexed may have been modified again within the resolution of
+	// its modification time, such entries are not trusted.
+	fsListingCacheRacyWindow = 2 * time.Second
+)
+
+// fsListingCacheIndexV1 - indexed entries of a directory.
+type fsListingCacheIndexV1 struct {
+	Version string    `json:"version"`
+	Dir     string    `json:"dir"`
+	ModTime time.Time `json:"modTime"`
+	Indexed time.Time `json:"indexed"`
+	Entries []string  `json:"entries"`
+}
+
+// fsListingCache - on-disk index of the entries of large directories,
+// so that listing pre-existing trees with millions of files does not
+// read every directory again. An index is valid as long as the
+// modification time of its directory is unchanged, which is updated
+// whenever an entry is added to or removed from the directory.
+type fsListingCache struct {
+	dir string
+}
+
+func newFSListingCache(dir string) (*fsListingCache, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+	return &fsListingCache{dir: dir}, nil
+}
+
+// indexPath - returns the path of the index of a directory of bucket.
+func (c *fsListingCache) indexPath(bucket, prefixDir string) string {
+	return pathJoin(c.dir, bucket, getSHA256Hash([]byte(prefixDir))+".json")
+}
+
+// load - returns the indexed entries of prefixDir, found is true if
+// an index exists even if it is outdated.
+func (c *fsListingCache) load(indexPath, prefixDir string, modTime time.Time) (entries []string, found, ok bool) {
+	data, err := ioutil.ReadFile(indexPath)
+	if err != nil {
+		return nil, false, false
+	}
+	var index fsListingCacheIndexV1
+	if err = json.Unmarshal(data, &index); err != nil {
+		return nil, true, false
+	}
+	if index.Version != fsListingCacheVersionV1 || index.Dir != prefixDir ||
+		!index.ModTime.Equal(modTime) || index.Indexed.Sub(index.ModTime) < fsListingCacheRacyWindow {
+		return nil, true, false
+	}
+	return index.Entries, true, true
+}
+
+func (c *fsListingCache) save(indexPath, prefixDir string, modTime time.Time, entries []string) error {
+	data, err := json.Marshal(fsListingCacheIndexV1{
+		Version: fsListingCacheVersionV1,
+		Dir:     prefixDir,
+		ModTime: modTime,
+		Indexed: UTCNow(),
+		Entries: entries,
+	})
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(path.Dir(indexPath), 0777); err != nil {
+		return err
+	}
+	tmpPath := indexPath + "." + mustGetUUID()
+	if err = ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	if err = os.Rename(tmpPath, indexPath); err != nil {
+		os.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// readDir - returns the entries of prefixDir of bucket at dirPath,
+// from its index if it is up to date. The index is refreshed when
+// the directory has been modified.
+func (c *fsListingCache) readDir(bucket, prefixDir, dirPath string) ([]string, error) {
+	if c == nil {
+		return readDir(dirPath)
+	}
+	fi, err := os.Stat(dirPath)
+	if err != nil || !fi.IsDir() {
+		return readDir(dirPath)
+	}
+
+	indexPath := c.indexPath(bucket, prefixDir)
+	entries, found, ok := c.load(indexPath, prefixDir, fi.ModTime())
+	if ok {
+		return entries, nil
+	}
+
+	if entries, err = readDir(dirPath); err != nil {
+		return nil, err
+	}
+	if len(entries) >= fsListingCacheMinEntries {
+		if err = c.save(indexPath, prefixDir, fi.ModTime(), entries); err != nil {
+			logger.LogIf(context.Background(), err)
+		}
+	} else if found {
+		os.Remove(indexPath)
+	}
+	return entries, nil
+}
+
+// DeleteBucket - removes the indexes of the directories of bucket.
+func (c *fsListingCache) DeleteBucket(ctx context.Context, bucket string) error {
+	if c == nil {
+		return nil
+	}
+	err := fsRemoveAll(ctx, pathJoin(c.dir, bucket))
+	if err == errFileNotFound {
+		return nil
+	}
+	return err
+}
+
+// refresh - reads all directories of bucket through the listing
+// cache, indexing the large directories which are not yet indexed
+// or were modified since.
+func (c *fsListingCache) refresh(fs *FSObjects, bucket, prefixDir string, doneCh <-chan struct{}) error {
+	select {
+	case <-doneCh:
+		return errWalkAbort
+	default:
+	}
+	entries, err := c.readDir(bucket, prefixDir, fs.bucketPath(bucket, prefixDir))
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !hasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		if err = c.refresh(fs, bucket, prefixDir+entry, doneCh); err == errWalkAbort {
+			return err
+		}
+	}
+	return nil
+}
+
+// refreshListingCache - indexes the large directories of all buckets
+// on startup and refreshes the modified ones periodically, so that the
+// first listings of large pre-existing trees are served by the index.
+func (fs *FSObjects) refreshListingCache(doneCh <-chan struct{}) {
+	ctx := context.Background()
+	for {
+		buckets, err := fs.ListBuckets(ctx)
+		if err == nil {
+			for _, bucket := range buckets {
+				if err = fs.listingCache.refresh(fs, bucket.Name, "", doneCh); err == errWalkAbort {
+					return
+				}
+			}
+		}
+
+		select {
+		case <-doneCh:
+			return
+		case <-time.After(fsListingCacheRefreshInterval):
+		}
+	}
+}
@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Tests listing large pre-existing directories through the listing cache.
+func TestFSListingCache(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	bucketDir := filepath.Join(disk, "bucket", "logs")
+	if err := os.MkdirAll(bucketDir, 0777); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < fsListingCacheMinEntries; i++ {
+		if err := ioutil.WriteFile(filepath.Join(bucketDir, fmt.Sprintf("%04d.log", i)), nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Directories modified just before being indexed are not
+	// trusted, backdate the directory of the pre-existing files.
+	past := time.Now().Add(-time.Hour)
+	if err := os.Chtimes(bucketDir, past, past); err != nil {
+		t.Fatal(err)
+	}
+
+	globalFSListingCache = true
+	defer func() { globalFSListingCache = false }()
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	ctx := context.Background()
+
+	countObjects := func() int {
+		t.Helper()
+		count := 0
+		marker := ""
+		for {
+			result, err := obj.ListObjects(ctx, "bucket", "logs/", marker, "", 100)
+			if err != nil {
+				t.Fatal(err)
+			}
+			count += len(result.Objects)
+			if !result.IsTruncated {
+				return count
+			}
+			marker = result.NextMarker
+		}
+	}
+
+	if n := countObjects(); n != fsListingCacheMinEntries {
+		t.Fatalf("Expected %d objects, got %d", fsListingCacheMinEntries, n)
+	}
+	if _, err := os.Stat(fs.listingCache.indexPath("bucket", "logs/")); err != nil {
+		t.Fatalf("Expected the directory to be indexed, got %v", err)
+	}
+
+	// A file added without changing the modification time of its
+	// directory is not seen, which shows that the index is used.
+	if err := ioutil.WriteFile(filepath.Join(bucketDir, "new.log"), nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chtimes(bucketDir, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if n := countObjects(); n != fsListingCacheMinEntries {
+		t.Fatalf("Expected %d objects from the index, got %d", fsListingCacheMinEntries, n)
+	}
+
+	// Modified directories are read again.
+	now := time.Now()
+	if err := os.Chtimes(bucketDir, now, now); err != nil {
+		t.Fatal(err)
+	}
+	if n := countObjects(); n != fsListingCacheMinEntries+1 {
+		t.Fatalf("Expected %d objects, got %d", fsListingCacheMinEntries+1, n)
+	}
+
+	if err := obj.DeleteBucket(ctx, "bucket", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(fs.listingCache.indexPath("bucket", "logs/")); !os.IsNotExist(err) {
+		t.Fatalf("Expected the index to be removed with the bucket, got %v", err)
+	}
+}
@@ -72,7 +72,7 @@ func (fs *FSObjects) backgroundAppend(ctx context.Context, bucket, object, uploa
 	file := fs.appendFileMap[uploadID]
 	if file == nil {
 		file = &fsAppendFile{
-			filePath: pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, fmt.Sprintf("%s.%s", uploadID, mustGetUUID())),
+			filePath: pathJoin(fs.fsTmpDir, fmt.Sprintf("%s.%s", uploadID, mustGetUUID())),
 		}
 		fs.appendFileMap[uploadID] = file
 	}
@@ -301,6 +301,8 @@ func (fs *FSObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID
 	}
 	buf := make([]byte, bufSize)
 
+	// Parts are renamed into the multipart directory of the backend,
+	// stage them on the backend itself to keep that rename cheap.
 	tmpPartPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, uploadID+"."+mustGetUUID()+"."+strconv.Itoa(partID))
 	bytesWritten, err := fsCreateFile(ctx, tmpPartPath, data, buf, data.Size())
 	if err != nil {
@@ -432,27 +434,24 @@ func (fs *FSObjects) ListObjectParts(ctx context.Context, bucket, object, upload
 	sort.Slice(parts, func(i int, j int) bool {
 		return parts[i].PartNumber < parts[j].PartNumber
 	})
-	i := 0
-	if partNumberMarker != 0 {
-		// If the marker was set, skip the entries till the marker.
-		for _, part := range parts {
-			i++
-			if part.PartNumber == partNumberMarker {
-				break
-			}
-		}
+	// Limit output to maxPartsList.
+	if maxParts > maxPartsList {
+		maxParts = maxPartsList
 	}
 
-	partsCount := 0
-	for partsCount < maxParts && i < len(parts) {
+	// Only parts with higher part numbers than the marker are listed,
+	// the marker need not be the number of an uploaded part.
+	i := sort.Search(len(parts), func(i int) bool {
+		return parts[i].PartNumber > partNumberMarker
+	})
+	for len(result.Parts) < maxParts && i < len(parts) {
 		result.Parts = append(result.Parts, parts[i])
 		i++
-		partsCount++
 	}
 	if i < len(parts) {
 		result.IsTruncated = true
-		if partsCount != 0 {
-			result.NextPartNumberMarker = result.Parts[partsCount-1].PartNumber
+		if len(result.Parts) != 0 {
+			result.NextPartNumberMarker = result.Parts[len(result.Parts)-1].PartNumber
 		}
 	}
 	for i, part := range result.Parts {
@@ -463,7 +462,12 @@ func (fs *FSObjects) ListObjectParts(ctx context.Context, bucket, object, upload
 			return result, toObjectErr(err)
 		}
 		result.Parts[i].LastModified = stat.ModTime()
+		// Report the size of the part as uploaded by the client,
+		// compressed parts are stored smaller than that.
 		result.Parts[i].Size = part.ActualSize
+		if part.ActualSize < 0 {
+			result.Parts[i].Size = stat.Size()
+		}
 	}
 
 	fsMetaBytes, err := ioutil.ReadFile(pathJoin(uploadIDDir, fs.metaJSONFile))
@@ -598,7 +602,7 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 
 	appendFallback := true // In case background-append did not append the required parts.
-	appendFilePath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, fmt.Sprintf("%s.%s", uploadID, mustGetUUID()))
+	appendFilePath := pathJoin(fs.fsTmpDir, fmt.Sprintf("%s.%s", uploadID, mustGetUUID()))
 
 	// Most of the times appendFile would already be fully appended by now. We call fs.backgroundAppend()
 	// to take care of the following corner case:
@@ -651,6 +655,11 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, err
 	}
 	defer destLock.Unlock()
+
+	if err = fs.checkPutPreconditions(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	metaFile, err := fs.rwPool.Create(fsMetaPath)
 	if err != nil {
@@ -684,20 +693,28 @@ func (fs *FSObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
-		if _, err = fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object)); err == nil {
+		if _, ok := fs.pack.Get(bucket, object); ok {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+		if _, err = fsStatFile(ctx, fs.bucketPath(bucket, object)); err == nil {
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
 		}
 	}
 
-	err = fsRenameFile(ctx, appendFilePath, pathJoin(fs.fsPath, bucket, object))
+	err = fs.renameTmpFile(ctx, appendFilePath, fs.bucketPath(bucket, object))
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return oi, toObjectErr(err, bucket, object)
 	}
+	// Drop a previously packed version of the object.
+	if _, err = fs.pack.Delete(bucket, object); err != nil {
+		logger.LogIf(ctx, err)
+		return oi, toObjectErr(err, bucket, object)
+	}
 	fsRemoveAll(ctx, uploadIDDir)
 	// It is safe to ignore any directory not empty error (in case there were multiple uploadIDs on the same object)
 	fsRemoveDir(ctx, fs.getMultipartSHADir(bucket, object))
-	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	fi, err := fsStatFile(ctx, fs.bucketPath(bucket, object))
 	if err != nil {
 		return oi, toObjectErr(err, bucket, object)
 	}
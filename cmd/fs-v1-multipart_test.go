@@ -21,8 +21,11 @@ import (
 	"context"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	"github.com/minio/minio/pkg/hash"
 )
 
 // Tests cleanup multipart uploads for filesystem backend.
@@ -218,6 +221,88 @@ func TestAbortMultipartUpload(t *testing.T) {
 	}
 }
 
+// TestFSListObjectParts - test ListObjectParts pagination and part sizes
+func TestFSListObjectParts(t *testing.T) {
+	// Prepare for tests
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+	obj := initFSObjects(disk, t)
+
+	bucketName := "bucket"
+	objectName := "object"
+	data := []byte("12345")
+
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal("Cannot create bucket, err: ", err)
+	}
+
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+
+	md5Hex := getMD5Hash(data)
+	for _, partID := range []int{1, 2, 4, 5} {
+		if _, err = obj.PutObjectPart(context.Background(), bucketName, objectName, uploadID, partID, mustGetPutObjReader(t, bytes.NewReader(data), 5, md5Hex, ""), ObjectOptions{}); err != nil {
+			t.Fatal("Unexpected error ", err)
+		}
+	}
+
+	// A compressed part is stored smaller than it was uploaded.
+	hashReader, err := hash.NewReader(bytes.NewReader(data), 5, "", "", 100, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+	if _, err = obj.PutObjectPart(context.Background(), bucketName, objectName, uploadID, 6, NewPutObjReader(hashReader, nil, nil), ObjectOptions{}); err != nil {
+		t.Fatal("Unexpected error ", err)
+	}
+
+	testCases := []struct {
+		partNumberMarker   int
+		maxParts           int
+		expectedParts      []int
+		expectedTruncated  bool
+		expectedNextMarker int
+	}{
+		{0, 1000, []int{1, 2, 4, 5, 6}, false, 0},
+		{0, 2, []int{1, 2}, true, 2},
+		{2, 2, []int{4, 5}, true, 5},
+		// The marker is not an uploaded part.
+		{3, 2, []int{4, 5}, true, 5},
+		{5, 2, []int{6}, false, 0},
+		{6, 2, nil, false, 0},
+	}
+
+	for i, testCase := range testCases {
+		result, err := obj.ListObjectParts(context.Background(), bucketName, objectName, uploadID, testCase.partNumberMarker, testCase.maxParts, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: Unexpected error %v", i+1, err)
+		}
+		var parts []int
+		for _, part := range result.Parts {
+			parts = append(parts, part.PartNumber)
+		}
+		if !reflect.DeepEqual(parts, testCase.expectedParts) {
+			t.Errorf("Test %d: Expected parts %v, got %v", i+1, testCase.expectedParts, parts)
+		}
+		if result.IsTruncated != testCase.expectedTruncated {
+			t.Errorf("Test %d: Expected truncated %v, got %v", i+1, testCase.expectedTruncated, result.IsTruncated)
+		}
+		if result.NextPartNumberMarker != testCase.expectedNextMarker {
+			t.Errorf("Test %d: Expected next marker %d, got %d", i+1, testCase.expectedNextMarker, result.NextPartNumberMarker)
+		}
+		for _, part := range result.Parts {
+			expectedSize := int64(5)
+			if part.PartNumber == 6 {
+				expectedSize = 100
+			}
+			if part.Size != expectedSize || part.ActualSize != expectedSize {
+				t.Errorf("Test %d: Expected part %d to be of size %d, got %d (actual %d)", i+1, part.PartNumber, expectedSize, part.Size, part.ActualSize)
+			}
+		}
+	}
+}
+
 // TestListMultipartUploadsFaultyDisk - test ListMultipartUploads with faulty disks
 func TestListMultipartUploadsFaultyDisk(t *testing.T) {
 	// Prepare for tests
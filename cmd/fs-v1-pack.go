@@ -0,0 +1,628 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/logger"
+	xioutil "github.com/minio/minio/pkg/ioutil"
+)
+
+const (
+	// Directory under minioMetaBucket holding the packed objects.
+	fsPackPrefix = "pack"
+
+	// Append-only log of the packed objects of a bucket.
+	fsPackIndexFile = "index.log"
+
+	// Prefix of the slab files holding the data of packed objects.
+	fsPackSlabPrefix = "slab-"
+
+	// A new slab is started once the current one grows beyond
+	// this size.
+	fsPackSlabSize = 64 * humanize.MiByte
+
+	// Largest object size accepted for MINIO_FS_PACK_THRESHOLD,
+	// packed objects are buffered in memory before being written.
+	fsPackMaxThreshold = 1 * humanize.MiByte
+)
+
+// fsPackEntry - record of the index log, either the location and
+// metadata of a packed object or the deletion of a packed object.
+type fsPackEntry struct {
+	Name    string            `json:"name"`
+	Deleted bool              `json:"deleted,omitempty"`
+	Slab    int               `json:"slab,omitempty"`
+	Offset  int64             `json:"offset,omitempty"`
+	Size    int64             `json:"size,omitempty"`
+	ModTime time.Time         `json:"modTime,omitempty"`
+	Meta    map[string]string `json:"meta,omitempty"`
+}
+
+// fsPackFileInfo - os.FileInfo of a packed object.
+type fsPackFileInfo struct {
+	entry fsPackEntry
+}
+
+func (fi fsPackFileInfo) Name() string       { return path.Base(fi.entry.Name) }
+func (fi fsPackFileInfo) Size() int64        { return fi.entry.Size }
+func (fi fsPackFileInfo) Mode() os.FileMode  { return 0644 }
+func (fi fsPackFileInfo) ModTime() time.Time { return fi.entry.ModTime }
+func (fi fsPackFileInfo) IsDir() bool        { return false }
+func (fi fsPackFileInfo) Sys() interface{}   { return nil }
+
+// ToObjectInfo - converts the entry of a packed object to ObjectInfo.
+func (e fsPackEntry) ToObjectInfo(bucket string) ObjectInfo {
+	fsMeta := newFSMetaV1()
+	fsMeta.Meta = cloneUserDefined(e.Meta)
+	return fsMeta.ToObjectInfo(bucket, e.Name, fsPackFileInfo{e})
+}
+
+// fsPackBucket - packed objects of a bucket.
+type fsPackBucket struct {
+	sync.RWMutex
+
+	dir     string
+	objects map[string]fsPackEntry
+	// Entries of each directory, with the number of packed
+	// objects below each entry, used for listing.
+	dirs map[string]map[string]int
+
+	index    *os.File
+	slab     *os.File
+	slabID   int
+	slabSize int64
+}
+
+func fsPackSlabName(id int) string {
+	return fmt.Sprintf("%s%06d", fsPackSlabPrefix, id)
+}
+
+// loadFSPackBucket - replays the index log of the bucket at dir and
+// opens its current slab for appending.
+func loadFSPackBucket(dir string) (*fsPackBucket, error) {
+	if err := os.MkdirAll(dir, 0777); err != nil {
+		return nil, err
+	}
+
+	b := &fsPackBucket{
+		dir:     dir,
+		objects: make(map[string]fsPackEntry),
+		dirs:    make(map[string]map[string]int),
+		slabID:  1,
+	}
+
+	// Records are written synchronously, acknowledged objects
+	// survive a crash of the server.
+	index, err := os.OpenFile(pathJoin(dir, fsPackIndexFile), os.O_CREATE|os.O_RDWR|os.O_APPEND|os.O_SYNC, 0666)
+	if err != nil {
+		return nil, err
+	}
+	var size int64 // Size of the complete records.
+	reader := bufio.NewReader(index)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			index.Close()
+			return nil, err
+		}
+		size += int64(len(line))
+		var e fsPackEntry
+		if err = json.Unmarshal(line, &e); err != nil {
+			logger.LogIf(context.Background(), fmt.Errorf("Skipping corrupted record of %s: %v", index.Name(), err))
+			continue
+		}
+		if e.Deleted {
+			b.remove(e.Name)
+		} else {
+			b.add(e)
+		}
+	}
+	// The last record is truncated if the server went down while
+	// writing it, its object was never acknowledged. It is cut off,
+	// the next record would be appended to it otherwise.
+	if err = index.Truncate(size); err != nil {
+		index.Close()
+		return nil, err
+	}
+	b.index = index
+
+	entries, err := readDir(dir)
+	if err != nil {
+		index.Close()
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry, fsPackSlabPrefix) {
+			continue
+		}
+		if id, err := strconv.Atoi(strings.TrimPrefix(entry, fsPackSlabPrefix)); err == nil && id > b.slabID {
+			b.slabID = id
+		}
+	}
+	if err = b.openSlab(); err != nil {
+		index.Close()
+		return nil, err
+	}
+	return b, nil
+}
+
+// openSlab - opens the slab b.slabID for appending.
+func (b *fsPackBucket) openSlab() error {
+	slab, err := os.OpenFile(pathJoin(b.dir, fsPackSlabName(b.slabID)), os.O_CREATE|os.O_WRONLY|os.O_APPEND|os.O_SYNC, 0666)
+	if err != nil {
+		return err
+	}
+	fi, err := slab.Stat()
+	if err != nil {
+		slab.Close()
+		return err
+	}
+	b.slab = slab
+	b.slabSize = fi.Size()
+	return nil
+}
+
+// walk calls fn for every directory entry leading to object,
+// "a/b/c" yields ("", "a/"), ("a/", "b/") and ("a/b/", "c").
+func (b *fsPackBucket) walk(object string, fn func(dir, entry string)) {
+	dir, rest := "", object
+	for {
+		i := strings.Index(rest, SlashSeparator)
+		if i < 0 {
+			fn(dir, rest)
+			return
+		}
+		fn(dir, rest[:i+1])
+		dir += rest[:i+1]
+		rest = rest[i+1:]
+	}
+}
+
+func (b *fsPackBucket) add(e fsPackEntry) {
+	if _, ok := b.objects[e.Name]; !ok {
+		b.walk(e.Name, func(dir, entry string) {
+			entries, ok := b.dirs[dir]
+			if !ok {
+				entries = make(map[string]int)
+				b.dirs[dir] = entries
+			}
+			entries[entry]++
+		})
+	}
+	b.objects[e.Name] = e
+}
+
+func (b *fsPackBucket) remove(object string) {
+	if _, ok := b.objects[object]; !ok {
+		return
+	}
+	delete(b.objects, object)
+	b.walk(object, func(dir, entry string) {
+		entries := b.dirs[dir]
+		if entries[entry]--; entries[entry] <= 0 {
+			delete(entries, entry)
+		}
+		if len(entries) == 0 {
+			delete(b.dirs, dir)
+		}
+	})
+}
+
+// appendIndex - appends a record to the index log.
+func (b *fsPackBucket) appendIndex(e fsPackEntry) error {
+	buf, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	_, err = b.index.Write(append(buf, '\n'))
+	return err
+}
+
+func (b *fsPackBucket) close() {
+	b.index.Close()
+	b.slab.Close()
+}
+
+// fsPackStore - packs small objects of the FS backend into append-only
+// slab files, instead of a data file and an `fs.json` per object. The
+// location and metadata of packed objects is kept in an append-only
+// index log per bucket, which is replayed into memory at startup.
+//
+// Packing is experimental, the space of overwritten and deleted packed
+// objects is not reclaimed and the index log is never compacted. Since
+// the index is held in memory, packing is not used when the backend is
+// shared by several servers.
+type fsPackStore struct {
+	mu      sync.Mutex
+	dir     string
+	buckets map[string]*fsPackBucket
+
+	// Objects of at most this size are packed, zero disables
+	// packing new objects.
+	threshold int64
+}
+
+// newFSPackStore - loads the packed objects of all buckets under dir.
+func newFSPackStore(dir string, threshold int64) (*fsPackStore, error) {
+	p := &fsPackStore{
+		dir:       dir,
+		buckets:   make(map[string]*fsPackBucket),
+		threshold: threshold,
+	}
+	entries, err := readDir(dir)
+	if err != nil && err != errFileNotFound {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if !hasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		bucket := strings.TrimSuffix(entry, SlashSeparator)
+		b, err := loadFSPackBucket(pathJoin(dir, bucket))
+		if err != nil {
+			p.Close()
+			return nil, err
+		}
+		p.buckets[bucket] = b
+	}
+	return p, nil
+}
+
+// canPack - returns true if an object of size should be packed.
+func (p *fsPackStore) canPack(bucket, object string, size int64) bool {
+	return p.threshold > 0 && bucket != minioMetaBucket &&
+		!hasSuffix(object, SlashSeparator) && size >= 0 && size <= p.threshold
+}
+
+func (p *fsPackStore) getBucket(bucket string) *fsPackBucket {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.buckets[bucket]
+}
+
+// Get - returns the index entry of a packed object.
+func (p *fsPackStore) Get(bucket, object string) (fsPackEntry, bool) {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return fsPackEntry{}, false
+	}
+	b.RLock()
+	defer b.RUnlock()
+	e, ok := b.objects[object]
+	return e, ok
+}
+
+// Put - appends the data of object to the current slab of bucket,
+// callers must hold the object write lock.
+func (p *fsPackStore) Put(bucket, object string, data []byte, meta map[string]string) (e fsPackEntry, err error) {
+	p.mu.Lock()
+	b, ok := p.buckets[bucket]
+	if !ok {
+		if b, err = loadFSPackBucket(pathJoin(p.dir, bucket)); err != nil {
+			p.mu.Unlock()
+			return e, err
+		}
+		p.buckets[bucket] = b
+	}
+	p.mu.Unlock()
+
+	b.Lock()
+	defer b.Unlock()
+
+	if b.slabSize > 0 && b.slabSize+int64(len(data)) > fsPackSlabSize {
+		b.slab.Close()
+		b.slabID++
+		if err = b.openSlab(); err != nil {
+			return e, err
+		}
+	}
+
+	e = fsPackEntry{
+		Name:    object,
+		Slab:    b.slabID,
+		Offset:  b.slabSize,
+		Size:    int64(len(data)),
+		ModTime: UTCNow(),
+		Meta:    meta,
+	}
+	n, err := b.slab.Write(data)
+	b.slabSize += int64(n)
+	if err != nil {
+		return e, err
+	}
+	if err = b.appendIndex(e); err != nil {
+		return e, err
+	}
+	b.add(e)
+	return e, nil
+}
+
+// UpdateMeta - replaces the metadata of a packed object, callers
+// must hold the object write lock.
+func (p *fsPackStore) UpdateMeta(bucket, object string, meta map[string]string) (e fsPackEntry, err error) {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return e, errFileNotFound
+	}
+	b.Lock()
+	defer b.Unlock()
+	e, ok := b.objects[object]
+	if !ok {
+		return e, errFileNotFound
+	}
+	e.Meta = meta
+	if err = b.appendIndex(e); err != nil {
+		return e, err
+	}
+	b.objects[object] = e
+	return e, nil
+}
+
+// Delete - deletes a packed object, returns false if the object
+// is not packed. Callers must hold the object write lock.
+func (p *fsPackStore) Delete(bucket, object string) (bool, error) {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return false, nil
+	}
+	b.Lock()
+	defer b.Unlock()
+	if _, ok := b.objects[object]; !ok {
+		return false, nil
+	}
+	if err := b.appendIndex(fsPackEntry{Name: object, Deleted: true}); err != nil {
+		return true, err
+	}
+	b.remove(object)
+	return true, nil
+}
+
+// Open - returns a reader of the data of a packed object from offset.
+func (p *fsPackStore) Open(bucket string, e fsPackEntry, offset int64) (io.ReadCloser, error) {
+	if offset < 0 {
+		return nil, errInvalidArgument
+	}
+	// Callers validate the range against the size of the object.
+	if offset > e.Size {
+		offset = e.Size
+	}
+	f, err := os.Open(pathJoin(p.dir, bucket, fsPackSlabName(e.Slab)))
+	if err != nil {
+		return nil, osErrToFSFileErr(err)
+	}
+	return struct {
+		io.Reader
+		io.Closer
+	}{io.NewSectionReader(f, e.Offset+offset, e.Size-offset), f}, nil
+}
+
+// ListDir - returns the sorted entries of packed objects in prefixDir,
+// directories are suffixed with a slash.
+func (p *fsPackStore) ListDir(bucket, prefixDir string) []string {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return nil
+	}
+	b.RLock()
+	entries := make([]string, 0, len(b.dirs[prefixDir]))
+	for entry := range b.dirs[prefixDir] {
+		entries = append(entries, entry)
+	}
+	b.RUnlock()
+	sort.Strings(entries)
+	return entries
+}
+
+// IsEmpty - returns true if bucket has no packed objects.
+func (p *fsPackStore) IsEmpty(bucket string) bool {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return true
+	}
+	b.RLock()
+	defer b.RUnlock()
+	return len(b.objects) == 0
+}
+
+// DeleteBucket - removes the slabs and index of bucket.
+func (p *fsPackStore) DeleteBucket(ctx context.Context, bucket string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b, ok := p.buckets[bucket]; ok {
+		b.close()
+		delete(p.buckets, bucket)
+	}
+	return fsRemoveAll(ctx, pathJoin(p.dir, bucket))
+}
+
+// copyFSPackBucket - copies the index log of the bucket at srcDir to
+// dstDir and hardlinks its slabs, which are append-only.
+func copyFSPackBucket(ctx context.Context, srcDir, dstDir string) error {
+	entries, err := readDir(srcDir)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(dstDir, 0777); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		srcPath, dstPath := pathJoin(srcDir, entry), pathJoin(dstDir, entry)
+		switch {
+		case entry == fsPackIndexFile:
+			err = fsCopyFile(ctx, srcPath, dstPath)
+		case strings.HasPrefix(entry, fsPackSlabPrefix):
+			if err = os.Link(srcPath, dstPath); err != nil {
+				err = fsCopyFile(ctx, srcPath, dstPath)
+			}
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Snapshot - saves the packed objects of bucket in dir.
+func (p *fsPackStore) Snapshot(bucket, dir string) error {
+	b := p.getBucket(bucket)
+	if b == nil {
+		return nil
+	}
+	// Hold off appends to the index log while it is copied.
+	b.Lock()
+	defer b.Unlock()
+	return copyFSPackBucket(context.Background(), b.dir, dir)
+}
+
+// Restore - replaces the packed objects of bucket by those saved
+// in dir by Snapshot, all packed objects are removed if dir does
+// not exist.
+func (p *fsPackStore) Restore(ctx context.Context, bucket, dir string) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if b, ok := p.buckets[bucket]; ok {
+		b.close()
+		delete(p.buckets, bucket)
+	}
+	bucketDir := pathJoin(p.dir, bucket)
+	if err := fsRemoveAll(ctx, bucketDir); err != nil {
+		return err
+	}
+	if err := copyFSPackBucket(ctx, dir, bucketDir); err != nil {
+		if err == errFileNotFound {
+			return nil
+		}
+		return err
+	}
+	b, err := loadFSPackBucket(bucketDir)
+	if err != nil {
+		return err
+	}
+	p.buckets[bucket] = b
+	return nil
+}
+
+// addUsage - adds the packed objects to the usage u.
+func (p *fsPackStore) addUsage(u *fsObjectsUsage) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for bucket, b := range p.buckets {
+		b.RLock()
+		for _, e := range b.objects {
+			u.addObject(bucket, e.Size)
+		}
+		b.RUnlock()
+	}
+}
+
+// Close - closes the slabs and index logs of all buckets.
+func (p *fsPackStore) Close() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, b := range p.buckets {
+		b.close()
+	}
+}
+
+// putPackedObject - packs a small object, callers must hold the object
+// write lock. A regular file previously holding the object is removed.
+func (fs *FSObjects) putPackedObject(ctx context.Context, bucket, object string, r *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket)
+	}
+
+	// Check if an object is present as one of the parent dir.
+	if fs.parentDirIsObject(ctx, bucket, path.Dir(object)) {
+		return ObjectInfo{}, toObjectErr(errFileParentIsFile, bucket, object)
+	}
+
+	fsNSObjPath := fs.bucketPath(bucket, object)
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		if _, ok := fs.pack.Get(bucket, object); ok {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+		if _, err := fsStatFile(ctx, fsNSObjPath); err == nil {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+	}
+
+	// Reading up to EOF lets the reader verify the checksums.
+	var buf bytes.Buffer
+	buf.Grow(int(r.Reader.Size()))
+	if _, err := buf.ReadFrom(xioutil.NewContextReader(ctx, r.Reader)); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	data := buf.Bytes()
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if int64(len(data)) < r.Reader.Size() {
+		return ObjectInfo{}, IncompleteBody{}
+	}
+
+	meta := make(map[string]string, len(opts.UserDefined)+1)
+	for k, v := range opts.UserDefined {
+		meta[k] = v
+	}
+	meta["etag"] = r.MD5CurrentHexString()
+
+	e, err := fs.pack.Put(bucket, object, data, meta)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Remove the regular file and `fs.json` of a previous version.
+	if err = fsDeleteFile(ctx, fs.bucketPath(bucket), fsNSObjPath); err != nil && err != errFileNotFound {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
+	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+	if err = fsDeleteFile(ctx, minioMetaBucketDir, fsMetaPath); err != nil && err != errFileNotFound {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	return e.ToObjectInfo(bucket), nil
+}
+
+// openObject - opens the data of a regular or packed object from
+// offset, returns the reader and the size of the object.
+func (fs *FSObjects) openObject(ctx context.Context, bucket, object string, offset int64) (io.ReadCloser, int64, error) {
+	if e, ok := fs.pack.Get(bucket, object); ok {
+		reader, err := fs.pack.Open(bucket, e, offset)
+		return reader, e.Size, err
+	}
+	return fsOpenObjectFile(ctx, fs.bucketPath(bucket, object), offset)
+}
@@ -0,0 +1,262 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Tests the packed object store and replaying its index log.
+func TestFSPackStore(t *testing.T) {
+	dir := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(dir)
+
+	p, err := newFSPackStore(dir, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	objects := map[string]string{
+		"a":     "hello",
+		"b/c":   "world",
+		"b/d/e": "",
+		"f":     "to be deleted",
+	}
+	for object, data := range objects {
+		if _, err = p.Put("bucket", object, []byte(data), map[string]string{"etag": object}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = p.Delete("bucket", "f"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.UpdateMeta("bucket", "a", map[string]string{"etag": "updated"}); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+
+	// All changes must be replayed from the index log.
+	if p, err = newFSPackStore(dir, 1024); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+
+	for object, data := range objects {
+		e, ok := p.Get("bucket", object)
+		if object == "f" {
+			if ok {
+				t.Fatal("Expected deleted object to be absent")
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("Expected object %s to be packed", object)
+		}
+		r, err := p.Open("bucket", e, 0)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != data {
+			t.Fatalf("Expected %q for %s, got %q", data, object, got)
+		}
+	}
+	if e, _ := p.Get("bucket", "a"); e.Meta["etag"] != "updated" {
+		t.Fatalf("Expected updated metadata, got %v", e.Meta)
+	}
+
+	listTests := []struct {
+		prefixDir string
+		entries   []string
+	}{
+		{"", []string{"a", "b/"}},
+		{"b/", []string{"c", "d/"}},
+		{"b/d/", []string{"e"}},
+		{"x/", []string{}},
+	}
+	for i, testCase := range listTests {
+		if entries := p.ListDir("bucket", testCase.prefixDir); !reflect.DeepEqual(entries, testCase.entries) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.entries, entries)
+		}
+	}
+
+	if p.IsEmpty("bucket") || !p.IsEmpty("other") {
+		t.Fatal("Unexpected IsEmpty result")
+	}
+	if err = p.DeleteBucket(context.Background(), "bucket"); err != nil {
+		t.Fatal(err)
+	}
+	if !p.IsEmpty("bucket") {
+		t.Fatal("Expected no packed objects after deleting the bucket")
+	}
+}
+
+// Tests replaying an index log whose last record is truncated.
+func TestFSPackStoreTruncatedIndex(t *testing.T) {
+	dir := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(dir)
+
+	p, err := newFSPackStore(dir, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = p.Put("bucket", "a", []byte("hello"), nil); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+
+	// The server went down while writing a record.
+	index, err := os.OpenFile(filepath.Join(dir, "bucket", fsPackIndexFile), os.O_WRONLY|os.O_APPEND, 0666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = index.Write([]byte(`{"name":"b","slab":1,"off`)); err != nil {
+		t.Fatal(err)
+	}
+	index.Close()
+
+	if p, err = newFSPackStore(dir, 1024); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := p.Get("bucket", "b"); ok {
+		t.Fatal("Expected the truncated record to be skipped")
+	}
+	if _, err = p.Put("bucket", "c", []byte("world"), nil); err != nil {
+		t.Fatal(err)
+	}
+	p.Close()
+
+	// Records appended after the truncated one are replayed.
+	if p, err = newFSPackStore(dir, 1024); err != nil {
+		t.Fatal(err)
+	}
+	defer p.Close()
+	for _, object := range []string{"a", "c"} {
+		if _, ok := p.Get("bucket", object); !ok {
+			t.Fatalf("Expected object %s to be packed", object)
+		}
+	}
+}
+
+// Tests packing small objects through the FS object layer.
+func TestFSPackedObjects(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	globalFSPackThreshold = 16
+	defer func() { globalFSPackThreshold = 0 }()
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object, data string) ObjectInfo {
+		t.Helper()
+		oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	get := func(object string) string {
+		t.Helper()
+		var buf bytes.Buffer
+		if err := obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String()
+	}
+	isPacked := func(object string) bool {
+		_, ok := fs.pack.Get(bucket, object)
+		return ok
+	}
+
+	small, large := "small data", "data above the pack threshold"
+	oi := put("dir/small", small)
+	put("dir/large", large)
+	if !isPacked("dir/small") || isPacked("dir/large") {
+		t.Fatal("Expected only the small object to be packed")
+	}
+	if oi.Size != int64(len(small)) || oi.ETag == "" {
+		t.Fatalf("Unexpected object info %#v", oi)
+	}
+	if got := get("dir/small"); got != small {
+		t.Fatalf("Expected %q, got %q", small, got)
+	}
+
+	// Ranged reads of packed objects.
+	gr, err := obj.GetObjectNInfo(ctx, bucket, "dir/small", &HTTPRangeSpec{Start: 6, End: 9}, nil, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil || string(got) != "data" {
+		t.Fatalf("Expected %q, got %q, %v", "data", got, err)
+	}
+
+	loi, err := obj.ListObjects(ctx, bucket, "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 2 || loi.Objects[0].Name != "dir/large" || loi.Objects[1].Name != "dir/small" {
+		t.Fatalf("Unexpected listing %#v", loi.Objects)
+	}
+	if loi.Objects[1].ETag != oi.ETag {
+		t.Fatalf("Expected ETag %s, got %s", oi.ETag, loi.Objects[1].ETag)
+	}
+
+	// Overwriting moves objects between the packed and regular format.
+	put("dir/small", large)
+	put("dir/large", small)
+	if isPacked("dir/small") || !isPacked("dir/large") {
+		t.Fatal("Expected objects to switch format when overwritten")
+	}
+	if _, err = os.Stat(pathJoin(disk, bucket, "dir", "large")); !os.IsNotExist(err) {
+		t.Fatal("Expected the regular file of a packed object to be removed")
+	}
+	if got := get("dir/large"); got != small {
+		t.Fatalf("Expected %q, got %q", small, got)
+	}
+
+	if err = obj.DeleteObject(ctx, bucket, "dir/small"); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.DeleteBucket(ctx, bucket, false); err == nil {
+		t.Fatal("Expected deleting a bucket with packed objects to fail")
+	}
+	if err = obj.DeleteObject(ctx, bucket, "dir/large"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "dir/large", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not found, got %v", err)
+	}
+}
@@ -24,13 +24,57 @@ import (
 
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/lock"
+	"github.com/minio/minio/pkg/sys"
 )
 
+// Maximum number of files held open by the pool when the open
+// files limit of the process is unknown.
+const fsDefaultMaxOpenFiles = 4096
+
 // fsIOPool represents a protected list to keep track of all
 // the concurrent readers at a given path.
 type fsIOPool struct {
 	sync.Mutex
 	readersMap map[string]*lock.RLockedFile
+
+	// Maximum number of paths held open, no limit if zero. An fd is
+	// closed as soon as its last reader is done, so there is nothing
+	// idle to evict and opening more paths fails instead.
+	maxOpen int
+
+	// Number of Open calls which failed due to maxOpen.
+	rejected uint64
+}
+
+// getFSMaxOpenFiles - returns the number of files the pool may hold
+// open, half of the open files limit of the process leaving the rest
+// for object data and network connections.
+func getFSMaxOpenFiles() int {
+	curLimit, _, err := sys.GetMaxOpenFileLimit()
+	if err != nil || curLimit == 0 {
+		return fsDefaultMaxOpenFiles
+	}
+	return int(curLimit / 2)
+}
+
+// isFull - returns true if no more paths can be opened.
+//
+// NOTE: this function is not protected and it is callers
+// responsibility to lock this call to be thread safe.
+func (fsi *fsIOPool) isFull() bool {
+	if fsi.maxOpen <= 0 || len(fsi.readersMap) < fsi.maxOpen {
+		return false
+	}
+	fsi.rejected++
+	return true
+}
+
+// Stats - returns the number of paths held open and the number of
+// Open calls which failed due to the limit.
+func (fsi *fsIOPool) Stats() (open int, rejected uint64) {
+	fsi.Lock()
+	defer fsi.Unlock()
+	return len(fsi.readersMap), fsi.rejected
 }
 
 // lookupToRead - looks up an fd from readers map and
@@ -87,6 +131,10 @@ func (fsi *fsIOPool) Open(path string) (*lock.RLockedFile, error) {
 
 	fsi.Lock()
 	rlkFile, ok := fsi.lookupToRead(path)
+	if !ok && fsi.isFull() {
+		fsi.Unlock()
+		return nil, errTooManyOpenFiles
+	}
 	fsi.Unlock()
 	// Locked path reference doesn't exist, acquire a read lock again on the file.
 	if !ok {
@@ -104,6 +152,8 @@ func (fsi *fsIOPool) Open(path string) (*lock.RLockedFile, error) {
 				return nil, errFileAccessDenied
 			case isSysErrPathNotFound(err):
 				return nil, errFileNotFound
+			case isSysErrTooManyFiles(err):
+				return nil, errTooManyOpenFiles
 			default:
 				return nil, err
 			}
@@ -123,6 +173,11 @@ func (fsi *fsIOPool) Open(path string) (*lock.RLockedFile, error) {
 			// Close the new fd, since we already seem to have
 			// an active reference.
 			newRlkFile.Close()
+		} else if fsi.isFull() {
+			// Concurrent calls opened other paths meanwhile.
+			fsi.Unlock()
+			newRlkFile.Close()
+			return nil, errTooManyOpenFiles
 		} else {
 			// Save the new rlk file.
 			rlkFile = newRlkFile
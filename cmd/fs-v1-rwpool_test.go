@@ -111,3 +111,50 @@ func TestRWPool(t *testing.T) {
 	}
 
 }
+
+// Tests the limit of paths held open by the RWPool.
+func TestRWPoolMaxOpen(t *testing.T) {
+	_, path, err := newPosixTestSetup()
+	if err != nil {
+		t.Fatalf("Unable to create posix test setup, %s", err)
+	}
+	defer os.RemoveAll(path)
+
+	rwPool := &fsIOPool{
+		readersMap: make(map[string]*lock.RLockedFile),
+		maxOpen:    1,
+	}
+	path1 := pathJoin(path, "success-vol", "1.txt")
+	path2 := pathJoin(path, "success-vol", "2.txt")
+	for _, p := range []string{path1, path2} {
+		wlk, err := rwPool.Create(p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wlk.Close()
+	}
+
+	if _, err = rwPool.Open(path1); err != nil {
+		t.Fatal(err)
+	}
+	// Readers of an open path share its fd.
+	if _, err = rwPool.Open(path1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = rwPool.Open(path2); err != errTooManyOpenFiles {
+		t.Fatalf("expected %v, got %v", errTooManyOpenFiles, err)
+	}
+	if open, rejected := rwPool.Stats(); open != 1 || rejected != 1 {
+		t.Fatalf("expected 1 open path and 1 rejected open, got %d and %d", open, rejected)
+	}
+
+	rwPool.Close(path1)
+	rwPool.Close(path1)
+	if _, err = rwPool.Open(path2); err != nil {
+		t.Fatal(err)
+	}
+	rwPool.Close(path2)
+	if open, _ := rwPool.Stats(); open != 0 {
+		t.Fatalf("expected no open paths, got %d", open)
+	}
+}
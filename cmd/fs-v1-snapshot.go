@@ -0,0 +1,372 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Directory under minioMetaBucket holding the snapshots of
+	// the buckets, in one directory per bucket and snapshot.
+	fsSnapshotsPrefix = "snapshots"
+
+	// Directory under fsSnapshotsPrefix where snapshots and
+	// restores are assembled before being renamed into place. It
+	// is on the same device as the buckets, unlike fsTmpDir.
+	fsSnapshotsStagingDir = ".staging"
+
+	// Description of a snapshot.
+	fsSnapshotInfoFile = "snapshot.json"
+
+	// Sub-directories of a snapshot holding the hardlinks of the
+	// data files, the copies of the `fs.json` files and the packed
+	// objects of the bucket.
+	fsSnapshotDataDir = "data"
+	fsSnapshotMetaDir = "meta"
+	fsSnapshotPackDir = "pack"
+)
+
+var (
+	errBucketSnapshotExists      = errors.New("Bucket snapshot already exists")
+	errBucketSnapshotNotFound    = errors.New("Bucket snapshot not found")
+	errInvalidBucketSnapshotName = errors.New("Invalid bucket snapshot name")
+)
+
+var validBucketSnapshotName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,62}$`)
+
+func (fs *FSObjects) bucketSnapshotsDir(bucket string) string {
+	return pathJoin(fs.fsPath, minioMetaBucket, fsSnapshotsPrefix, bucket)
+}
+
+// newSnapshotsStagingPath - returns a new path in the staging
+// directory of the snapshots.
+func (fs *FSObjects) newSnapshotsStagingPath() string {
+	return pathJoin(fs.fsPath, minioMetaBucket, fsSnapshotsPrefix, fsSnapshotsStagingDir, mustGetUUID())
+}
+
+// hasBucketSnapshots - returns true if the data files of bucket may
+// be shared with snapshots.
+func (fs *FSObjects) hasBucketSnapshots(bucket string) bool {
+	entries, err := readDir(fs.bucketSnapshotsDir(bucket))
+	return err == nil && len(entries) > 0
+}
+
+// unshareObjectFile - replaces the data file of an object by a copy
+// if it may be hardlinked from a snapshot, must be called before the
+// file is modified in place. Callers must hold the object write lock.
+func (fs *FSObjects) unshareObjectFile(ctx context.Context, bucket, fsObjPath string) error {
+	if !fs.hasBucketSnapshots(bucket) {
+		return nil
+	}
+	tmpPath := fs.newSnapshotsStagingPath()
+	if err := fsCopyFileWithMeta(ctx, fsObjPath, tmpPath); err != nil {
+		fsRemoveFile(ctx, tmpPath)
+		return err
+	}
+	return fsRenameFile(ctx, tmpPath, fsObjPath)
+}
+
+// fsCopyFileWithMeta - copies a data file along with its inline
+// `fs.json`, if any.
+func fsCopyFileWithMeta(ctx context.Context, sourcePath, destPath string) error {
+	if err := fsCopyFile(ctx, sourcePath, destPath); err != nil {
+		return err
+	}
+	if fsMeta, err := fsReadInlineMeta(sourcePath); err == nil {
+		return fsWriteInlineMeta(destPath, fsMeta)
+	}
+	return nil
+}
+
+// fsLinkTree - recreates the directories of srcDir under dstDir and
+// hardlinks its files, which are copied if they cannot be linked.
+func fsLinkTree(ctx context.Context, srcDir, dstDir string) error {
+	return filepath.Walk(srcDir, func(srcPath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		dstPath := filepath.Join(dstDir, strings.TrimPrefix(srcPath, filepath.Clean(srcDir)))
+		if fi.IsDir() {
+			return os.MkdirAll(dstPath, 0777)
+		}
+		if err = os.Link(srcPath, dstPath); err != nil {
+			return fsCopyFileWithMeta(ctx, srcPath, dstPath)
+		}
+		return nil
+	})
+}
+
+// fsCopyMetaTree - copies the `fs.json` files of the objects from
+// the metadata directory of a bucket at srcDir to dstDir, the
+// configuration files at the top of srcDir are skipped.
+func fsCopyMetaTree(ctx context.Context, srcDir, dstDir string, metaJSONFile string) error {
+	srcDir = filepath.Clean(srcDir)
+	return filepath.Walk(srcDir, func(srcPath string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fi.IsDir() || filepath.Base(srcPath) != metaJSONFile || filepath.Dir(srcPath) == srcDir {
+			return nil
+		}
+		return fsCopyFile(ctx, srcPath, filepath.Join(dstDir, strings.TrimPrefix(srcPath, srcDir)))
+	})
+}
+
+// CreateBucketSnapshot - saves the objects of bucket as snapshot name,
+// data files are hardlinked into the snapshot and copied on write
+// afterwards. Objects written while the snapshot is created may or
+// may not be part of it.
+func (fs *FSObjects) CreateBucketSnapshot(ctx context.Context, bucket, name string) (madmin.BucketSnapshot, error) {
+	if !validBucketSnapshotName.MatchString(name) {
+		return madmin.BucketSnapshot{}, errInvalidBucketSnapshotName
+	}
+	bucketDir, err := fs.getBucketDir(ctx, bucket)
+	if err != nil {
+		return madmin.BucketSnapshot{}, toObjectErr(err, bucket)
+	}
+	if _, err = fsStatVolume(ctx, bucketDir); err != nil {
+		return madmin.BucketSnapshot{}, toObjectErr(err, bucket)
+	}
+
+	snapshotDir := pathJoin(fs.bucketSnapshotsDir(bucket), name)
+	if _, err = os.Stat(snapshotDir); err == nil {
+		return madmin.BucketSnapshot{}, errBucketSnapshotExists
+	}
+
+	stagingDir := fs.newSnapshotsStagingPath()
+	defer fsRemoveAll(ctx, stagingDir)
+
+	if err = fsLinkTree(ctx, bucketDir, pathJoin(stagingDir, fsSnapshotDataDir)); err != nil {
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+	metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket)
+	if err = fsCopyMetaTree(ctx, metaDir, pathJoin(stagingDir, fsSnapshotMetaDir), fs.metaJSONFile); err != nil && !os.IsNotExist(err) {
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+	if err = fs.pack.Snapshot(bucket, pathJoin(stagingDir, fsSnapshotPackDir)); err != nil {
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+
+	snapshot := madmin.BucketSnapshot{Name: name, Created: UTCNow()}
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return madmin.BucketSnapshot{}, err
+	}
+	if err = ioutil.WriteFile(pathJoin(stagingDir, fsSnapshotInfoFile), data, 0666); err != nil {
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+	if err = os.MkdirAll(fs.bucketSnapshotsDir(bucket), 0777); err != nil {
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+	if err = os.Rename(stagingDir, snapshotDir); err != nil {
+		if os.IsExist(err) {
+			return madmin.BucketSnapshot{}, errBucketSnapshotExists
+		}
+		logger.LogIf(ctx, err)
+		return madmin.BucketSnapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// ListBucketSnapshots - returns the snapshots of bucket sorted by name,
+// the snapshots of deleted buckets are kept.
+func (fs *FSObjects) ListBucketSnapshots(ctx context.Context, bucket string) ([]madmin.BucketSnapshot, error) {
+	entries, err := readDir(fs.bucketSnapshotsDir(bucket))
+	if err != nil && err != errFileNotFound {
+		logger.LogIf(ctx, err)
+		return nil, err
+	}
+	snapshots := []madmin.BucketSnapshot{}
+	for _, entry := range entries {
+		if !hasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		data, err := ioutil.ReadFile(pathJoin(fs.bucketSnapshotsDir(bucket), entry, fsSnapshotInfoFile))
+		if err != nil {
+			continue
+		}
+		var snapshot madmin.BucketSnapshot
+		if err = json.Unmarshal(data, &snapshot); err != nil {
+			continue
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool {
+		return snapshots[i].Name < snapshots[j].Name
+	})
+	return snapshots, nil
+}
+
+// DeleteBucketSnapshot - removes snapshot name of bucket.
+func (fs *FSObjects) DeleteBucketSnapshot(ctx context.Context, bucket, name string) error {
+	if !validBucketSnapshotName.MatchString(name) {
+		return errInvalidBucketSnapshotName
+	}
+	snapshotDir := pathJoin(fs.bucketSnapshotsDir(bucket), name)
+	if _, err := os.Stat(snapshotDir); err != nil {
+		return errBucketSnapshotNotFound
+	}
+	// Move the snapshot out of the way first, such that it
+	// disappears at once.
+	stagingDir := fs.newSnapshotsStagingPath()
+	if err := fsRenameFile(ctx, snapshotDir, stagingDir); err != nil {
+		return err
+	}
+	if err := fsRemoveAll(ctx, stagingDir); err != nil {
+		return err
+	}
+	// Remove the directory of the bucket with its last snapshot.
+	if entries, err := readDir(fs.bucketSnapshotsDir(bucket)); err == nil && len(entries) == 0 {
+		fsRemoveDir(ctx, fs.bucketSnapshotsDir(bucket))
+	}
+	return nil
+}
+
+// RestoreBucketSnapshot - replaces the objects of bucket by those of
+// snapshot name, the configurations of the bucket are unchanged. The
+// bucket is write locked during the restore, which only holds off
+// bucket wide operations.
+func (fs *FSObjects) RestoreBucketSnapshot(ctx context.Context, bucket, name string) error {
+	if !validBucketSnapshotName.MatchString(name) {
+		return errInvalidBucketSnapshotName
+	}
+
+	bucketLock := fs.nsMutex.NewNSLock(ctx, bucket, "")
+	if err := bucketLock.GetLock(globalObjectTimeout); err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer bucketLock.Unlock()
+
+	bucketDir, err := fs.getBucketDir(ctx, bucket)
+	if err != nil {
+		return toObjectErr(err, bucket)
+	}
+	if _, err = fsStatVolume(ctx, bucketDir); err != nil {
+		return toObjectErr(err, bucket)
+	}
+	snapshotDir := pathJoin(fs.bucketSnapshotsDir(bucket), name)
+	if _, err = os.Stat(pathJoin(snapshotDir, fsSnapshotInfoFile)); err != nil {
+		return errBucketSnapshotNotFound
+	}
+
+	// The objects of the bucket are kept in the staging directory
+	// until the restore is complete, it is only left behind if they
+	// could not be moved back.
+	stagingDir := fs.newSnapshotsStagingPath()
+	keepStaging := false
+	defer func() {
+		if !keepStaging {
+			fsRemoveAll(ctx, stagingDir)
+		}
+	}()
+
+	// Assemble the objects of the snapshot, the data files stay
+	// shared with the snapshot.
+	newDataDir := pathJoin(stagingDir, "new-"+fsSnapshotDataDir)
+	if err = fsLinkTree(ctx, pathJoin(snapshotDir, fsSnapshotDataDir), newDataDir); err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	// `fs.json` files are modified in place, they are copied.
+	newMetaDir := pathJoin(stagingDir, "new-"+fsSnapshotMetaDir)
+	if err = fsCopyMetaTree(ctx, pathJoin(snapshotDir, fsSnapshotMetaDir), newMetaDir, fs.metaJSONFile); err != nil && !os.IsNotExist(err) {
+		logger.LogIf(ctx, err)
+		return err
+	}
+
+	// Keep the configurations of the bucket.
+	metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket)
+	entries, err := readDir(metaDir)
+	if err != nil && err != errFileNotFound {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	for _, entry := range entries {
+		if hasSuffix(entry, SlashSeparator) {
+			continue
+		}
+		if err = fsCopyFile(ctx, pathJoin(metaDir, entry), pathJoin(newMetaDir, entry)); err != nil {
+			return err
+		}
+	}
+
+	oldDataDir := pathJoin(stagingDir, "old-"+fsSnapshotDataDir)
+	oldMetaDir := pathJoin(stagingDir, "old-"+fsSnapshotMetaDir)
+	oldPackDir := pathJoin(stagingDir, "old-"+fsSnapshotPackDir)
+	if err = fs.pack.Snapshot(bucket, oldPackDir); err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+
+	// Swap the objects of the bucket with those of the snapshot, every
+	// step done is undone in reverse order if a later one fails.
+	var undo []func() error
+	rollback := func(err error) error {
+		logger.LogIf(ctx, err)
+		for i := len(undo) - 1; i >= 0; i-- {
+			if uerr := undo[i](); uerr != nil {
+				logger.LogIf(ctx, uerr)
+				keepStaging = true
+			}
+		}
+		return err
+	}
+
+	if err = fsRenameFile(ctx, bucketDir, oldDataDir); err != nil {
+		return rollback(err)
+	}
+	undo = append(undo, func() error { return fsRenameFile(ctx, oldDataDir, bucketDir) })
+	if err = fsRenameFile(ctx, newDataDir, bucketDir); err != nil {
+		return rollback(err)
+	}
+	undo = append(undo, func() error { return fsRenameFile(ctx, bucketDir, newDataDir) })
+	if _, err = os.Stat(metaDir); err == nil {
+		if err = fsRenameFile(ctx, metaDir, oldMetaDir); err != nil {
+			return rollback(err)
+		}
+		undo = append(undo, func() error { return fsRenameFile(ctx, oldMetaDir, metaDir) })
+	}
+	if _, err = os.Stat(newMetaDir); err == nil {
+		if err = fsRenameFile(ctx, newMetaDir, metaDir); err != nil {
+			return rollback(err)
+		}
+		undo = append(undo, func() error { return fsRenameFile(ctx, metaDir, newMetaDir) })
+	}
+	if err = fs.pack.Restore(ctx, bucket, pathJoin(snapshotDir, fsSnapshotPackDir)); err != nil {
+		undo = append(undo, func() error { return fs.pack.Restore(ctx, bucket, oldPackDir) })
+		return rollback(err)
+	}
+	return nil
+}
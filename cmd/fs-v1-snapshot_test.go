@@ -0,0 +1,229 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// Tests creating and restoring the snapshots of an FS bucket.
+func TestFSBucketSnapshot(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	globalFSPackThreshold = 16
+	defer func() { globalFSPackThreshold = 0 }()
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object, data string) {
+		t.Helper()
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	appendObject := func(object string, position int64, data string) {
+		t.Helper()
+		if _, err := fs.AppendObject(ctx, bucket, object, position, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get := func(object string) (string, error) {
+		var buf bytes.Buffer
+		err := obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{})
+		return buf.String(), err
+	}
+
+	put("dir/large", "data above the pack threshold")
+	put("dir/small", "small data")
+	appendObject("app.log", 0, "hello ")
+	if _, ok := fs.pack.Get(bucket, "dir/small"); !ok {
+		t.Fatal("Expected dir/small to be packed")
+	}
+
+	snapshot, err := fs.CreateBucketSnapshot(ctx, bucket, "before-job")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if snapshot.Name != "before-job" || snapshot.Created.IsZero() {
+		t.Fatalf("Unexpected snapshot %+v", snapshot)
+	}
+	if _, err = fs.CreateBucketSnapshot(ctx, bucket, "before-job"); err != errBucketSnapshotExists {
+		t.Fatalf("Expected errBucketSnapshotExists, got %v", err)
+	}
+	if _, err = fs.CreateBucketSnapshot(ctx, bucket, "../escape"); err != errInvalidBucketSnapshotName {
+		t.Fatalf("Expected errInvalidBucketSnapshotName, got %v", err)
+	}
+
+	// Changes made after the snapshot, appending in place must not
+	// change the hardlinked data file of the snapshot.
+	put("dir/large", "overwritten data above the pack threshold")
+	if err = obj.DeleteObject(ctx, bucket, "dir/small"); err != nil {
+		t.Fatal(err)
+	}
+	appendObject("app.log", 6, "world")
+	put("new", "new object above the pack threshold")
+	if err = obj.SetBucketPolicy(ctx, bucket, getAnonReadOnlyBucketPolicy(bucket)); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = fs.RestoreBucketSnapshot(ctx, bucket, "before-job"); err != nil {
+		t.Fatal(err)
+	}
+	for object, expected := range map[string]string{
+		"dir/large": "data above the pack threshold",
+		"dir/small": "small data",
+		"app.log":   "hello ",
+	} {
+		got, err := get(object)
+		if err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", object, expected, got)
+		}
+	}
+	if _, err = get("new"); !isErrObjectNotFound(err) {
+		t.Errorf("Expected the object created after the snapshot to be removed, got %v", err)
+	}
+	// The configurations of the bucket are not part of snapshots.
+	if _, err = obj.GetBucketPolicy(ctx, bucket); err != nil {
+		t.Errorf("Expected the bucket policy to be kept, got %v", err)
+	}
+
+	// The restored objects can be appended to and packed again.
+	appendObject("app.log", 6, "again")
+	put("dir/small2", "small again")
+	if got, _ := get("app.log"); got != "hello again" {
+		t.Errorf("Expected %q, got %q", "hello again", got)
+	}
+	if got, _ := get("dir/small2"); got != "small again" {
+		t.Errorf("Expected %q, got %q", "small again", got)
+	}
+
+	snapshots, err := fs.ListBucketSnapshots(ctx, bucket)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 || snapshots[0].Name != "before-job" {
+		t.Fatalf("Unexpected snapshots %+v", snapshots)
+	}
+	if err = fs.DeleteBucketSnapshot(ctx, bucket, "before-job"); err != nil {
+		t.Fatal(err)
+	}
+	if snapshots, err = fs.ListBucketSnapshots(ctx, bucket); err != nil || len(snapshots) != 0 {
+		t.Fatalf("Expected no snapshots, got %+v, %v", snapshots, err)
+	}
+	if err = fs.RestoreBucketSnapshot(ctx, bucket, "before-job"); err != errBucketSnapshotNotFound {
+		t.Fatalf("Expected errBucketSnapshotNotFound, got %v", err)
+	}
+}
+
+func TestFSBucketSnapshotRestoreRollback(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	globalFSPackThreshold = 16
+	defer func() { globalFSPackThreshold = 0 }()
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	ctx := context.Background()
+	bucket := "bucket"
+	if err := obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	put := func(object, data string) {
+		t.Helper()
+		if _, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	get := func(object string) (string, error) {
+		var buf bytes.Buffer
+		err := obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{})
+		return buf.String(), err
+	}
+
+	put("large", "data above the pack threshold")
+	put("small", "small data")
+	if _, err := fs.CreateBucketSnapshot(ctx, bucket, "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+	put("large", "changed data above the pack threshold")
+	put("small", "changed")
+	put("new", "new object above the pack threshold")
+	if err := obj.SetBucketPolicy(ctx, bucket, getAnonReadOnlyBucketPolicy(bucket)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Restoring the packed objects is the last step, a slab which
+	// cannot be copied makes it fail after everything else was swapped.
+	badSlab := pathJoin(fs.bucketSnapshotsDir(bucket), "snapshot", fsSnapshotPackDir, fsPackSlabPrefix+"bad")
+	if err := os.MkdirAll(badSlab, 0777); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.RestoreBucketSnapshot(ctx, bucket, "snapshot"); err == nil {
+		t.Fatal("Expected the restore to fail")
+	}
+
+	// The bucket is left as it was before the restore.
+	for object, expected := range map[string]string{
+		"large": "changed data above the pack threshold",
+		"small": "changed",
+		"new":   "new object above the pack threshold",
+	} {
+		got, err := get(object)
+		if err != nil {
+			t.Fatalf("%s: %v", object, err)
+		}
+		if got != expected {
+			t.Errorf("%s: expected %q, got %q", object, expected, got)
+		}
+	}
+	if _, err := obj.GetBucketPolicy(ctx, bucket); err != nil {
+		t.Errorf("Expected the bucket policy to be kept, got %v", err)
+	}
+	stagingDir := pathJoin(fs.fsPath, minioMetaBucket, fsSnapshotsPrefix, fsSnapshotsStagingDir)
+	if entries, err := readDir(stagingDir); err == nil && len(entries) != 0 {
+		t.Errorf("Expected the staging directory to be cleaned up, got %v", entries)
+	}
+
+	if err := os.Remove(badSlab); err != nil {
+		t.Fatal(err)
+	}
+	if err := fs.RestoreBucketSnapshot(ctx, bucket, "snapshot"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := get("small"); got != "small data" {
+		t.Errorf("Expected %q, got %q", "small data", got)
+	}
+	if _, err := get("new"); !isErrObjectNotFound(err) {
+		t.Errorf("Expected the object created after the snapshot to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,75 @@
+//go:build linux
+// +build linux
+
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// Name of the extended attribute holding inline `fs.json`.
+const fsMetaXattrName = "user.minio.fs.json"
+
+func fsXattrErr(err error) error {
+	switch {
+	case errors.Is(err, unix.ENODATA):
+		return errFileNotFound
+	case errors.Is(err, syscall.ENOTSUP):
+		return errFSXattrNotSupported
+	}
+	return osErrToFSFileErr(err)
+}
+
+// fsGetMetaXattr - returns the inline `fs.json` of the file at filePath.
+func fsGetMetaXattr(filePath string) ([]byte, error) {
+	buf := make([]byte, 1024)
+	for {
+		n, err := unix.Getxattr(filePath, fsMetaXattrName, buf)
+		if err == nil {
+			return buf[:n], nil
+		}
+		if !errors.Is(err, unix.ERANGE) {
+			return nil, fsXattrErr(err)
+		}
+		// Retry with a buffer of the current size of the attribute.
+		if n, err = unix.Getxattr(filePath, fsMetaXattrName, nil); err != nil {
+			return nil, fsXattrErr(err)
+		}
+		buf = make([]byte, n)
+	}
+}
+
+// fsSetMetaXattr - stores buf as the inline `fs.json` of the file at filePath.
+func fsSetMetaXattr(filePath string, buf []byte) error {
+	if err := unix.Setxattr(filePath, fsMetaXattrName, buf, 0); err != nil {
+		return fsXattrErr(err)
+	}
+	return nil
+}
+
+// fsRemoveMetaXattr - removes the inline `fs.json` of the file at filePath.
+func fsRemoveMetaXattr(filePath string) error {
+	if err := unix.Removexattr(filePath, fsMetaXattrName); err != nil {
+		return fsXattrErr(err)
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package cmd
 import (
 	"bytes"
 	"context"
+	"errors"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -32,6 +33,7 @@ import (
 
 	jsoniter "github.com/json-iterator/go"
 	"github.com/minio/minio-go/v6/pkg/s3utils"
+	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/config"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/lifecycle"
@@ -57,6 +59,9 @@ type FSObjects struct {
 	// Unique value to be used for all
 	// temporary transactions.
 	fsUUID string
+	// Directory where uploaded data is staged before
+	// being renamed into place, may be on another device.
+	fsTmpDir string
 
 	// This value shouldn't be touched, once initialized.
 	fsFormatRlk *lock.RLockedFile // Is a read lock on `format.json`.
@@ -74,6 +79,86 @@ type FSObjects struct {
 
 	// To manage the appendRoutine go-routines
 	nsMutex *nsLockMap
+
+	// ObjectInfo of recently read objects.
+	objInfoCache *fsObjInfoCache
+
+	// Small objects packed into slabs.
+	pack *fsPackStore
+
+	// Aliases of the legacy bucket directories, nil
+	// when MINIO_FS_BUCKET_ALIASES is not enabled.
+	bucketAliases *fsBucketAliases
+
+	// Index of the entries of large directories, nil
+	// when MINIO_FS_LISTING_CACHE is not enabled.
+	listingCache *fsListingCache
+
+	// Objects of at most this size keep their `fs.json` in an
+	// extended attribute of the data file, zero disables it.
+	inlineMetaThreshold int64
+
+	// Objects usage of the last disk usage crawl.
+	objectsUsage   fsObjectsUsage
+	objectsUsageMu sync.RWMutex
+}
+
+// fsObjectsUsage - count, total size and sizes histogram of the
+// objects in all buckets.
+type fsObjectsUsage struct {
+	ObjectsCount          uint64
+	ObjectsSizesHistogram map[string]uint64
+	BucketsUsage          map[string]madmin.BucketUsageInfo
+}
+
+func newFSObjectsUsage() fsObjectsUsage {
+	return fsObjectsUsage{
+		ObjectsSizesHistogram: make(map[string]uint64),
+		BucketsUsage:          make(map[string]madmin.BucketUsageInfo),
+	}
+}
+
+// addBucket - adds a bucket without objects yet.
+func (u *fsObjectsUsage) addBucket(bucket string) {
+	if _, ok := u.BucketsUsage[bucket]; !ok {
+		u.BucketsUsage[bucket] = madmin.BucketUsageInfo{
+			ObjectsSizesHistogram: make(map[string]uint64),
+		}
+	}
+}
+
+// addObject - adds an object of size to the usage of bucket.
+func (u *fsObjectsUsage) addObject(bucket string, size int64) {
+	u.addBucket(bucket)
+	interval := getObjectsHistogramInterval(size)
+
+	bucketUsage := u.BucketsUsage[bucket]
+	bucketUsage.ObjectsCount++
+	bucketUsage.ObjectsTotalSize += uint64(size)
+	bucketUsage.ObjectsSizesHistogram[interval]++
+	u.BucketsUsage[bucket] = bucketUsage
+
+	u.ObjectsCount++
+	u.ObjectsSizesHistogram[interval]++
+}
+
+// add - adds an entry found by the disk usage crawl of fsPath,
+// only bucket directories and files within buckets are counted.
+func (u *fsObjectsUsage) add(fsPath, entry string, fi os.FileInfo) {
+	entry = strings.TrimPrefix(entry, fsPath+SlashSeparator)
+	idx := strings.Index(entry, SlashSeparator)
+	if idx <= 0 {
+		return
+	}
+	bucket := entry[:idx]
+	if bucket == minioMetaBucket {
+		return
+	}
+	if fi.IsDir() {
+		u.addBucket(bucket)
+		return
+	}
+	u.addObject(bucket, fi.Size())
 }
 
 // Represents the background append file.
@@ -129,25 +214,83 @@ func NewFSObjectLayer(fsPath string) (ObjectLayer, error) {
 		return nil, err
 	}
 
+	fsTmpDir := pathJoin(fsPath, minioMetaTmpBucket, fsUUID)
+	if globalFSTmpDir != "" {
+		fsTmpDir = pathJoin(globalFSTmpDir, fsUUID)
+		if err = os.MkdirAll(fsTmpDir, 0777); err != nil {
+			return nil, config.ErrInvalidFSTmpDirValue(err)
+		}
+	}
+
 	// Initialize `format.json`, this function also returns.
 	rlk, err := initFormatFS(ctx, fsPath)
 	if err != nil {
 		return nil, err
 	}
 
+	// The index of packed objects is held in memory, which
+	// does not work for backends shared by several servers.
+	packThreshold := globalFSPackThreshold
+	if globalIsGateway {
+		packThreshold = 0
+	}
+	pack, err := newFSPackStore(pathJoin(fsPath, minioMetaBucket, fsPackPrefix), packThreshold)
+	if err != nil {
+		rlk.Close()
+		return nil, err
+	}
+
+	var bucketAliases *fsBucketAliases
+	if globalFSBucketAliases {
+		if bucketAliases, err = loadFSBucketAliases(fsPath); err != nil {
+			rlk.Close()
+			pack.Close()
+			return nil, err
+		}
+	}
+
+	var listingCache *fsListingCache
+	if globalFSListingCache {
+		if listingCache, err = newFSListingCache(pathJoin(fsPath, minioMetaBucket, fsListingCachePrefix)); err != nil {
+			rlk.Close()
+			pack.Close()
+			return nil, err
+		}
+	}
+
+	// Inline metadata is not protected by the `fs.json` locks
+	// which synchronize servers sharing a backend.
+	inlineMetaThreshold := globalFSInlineMetaThreshold
+	if globalIsGateway {
+		inlineMetaThreshold = 0
+	}
+	if inlineMetaThreshold > 0 && !fsInlineMetaSupported(pathJoin(fsPath, minioMetaTmpBucket, fsUUID)) {
+		logger.Info("Inline FS metadata is disabled, %s does not support extended attributes", fsPath)
+		inlineMetaThreshold = 0
+	}
+
 	// Initialize fs objects.
 	fs := &FSObjects{
 		fsPath:       fsPath,
 		metaJSONFile: fsMetaJSONFile,
 		fsUUID:       fsUUID,
+		fsTmpDir:     fsTmpDir,
 		rwPool: &fsIOPool{
 			readersMap: make(map[string]*lock.RLockedFile),
+			maxOpen:    getFSMaxOpenFiles(),
 		},
 		nsMutex:       newNSLock(false),
 		listPool:      NewTreeWalkPool(globalLookupTimeout),
 		appendFileMap: make(map[string]*fsAppendFile),
 		diskMount:     mountinfo.IsLikelyMountPoint(fsPath),
+		objInfoCache:  newFSObjInfoCache(fsObjInfoCacheSize, fsObjInfoCacheTTL),
+		pack:          pack,
+		bucketAliases: bucketAliases,
+		listingCache:  listingCache,
+
+		inlineMetaThreshold: inlineMetaThreshold,
 	}
+	fs.nsMutex.onWriteUnlock = fs.objInfoCache.Invalidate
 
 	// Once the filesystem has initialized hold the read lock for
 	// the life time of the server. This is done to ensure that under
@@ -155,8 +298,10 @@ func NewFSObjectLayer(fsPath string) (ObjectLayer, error) {
 	// or cause changes on backend format.
 	fs.fsFormatRlk = rlk
 
-	if !fs.diskMount {
-		go fs.diskUsage(GlobalServiceDoneCh)
+	go fs.diskUsage(GlobalServiceDoneCh)
+
+	if fs.listingCache != nil {
+		go fs.refreshListingCache(GlobalServiceDoneCh)
 	}
 
 	go fs.cleanupStaleMultipartUploads(ctx, GlobalMultipartCleanupInterval, GlobalMultipartExpiry, GlobalServiceDoneCh)
@@ -168,13 +313,51 @@ func NewFSObjectLayer(fsPath string) (ObjectLayer, error) {
 // Shutdown - should be called when process shuts down.
 func (fs *FSObjects) Shutdown(ctx context.Context) error {
 	fs.fsFormatRlk.Close()
+	fs.pack.Close()
 
 	// Cleanup and delete tmp uuid.
+	if fs.fsTmpDir != pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID) {
+		fsRemoveAll(ctx, fs.fsTmpDir)
+	}
 	return fsRemoveAll(ctx, pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID))
 }
 
-// diskUsage returns du information for the posix path, in a continuous routine.
+// renameTmpFile renames a file staged in fs.fsTmpDir to destPath. When
+// the staging directory is on another device the file is first copied
+// into the temporary directory of the backend and renamed from there,
+// so that destPath never holds a partially written file.
+func (fs *FSObjects) renameTmpFile(ctx context.Context, tmpPath, destPath string) error {
+	err := renameAll(tmpPath, destPath)
+	if err == nil || !errors.Is(err, errCrossDeviceLink) {
+		logger.LogIf(ctx, err)
+		return err
+	}
+
+	fsTmpPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, mustGetUUID())
+	if err = fsCopyFile(ctx, tmpPath, fsTmpPath); err != nil {
+		fsRemoveFile(ctx, fsTmpPath)
+		return err
+	}
+	// Inline `fs.json` is not copied along with the data.
+	if buf, xerr := fsGetMetaXattr(tmpPath); xerr == nil {
+		if err = fsSetMetaXattr(fsTmpPath, buf); err != nil {
+			logger.LogIf(ctx, err)
+			fsRemoveFile(ctx, fsTmpPath)
+			return err
+		}
+	}
+	if err = fsRenameFile(ctx, fsTmpPath, destPath); err != nil {
+		fsRemoveFile(ctx, fsTmpPath)
+		return err
+	}
+	fsRemoveFile(ctx, tmpPath)
+	return nil
+}
+
+// diskUsage returns du information for the posix path, along with
+// the objects usage of all buckets, in a continuous routine.
 func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
+	objectsUsage := newFSObjectsUsage()
 	usageFn := func(ctx context.Context, entry string) error {
 		if globalHTTPServer != nil {
 			// Wait at max 1 minute for an inprogress request
@@ -197,15 +380,22 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 				return err
 			}
 			atomic.AddUint64(&fs.totalUsed, uint64(fi.Size()))
+			objectsUsage.add(fs.fsPath, entry, fi)
 		}
 		return nil
 	}
 
 	// Return this routine upon errWalkAbort, continue for any other error on purpose
 	// so that we can start the routine freshly in another 12 hours.
-	if err := getDiskUsage(context.Background(), fs.fsPath, usageFn); err == errWalkAbort {
+	err := getDiskUsage(context.Background(), fs.fsPath, usageFn)
+	if err == errWalkAbort {
 		return
 	}
+	if err == nil {
+		fs.pack.addUsage(&objectsUsage)
+		fs.bucketAliases.renameUsage(&objectsUsage)
+		fs.setObjectsUsage(objectsUsage)
+	}
 
 	for {
 		select {
@@ -213,6 +403,7 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 			return
 		case <-time.After(globalUsageCheckInterval):
 			var usage uint64
+			objectsUsage = newFSObjectsUsage()
 			usageFn = func(ctx context.Context, entry string) error {
 				if globalHTTPServer != nil {
 					// Wait at max 1 minute for an inprogress request
@@ -231,6 +422,7 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 					return err
 				}
 				usage = usage + uint64(fi.Size())
+				objectsUsage.add(fs.fsPath, entry, fi)
 				return nil
 			}
 
@@ -238,10 +430,19 @@ func (fs *FSObjects) diskUsage(doneCh chan struct{}) {
 				continue
 			}
 			atomic.StoreUint64(&fs.totalUsed, usage)
+			fs.pack.addUsage(&objectsUsage)
+			fs.bucketAliases.renameUsage(&objectsUsage)
+			fs.setObjectsUsage(objectsUsage)
 		}
 	}
 }
 
+func (fs *FSObjects) setObjectsUsage(objectsUsage fsObjectsUsage) {
+	fs.objectsUsageMu.Lock()
+	fs.objectsUsage = objectsUsage
+	fs.objectsUsageMu.Unlock()
+}
+
 // StorageInfo - returns underlying storage statistics.
 func (fs *FSObjects) StorageInfo(ctx context.Context) StorageInfo {
 	di, err := getDiskInfo(fs.fsPath)
@@ -258,6 +459,13 @@ func (fs *FSObjects) StorageInfo(ctx context.Context) StorageInfo {
 		Available: di.Free,
 	}
 	storageInfo.Backend.Type = BackendFS
+
+	fs.objectsUsageMu.RLock()
+	storageInfo.Backend.ObjectsCount = fs.objectsUsage.ObjectsCount
+	storageInfo.Backend.ObjectsSizesHistogram = fs.objectsUsage.ObjectsSizesHistogram
+	storageInfo.Backend.BucketsUsage = fs.objectsUsage.BucketsUsage
+	fs.objectsUsageMu.RUnlock()
+
 	return storageInfo
 }
 
@@ -270,10 +478,16 @@ func (fs *FSObjects) getBucketDir(ctx context.Context, bucket string) (string, e
 	if bucket == "" || bucket == "." || bucket == ".." {
 		return "", errVolumeNotFound
 	}
-	bucketDir := pathJoin(fs.fsPath, bucket)
+	bucketDir := fs.bucketPath(bucket)
 	return bucketDir, nil
 }
 
+// bucketPath - returns the path of bucket, or of an entry of bucket,
+// in the backend. Aliases are resolved to their legacy directory.
+func (fs *FSObjects) bucketPath(bucket string, elem ...string) string {
+	return pathJoin(append([]string{fs.fsPath, fs.bucketAliases.Dir(bucket)}, elem...)...)
+}
+
 func (fs *FSObjects) statBucketDir(ctx context.Context, bucket string) (os.FileInfo, error) {
 	bucketDir, err := fs.getBucketDir(ctx, bucket)
 	if err != nil {
@@ -330,6 +544,18 @@ func (fs *FSObjects) GetBucketInfo(ctx context.Context, bucket string) (bi Bucke
 	}, nil
 }
 
+// BucketExists - returns true if the bucket directory exists, unlike
+// GetBucketInfo the bucket lock is not taken.
+func (fs *FSObjects) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	if _, err := fs.statBucketDir(ctx, bucket); err != nil {
+		if err == errVolumeNotFound {
+			return false, nil
+		}
+		return false, toObjectErr(err, bucket)
+	}
+	return true, nil
+}
+
 // ListBuckets - list all s3 compatible buckets (directories) at fsPath.
 func (fs *FSObjects) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
 	if err := checkPathLength(fs.fsPath); err != nil {
@@ -358,8 +584,12 @@ func (fs *FSObjects) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
 			// Ignore any errors returned here.
 			continue
 		}
+		name := fi.Name()
+		if alias, ok := fs.bucketAliases.Alias(name); ok {
+			name = alias
+		}
 		bucketInfos = append(bucketInfos, BucketInfo{
-			Name: fi.Name(),
+			Name: name,
 			// As os.Stat() doesnt carry CreatedTime, use ModTime() as CreatedTime.
 			Created: fi.ModTime(),
 		})
@@ -374,7 +604,9 @@ func (fs *FSObjects) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
 
 // DeleteBucket - delete a bucket and all the metadata associated
 // with the bucket including pending multipart, object metadata.
-func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string) error {
+// When forceDelete is set a bucket which is not empty is deleted
+// along with all of its objects.
+func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	bucketLock := fs.nsMutex.NewNSLock(ctx, bucket, "")
 	if err := bucketLock.GetLock(globalObjectTimeout); err != nil {
 		logger.LogIf(ctx, err)
@@ -386,8 +618,30 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string) error {
 		return toObjectErr(err, bucket)
 	}
 
-	// Attempt to delete regular bucket.
-	if err = fsRemoveDir(ctx, bucketDir); err != nil {
+	if forceDelete {
+		if err = fs.removeBucketDir(ctx, bucketDir); err != nil {
+			return toObjectErr(err, bucket)
+		}
+	} else {
+		if !fs.pack.IsEmpty(bucket) {
+			return toObjectErr(errVolumeNotEmpty, bucket)
+		}
+
+		// Attempt to delete regular bucket.
+		if err = fsRemoveDir(ctx, bucketDir); err != nil {
+			return toObjectErr(err, bucket)
+		}
+	}
+
+	if err = fs.pack.DeleteBucket(ctx, bucket); err != nil {
+		return toObjectErr(err, bucket)
+	}
+
+	if err = fs.listingCache.DeleteBucket(ctx, bucket); err != nil {
+		return toObjectErr(err, bucket)
+	}
+
+	if err = fs.bucketAliases.Remove(bucket); err != nil {
 		return toObjectErr(err, bucket)
 	}
 
@@ -403,6 +657,23 @@ func (fs *FSObjects) DeleteBucket(ctx context.Context, bucket string) error {
 	return nil
 }
 
+// removeBucketDir - removes the bucket directory and all of its
+// objects, the directory is first moved into the temporary bucket
+// such that the bucket disappears at once.
+func (fs *FSObjects) removeBucketDir(ctx context.Context, bucketDir string) error {
+	if _, err := fsStatVolume(ctx, bucketDir); err != nil {
+		return err
+	}
+	tmpBucketDir := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, mustGetUUID())
+	if err := fsRenameFile(ctx, bucketDir, tmpBucketDir); err != nil {
+		if err == errFileNotFound {
+			return errVolumeNotFound
+		}
+		return err
+	}
+	return fsRemoveAll(ctx, tmpBucketDir)
+}
+
 /// Object Operations
 
 // CopyObject - copy object source object to destination object.
@@ -416,6 +687,10 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 			return oi, err
 		}
 		defer objectDWLock.Unlock()
+	} else {
+		// The object is updated in place without taking the
+		// namespace write lock, drop its cached ObjectInfo.
+		defer fs.objInfoCache.Invalidate(srcBucket, srcObject)
 	}
 
 	if _, err := fs.statBucketDir(ctx, srcBucket); err != nil {
@@ -423,7 +698,45 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	}
 
 	if cpSrcDstSame && srcInfo.metadataOnly {
+		if _, ok := fs.pack.Get(srcBucket, srcObject); ok {
+			meta := cloneUserDefined(srcInfo.UserDefined)
+			meta["etag"] = srcInfo.ETag
+			e, err := fs.pack.UpdateMeta(srcBucket, srcObject, meta)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return oi, toObjectErr(err, srcBucket, srcObject)
+			}
+			return e.ToObjectInfo(srcBucket), nil
+		}
+
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, srcBucket, srcObject, fs.metaJSONFile)
+
+		fsObjPath := fs.bucketPath(srcBucket, srcObject)
+		if fsMeta, err := fsReadInlineMeta(fsObjPath); err == nil {
+			fsMeta.Meta = srcInfo.UserDefined
+			fsMeta.Meta["etag"] = srcInfo.ETag
+			if err = fs.unshareObjectFile(ctx, srcBucket, fsObjPath); err != nil {
+				logger.LogIf(ctx, err)
+				return oi, toObjectErr(err, srcBucket, srcObject)
+			}
+			if err = fsWriteInlineMeta(fsObjPath, fsMeta); err != nil {
+				// The metadata no longer fits in an extended
+				// attribute, move it to `fs.json`.
+				if err = fs.writeFsJSON(fsMetaPath, fsMeta); err == nil {
+					err = fsRemoveMetaXattr(fsObjPath)
+				}
+			}
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return oi, toObjectErr(err, srcBucket, srcObject)
+			}
+			fi, err := fsStatFile(ctx, fsObjPath)
+			if err != nil {
+				return oi, toObjectErr(err, srcBucket, srcObject)
+			}
+			return fsMeta.ToObjectInfo(srcBucket, srcObject, fi), nil
+		}
+
 		wlk, err := fs.rwPool.Write(fsMetaPath)
 		if err != nil {
 			logger.LogIf(ctx, err)
@@ -446,7 +759,7 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		}
 
 		// Stat the file to get file size.
-		fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, srcBucket, srcObject))
+		fi, err := fsStatFile(ctx, fs.bucketPath(srcBucket, srcObject))
 		if err != nil {
 			return oi, toObjectErr(err, srcBucket, srcObject)
 		}
@@ -459,7 +772,14 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return ObjectInfo{}, err
 	}
 
-	objInfo, err := fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined})
+	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
+	var objInfo ObjectInfo
+	var err error
+	if fs.pack.canPack(dstBucket, dstObject, srcInfo.PutObjReader.Size()) {
+		objInfo, err = fs.putPackedObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
+	} else {
+		objInfo, err = fs.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
+	}
 	if err != nil {
 		return oi, toObjectErr(err, dstBucket, dstObject)
 	}
@@ -481,6 +801,10 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 
 	var nsUnlocker = func() {}
 
+	if lockType == readLock && isObjectImmutable(bucket) {
+		lockType = noLock
+	}
+
 	if lockType != noLock {
 		// Lock the object before reading.
 		lock := fs.nsMutex.NewNSLock(ctx, bucket, object)
@@ -533,8 +857,7 @@ func (fs *FSObjects) GetObjectNInfo(ctx context.Context, bucket, object string,
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
-	readCloser, size, err := fsOpenFile(ctx, fsObjPath, off)
+	readCloser, size, err := fs.openObject(ctx, bucket, object, off)
 	if err != nil {
 		rwPoolUnlocker()
 		nsUnlocker()
@@ -628,8 +951,7 @@ func (fs *FSObjects) getObject(ctx context.Context, bucket, object string, offse
 	}
 
 	// Read the object, doesn't exist returns an s3 compatible error.
-	fsObjPath := pathJoin(fs.fsPath, bucket, object)
-	reader, size, err := fsOpenFile(ctx, fsObjPath, offset)
+	reader, size, err := fs.openObject(ctx, bucket, object, offset)
 	if err != nil {
 		return toObjectErr(err, bucket, object)
 	}
@@ -679,6 +1001,17 @@ func (fs *FSObjects) createFsJSON(object, fsMetaPath string) error {
 	return werr
 }
 
+// writeFsJSON - writes fsMeta to the `fs.json` at fsMetaPath.
+func (fs *FSObjects) writeFsJSON(fsMetaPath string, fsMeta fsMetaV1) error {
+	wlk, err := fs.rwPool.Create(fsMetaPath)
+	if err != nil {
+		return err
+	}
+	defer wlk.Close()
+	_, err = fsMeta.WriteTo(wlk)
+	return err
+}
+
 // Used to return default etag values when a pre-existing object's meta data is queried.
 func (fs *FSObjects) defaultFsJSON(object string) fsMetaV1 {
 	fsMeta := newFSMetaV1()
@@ -693,13 +1026,40 @@ func (fs *FSObjects) defaultFsJSON(object string) fsMetaV1 {
 func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (oi ObjectInfo, e error) {
 	fsMeta := fsMetaV1{}
 	if hasSuffix(object, SlashSeparator) {
-		fi, err := fsStatDir(ctx, pathJoin(fs.fsPath, bucket, object))
+		fi, err := fsStatDir(ctx, fs.bucketPath(bucket, object))
 		if err != nil {
 			return oi, err
 		}
 		return fsMeta.ToObjectInfo(bucket, object, fi), nil
 	}
 
+	if oi, ok := fs.objInfoCache.Get(bucket, object); ok {
+		return oi, nil
+	}
+
+	if e, ok := fs.pack.Get(bucket, object); ok {
+		return e.ToObjectInfo(bucket), nil
+	}
+	gen := fs.objInfoCache.Generation()
+
+	// Stat the file to get file size.
+	fsObjPath := fs.bucketPath(bucket, object)
+	fi, err := fsStatFile(ctx, fsObjPath)
+	if err != nil {
+		return oi, err
+	}
+
+	// Small objects may keep `fs.json` in an extended
+	// attribute, which saves opening another file.
+	if fi.Size() <= fsInlineMetaMaxThreshold {
+		if fsMeta, err = fsReadInlineMeta(fsObjPath); err == nil {
+			oi = fsMeta.ToObjectInfo(bucket, object, fi)
+			fs.objInfoCache.Set(bucket, object, oi, gen)
+			return oi, nil
+		}
+		fsMeta = fsMetaV1{}
+	}
+
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	// Read `fs.json` to perhaps contend with
 	// parallel Put() operations.
@@ -726,23 +1086,21 @@ func (fs *FSObjects) getObjectInfo(ctx context.Context, bucket, object string) (
 		return oi, err
 	}
 
-	// Stat the file to get file size.
-	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
-	if err != nil {
-		return oi, err
-	}
-
-	return fsMeta.ToObjectInfo(bucket, object, fi), nil
+	oi = fsMeta.ToObjectInfo(bucket, object, fi)
+	fs.objInfoCache.Set(bucket, object, oi, gen)
+	return oi, nil
 }
 
 // getObjectInfoWithLock - reads object metadata and replies back ObjectInfo.
 func (fs *FSObjects) getObjectInfoWithLock(ctx context.Context, bucket, object string) (oi ObjectInfo, e error) {
 	// Lock the object before reading.
-	objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
-	if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
-		return oi, err
+	if !isObjectImmutable(bucket) {
+		objectLock := fs.nsMutex.NewNSLock(ctx, bucket, object)
+		if err := objectLock.GetRLock(globalObjectTimeout); err != nil {
+			return oi, err
+		}
+		defer objectLock.RUnlock()
 	}
-	defer objectLock.RUnlock()
 
 	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
 		return oi, err
@@ -789,7 +1147,7 @@ func (fs *FSObjects) parentDirIsObject(ctx context.Context, bucket, parent strin
 		if p == "." || p == SlashSeparator {
 			return false
 		}
-		if fsIsFile(ctx, pathJoin(fs.fsPath, bucket, p)) {
+		if fsIsFile(ctx, fs.bucketPath(bucket, p)) {
 			// If there is already a file at prefix "p", return true.
 			return true
 		}
@@ -816,9 +1174,25 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 	}
 	defer objectLock.Unlock()
 
+	if err := fs.checkPutPreconditions(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
+	if fs.pack.canPack(bucket, object, r.Size()) {
+		return fs.putPackedObject(ctx, bucket, object, r, opts)
+	}
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
+// checkPutPreconditions - evaluates the write preconditions of opts,
+// callers must hold the object write lock.
+func (fs *FSObjects) checkPutPreconditions(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return checkPutPreconditions(opts, func() (ObjectInfo, error) {
+		objInfo, err := fs.getObjectInfo(ctx, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	})
+}
+
 // putObject - wrapper for PutObject
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
@@ -846,12 +1220,12 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		if fs.parentDirIsObject(ctx, bucket, path.Dir(object)) {
 			return ObjectInfo{}, toObjectErr(errFileParentIsFile, bucket, object)
 		}
-		if err = mkdirAll(pathJoin(fs.fsPath, bucket, object), 0777); err != nil {
+		if err = mkdirAll(fs.bucketPath(bucket, object), 0777); err != nil {
 			logger.LogIf(ctx, err)
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
 		var fi os.FileInfo
-		if fi, err = fsStatDir(ctx, pathJoin(fs.fsPath, bucket, object)); err != nil {
+		if fi, err = fsStatDir(ctx, fs.bucketPath(bucket, object)); err != nil {
 			return ObjectInfo{}, toObjectErr(err, bucket, object)
 		}
 		return fsMeta.ToObjectInfo(bucket, object, fi), nil
@@ -868,8 +1242,12 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, errInvalidArgument
 	}
 
+	// Small objects keep `fs.json` in an extended attribute of the
+	// data file, which is renamed into place along with the data.
+	inlineMeta := fs.canInlineMeta(bucket, data.Size())
+
 	var wlk *lock.LockedFile
-	if bucket != minioMetaBucket {
+	if bucket != minioMetaBucket && !inlineMeta {
 		bucketMetaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix)
 
 		fsMetaPath := pathJoin(bucketMetaDir, bucket, object, fs.metaJSONFile)
@@ -901,7 +1279,7 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	}
 
 	buf := make([]byte, int(bufSize))
-	fsTmpObjPath := pathJoin(fs.fsPath, minioMetaTmpBucket, fs.fsUUID, tempObj)
+	fsTmpObjPath := pathJoin(fs.fsTmpDir, tempObj)
 	bytesWritten, err := fsCreateFile(ctx, fsTmpObjPath, data, buf, data.Size())
 	if err != nil {
 		fsRemoveFile(ctx, fsTmpObjPath)
@@ -921,27 +1299,57 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 	// nothing to delete.
 	defer fsRemoveFile(ctx, fsTmpObjPath)
 
+	// Metadata too large for an extended attribute is
+	// written to `fs.json` instead.
+	if inlineMeta && fsWriteInlineMeta(fsTmpObjPath, fsMeta) != nil {
+		inlineMeta = false
+	}
+
 	// Entire object was written to the temp location, now it's safe to rename it to the actual location.
-	fsNSObjPath := pathJoin(fs.fsPath, bucket, object)
+	fsNSObjPath := fs.bucketPath(bucket, object)
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
+		if _, ok := fs.pack.Get(bucket, object); ok {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
 		if _, err = fsStatFile(ctx, fsNSObjPath); err == nil {
 			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
 		}
 	}
-	if err = fsRenameFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
+	if err = fs.renameTmpFile(ctx, fsTmpObjPath, fsNSObjPath); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Drop a previously packed version of the object.
+	if _, err = fs.pack.Delete(bucket, object); err != nil {
+		logger.LogIf(ctx, err)
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 
 	if bucket != minioMetaBucket {
-		// Write FS metadata after a successful namespace operation.
-		if _, err = fsMeta.WriteTo(wlk); err != nil {
-			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
+		fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
+		switch {
+		case wlk != nil:
+			// Write FS metadata after a successful namespace operation.
+			if _, err = fsMeta.WriteTo(wlk); err != nil {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		case inlineMeta:
+			// Remove `fs.json` of a previous version of the object.
+			if err = fsDeleteFile(ctx, minioMetaBucketDir, fsMetaPath); err != nil && err != errFileNotFound {
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
+		default:
+			if err = fs.writeFsJSON(fsMetaPath, fsMeta); err != nil {
+				logger.LogIf(ctx, err)
+				return ObjectInfo{}, toObjectErr(err, bucket, object)
+			}
 		}
 	}
 
 	// Stat the file to fetch timestamp, size.
-	fi, err := fsStatFile(ctx, pathJoin(fs.fsPath, bucket, object))
+	fi, err := fsStatFile(ctx, fs.bucketPath(bucket, object))
 	if err != nil {
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
@@ -978,6 +1386,11 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 		return toObjectErr(err, bucket)
 	}
 
+	if packed, err := fs.pack.Delete(bucket, object); packed {
+		logger.LogIf(ctx, err)
+		return toObjectErr(err, bucket, object)
+	}
+
 	minioMetaBucketDir := pathJoin(fs.fsPath, minioMetaBucket)
 	fsMetaPath := pathJoin(minioMetaBucketDir, bucketMetaPrefix, bucket, object, fs.metaJSONFile)
 	if bucket != minioMetaBucket {
@@ -993,7 +1406,7 @@ func (fs *FSObjects) DeleteObject(ctx context.Context, bucket, object string) er
 	}
 
 	// Delete the object.
-	if err := fsDeleteFile(ctx, pathJoin(fs.fsPath, bucket), pathJoin(fs.fsPath, bucket, object)); err != nil {
+	if err := fsDeleteFile(ctx, fs.bucketPath(bucket), fs.bucketPath(bucket, object)); err != nil {
 		return toObjectErr(err, bucket, object)
 	}
 
@@ -1014,11 +1427,14 @@ func (fs *FSObjects) listDirFactory() ListDirFunc {
 	// listDir - lists all the entries at a given prefix and given entry in the prefix.
 	listDir := func(bucket, prefixDir, prefixEntry string) (entries []string) {
 		var err error
-		entries, err = readDir(pathJoin(fs.fsPath, bucket, prefixDir))
+		entries, err = fs.listingCache.readDir(bucket, prefixDir, fs.bucketPath(bucket, prefixDir))
 		if err != nil && err != errFileNotFound {
 			logger.LogIf(context.Background(), err)
 			return
 		}
+		if packed := fs.pack.ListDir(bucket, prefixDir); len(packed) > 0 {
+			entries = set.CreateStringSet(append(entries, packed...)...).ToSlice()
+		}
 		sort.Strings(entries)
 		return filterMatchingPrefix(entries, prefixEntry)
 	}
@@ -1031,7 +1447,7 @@ func (fs *FSObjects) listDirFactory() ListDirFunc {
 // and the prefix represents an empty directory. An S3 empty directory
 // is also an empty directory in the FS backend.
 func (fs *FSObjects) isObjectDir(bucket, prefix string) bool {
-	entries, err := readDirN(pathJoin(fs.fsPath, bucket, prefix), 1)
+	entries, err := readDirN(fs.bucketPath(bucket, prefix), 1)
 	if err != nil {
 		return false
 	}
@@ -1041,6 +1457,13 @@ func (fs *FSObjects) isObjectDir(bucket, prefix string) bool {
 // getObjectETag is a helper function, which returns only the md5sum
 // of the file on the disk.
 func (fs *FSObjects) getObjectETag(ctx context.Context, bucket, entry string, lock bool) (string, error) {
+	if e, ok := fs.pack.Get(bucket, entry); ok {
+		return extractETag(e.Meta), nil
+	}
+	if fsMeta, err := fsReadInlineMeta(fs.bucketPath(bucket, entry)); err == nil {
+		return extractETag(fsMeta.Meta), nil
+	}
+
 	fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket, entry, fs.metaJSONFile)
 
 	var reader io.Reader
@@ -1231,3 +1654,16 @@ func (fs *FSObjects) IsEncryptionSupported() bool {
 func (fs *FSObjects) IsCompressionSupported() bool {
 	return true
 }
+
+// IsReady returns whether the backend disk is reachable and
+// the lock on `format.json` is still held.
+func (fs *FSObjects) IsReady(ctx context.Context) bool {
+	if fs.fsFormatRlk == nil || fs.fsFormatRlk.IsClosed() {
+		return false
+	}
+	if _, err := os.Stat(fs.fsPath); err != nil {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	return true
+}
@@ -22,7 +22,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/pkg/madmin"
 )
 
@@ -302,6 +304,97 @@ func TestFSDeleteObject(t *testing.T) {
 
 }
 
+// TestFSImmutableObjectRead - objects are read without the namespace
+// lock when WORM is enabled.
+func TestFSImmutableObjectRead(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	bucketName := "bucket"
+	objectName := "object"
+
+	obj.MakeBucketWithLocation(context.Background(), bucketName, "")
+	obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader([]byte("abcd")), int64(len("abcd")), "", ""), ObjectOptions{})
+
+	globalWORMEnabled = true
+	defer func() { globalWORMEnabled = false }()
+
+	// Readers must not wait for the write lock to be released.
+	objectLock := fs.nsMutex.NewNSLock(context.Background(), bucketName, objectName)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		t.Fatal(err)
+	}
+	defer objectLock.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		if _, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{}); err != nil {
+			done <- err
+			return
+		}
+		gr, err := obj.GetObjectNInfo(context.Background(), bucketName, objectName, nil, nil, readLock, ObjectOptions{})
+		if err != nil {
+			done <- err
+			return
+		}
+		gr.Close()
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal("Unexpected error: ", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Reading an immutable object waited for the namespace lock")
+	}
+}
+
+// TestFSPutObjectTmpDir - tests staging uploads in a separate temporary directory.
+func TestFSPutObjectTmpDir(t *testing.T) {
+	disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(disk)
+	tmpDir := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+	defer os.RemoveAll(tmpDir)
+
+	globalFSTmpDir = tmpDir
+	defer func() { globalFSTmpDir = "" }()
+
+	obj := initFSObjects(disk, t)
+	fs := obj.(*FSObjects)
+	if fs.fsTmpDir != pathJoin(tmpDir, fs.fsUUID) {
+		t.Fatalf("Expected temporary directory %s, got %s", pathJoin(tmpDir, fs.fsUUID), fs.fsTmpDir)
+	}
+
+	bucketName := "bucket"
+	objectName := "object"
+	if err := obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("abcd")
+	if _, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	var buf bytes.Buffer
+	if err := obj.GetObject(context.Background(), bucketName, objectName, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatalf("Expected %q, got %q", data, buf.Bytes())
+	}
+
+	if err := obj.Shutdown(context.Background()); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+	if _, err := os.Stat(fs.fsTmpDir); !os.IsNotExist(err) {
+		t.Fatal("Expected the temporary directory to be removed on shutdown")
+	}
+}
+
 // TestFSDeleteBucket - tests for fs DeleteBucket
 func TestFSDeleteBucket(t *testing.T) {
 	// Prepare for testing
@@ -318,16 +411,16 @@ func TestFSDeleteBucket(t *testing.T) {
 	}
 
 	// Test with an invalid bucket name
-	if err = fs.DeleteBucket(context.Background(), "fo"); !isSameType(err, BucketNotFound{}) {
+	if err = fs.DeleteBucket(context.Background(), "fo", false); !isSameType(err, BucketNotFound{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 
 	// Test with an inexistant bucket
-	if err = fs.DeleteBucket(context.Background(), "foobucket"); !isSameType(err, BucketNotFound{}) {
+	if err = fs.DeleteBucket(context.Background(), "foobucket", false); !isSameType(err, BucketNotFound{}) {
 		t.Fatal("Unexpected error: ", err)
 	}
 	// Test with a valid case
-	if err = fs.DeleteBucket(context.Background(), bucketName); err != nil {
+	if err = fs.DeleteBucket(context.Background(), bucketName, false); err != nil {
 		t.Fatal("Unexpected error: ", err)
 	}
 
@@ -335,7 +428,7 @@ func TestFSDeleteBucket(t *testing.T) {
 
 	// Delete bucket should get error disk not found.
 	os.RemoveAll(disk)
-	if err = fs.DeleteBucket(context.Background(), bucketName); err != nil {
+	if err = fs.DeleteBucket(context.Background(), bucketName, false); err != nil {
 		if !isSameType(err, BucketNotFound{}) {
 			t.Fatal("Unexpected error: ", err)
 		}
@@ -409,3 +502,60 @@ func TestFSHealObjects(t *testing.T) {
 		t.Fatalf("Heal Object should return NotImplemented error ")
 	}
 }
+
+// TestFSStorageInfoObjectsUsage - tests the objects usage of the disk usage crawl.
+func TestFSStorageInfoObjectsUsage(t *testing.T) {
+	obj, disk, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(disk)
+
+	fs := obj.(*FSObjects)
+	ctx := context.Background()
+	for _, bucket := range []string{"bucket", "emptybucket"} {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for object, size := range map[string]int{"small": 10, "dir/large": 2 * humanize.MiByte} {
+		data := bytes.Repeat([]byte("a"), size)
+		_, err = obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	objectsUsage := newFSObjectsUsage()
+	usageFn := func(ctx context.Context, entry string) error {
+		fi, err := os.Stat(entry)
+		if err != nil {
+			return err
+		}
+		objectsUsage.add(fs.fsPath, entry, fi)
+		return nil
+	}
+	if err = getDiskUsage(ctx, fs.fsPath, usageFn); err != nil {
+		t.Fatal(err)
+	}
+	fs.setObjectsUsage(objectsUsage)
+
+	storageInfo := fs.StorageInfo(ctx)
+	if storageInfo.Backend.ObjectsCount != 2 {
+		t.Fatalf("expected 2 objects, got %d", storageInfo.Backend.ObjectsCount)
+	}
+	if storageInfo.Backend.ObjectsSizesHistogram["LESS_THAN_1024_B"] != 1 ||
+		storageInfo.Backend.ObjectsSizesHistogram["BETWEEN_1_MB_AND_10_MB"] != 1 {
+		t.Fatalf("unexpected objects sizes histogram %v", storageInfo.Backend.ObjectsSizesHistogram)
+	}
+	bucketUsage, ok := storageInfo.Backend.BucketsUsage["bucket"]
+	if !ok || bucketUsage.ObjectsCount != 2 || bucketUsage.ObjectsTotalSize != 10+2*humanize.MiByte {
+		t.Fatalf("unexpected usage of bucket %+v", bucketUsage)
+	}
+	if bucketUsage, ok = storageInfo.Backend.BucketsUsage["emptybucket"]; !ok || bucketUsage.ObjectsCount != 0 {
+		t.Fatalf("unexpected usage of emptybucket %+v", bucketUsage)
+	}
+	if _, ok = storageInfo.Backend.BucketsUsage[minioMetaBucket]; ok {
+		t.Fatalf("expected %s not to be counted", minioMetaBucket)
+	}
+}
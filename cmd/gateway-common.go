@@ -20,6 +20,7 @@ import (
 	"net/http"
 	"strings"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/cmd/config"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -53,6 +54,36 @@ var (
 	IsStringEqual = isStringEqual
 )
 
+const (
+	// GatewayMaxSinglePutSize - largest object a gateway forwards to its
+	// backend as a single PUT, larger objects are transparently uploaded
+	// as backend multipart uploads.
+	GatewayMaxSinglePutSize = globalMaxPartSize
+
+	// gatewayMinPartSize - minimum part size used while splitting a
+	// large PutObject into multipart uploads.
+	gatewayMinPartSize = 64 * humanize.MiByte
+)
+
+// GatewayPartSize - returns the part size to be used while splitting an
+// object of given size into a multipart upload, such that the object fits
+// within the maximum number of parts allowed.
+func GatewayPartSize(objectSize int64) int64 {
+	partSize := int64(gatewayMinPartSize)
+	if objectSize <= partSize*globalMaxPartID {
+		return partSize
+	}
+	partSize = objectSize / globalMaxPartID
+	if objectSize%globalMaxPartID != 0 {
+		partSize++
+	}
+	// Round up to the nearest MiB.
+	if rem := partSize % humanize.MiByte; rem != 0 {
+		partSize += humanize.MiByte - rem
+	}
+	return partSize
+}
+
 // StatInfo -  alias for statInfo
 type StatInfo struct {
 	statInfo
@@ -171,6 +202,12 @@ func FromMinioClientObjectInfo(bucket string, oi minio.ObjectInfo) ObjectInfo {
 	userDefined := FromMinioClientMetadata(oi.Metadata)
 	userDefined[xhttp.ContentType] = oi.ContentType
 
+	// HEAD reports the storage class as a header only.
+	storageClass := oi.StorageClass
+	if storageClass == "" {
+		storageClass = oi.Metadata.Get(xhttp.AmzStorageClass)
+	}
+
 	return ObjectInfo{
 		Bucket:          bucket,
 		Name:            oi.Key,
@@ -180,7 +217,7 @@ func FromMinioClientObjectInfo(bucket string, oi minio.ObjectInfo) ObjectInfo {
 		UserDefined:     userDefined,
 		ContentType:     oi.ContentType,
 		ContentEncoding: oi.Metadata.Get(xhttp.ContentEncoding),
-		StorageClass:    oi.StorageClass,
+		StorageClass:    storageClass,
 		Expires:         oi.Expires,
 	}
 }
@@ -18,6 +18,8 @@ package cmd
 import (
 	"reflect"
 	"testing"
+
+	humanize "github.com/dustin/go-humanize"
 )
 
 // Tests cache exclude parsing.
@@ -50,3 +52,27 @@ func TestParseGatewaySSE(t *testing.T) {
 		}
 	}
 }
+
+// Tests part size computation for gateway multipart PutObject.
+func TestGatewayPartSize(t *testing.T) {
+	testCases := []struct {
+		objectSize int64
+		partSize   int64
+	}{
+		{1, 64 * humanize.MiByte},
+		{GatewayMaxSinglePutSize + 1, 64 * humanize.MiByte},
+		{64 * humanize.MiByte * globalMaxPartID, 64 * humanize.MiByte},
+		{64*humanize.MiByte*globalMaxPartID + 1, 65 * humanize.MiByte},
+		{globalMaxObjectSize, 525 * humanize.MiByte},
+	}
+
+	for i, testCase := range testCases {
+		partSize := GatewayPartSize(testCase.objectSize)
+		if partSize != testCase.partSize {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.partSize, partSize)
+		}
+		if (testCase.objectSize+partSize-1)/partSize > globalMaxPartID {
+			t.Errorf("Test %d: object of size %d doesn't fit in %d parts", i+1, testCase.objectSize, globalMaxPartID)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+)
+
+// GatewayEvent - an object change reported by the change feed of a
+// gateway backend, like GCS Pub/Sub notifications.
+type GatewayEvent struct {
+	Name   event.Name
+	Bucket string
+	Object ObjectInfo
+	// Host is the backend which reported the change.
+	Host string
+}
+
+// PublishGatewayEvent - sends an event reported by the backend of a
+// gateway to the notification targets. Gateways have no bucket
+// notification configuration, every configured target receives the
+// events of all buckets.
+func PublishGatewayEvent(ev GatewayEvent) {
+	// globalNotificationSys is not initialized before the gateway layer.
+	if globalNotificationSys == nil {
+		return
+	}
+
+	args := eventArgs{
+		EventName:  ev.Name,
+		BucketName: ev.Bucket,
+		Object:     ev.Object,
+		Host:       ev.Host,
+	}
+	for _, err := range globalNotificationSys.sendAll(args) {
+		reqInfo := &logger.ReqInfo{BucketName: args.BucketName, ObjectName: args.Object.Name}
+		reqInfo.AppendTags("EventName", args.EventName.String())
+		reqInfo.AppendTags("targetID", err.ID.Name)
+		ctx := logger.SetReqInfo(context.Background(), reqInfo)
+		logger.LogOnceIf(ctx, err.Err, err.ID)
+	}
+}
+
+// sendAll - sends event data to all configured targets, regardless
+// of the bucket rules.
+func (sys *NotificationSys) sendAll(args eventArgs) []event.TargetIDErr {
+	var targetIDs []event.TargetID
+	for _, targetID := range sys.targetList.List() {
+		// Skip the targets of ListenBucketNotification clients.
+		if !strings.HasPrefix(targetID.ID, "httpclient+") {
+			targetIDs = append(targetIDs, targetID)
+		}
+	}
+	if len(targetIDs) == 0 {
+		return nil
+	}
+	return sys.send(args.BucketName, args.ToEvent(), targetIDs...)
+}
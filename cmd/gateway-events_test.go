@@ -0,0 +1,86 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+type gatewayEventTarget struct {
+	id     event.TargetID
+	events chan event.Event
+}
+
+func (target *gatewayEventTarget) ID() event.TargetID {
+	return target.id
+}
+
+func (target *gatewayEventTarget) Save(eventData event.Event) error {
+	target.events <- eventData
+	return nil
+}
+
+func (target *gatewayEventTarget) Send(eventKey string) error {
+	return nil
+}
+
+func (target *gatewayEventTarget) Close() error {
+	return nil
+}
+
+func TestNotificationSysSendAll(t *testing.T) {
+	target := &gatewayEventTarget{id: event.TargetID{ID: "1", Name: "webhook"}, events: make(chan event.Event, 1)}
+	listener := &gatewayEventTarget{id: event.TargetID{ID: "httpclient+1", Name: "listener"}, events: make(chan event.Event, 1)}
+	targetList := event.NewTargetList()
+	for _, target := range []event.Target{target, listener} {
+		if err := targetList.Add(target); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sys := &NotificationSys{
+		targetList:                 targetList,
+		bucketRulesMap:             make(map[string]event.RulesMap),
+		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+	}
+
+	errs := sys.sendAll(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: "bucket",
+		Object:     ObjectInfo{Name: "dir/object", Size: 10, ETag: "etag"},
+		Host:       "storage.googleapis.com",
+	})
+	if len(errs) != 0 {
+		t.Fatalf("unexpected errors %v", errs)
+	}
+
+	select {
+	case ev := <-target.events:
+		if ev.EventName != event.ObjectCreatedPut || ev.S3.Bucket.Name != "bucket" ||
+			ev.S3.Object.Key != "dir%2Fobject" || ev.S3.Object.Size != 10 || ev.Source.Host != "storage.googleapis.com" {
+			t.Errorf("unexpected event %+v", ev)
+		}
+	default:
+		t.Error("expected event to be sent to the configured target")
+	}
+	select {
+	case ev := <-listener.events:
+		t.Errorf("unexpected event %+v sent to a ListenBucketNotification client", ev)
+	default:
+	}
+}
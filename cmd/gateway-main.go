@@ -203,6 +203,8 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	}()
 
 	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(globalReloadSignalCh, syscall.SIGHUP)
+	notifyProfileSignal()
 
 	// !!! Do not move this block !!!
 	// For all gateways, the config needs to be loaded from env
@@ -274,6 +276,15 @@ func StartGateway(ctx *cli.Context, gw Gateway) {
 	// Create new lifecycle system.
 	globalLifecycleSys = NewLifecycleSys()
 
+	// Create new website system.
+	globalWebsiteSys = NewWebsiteSys()
+
+	// Create new bucket defaults system.
+	globalBucketDefaultsSys = NewBucketDefaultsSys()
+
+	// Create new bucket encryption system.
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
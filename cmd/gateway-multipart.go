@@ -0,0 +1,494 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Path where the gateway multipart helper saves its metadata. Gateways
+	// which stored their uploads under multipart/v1 will not be migrated,
+	// stale v1 entries are removed by the cleanup routine.
+	gatewayMultipartPath = GatewayMinioSysTmp + "multipart/v2/"
+
+	// Metadata of a multipart upload.
+	gatewayMultipartUploadMeta = "upload.json"
+
+	// gatewayMultipartUploadMeta version number.
+	gatewayMultipartMetaVersion = "1"
+
+	// Maximum fraction of the cleanup interval added as jitter, so
+	// that gateways sharing a backend do not scan it at the same time.
+	gatewayCleanupJitter = 0.1
+)
+
+// errGatewayCleanupNotRunning - the stale multipart cleanup job is not running.
+var errGatewayCleanupNotRunning = errors.New("Stale multipart upload cleanup is not running")
+
+// gatewayMultipartCleaner - implemented by gateways which keep their
+// multipart uploads with GatewayMultipart, so that the stale upload
+// cleanup can be triggered through the admin API.
+type gatewayMultipartCleaner interface {
+	TriggerMultipartCleanup() error
+}
+
+// GatewayTempObjectInfo - name and modification time of an object saved
+// under GatewayMinioSysTmp.
+type GatewayTempObjectInfo struct {
+	Name    string
+	ModTime time.Time
+}
+
+// GatewayMultipartStore - backend operations needed by GatewayMultipart,
+// temporary objects are always small json documents or gateway specific
+// part data which is never read back through the store.
+type GatewayMultipartStore interface {
+	// ListBuckets lists the buckets scanned for stale uploads.
+	ListBuckets(ctx context.Context) ([]BucketInfo, error)
+
+	// PutTempObject saves data as the object name in bucket.
+	PutTempObject(ctx context.Context, bucket, name string, data []byte) error
+
+	// GetTempObject returns the content of the object name in bucket,
+	// ObjectNotFound is returned if the object does not exist.
+	GetTempObject(ctx context.Context, bucket, name string) ([]byte, error)
+
+	// DeleteTempObject deletes the object name in bucket.
+	DeleteTempObject(ctx context.Context, bucket, name string) error
+
+	// ListTempObjects recursively lists all objects in bucket whose
+	// name starts with prefix.
+	ListTempObjects(ctx context.Context, bucket, prefix string) ([]GatewayTempObjectInfo, error)
+}
+
+// GatewayMultipartUpload - contents of upload.json saved by NewMultipartUpload.
+type GatewayMultipartUpload struct {
+	Version   string            `json:"version"`
+	Bucket    string            `json:"bucket"`
+	Object    string            `json:"object"`
+	Metadata  map[string]string `json:"metadata,omitempty"`
+	Initiated time.Time         `json:"initiated"`
+}
+
+// GatewayPart - contents of the part metadata saved by PutObjectPart.
+// Blocks holds backend specific references to the part data, like
+// the block IDs staged for the part on Azure.
+type GatewayPart struct {
+	PartNumber   int       `json:"partNumber"`
+	ETag         string    `json:"etag"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"lastModified"`
+	Blocks       []string  `json:"blocks,omitempty"`
+}
+
+// GatewayMultipart - implements the bookkeeping of multipart uploads for
+// gateways whose backend has no native multipart API. Upload and part
+// metadata are saved as json objects under
+//
+//	minio.sys.tmp/multipart/v2/<upload-id>/
+//
+// of the bucket. Gateways may save their part data under the same
+// prefix with GatewayMultipartObjectName, it is removed along with the metadata.
+type GatewayMultipart struct {
+	store GatewayMultipartStore
+
+	// Stale upload cleanup job state.
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	trigger chan struct{}
+}
+
+// NewGatewayMultipart - returns a multipart helper saving its metadata
+// through store.
+func NewGatewayMultipart(store GatewayMultipartStore) *GatewayMultipart {
+	return &GatewayMultipart{
+		store:   store,
+		trigger: make(chan struct{}, 1),
+	}
+}
+
+// Returns the prefix of all objects saved for uploadID.
+func gatewayMultipartUploadPrefix(uploadID string) string {
+	return gatewayMultipartPath + uploadID + SlashSeparator
+}
+
+// Returns the name of the part metadata object.
+func gatewayMultipartPartName(uploadID string, partNumber int) string {
+	return fmt.Sprintf("%spart.%05d.json", gatewayMultipartUploadPrefix(uploadID), partNumber)
+}
+
+// Returns the part number of a part metadata object name, 0 if the
+// name does not refer to part metadata.
+func gatewayMultipartPartNumber(uploadID, name string) int {
+	name = strings.TrimPrefix(name, gatewayMultipartUploadPrefix(uploadID))
+	if !strings.HasPrefix(name, "part.") || !strings.HasSuffix(name, ".json") {
+		return 0
+	}
+	partNumber, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(name, "part."), ".json"))
+	if err != nil || partNumber <= 0 {
+		return 0
+	}
+	return partNumber
+}
+
+// GatewayMultipartObjectName - returns the name under which a gateway
+// can save its own data for uploadID, name must not end with ".json".
+func GatewayMultipartObjectName(uploadID, name string) string {
+	return gatewayMultipartUploadPrefix(uploadID) + name
+}
+
+// NewMultipartUpload - saves the metadata of a new upload.
+func (m *GatewayMultipart) NewMultipartUpload(ctx context.Context, bucket, object, uploadID string, metadata map[string]string) error {
+	data, err := json.Marshal(GatewayMultipartUpload{
+		Version:   gatewayMultipartMetaVersion,
+		Bucket:    bucket,
+		Object:    object,
+		Metadata:  metadata,
+		Initiated: UTCNow(),
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	return m.store.PutTempObject(ctx, bucket, gatewayMultipartUploadPrefix(uploadID)+gatewayMultipartUploadMeta, data)
+}
+
+// GetMultipartUpload - returns the metadata of an upload,
+// InvalidUploadID is returned if the upload does not exist for object.
+func (m *GatewayMultipart) GetMultipartUpload(ctx context.Context, bucket, object, uploadID string) (GatewayMultipartUpload, error) {
+	var upload GatewayMultipartUpload
+	data, err := m.store.GetTempObject(ctx, bucket, gatewayMultipartUploadPrefix(uploadID)+gatewayMultipartUploadMeta)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			err = InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+		}
+		return upload, err
+	}
+	if err = json.Unmarshal(data, &upload); err != nil {
+		logger.LogIf(ctx, err)
+		return upload, err
+	}
+	if upload.Version != gatewayMultipartMetaVersion {
+		err = fmt.Errorf("Unknown multipart metadata version %s", upload.Version)
+		logger.LogIf(ctx, err)
+		return upload, err
+	}
+	if upload.Bucket != bucket || upload.Object != object {
+		return upload, InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+	}
+	return upload, nil
+}
+
+// PutObjectPart - saves the metadata of an uploaded part, the part
+// data must already be saved by the gateway.
+func (m *GatewayMultipart) PutObjectPart(ctx context.Context, bucket, uploadID string, part GatewayPart) (PartInfo, error) {
+	data, err := json.Marshal(part)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return PartInfo{}, err
+	}
+	if err = m.store.PutTempObject(ctx, bucket, gatewayMultipartPartName(uploadID, part.PartNumber), data); err != nil {
+		return PartInfo{}, err
+	}
+	return PartInfo{
+		PartNumber:   part.PartNumber,
+		ETag:         part.ETag,
+		Size:         part.Size,
+		LastModified: part.LastModified,
+	}, nil
+}
+
+// Returns the metadata of a part.
+func (m *GatewayMultipart) getPart(ctx context.Context, bucket, uploadID string, partNumber int) (GatewayPart, error) {
+	var part GatewayPart
+	data, err := m.store.GetTempObject(ctx, bucket, gatewayMultipartPartName(uploadID, partNumber))
+	if err != nil {
+		return part, err
+	}
+	if err = json.Unmarshal(data, &part); err != nil {
+		logger.LogIf(ctx, err)
+	}
+	return part, err
+}
+
+// GetObjectParts - returns the metadata of the parts sent to
+// CompleteMultipartUpload, after checking their ETags and that all
+// parts but the last one are at least minPartSize bytes.
+func (m *GatewayMultipart) GetObjectParts(ctx context.Context, bucket, uploadID string, uploadedParts []CompletePart, minPartSize int64) ([]GatewayPart, error) {
+	parts := make([]GatewayPart, len(uploadedParts))
+	for i, uploadedPart := range uploadedParts {
+		part, err := m.getPart(ctx, bucket, uploadID, uploadedPart.PartNumber)
+		if err != nil {
+			if isErrObjectNotFound(err) {
+				err = InvalidPart{PartNumber: uploadedPart.PartNumber, GotETag: uploadedPart.ETag}
+			}
+			return nil, err
+		}
+		if canonicalizeETag(uploadedPart.ETag) != part.ETag {
+			return nil, InvalidPart{
+				PartNumber: uploadedPart.PartNumber,
+				ExpETag:    part.ETag,
+				GotETag:    uploadedPart.ETag,
+			}
+		}
+		if i < len(uploadedParts)-1 && part.Size < minPartSize {
+			return nil, PartTooSmall{
+				PartNumber: uploadedPart.PartNumber,
+				PartSize:   part.Size,
+				PartETag:   uploadedPart.ETag,
+			}
+		}
+		parts[i] = part
+	}
+	return parts, nil
+}
+
+// ListObjectParts - lists the parts of an upload in part number order.
+func (m *GatewayMultipart) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker, maxParts int) (ListPartsInfo, error) {
+	result := ListPartsInfo{
+		Bucket:           bucket,
+		Object:           object,
+		UploadID:         uploadID,
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+	}
+	if _, err := m.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
+		return result, err
+	}
+
+	objects, err := m.store.ListTempObjects(ctx, bucket, gatewayMultipartUploadPrefix(uploadID))
+	if err != nil {
+		return result, err
+	}
+	var partNumbers []int
+	for _, obj := range objects {
+		partNumber := gatewayMultipartPartNumber(uploadID, obj.Name)
+		if partNumber > partNumberMarker {
+			partNumbers = append(partNumbers, partNumber)
+		}
+	}
+	sort.Ints(partNumbers)
+	if len(partNumbers) > maxParts {
+		partNumbers = partNumbers[:maxParts]
+		result.IsTruncated = true
+	}
+
+	for _, partNumber := range partNumbers {
+		part, err := m.getPart(ctx, bucket, uploadID, partNumber)
+		if err != nil {
+			if isErrObjectNotFound(err) {
+				// Part was overwritten or the upload was aborted
+				// in the meantime.
+				continue
+			}
+			return result, err
+		}
+		result.Parts = append(result.Parts, PartInfo{
+			PartNumber:   part.PartNumber,
+			ETag:         part.ETag,
+			Size:         part.Size,
+			LastModified: part.LastModified,
+		})
+	}
+	if result.IsTruncated && len(result.Parts) > 0 {
+		result.NextPartNumberMarker = result.Parts[len(result.Parts)-1].PartNumber
+	}
+	return result, nil
+}
+
+// ListMultipartUploads - lists the uploads of the objects starting with
+// prefix, ordered by object name and initiation time.
+func (m *GatewayMultipart) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (ListMultipartsInfo, error) {
+	result := ListMultipartsInfo{
+		KeyMarker:      keyMarker,
+		UploadIDMarker: uploadIDMarker,
+		MaxUploads:     maxUploads,
+		Prefix:         prefix,
+		Delimiter:      delimiter,
+	}
+
+	objects, err := m.store.ListTempObjects(ctx, bucket, gatewayMultipartPath)
+	if err != nil {
+		return result, err
+	}
+	var uploads []MultipartInfo
+	for _, obj := range objects {
+		if !strings.HasSuffix(obj.Name, SlashSeparator+gatewayMultipartUploadMeta) {
+			continue
+		}
+		uploadID := strings.TrimSuffix(strings.TrimPrefix(obj.Name, gatewayMultipartPath), SlashSeparator+gatewayMultipartUploadMeta)
+		data, err := m.store.GetTempObject(ctx, bucket, obj.Name)
+		if err != nil {
+			if isErrObjectNotFound(err) {
+				continue
+			}
+			return result, err
+		}
+		var upload GatewayMultipartUpload
+		if err = json.Unmarshal(data, &upload); err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		if !strings.HasPrefix(upload.Object, prefix) {
+			continue
+		}
+		if upload.Object < keyMarker || (upload.Object == keyMarker && (uploadIDMarker == "" || uploadID <= uploadIDMarker)) {
+			continue
+		}
+		uploads = append(uploads, MultipartInfo{
+			Object:    upload.Object,
+			UploadID:  uploadID,
+			Initiated: upload.Initiated,
+		})
+	}
+	sort.Slice(uploads, func(i, j int) bool {
+		if uploads[i].Object != uploads[j].Object {
+			return uploads[i].Object < uploads[j].Object
+		}
+		return uploads[i].UploadID < uploads[j].UploadID
+	})
+	if len(uploads) > maxUploads {
+		uploads = uploads[:maxUploads]
+		result.IsTruncated = true
+		result.NextKeyMarker = uploads[len(uploads)-1].Object
+		result.NextUploadIDMarker = uploads[len(uploads)-1].UploadID
+	}
+	result.Uploads = uploads
+	return result, nil
+}
+
+// CleanupMultipartUpload - removes the metadata and all gateway data
+// saved for uploadID, called on abort and on completion of an upload.
+func (m *GatewayMultipart) CleanupMultipartUpload(ctx context.Context, bucket, uploadID string) error {
+	objects, err := m.store.ListTempObjects(ctx, bucket, gatewayMultipartUploadPrefix(uploadID))
+	if err != nil {
+		return err
+	}
+	for _, obj := range objects {
+		// Ignore the error as parallel AbortMultipartUpload might have deleted it.
+		m.store.DeleteTempObject(ctx, bucket, obj.Name)
+	}
+	return nil
+}
+
+// Returns the delay until the next stale upload cleanup.
+func gatewayCleanupDelay(interval time.Duration) time.Duration {
+	return interval + time.Duration(rand.Float64()*gatewayCleanupJitter*float64(interval))
+}
+
+// CleanupStaleMultipartUploads - removes everything saved under
+// GatewayMinioSysTmp which is older than expiry, every cleanupInterval
+// plus some jitter or when triggered with TriggerCleanup. It returns
+// once ctx is canceled, doneCh is closed or Shutdown is called.
+func (m *GatewayMultipart) CleanupStaleMultipartUploads(ctx context.Context, cleanupInterval, expiry time.Duration, doneCh chan struct{}) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	m.mu.Lock()
+	m.cancel = cancel
+	m.mu.Unlock()
+	defer func() {
+		m.mu.Lock()
+		m.cancel = nil
+		m.mu.Unlock()
+	}()
+
+	timer := time.NewTimer(gatewayCleanupDelay(cleanupInterval))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-doneCh:
+			return
+		case <-timer.C:
+			m.cleanupStaleMultipartUploads(ctx, expiry)
+			timer.Reset(gatewayCleanupDelay(cleanupInterval))
+		case <-m.trigger:
+			m.cleanupStaleMultipartUploads(ctx, expiry)
+		}
+	}
+}
+
+// TriggerCleanup - runs the stale upload cleanup without waiting for
+// the next interval, a cleanup already requested is not queued again.
+func (m *GatewayMultipart) TriggerCleanup() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel == nil {
+		return errGatewayCleanupNotRunning
+	}
+	select {
+	case m.trigger <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// Shutdown - stops the stale upload cleanup job.
+func (m *GatewayMultipart) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.cancel != nil {
+		m.cancel()
+	}
+}
+
+func (m *GatewayMultipart) cleanupStaleMultipartUploads(ctx context.Context, expiry time.Duration) {
+	buckets, err := m.store.ListBuckets(ctx)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	now := UTCNow()
+	for _, bucket := range buckets {
+		if ctx.Err() != nil {
+			return
+		}
+		objects, err := m.store.ListTempObjects(ctx, bucket.Name, GatewayMinioSysTmp)
+		if err != nil {
+			gatewayCleanupErrors.WithLabelValues(bucket.Name).Inc()
+			logger.LogIf(logger.SetReqInfo(ctx, &logger.ReqInfo{BucketName: bucket.Name}), err)
+			continue
+		}
+		for _, obj := range objects {
+			if now.Sub(obj.ModTime) <= expiry {
+				continue
+			}
+			if err = m.store.DeleteTempObject(ctx, bucket.Name, obj.Name); err != nil && !isErrObjectNotFound(err) {
+				gatewayCleanupErrors.WithLabelValues(bucket.Name).Inc()
+				logger.LogIf(logger.SetReqInfo(ctx, &logger.ReqInfo{BucketName: bucket.Name, ObjectName: obj.Name}), err)
+				continue
+			}
+			gatewayCleanupDeleted.WithLabelValues(bucket.Name).Inc()
+		}
+	}
+	gatewayCleanupLastRun.SetToCurrentTime()
+}
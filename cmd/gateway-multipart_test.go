@@ -0,0 +1,250 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// In memory GatewayMultipartStore.
+type gatewayTempObjects struct {
+	sync.Mutex
+	buckets map[string]map[string]GatewayTempObjectInfo
+	data    map[string][]byte
+}
+
+func newGatewayTempObjects(buckets ...string) *gatewayTempObjects {
+	s := &gatewayTempObjects{
+		buckets: make(map[string]map[string]GatewayTempObjectInfo),
+		data:    make(map[string][]byte),
+	}
+	for _, bucket := range buckets {
+		s.buckets[bucket] = make(map[string]GatewayTempObjectInfo)
+	}
+	return s
+}
+
+func (s *gatewayTempObjects) ListBuckets(ctx context.Context) (buckets []BucketInfo, err error) {
+	s.Lock()
+	defer s.Unlock()
+	for bucket := range s.buckets {
+		buckets = append(buckets, BucketInfo{Name: bucket})
+	}
+	return buckets, nil
+}
+
+func (s *gatewayTempObjects) PutTempObject(ctx context.Context, bucket, name string, data []byte) error {
+	s.Lock()
+	defer s.Unlock()
+	s.buckets[bucket][name] = GatewayTempObjectInfo{Name: name, ModTime: UTCNow()}
+	s.data[bucket+SlashSeparator+name] = data
+	return nil
+}
+
+func (s *gatewayTempObjects) GetTempObject(ctx context.Context, bucket, name string) ([]byte, error) {
+	s.Lock()
+	defer s.Unlock()
+	data, ok := s.data[bucket+SlashSeparator+name]
+	if !ok {
+		return nil, ObjectNotFound{Bucket: bucket, Object: name}
+	}
+	return data, nil
+}
+
+func (s *gatewayTempObjects) DeleteTempObject(ctx context.Context, bucket, name string) error {
+	s.Lock()
+	defer s.Unlock()
+	delete(s.buckets[bucket], name)
+	delete(s.data, bucket+SlashSeparator+name)
+	return nil
+}
+
+func (s *gatewayTempObjects) ListTempObjects(ctx context.Context, bucket, prefix string) (objects []GatewayTempObjectInfo, err error) {
+	s.Lock()
+	defer s.Unlock()
+	for name, obj := range s.buckets[bucket] {
+		if strings.HasPrefix(name, prefix) {
+			objects = append(objects, obj)
+		}
+	}
+	return objects, nil
+}
+
+func TestGatewayMultipart(t *testing.T) {
+	ctx := context.Background()
+	store := newGatewayTempObjects("bucket")
+	m := NewGatewayMultipart(store)
+
+	if err := m.NewMultipartUpload(ctx, "bucket", "dir/object", "upload1", map[string]string{"content-type": "text/plain"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := m.NewMultipartUpload(ctx, "bucket", "other", "upload2", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	upload, err := m.GetMultipartUpload(ctx, "bucket", "dir/object", "upload1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upload.Metadata["content-type"] != "text/plain" {
+		t.Errorf("expected metadata to be saved, got %v", upload.Metadata)
+	}
+	if _, err = m.GetMultipartUpload(ctx, "bucket", "other", "upload1"); err != (InvalidUploadID{Bucket: "bucket", Object: "other", UploadID: "upload1"}) {
+		t.Errorf("expected InvalidUploadID for a different object, got %v", err)
+	}
+	if _, err = m.GetMultipartUpload(ctx, "bucket", "dir/object", "unknown"); err != (InvalidUploadID{Bucket: "bucket", Object: "dir/object", UploadID: "unknown"}) {
+		t.Errorf("expected InvalidUploadID for an unknown upload, got %v", err)
+	}
+
+	// Data saved by the gateway is not a part.
+	store.PutTempObject(ctx, "bucket", GatewayMultipartObjectName("upload1", "00001.etag1.data"), []byte("data"))
+	for _, part := range []GatewayPart{
+		{PartNumber: 3, ETag: "etag3", Size: 1},
+		{PartNumber: 1, ETag: "etag1", Size: 10, Blocks: []string{"a", "b"}},
+		{PartNumber: 2, ETag: "etag2", Size: 10},
+	} {
+		if _, err = m.PutObjectPart(ctx, "bucket", "upload1", part); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lpi, err := m.ListObjectParts(ctx, "bucket", "dir/object", "upload1", 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lpi.Parts) != 2 || lpi.Parts[0].PartNumber != 1 || lpi.Parts[1].PartNumber != 2 || !lpi.IsTruncated || lpi.NextPartNumberMarker != 2 {
+		t.Errorf("unexpected first page of parts %+v", lpi)
+	}
+	lpi, err = m.ListObjectParts(ctx, "bucket", "dir/object", "upload1", lpi.NextPartNumberMarker, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lpi.Parts) != 1 || lpi.Parts[0].PartNumber != 3 || lpi.IsTruncated {
+		t.Errorf("unexpected second page of parts %+v", lpi)
+	}
+
+	testCases := []struct {
+		parts []CompletePart
+		err   error
+	}{
+		{[]CompletePart{{PartNumber: 1, ETag: `"etag1"`}, {PartNumber: 3, ETag: "etag3"}}, nil},
+		{[]CompletePart{{PartNumber: 1, ETag: "etag2"}}, InvalidPart{PartNumber: 1, ExpETag: "etag1", GotETag: "etag2"}},
+		{[]CompletePart{{PartNumber: 4, ETag: "etag4"}}, InvalidPart{PartNumber: 4, GotETag: "etag4"}},
+		{[]CompletePart{{PartNumber: 3, ETag: "etag3"}, {PartNumber: 1, ETag: "etag1"}}, PartTooSmall{PartNumber: 3, PartSize: 1, PartETag: "etag3"}},
+	}
+	for i, testCase := range testCases {
+		parts, err := m.GetObjectParts(ctx, "bucket", "upload1", testCase.parts, 5)
+		if err != testCase.err {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.err, err)
+		}
+		if err == nil && (len(parts) != 2 || len(parts[0].Blocks) != 2) {
+			t.Errorf("Test %d: unexpected parts %+v", i+1, parts)
+		}
+	}
+
+	lmi, err := m.ListMultipartUploads(ctx, "bucket", "", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lmi.Uploads) != 2 || lmi.Uploads[0].UploadID != "upload1" || lmi.Uploads[1].UploadID != "upload2" {
+		t.Errorf("unexpected uploads %+v", lmi.Uploads)
+	}
+	lmi, err = m.ListMultipartUploads(ctx, "bucket", "dir/", "", "", "", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(lmi.Uploads) != 1 || lmi.Uploads[0].Object != "dir/object" {
+		t.Errorf("unexpected uploads for prefix %+v", lmi.Uploads)
+	}
+
+	if err = m.CleanupMultipartUpload(ctx, "bucket", "upload1"); err != nil {
+		t.Fatal(err)
+	}
+	objects, _ := store.ListTempObjects(ctx, "bucket", gatewayMultipartUploadPrefix("upload1"))
+	if len(objects) != 0 {
+		t.Errorf("expected upload to be removed, found %v", objects)
+	}
+	if _, err = m.ListObjectParts(ctx, "bucket", "dir/object", "upload1", 0, 1000); err != (InvalidUploadID{Bucket: "bucket", Object: "dir/object", UploadID: "upload1"}) {
+		t.Errorf("expected InvalidUploadID after cleanup, got %v", err)
+	}
+}
+
+func TestGatewayMultipartCleanupStale(t *testing.T) {
+	ctx := context.Background()
+	store := newGatewayTempObjects("bucket")
+	m := NewGatewayMultipart(store)
+
+	if err := m.NewMultipartUpload(ctx, "bucket", "object", "upload1", nil); err != nil {
+		t.Fatal(err)
+	}
+	store.PutTempObject(ctx, "bucket", GatewayMinioSysTmp+"multipart/v1/old/gcs.json", nil)
+	store.buckets["bucket"][GatewayMinioSysTmp+"multipart/v1/old/gcs.json"] = GatewayTempObjectInfo{
+		Name:    GatewayMinioSysTmp + "multipart/v1/old/gcs.json",
+		ModTime: UTCNow().Add(-2 * time.Hour),
+	}
+
+	m.cleanupStaleMultipartUploads(ctx, time.Hour)
+
+	objects, _ := store.ListTempObjects(ctx, "bucket", GatewayMinioSysTmp)
+	if len(objects) != 1 || objects[0].Name != gatewayMultipartUploadPrefix("upload1")+gatewayMultipartUploadMeta {
+		t.Errorf("expected only the recent upload to remain, found %v", objects)
+	}
+}
+
+func TestGatewayMultipartCleanupJob(t *testing.T) {
+	store := newGatewayTempObjects("bucket")
+	m := NewGatewayMultipart(store)
+
+	if err := m.TriggerCleanup(); err != errGatewayCleanupNotRunning {
+		t.Fatalf("expected %v before the job is started, got %v", errGatewayCleanupNotRunning, err)
+	}
+
+	stale := GatewayMinioSysTmp + "multipart/v2/upload1/upload.json"
+	store.buckets["bucket"][stale] = GatewayTempObjectInfo{Name: stale, ModTime: UTCNow().Add(-2 * time.Hour)}
+
+	done := make(chan struct{})
+	go func() {
+		m.CleanupStaleMultipartUploads(context.Background(), time.Hour, time.Hour, nil)
+		close(done)
+	}()
+
+	// Wait for the job to start and run it on demand.
+	for m.TriggerCleanup() != nil {
+		time.Sleep(time.Millisecond)
+	}
+	for {
+		objects, _ := store.ListTempObjects(context.Background(), "bucket", GatewayMinioSysTmp)
+		if len(objects) == 0 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	m.Shutdown()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("cleanup job did not stop on Shutdown")
+	}
+	if err := m.TriggerCleanup(); err != errGatewayCleanupNotRunning {
+		t.Errorf("expected %v after Shutdown, got %v", errGatewayCleanupNotRunning, err)
+	}
+}
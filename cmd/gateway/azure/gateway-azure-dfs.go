@@ -0,0 +1,343 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azure
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/storage"
+	sha256 "github.com/minio/sha256-simd"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+const (
+	// API version of the Data Lake Storage Gen2 (DFS) endpoints.
+	azureDFSAPIVersion = "2018-11-09"
+
+	// First API version returning whether the hierarchical
+	// namespace is enabled on the storage account.
+	azureAccountInfoAPIVersion = "2019-07-07"
+
+	// API version of Put Block From URL, supported since 2018-03-28.
+	azurePutBlockFromURLAPIVersion = "2018-11-09"
+)
+
+// azureDFSClient - client of the Data Lake Storage Gen2 (DFS)
+// endpoints of a storage account with hierarchical namespace, also
+// used for the blob APIs which the sdk does not implement.
+type azureDFSClient struct {
+	accountName  string
+	accountKey   []byte
+	blobEndpoint string
+	dfsEndpoint  string
+	httpClient   *http.Client
+}
+
+// azureDFSPath - path entry returned by the DFS List Paths API.
+type azureDFSPath struct {
+	Name          string `json:"name"`
+	IsDirectory   string `json:"isDirectory"`
+	ContentLength string `json:"contentLength"`
+	LastModified  string `json:"lastModified"`
+	ETag          string `json:"etag"`
+}
+
+func (p azureDFSPath) isDirectory() bool {
+	return p.IsDirectory == "true"
+}
+
+func newAzureDFSClient(accountName, accountKey, endpoint string, secure bool, httpClient *http.Client) (*azureDFSClient, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return nil, err
+	}
+	scheme := "http"
+	if secure {
+		scheme = "https"
+	}
+	return &azureDFSClient{
+		accountName:  accountName,
+		accountKey:   key,
+		blobEndpoint: fmt.Sprintf("%s://%s.blob.%s", scheme, accountName, endpoint),
+		dfsEndpoint:  fmt.Sprintf("%s://%s.dfs.%s", scheme, accountName, endpoint),
+		httpClient:   httpClient,
+	}, nil
+}
+
+// sign - signs the request with the account key using the Shared
+// Key authorization scheme.
+// Ref - https://docs.microsoft.com/en-us/rest/api/storageservices/authorize-with-shared-key
+func (c *azureDFSClient) sign(req *http.Request) {
+	req.Header.Set("x-ms-date", time.Now().UTC().Format(http.TimeFormat))
+
+	contentLength := ""
+	if req.ContentLength > 0 {
+		contentLength = strconv.FormatInt(req.ContentLength, 10)
+	}
+
+	var msHeaders []string
+	for k := range req.Header {
+		if k = strings.ToLower(k); strings.HasPrefix(k, "x-ms-") {
+			msHeaders = append(msHeaders, k)
+		}
+	}
+	sort.Strings(msHeaders)
+
+	var b strings.Builder
+	b.WriteString(req.Method + "\n")
+	for _, h := range []string{"Content-Encoding", "Content-Language"} {
+		b.WriteString(req.Header.Get(h) + "\n")
+	}
+	b.WriteString(contentLength + "\n")
+	for _, h := range []string{"Content-MD5", "Content-Type", "Date", "If-Modified-Since",
+		"If-Match", "If-None-Match", "If-Unmodified-Since", "Range"} {
+		b.WriteString(req.Header.Get(h) + "\n")
+	}
+	for _, h := range msHeaders {
+		b.WriteString(h + ":" + strings.TrimSpace(req.Header.Get(h)) + "\n")
+	}
+
+	b.WriteString("/" + c.accountName + req.URL.EscapedPath())
+	query := req.URL.Query()
+	var params []string
+	for k := range query {
+		params = append(params, k)
+	}
+	sort.Strings(params)
+	for _, k := range params {
+		values := query[k]
+		sort.Strings(values)
+		b.WriteString("\n" + strings.ToLower(k) + ":" + strings.Join(values, ","))
+	}
+
+	mac := hmac.New(sha256.New, c.accountKey)
+	mac.Write([]byte(b.String()))
+	signature := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+	req.Header.Set("Authorization", "SharedKey "+c.accountName+":"+signature)
+}
+
+// do - sends a signed request, errors are returned as
+// storage.AzureStorageServiceError to be converted by
+// azureToObjectError.
+func (c *azureDFSClient) do(ctx context.Context, method, urlStr, version string) (*http.Response, error) {
+	return c.doWithHeader(ctx, method, urlStr, version, nil)
+}
+
+// doWithHeader - sends a signed request with the additional headers.
+func (c *azureDFSClient) doWithHeader(ctx context.Context, method, urlStr, version string, header http.Header) (*http.Response, error) {
+	req, err := http.NewRequest(method, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("x-ms-version", version)
+	c.sign(req)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		resp.Body.Close()
+		return nil, storage.AzureStorageServiceError{
+			Code:       resp.Header.Get("x-ms-error-code"),
+			StatusCode: resp.StatusCode,
+			RequestID:  resp.Header.Get("x-ms-request-id"),
+			APIVersion: version,
+		}
+	}
+	return resp, nil
+}
+
+// isHierarchicalNamespace - returns whether the hierarchical
+// namespace is enabled on the storage account.
+func (c *azureDFSClient) isHierarchicalNamespace(ctx context.Context) (bool, error) {
+	resp, err := c.do(ctx, http.MethodHead, c.blobEndpoint+"/?restype=account&comp=properties", azureAccountInfoAPIVersion)
+	if err != nil {
+		return false, err
+	}
+	resp.Body.Close()
+	return resp.Header.Get("x-ms-is-hns-enabled") == "true", nil
+}
+
+// listPaths - lists the paths in directory of the filesystem, returns
+// the continuation token of the next page if any.
+func (c *azureDFSClient) listPaths(ctx context.Context, filesystem, directory string, recursive bool, continuation string, maxResults int) ([]azureDFSPath, string, error) {
+	query := url.Values{}
+	query.Set("resource", "filesystem")
+	query.Set("recursive", strconv.FormatBool(recursive))
+	if directory != "" {
+		query.Set("directory", directory)
+	}
+	if continuation != "" {
+		query.Set("continuation", continuation)
+	}
+	if maxResults > 0 {
+		query.Set("maxResults", strconv.Itoa(maxResults))
+	}
+
+	resp, err := c.do(ctx, http.MethodGet, c.dfsEndpoint+"/"+url.PathEscape(filesystem)+"?"+query.Encode(), azureDFSAPIVersion)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var list struct {
+		Paths []azureDFSPath `json:"paths"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return nil, "", err
+	}
+	return list.Paths, resp.Header.Get("x-ms-continuation"), nil
+}
+
+// deleteEmptyDirectory - deletes directory of the filesystem, the
+// request fails if the directory is not empty.
+func (c *azureDFSClient) deleteEmptyDirectory(ctx context.Context, filesystem, directory string) error {
+	p := (&url.URL{Path: "/" + filesystem + "/" + directory}).EscapedPath()
+	resp, err := c.do(ctx, http.MethodDelete, c.dfsEndpoint+p+"?recursive=false", azureDFSAPIVersion)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// putBlockFromURL - stages a block of blob with length bytes of the
+// source URL starting at offset, the data is copied by the storage
+// service.
+// Ref - https://docs.microsoft.com/en-us/rest/api/storageservices/put-block-from-url
+func (c *azureDFSClient) putBlockFromURL(ctx context.Context, container, blob, blockID, sourceURL string, offset, length int64) error {
+	query := url.Values{}
+	query.Set("comp", "block")
+	query.Set("blockid", blockID)
+
+	header := make(http.Header)
+	header.Set("x-ms-copy-source", sourceURL)
+	header.Set("x-ms-source-range", fmt.Sprintf("bytes=%d-%d", offset, offset+length-1))
+
+	p := (&url.URL{Path: "/" + container + "/" + blob}).EscapedPath()
+	resp, err := c.doWithHeader(ctx, http.MethodPut, c.blobEndpoint+p+"?"+query.Encode(), azurePutBlockFromURLAPIVersion, header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// listObjectsDFS - lists the paths of a storage account with
+// hierarchical namespace, directories are returned as prefixes
+// when listing with the "/" delimiter and skipped otherwise.
+func (a *azureObjects) listObjectsDFS(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result minio.ListObjectsInfo, err error) {
+	recursive := delimiter == ""
+
+	// Only directories can be listed, list the parent
+	// directory of the prefix and filter its entries.
+	directory := ""
+	if i := strings.LastIndex(prefix, minio.SlashSeparator); i >= 0 {
+		directory = prefix[:i]
+	}
+
+	continuation := ""
+	if isAzureMarker(marker) {
+		continuation = strings.TrimPrefix(marker, azureMarkerPrefix)
+	}
+
+	for len(result.Objects) == 0 && len(result.Prefixes) == 0 {
+		paths, next, err := a.dfs.listPaths(ctx, bucket, directory, recursive, continuation, maxKeys)
+		if err != nil {
+			if azureErr, ok := err.(storage.AzureStorageServiceError); ok && azureErr.Code == "PathNotFound" {
+				return result, nil
+			}
+			return result, azureToObjectError(err, bucket)
+		}
+
+		for _, p := range paths {
+			name := p.Name
+			if p.isDirectory() {
+				name += minio.SlashSeparator
+			}
+			if !strings.HasPrefix(name, prefix) || strings.HasPrefix(name, minio.GatewayMinioSysTmp) {
+				continue
+			}
+			if !isAzureMarker(marker) && name <= marker {
+				// If the application used ListObjectsV1 style marker then we
+				// skip all the entries till we reach the marker.
+				continue
+			}
+			if p.isDirectory() {
+				if !recursive {
+					result.Prefixes = append(result.Prefixes, name)
+				}
+				continue
+			}
+			size, err := strconv.ParseInt(p.ContentLength, 10, 64)
+			if err != nil {
+				return result, err
+			}
+			modTime, err := time.Parse(time.RFC1123, p.LastModified)
+			if err != nil {
+				return result, err
+			}
+			result.Objects = append(result.Objects, minio.ObjectInfo{
+				Bucket:  bucket,
+				Name:    name,
+				ModTime: modTime,
+				Size:    size,
+				ETag:    minio.ToS3ETag(p.ETag),
+			})
+		}
+
+		continuation = next
+		if continuation == "" {
+			// Reached end of listing.
+			break
+		}
+	}
+
+	if continuation != "" {
+		result.NextMarker = azureMarkerPrefix + continuation
+		result.IsTruncated = true
+	}
+	return result, nil
+}
+
+// deleteEmptyParentsDFS - deletes the parent directories of object
+// left empty, as prefixes do not outlive their last object on S3.
+func (a *azureObjects) deleteEmptyParentsDFS(ctx context.Context, bucket, object string) {
+	for dir := path.Dir(object); dir != "." && dir != minio.SlashSeparator; dir = path.Dir(dir) {
+		if err := a.dfs.deleteEmptyDirectory(ctx, bucket, dir); err != nil {
+			// Directory is not empty, neither are its parents.
+			return
+		}
+	}
+}
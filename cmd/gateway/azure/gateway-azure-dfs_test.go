@@ -0,0 +1,157 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azure
+
+import (
+	"context"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAzureDFSListObjects(t *testing.T) {
+	listings := map[string]string{
+		"":    `{"paths":[{"name":"dir","isDirectory":"true","lastModified":"Mon, 02 Dec 2019 10:00:00 GMT","etag":"0x1"},{"name":"empty","isDirectory":"true","lastModified":"Mon, 02 Dec 2019 10:00:00 GMT","etag":"0x2"},{"name":"object","contentLength":"5","lastModified":"Mon, 02 Dec 2019 10:00:00 GMT","etag":"0x3"}]}`,
+		"dir": `{"paths":[{"name":"dir/object","contentLength":"10","lastModified":"Mon, 02 Dec 2019 10:00:00 GMT","etag":"0x4"}]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		switch {
+		case r.Method == http.MethodHead && r.URL.Query().Get("comp") == "properties":
+			w.Header().Set("x-ms-is-hns-enabled", "true")
+		case r.Method == http.MethodGet && r.URL.Path == "/bucket":
+			listing, ok := listings[r.URL.Query().Get("directory")]
+			if !ok {
+				w.Header().Set("x-ms-error-code", "PathNotFound")
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(listing))
+		default:
+			w.Header().Set("x-ms-error-code", "FilesystemNotFound")
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	a := &azureObjects{
+		dfs: &azureDFSClient{
+			accountName:  "account",
+			accountKey:   []byte("key"),
+			blobEndpoint: server.URL,
+			dfsEndpoint:  server.URL,
+			httpClient:   server.Client(),
+		},
+	}
+	hns, err := a.dfs.isHierarchicalNamespace(context.Background())
+	if err != nil || !hns {
+		t.Fatalf("expected hierarchical namespace to be detected, got %v %v", hns, err)
+	}
+
+	testCases := []struct {
+		bucket, prefix, delimiter string
+		expectedObjects           []string
+		expectedPrefixes          []string
+		expectErr                 bool
+	}{
+		{"bucket", "", "/", []string{"object"}, []string{"dir/", "empty/"}, false},
+		{"bucket", "e", "/", nil, []string{"empty/"}, false},
+		{"bucket", "dir/", "/", []string{"dir/object"}, nil, false},
+		{"bucket", "", "", []string{"object"}, nil, false},
+		{"bucket", "missing/", "/", nil, nil, false},
+		{"missing", "", "/", nil, nil, true},
+	}
+	for i, tc := range testCases {
+		result, err := a.ListObjects(context.Background(), tc.bucket, tc.prefix, "", tc.delimiter, 1000)
+		if (err != nil) != tc.expectErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		var objects []string
+		for _, object := range result.Objects {
+			objects = append(objects, object.Name)
+		}
+		if !reflect.DeepEqual(objects, tc.expectedObjects) {
+			t.Errorf("Test %d: expected objects %v, got %v", i+1, tc.expectedObjects, objects)
+		}
+		if !reflect.DeepEqual(result.Prefixes, tc.expectedPrefixes) {
+			t.Errorf("Test %d: expected prefixes %v, got %v", i+1, tc.expectedPrefixes, result.Prefixes)
+		}
+	}
+}
+
+func TestAzureDFSSign(t *testing.T) {
+	c := &azureDFSClient{accountName: "account", accountKey: []byte("key")}
+	req, err := http.NewRequest(http.MethodGet, "https://account.dfs.core.windows.net/bucket?resource=filesystem&recursive=false", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("x-ms-version", azureDFSAPIVersion)
+	c.sign(req)
+
+	auth := req.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "SharedKey account:") {
+		t.Fatalf("unexpected authorization header %s", auth)
+	}
+	if _, err = base64.StdEncoding.DecodeString(strings.TrimPrefix(auth, "SharedKey account:")); err != nil {
+		t.Fatalf("unexpected signature %s: %v", auth, err)
+	}
+	if req.Header.Get("x-ms-date") == "" {
+		t.Fatal("expected x-ms-date to be set")
+	}
+}
+
+func TestAzurePutBlockFromURL(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		if r.Method != http.MethodPut || r.URL.Path != "/bucket/object" ||
+			r.URL.Query().Get("comp") != "block" || r.URL.Query().Get("blockid") != "block1" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("x-ms-copy-source") != "https://account.blob.core.windows.net/src/object?sig=abc" ||
+			r.Header.Get("x-ms-source-range") != "bytes=10-19" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	c := &azureDFSClient{
+		accountName:  "account",
+		accountKey:   []byte("key"),
+		blobEndpoint: server.URL,
+		httpClient:   server.Client(),
+	}
+	err := c.putBlockFromURL(context.Background(), "bucket", "object", "block1",
+		"https://account.blob.core.windows.net/src/object?sig=abc", 10, 10)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if err = c.putBlockFromURL(context.Background(), "missing", "object", "block1", "", 0, 1); err == nil {
+		t.Fatal("expected an error")
+	}
+}
@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+// First API version supporting the rehydrate priority of Set Blob Tier.
+const azureBlobTierAPIVersion = "2019-02-02"
+
+// Access tiers of block blobs.
+const (
+	azureTierHot     = "Hot"
+	azureTierCool    = "Cool"
+	azureTierArchive = "Archive"
+)
+
+// Rehydrate priorities of archived blobs.
+const (
+	azureRehydrateStandard = "Standard"
+	azureRehydrateHigh     = "High"
+)
+
+// azureBlobTier - access tier of a blob, archiveStatus is set while
+// an archived blob is rehydrated.
+type azureBlobTier struct {
+	tier          string
+	archiveStatus string
+}
+
+// isRehydrating - returns whether the blob is being rehydrated from
+// the archive tier.
+func (t azureBlobTier) isRehydrating() bool {
+	return strings.HasPrefix(t.archiveStatus, "rehydrate-pending-")
+}
+
+// s3StorageClassToAzureTier - returns the access tier of the S3
+// storage class, infrequent access classes map to the cool tier and
+// archive classes to the archive tier.
+func s3StorageClassToAzureTier(sc string) string {
+	switch sc {
+	case storageclass.STANDARDIA, storageclass.ONEZONEIA, storageclass.INTELLIGENTTIERING:
+		return azureTierCool
+	case storageclass.GLACIER, storageclass.DEEPARCHIVE:
+		return azureTierArchive
+	}
+	return azureTierHot
+}
+
+// azureTierToS3StorageClass - returns the S3 storage class of the
+// access tier, the reverse of s3StorageClassToAzureTier.
+func azureTierToS3StorageClass(tier string) string {
+	switch tier {
+	case azureTierCool:
+		return storageclass.STANDARDIA
+	case azureTierArchive:
+		return storageclass.GLACIER
+	}
+	return storageclass.STANDARD
+}
+
+// restoreTierToAzureRehydratePriority - returns the rehydrate priority
+// of the retrieval tier of a restore request.
+func restoreTierToAzureRehydratePriority(tier string) string {
+	if tier == minio.RestoreTierExpedited {
+		return azureRehydrateHigh
+	}
+	return azureRehydrateStandard
+}
+
+// getBlobTier - returns the access tier of the blob.
+// Ref - https://docs.microsoft.com/en-us/rest/api/storageservices/get-blob-properties
+func (c *azureDFSClient) getBlobTier(ctx context.Context, container, blob string) (azureBlobTier, error) {
+	p := (&url.URL{Path: "/" + container + "/" + blob}).EscapedPath()
+	resp, err := c.do(ctx, http.MethodHead, c.blobEndpoint+p, azureBlobTierAPIVersion)
+	if err != nil {
+		return azureBlobTier{}, err
+	}
+	resp.Body.Close()
+	return azureBlobTier{
+		tier:          resp.Header.Get("x-ms-access-tier"),
+		archiveStatus: resp.Header.Get("x-ms-archive-status"),
+	}, nil
+}
+
+// setBlobTier - sets the access tier of the blob, the rehydrate
+// priority is only used when moving a blob out of the archive tier.
+// Ref - https://docs.microsoft.com/en-us/rest/api/storageservices/set-blob-tier
+func (c *azureDFSClient) setBlobTier(ctx context.Context, container, blob, tier, priority string) error {
+	header := make(http.Header)
+	header.Set("x-ms-access-tier", tier)
+	if priority != "" {
+		header.Set("x-ms-rehydrate-priority", priority)
+	}
+
+	p := (&url.URL{Path: "/" + container + "/" + blob}).EscapedPath()
+	resp, err := c.doWithHeader(ctx, http.MethodPut, c.blobEndpoint+p+"?comp=tier", azureBlobTierAPIVersion, header)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// setObjectTier - moves the object to the access tier of the storage
+// class in metadata, objects uploaded without a storage class are
+// left in the default tier of the storage account.
+func (a *azureObjects) setObjectTier(ctx context.Context, bucket, object string, metadata map[string]string) error {
+	sc, ok := metadata[xhttp.AmzStorageClass]
+	if !ok {
+		return nil
+	}
+	if err := a.rest.setBlobTier(ctx, bucket, object, s3StorageClassToAzureTier(sc), ""); err != nil {
+		return azureToObjectError(err, bucket, object)
+	}
+	return nil
+}
+
+// RestoreObject - rehydrates an archived blob to the hot tier. Unlike
+// S3 where the restored copy expires, the blob stays in the hot tier
+// once rehydrated, the number of days of the request is ignored.
+func (a *azureObjects) RestoreObject(ctx context.Context, bucket, object string, req minio.RestoreRequest) (restored bool, err error) {
+	t, err := a.rest.getBlobTier(ctx, bucket, object)
+	if err != nil {
+		return false, azureToObjectError(err, bucket, object)
+	}
+	if t.isRehydrating() {
+		return false, minio.ObjectRestoreInProgress{Bucket: bucket, Object: object}
+	}
+	if t.tier != azureTierArchive {
+		return true, nil
+	}
+	err = a.rest.setBlobTier(ctx, bucket, object, azureTierHot, restoreTierToAzureRehydratePriority(req.Tier()))
+	if err != nil {
+		return false, azureToObjectError(err, bucket, object)
+	}
+	return false, nil
+}
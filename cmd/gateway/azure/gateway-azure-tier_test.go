@@ -0,0 +1,129 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestAzureStorageClassTier(t *testing.T) {
+	testCases := []struct {
+		storageClass string
+		tier         string
+		expectedSC   string
+	}{
+		{"", azureTierHot, "STANDARD"},
+		{"STANDARD", azureTierHot, "STANDARD"},
+		{"REDUCED_REDUNDANCY", azureTierHot, "STANDARD"},
+		{"STANDARD_IA", azureTierCool, "STANDARD_IA"},
+		{"ONEZONE_IA", azureTierCool, "STANDARD_IA"},
+		{"INTELLIGENT_TIERING", azureTierCool, "STANDARD_IA"},
+		{"GLACIER", azureTierArchive, "GLACIER"},
+		{"DEEP_ARCHIVE", azureTierArchive, "GLACIER"},
+	}
+	for i, tc := range testCases {
+		tier := s3StorageClassToAzureTier(tc.storageClass)
+		if tier != tc.tier {
+			t.Errorf("Test %d: expected tier %s, got %s", i+1, tc.tier, tier)
+		}
+		if sc := azureTierToS3StorageClass(tier); sc != tc.expectedSC {
+			t.Errorf("Test %d: expected storage class %s, got %s", i+1, tc.expectedSC, sc)
+		}
+	}
+}
+
+func TestAzureRestoreObject(t *testing.T) {
+	blobs := map[string]http.Header{
+		"/bucket/hot":         {"X-Ms-Access-Tier": {"Hot"}},
+		"/bucket/archived":    {"X-Ms-Access-Tier": {"Archive"}},
+		"/bucket/rehydrating": {"X-Ms-Access-Tier": {"Archive"}, "X-Ms-Archive-Status": {"rehydrate-pending-to-hot"}},
+	}
+	var rehydrated []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "SharedKey account:") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		header, ok := blobs[r.URL.Path]
+		if !ok {
+			w.Header().Set("x-ms-error-code", "BlobNotFound")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch {
+		case r.Method == http.MethodHead:
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+		case r.Method == http.MethodPut && r.URL.Query().Get("comp") == "tier":
+			if r.Header.Get("x-ms-access-tier") != azureTierHot {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			rehydrated = append(rehydrated, r.URL.Path+":"+r.Header.Get("x-ms-rehydrate-priority"))
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	a := &azureObjects{
+		rest: &azureDFSClient{
+			accountName:  "account",
+			accountKey:   []byte("key"),
+			blobEndpoint: server.URL,
+			httpClient:   server.Client(),
+		},
+	}
+
+	var expedited minio.RestoreRequest
+	expedited.GlacierJobParameters.Tier = minio.RestoreTierExpedited
+
+	testCases := []struct {
+		object           string
+		req              minio.RestoreRequest
+		expectedRestored bool
+		expectedErr      error
+	}{
+		{"hot", minio.RestoreRequest{}, true, nil},
+		{"archived", minio.RestoreRequest{}, false, nil},
+		{"archived", expedited, false, nil},
+		{"rehydrating", minio.RestoreRequest{}, false, minio.ObjectRestoreInProgress{Bucket: "bucket", Object: "rehydrating"}},
+		{"missing", minio.RestoreRequest{}, false, minio.ObjectNotFound{Bucket: "bucket", Object: "missing"}},
+	}
+	for i, tc := range testCases {
+		restored, err := a.RestoreObject(context.Background(), "bucket", tc.object, tc.req)
+		if err != tc.expectedErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, tc.expectedErr, err)
+		}
+		if restored != tc.expectedRestored {
+			t.Errorf("Test %d: expected restored %v, got %v", i+1, tc.expectedRestored, restored)
+		}
+	}
+
+	expected := []string{"/bucket/archived:Standard", "/bucket/archived:High"}
+	if strings.Join(rehydrated, ",") != strings.Join(expected, ",") {
+		t.Errorf("expected rehydrations %v, got %v", expected, rehydrated)
+	}
+}
@@ -22,12 +22,10 @@ import (
 	"crypto/rand"
 	"encoding/base64"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
-	"path"
-	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -38,24 +36,23 @@ import (
 	"github.com/minio/cli"
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
 	"github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/config/storageclass"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/policy/condition"
-	sha256 "github.com/minio/sha256-simd"
 
 	minio "github.com/minio/minio/cmd"
 )
 
 const (
-	globalAzureAPIVersion      = "2016-05-31"
-	azureBlockSize             = 100 * humanize.MiByte
-	azureS3MinPartSize         = 5 * humanize.MiByte
-	metadataObjectNameTemplate = minio.GatewayMinioSysTmp + "multipart/v1/%s.%x/azure.json"
-	azureBackend               = "azure"
-	azureMarkerPrefix          = "{minio}"
-	metadataPartNamePrefix     = minio.GatewayMinioSysTmp + "multipart/v1/%s.%x"
-	maxPartsCount              = 10000
+	globalAzureAPIVersion = "2016-05-31"
+	azureBlockSize        = 100 * humanize.MiByte
+	azureCopySASExpiry    = time.Hour
+	azureS3MinPartSize    = 5 * humanize.MiByte
+	azureBackend          = "azure"
+	azureMarkerPrefix     = "{minio}"
 )
 
 func init() {
@@ -184,9 +181,28 @@ func (g *Azure) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, erro
 	c.AddToUserAgent(fmt.Sprintf("APN/1.0 MinIO/1.0 MinIO/%s", minio.Version))
 	c.HTTPClient = &http.Client{Transport: minio.NewCustomHTTPTransport()}
 
-	return &azureObjects{
+	a := &azureObjects{
 		client: c.GetBlobService(),
-	}, nil
+	}
+	a.multipart = minio.NewGatewayMultipart(a)
+
+	// Start background process to cleanup old files in minio.sys.tmp
+	go a.multipart.CleanupStaleMultipartUploads(context.Background(), minio.GlobalMultipartCleanupInterval,
+		minio.GlobalMultipartExpiry, minio.GlobalServiceDoneCh)
+
+	// Use the DFS endpoints of ADLS Gen2 storage accounts
+	// for directory aware listing.
+	dfs, err := newAzureDFSClient(creds.AccessKey, creds.SecretKey, endpoint, secure, c.HTTPClient)
+	if err != nil {
+		return a, err
+	}
+	a.rest = dfs
+	hns, err := dfs.isHierarchicalNamespace(context.Background())
+	logger.LogIf(context.Background(), err)
+	if hns {
+		a.dfs = dfs
+	}
+	return a, nil
 }
 
 // Production - Azure gateway is production ready.
@@ -265,26 +281,6 @@ func s3MetaToAzureProperties(ctx context.Context, s3Metadata map[string]string)
 	return blobMeta, props, nil
 }
 
-const (
-	partMetaVersionV1 = "1"
-)
-
-// partMetadataV1 struct holds the part specific metadata for
-// multipart operations.
-type partMetadataV1 struct {
-	Version  string   `json:"version"`
-	Size     int64    `json:"Size"`
-	BlockIDs []string `json:"blockIDs"`
-	ETag     string   `json:"etag"`
-}
-
-// Returns the initialized part metadata struct
-func newPartMetaV1(uploadID string, partID int) (partMeta *partMetadataV1) {
-	p := &partMetadataV1{}
-	p.Version = partMetaVersionV1
-	return p
-}
-
 // azurePropertiesToS3Meta converts Azure metadata/properties to S3
 // metadata. It is the reverse of s3MetaToAzureProperties. Azure's
 // `.GetMetadata()` lower-cases all header keys, so this is taken into
@@ -343,7 +339,10 @@ func azurePropertiesToS3Meta(meta storage.BlobMetadata, props storage.BlobProper
 // azureObjects - Implements Object layer for Azure blob storage.
 type azureObjects struct {
 	minio.GatewayUnsupported
-	client storage.BlobStorageClient // Azure sdk client
+	client    storage.BlobStorageClient // Azure sdk client
+	dfs       *azureDFSClient           // Set for storage accounts with hierarchical namespace
+	rest      *azureDFSClient           // Signed client for the blob APIs missing from the sdk
+	multipart *minio.GatewayMultipart
 }
 
 // Convert azure errors to minio object layer errors.
@@ -377,6 +376,8 @@ func azureToObjectError(err error, params ...string) error {
 		err = minio.PartTooBig{}
 	case "InvalidMetadata":
 		err = minio.UnsupportedMetadata{}
+	case "BlobArchived":
+		err = minio.ObjectArchived{Bucket: bucket, Object: object}
 	default:
 		switch azureErr.StatusCode {
 		case http.StatusNotFound:
@@ -429,22 +430,18 @@ func checkAzureUploadID(ctx context.Context, uploadID string) (err error) {
 	return nil
 }
 
-// parses partID from part metadata file name
-func parseAzurePart(metaPartFileName, prefix string) (partID int, err error) {
-	partStr := strings.TrimPrefix(metaPartFileName, prefix+minio.SlashSeparator)
-	if partID, err = strconv.Atoi(partStr); err != nil || partID <= 0 {
-		err = fmt.Errorf("invalid part number in block id '%s'", string(partID))
-		return
-	}
-	return
-}
-
 // Shutdown - save any gateway metadata to disk
 // if necessary and reload upon next restart.
 func (a *azureObjects) Shutdown(ctx context.Context) error {
+	a.multipart.Shutdown()
 	return nil
 }
 
+// TriggerMultipartCleanup - removes stale multipart uploads in minio.sys.tmp
+func (a *azureObjects) TriggerMultipartCleanup() error {
+	return a.multipart.TriggerCleanup()
+}
+
 // StorageInfo - Not relevant to Azure backend.
 func (a *azureObjects) StorageInfo(ctx context.Context) (si minio.StorageInfo) {
 	return si
@@ -512,7 +509,7 @@ func (a *azureObjects) ListBuckets(ctx context.Context) (buckets []minio.BucketI
 }
 
 // DeleteBucket - delete a container on azure, uses Azure equivalent DeleteContainer.
-func (a *azureObjects) DeleteBucket(ctx context.Context, bucket string) error {
+func (a *azureObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	container := a.client.GetContainerReference(bucket)
 	err := container.Delete(nil)
 	return azureToObjectError(err, bucket)
@@ -533,6 +530,10 @@ func (a *azureObjects) DeleteBucket(ctx context.Context, bucket string) error {
 // - Application supplied markers are used as-is to list
 //   object keys that appear after it in the lexicographical order.
 func (a *azureObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result minio.ListObjectsInfo, err error) {
+	if a.dfs != nil && (delimiter == "" || delimiter == minio.SlashSeparator) {
+		return a.listObjectsDFS(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	}
+
 	var objects []minio.ObjectInfo
 	var prefixes []string
 
@@ -661,6 +662,11 @@ func (a *azureObjects) GetObjectNInfo(ctx context.Context, bucket, object string
 		return nil, err
 	}
 
+	// Archived blobs have to be rehydrated before they can be read.
+	if objInfo.StorageClass == storageclass.GLACIER {
+		return nil, minio.ObjectArchived{Bucket: bucket, Object: object}
+	}
+
 	var startOffset, length int64
 	startOffset, length, err = rs.GetOffsetLength(objInfo.Size)
 	if err != nil {
@@ -745,15 +751,28 @@ func (a *azureObjects) GetObjectInfo(ctx context.Context, bucket, object string,
 		delete(blob.Metadata, "md5sum")
 	}
 
+	// The sdk does not return the access tier of the blob.
+	tier, err := a.rest.getBlobTier(ctx, bucket, object)
+	if err != nil {
+		return objInfo, azureToObjectError(err, bucket, object)
+	}
+	userDefined := azurePropertiesToS3Meta(blob.Metadata, blob.Properties)
+	storageClass := azureTierToS3StorageClass(tier.tier)
+	if storageClass != storageclass.STANDARD {
+		userDefined[xhttp.AmzStorageClass] = storageClass
+	}
+
 	return minio.ObjectInfo{
 		Bucket:          bucket,
-		UserDefined:     azurePropertiesToS3Meta(blob.Metadata, blob.Properties),
+		UserDefined:     userDefined,
 		ETag:            etag,
 		ModTime:         time.Time(blob.Properties.LastModified),
 		Name:            object,
 		Size:            blob.Properties.ContentLength,
 		ContentType:     blob.Properties.ContentType,
 		ContentEncoding: blob.Properties.ContentEncoding,
+		StorageClass:    storageClass,
+		RestoreOngoing:  tier.isRehydrating(),
 	}, nil
 }
 
@@ -770,6 +789,9 @@ func (a *azureObjects) PutObject(ctx context.Context, bucket, object string, r *
 		if err = blob.CreateBlockBlobFromReader(data, nil); err != nil {
 			return objInfo, azureToObjectError(err, bucket, object)
 		}
+		if err = a.setObjectTier(ctx, bucket, object, opts.UserDefined); err != nil {
+			return objInfo, err
+		}
 		return a.GetObjectInfo(ctx, bucket, object, opts)
 	}
 
@@ -819,6 +841,11 @@ func (a *azureObjects) PutObject(ctx context.Context, bucket, object string, r *
 		return objInfo, azureToObjectError(err, bucket, object)
 	}
 
+	// Archived blobs cannot be modified, the tier is set last.
+	if err = a.setObjectTier(ctx, bucket, object, opts.UserDefined); err != nil {
+		return objInfo, err
+	}
+
 	return a.GetObjectInfo(ctx, bucket, object, opts)
 }
 
@@ -854,6 +881,9 @@ func (a *azureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, des
 	if err != nil {
 		return objInfo, azureToObjectError(err, srcBucket, srcObject)
 	}
+	if err = a.setObjectTier(ctx, destBucket, destObject, srcInfo.UserDefined); err != nil {
+		return objInfo, err
+	}
 	return a.GetObjectInfo(ctx, destBucket, destObject, dstOpts)
 }
 
@@ -865,6 +895,9 @@ func (a *azureObjects) DeleteObject(ctx context.Context, bucket, object string)
 	if err != nil {
 		return azureToObjectError(err, bucket, object)
 	}
+	if a.dfs != nil {
+		a.deleteEmptyParentsDFS(ctx, bucket, object)
+	}
 	return nil
 }
 
@@ -876,47 +909,66 @@ func (a *azureObjects) DeleteObjects(ctx context.Context, bucket string, objects
 	return errs, nil
 }
 
-// ListMultipartUploads - It's decided not to support List Multipart Uploads, hence returning empty result.
-func (a *azureObjects) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
-	// It's decided not to support List Multipart Uploads, hence returning empty result.
-	return result, nil
-}
-
-type azureMultipartMetadata struct {
-	Name     string            `json:"name"`
-	Metadata map[string]string `json:"metadata"`
-}
-
-func getAzureMetadataObjectName(objectName, uploadID string) string {
-	return fmt.Sprintf(metadataObjectNameTemplate, uploadID, sha256.Sum256([]byte(objectName)))
+// PutTempObject - saves multipart metadata in minio.sys.tmp
+func (a *azureObjects) PutTempObject(ctx context.Context, bucket, name string, data []byte) error {
+	blob := a.client.GetContainerReference(bucket).GetBlobReference(name)
+	err := blob.CreateBlockBlobFromReader(bytes.NewReader(data), nil)
+	return azureToObjectError(err, bucket, name)
 }
 
-// gets the name of part metadata file for multipart upload operations
-func getAzureMetadataPartName(objectName, uploadID string, partID int) string {
-	partMetaPrefix := getAzureMetadataPartPrefix(uploadID, objectName)
-	return path.Join(partMetaPrefix, fmt.Sprintf("%d", partID))
+// GetTempObject - reads multipart metadata from minio.sys.tmp
+func (a *azureObjects) GetTempObject(ctx context.Context, bucket, name string) ([]byte, error) {
+	blob := a.client.GetContainerReference(bucket).GetBlobReference(name)
+	rc, err := blob.Get(nil)
+	if err != nil {
+		return nil, azureToObjectError(err, bucket, name)
+	}
+	defer rc.Close()
+	data, err := ioutil.ReadAll(rc)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil, azureToObjectError(err, bucket, name)
+	}
+	return data, nil
 }
 
-// gets the prefix of part metadata file
-func getAzureMetadataPartPrefix(uploadID, objectName string) string {
-	return fmt.Sprintf(metadataPartNamePrefix, uploadID, sha256.Sum256([]byte(objectName)))
+// DeleteTempObject - deletes a blob in minio.sys.tmp
+func (a *azureObjects) DeleteTempObject(ctx context.Context, bucket, name string) error {
+	blob := a.client.GetContainerReference(bucket).GetBlobReference(name)
+	return azureToObjectError(blob.Delete(nil), bucket, name)
 }
 
-func (a *azureObjects) checkUploadIDExists(ctx context.Context, bucketName, objectName, uploadID string) (err error) {
-	blob := a.client.GetContainerReference(bucketName).GetBlobReference(
-		getAzureMetadataObjectName(objectName, uploadID))
-	err = blob.GetMetadata(nil)
-	err = azureToObjectError(err, bucketName, objectName)
-	oerr := minio.ObjectNotFound{
-		Bucket: bucketName,
-		Object: objectName,
-	}
-	if err == oerr {
-		err = minio.InvalidUploadID{
-			UploadID: uploadID,
+// ListTempObjects - lists all blobs in minio.sys.tmp starting with prefix
+func (a *azureObjects) ListTempObjects(ctx context.Context, bucket, prefix string) ([]minio.GatewayTempObjectInfo, error) {
+	var objects []minio.GatewayTempObjectInfo
+	container := a.client.GetContainerReference(bucket)
+	var marker string
+	for {
+		resp, err := container.ListBlobs(storage.ListBlobsParameters{
+			Prefix: prefix,
+			Marker: marker,
+		})
+		if err != nil {
+			return nil, azureToObjectError(err, bucket, prefix)
+		}
+		for _, blob := range resp.Blobs {
+			objects = append(objects, minio.GatewayTempObjectInfo{
+				Name:    blob.Name,
+				ModTime: time.Time(blob.Properties.LastModified),
+			})
 		}
+		if resp.NextMarker == "" {
+			break
+		}
+		marker = resp.NextMarker
 	}
-	return err
+	return objects, nil
+}
+
+// ListMultipartUploads - lists all multipart uploads of objects starting with prefix.
+func (a *azureObjects) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	result, err = a.multipart.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	return result, azureToObjectError(err, bucket)
 }
 
 // NewMultipartUpload - Use Azure equivalent CreateBlockBlob.
@@ -926,35 +978,25 @@ func (a *azureObjects) NewMultipartUpload(ctx context.Context, bucket, object st
 		logger.LogIf(ctx, err)
 		return "", err
 	}
-	metadataObject := getAzureMetadataObjectName(object, uploadID)
-
-	var jsonData []byte
-	if jsonData, err = json.Marshal(azureMultipartMetadata{Name: object, Metadata: opts.UserDefined}); err != nil {
-		logger.LogIf(ctx, err)
-		return "", err
-	}
 
-	blob := a.client.GetContainerReference(bucket).GetBlobReference(metadataObject)
-	err = blob.CreateBlockBlobFromReader(bytes.NewBuffer(jsonData), nil)
-	if err != nil {
-		return "", azureToObjectError(err, bucket, metadataObject)
+	if err = a.multipart.NewMultipartUpload(ctx, bucket, object, uploadID, opts.UserDefined); err != nil {
+		return "", azureToObjectError(err, bucket, object)
 	}
-
 	return uploadID, nil
 }
 
 // PutObjectPart - Use Azure equivalent PutBlockWithLength.
 func (a *azureObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, r *minio.PutObjReader, opts minio.ObjectOptions) (info minio.PartInfo, err error) {
 	data := r.Reader
-	if err = a.checkUploadIDExists(ctx, bucket, object, uploadID); err != nil {
+	if err = checkAzureUploadID(ctx, uploadID); err != nil {
 		return info, err
 	}
 
-	if err = checkAzureUploadID(ctx, uploadID); err != nil {
+	if _, err = a.multipart.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
 		return info, err
 	}
 
-	partMetaV1 := newPartMetaV1(uploadID, partID)
+	var blockIDs []string
 	subPartSize, subPartNumber := int64(azureBlockSize), 1
 	for remainingSize := data.Size(); remainingSize >= 0; remainingSize -= subPartSize {
 		// Allow to create zero sized part.
@@ -972,217 +1014,122 @@ func (a *azureObjects) PutObjectPart(ctx context.Context, bucket, object, upload
 		if err != nil {
 			return info, azureToObjectError(err, bucket, object)
 		}
-		partMetaV1.BlockIDs = append(partMetaV1.BlockIDs, id)
+		blockIDs = append(blockIDs, id)
 		subPartNumber++
 	}
 
-	partMetaV1.ETag = r.MD5CurrentHexString()
-	partMetaV1.Size = data.Size()
+	// maintain per part md5sum and block IDs in the part metadata
+	// until upload is finalized.
+	info, err = a.multipart.PutObjectPart(ctx, bucket, uploadID, minio.GatewayPart{
+		PartNumber:   partID,
+		ETag:         r.MD5CurrentHexString(),
+		Size:         data.Size(),
+		LastModified: minio.UTCNow(),
+		Blocks:       blockIDs,
+	})
+	return info, azureToObjectError(err, bucket, object)
+}
 
-	// maintain per part md5sum in a temporary part metadata file until upload
-	// is finalized.
-	metadataObject := getAzureMetadataPartName(object, uploadID, partID)
-	var jsonData []byte
-	if jsonData, err = json.Marshal(partMetaV1); err != nil {
-		logger.LogIf(ctx, err)
-		return info, err
+// CopyObjectPart - Use Azure equivalent Put Block From URL, the range
+// of the source blob is copied by Azure without going through the gateway.
+func (a *azureObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject, uploadID string, partID int,
+	startOffset, length int64, srcInfo minio.ObjectInfo, srcOpts, dstOpts minio.ObjectOptions) (info minio.PartInfo, err error) {
+	if srcOpts.CheckCopyPrecondFn != nil && srcOpts.CheckCopyPrecondFn(srcInfo, "") {
+		return info, minio.PreConditionFailed{}
 	}
-
-	blob := a.client.GetContainerReference(bucket).GetBlobReference(metadataObject)
-	err = blob.CreateBlockBlobFromReader(bytes.NewBuffer(jsonData), nil)
-	if err != nil {
-		return info, azureToObjectError(err, bucket, metadataObject)
+	if length == 0 {
+		// Put Block From URL cannot copy an empty range.
+		return a.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, srcInfo.PutObjReader, dstOpts)
 	}
 
-	info.PartNumber = partID
-	info.ETag = partMetaV1.ETag
-	info.LastModified = minio.UTCNow()
-	info.Size = data.Size()
-	return info, nil
-}
-
-// ListObjectParts - Use Azure equivalent GetBlockList.
-func (a *azureObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (result minio.ListPartsInfo, err error) {
-	if err = a.checkUploadIDExists(ctx, bucket, object, uploadID); err != nil {
-		return result, err
+	if err = checkAzureUploadID(ctx, uploadID); err != nil {
+		return info, err
 	}
 
-	result.Bucket = bucket
-	result.Object = object
-	result.UploadID = uploadID
-	result.MaxParts = maxParts
-
-	var parts []minio.PartInfo
-	var marker, delimiter string
-	maxKeys := maxPartsCount
-	if partNumberMarker == 0 {
-		maxKeys = maxParts
+	if _, err = a.multipart.GetMultipartUpload(ctx, destBucket, destObject, uploadID); err != nil {
+		return info, err
 	}
-	prefix := getAzureMetadataPartPrefix(uploadID, object)
-	container := a.client.GetContainerReference(bucket)
-	resp, err := container.ListBlobs(storage.ListBlobsParameters{
-		Prefix:     prefix,
-		Marker:     marker,
-		Delimiter:  delimiter,
-		MaxResults: uint(maxKeys),
+
+	// Azure fetches the source blob with a short lived read only SAS.
+	srcBlob := a.client.GetContainerReference(srcBucket).GetBlobReference(srcObject)
+	srcURL, err := srcBlob.GetSASURI(storage.BlobSASOptions{
+		BlobServiceSASPermissions: storage.BlobServiceSASPermissions{Read: true},
+		SASOptions:                storage.SASOptions{Expiry: time.Now().Add(azureCopySASExpiry)},
 	})
 	if err != nil {
-		return result, azureToObjectError(err, bucket, prefix)
+		return info, azureToObjectError(err, srcBucket, srcObject)
 	}
 
-	for _, blob := range resp.Blobs {
-		if delimiter == "" && !strings.HasPrefix(blob.Name, minio.GatewayMinioSysTmp) {
-			// We filter out non minio.GatewayMinioSysTmp entries in the recursive listing.
-			continue
-		}
-		// filter temporary metadata file for blob
-		if strings.HasSuffix(blob.Name, "azure.json") {
-			continue
+	var blockIDs []string
+	for offset := int64(0); offset < length; offset += azureBlockSize {
+		subPartSize := length - offset
+		if subPartSize > azureBlockSize {
+			subPartSize = azureBlockSize
 		}
-		if !isAzureMarker(marker) && blob.Name <= marker {
-			// If the application used ListObjectsV1 style marker then we
-			// skip all the entries till we reach the marker.
-			continue
-		}
-		partNumber, err := parseAzurePart(blob.Name, prefix)
+
+		id := base64.StdEncoding.EncodeToString([]byte(minio.MustGetUUID()))
+		err = a.rest.putBlockFromURL(ctx, destBucket, destObject, id, srcURL, startOffset+offset, subPartSize)
 		if err != nil {
-			return result, azureToObjectError(fmt.Errorf("Unexpected error"), bucket, object)
-		}
-		var metadata partMetadataV1
-		var metadataReader io.Reader
-		blob := a.client.GetContainerReference(bucket).GetBlobReference(blob.Name)
-		if metadataReader, err = blob.Get(nil); err != nil {
-			return result, azureToObjectError(fmt.Errorf("Unexpected error"), bucket, object)
-		}
-		if err = json.NewDecoder(metadataReader).Decode(&metadata); err != nil {
-			logger.LogIf(ctx, err)
-			return result, azureToObjectError(err, bucket, object)
+			return info, azureToObjectError(err, destBucket, destObject)
 		}
-		parts = append(parts, minio.PartInfo{
-			PartNumber: partNumber,
-			Size:       metadata.Size,
-			ETag:       metadata.ETag,
-		})
+		blockIDs = append(blockIDs, id)
 	}
-	sort.Slice(parts, func(i int, j int) bool {
-		return parts[i].PartNumber < parts[j].PartNumber
+
+	info, err = a.multipart.PutObjectPart(ctx, destBucket, uploadID, minio.GatewayPart{
+		PartNumber:   partID,
+		ETag:         minio.GenETag(),
+		Size:         length,
+		LastModified: minio.UTCNow(),
+		Blocks:       blockIDs,
 	})
-	partsCount := 0
-	i := 0
-	if partNumberMarker != 0 {
-		// If the marker was set, skip the entries till the marker.
-		for _, part := range parts {
-			i++
-			if part.PartNumber == partNumberMarker {
-				break
-			}
-		}
-	}
-	for partsCount < maxParts && i < len(parts) {
-		result.Parts = append(result.Parts, parts[i])
-		i++
-		partsCount++
-	}
+	return info, azureToObjectError(err, destBucket, destObject)
+}
 
-	if i < len(parts) {
-		result.IsTruncated = true
-		if partsCount != 0 {
-			result.NextPartNumberMarker = result.Parts[partsCount-1].PartNumber
-		}
-	}
-	result.PartNumberMarker = partNumberMarker
-	return result, nil
+// ListObjectParts - lists the parts saved in the part metadata.
+func (a *azureObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (result minio.ListPartsInfo, err error) {
+	result, err = a.multipart.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts)
+	return result, azureToObjectError(err, bucket, object)
 }
 
-// AbortMultipartUpload - Not Implemented.
+// AbortMultipartUpload - removes the part metadata.
 // There is no corresponding API in azure to abort an incomplete upload. The uncommmitted blocks
 // gets deleted after one week.
 func (a *azureObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
-	if err = a.checkUploadIDExists(ctx, bucket, object, uploadID); err != nil {
+	if _, err = a.multipart.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
 		return err
 	}
-	var partNumberMarker int
-	for {
-		lpi, err := a.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxPartsCount, minio.ObjectOptions{})
-		if err != nil {
-			break
-		}
-		for _, part := range lpi.Parts {
-			pblob := a.client.GetContainerReference(bucket).GetBlobReference(
-				getAzureMetadataPartName(object, uploadID, part.PartNumber))
-			pblob.Delete(nil)
-		}
-		partNumberMarker = lpi.NextPartNumberMarker
-		if !lpi.IsTruncated {
-			break
-		}
-	}
-
-	blob := a.client.GetContainerReference(bucket).GetBlobReference(
-		getAzureMetadataObjectName(object, uploadID))
-	return blob.Delete(nil)
+	return azureToObjectError(a.multipart.CleanupMultipartUpload(ctx, bucket, uploadID), bucket, object)
 }
 
 // CompleteMultipartUpload - Use Azure equivalent PutBlockList.
 func (a *azureObjects) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
-	metadataObject := getAzureMetadataObjectName(object, uploadID)
-	if err = a.checkUploadIDExists(ctx, bucket, object, uploadID); err != nil {
-		return objInfo, err
-	}
-
 	if err = checkAzureUploadID(ctx, uploadID); err != nil {
 		return objInfo, err
 	}
 
-	var metadataReader io.Reader
-	blob := a.client.GetContainerReference(bucket).GetBlobReference(metadataObject)
-	if metadataReader, err = blob.Get(nil); err != nil {
-		return objInfo, azureToObjectError(err, bucket, metadataObject)
+	upload, err := a.multipart.GetMultipartUpload(ctx, bucket, object, uploadID)
+	if err != nil {
+		return objInfo, err
 	}
 
-	var metadata azureMultipartMetadata
-	if err = json.NewDecoder(metadataReader).Decode(&metadata); err != nil {
-		logger.LogIf(ctx, err)
-		return objInfo, azureToObjectError(err, bucket, metadataObject)
+	parts, err := a.multipart.GetObjectParts(ctx, bucket, uploadID, uploadedParts, azureS3MinPartSize)
+	if err != nil {
+		return objInfo, azureToObjectError(err, bucket, object)
 	}
 
-	objBlob := a.client.GetContainerReference(bucket).GetBlobReference(object)
-
 	var allBlocks []storage.Block
-	for i, part := range uploadedParts {
-		var partMetadataReader io.Reader
-		var partMetadata partMetadataV1
-		partMetadataObject := getAzureMetadataPartName(object, uploadID, part.PartNumber)
-		pblob := a.client.GetContainerReference(bucket).GetBlobReference(partMetadataObject)
-		if partMetadataReader, err = pblob.Get(nil); err != nil {
-			return objInfo, azureToObjectError(err, bucket, partMetadataObject)
-		}
-
-		if err = json.NewDecoder(partMetadataReader).Decode(&partMetadata); err != nil {
-			logger.LogIf(ctx, err)
-			return objInfo, azureToObjectError(err, bucket, partMetadataObject)
-		}
-
-		if partMetadata.ETag != part.ETag {
-			return objInfo, minio.InvalidPart{}
-		}
-		for _, blockID := range partMetadata.BlockIDs {
+	for _, part := range parts {
+		for _, blockID := range part.Blocks {
 			allBlocks = append(allBlocks, storage.Block{ID: blockID, Status: storage.BlockStatusUncommitted})
 		}
-		if i < (len(uploadedParts)-1) && partMetadata.Size < azureS3MinPartSize {
-			return objInfo, minio.PartTooSmall{
-				PartNumber: uploadedParts[i].PartNumber,
-				PartSize:   partMetadata.Size,
-				PartETag:   uploadedParts[i].ETag,
-			}
-		}
 	}
 
+	objBlob := a.client.GetContainerReference(bucket).GetBlobReference(object)
 	err = objBlob.PutBlockList(allBlocks, nil)
 	if err != nil {
 		return objInfo, azureToObjectError(err, bucket, object)
 	}
-	objBlob.Metadata, objBlob.Properties, err = s3MetaToAzureProperties(ctx, metadata.Metadata)
+	objBlob.Metadata, objBlob.Properties, err = s3MetaToAzureProperties(ctx, upload.Metadata)
 	if err != nil {
 		return objInfo, azureToObjectError(err, bucket, object)
 	}
@@ -1195,25 +1142,11 @@ func (a *azureObjects) CompleteMultipartUpload(ctx context.Context, bucket, obje
 	if err != nil {
 		return objInfo, azureToObjectError(err, bucket, object)
 	}
-	var partNumberMarker int
-	for {
-		lpi, err := a.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxPartsCount, minio.ObjectOptions{})
-		if err != nil {
-			break
-		}
-		for _, part := range lpi.Parts {
-			pblob := a.client.GetContainerReference(bucket).GetBlobReference(
-				getAzureMetadataPartName(object, uploadID, part.PartNumber))
-			pblob.Delete(nil)
-		}
-		partNumberMarker = lpi.NextPartNumberMarker
-		if !lpi.IsTruncated {
-			break
-		}
+	if err = a.setObjectTier(ctx, bucket, object, upload.Metadata); err != nil {
+		return objInfo, err
 	}
 
-	blob = a.client.GetContainerReference(bucket).GetBlobReference(metadataObject)
-	derr := blob.Delete(nil)
+	derr := a.multipart.CleanupMultipartUpload(ctx, bucket, uploadID)
 	logger.GetReqInfo(ctx).AppendTags("uploadID", uploadID)
 	logger.LogIf(ctx, derr)
 
@@ -1310,3 +1243,11 @@ func (a *azureObjects) DeleteBucketPolicy(ctx context.Context, bucket string) er
 func (a *azureObjects) IsCompressionSupported() bool {
 	return false
 }
+
+// IsReady returns whether the storage account is reachable with the
+// configured credentials.
+func (a *azureObjects) IsReady(ctx context.Context) bool {
+	_, err := a.client.ListContainers(storage.ListContainersParameters{MaxResults: 1})
+	logger.LogIf(ctx, err)
+	return err == nil
+}
@@ -160,6 +160,15 @@ func TestAzureToObjectError(t *testing.T) {
 				Code: "InvalidMetadata",
 			}, minio.UnsupportedMetadata{}, "", "",
 		},
+		{
+			storage.AzureStorageServiceError{
+				Code:       "BlobArchived",
+				StatusCode: http.StatusConflict,
+			}, minio.ObjectArchived{
+				Bucket: "bucket",
+				Object: "object",
+			}, "bucket", "object",
+		},
 		{
 			storage.AzureStorageServiceError{
 				StatusCode: http.StatusNotFound,
@@ -321,7 +321,7 @@ func (l *b2Objects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 }
 
 // DeleteBucket deletes a bucket on B2
-func (l *b2Objects) DeleteBucket(ctx context.Context, bucket string) error {
+func (l *b2Objects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	bkt, err := l.Bucket(ctx, bucket)
 	if err != nil {
 		return err
@@ -873,3 +873,9 @@ func (l *b2Objects) DeleteBucketPolicy(ctx context.Context, bucket string) error
 func (l *b2Objects) IsCompressionSupported() bool {
 	return false
 }
+
+// IsReady returns whether B2 is reachable with the configured credentials.
+func (l *b2Objects) IsReady(ctx context.Context) bool {
+	_, err := l.listBuckets(ctx, nil)
+	return err == nil
+}
@@ -24,6 +24,7 @@ import (
 	_ "github.com/minio/minio/cmd/gateway/nas"
 	_ "github.com/minio/minio/cmd/gateway/oss"
 	_ "github.com/minio/minio/cmd/gateway/s3"
+	_ "github.com/minio/minio/cmd/gateway/sftp"
 
 	// B2 is specifically kept here to avoid re-ordering by goimports,
 	// please ask on github.com/minio/minio/issues before changing this.
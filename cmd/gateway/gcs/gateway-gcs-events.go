@@ -0,0 +1,151 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gcs
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/pubsub"
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+const (
+	// Event types of Cloud Storage Pub/Sub notifications.
+	// Refer https://cloud.google.com/storage/docs/pubsub-notifications
+	gcsEventObjectFinalize = "OBJECT_FINALIZE"
+	gcsEventObjectDelete   = "OBJECT_DELETE"
+
+	// Payload format carrying the object resource as JSON.
+	gcsPayloadJSONAPIV1 = "JSON_API_V1"
+
+	// Delay before receiving again from a failed subscription.
+	gcsEventsRetryInterval = 10 * time.Second
+
+	gcsEventsHost = "storage.googleapis.com"
+)
+
+// gcsEventObject - fields of the object resource sent as the payload
+// of JSON_API_V1 notifications.
+type gcsEventObject struct {
+	Bucket             string            `json:"bucket"`
+	Name               string            `json:"name"`
+	Size               string            `json:"size"`
+	MD5Hash            string            `json:"md5Hash"`
+	CRC32C             string            `json:"crc32c"`
+	ContentType        string            `json:"contentType"`
+	ContentEncoding    string            `json:"contentEncoding"`
+	ContentDisposition string            `json:"contentDisposition"`
+	ContentLanguage    string            `json:"contentLanguage"`
+	CacheControl       string            `json:"cacheControl"`
+	StorageClass       string            `json:"storageClass"`
+	KMSKeyName         string            `json:"kmsKeyName"`
+	Updated            time.Time         `json:"updated"`
+	Metadata           map[string]string `json:"metadata"`
+}
+
+// Converts the object resource of a notification to object attributes.
+func (o gcsEventObject) toAttrs() *storage.ObjectAttrs {
+	attrs := &storage.ObjectAttrs{
+		Bucket:             o.Bucket,
+		Name:               o.Name,
+		ContentType:        o.ContentType,
+		ContentEncoding:    o.ContentEncoding,
+		ContentDisposition: o.ContentDisposition,
+		ContentLanguage:    o.ContentLanguage,
+		CacheControl:       o.CacheControl,
+		StorageClass:       o.StorageClass,
+		KMSKeyName:         o.KMSKeyName,
+		Updated:            o.Updated,
+		Metadata:           o.Metadata,
+	}
+	attrs.Size, _ = strconv.ParseInt(o.Size, 10, 64)
+	attrs.MD5, _ = base64.StdEncoding.DecodeString(o.MD5Hash)
+	if crc, err := base64.StdEncoding.DecodeString(o.CRC32C); err == nil && len(crc) == 4 {
+		attrs.CRC32C = binary.BigEndian.Uint32(crc)
+	}
+	return attrs
+}
+
+// gcsMessageToEvent - converts a Cloud Storage Pub/Sub notification to
+// a gateway event, false is returned for notifications which have no
+// S3 equivalent or are about objects of the gateway itself.
+func gcsMessageToEvent(attributes map[string]string, data []byte) (minio.GatewayEvent, bool) {
+	ev := minio.GatewayEvent{
+		Bucket: attributes["bucketId"],
+		Host:   gcsEventsHost,
+	}
+	objectName := attributes["objectId"]
+	if ev.Bucket == "" || objectName == "" || strings.HasPrefix(objectName, minio.GatewayMinioSysTmp) {
+		return ev, false
+	}
+
+	switch attributes["eventType"] {
+	case gcsEventObjectFinalize:
+		ev.Name = event.ObjectCreatedPut
+	case gcsEventObjectDelete:
+		// Overwritten objects are reported by the OBJECT_FINALIZE
+		// notification of the new object.
+		if attributes["overwrittenByGeneration"] != "" {
+			return ev, false
+		}
+		ev.Name = event.ObjectRemovedDelete
+	default:
+		return ev, false
+	}
+
+	ev.Object = minio.ObjectInfo{Bucket: ev.Bucket, Name: objectName}
+	if attributes["payloadFormat"] == gcsPayloadJSONAPIV1 {
+		var object gcsEventObject
+		if err := json.Unmarshal(data, &object); err == nil {
+			ev.Object = fromGCSAttrsToObjectInfo(object.toAttrs())
+		}
+	}
+	return ev, true
+}
+
+// listenEvents - republishes the notifications received from the
+// subscription until ctx is canceled.
+func (l *gcsGateway) listenEvents(ctx context.Context, sub *pubsub.Subscription) {
+	for {
+		err := sub.Receive(ctx, func(ctx context.Context, msg *pubsub.Message) {
+			msg.Ack()
+			if ev, ok := gcsMessageToEvent(msg.Attributes, msg.Data); ok {
+				minio.PublishGatewayEvent(ev)
+			}
+		})
+		if ctx.Err() != nil {
+			return
+		}
+		logger.LogIf(ctx, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(gcsEventsRetryInterval):
+		}
+	}
+}
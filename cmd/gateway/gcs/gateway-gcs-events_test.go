@@ -0,0 +1,83 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package gcs
+
+import (
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func TestGCSMessageToEvent(t *testing.T) {
+	payload := []byte(`{"bucket":"bucket","name":"dir/object","size":"1024","md5Hash":"XUFAKrxLKna5cZ2REBfFkg==","contentType":"text/plain","metadata":{"x-goog-meta-color":"blue"}}`)
+
+	testCases := []struct {
+		attributes map[string]string
+		data       []byte
+		ok         bool
+		name       event.Name
+		size       int64
+		etag       string
+	}{
+		{
+			attributes: map[string]string{"eventType": "OBJECT_FINALIZE", "bucketId": "bucket", "objectId": "dir/object", "payloadFormat": "JSON_API_V1"},
+			data:       payload,
+			ok:         true,
+			name:       event.ObjectCreatedPut,
+			size:       1024,
+			etag:       "5d41402abc4b2a76b9719d911017c592",
+		},
+		{
+			attributes: map[string]string{"eventType": "OBJECT_FINALIZE", "bucketId": "bucket", "objectId": "dir/object", "payloadFormat": "NONE"},
+			ok:         true,
+			name:       event.ObjectCreatedPut,
+		},
+		{
+			attributes: map[string]string{"eventType": "OBJECT_DELETE", "bucketId": "bucket", "objectId": "dir/object"},
+			ok:         true,
+			name:       event.ObjectRemovedDelete,
+		},
+		{
+			attributes: map[string]string{"eventType": "OBJECT_DELETE", "bucketId": "bucket", "objectId": "dir/object", "overwrittenByGeneration": "2"},
+		},
+		{
+			attributes: map[string]string{"eventType": "OBJECT_METADATA_UPDATE", "bucketId": "bucket", "objectId": "dir/object"},
+		},
+		{
+			attributes: map[string]string{"eventType": "OBJECT_FINALIZE", "bucketId": "bucket", "objectId": minio.GatewayMinioSysTmp + "multipart/v2/upload/upload.json"},
+		},
+	}
+
+	for i, tc := range testCases {
+		ev, ok := gcsMessageToEvent(tc.attributes, tc.data)
+		if ok != tc.ok {
+			t.Errorf("Test %d: expected %t, got %t", i+1, tc.ok, ok)
+			continue
+		}
+		if !ok {
+			continue
+		}
+		if ev.Name != tc.name || ev.Bucket != "bucket" || ev.Object.Name != "dir/object" {
+			t.Errorf("Test %d: unexpected event %+v", i+1, ev)
+		}
+		if ev.Object.Size != tc.size || ev.Object.ETag != tc.etag {
+			t.Errorf("Test %d: expected size %d and etag %s, got %d and %s", i+1, tc.size, tc.etag, ev.Object.Size, ev.Object.ETag)
+		}
+	}
+}
@@ -21,24 +21,23 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"math"
 	"net/http"
 	"os"
-	"path"
-	"strconv"
 
 	"regexp"
 	"strings"
 	"time"
 
+	"cloud.google.com/go/pubsub"
 	"cloud.google.com/go/storage"
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
+	"github.com/minio/minio/cmd/config/storageclass"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
 	"github.com/minio/minio/pkg/env"
@@ -59,22 +58,11 @@ var (
 	// Project ID not found
 	errGCSProjectIDNotFound = fmt.Errorf("Unknown project id")
 
-	// Invalid format.
-	errGCSFormat = fmt.Errorf("Unknown format")
+	// KMS key name format is not valid.
+	errGCSInvalidKMSKeyName = fmt.Errorf("GCS KMS key name should be of the form projects/PROJECT/locations/LOCATION/keyRings/KEYRING/cryptoKeys/KEY")
 )
 
 const (
-	// Path where multipart objects are saved.
-	// If we change the backend format we will use a different url path like /multipart/v2
-	// but we will not migrate old data.
-	gcsMinioMultipartPathV1 = minio.GatewayMinioSysTmp + "multipart/v1"
-
-	// Multipart meta file.
-	gcsMinioMultipartMeta = "gcs.json"
-
-	// gcs.json version number
-	gcsMinioMultipartMetaCurrentVersion = "1"
-
 	// token prefixed with GCS returned marker to differentiate
 	// from user supplied marker.
 	gcsTokenPrefix = "{minio}"
@@ -83,15 +71,19 @@ const (
 	// Refer https://cloud.google.com/storage/docs/composite-objects
 	gcsMaxComponents = 32
 
-	// Every 24 hours we scan minio.sys.tmp to delete expired multiparts in minio.sys.tmp
-	gcsCleanupInterval = time.Hour * 24
-
-	// The cleanup routine deletes files older than 2 weeks in minio.sys.tmp
-	gcsMultipartExpiry = time.Hour * 24 * 14
-
 	// Project ID key in credentials.json
 	gcsProjectIDKey = "project_id"
 
+	// Metadata key of the Cloud KMS key encrypting an object.
+	gcsKMSKeyNameMetaKey = "X-Goog-Encryption-Kms-Key-Name"
+
+	// Storage classes of GCS.
+	// Refer https://cloud.google.com/storage/docs/storage-classes
+	gcsStorageClassStandard = "STANDARD"
+	gcsStorageClassNearline = "NEARLINE"
+	gcsStorageClassColdline = "COLDLINE"
+	gcsStorageClassArchive  = "ARCHIVE"
+
 	gcsBackend = "gcs"
 )
 
@@ -128,6 +120,12 @@ ENVIRONMENT VARIABLES:
   GCS credentials file:
      GOOGLE_APPLICATION_CREDENTIALS: Path to credentials.json
 
+  GCS encryption:
+     MINIO_GATEWAY_GCS_KMS_KEY_NAME: Cloud KMS key name to encrypt buckets and objects created through the gateway.
+
+  GCS notifications:
+     MINIO_GATEWAY_GCS_PUBSUB_SUBSCRIPTION: Pub/Sub subscription receiving Cloud Storage notifications, published to all notification targets.
+
 EXAMPLES:
   1. Start minio gateway server for GCS backend.
      {{.Prompt}} {{.EnvVarSetCommand}} GOOGLE_APPLICATION_CREDENTIALS{{.AssignmentOperator}}/path/to/credentials.json
@@ -145,6 +143,22 @@ EXAMPLES:
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_CACHE_EXPIRY{{.AssignmentOperator}}40
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_CACHE_MAXUSE{{.AssignmentOperator}}80
      {{.Prompt}} {{.HelpName}} mygcsprojectid
+
+  3. Start minio gateway server for GCS backend with customer-managed encryption keys.
+     {{.Prompt}} {{.EnvVarSetCommand}} GOOGLE_APPLICATION_CREDENTIALS{{.AssignmentOperator}}/path/to/credentials.json
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_GATEWAY_GCS_KMS_KEY_NAME{{.AssignmentOperator}}projects/mygcsprojectid/locations/us/keyRings/minio/cryptoKeys/gateway
+     {{.Prompt}} {{.HelpName}} mygcsprojectid
+
+  4. Start minio gateway server for GCS backend sending bucket events to a webhook.
+     {{.Prompt}} {{.EnvVarSetCommand}} GOOGLE_APPLICATION_CREDENTIALS{{.AssignmentOperator}}/path/to/credentials.json
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_NOTIFY_WEBHOOK_ENABLE{{.AssignmentOperator}}on
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_NOTIFY_WEBHOOK_ENDPOINT{{.AssignmentOperator}}http://localhost:3000/events
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_GATEWAY_GCS_PUBSUB_SUBSCRIPTION{{.AssignmentOperator}}minio-events
+     {{.Prompt}} {{.HelpName}} mygcsprojectid
 `
 
 	minio.RegisterGatewayCommand(cli.Command{
@@ -170,12 +184,21 @@ func gcsGatewayMain(ctx *cli.Context) {
 		cli.ShowCommandHelpAndExit(ctx, "gcs", 1)
 	}
 
-	minio.StartGateway(ctx, &GCS{projectID})
+	kmsKeyName := env.Get("MINIO_GATEWAY_GCS_KMS_KEY_NAME", "")
+	if kmsKeyName != "" && !isValidGCSKMSKeyName(kmsKeyName) {
+		logger.Fatal(errGCSInvalidKMSKeyName, "Unable to parse MINIO_GATEWAY_GCS_KMS_KEY_NAME value (`%s`)", kmsKeyName)
+	}
+
+	subscription := env.Get("MINIO_GATEWAY_GCS_PUBSUB_SUBSCRIPTION", "")
+
+	minio.StartGateway(ctx, &GCS{projectID, kmsKeyName, subscription})
 }
 
 // GCS implements Azure.
 type GCS struct {
-	projectID string
+	projectID    string
+	kmsKeyName   string
+	subscription string
 }
 
 // Name returns the name of gcs ObjectLayer.
@@ -206,12 +229,32 @@ func (g *GCS) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error)
 	}
 
 	gcs := &gcsGateway{
-		client:    client,
-		projectID: g.projectID,
+		client:     client,
+		projectID:  g.projectID,
+		kmsKeyName: g.kmsKeyName,
 	}
 
+	gcs.multipart = minio.NewGatewayMultipart(gcs)
+
 	// Start background process to cleanup old files in minio.sys.tmp
-	go gcs.CleanupGCSMinioSysTmp(ctx)
+	go gcs.multipart.CleanupStaleMultipartUploads(ctx, minio.GlobalMultipartCleanupInterval,
+		minio.GlobalMultipartExpiry, minio.GlobalServiceDoneCh)
+
+	if g.subscription != "" {
+		// Subscriptions of other projects are given by their full name.
+		projectID, subscriptionID := g.projectID, g.subscription
+		if c := strings.Split(g.subscription, "/"); len(c) == 4 && c[0] == "projects" && c[2] == "subscriptions" {
+			projectID, subscriptionID = c[1], c[3]
+		}
+		pubsubClient, err := pubsub.NewClient(ctx, projectID, option.WithUserAgent(fmt.Sprintf("MinIO/%s (GPN:MinIO;)", minio.Version)))
+		if err != nil {
+			return nil, err
+		}
+
+		var eventsCtx context.Context
+		eventsCtx, gcs.cancelEvents = context.WithCancel(ctx)
+		go gcs.listenEvents(eventsCtx, pubsubClient.Subscription(subscriptionID))
+	}
 	return gcs, nil
 }
 
@@ -220,22 +263,14 @@ func (g *GCS) Production() bool {
 	return true
 }
 
-// Stored in gcs.json - Contents of this file is not used anywhere. It can be
-// used for debugging purposes.
-type gcsMultipartMetaV1 struct {
-	Version string `json:"version"` // Version number
-	Bucket  string `json:"bucket"`  // Bucket name
-	Object  string `json:"object"`  // Object name
-}
-
-// Returns name of the multipart meta object.
-func gcsMultipartMetaName(uploadID string) string {
-	return fmt.Sprintf("%s/%s/%s", gcsMinioMultipartPathV1, uploadID, gcsMinioMultipartMeta)
-}
-
 // Returns name of the part object.
 func gcsMultipartDataName(uploadID string, partNumber int, etag string) string {
-	return fmt.Sprintf("%s/%s/%05d.%s", gcsMinioMultipartPathV1, uploadID, partNumber, etag)
+	return minio.GatewayMultipartObjectName(uploadID, fmt.Sprintf("%05d.%s.data", partNumber, etag))
+}
+
+// Returns name of the composed object.
+func gcsMultipartComposeName(uploadID string, composeNumber int) string {
+	return minio.GatewayMultipartObjectName(uploadID, fmt.Sprintf("composed-object-%05d.data", composeNumber))
 }
 
 // Convert MinIO errors to minio object layer errors.
@@ -343,11 +378,25 @@ func isValidGCSProjectIDFormat(projectID string) bool {
 	return gcsProjectIDRegex.MatchString(projectID)
 }
 
+// gcsKMSKeyNameRegex defines a valid Cloud KMS key name format
+var gcsKMSKeyNameRegex = regexp.MustCompile("^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+$")
+
+// isValidGCSKMSKeyName - checks if a given Cloud KMS key name format is valid or not.
+// Ref: https://cloud.google.com/storage/docs/encryption/using-customer-managed-keys
+func isValidGCSKMSKeyName(kmsKeyName string) bool {
+	return gcsKMSKeyNameRegex.MatchString(kmsKeyName)
+}
+
 // gcsGateway - Implements gateway for MinIO and GCS compatible object storage servers.
 type gcsGateway struct {
 	minio.GatewayUnsupported
-	client    *storage.Client
-	projectID string
+	client     *storage.Client
+	projectID  string
+	kmsKeyName string // Cloud KMS key of buckets and objects created through the gateway
+	multipart  *minio.GatewayMultipart
+
+	// Stops receiving Pub/Sub notifications, if enabled.
+	cancelEvents context.CancelFunc
 }
 
 // Returns projectID from the GOOGLE_APPLICATION_CREDENTIALS file.
@@ -363,54 +412,21 @@ func gcsParseProjectID(credsFile string) (projectID string, err error) {
 	return googleCreds[gcsProjectIDKey], err
 }
 
-// Cleanup old files in minio.sys.tmp of the given bucket.
-func (l *gcsGateway) CleanupGCSMinioSysTmpBucket(ctx context.Context, bucket string) {
-	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: minio.GatewayMinioSysTmp, Versions: false})
-	for {
-		attrs, err := it.Next()
-		if err != nil {
-			if err != iterator.Done {
-				reqInfo := &logger.ReqInfo{BucketName: bucket}
-				ctx := logger.SetReqInfo(context.Background(), reqInfo)
-				logger.LogIf(ctx, err)
-			}
-			return
-		}
-		if time.Since(attrs.Updated) > gcsMultipartExpiry {
-			// Delete files older than 2 weeks.
-			err := l.client.Bucket(bucket).Object(attrs.Name).Delete(ctx)
-			if err != nil {
-				reqInfo := &logger.ReqInfo{BucketName: bucket, ObjectName: attrs.Name}
-				ctx := logger.SetReqInfo(context.Background(), reqInfo)
-				logger.LogIf(ctx, err)
-				return
-			}
-		}
-	}
-}
-
-// Cleanup old files in minio.sys.tmp of all buckets.
-func (l *gcsGateway) CleanupGCSMinioSysTmp(ctx context.Context) {
-	for {
-		it := l.client.Buckets(ctx, l.projectID)
-		for {
-			attrs, err := it.Next()
-			if err != nil {
-				break
-			}
-			l.CleanupGCSMinioSysTmpBucket(ctx, attrs.Name)
-		}
-		// Run the cleanup loop every 1 day.
-		time.Sleep(gcsCleanupInterval)
-	}
-}
-
 // Shutdown - save any gateway metadata to disk
 // if necessary and reload upon next restart.
 func (l *gcsGateway) Shutdown(ctx context.Context) error {
+	l.multipart.Shutdown()
+	if l.cancelEvents != nil {
+		l.cancelEvents()
+	}
 	return nil
 }
 
+// TriggerMultipartCleanup - removes stale multipart uploads in minio.sys.tmp
+func (l *gcsGateway) TriggerMultipartCleanup() error {
+	return l.multipart.TriggerCleanup()
+}
+
 // StorageInfo - Not relevant to GCS backend.
 func (l *gcsGateway) StorageInfo(ctx context.Context) minio.StorageInfo {
 	return minio.StorageInfo{}
@@ -425,9 +441,13 @@ func (l *gcsGateway) MakeBucketWithLocation(ctx context.Context, bucket, locatio
 		location = "us"
 	}
 
-	err := bkt.Create(ctx, l.projectID, &storage.BucketAttrs{
+	attrs := &storage.BucketAttrs{
 		Location: location,
-	})
+	}
+	if l.kmsKeyName != "" {
+		attrs.Encryption = &storage.BucketEncryption{DefaultKMSKeyName: l.kmsKeyName}
+	}
+	err := bkt.Create(ctx, l.projectID, attrs)
 	logger.LogIf(ctx, err)
 	return gcsToObjectError(err, bucket)
 }
@@ -471,7 +491,7 @@ func (l *gcsGateway) ListBuckets(ctx context.Context) (buckets []minio.BucketInf
 }
 
 // DeleteBucket delete a bucket on GCS.
-func (l *gcsGateway) DeleteBucket(ctx context.Context, bucket string) error {
+func (l *gcsGateway) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	itObject := l.client.Bucket(bucket).Objects(ctx, &storage.Query{
 		Delimiter: minio.SlashSeparator,
 		Versions:  false,
@@ -827,6 +847,13 @@ func fromGCSAttrsToObjectInfo(attrs *storage.ObjectAttrs) minio.ObjectInfo {
 	if attrs.ContentLanguage != "" {
 		metadata["Content-Language"] = attrs.ContentLanguage
 	}
+	if attrs.KMSKeyName != "" {
+		metadata[gcsKMSKeyNameMetaKey] = attrs.KMSKeyName
+	}
+	storageClass := fromGCSStorageClass(attrs.StorageClass)
+	if storageClass != storageclass.STANDARD {
+		metadata["X-Amz-Storage-Class"] = storageClass
+	}
 
 	etag := hex.EncodeToString(attrs.MD5)
 	if etag == "" {
@@ -841,10 +868,43 @@ func fromGCSAttrsToObjectInfo(attrs *storage.ObjectAttrs) minio.ObjectInfo {
 		UserDefined:     metadata,
 		ContentType:     attrs.ContentType,
 		ContentEncoding: attrs.ContentEncoding,
+		StorageClass:    storageClass,
 		Expires:         expiry,
 	}
 }
 
+// toGCSStorageClass translates a S3 storage class to the GCS storage class
+// of the same access frequency, an empty storage class stands for the
+// default storage class of the bucket.
+func toGCSStorageClass(sc string) string {
+	switch sc {
+	case storageclass.STANDARD:
+		return gcsStorageClassStandard
+	case storageclass.STANDARDIA, storageclass.ONEZONEIA:
+		return gcsStorageClassNearline
+	case storageclass.GLACIER:
+		return gcsStorageClassColdline
+	case storageclass.DEEPARCHIVE:
+		return gcsStorageClassArchive
+	}
+	return ""
+}
+
+// fromGCSStorageClass translates a GCS storage class to a S3 storage class.
+func fromGCSStorageClass(sc string) string {
+	switch sc {
+	case gcsStorageClassNearline:
+		return storageclass.STANDARDIA
+	case gcsStorageClassColdline:
+		return storageclass.GLACIER
+	case gcsStorageClassArchive:
+		return storageclass.DEEPARCHIVE
+	}
+	// MULTI_REGIONAL, REGIONAL and DURABLE_REDUCED_AVAILABILITY
+	// are all as frequently accessible as STANDARD.
+	return storageclass.STANDARD
+}
+
 // applyMetadataToGCSAttrs applies metadata to a GCS ObjectAttrs instance
 func applyMetadataToGCSAttrs(metadata map[string]string, attrs *storage.ObjectAttrs) {
 	attrs.Metadata = make(map[string]string)
@@ -865,6 +925,8 @@ func applyMetadataToGCSAttrs(metadata map[string]string, attrs *storage.ObjectAt
 			attrs.ContentDisposition = v
 		case k == "Content-Language":
 			attrs.ContentLanguage = v
+		case k == "X-Amz-Storage-Class":
+			attrs.StorageClass = toGCSStorageClass(v)
 		}
 	}
 }
@@ -908,6 +970,7 @@ func (l *gcsGateway) PutObject(ctx context.Context, bucket string, key string, r
 		w.ChunkSize = 0
 	}
 	applyMetadataToGCSAttrs(opts.UserDefined, &w.ObjectAttrs)
+	w.KMSKeyName = l.kmsKeyName
 
 	if _, err := io.Copy(w, data); err != nil {
 		// Close the object writer upon error.
@@ -936,6 +999,7 @@ func (l *gcsGateway) CopyObject(ctx context.Context, srcBucket string, srcObject
 
 	copier := dst.CopierFrom(src)
 	applyMetadataToGCSAttrs(srcInfo.UserDefined, &copier.ObjectAttrs)
+	copier.DestinationKMSKeyName = l.kmsKeyName
 
 	attrs, err := copier.Run(ctx)
 	if err != nil {
@@ -965,123 +1029,86 @@ func (l *gcsGateway) DeleteObjects(ctx context.Context, bucket string, objects [
 	return errs, nil
 }
 
-// NewMultipartUpload - upload object in multiple parts
-func (l *gcsGateway) NewMultipartUpload(ctx context.Context, bucket string, key string, o minio.ObjectOptions) (uploadID string, err error) {
-	// generate new uploadid
-	uploadID = minio.MustGetUUID()
-
-	// generate name for part zero
-	meta := gcsMultipartMetaName(uploadID)
-
-	w := l.client.Bucket(bucket).Object(meta).NewWriter(ctx)
-	defer w.Close()
-
-	applyMetadataToGCSAttrs(o.UserDefined, &w.ObjectAttrs)
-
-	if err = json.NewEncoder(w).Encode(gcsMultipartMetaV1{
-		gcsMinioMultipartMetaCurrentVersion,
-		bucket,
-		key,
-	}); err != nil {
+// PutTempObject - saves multipart metadata in minio.sys.tmp
+func (l *gcsGateway) PutTempObject(ctx context.Context, bucket, name string, data []byte) error {
+	w := l.client.Bucket(bucket).Object(name).NewWriter(ctx)
+	w.KMSKeyName = l.kmsKeyName
+	if _, err := w.Write(data); err != nil {
+		// Make sure to close object writer upon error.
+		w.Close()
 		logger.LogIf(ctx, err)
-		return "", gcsToObjectError(err, bucket, key)
+		return gcsToObjectError(err, bucket, name)
 	}
-	return uploadID, nil
+	if err := w.Close(); err != nil {
+		logger.LogIf(ctx, err)
+		return gcsToObjectError(err, bucket, name)
+	}
+	return nil
 }
 
-// ListMultipartUploads - lists the (first) multipart upload for an object
-// matched _exactly_ by the prefix
-func (l *gcsGateway) ListMultipartUploads(ctx context.Context, bucket string, prefix string, keyMarker string, uploadIDMarker string, delimiter string, maxUploads int) (minio.ListMultipartsInfo, error) {
-	// List objects under <bucket>/gcsMinioMultipartPathV1
-	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{
-		Prefix: gcsMinioMultipartPathV1,
-	})
+// GetTempObject - reads multipart metadata from minio.sys.tmp
+func (l *gcsGateway) GetTempObject(ctx context.Context, bucket, name string) ([]byte, error) {
+	r, err := l.client.Bucket(bucket).Object(name).NewReader(ctx)
+	if err != nil {
+		return nil, gcsToObjectError(err, bucket, name)
+	}
+	defer r.Close()
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return nil, gcsToObjectError(err, bucket, name)
+	}
+	return data, nil
+}
 
-	var uploads []minio.MultipartInfo
+// DeleteTempObject - deletes an object in minio.sys.tmp
+func (l *gcsGateway) DeleteTempObject(ctx context.Context, bucket, name string) error {
+	return gcsToObjectError(l.client.Bucket(bucket).Object(name).Delete(ctx), bucket, name)
+}
 
+// ListTempObjects - lists all objects in minio.sys.tmp starting with prefix
+func (l *gcsGateway) ListTempObjects(ctx context.Context, bucket, prefix string) ([]minio.GatewayTempObjectInfo, error) {
+	var objects []minio.GatewayTempObjectInfo
+	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix, Versions: false})
 	for {
 		attrs, err := it.Next()
 		if err == iterator.Done {
 			break
 		}
-
 		if err != nil {
 			logger.LogIf(ctx, err)
-			return minio.ListMultipartsInfo{
-				KeyMarker:      keyMarker,
-				UploadIDMarker: uploadIDMarker,
-				MaxUploads:     maxUploads,
-				Prefix:         prefix,
-				Delimiter:      delimiter,
-			}, gcsToObjectError(err)
-		}
-
-		// Skip entries other than gcs.json
-		if !strings.HasSuffix(attrs.Name, gcsMinioMultipartMeta) {
-			continue
+			return nil, gcsToObjectError(err, bucket, prefix)
 		}
+		objects = append(objects, minio.GatewayTempObjectInfo{
+			Name:    attrs.Name,
+			ModTime: attrs.Updated,
+		})
+	}
+	return objects, nil
+}
 
-		// Extract multipart upload information from gcs.json
-		obj := l.client.Bucket(bucket).Object(attrs.Name)
-		objReader, rErr := obj.NewReader(ctx)
-		if rErr != nil {
-			logger.LogIf(ctx, rErr)
-			return minio.ListMultipartsInfo{}, rErr
-		}
-		defer objReader.Close()
-
-		var mpMeta gcsMultipartMetaV1
-		dec := json.NewDecoder(objReader)
-		decErr := dec.Decode(&mpMeta)
-		if decErr != nil {
-			logger.LogIf(ctx, decErr)
-			return minio.ListMultipartsInfo{}, decErr
-		}
+// NewMultipartUpload - upload object in multiple parts
+func (l *gcsGateway) NewMultipartUpload(ctx context.Context, bucket string, key string, o minio.ObjectOptions) (uploadID string, err error) {
+	// generate new uploadid
+	uploadID = minio.MustGetUUID()
 
-		if prefix == mpMeta.Object {
-			// Extract uploadId
-			// E.g minio.sys.tmp/multipart/v1/d063ad89-fdc4-4ea3-a99e-22dba98151f5/gcs.json
-			components := strings.SplitN(attrs.Name, minio.SlashSeparator, 5)
-			if len(components) != 5 {
-				compErr := errors.New("Invalid multipart upload format")
-				logger.LogIf(ctx, compErr)
-				return minio.ListMultipartsInfo{}, compErr
-			}
-			upload := minio.MultipartInfo{
-				Object:    mpMeta.Object,
-				UploadID:  components[3],
-				Initiated: attrs.Created,
-			}
-			uploads = append(uploads, upload)
-		}
+	if err = l.multipart.NewMultipartUpload(ctx, bucket, key, uploadID, o.UserDefined); err != nil {
+		return "", gcsToObjectError(err, bucket, key)
 	}
-
-	return minio.ListMultipartsInfo{
-		KeyMarker:          keyMarker,
-		UploadIDMarker:     uploadIDMarker,
-		MaxUploads:         maxUploads,
-		Prefix:             prefix,
-		Delimiter:          delimiter,
-		Uploads:            uploads,
-		NextKeyMarker:      "",
-		NextUploadIDMarker: "",
-		IsTruncated:        false,
-	}, nil
+	return uploadID, nil
 }
 
-// Checks if minio.sys.tmp/multipart/v1/<upload-id>/gcs.json exists, returns
-// an object layer compatible error upon any error.
-func (l *gcsGateway) checkUploadIDExists(ctx context.Context, bucket string, key string, uploadID string) error {
-	_, err := l.client.Bucket(bucket).Object(gcsMultipartMetaName(uploadID)).Attrs(ctx)
-	logger.LogIf(ctx, err)
-	return gcsToObjectError(err, bucket, key, uploadID)
+// ListMultipartUploads - lists all multipart uploads of objects starting with prefix
+func (l *gcsGateway) ListMultipartUploads(ctx context.Context, bucket string, prefix string, keyMarker string, uploadIDMarker string, delimiter string, maxUploads int) (minio.ListMultipartsInfo, error) {
+	result, err := l.multipart.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	return result, gcsToObjectError(err, bucket)
 }
 
 // PutObjectPart puts a part of object in bucket
 func (l *gcsGateway) PutObjectPart(ctx context.Context, bucket string, key string, uploadID string, partNumber int, r *minio.PutObjReader, opts minio.ObjectOptions) (minio.PartInfo, error) {
 	data := r.Reader
-	if err := l.checkUploadIDExists(ctx, bucket, key, uploadID); err != nil {
-		return minio.PartInfo{}, err
+	if _, err := l.multipart.GetMultipartUpload(ctx, bucket, key, uploadID); err != nil {
+		return minio.PartInfo{}, gcsToObjectError(err, bucket, key, uploadID)
 	}
 	etag := data.MD5HexString()
 	if etag == "" {
@@ -1093,6 +1120,7 @@ func (l *gcsGateway) PutObjectPart(ctx context.Context, bucket string, key strin
 	// Disable "chunked" uploading in GCS client. If enabled, it can cause a corner case
 	// where it tries to upload 0 bytes in the last chunk and get error from server.
 	w.ChunkSize = 0
+	w.KMSKeyName = l.kmsKeyName
 	if _, err := io.Copy(w, data); err != nil {
 		// Make sure to close object writer upon error.
 		w.Close()
@@ -1104,133 +1132,64 @@ func (l *gcsGateway) PutObjectPart(ctx context.Context, bucket string, key strin
 		logger.LogIf(ctx, err)
 		return minio.PartInfo{}, gcsToObjectError(err, bucket, key)
 	}
-	return minio.PartInfo{
+	partInfo, err := l.multipart.PutObjectPart(ctx, bucket, uploadID, minio.GatewayPart{
 		PartNumber:   partNumber,
 		ETag:         etag,
-		LastModified: minio.UTCNow(),
 		Size:         data.Size(),
-	}, nil
-
+		LastModified: minio.UTCNow(),
+	})
+	return partInfo, gcsToObjectError(err, bucket, key)
 }
 
-// gcsGetPartInfo returns PartInfo of a given object part
-func gcsGetPartInfo(ctx context.Context, attrs *storage.ObjectAttrs) (minio.PartInfo, error) {
-	components := strings.SplitN(attrs.Name, minio.SlashSeparator, 5)
-	if len(components) != 5 {
-		logger.LogIf(ctx, errors.New("Invalid multipart upload format"))
-		return minio.PartInfo{}, errors.New("Invalid multipart upload format")
+// CopyObjectPart - copies the whole source object into a part with a
+// server side rewrite, GCS cannot copy a range of an object so ranged
+// copies are uploaded through the gateway.
+func (l *gcsGateway) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject, uploadID string, partID int,
+	startOffset, length int64, srcInfo minio.ObjectInfo, srcOpts, dstOpts minio.ObjectOptions) (minio.PartInfo, error) {
+	if srcOpts.CheckCopyPrecondFn != nil && srcOpts.CheckCopyPrecondFn(srcInfo, "") {
+		return minio.PartInfo{}, minio.PreConditionFailed{}
 	}
-
-	partComps := strings.SplitN(components[4], ".", 2)
-	if len(partComps) != 2 {
-		logger.LogIf(ctx, errors.New("Invalid multipart part format"))
-		return minio.PartInfo{}, errors.New("Invalid multipart part format")
+	if startOffset != 0 || length != srcInfo.Size {
+		return l.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, srcInfo.PutObjReader, dstOpts)
 	}
 
-	partNum, pErr := strconv.Atoi(partComps[0])
-	if pErr != nil {
-		logger.LogIf(ctx, pErr)
-		return minio.PartInfo{}, errors.New("Invalid part number")
+	if _, err := l.multipart.GetMultipartUpload(ctx, destBucket, destObject, uploadID); err != nil {
+		return minio.PartInfo{}, gcsToObjectError(err, destBucket, destObject, uploadID)
 	}
 
-	return minio.PartInfo{
-		PartNumber:   partNum,
-		LastModified: attrs.Updated,
-		Size:         attrs.Size,
-		ETag:         partComps[1],
-	}, nil
-}
-
-//  ListObjectParts returns all object parts for specified object in specified bucket
-func (l *gcsGateway) ListObjectParts(ctx context.Context, bucket string, key string, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (minio.ListPartsInfo, error) {
-	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{
-		Prefix: path.Join(gcsMinioMultipartPathV1, uploadID),
-	})
-
-	var (
-		count     int
-		partInfos []minio.PartInfo
-	)
-
-	isTruncated := true
-	for count < maxParts {
-		attrs, err := it.Next()
-		if err == iterator.Done {
-			isTruncated = false
-			break
-		}
-
-		if err != nil {
-			logger.LogIf(ctx, err)
-			return minio.ListPartsInfo{}, gcsToObjectError(err)
-		}
-
-		if strings.HasSuffix(attrs.Name, gcsMinioMultipartMeta) {
-			continue
-		}
-
-		partInfo, pErr := gcsGetPartInfo(ctx, attrs)
-		if pErr != nil {
-			logger.LogIf(ctx, pErr)
-			return minio.ListPartsInfo{}, pErr
-		}
-
-		if partInfo.PartNumber <= partNumberMarker {
-			continue
-		}
-
-		partInfos = append(partInfos, partInfo)
-		count++
-	}
+	// Generate random ETag, the MD5 of the data is not known.
+	etag := minio.GenETag()
+	src := l.client.Bucket(srcBucket).Object(srcObject)
+	dst := l.client.Bucket(destBucket).Object(gcsMultipartDataName(uploadID, partID, etag))
 
-	nextPartNumberMarker := 0
-	if isTruncated {
-		nextPartNumberMarker = partInfos[maxParts-1].PartNumber
+	copier := dst.CopierFrom(src)
+	copier.DestinationKMSKeyName = l.kmsKeyName
+	if _, err := copier.Run(ctx); err != nil {
+		logger.LogIf(ctx, err)
+		return minio.PartInfo{}, gcsToObjectError(err, srcBucket, srcObject)
 	}
 
-	return minio.ListPartsInfo{
-		Bucket:               bucket,
-		Object:               key,
-		UploadID:             uploadID,
-		PartNumberMarker:     partNumberMarker,
-		NextPartNumberMarker: nextPartNumberMarker,
-		MaxParts:             maxParts,
-		Parts:                partInfos,
-		IsTruncated:          isTruncated,
-	}, nil
+	partInfo, err := l.multipart.PutObjectPart(ctx, destBucket, uploadID, minio.GatewayPart{
+		PartNumber:   partID,
+		ETag:         etag,
+		Size:         length,
+		LastModified: minio.UTCNow(),
+	})
+	return partInfo, gcsToObjectError(err, destBucket, destObject)
 }
 
-// Called by AbortMultipartUpload and CompleteMultipartUpload for cleaning up.
-func (l *gcsGateway) cleanupMultipartUpload(ctx context.Context, bucket, key, uploadID string) error {
-	prefix := fmt.Sprintf("%s/%s/", gcsMinioMultipartPathV1, uploadID)
-
-	// iterate through all parts and delete them
-	it := l.client.Bucket(bucket).Objects(ctx, &storage.Query{Prefix: prefix, Versions: false})
-
-	for {
-		attrs, err := it.Next()
-		if err == iterator.Done {
-			break
-		}
-		if err != nil {
-			logger.LogIf(ctx, err)
-			return gcsToObjectError(err, bucket, key)
-		}
-
-		object := l.client.Bucket(bucket).Object(attrs.Name)
-		// Ignore the error as parallel AbortMultipartUpload might have deleted it.
-		object.Delete(ctx)
-	}
-
-	return nil
+// ListObjectParts returns all object parts for specified object in specified bucket
+func (l *gcsGateway) ListObjectParts(ctx context.Context, bucket string, key string, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (minio.ListPartsInfo, error) {
+	result, err := l.multipart.ListObjectParts(ctx, bucket, key, uploadID, partNumberMarker, maxParts)
+	return result, gcsToObjectError(err, bucket, key, uploadID)
 }
 
 // AbortMultipartUpload aborts a ongoing multipart upload
 func (l *gcsGateway) AbortMultipartUpload(ctx context.Context, bucket string, key string, uploadID string) error {
-	if err := l.checkUploadIDExists(ctx, bucket, key, uploadID); err != nil {
-		return err
+	if _, err := l.multipart.GetMultipartUpload(ctx, bucket, key, uploadID); err != nil {
+		return gcsToObjectError(err, bucket, key, uploadID)
 	}
-	return l.cleanupMultipartUpload(ctx, bucket, key, uploadID)
+	return gcsToObjectError(l.multipart.CleanupMultipartUpload(ctx, bucket, uploadID), bucket, key)
 }
 
 // CompleteMultipartUpload completes ongoing multipart upload and finalizes object
@@ -1238,74 +1197,27 @@ func (l *gcsGateway) AbortMultipartUpload(ctx context.Context, bucket string, ke
 // be composed in a single operation. There is a per-project rate limit (currently 200)
 // to the number of source objects you can compose per second.
 func (l *gcsGateway) CompleteMultipartUpload(ctx context.Context, bucket string, key string, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (minio.ObjectInfo, error) {
-	meta := gcsMultipartMetaName(uploadID)
-	object := l.client.Bucket(bucket).Object(meta)
-
-	partZeroAttrs, err := object.Attrs(ctx)
+	upload, err := l.multipart.GetMultipartUpload(ctx, bucket, key, uploadID)
 	if err != nil {
-		logger.LogIf(ctx, err)
 		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key, uploadID)
 	}
 
-	r, err := object.NewReader(ctx)
+	// Error out if parts except last part sizing < 5MiB.
+	uploadParts, err := l.multipart.GetObjectParts(ctx, bucket, uploadID, uploadedParts, 5*humanize.MiByte)
 	if err != nil {
 		logger.LogIf(ctx, err)
-		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
-	}
-	defer r.Close()
-
-	// Check version compatibility of the meta file before compose()
-	multipartMeta := gcsMultipartMetaV1{}
-	if err = json.NewDecoder(r).Decode(&multipartMeta); err != nil {
-		logger.LogIf(ctx, err)
-		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
-	}
-
-	if multipartMeta.Version != gcsMinioMultipartMetaCurrentVersion {
-		logger.LogIf(ctx, errGCSFormat)
-		return minio.ObjectInfo{}, gcsToObjectError(errGCSFormat, bucket, key)
-	}
-
-	// Validate if the gcs.json stores valid entries for the bucket and key.
-	if multipartMeta.Bucket != bucket || multipartMeta.Object != key {
-		return minio.ObjectInfo{}, gcsToObjectError(minio.InvalidUploadID{
-			UploadID: uploadID,
-		}, bucket, key)
+		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key, uploadID)
 	}
 
 	var parts []*storage.ObjectHandle
-	partSizes := make([]int64, len(uploadedParts))
-	for i, uploadedPart := range uploadedParts {
+	for _, part := range uploadParts {
 		parts = append(parts, l.client.Bucket(bucket).Object(gcsMultipartDataName(uploadID,
-			uploadedPart.PartNumber, uploadedPart.ETag)))
-		partAttr, pErr := l.client.Bucket(bucket).Object(gcsMultipartDataName(uploadID, uploadedPart.PartNumber, uploadedPart.ETag)).Attrs(ctx)
-		if pErr != nil {
-			logger.LogIf(ctx, pErr)
-			return minio.ObjectInfo{}, gcsToObjectError(pErr, bucket, key, uploadID)
-		}
-		partSizes[i] = partAttr.Size
-	}
-
-	// Error out if parts except last part sizing < 5MiB.
-	for i, size := range partSizes[:len(partSizes)-1] {
-		if size < 5*humanize.MiByte {
-			logger.LogIf(ctx, minio.PartTooSmall{
-				PartNumber: uploadedParts[i].PartNumber,
-				PartSize:   size,
-				PartETag:   uploadedParts[i].ETag,
-			})
-			return minio.ObjectInfo{}, minio.PartTooSmall{
-				PartNumber: uploadedParts[i].PartNumber,
-				PartSize:   size,
-				PartETag:   uploadedParts[i].ETag,
-			}
-		}
+			part.PartNumber, part.ETag)))
 	}
 
-	// Returns name of the composed object.
-	gcsMultipartComposeName := func(uploadID string, composeNumber int) string {
-		return fmt.Sprintf("%s/tmp/%s/composed-object-%05d", minio.GatewayMinioSysTmp, uploadID, composeNumber)
-	}
+	// Attributes of the final object from the metadata sent to NewMultipartUpload.
+	var uploadAttrs storage.ObjectAttrs
+	applyMetadataToGCSAttrs(upload.Metadata, &uploadAttrs)
 
 	composeCount := int(math.Ceil(float64(len(parts)) / float64(gcsMaxComponents)))
 	if composeCount > 1 {
@@ -1321,8 +1233,8 @@ func (l *gcsGateway) CompleteMultipartUpload(ctx context.Context, bucket string,
 			}
 
 			composer := composeParts[i].ComposerFrom(parts[start:end]...)
-			composer.ContentType = partZeroAttrs.ContentType
-			composer.Metadata = partZeroAttrs.Metadata
+			composer.ContentType = uploadAttrs.ContentType
+			composer.Metadata = uploadAttrs.Metadata
 
 			if _, err = composer.Run(ctx); err != nil {
 				logger.LogIf(ctx, err)
@@ -1335,18 +1247,37 @@ func (l *gcsGateway) CompleteMultipartUpload(ctx context.Context, bucket string,
 	}
 
 	composer := l.client.Bucket(bucket).Object(key).ComposerFrom(parts...)
-	composer.ContentType = partZeroAttrs.ContentType
-	composer.ContentEncoding = partZeroAttrs.ContentEncoding
-	composer.CacheControl = partZeroAttrs.CacheControl
-	composer.ContentDisposition = partZeroAttrs.ContentDisposition
-	composer.ContentLanguage = partZeroAttrs.ContentLanguage
-	composer.Metadata = partZeroAttrs.Metadata
+	composer.ContentType = uploadAttrs.ContentType
+	composer.ContentEncoding = uploadAttrs.ContentEncoding
+	composer.CacheControl = uploadAttrs.CacheControl
+	composer.ContentDisposition = uploadAttrs.ContentDisposition
+	composer.ContentLanguage = uploadAttrs.ContentLanguage
+	composer.StorageClass = uploadAttrs.StorageClass
+	composer.Metadata = uploadAttrs.Metadata
 	attrs, err := composer.Run(ctx)
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
 	}
-	if err = l.cleanupMultipartUpload(ctx, bucket, key, uploadID); err != nil {
+	if l.kmsKeyName != "" && attrs.KMSKeyName == "" {
+		// Composite objects are encrypted with the default key of the
+		// bucket, rewrite them with the KMS key if the bucket has none.
+		object := l.client.Bucket(bucket).Object(key)
+		copier := object.CopierFrom(object)
+		copier.ContentType = attrs.ContentType
+		copier.ContentEncoding = attrs.ContentEncoding
+		copier.CacheControl = attrs.CacheControl
+		copier.ContentDisposition = attrs.ContentDisposition
+		copier.ContentLanguage = attrs.ContentLanguage
+		copier.StorageClass = attrs.StorageClass
+		copier.Metadata = attrs.Metadata
+		copier.DestinationKMSKeyName = l.kmsKeyName
+		if attrs, err = copier.Run(ctx); err != nil {
+			logger.LogIf(ctx, err)
+			return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
+		}
+	}
+	if err = l.multipart.CleanupMultipartUpload(ctx, bucket, uploadID); err != nil {
 		return minio.ObjectInfo{}, gcsToObjectError(err, bucket, key)
 	}
 	return fromGCSAttrsToObjectInfo(attrs), nil
@@ -1479,3 +1410,13 @@ func (l *gcsGateway) DeleteBucketPolicy(ctx context.Context, bucket string) erro
 func (l *gcsGateway) IsCompressionSupported() bool {
 	return false
 }
+
+// IsReady returns whether GCS is reachable with the configured credentials.
+func (l *gcsGateway) IsReady(ctx context.Context) bool {
+	_, err := l.client.Buckets(ctx, l.projectID).Next()
+	if err != nil && err != iterator.Done {
+		logger.LogIf(ctx, err)
+		return false
+	}
+	return true
+}
@@ -109,6 +109,28 @@ func TestValidGCSProjectIDFormat(t *testing.T) {
 	}
 }
 
+// TestValidGCSKMSKeyName tests isValidGCSKMSKeyName
+func TestValidGCSKMSKeyName(t *testing.T) {
+	testCases := []struct {
+		KMSKeyName string
+		Valid      bool
+	}{
+		{"", false},
+		{"key", false},
+		{"projects/p/locations/us/keyRings/r/cryptoKeys/k", true},
+		{"projects/p/locations/us/keyRings/r/cryptoKeys/", false},
+		{"projects/p/locations/us/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1", false},
+		{"/projects/p/locations/us/keyRings/r/cryptoKeys/k", false},
+	}
+
+	for i, testCase := range testCases {
+		valid := isValidGCSKMSKeyName(testCase.KMSKeyName)
+		if valid != testCase.Valid {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.Valid, valid)
+		}
+	}
+}
+
 // Test for isGCSMarker.
 func TestIsGCSMarker(t *testing.T) {
 	testCases := []struct {
@@ -141,16 +163,6 @@ func TestIsGCSMarker(t *testing.T) {
 	}
 }
 
-// Test for gcsMultipartMetaName.
-func TestGCSMultipartMetaName(t *testing.T) {
-	uploadID := "a"
-	expected := path.Join(gcsMinioMultipartPathV1, uploadID, gcsMinioMultipartMeta)
-	got := gcsMultipartMetaName(uploadID)
-	if expected != got {
-		t.Errorf("expected: %s, got: %s", expected, got)
-	}
-}
-
 // Test for gcsMultipartDataName.
 func TestGCSMultipartDataName(t *testing.T) {
 	var (
@@ -158,7 +170,7 @@ func TestGCSMultipartDataName(t *testing.T) {
 		etag       = "b"
 		partNumber = 1
 	)
-	expected := path.Join(gcsMinioMultipartPathV1, uploadID, fmt.Sprintf("%05d.%s", partNumber, etag))
+	expected := path.Join(minio.GatewayMinioSysTmp, "multipart/v2", uploadID, fmt.Sprintf("%05d.%s.data", partNumber, etag))
 	got := gcsMultipartDataName(uploadID, partNumber, etag)
 	if expected != got {
 		t.Errorf("expected: %s, got: %s", expected, got)
@@ -395,6 +407,7 @@ func TestS3MetaToGCSAttributes(t *testing.T) {
 		"content-disposition":      "dummy",
 		"content-type":             "application/javascript",
 		"Content-Language":         "en",
+		"X-Amz-Storage-Class":      "GLACIER",
 		"X-Amz-Meta-Hdr":           "value",
 		"X-Amz-Meta-X-Amz-Key":     "hu3ZSqtqwn+aL4V2VhAeov4i+bG3KyCtRMSXQFRHXOk=",
 		"X-Amz-Meta-X-Amz-Matdesc": "{}",
@@ -431,6 +444,9 @@ func TestS3MetaToGCSAttributes(t *testing.T) {
 	if attrs.ContentType != headers["content-type"] {
 		t.Fatalf("Test failed with Content-Type mistmatch, expected %s, got %s", headers["content-type"], attrs.ContentType)
 	}
+	if attrs.StorageClass != gcsStorageClassColdline {
+		t.Fatalf("Test failed with StorageClass mistmatch, expected %s, got %s", gcsStorageClassColdline, attrs.StorageClass)
+	}
 }
 
 func TestGCSAttrsToObjectInfo(t *testing.T) {
@@ -450,6 +466,8 @@ func TestGCSAttrsToObjectInfo(t *testing.T) {
 		"Content-Encoding":         "gzip",
 		"Content-Language":         "en",
 		"Content-Type":             "application/javascript",
+		gcsKMSKeyNameMetaKey:       "projects/p/locations/us/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+		"X-Amz-Storage-Class":      "STANDARD_IA",
 	}
 
 	attrs := storage.ObjectAttrs{
@@ -463,6 +481,8 @@ func TestGCSAttrsToObjectInfo(t *testing.T) {
 		ContentEncoding:    "gzip",
 		ContentLanguage:    "en",
 		ContentType:        "application/javascript",
+		KMSKeyName:         "projects/p/locations/us/keyRings/r/cryptoKeys/k/cryptoKeyVersions/1",
+		StorageClass:       "NEARLINE",
 		Metadata:           metadata,
 	}
 	expectedETag := minio.ToS3ETag(fmt.Sprintf("%d", attrs.CRC32C))
@@ -487,4 +507,36 @@ func TestGCSAttrsToObjectInfo(t *testing.T) {
 	if objInfo.ETag != expectedETag {
 		t.Fatalf("Test failed with ETag mistmatch, expected %s, got %s", expectedETag, objInfo.ETag)
 	}
+	if objInfo.StorageClass != "STANDARD_IA" {
+		t.Fatalf("Test failed with StorageClass mistmatch, expected %s, got %s", "STANDARD_IA", objInfo.StorageClass)
+	}
+}
+
+// Test for GCS storage class translation.
+func TestGCSStorageClass(t *testing.T) {
+	testCases := []struct {
+		s3StorageClass  string
+		gcsStorageClass string
+	}{
+		{"STANDARD", "STANDARD"},
+		{"STANDARD_IA", "NEARLINE"},
+		{"GLACIER", "COLDLINE"},
+		{"DEEP_ARCHIVE", "ARCHIVE"},
+	}
+	for i, tc := range testCases {
+		if sc := toGCSStorageClass(tc.s3StorageClass); sc != tc.gcsStorageClass {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, tc.gcsStorageClass, sc)
+		}
+		if sc := fromGCSStorageClass(tc.gcsStorageClass); sc != tc.s3StorageClass {
+			t.Errorf("Test %d: Expected %s, got %s", i+1, tc.s3StorageClass, sc)
+		}
+	}
+
+	// Storage classes without equivalent use the defaults.
+	if sc := toGCSStorageClass("INTELLIGENT_TIERING"); sc != "" {
+		t.Errorf("Expected bucket default storage class, got %s", sc)
+	}
+	if sc := fromGCSStorageClass("MULTI_REGIONAL"); sc != "STANDARD" {
+		t.Errorf("Expected STANDARD, got %s", sc)
+	}
 }
@@ -220,6 +220,13 @@ func (n *hdfsObjects) Shutdown(ctx context.Context) error {
 	return n.clnt.Close()
 }
 
+// IsReady returns whether the HDFS namenode is reachable.
+func (n *hdfsObjects) IsReady(ctx context.Context) bool {
+	_, err := n.clnt.StatFs()
+	logger.LogIf(ctx, err)
+	return err == nil
+}
+
 func (n *hdfsObjects) StorageInfo(ctx context.Context) minio.StorageInfo {
 	fsInfo, err := n.clnt.StatFs()
 	if err != nil {
@@ -288,7 +295,7 @@ func hdfsIsValidBucketName(bucket string) bool {
 	return s3utils.CheckValidBucketNameStrict(bucket) == nil
 }
 
-func (n *hdfsObjects) DeleteBucket(ctx context.Context, bucket string) error {
+func (n *hdfsObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	if !hdfsIsValidBucketName(bucket) {
 		return minio.BucketNameInvalid{Bucket: bucket}
 	}
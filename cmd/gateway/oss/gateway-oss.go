@@ -414,7 +414,7 @@ func (l *ossObjects) ListBuckets(ctx context.Context) (buckets []minio.BucketInf
 }
 
 // DeleteBucket deletes a bucket on OSS.
-func (l *ossObjects) DeleteBucket(ctx context.Context, bucket string) error {
+func (l *ossObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	err := l.Client.DeleteBucket(bucket)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -647,6 +647,10 @@ func ossPutObject(ctx context.Context, client *oss.Client, bucket, object string
 		return objInfo, ossToObjectError(err, bucket, object)
 	}
 
+	if data.Size() > minio.GatewayMaxSinglePutSize {
+		return ossPutObjectMultipart(ctx, client, bkt, object, data, opts)
+	}
+
 	err = bkt.PutObject(object, data, opts...)
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -656,6 +660,49 @@ func ossPutObject(ctx context.Context, client *oss.Client, bucket, object string
 	return ossGetObjectInfo(ctx, client, bucket, object)
 }
 
+// ossPutObjectMultipart uploads objects larger than what OSS accepts in a
+// single PUT as a multipart upload, the upload is aborted on failure.
+func ossPutObjectMultipart(ctx context.Context, client *oss.Client, bkt *oss.Bucket, object string, data *hash.Reader, opts []oss.Option) (objInfo minio.ObjectInfo, err error) {
+	imur, err := bkt.InitiateMultipartUpload(object, opts...)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, ossToObjectError(err, bkt.BucketName, object)
+	}
+	defer func() {
+		if err != nil {
+			logger.LogIf(ctx, bkt.AbortMultipartUpload(imur))
+		}
+	}()
+
+	partSize := minio.GatewayPartSize(data.Size())
+	var parts []oss.UploadPart
+	for partID, remaining := 1, data.Size(); remaining > 0; partID++ {
+		if remaining < partSize {
+			partSize = remaining
+		}
+		var up oss.UploadPart
+		up, err = bkt.UploadPart(imur, io.LimitReader(data, partSize), partSize, partID)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			return objInfo, ossToObjectError(err, bkt.BucketName, object)
+		}
+		parts = append(parts, up)
+		remaining -= partSize
+	}
+
+	// Verify the client provided checksums before committing the upload.
+	if err = data.Verify(); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = bkt.CompleteMultipartUpload(imur, parts); err != nil {
+		logger.LogIf(ctx, err)
+		return objInfo, ossToObjectError(err, bkt.BucketName, object)
+	}
+
+	return ossGetObjectInfo(ctx, client, bkt.BucketName, object)
+}
+
 // PutObject creates a new object with the incoming data.
 func (l *ossObjects) PutObject(ctx context.Context, bucket, object string, r *minio.PutObjReader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
 	data := r.Reader
@@ -1116,3 +1163,10 @@ func (l *ossObjects) DeleteBucketPolicy(ctx context.Context, bucket string) erro
 func (l *ossObjects) IsCompressionSupported() bool {
 	return false
 }
+
+// IsReady returns whether OSS is reachable with the configured credentials.
+func (l *ossObjects) IsReady(ctx context.Context) bool {
+	_, err := l.Client.ListBuckets(oss.MaxKeys(1))
+	logger.LogIf(ctx, err)
+	return err == nil
+}
@@ -0,0 +1,95 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"time"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+// Delay before listening again after the upstream connection failed.
+const s3EventsRetryInterval = 10 * time.Second
+
+// Events republished from the upstream server.
+var s3EventNames = []string{
+	"s3:ObjectCreated:*",
+	"s3:ObjectRemoved:*",
+}
+
+// s3RecordToEvent - converts a bucket notification of the upstream
+// server to a gateway event, false is returned for notifications about
+// objects of the gateway itself.
+func s3RecordToEvent(record miniogo.NotificationEvent, host string) (minio.GatewayEvent, bool) {
+	name, err := event.ParseName(record.EventName)
+	if err != nil {
+		return minio.GatewayEvent{}, false
+	}
+	key, err := url.QueryUnescape(record.S3.Object.Key)
+	if err != nil {
+		return minio.GatewayEvent{}, false
+	}
+	// Encrypted objects are stored with their metadata under the
+	// gateway prefix when KMS is configured.
+	if minio.GlobalKMS != nil && strings.Contains(key, defaultMinioGWPrefix) {
+		return minio.GatewayEvent{}, false
+	}
+	return minio.GatewayEvent{
+		Name:   name,
+		Bucket: record.S3.Bucket.Name,
+		Object: minio.ObjectInfo{
+			Bucket: record.S3.Bucket.Name,
+			Name:   key,
+			Size:   record.S3.Object.Size,
+			ETag:   record.S3.Object.ETag,
+		},
+		Host: host,
+	}, true
+}
+
+// listenEvents - republishes the bucket notifications of an upstream
+// MinIO server until doneCh is closed.
+func (l *s3Objects) listenEvents(bucket string, doneCh <-chan struct{}) {
+	ctx := logger.SetReqInfo(context.Background(), &logger.ReqInfo{BucketName: bucket})
+	for {
+		clnt := l.pool.readClient()
+		for info := range clnt.ListenBucketNotification(bucket, "", "", s3EventNames, doneCh) {
+			if info.Err != nil {
+				logger.LogIf(ctx, info.Err)
+				continue
+			}
+			for _, record := range info.Records {
+				if ev, ok := s3RecordToEvent(record, clnt.EndpointURL().Host); ok {
+					minio.PublishGatewayEvent(ev)
+				}
+			}
+		}
+
+		select {
+		case <-doneCh:
+			return
+		case <-time.After(s3EventsRetryInterval):
+		}
+	}
+}
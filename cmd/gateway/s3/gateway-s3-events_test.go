@@ -0,0 +1,67 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"testing"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestS3RecordToEvent(t *testing.T) {
+	newRecord := func(name, key string) miniogo.NotificationEvent {
+		var record miniogo.NotificationEvent
+		record.EventName = name
+		record.S3.Bucket.Name = "bucket"
+		record.S3.Object.Key = key
+		record.S3.Object.Size = 1024
+		record.S3.Object.ETag = "5d41402abc4b2a76b9719d911017c592"
+		return record
+	}
+
+	testCases := []struct {
+		record miniogo.NotificationEvent
+		ok     bool
+		name   event.Name
+		object string
+	}{
+		{newRecord("s3:ObjectCreated:Put", "dir/object"), true, event.ObjectCreatedPut, "dir/object"},
+		{newRecord("s3:ObjectRemoved:Delete", "dir%2Fmy+object"), true, event.ObjectRemovedDelete, "dir/my object"},
+		{newRecord("s3:Unknown", "object"), false, 0, ""},
+		{newRecord("s3:ObjectCreated:Put", "%zz"), false, 0, ""},
+	}
+
+	for i, testCase := range testCases {
+		ev, ok := s3RecordToEvent(testCase.record, "minio:9000")
+		if ok != testCase.ok {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if ev.Name != testCase.name {
+			t.Errorf("Test %d: expected event %v, got %v", i+1, testCase.name, ev.Name)
+		}
+		if ev.Bucket != "bucket" || ev.Object.Name != testCase.object {
+			t.Errorf("Test %d: expected bucket/%s, got %s/%s", i+1, testCase.object, ev.Bucket, ev.Object.Name)
+		}
+		if ev.Object.Size != 1024 || ev.Host != "minio:9000" {
+			t.Errorf("Test %d: unexpected event %#v", i+1, ev)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+// s3HealthCheckInterval - interval between health checks of the
+// upstream endpoints.
+const s3HealthCheckInterval = 10 * time.Second
+
+var errNoUpstreamOnline = errors.New("none of the upstream S3 endpoints are reachable")
+
+// s3Endpoint - upstream S3 endpoint of the gateway.
+type s3Endpoint struct {
+	url    string
+	client *s3Client
+	online int32
+}
+
+func (e *s3Endpoint) isOnline() bool {
+	return atomic.LoadInt32(&e.online) == 1
+}
+
+func (e *s3Endpoint) setOnline() {
+	if atomic.SwapInt32(&e.online, 1) == 0 {
+		logger.Info("S3 endpoint %s is online", e.url)
+	}
+}
+
+func (e *s3Endpoint) setOffline(err error) {
+	if atomic.SwapInt32(&e.online, 0) == 1 {
+		logger.Info("S3 endpoint %s is offline: %v", e.url, err)
+	}
+}
+
+// s3EndpointTransport - marks its endpoint offline as soon as a
+// request to it fails at the network level, so that the following
+// requests fail over to the other endpoints.
+type s3EndpointTransport struct {
+	endpoint  *s3Endpoint
+	transport http.RoundTripper
+}
+
+func (t *s3EndpointTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil && req.Context().Err() == nil {
+		t.endpoint.setOffline(err)
+		// Fail fast instead of letting the client retry
+		// the request against an unreachable endpoint.
+		return nil, fmt.Errorf("S3 endpoint %s is offline", t.endpoint.url)
+	}
+	return resp, err
+}
+
+// s3Pool - upstream S3 endpoints holding the same data, for
+// instance a replicated pair of S3 compatible stores. Writes are
+// sent to the first online endpoint in the order they were given,
+// reads are balanced across all online endpoints.
+type s3Pool struct {
+	endpoints []*s3Endpoint
+	next      uint32
+}
+
+// newS3Pool - initializes a client for every upstream endpoint, at
+// least one of them has to be reachable.
+func newS3Pool(urls []string, transport http.RoundTripper) (*s3Pool, error) {
+	p := &s3Pool{}
+	for _, u := range urls {
+		endpoint := &s3Endpoint{url: u}
+		endpointTransport := transport
+		if len(urls) > 1 {
+			endpointTransport = &s3EndpointTransport{
+				endpoint:  endpoint,
+				transport: transport,
+			}
+		}
+		clnt, err := newS3Client(u, endpointTransport)
+		if err != nil {
+			return nil, err
+		}
+		endpoint.client = clnt
+		p.endpoints = append(p.endpoints, endpoint)
+	}
+
+	var lastErr error
+	for _, endpoint := range p.endpoints {
+		if lastErr = probeS3(endpoint.client.Core); lastErr == nil {
+			atomic.StoreInt32(&endpoint.online, 1)
+		}
+	}
+	if len(p.endpoints) == 1 && lastErr != nil {
+		return nil, lastErr
+	}
+	for _, endpoint := range p.endpoints {
+		if endpoint.isOnline() {
+			return p, nil
+		}
+	}
+	return nil, errNoUpstreamOnline
+}
+
+// writeClient - returns the client of the first online endpoint.
+func (p *s3Pool) writeClient() *s3Client {
+	for _, endpoint := range p.endpoints {
+		if endpoint.isOnline() {
+			return endpoint.client
+		}
+	}
+	// No endpoint is online, let the request
+	// fail against the first one.
+	return p.endpoints[0].client
+}
+
+// readClient - returns the client of the next online endpoint.
+func (p *s3Pool) readClient() *s3Client {
+	n := uint32(len(p.endpoints))
+	start := atomic.AddUint32(&p.next, 1)
+	for i := uint32(0); i < n; i++ {
+		if endpoint := p.endpoints[(start+i)%n]; endpoint.isOnline() {
+			return endpoint.client
+		}
+	}
+	return p.endpoints[0].client
+}
+
+// healthCheck - probes all endpoints and updates their status.
+func (p *s3Pool) healthCheck() {
+	for _, endpoint := range p.endpoints {
+		if err := probeS3(endpoint.client.Core); err != nil {
+			endpoint.setOffline(err)
+		} else {
+			endpoint.setOnline()
+		}
+	}
+}
+
+// monitor - periodically checks the health of all endpoints,
+// bringing back endpoints after an outage.
+func (p *s3Pool) monitor(ctx context.Context, interval time.Duration, doneCh <-chan struct{}) {
+	if len(p.endpoints) == 1 {
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-doneCh:
+			return
+		case <-ticker.C:
+			p.healthCheck()
+		}
+	}
+}
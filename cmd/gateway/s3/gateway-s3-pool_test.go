@@ -0,0 +1,89 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+)
+
+func newTestS3Server() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.URL.Query()["location"]; ok {
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+		}
+	}))
+}
+
+func TestS3Pool(t *testing.T) {
+	server1 := newTestS3Server()
+	defer server1.Close()
+	server2 := newTestS3Server()
+	defer server2.Close()
+	offline := newTestS3Server()
+	offline.Close()
+
+	pool, err := newS3Pool([]string{offline.URL, server1.URL, server2.URL}, minio.NewCustomHTTPTransport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if pool.endpoints[0].isOnline() || !pool.endpoints[1].isOnline() || !pool.endpoints[2].isOnline() {
+		t.Fatal("expected only the reachable endpoints to be online")
+	}
+
+	// Writes go to the first online endpoint.
+	if clnt := pool.writeClient(); clnt != pool.endpoints[1].client {
+		t.Fatalf("expected writes to be sent to %s", server1.URL)
+	}
+
+	// Reads are balanced across online endpoints.
+	seen := make(map[interface{}]bool)
+	for i := 0; i < 4; i++ {
+		clnt := pool.readClient()
+		if clnt == pool.endpoints[0].client {
+			t.Fatal("expected reads not to be sent to an offline endpoint")
+		}
+		seen[clnt] = true
+	}
+	if len(seen) != 2 {
+		t.Fatalf("expected reads to be sent to 2 endpoints, got %d", len(seen))
+	}
+
+	// A network failure fails over writes to the next endpoint.
+	server1.Close()
+	pool.writeClient().ListBuckets()
+	if pool.endpoints[1].isOnline() {
+		t.Fatal("expected endpoint to be offline after a network failure")
+	}
+	if clnt := pool.writeClient(); clnt != pool.endpoints[2].client {
+		t.Fatalf("expected writes to fail over to %s", server2.URL)
+	}
+
+	// Health checks bring back reachable endpoints.
+	pool.endpoints[2].setOffline(errNoUpstreamOnline)
+	pool.healthCheck()
+	if !pool.endpoints[2].isOnline() || pool.endpoints[1].isOnline() {
+		t.Fatal("expected health check to only bring back reachable endpoints")
+	}
+
+	if _, err = newS3Pool([]string{offline.URL, server1.URL}, minio.NewCustomHTTPTransport()); err != errNoUpstreamOnline {
+		t.Fatalf("expected %v, got %v", errNoUpstreamOnline, err)
+	}
+}
@@ -743,7 +743,7 @@ func (l *s3EncObjects) getStalePartsForBucket(ctx context.Context, bucket string
 	return
 }
 
-func (l *s3EncObjects) DeleteBucket(ctx context.Context, bucket string) error {
+func (l *s3EncObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	var prefix, continuationToken, delimiter, startAfter string
 	expParts := make(map[string]string)
 
@@ -773,7 +773,7 @@ func (l *s3EncObjects) DeleteBucket(ctx context.Context, bucket string) error {
 	for k := range expParts {
 		l.s3Objects.DeleteObject(ctx, bucket, k)
 	}
-	err := l.Client.RemoveBucket(bucket)
+	err := l.pool.writeClient().RemoveBucket(bucket)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -0,0 +1,196 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"crypto/md5"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strings"
+
+	miniogo "github.com/minio/minio-go/v6"
+	"github.com/minio/minio-go/v6/pkg/s3signer"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	minio "github.com/minio/minio/cmd"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+// s3Tagging - tag set of an object, as sent and received
+// by the object tagging API.
+type s3Tagging struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Tagging"`
+	TagSet  []s3Tag  `xml:"TagSet>Tag"`
+}
+
+type s3Tag struct {
+	Key   string `xml:"Key"`
+	Value string `xml:"Value"`
+}
+
+// toS3Metadata - translates the metadata of a write into the metadata
+// sent to the upstream, the canned ACL kept by the handlers becomes the
+// x-amz-acl header and the tag set is returned separately since
+// minio-go sends any unknown header as user metadata.
+func toS3Metadata(userDefined map[string]string) (metadata map[string]string, tags string) {
+	metadata = make(map[string]string, len(userDefined))
+	for k, v := range userDefined {
+		switch http.CanonicalHeaderKey(k) {
+		case http.CanonicalHeaderKey(minio.ObjectTagsKey):
+			tags = v
+		case http.CanonicalHeaderKey(minio.ObjectACLKey):
+			metadata[xhttp.AmzACL] = v
+		case http.CanonicalHeaderKey(xhttp.AmzTagCount):
+			// Reported by the upstream, never sent back.
+		default:
+			metadata[k] = v
+		}
+	}
+	return metadata, tags
+}
+
+// targetURL - returns the path style URL of an object, AWS S3 buckets
+// outside us-east-1 are addressed through their regional endpoint.
+func (c *s3Client) targetURL(bucket, object, location string, query url.Values) *url.URL {
+	u := *c.EndpointURL()
+	if u.Host == "s3.amazonaws.com" && location != "" && location != "us-east-1" {
+		u.Host = "s3." + location + ".amazonaws.com"
+		if strings.HasPrefix(location, "cn-") {
+			u.Host += ".cn"
+		}
+	}
+	u.Path = "/" + bucket + "/" + object
+	u.RawPath = "/" + bucket + "/" + s3utils.EncodePath(object)
+	u.RawQuery = s3utils.QueryEncode(query)
+	return &u
+}
+
+// execute - sends a signed request to the upstream, used for the
+// requests minio-go does not implement. A response other than 200 OK
+// is returned as a miniogo.ErrorResponse.
+func (c *s3Client) execute(ctx context.Context, method, bucket, object string, query url.Values, header http.Header, body []byte) (*http.Response, error) {
+	location, err := c.GetBucketLocation(bucket)
+	if err != nil {
+		return nil, err
+	}
+	creds, err := c.creds.Get()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(method, c.targetURL(bucket, object, location, query).String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	sum := sha256.Sum256(body)
+	req.Header.Set(xhttp.AmzContentSha256, hex.EncodeToString(sum[:]))
+	if len(body) > 0 {
+		md5Sum := md5.Sum(body)
+		req.Header.Set(xhttp.ContentMD5, base64.StdEncoding.EncodeToString(md5Sum[:]))
+	}
+	req.ContentLength = int64(len(body))
+	req = s3signer.SignV4(*req, creds.AccessKeyID, creds.SecretAccessKey, creds.SessionToken, location)
+
+	resp, err := (&http.Client{Transport: c.transport}).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		errResp := miniogo.ErrorResponse{}
+		if xml.NewDecoder(resp.Body).Decode(&errResp) != nil {
+			errResp.Code = http.StatusText(resp.StatusCode)
+			errResp.Message = http.StatusText(resp.StatusCode)
+		}
+		errResp.StatusCode = resp.StatusCode
+		errResp.BucketName = bucket
+		errResp.Key = object
+		return nil, errResp
+	}
+	return resp, nil
+}
+
+// getObjectTags - returns the URL encoded tag set of an object.
+func (c *s3Client) getObjectTags(ctx context.Context, bucket, object string) (string, error) {
+	resp, err := c.execute(ctx, http.MethodGet, bucket, object, url.Values{"tagging": {""}}, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var tagging s3Tagging
+	if err = xml.NewDecoder(resp.Body).Decode(&tagging); err != nil {
+		return "", err
+	}
+	tags := make(url.Values, len(tagging.TagSet))
+	for _, tag := range tagging.TagSet {
+		tags.Set(tag.Key, tag.Value)
+	}
+	return tags.Encode(), nil
+}
+
+// putObjectTags - replaces the tag set of an object with the
+// URL encoded tags.
+func (c *s3Client) putObjectTags(ctx context.Context, bucket, object, tags string) error {
+	values, err := url.ParseQuery(tags)
+	if err != nil {
+		return err
+	}
+	var tagging s3Tagging
+	for k := range values {
+		tagging.TagSet = append(tagging.TagSet, s3Tag{Key: k, Value: values.Get(k)})
+	}
+	body, err := xml.Marshal(tagging)
+	if err != nil {
+		return err
+	}
+	resp, err := c.execute(ctx, http.MethodPut, bucket, object, url.Values{"tagging": {""}}, nil, body)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+// newMultipartUploadWithTags - initiates a multipart upload of an object
+// tagged with the URL encoded tags once the upload is completed.
+func (c *s3Client) newMultipartUploadWithTags(ctx context.Context, bucket, object string, opts miniogo.PutObjectOptions, tags string) (string, error) {
+	header := opts.Header()
+	header.Set(xhttp.AmzObjectTagging, tags)
+	resp, err := c.execute(ctx, http.MethodPost, bucket, object, url.Values{"uploads": {""}}, header, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err = xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", err
+	}
+	return result.UploadID, nil
+}
@@ -0,0 +1,145 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+
+package s3
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/hash"
+)
+
+// newTestS3TaggingServer - returns an upstream recording the headers
+// and the tag set of a single object.
+func newTestS3TaggingServer() (*httptest.Server, func() (http.Header, string)) {
+	var mu sync.Mutex
+	var header http.Header
+	var tagging string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		query := r.URL.Query()
+		switch {
+		case query["location"] != nil:
+			w.Write([]byte(`<LocationConstraint xmlns="http://s3.amazonaws.com/doc/2006-03-01/">us-east-1</LocationConstraint>`))
+		case query["tagging"] != nil && r.Method == http.MethodPut:
+			body, _ := ioutil.ReadAll(r.Body)
+			tagging = string(body)
+		case query["tagging"] != nil:
+			w.Write([]byte(tagging))
+		case query["uploads"] != nil:
+			header = r.Header
+			w.Write([]byte(`<InitiateMultipartUploadResult><UploadId>upload-id</UploadId></InitiateMultipartUploadResult>`))
+		case r.Method == http.MethodPut && r.Header.Get(xhttp.AmzCopySource) != "":
+			header = r.Header
+			w.Write([]byte(`<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`))
+		case r.Method == http.MethodPut:
+			header = r.Header
+			w.Header().Set(xhttp.ETag, `"etag"`)
+		case r.Method == http.MethodHead:
+			w.Header().Set(xhttp.ETag, `"etag"`)
+			w.Header().Set(xhttp.LastModified, "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Header().Set(xhttp.AmzStorageClass, "STANDARD_IA")
+			if tagging != "" {
+				w.Header().Set(xhttp.AmzTagCount, "1")
+			}
+		}
+	}))
+	return server, func() (http.Header, string) {
+		mu.Lock()
+		defer mu.Unlock()
+		return header, tagging
+	}
+}
+
+func TestS3ObjectTaggingAndACL(t *testing.T) {
+	server, recorded := newTestS3TaggingServer()
+	defer server.Close()
+
+	pool, err := newS3Pool([]string{server.URL}, minio.NewCustomHTTPTransport())
+	if err != nil {
+		t.Fatal(err)
+	}
+	l := &s3Objects{pool: pool}
+	ctx := context.Background()
+
+	data := []byte("hello")
+	reader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	opts := minio.ObjectOptions{UserDefined: map[string]string{
+		"content-type":         "text/plain",
+		xhttp.AmzStorageClass:  "STANDARD_IA",
+		minio.ObjectTagsKey:    "project=blue",
+		minio.ObjectACLKey:     "public-read",
+		"X-Amz-Meta-Something": "value",
+	}}
+	if _, err = l.PutObject(ctx, "bucket", "object", minio.NewPutObjReader(reader, nil, nil), opts); err != nil {
+		t.Fatal(err)
+	}
+	header, tagging := recorded()
+	if header.Get(xhttp.AmzACL) != "public-read" || header.Get(xhttp.AmzStorageClass) != "STANDARD_IA" || header.Get("X-Amz-Meta-Something") != "value" {
+		t.Fatalf("unexpected upstream headers %v", header)
+	}
+	for k := range header {
+		if strings.Contains(k, "Internal") {
+			t.Fatalf("unexpected internal metadata %s sent upstream", k)
+		}
+	}
+	if !strings.Contains(tagging, "<Key>project</Key><Value>blue</Value>") {
+		t.Fatalf("unexpected upstream tag set %s", tagging)
+	}
+
+	// The tag set and the storage class are reported back.
+	objInfo, err := l.GetObjectInfo(ctx, "bucket", "object", minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.UserDefined[minio.ObjectTagsKey] != "project=blue" || objInfo.StorageClass != "STANDARD_IA" {
+		t.Fatalf("unexpected object info %#v", objInfo)
+	}
+
+	// Copies always replace the tag set with the resolved one.
+	objInfo.UserDefined[minio.ObjectACLKey] = "private"
+	if _, err = l.CopyObject(ctx, "bucket", "object", "bucket", "copy", objInfo, minio.ObjectOptions{}, minio.ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	header, _ = recorded()
+	if header.Get(xhttp.AmzTagDirective) != "REPLACE" || header.Get(xhttp.AmzObjectTagging) != "project=blue" || header.Get(xhttp.AmzACL) != "private" {
+		t.Fatalf("unexpected upstream copy headers %v", header)
+	}
+
+	// Multipart uploads are tagged when initiated.
+	uploadID, err := l.NewMultipartUpload(ctx, "bucket", "object", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header, _ = recorded()
+	if uploadID != "upload-id" || header.Get(xhttp.AmzObjectTagging) != "project=blue" || header.Get(xhttp.AmzACL) != "public-read" {
+		t.Fatalf("unexpected upstream multipart headers %v", header)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v6/pkg/credentials"
+	"github.com/minio/minio/pkg/env"
+)
+
+const (
+	// Environment variables describing the role to assume with a
+	// web identity token, these follow the AWS SDK conventions.
+	envAWSWebIdentityTokenFile = "AWS_WEB_IDENTITY_TOKEN_FILE"
+	envAWSRoleARN              = "AWS_ROLE_ARN"
+	envAWSRoleSessionName      = "AWS_ROLE_SESSION_NAME"
+
+	// Overrides the STS endpoint used to assume the role.
+	envGatewayS3STSEndpoint = "MINIO_GATEWAY_S3_STS_ENDPOINT"
+
+	defaultSTSEndpoint     = "https://sts.amazonaws.com"
+	defaultRoleSessionName = "minio-gateway-s3"
+)
+
+// webIdentity is a credentials.Provider assuming an AWS IAM role
+// with the web identity token found in AWS_WEB_IDENTITY_TOKEN_FILE,
+// as set up by EKS for service accounts. The token file is read again
+// on every refresh, since it is rotated by the orchestrator.
+type webIdentity struct {
+	credentials.Expiry
+
+	Client *http.Client
+
+	// When empty, these are read from the environment.
+	stsEndpoint string
+	tokenFile   string
+	roleARN     string
+	sessionName string
+}
+
+// Retrieve assumes the configured role, returns an error when
+// no web identity token is configured.
+func (w *webIdentity) Retrieve() (credentials.Value, error) {
+	tokenFile := w.tokenFile
+	if tokenFile == "" {
+		tokenFile = env.Get(envAWSWebIdentityTokenFile, "")
+	}
+	roleARN := w.roleARN
+	if roleARN == "" {
+		roleARN = env.Get(envAWSRoleARN, "")
+	}
+	if tokenFile == "" || roleARN == "" {
+		return credentials.Value{}, errors.New("web identity token file or role ARN not configured")
+	}
+	sessionName := w.sessionName
+	if sessionName == "" {
+		sessionName = env.Get(envAWSRoleSessionName, defaultRoleSessionName)
+	}
+	stsEndpoint := w.stsEndpoint
+	if stsEndpoint == "" {
+		stsEndpoint = env.Get(envGatewayS3STSEndpoint, defaultSTSEndpoint)
+	}
+
+	token, err := ioutil.ReadFile(tokenFile)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	u, err := url.Parse(stsEndpoint)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+
+	v := url.Values{}
+	v.Set("Action", "AssumeRoleWithWebIdentity")
+	v.Set("RoleArn", roleARN)
+	v.Set("RoleSessionName", sessionName)
+	v.Set("WebIdentityToken", strings.TrimSpace(string(token)))
+	v.Set("Version", "2011-06-15")
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), strings.NewReader(v.Encode()))
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return credentials.Value{}, fmt.Errorf("AssumeRoleWithWebIdentity failed: %s", resp.Status)
+	}
+
+	var a credentials.AssumeRoleWithWebIdentityResponse
+	if err = xml.NewDecoder(resp.Body).Decode(&a); err != nil {
+		return credentials.Value{}, err
+	}
+
+	// Refresh a little before the credentials actually expire.
+	w.SetExpiration(a.Result.Credentials.Expiration, credentials.DefaultExpiryWindow)
+
+	return credentials.Value{
+		AccessKeyID:     a.Result.Credentials.AccessKey,
+		SecretAccessKey: a.Result.Credentials.SecretKey,
+		SessionToken:    a.Result.Credentials.SessionToken,
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
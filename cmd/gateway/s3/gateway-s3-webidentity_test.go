@@ -0,0 +1,93 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package s3
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWebIdentity(t *testing.T) {
+	dir, err := ioutil.TempDir("", "webidentity")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	tokenFile := filepath.Join(dir, "token")
+	if err = ioutil.WriteFile(tokenFile, []byte("token1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var expiration time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("Action") != "AssumeRoleWithWebIdentity" || r.FormValue("RoleArn") != "arn:aws:iam::123456789012:role/minio" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		fmt.Fprintf(w, `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleWithWebIdentityResult><Credentials>
+<AccessKeyId>access-%[1]s</AccessKeyId><SecretAccessKey>secret-%[1]s</SecretAccessKey>
+<SessionToken>session-%[1]s</SessionToken><Expiration>%[2]s</Expiration>
+</Credentials></AssumeRoleWithWebIdentityResult></AssumeRoleWithWebIdentityResponse>`,
+			r.FormValue("WebIdentityToken"), expiration.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	// Nothing is returned unless a token and a role are configured.
+	w := &webIdentity{stsEndpoint: server.URL, tokenFile: tokenFile}
+	if _, err = w.Retrieve(); err == nil {
+		t.Fatal("expected an error without a role ARN")
+	}
+
+	w.roleARN = "arn:aws:iam::123456789012:role/minio"
+	expiration = time.Now().Add(time.Hour).UTC()
+	v, err := w.Retrieve()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKeyID != "access-token1" || v.SecretAccessKey != "secret-token1" || v.SessionToken != "session-token1" {
+		t.Fatalf("unexpected credentials %#v", v)
+	}
+	if w.IsExpired() {
+		t.Fatal("expected credentials not to be expired")
+	}
+
+	// Credentials about to expire are refreshed with the rotated token.
+	if err = ioutil.WriteFile(tokenFile, []byte("token2"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	expiration = time.Now().Add(time.Second).UTC()
+	if _, err = w.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if !w.IsExpired() {
+		t.Fatal("expected credentials within the expiry window to be expired")
+	}
+	if v, err = w.Retrieve(); err != nil {
+		t.Fatal(err)
+	}
+	if v.AccessKeyID != "access-token2" {
+		t.Fatalf("expected credentials from the rotated token, got %#v", v)
+	}
+}
@@ -33,8 +33,11 @@ import (
 
 	"github.com/minio/minio-go/v6/pkg/encrypt"
 	"github.com/minio/minio-go/v6/pkg/s3utils"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/env"
+	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/policy"
 )
 
@@ -47,19 +50,27 @@ func init() {
   {{.HelpName}} - {{.Usage}}
 
 USAGE:
-  {{.HelpName}} {{if .VisibleFlags}}[FLAGS]{{end}} [ENDPOINT]
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS]{{end}} [ENDPOINT...]
 {{if .VisibleFlags}}
 FLAGS:
   {{range .VisibleFlags}}{{.}}
   {{end}}{{end}}
 ENDPOINT:
-  S3 server endpoint. Default ENDPOINT is https://s3.amazonaws.com
+  S3 server endpoint. Default ENDPOINT is https://s3.amazonaws.com. Multiple endpoints
+  of replicated S3 servers may be given, writes are sent to the first online endpoint
+  and reads are balanced across all online endpoints.
 
 ENVIRONMENT VARIABLES:
   ACCESS:
      MINIO_ACCESS_KEY: Username or access key of S3 storage.
      MINIO_SECRET_KEY: Password or secret key of S3 storage.
 
+  AWS ROLE:
+     AWS_ROLE_ARN: IAM role assumed with the web identity token to access AWS S3.
+     AWS_WEB_IDENTITY_TOKEN_FILE: Path to the web identity token, re-read on every credentials refresh.
+     AWS_ROLE_SESSION_NAME: Session name of the assumed role, defaults to "minio-gateway-s3".
+     MINIO_GATEWAY_S3_STS_ENDPOINT: STS endpoint used to assume the role, defaults to "https://sts.amazonaws.com".
+
   BROWSER:
      MINIO_BROWSER: To disable web browser access, set this value to "off".
 
@@ -75,6 +86,9 @@ ENVIRONMENT VARIABLES:
   LOGGER:
      MINIO_LOGGER_HTTP_ENDPOINT: HTTP endpoint URL to log all incoming requests.
 
+  NOTIFICATIONS:
+     MINIO_GATEWAY_S3_EVENTS_BUCKETS: List of buckets delimited by ",", whose events on an upstream MinIO server are published to all notification targets.
+
 EXAMPLES:
   1. Start minio gateway server for AWS S3 backend.
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
@@ -109,6 +123,26 @@ EXAMPLES:
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
      {{.Prompt}} {{.HelpName}}
+
+  5. Start minio gateway server for AWS S3 backend assuming an IAM role with a web identity token.
+     {{.Prompt}} {{.EnvVarSetCommand}} AWS_ROLE_ARN{{.AssignmentOperator}}arn:aws:iam::123456789012:role/minio-gateway
+     {{.Prompt}} {{.EnvVarSetCommand}} AWS_WEB_IDENTITY_TOKEN_FILE{{.AssignmentOperator}}/var/run/secrets/eks.amazonaws.com/serviceaccount/token
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.HelpName}}
+
+  6. Start minio gateway server in front of a replicated pair of S3 compatible servers.
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.HelpName}} https://s3-site1.example.com:9000 https://s3-site2.example.com:9000
+
+  7. Start minio gateway server for a MinIO backend sending the events of two buckets to a webhook.
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_NOTIFY_WEBHOOK_ENABLE{{.AssignmentOperator}}on
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_NOTIFY_WEBHOOK_ENDPOINT{{.AssignmentOperator}}http://localhost:3000/events
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_GATEWAY_S3_EVENTS_BUCKETS{{.AssignmentOperator}}"photos,videos"
+     {{.Prompt}} {{.HelpName}} https://minio.example.com:9000
 `
 
 	minio.RegisterGatewayCommand(cli.Command{
@@ -128,15 +162,23 @@ func s3GatewayMain(ctx *cli.Context) {
 	}
 
 	// Validate gateway arguments.
-	logger.FatalIf(minio.ValidateGatewayArguments(ctx.GlobalString("address"), args.First()), "Invalid argument")
+	for _, arg := range args {
+		logger.FatalIf(minio.ValidateGatewayArguments(ctx.GlobalString("address"), arg), "Invalid argument")
+	}
+
+	var eventBuckets []string
+	if v := env.Get("MINIO_GATEWAY_S3_EVENTS_BUCKETS", ""); v != "" {
+		eventBuckets = strings.Split(v, ",")
+	}
 
 	// Start the gateway..
-	minio.StartGateway(ctx, &S3{args.First()})
+	minio.StartGateway(ctx, &S3{args, eventBuckets})
 }
 
 // S3 implements Gateway.
 type S3 struct {
-	host string
+	hosts        []string
+	eventBuckets []string
 }
 
 // Name implements Gateway interface.
@@ -182,12 +224,20 @@ var defaultProviders = []credentials.Provider{
 // Chains all credential types, in the following order:
 //  - AWS env vars (i.e. AWS_ACCESS_KEY_ID)
 //  - AWS creds file (i.e. AWS_SHARED_CREDENTIALS_FILE or ~/.aws/credentials)
+//  - Web identity credentials. (assumes AWS_ROLE_ARN with the
+//    token in AWS_WEB_IDENTITY_TOKEN_FILE, i.e. EKS service accounts)
 //  - IAM profile based credentials. (performs an HTTP
 //    call to a pre-defined endpoint, only valid inside
-//    configured ec2 instances)
+//    configured ec2 instances or ecs tasks)
+//  - Static credentials provided by user (i.e. MINIO_ACCESS_KEY)
 var defaultAWSCredProviders = []credentials.Provider{
 	&credentials.EnvAWS{},
 	&credentials.FileAWSCredentials{},
+	&webIdentity{
+		Client: &http.Client{
+			Transport: minio.NewCustomHTTPTransport(),
+		},
+	},
 	&credentials.IAM{
 		Client: &http.Client{
 			Transport: minio.NewCustomHTTPTransport(),
@@ -196,8 +246,16 @@ var defaultAWSCredProviders = []credentials.Provider{
 	&credentials.EnvMinio{},
 }
 
-// newS3 - Initializes a new client by auto probing S3 server signature.
-func newS3(urlStr string) (*miniogo.Core, error) {
+// s3Client - client of an upstream S3 server, keeping its credentials
+// and transport around for the requests minio-go does not implement.
+type s3Client struct {
+	*miniogo.Core
+	creds     *credentials.Credentials
+	transport http.RoundTripper
+}
+
+// newS3Client - Initializes a new client for the S3 server at urlStr.
+func newS3Client(urlStr string, transport http.RoundTripper) (*s3Client, error) {
 	if urlStr == "" {
 		urlStr = "https://s3.amazonaws.com"
 	}
@@ -236,32 +294,50 @@ func newS3(urlStr string) (*miniogo.Core, error) {
 	}
 
 	// Set custom transport
-	clnt.SetCustomTransport(minio.NewCustomHTTPTransport())
+	clnt.SetCustomTransport(transport)
 
+	return &s3Client{
+		Core:      &miniogo.Core{Client: clnt},
+		creds:     creds,
+		transport: transport,
+	}, nil
+}
+
+// probeS3 - checks if the S3 server is reachable by auto probing
+// its signature with the provided keys.
+func probeS3(clnt *miniogo.Core) error {
 	probeBucketName := randString(60, rand.NewSource(time.Now().UnixNano()), "probe-bucket-sign-")
 
 	// Check if the provided keys are valid.
-	if _, err = clnt.BucketExists(probeBucketName); err != nil {
+	if _, err := clnt.BucketExists(probeBucketName); err != nil {
 		if miniogo.ToErrorResponse(err).Code != "AccessDenied" {
-			return nil, err
+			return err
 		}
 	}
-
-	return &miniogo.Core{Client: clnt}, nil
+	return nil
 }
 
 // NewGatewayLayer returns s3 ObjectLayer.
 func (g *S3) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
 	// creds are ignored here, since S3 gateway implements chaining
 	// all credentials.
-	clnt, err := newS3(g.host)
+	pool, err := newS3Pool(g.hosts, minio.NewCustomHTTPTransport())
 	if err != nil {
 		return nil, err
 	}
 
+	// Bring back upstream endpoints after an outage.
+	go pool.monitor(context.Background(), s3HealthCheckInterval, minio.GlobalServiceDoneCh)
+
 	s := s3Objects{
-		Client: clnt,
+		pool: pool,
+	}
+
+	// Republish the events of the upstream buckets.
+	for _, bucket := range g.eventBuckets {
+		go s.listenEvents(bucket, minio.GlobalServiceDoneCh)
 	}
+
 	// Enables single encyption of KMS is configured.
 	if minio.GlobalKMS != nil {
 		encS := s3EncObjects{s}
@@ -283,7 +359,7 @@ func (g *S3) Production() bool {
 // s3Objects implements gateway for MinIO and S3 compatible object storage servers.
 type s3Objects struct {
 	minio.GatewayUnsupported
-	Client *miniogo.Core
+	pool *s3Pool
 }
 
 // Shutdown saves any gateway metadata to disk
@@ -310,7 +386,7 @@ func (l *s3Objects) MakeBucketWithLocation(ctx context.Context, bucket, location
 		return minio.BucketNameInvalid{Bucket: bucket}
 	}
 
-	err := l.Client.MakeBucket(bucket, location)
+	err := l.pool.writeClient().MakeBucket(bucket, location)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -319,12 +395,13 @@ func (l *s3Objects) MakeBucketWithLocation(ctx context.Context, bucket, location
 
 // GetBucketInfo gets bucket metadata..
 func (l *s3Objects) GetBucketInfo(ctx context.Context, bucket string) (bi minio.BucketInfo, e error) {
-	buckets, err := l.Client.ListBuckets()
+	clnt := l.pool.readClient()
+	buckets, err := clnt.ListBuckets()
 	if err != nil {
 		// Listbuckets may be disallowed, proceed to check if
 		// bucket indeed exists, if yes return success.
 		var ok bool
-		if ok, err = l.Client.BucketExists(bucket); err != nil {
+		if ok, err = clnt.BucketExists(bucket); err != nil {
 			return bi, minio.ErrorRespToObjectError(err, bucket)
 		}
 		if !ok {
@@ -352,7 +429,7 @@ func (l *s3Objects) GetBucketInfo(ctx context.Context, bucket string) (bi minio.
 
 // ListBuckets lists all S3 buckets
 func (l *s3Objects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error) {
-	buckets, err := l.Client.ListBuckets()
+	buckets, err := l.pool.readClient().ListBuckets()
 	if err != nil {
 		return nil, minio.ErrorRespToObjectError(err)
 	}
@@ -369,8 +446,8 @@ func (l *s3Objects) ListBuckets(ctx context.Context) ([]minio.BucketInfo, error)
 }
 
 // DeleteBucket deletes a bucket on S3
-func (l *s3Objects) DeleteBucket(ctx context.Context, bucket string) error {
-	err := l.Client.RemoveBucket(bucket)
+func (l *s3Objects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	err := l.pool.writeClient().RemoveBucket(bucket)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -379,7 +456,7 @@ func (l *s3Objects) DeleteBucket(ctx context.Context, bucket string) error {
 
 // ListObjects lists all blobs in S3 bucket filtered by prefix
 func (l *s3Objects) ListObjects(ctx context.Context, bucket string, prefix string, marker string, delimiter string, maxKeys int) (loi minio.ListObjectsInfo, e error) {
-	result, err := l.Client.ListObjects(bucket, prefix, marker, delimiter, maxKeys)
+	result, err := l.pool.readClient().ListObjects(bucket, prefix, marker, delimiter, maxKeys)
 	if err != nil {
 		return loi, minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -390,7 +467,7 @@ func (l *s3Objects) ListObjects(ctx context.Context, bucket string, prefix strin
 // ListObjectsV2 lists all blobs in S3 bucket filtered by prefix
 func (l *s3Objects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (loi minio.ListObjectsV2Info, e error) {
 
-	result, err := l.Client.ListObjectsV2(bucket, prefix, continuationToken, fetchOwner, delimiter, maxKeys, startAfter)
+	result, err := l.pool.readClient().ListObjectsV2(bucket, prefix, continuationToken, fetchOwner, delimiter, maxKeys, startAfter)
 	if err != nil {
 		return loi, minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -442,7 +519,7 @@ func (l *s3Objects) GetObject(ctx context.Context, bucket string, key string, st
 			return minio.ErrorRespToObjectError(err, bucket, key)
 		}
 	}
-	object, _, _, err := l.Client.GetObject(bucket, key, opts)
+	object, _, _, err := l.pool.readClient().GetObject(bucket, key, opts)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, key)
 	}
@@ -455,7 +532,8 @@ func (l *s3Objects) GetObject(ctx context.Context, bucket string, key string, st
 
 // GetObjectInfo reads object info and replies back ObjectInfo
 func (l *s3Objects) GetObjectInfo(ctx context.Context, bucket string, object string, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
-	oi, err := l.Client.StatObject(bucket, object, miniogo.StatObjectOptions{
+	clnt := l.pool.readClient()
+	oi, err := clnt.StatObject(bucket, object, miniogo.StatObjectOptions{
 		GetObjectOptions: miniogo.GetObjectOptions{
 			ServerSideEncryption: opts.ServerSideEncryption,
 		},
@@ -464,16 +542,38 @@ func (l *s3Objects) GetObjectInfo(ctx context.Context, bucket string, object str
 		return minio.ObjectInfo{}, minio.ErrorRespToObjectError(err, bucket, object)
 	}
 
-	return minio.FromMinioClientObjectInfo(bucket, oi), nil
+	objInfo = minio.FromMinioClientObjectInfo(bucket, oi)
+
+	// Only the number of tags is reported by HEAD, fetch
+	// the tag set of tagged objects.
+	if count := oi.Metadata.Get(xhttp.AmzTagCount); count != "" && count != "0" {
+		tags, err := clnt.getObjectTags(ctx, bucket, object)
+		if err != nil {
+			return minio.ObjectInfo{}, minio.ErrorRespToObjectError(err, bucket, object)
+		}
+		objInfo.UserDefined[minio.ObjectTagsKey] = tags
+	}
+	return objInfo, nil
 }
 
 // PutObject creates a new object with the incoming data,
 func (l *s3Objects) PutObject(ctx context.Context, bucket string, object string, r *minio.PutObjReader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
 	data := r.Reader
-	oi, err := l.Client.PutObject(bucket, object, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), minio.ToMinioClientMetadata(opts.UserDefined), opts.ServerSideEncryption)
+	if data.Size() > minio.GatewayMaxSinglePutSize {
+		return l.putObjectMultipart(ctx, bucket, object, data, opts)
+	}
+	clnt := l.pool.writeClient()
+	metadata, tags := toS3Metadata(opts.UserDefined)
+	oi, err := clnt.PutObject(bucket, object, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), minio.ToMinioClientMetadata(metadata), opts.ServerSideEncryption)
 	if err != nil {
 		return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
 	}
+	// minio-go cannot send x-amz-tagging with the object.
+	if tags != "" {
+		if err = clnt.putObjectTags(ctx, bucket, object, tags); err != nil {
+			return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
+		}
+	}
 	// On success, populate the key & metadata so they are present in the notification
 	oi.Key = object
 	oi.Metadata = minio.ToMinioClientObjectInfoMetadata(opts.UserDefined)
@@ -481,6 +581,57 @@ func (l *s3Objects) PutObject(ctx context.Context, bucket string, object string,
 	return minio.FromMinioClientObjectInfo(bucket, oi), nil
 }
 
+// putObjectMultipart uploads objects larger than what the upstream accepts
+// in a single PUT as a multipart upload, the upload is aborted on failure
+// such that no partial object or dangling parts are left behind.
+func (l *s3Objects) putObjectMultipart(ctx context.Context, bucket string, object string, data *hash.Reader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	// All parts of the upload have to be sent to the same endpoint.
+	clnt := l.pool.writeClient()
+	uploadID, err := newS3MultipartUpload(ctx, clnt, bucket, object, opts)
+	if err != nil {
+		return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
+	}
+	defer func() {
+		if err != nil {
+			logger.LogIf(ctx, clnt.AbortMultipartUpload(bucket, object, uploadID))
+		}
+	}()
+
+	partSize := minio.GatewayPartSize(data.Size())
+	var parts []miniogo.CompletePart
+	for partID, remaining := 1, data.Size(); remaining > 0; partID++ {
+		if remaining < partSize {
+			partSize = remaining
+		}
+		var pi miniogo.ObjectPart
+		pi, err = clnt.PutObjectPart(bucket, object, uploadID, partID, io.LimitReader(data, partSize), partSize, "", "", opts.ServerSideEncryption)
+		if err != nil {
+			return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
+		}
+		parts = append(parts, miniogo.CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+		remaining -= partSize
+	}
+
+	// Parts are uploaded without individual checksums, verify the
+	// client provided checksums for the whole object before committing.
+	if err = data.Verify(); err != nil {
+		return objInfo, err
+	}
+
+	if _, err = clnt.CompleteMultipartUpload(bucket, object, uploadID, parts); err != nil {
+		return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
+	}
+	oi, err := clnt.StatObject(bucket, object, miniogo.StatObjectOptions{
+		GetObjectOptions: miniogo.GetObjectOptions{
+			ServerSideEncryption: opts.ServerSideEncryption,
+		},
+	})
+	if err != nil {
+		return objInfo, minio.ErrorRespToObjectError(err, bucket, object)
+	}
+	return minio.FromMinioClientObjectInfo(bucket, oi), nil
+}
+
 // CopyObject copies an object from source bucket to a destination bucket.
 func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject string, dstBucket string, dstObject string, srcInfo minio.ObjectInfo, srcOpts, dstOpts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
 	if srcOpts.CheckCopyPrecondFn != nil && srcOpts.CheckCopyPrecondFn(srcInfo, "") {
@@ -490,8 +641,14 @@ func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject
 	// metadata input is already a trickled down value from interpreting x-amz-metadata-directive at
 	// handler layer. So what we have right now is supposed to be applied on the destination object anyways.
 	// So preserve it by adding "REPLACE" directive to save all the metadata set by CopyObject API.
-	srcInfo.UserDefined["x-amz-metadata-directive"] = "REPLACE"
-	srcInfo.UserDefined["x-amz-copy-source-if-match"] = srcInfo.ETag
+	metadata, tags := toS3Metadata(srcInfo.UserDefined)
+	metadata["x-amz-metadata-directive"] = "REPLACE"
+	metadata["x-amz-copy-source-if-match"] = srcInfo.ETag
+	// Tags were likewise resolved from x-amz-tagging-directive.
+	metadata[xhttp.AmzTagDirective] = "REPLACE"
+	if tags != "" {
+		metadata[xhttp.AmzObjectTagging] = tags
+	}
 	header := make(http.Header)
 	if srcOpts.ServerSideEncryption != nil {
 		encrypt.SSECopy(srcOpts.ServerSideEncryption).Marshal(header)
@@ -501,10 +658,10 @@ func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject
 		dstOpts.ServerSideEncryption.Marshal(header)
 	}
 	for k, v := range header {
-		srcInfo.UserDefined[k] = v[0]
+		metadata[k] = v[0]
 	}
 
-	if _, err = l.Client.CopyObject(srcBucket, srcObject, dstBucket, dstObject, srcInfo.UserDefined); err != nil {
+	if _, err = l.pool.writeClient().CopyObject(srcBucket, srcObject, dstBucket, dstObject, metadata); err != nil {
 		return objInfo, minio.ErrorRespToObjectError(err, srcBucket, srcObject)
 	}
 	return l.GetObjectInfo(ctx, dstBucket, dstObject, dstOpts)
@@ -512,7 +669,7 @@ func (l *s3Objects) CopyObject(ctx context.Context, srcBucket string, srcObject
 
 // DeleteObject deletes a blob in bucket
 func (l *s3Objects) DeleteObject(ctx context.Context, bucket string, object string) error {
-	err := l.Client.RemoveObject(bucket, object)
+	err := l.pool.writeClient().RemoveObject(bucket, object)
 	if err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -530,7 +687,7 @@ func (l *s3Objects) DeleteObjects(ctx context.Context, bucket string, objects []
 
 // ListMultipartUploads lists all multipart uploads.
 func (l *s3Objects) ListMultipartUploads(ctx context.Context, bucket string, prefix string, keyMarker string, uploadIDMarker string, delimiter string, maxUploads int) (lmi minio.ListMultipartsInfo, e error) {
-	result, err := l.Client.ListMultipartUploads(bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	result, err := l.pool.writeClient().ListMultipartUploads(bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
 	if err != nil {
 		return lmi, err
 	}
@@ -540,19 +697,28 @@ func (l *s3Objects) ListMultipartUploads(ctx context.Context, bucket string, pre
 
 // NewMultipartUpload upload object in multiple parts
 func (l *s3Objects) NewMultipartUpload(ctx context.Context, bucket string, object string, o minio.ObjectOptions) (uploadID string, err error) {
-	// Create PutObject options
-	opts := miniogo.PutObjectOptions{UserMetadata: o.UserDefined, ServerSideEncryption: o.ServerSideEncryption}
-	uploadID, err = l.Client.NewMultipartUpload(bucket, object, opts)
+	uploadID, err = newS3MultipartUpload(ctx, l.pool.writeClient(), bucket, object, o)
 	if err != nil {
 		return uploadID, minio.ErrorRespToObjectError(err, bucket, object)
 	}
 	return uploadID, nil
 }
 
+// newS3MultipartUpload - initiates a multipart upload on the upstream,
+// the tag set is sent along when the upload is initiated.
+func newS3MultipartUpload(ctx context.Context, clnt *s3Client, bucket, object string, o minio.ObjectOptions) (string, error) {
+	metadata, tags := toS3Metadata(o.UserDefined)
+	opts := miniogo.PutObjectOptions{UserMetadata: metadata, ServerSideEncryption: o.ServerSideEncryption}
+	if tags != "" {
+		return clnt.newMultipartUploadWithTags(ctx, bucket, object, opts, tags)
+	}
+	return clnt.NewMultipartUpload(bucket, object, opts)
+}
+
 // PutObjectPart puts a part of object in bucket
 func (l *s3Objects) PutObjectPart(ctx context.Context, bucket string, object string, uploadID string, partID int, r *minio.PutObjReader, opts minio.ObjectOptions) (pi minio.PartInfo, e error) {
 	data := r.Reader
-	info, err := l.Client.PutObjectPart(bucket, object, uploadID, partID, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), opts.ServerSideEncryption)
+	info, err := l.pool.writeClient().PutObjectPart(bucket, object, uploadID, partID, data, data.Size(), data.MD5Base64String(), data.SHA256HexString(), opts.ServerSideEncryption)
 	if err != nil {
 		return pi, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -582,7 +748,7 @@ func (l *s3Objects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, de
 		srcInfo.UserDefined[k] = v[0]
 	}
 
-	completePart, err := l.Client.CopyObjectPart(srcBucket, srcObject, destBucket, destObject,
+	completePart, err := l.pool.writeClient().CopyObjectPart(srcBucket, srcObject, destBucket, destObject,
 		uploadID, partID, startOffset, length, srcInfo.UserDefined)
 	if err != nil {
 		return p, minio.ErrorRespToObjectError(err, srcBucket, srcObject)
@@ -594,7 +760,7 @@ func (l *s3Objects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, de
 
 // ListObjectParts returns all object parts for specified object in specified bucket
 func (l *s3Objects) ListObjectParts(ctx context.Context, bucket string, object string, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (lpi minio.ListPartsInfo, e error) {
-	result, err := l.Client.ListObjectParts(bucket, object, uploadID, partNumberMarker, maxParts)
+	result, err := l.pool.writeClient().ListObjectParts(bucket, object, uploadID, partNumberMarker, maxParts)
 	if err != nil {
 		return lpi, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -604,13 +770,13 @@ func (l *s3Objects) ListObjectParts(ctx context.Context, bucket string, object s
 
 // AbortMultipartUpload aborts a ongoing multipart upload
 func (l *s3Objects) AbortMultipartUpload(ctx context.Context, bucket string, object string, uploadID string) error {
-	err := l.Client.AbortMultipartUpload(bucket, object, uploadID)
+	err := l.pool.writeClient().AbortMultipartUpload(bucket, object, uploadID)
 	return minio.ErrorRespToObjectError(err, bucket, object)
 }
 
 // CompleteMultipartUpload completes ongoing multipart upload and finalizes object
 func (l *s3Objects) CompleteMultipartUpload(ctx context.Context, bucket string, object string, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (oi minio.ObjectInfo, e error) {
-	etag, err := l.Client.CompleteMultipartUpload(bucket, object, uploadID, minio.ToMinioClientCompleteParts(uploadedParts))
+	etag, err := l.pool.writeClient().CompleteMultipartUpload(bucket, object, uploadID, minio.ToMinioClientCompleteParts(uploadedParts))
 	if err != nil {
 		return oi, minio.ErrorRespToObjectError(err, bucket, object)
 	}
@@ -627,7 +793,7 @@ func (l *s3Objects) SetBucketPolicy(ctx context.Context, bucket string, bucketPo
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
 
-	if err := l.Client.SetBucketPolicy(bucket, string(data)); err != nil {
+	if err := l.pool.writeClient().SetBucketPolicy(bucket, string(data)); err != nil {
 		return minio.ErrorRespToObjectError(err, bucket)
 	}
 
@@ -636,7 +802,7 @@ func (l *s3Objects) SetBucketPolicy(ctx context.Context, bucket string, bucketPo
 
 // GetBucketPolicy will get policy on bucket
 func (l *s3Objects) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
-	data, err := l.Client.GetBucketPolicy(bucket)
+	data, err := l.pool.readClient().GetBucketPolicy(bucket)
 	if err != nil {
 		return nil, minio.ErrorRespToObjectError(err, bucket)
 	}
@@ -647,7 +813,7 @@ func (l *s3Objects) GetBucketPolicy(ctx context.Context, bucket string) (*policy
 
 // DeleteBucketPolicy deletes all policies on bucket
 func (l *s3Objects) DeleteBucketPolicy(ctx context.Context, bucket string) error {
-	if err := l.Client.SetBucketPolicy(bucket, ""); err != nil {
+	if err := l.pool.writeClient().SetBucketPolicy(bucket, ""); err != nil {
 		return minio.ErrorRespToObjectError(err, bucket, "")
 	}
 	return nil
@@ -658,6 +824,14 @@ func (l *s3Objects) IsCompressionSupported() bool {
 	return false
 }
 
+// IsReady returns whether the S3 backend is reachable with the
+// configured credentials.
+func (l *s3Objects) IsReady(ctx context.Context) bool {
+	_, err := l.pool.readClient().ListBuckets()
+	logger.LogIf(ctx, err)
+	return err == nil
+}
+
 // IsEncryptionSupported returns whether server side encryption is implemented for this layer.
 func (l *s3Objects) IsEncryptionSupported() bool {
 	return minio.GlobalKMS != nil || len(minio.GlobalGatewaySSE) > 0
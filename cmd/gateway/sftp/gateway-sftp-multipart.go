@@ -0,0 +1,257 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sftp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Minimum size of all parts but the last one, as on S3.
+const sftpMinPartSize = 5 * 1024 * 1024
+
+// sftpMultipartStore - implements minio.GatewayMultipartStore on a
+// local directory, every bucket with uploads in progress has its own
+// sub-directory. Parts are kept locally so that the remote server only
+// ever sees complete objects.
+type sftpMultipartStore struct {
+	dir string
+}
+
+// Returns the local path of the temporary object name in bucket.
+func (s *sftpMultipartStore) localPath(bucket, name string) string {
+	return filepath.Join(s.dir, bucket, filepath.FromSlash(name))
+}
+
+func (s *sftpMultipartStore) ListBuckets(ctx context.Context) (buckets []minio.BucketInfo, err error) {
+	entries, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			buckets = append(buckets, minio.BucketInfo{Name: entry.Name(), Created: entry.ModTime()})
+		}
+	}
+	return buckets, nil
+}
+
+func (s *sftpMultipartStore) PutTempObject(ctx context.Context, bucket, name string, data []byte) error {
+	p := s.localPath(bucket, name)
+	if err := os.MkdirAll(filepath.Dir(p), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(p, data, 0600)
+}
+
+func (s *sftpMultipartStore) GetTempObject(ctx context.Context, bucket, name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(s.localPath(bucket, name))
+	if os.IsNotExist(err) {
+		return nil, minio.ObjectNotFound{Bucket: bucket, Object: name}
+	}
+	return data, err
+}
+
+// DeleteTempObject deletes the object and its parent directories left
+// empty, up to the bucket directory which is removed as well.
+func (s *sftpMultipartStore) DeleteTempObject(ctx context.Context, bucket, name string) error {
+	p := s.localPath(bucket, name)
+	if err := os.Remove(p); err != nil {
+		return err
+	}
+	for dir := filepath.Dir(p); dir != s.dir && strings.HasPrefix(dir, s.dir); dir = filepath.Dir(dir) {
+		// Removing a directory which is not empty fails.
+		if os.Remove(dir) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+func (s *sftpMultipartStore) ListTempObjects(ctx context.Context, bucket, prefix string) (objects []minio.GatewayTempObjectInfo, err error) {
+	bucketDir := filepath.Join(s.dir, bucket)
+	err = filepath.Walk(bucketDir, func(p string, fi os.FileInfo, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if fi.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(bucketDir, p)
+		if err != nil {
+			return err
+		}
+		if name := filepath.ToSlash(rel); strings.HasPrefix(name, prefix) {
+			objects = append(objects, minio.GatewayTempObjectInfo{Name: name, ModTime: fi.ModTime()})
+		}
+		return nil
+	})
+	return objects, err
+}
+
+// Returns the name of the part data saved next to its metadata.
+func sftpPartName(uploadID string, partID int) string {
+	return minio.GatewayMultipartObjectName(uploadID, fmt.Sprintf("part.%05d", partID))
+}
+
+// checkSFTPUploadID - upload IDs name local directories, reject
+// any which could escape the multipart directory.
+func checkSFTPUploadID(bucket, object, uploadID string) error {
+	if uploadID == "" || uploadID == "." || uploadID == ".." || strings.ContainsAny(uploadID, `/\`) {
+		return minio.MalformedUploadID{UploadID: uploadID}
+	}
+	if !sftpIsValidBucketName(bucket) {
+		return minio.InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+	}
+	return nil
+}
+
+// ListMultipartUploads - lists all multipart uploads of objects starting with prefix.
+func (n *sftpObjects) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result minio.ListMultipartsInfo, err error) {
+	if _, err = n.clnt.Stat(n.remotePath(bucket)); err != nil {
+		return result, sftpToObjectErr(ctx, err, bucket)
+	}
+	return n.multipart.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+}
+
+// NewMultipartUpload - saves the upload metadata locally, nothing is
+// written to the server until the upload completes.
+func (n *sftpObjects) NewMultipartUpload(ctx context.Context, bucket, object string, opts minio.ObjectOptions) (uploadID string, err error) {
+	if _, err = n.clnt.Stat(n.remotePath(bucket)); err != nil {
+		return "", sftpToObjectErr(ctx, err, bucket)
+	}
+	uploadID = minio.MustGetUUID()
+	if err = n.multipart.NewMultipartUpload(ctx, bucket, object, uploadID, opts.UserDefined); err != nil {
+		return "", err
+	}
+	return uploadID, nil
+}
+
+// PutObjectPart - saves the part data and its metadata locally.
+func (n *sftpObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, r *minio.PutObjReader, opts minio.ObjectOptions) (info minio.PartInfo, err error) {
+	if err = checkSFTPUploadID(bucket, object, uploadID); err != nil {
+		return info, err
+	}
+	if _, err = n.multipart.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
+		return info, err
+	}
+
+	p := n.store.localPath(bucket, sftpPartName(uploadID, partID))
+	f, err := os.Create(p)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return info, err
+	}
+	size, err := io.Copy(f, r)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(p)
+		return info, err
+	}
+
+	return n.multipart.PutObjectPart(ctx, bucket, uploadID, minio.GatewayPart{
+		PartNumber:   partID,
+		ETag:         r.MD5CurrentHexString(),
+		Size:         size,
+		LastModified: minio.UTCNow(),
+	})
+}
+
+// ListObjectParts - lists the parts saved in the part metadata.
+func (n *sftpObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts minio.ObjectOptions) (result minio.ListPartsInfo, err error) {
+	if err = checkSFTPUploadID(bucket, object, uploadID); err != nil {
+		return result, err
+	}
+	return n.multipart.ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts)
+}
+
+// AbortMultipartUpload - removes the local parts and metadata.
+func (n *sftpObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) (err error) {
+	if err = checkSFTPUploadID(bucket, object, uploadID); err != nil {
+		return err
+	}
+	if _, err = n.multipart.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
+		return err
+	}
+	return n.multipart.CleanupMultipartUpload(ctx, bucket, uploadID)
+}
+
+// CompleteMultipartUpload - streams the local parts in order to the
+// server as a single object, then removes them.
+func (n *sftpObjects) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []minio.CompletePart, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	if err = checkSFTPUploadID(bucket, object, uploadID); err != nil {
+		return objInfo, err
+	}
+	if _, err = n.multipart.GetMultipartUpload(ctx, bucket, object, uploadID); err != nil {
+		return objInfo, err
+	}
+	parts, err := n.multipart.GetObjectParts(ctx, bucket, uploadID, uploadedParts, sftpMinPartSize)
+	if err != nil {
+		return objInfo, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		for _, part := range parts {
+			f, err := os.Open(n.store.localPath(bucket, sftpPartName(uploadID, part.PartNumber)))
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+			_, err = io.Copy(pw, f)
+			f.Close()
+			if err != nil {
+				pw.CloseWithError(err)
+				return
+			}
+		}
+		pw.Close()
+	}()
+
+	fi, err := n.putObject(ctx, bucket, object, pr)
+	// Unblock the writer if the upload stopped before reading all parts.
+	pr.Close()
+	if err != nil {
+		return objInfo, err
+	}
+
+	derr := n.multipart.CleanupMultipartUpload(ctx, bucket, uploadID)
+	logger.GetReqInfo(ctx).AppendTags("uploadID", uploadID)
+	logger.LogIf(ctx, derr)
+
+	return minio.ObjectInfo{
+		Bucket:  bucket,
+		Name:    object,
+		ETag:    minio.ComputeCompleteMultipartMD5(uploadedParts),
+		ModTime: fi.ModTime(),
+		Size:    fi.Size(),
+		IsDir:   fi.IsDir(),
+	}, nil
+}
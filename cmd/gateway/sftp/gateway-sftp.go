@@ -0,0 +1,684 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sftp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/env"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+const (
+	sftpBackend = "sftp"
+
+	sftpSeparator = minio.SlashSeparator
+
+	// Default port of the SSH server.
+	sftpDefaultPort = "22"
+
+	// Directory of the remote root where objects are written before
+	// being renamed to their final name.
+	sftpMetaTmpDir = ".minio.sys/tmp"
+
+	// Name of the bucket reserved by MinIO.
+	minioReservedBucket = "minio"
+
+	// Status code returned by the server when the user is not allowed
+	// to access a file, SSH_FX_PERMISSION_DENIED.
+	sftpPermissionDenied = 3
+)
+
+func init() {
+	const sftpGatewayTemplate = `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS]{{end}} SFTP-URL
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+SFTP-URL:
+  SFTP server and the directory whose sub-directories are served as buckets,
+  the login directory of the user is served when no directory is given.
+
+ENVIRONMENT VARIABLES:
+  ACCESS:
+     MINIO_ACCESS_KEY: Username of the SFTP user.
+     MINIO_SECRET_KEY: Password of the SFTP user.
+
+  SFTP:
+     MINIO_SFTP_PRIVATE_KEY: Path to a private key used to authenticate before the password.
+     MINIO_SFTP_KNOWN_HOSTS: Path to the known_hosts file verifying the host key of the server, defaults to "~/.ssh/known_hosts".
+     MINIO_SFTP_MULTIPART_DIR: Local directory holding the parts of multipart uploads until they complete.
+
+  BROWSER:
+     MINIO_BROWSER: To disable web browser access, set this value to "off".
+
+  DOMAIN:
+     MINIO_DOMAIN: To enable virtual-host-style requests, set this value to MinIO host domain name.
+
+  CACHE:
+     MINIO_CACHE_DRIVES: List of mounted drives or directories delimited by ";".
+     MINIO_CACHE_EXCLUDE: List of cache exclusion patterns delimited by ";".
+     MINIO_CACHE_EXPIRY: Cache expiry duration in days.
+     MINIO_CACHE_MAXUSE: Maximum permitted usage of the cache in percentage (0-100).
+
+EXAMPLES:
+  1. Start minio gateway server for an SFTP backend.
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}sftpuser
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}sftppassword
+     {{.Prompt}} {{.HelpName}} sftp://sftp.example.com:22/srv/dropzone
+
+  2. Start minio gateway server for an SFTP backend authenticating with a private key.
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}sftpuser
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}sftppassword
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SFTP_PRIVATE_KEY{{.AssignmentOperator}}/home/sftpuser/.ssh/id_rsa
+     {{.Prompt}} {{.HelpName}} sftp://sftp.example.com/srv/dropzone
+`
+
+	minio.RegisterGatewayCommand(cli.Command{
+		Name:               sftpBackend,
+		Usage:              "SSH File Transfer Protocol (SFTP)",
+		Action:             sftpGatewayMain,
+		CustomHelpTemplate: sftpGatewayTemplate,
+		HideHelpCommand:    true,
+	})
+}
+
+// Handler for 'minio gateway sftp' command line.
+func sftpGatewayMain(ctx *cli.Context) {
+	// Validate gateway arguments.
+	if !ctx.Args().Present() || ctx.Args().First() == "help" {
+		cli.ShowCommandHelpAndExit(ctx, sftpBackend, 1)
+	}
+
+	minio.StartGateway(ctx, &SFTP{url: ctx.Args().First()})
+}
+
+// SFTP implements Gateway.
+type SFTP struct {
+	url string
+}
+
+// Name implements Gateway interface.
+func (g *SFTP) Name() string {
+	return sftpBackend
+}
+
+// sshAuthMethods - returns the private key authentication, if
+// configured, followed by the password authentication.
+func sshAuthMethods(creds auth.Credentials) ([]ssh.AuthMethod, error) {
+	var methods []ssh.AuthMethod
+	if keyFile := env.Get("MINIO_SFTP_PRIVATE_KEY", ""); keyFile != "" {
+		key, err := ioutil.ReadFile(keyFile)
+		if err != nil {
+			return nil, err
+		}
+		signer, err := ssh.ParsePrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, ssh.PublicKeys(signer))
+	}
+	return append(methods, ssh.Password(creds.SecretKey)), nil
+}
+
+// NewGatewayLayer returns sftp gatewaylayer.
+func (g *SFTP) NewGatewayLayer(creds auth.Credentials) (minio.ObjectLayer, error) {
+	u, err := url.Parse(g.url)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != sftpBackend {
+		return nil, errors.New("SFTP URL must start with sftp://")
+	}
+	addr := u.Host
+	if u.Port() == "" {
+		addr = net.JoinHostPort(u.Hostname(), sftpDefaultPort)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	hostKeyCallback, err := knownhosts.New(env.Get("MINIO_SFTP_KNOWN_HOSTS", filepath.Join(home, ".ssh", "known_hosts")))
+	if err != nil {
+		return nil, err
+	}
+	authMethods, err := sshAuthMethods(creds)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := ssh.Dial("tcp", addr, &ssh.ClientConfig{
+		User:            creds.AccessKey,
+		Auth:            authMethods,
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         30 * time.Second,
+	})
+	if err != nil {
+		return nil, err
+	}
+	clnt, err := sftp.NewClient(conn)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	multipartDir := env.Get("MINIO_SFTP_MULTIPART_DIR", filepath.Join(os.TempDir(), "minio-sftp-multipart"))
+	n, err := newSFTPObjects(clnt, u.Path, multipartDir)
+	if err != nil {
+		clnt.Close()
+		return nil, err
+	}
+
+	// Start background process to cleanup old multipart uploads.
+	go n.multipart.CleanupStaleMultipartUploads(context.Background(), minio.GlobalMultipartCleanupInterval,
+		minio.GlobalMultipartExpiry, minio.GlobalServiceDoneCh)
+
+	return n, nil
+}
+
+// Production - sftp gateway is not production ready.
+func (g *SFTP) Production() bool {
+	return false
+}
+
+// sftpObjects implements gateway for MinIO and S3 compatible object storage servers.
+type sftpObjects struct {
+	minio.GatewayUnsupported
+	clnt      *sftp.Client
+	root      string // Remote directory whose sub-directories are the buckets
+	listPool  *minio.TreeWalkPool
+	store     *sftpMultipartStore
+	multipart *minio.GatewayMultipart
+}
+
+// newSFTPObjects - returns the object layer serving the sub-directories
+// of root, the login directory when root is empty, multipart uploads
+// are kept in the local multipartDir until they complete.
+func newSFTPObjects(clnt *sftp.Client, root, multipartDir string) (*sftpObjects, error) {
+	if root == "" {
+		root = "."
+	}
+	if err := clnt.MkdirAll(path.Join(root, sftpMetaTmpDir)); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(multipartDir, 0700); err != nil {
+		return nil, err
+	}
+	store := &sftpMultipartStore{dir: multipartDir}
+	return &sftpObjects{
+		clnt:      clnt,
+		root:      root,
+		listPool:  minio.NewTreeWalkPool(time.Minute * 30),
+		store:     store,
+		multipart: minio.NewGatewayMultipart(store),
+	}, nil
+}
+
+// Returns the remote path of the elements joined under root.
+func (n *sftpObjects) remotePath(elem ...string) string {
+	return path.Join(append([]string{n.root}, elem...)...)
+}
+
+func (n *sftpObjects) Shutdown(ctx context.Context) error {
+	n.multipart.Shutdown()
+	return n.clnt.Close()
+}
+
+// IsReady returns whether the SFTP server is reachable.
+func (n *sftpObjects) IsReady(ctx context.Context) bool {
+	_, err := n.clnt.Stat(n.root)
+	logger.LogIf(ctx, err)
+	return err == nil
+}
+
+// StorageInfo returns the space used on the SFTP server, if the server
+// supports the statvfs extension.
+func (n *sftpObjects) StorageInfo(ctx context.Context) minio.StorageInfo {
+	sinfo := minio.StorageInfo{}
+	sinfo.Backend.Type = minio.Unknown
+	if st, err := n.clnt.StatVFS(n.root); err == nil {
+		sinfo.Used = (st.Blocks - st.Bfree) * st.Frsize
+	}
+	return sinfo
+}
+
+// TriggerMultipartCleanup - removes stale multipart uploads.
+func (n *sftpObjects) TriggerMultipartCleanup() error {
+	return n.multipart.TriggerCleanup()
+}
+
+func sftpToObjectErr(ctx context.Context, err error, params ...string) error {
+	if err == nil {
+		return nil
+	}
+	bucket := ""
+	object := ""
+	switch len(params) {
+	case 2:
+		object = params[1]
+		fallthrough
+	case 1:
+		bucket = params[0]
+	}
+
+	if statusErr, ok := err.(*sftp.StatusError); ok && statusErr.Code == sftpPermissionDenied {
+		return minio.PrefixAccessDenied{Bucket: bucket, Object: object}
+	}
+	switch {
+	case os.IsNotExist(err):
+		if object != "" {
+			return minio.ObjectNotFound{Bucket: bucket, Object: object}
+		}
+		return minio.BucketNotFound{Bucket: bucket}
+	default:
+		logger.LogIf(ctx, err)
+		return err
+	}
+}
+
+// sftpIsValidBucketName verifies whether a bucket name is valid.
+func sftpIsValidBucketName(bucket string) bool {
+	return s3utils.CheckValidBucketNameStrict(bucket) == nil && bucket != minioReservedBucket
+}
+
+// isEmptyDir - returns whether the remote directory has no entries.
+func (n *sftpObjects) isEmptyDir(dir string) (bool, error) {
+	entries, err := n.clnt.ReadDir(dir)
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
+
+// removeAll - removes the remote directory and all its content.
+func (n *sftpObjects) removeAll(dir string) error {
+	var paths []string
+	walker := n.clnt.Walk(dir)
+	for walker.Step() {
+		if err := walker.Err(); err != nil {
+			return err
+		}
+		paths = append(paths, walker.Path())
+	}
+	// Remove the deepest entries first, the walk lists a
+	// directory before its entries.
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := n.clnt.Remove(paths[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (n *sftpObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	if !sftpIsValidBucketName(bucket) {
+		return minio.BucketNameInvalid{Bucket: bucket}
+	}
+	dir := n.remotePath(bucket)
+	if forceDelete {
+		if _, err := n.clnt.Stat(dir); err != nil {
+			return sftpToObjectErr(ctx, err, bucket)
+		}
+		return sftpToObjectErr(ctx, n.removeAll(dir), bucket)
+	}
+	// Servers do not tell a non-empty directory apart from
+	// other failures, check it beforehand.
+	empty, err := n.isEmptyDir(dir)
+	if err != nil {
+		return sftpToObjectErr(ctx, err, bucket)
+	}
+	if !empty {
+		return minio.BucketNotEmpty{Bucket: bucket}
+	}
+	return sftpToObjectErr(ctx, n.clnt.RemoveDirectory(dir), bucket)
+}
+
+func (n *sftpObjects) MakeBucketWithLocation(ctx context.Context, bucket, location string) error {
+	if !sftpIsValidBucketName(bucket) {
+		return minio.BucketNameInvalid{Bucket: bucket}
+	}
+	if _, err := n.clnt.Stat(n.remotePath(bucket)); err == nil {
+		return minio.BucketAlreadyOwnedByYou{Bucket: bucket}
+	}
+	return sftpToObjectErr(ctx, n.clnt.Mkdir(n.remotePath(bucket)), bucket)
+}
+
+func (n *sftpObjects) GetBucketInfo(ctx context.Context, bucket string) (bi minio.BucketInfo, err error) {
+	if !sftpIsValidBucketName(bucket) {
+		return bi, minio.BucketNotFound{Bucket: bucket}
+	}
+	fi, err := n.clnt.Stat(n.remotePath(bucket))
+	if err != nil {
+		return bi, sftpToObjectErr(ctx, err, bucket)
+	}
+	if !fi.IsDir() {
+		return bi, minio.BucketNotFound{Bucket: bucket}
+	}
+	// SFTP does not return the creation time, use ModTime() as CreatedTime.
+	return minio.BucketInfo{
+		Name:    bucket,
+		Created: fi.ModTime(),
+	}, nil
+}
+
+func (n *sftpObjects) ListBuckets(ctx context.Context) (buckets []minio.BucketInfo, err error) {
+	entries, err := n.clnt.ReadDir(n.root)
+	if err != nil {
+		return nil, sftpToObjectErr(ctx, err)
+	}
+
+	for _, entry := range entries {
+		// Ignore files, reserved bucket names and invalid bucket names.
+		if !entry.IsDir() || !sftpIsValidBucketName(entry.Name()) {
+			continue
+		}
+		buckets = append(buckets, minio.BucketInfo{
+			Name: entry.Name(),
+			// SFTP does not return the creation time, use ModTime() as CreatedTime.
+			Created: entry.ModTime(),
+		})
+	}
+
+	// Sort bucket infos by bucket name.
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+	return buckets, nil
+}
+
+func (n *sftpObjects) listDirFactory() minio.ListDirFunc {
+	// listDir - lists all the entries at a given prefix and given entry in the prefix.
+	listDir := func(bucket, prefixDir, prefixEntry string) (entries []string) {
+		fis, err := n.clnt.ReadDir(n.remotePath(bucket, prefixDir))
+		if err != nil {
+			if os.IsNotExist(err) {
+				err = nil
+			}
+			logger.LogIf(context.Background(), err)
+			return
+		}
+		for _, fi := range fis {
+			if fi.IsDir() {
+				entries = append(entries, fi.Name()+sftpSeparator)
+			} else {
+				entries = append(entries, fi.Name())
+			}
+		}
+		return minio.FilterMatchingPrefix(entries, prefixEntry)
+	}
+
+	// Return list factory instance.
+	return listDir
+}
+
+// ListObjects lists all files in SFTP bucket filtered by prefix.
+func (n *sftpObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi minio.ListObjectsInfo, err error) {
+	getObjectInfo := func(ctx context.Context, bucket, entry string) (minio.ObjectInfo, error) {
+		return n.GetObjectInfo(ctx, bucket, entry, minio.ObjectOptions{})
+	}
+	// Directories are listed as prefixes whether they are empty or not.
+	getObjectInfoDir := func(ctx context.Context, bucket, entry string) (minio.ObjectInfo, error) {
+		fi, err := n.clnt.Stat(n.remotePath(bucket, entry))
+		if err != nil {
+			return minio.ObjectInfo{}, sftpToObjectErr(ctx, err, bucket, entry)
+		}
+		return minio.ObjectInfo{
+			Bucket:  bucket,
+			Name:    entry,
+			ModTime: fi.ModTime(),
+			IsDir:   true,
+		}, nil
+	}
+
+	return minio.ListObjects(ctx, n, bucket, prefix, marker, delimiter, maxKeys, n.listPool, n.listDirFactory(), getObjectInfo, getObjectInfoDir)
+}
+
+// ListObjectsV2 lists all files in SFTP bucket filtered by prefix
+func (n *sftpObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int,
+	fetchOwner bool, startAfter string) (loi minio.ListObjectsV2Info, err error) {
+	// fetchOwner is not supported and unused.
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+	resultV1, err := n.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	if err != nil {
+		return loi, err
+	}
+	return minio.ListObjectsV2Info{
+		Objects:               resultV1.Objects,
+		Prefixes:              resultV1.Prefixes,
+		ContinuationToken:     continuationToken,
+		NextContinuationToken: resultV1.NextMarker,
+		IsTruncated:           resultV1.IsTruncated,
+	}, nil
+}
+
+// deleteObject deletes a file path if its empty. If it's successfully deleted,
+// it will recursively move up the tree, deleting empty parent directories
+// until it finds one with files in it. Returns nil for a non-empty directory.
+func (n *sftpObjects) deleteObject(basePath, deletePath string) error {
+	if basePath == deletePath {
+		return nil
+	}
+
+	// Attempt to remove path.
+	if err := n.clnt.Remove(deletePath); err != nil {
+		if empty, derr := n.isEmptyDir(deletePath); derr == nil && !empty {
+			// Ignore errors if the directory is not empty. The server relies on
+			// this functionality, and sometimes uses recursion that should not
+			// error on parent directories.
+			return nil
+		}
+		return err
+	}
+
+	// Delete parent directory. Errors for parent directories shouldn't trickle down.
+	n.deleteObject(basePath, path.Dir(deletePath))
+
+	return nil
+}
+
+func (n *sftpObjects) DeleteObject(ctx context.Context, bucket, object string) error {
+	if _, err := n.clnt.Stat(n.remotePath(bucket)); err != nil {
+		return sftpToObjectErr(ctx, err, bucket)
+	}
+	return sftpToObjectErr(ctx, n.deleteObject(n.remotePath(bucket), n.remotePath(bucket, object)), bucket, object)
+}
+
+func (n *sftpObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+	errs := make([]error, len(objects))
+	for idx, object := range objects {
+		errs[idx] = n.DeleteObject(ctx, bucket, object)
+	}
+	return errs, nil
+}
+
+func (n *sftpObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *minio.HTTPRangeSpec, h http.Header, lockType minio.LockType, opts minio.ObjectOptions) (gr *minio.GetObjectReader, err error) {
+	objInfo, err := n.GetObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var startOffset, length int64
+	startOffset, length, err = rs.GetOffsetLength(objInfo.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		nerr := n.GetObject(ctx, bucket, object, startOffset, length, pw, objInfo.ETag, opts)
+		pw.CloseWithError(nerr)
+	}()
+
+	// Setup cleanup function to cause the above go-routine to
+	// exit in case of partial read
+	pipeCloser := func() { pr.Close() }
+	return minio.NewGetObjectReaderFromReader(pr, objInfo, opts.CheckCopyPrecondFn, pipeCloser)
+}
+
+func (n *sftpObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo minio.ObjectInfo, srcOpts, dstOpts minio.ObjectOptions) (minio.ObjectInfo, error) {
+	cpSrcDstSame := minio.IsStringEqual(n.remotePath(srcBucket, srcObject), n.remotePath(dstBucket, dstObject))
+	if cpSrcDstSame {
+		return n.GetObjectInfo(ctx, srcBucket, srcObject, minio.ObjectOptions{})
+	}
+
+	return n.PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, minio.ObjectOptions{
+		ServerSideEncryption: dstOpts.ServerSideEncryption,
+		UserDefined:          srcInfo.UserDefined,
+	})
+}
+
+func (n *sftpObjects) GetObject(ctx context.Context, bucket, key string, startOffset, length int64, writer io.Writer, etag string, opts minio.ObjectOptions) error {
+	if _, err := n.clnt.Stat(n.remotePath(bucket)); err != nil {
+		return sftpToObjectErr(ctx, err, bucket)
+	}
+	rd, err := n.clnt.Open(n.remotePath(bucket, key))
+	if err != nil {
+		return sftpToObjectErr(ctx, err, bucket, key)
+	}
+	defer rd.Close()
+	if _, err = rd.Seek(startOffset, io.SeekStart); err != nil {
+		return sftpToObjectErr(ctx, err, bucket, key)
+	}
+	var r io.Reader = rd
+	if length >= 0 {
+		r = io.LimitReader(rd, length)
+	}
+	_, err = io.Copy(writer, r)
+	return sftpToObjectErr(ctx, err, bucket, key)
+}
+
+// GetObjectInfo reads object info and replies back ObjectInfo.
+func (n *sftpObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	_, err = n.clnt.Stat(n.remotePath(bucket))
+	if err != nil {
+		return objInfo, sftpToObjectErr(ctx, err, bucket)
+	}
+
+	fi, err := n.clnt.Stat(n.remotePath(bucket, object))
+	if err != nil {
+		return objInfo, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	if strings.HasSuffix(object, sftpSeparator) {
+		// Only empty directories are returned as objects.
+		empty, err := n.isEmptyDir(n.remotePath(bucket, object))
+		if err != nil || !empty {
+			return objInfo, minio.ObjectNotFound{Bucket: bucket, Object: object}
+		}
+	} else if fi.IsDir() {
+		return objInfo, minio.ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	return minio.ObjectInfo{
+		Bucket:  bucket,
+		Name:    object,
+		ModTime: fi.ModTime(),
+		Size:    fi.Size(),
+		IsDir:   fi.IsDir(),
+	}, nil
+}
+
+// putObject - streams r to a temporary file and renames it to name
+// once complete, so that readers never see a partially written object.
+func (n *sftpObjects) putObject(ctx context.Context, bucket, object string, r io.Reader) (os.FileInfo, error) {
+	name := n.remotePath(bucket, object)
+	tmpname := n.remotePath(sftpMetaTmpDir, minio.MustGetUUID())
+	w, err := n.clnt.Create(tmpname)
+	if err != nil {
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	if _, err = io.Copy(w, r); err != nil {
+		w.Close()
+		n.clnt.Remove(tmpname)
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	if err = w.Close(); err != nil {
+		n.clnt.Remove(tmpname)
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	if err = n.clnt.MkdirAll(path.Dir(name)); err != nil {
+		n.clnt.Remove(tmpname)
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	// Plain SFTP renames fail if the target exists, the posix
+	// rename extension replaces it.
+	if err = n.clnt.PosixRename(tmpname, name); err != nil {
+		n.clnt.Remove(tmpname)
+		n.deleteObject(n.remotePath(bucket), path.Dir(name))
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	fi, err := n.clnt.Stat(name)
+	if err != nil {
+		return nil, sftpToObjectErr(ctx, err, bucket, object)
+	}
+	return fi, nil
+}
+
+func (n *sftpObjects) PutObject(ctx context.Context, bucket string, object string, r *minio.PutObjReader, opts minio.ObjectOptions) (objInfo minio.ObjectInfo, err error) {
+	_, err = n.clnt.Stat(n.remotePath(bucket))
+	if err != nil {
+		return objInfo, sftpToObjectErr(ctx, err, bucket)
+	}
+
+	// If its a directory create a prefix.
+	if strings.HasSuffix(object, sftpSeparator) && r.Size() == 0 {
+		name := n.remotePath(bucket, object)
+		if err = n.clnt.MkdirAll(name); err != nil {
+			n.deleteObject(n.remotePath(bucket), name)
+			return objInfo, sftpToObjectErr(ctx, err, bucket, object)
+		}
+		return n.GetObjectInfo(ctx, bucket, object, opts)
+	}
+
+	fi, err := n.putObject(ctx, bucket, object, r)
+	if err != nil {
+		return objInfo, err
+	}
+	return minio.ObjectInfo{
+		Bucket:  bucket,
+		Name:    object,
+		ETag:    r.MD5CurrentHexString(),
+		ModTime: fi.ModTime(),
+		Size:    fi.Size(),
+		IsDir:   fi.IsDir(),
+	}, nil
+}
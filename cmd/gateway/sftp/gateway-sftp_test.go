@@ -0,0 +1,236 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package sftp
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	minio "github.com/minio/minio/cmd"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/pkg/sftp"
+)
+
+// Returns an object layer talking to an in-process SFTP server serving
+// a temporary directory, and a function removing all test state.
+func newTestSFTPObjects(t *testing.T) (*sftpObjects, func()) {
+	dir, err := ioutil.TempDir("", "minio-sftp-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	root, mpDir := filepath.Join(dir, "root"), filepath.Join(dir, "multipart")
+	if err = os.Mkdir(root, 0700); err != nil {
+		t.Fatal(err)
+	}
+
+	serverRd, clientWr := io.Pipe()
+	clientRd, serverWr := io.Pipe()
+	server, err := sftp.NewServer(struct {
+		io.Reader
+		io.WriteCloser
+	}{serverRd, serverWr})
+	if err != nil {
+		t.Fatal(err)
+	}
+	go server.Serve()
+
+	clnt, err := sftp.NewClientPipe(clientRd, clientWr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	n, err := newSFTPObjects(clnt, root, mpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return n, func() {
+		// Closing the server ends the connection the client waits on.
+		server.Close()
+		n.Shutdown(context.Background())
+		os.RemoveAll(dir)
+	}
+}
+
+func newTestPutObjReader(t *testing.T, data []byte) *minio.PutObjReader {
+	r, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", "", int64(len(data)), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return minio.NewPutObjReader(r, nil, nil)
+}
+
+func TestSFTPBuckets(t *testing.T) {
+	n, cleanup := newTestSFTPObjects(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := n.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := n.MakeBucketWithLocation(ctx, "bucket", ""); err != (minio.BucketAlreadyOwnedByYou{Bucket: "bucket"}) {
+		t.Fatalf("expected BucketAlreadyOwnedByYou, got %v", err)
+	}
+	if err := n.MakeBucketWithLocation(ctx, "minio", ""); err != (minio.BucketNameInvalid{Bucket: "minio"}) {
+		t.Fatalf("expected BucketNameInvalid, got %v", err)
+	}
+	if _, err := n.GetBucketInfo(ctx, "missing"); err != (minio.BucketNotFound{Bucket: "missing"}) {
+		t.Fatalf("expected BucketNotFound, got %v", err)
+	}
+
+	// The temporary directory of the gateway is not a bucket.
+	buckets, err := n.ListBuckets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(buckets) != 1 || buckets[0].Name != "bucket" {
+		t.Fatalf("expected only bucket, got %v", buckets)
+	}
+
+	if _, err = n.PutObject(ctx, "bucket", "dir/object", newTestPutObjReader(t, []byte("data")), minio.ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = n.DeleteBucket(ctx, "bucket", false); err != (minio.BucketNotEmpty{Bucket: "bucket"}) {
+		t.Fatalf("expected BucketNotEmpty, got %v", err)
+	}
+	if err = n.DeleteBucket(ctx, "bucket", true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.GetBucketInfo(ctx, "bucket"); err != (minio.BucketNotFound{Bucket: "bucket"}) {
+		t.Fatalf("expected BucketNotFound, got %v", err)
+	}
+}
+
+func TestSFTPObjects(t *testing.T) {
+	n, cleanup := newTestSFTPObjects(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := n.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"a/b/c", "a/d", "e"} {
+		if _, err := n.PutObject(ctx, "bucket", object, newTestPutObjReader(t, []byte(object)), minio.ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Overwrite an existing object.
+	objInfo, err := n.PutObject(ctx, "bucket", "e", newTestPutObjReader(t, []byte("hello world")), minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != 11 {
+		t.Fatalf("expected size 11, got %d", objInfo.Size)
+	}
+
+	var buf bytes.Buffer
+	if err = n.GetObject(ctx, "bucket", "e", 6, 5, &buf, "", minio.ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != "world" {
+		t.Fatalf("expected world, got %s", buf.String())
+	}
+	if _, err = n.GetObjectInfo(ctx, "bucket", "a", minio.ObjectOptions{}); err != (minio.ObjectNotFound{Bucket: "bucket", Object: "a"}) {
+		t.Fatalf("expected ObjectNotFound, got %v", err)
+	}
+
+	result, err := n.ListObjects(ctx, "bucket", "", "", minio.SlashSeparator, 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != 1 || result.Objects[0].Name != "e" {
+		t.Fatalf("expected object e, got %v", result.Objects)
+	}
+	if len(result.Prefixes) != 1 || result.Prefixes[0] != "a/" {
+		t.Fatalf("expected prefix a/, got %v", result.Prefixes)
+	}
+
+	// Deleting the last object of a directory removes the directory.
+	if err = n.DeleteObject(ctx, "bucket", "a/b/c"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = n.clnt.Stat(n.remotePath("bucket", "a/b")); !os.IsNotExist(err) {
+		t.Fatalf("expected a/b to be removed, got %v", err)
+	}
+	if _, err = n.clnt.Stat(n.remotePath("bucket", "a/d")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSFTPMultipartUpload(t *testing.T) {
+	n, cleanup := newTestSFTPObjects(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	if err := n.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := n.NewMultipartUpload(ctx, "bucket", "object", minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	part1 := bytes.Repeat([]byte("a"), sftpMinPartSize)
+	part2 := []byte("b")
+	var uploadedParts []minio.CompletePart
+	for i, data := range [][]byte{part1, part2} {
+		info, err := n.PutObjectPart(ctx, "bucket", "object", uploadID, i+1, newTestPutObjReader(t, data), minio.ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		uploadedParts = append(uploadedParts, minio.CompletePart{PartNumber: info.PartNumber, ETag: info.ETag})
+	}
+
+	// Nothing is written to the server before the upload completes.
+	if _, err = n.GetObjectInfo(ctx, "bucket", "object", minio.ObjectOptions{}); err != (minio.ObjectNotFound{Bucket: "bucket", Object: "object"}) {
+		t.Fatalf("expected ObjectNotFound, got %v", err)
+	}
+
+	objInfo, err := n.CompleteMultipartUpload(ctx, "bucket", "object", uploadID, uploadedParts, minio.ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != int64(len(part1)+len(part2)) {
+		t.Fatalf("expected size %d, got %d", len(part1)+len(part2), objInfo.Size)
+	}
+	var buf bytes.Buffer
+	if err = n.GetObject(ctx, "bucket", "object", 0, -1, &buf, "", minio.ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(buf.Bytes(), append(part1, part2...)) {
+		t.Fatal("unexpected object content")
+	}
+
+	// All local state of the upload is removed.
+	entries, err := ioutil.ReadDir(n.store.dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("expected empty multipart directory, got %d entries", len(entries))
+	}
+
+	if err = n.AbortMultipartUpload(ctx, "bucket", "object", uploadID); err != (minio.InvalidUploadID{Bucket: "bucket", Object: "object", UploadID: uploadID}) {
+		t.Fatalf("expected InvalidUploadID, got %v", err)
+	}
+	if err = n.AbortMultipartUpload(ctx, "bucket", "object", "../.."); err != (minio.MalformedUploadID{UploadID: "../.."}) {
+		t.Fatalf("expected MalformedUploadID, got %v", err)
+	}
+}
@@ -427,15 +427,14 @@ func setIgnoreResourcesHandler(h http.Handler) http.Handler {
 // Checks requests for not implemented Bucket resources
 func ignoreNotImplementedBucketResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetBucketACL, GetBucketCors, GetBucketWebsite,
+		// Enable GetBucketACL, GetBucketCors,
 		// GetBucketAcccelerate, GetBucketRequestPayment,
 		// GetBucketLogging, GetBucketLifecycle,
 		// GetBucketReplication, GetBucketTagging,
-		// GetBucketVersioning, DeleteBucketTagging,
-		// and DeleteBucketWebsite dummy calls specifically.
+		// GetBucketVersioning and DeleteBucketTagging
+		// dummy calls specifically.
 		if ((name == "acl" ||
 			name == "cors" ||
-			name == "website" ||
 			name == "accelerate" ||
 			name == "requestPayment" ||
 			name == "logging" ||
@@ -443,8 +442,7 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 			name == "replication" ||
 			name == "tagging" ||
 			name == "versioning") && req.Method == http.MethodGet) ||
-			((name == "tagging" ||
-				name == "website") && req.Method == http.MethodDelete) {
+			(name == "tagging" && req.Method == http.MethodDelete) {
 			return false
 		}
 
@@ -458,8 +456,13 @@ func ignoreNotImplementedBucketResources(req *http.Request) bool {
 // Checks requests for not implemented Object resources
 func ignoreNotImplementedObjectResources(req *http.Request) bool {
 	for name := range req.URL.Query() {
-		// Enable GetObjectACL and GetObjectTagging dummy calls specifically.
-		if (name == "acl" || name == "tagging") && req.Method == http.MethodGet {
+		// Enable GetObjectACL and GetObjectTagging dummy calls and
+		// GetObjectTorrent calls specifically.
+		if (name == "acl" || name == "tagging" || name == "torrent") && req.Method == http.MethodGet {
+			return false
+		}
+		// Enable RestoreObject calls specifically.
+		if name == "restore" && req.Method == http.MethodPost {
 			return false
 		}
 		if notimplementedObjectResourceNames[name] {
@@ -481,7 +484,6 @@ var notimplementedBucketResourceNames = map[string]bool{
 	"requestPayment": true,
 	"tagging":        true,
 	"versioning":     true,
-	"website":        true,
 }
 
 // List of not implemented object queries
@@ -828,3 +830,68 @@ func (h sseTLSHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 	h.handler.ServeHTTP(w, r)
 }
+
+// rateLimitHandler throttles S3 API requests which exceed
+// the configured per API class or per access key rates.
+type rateLimitHandler struct{ handler http.Handler }
+
+func setRateLimitHandler(h http.Handler) http.Handler { return rateLimitHandler{h} }
+
+func (h rateLimitHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	limiter := globalAPIRateLimiter
+	if limiter == nil {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	// Only S3 API requests are throttled.
+	if guessIsS3APIReq(r) {
+		var accessKey string
+		if limiter.userRate > 0 {
+			accessKey = getReqAccessKey(r)
+		}
+		if class, ok := limiter.allow(getAPIClass(r), accessKey); !ok {
+			httpRequestsThrottled.WithLabelValues(class).Inc()
+			writeRejectedRequestResponse(w, r, ErrSlowDown)
+			return
+		}
+	}
+	h.handler.ServeHTTP(w, r)
+}
+
+// maxClientsHandler caps the number of S3 API requests served
+// concurrently, requests which can't be served in time are
+// rejected instead of piling up in memory.
+type maxClientsHandler struct{ handler http.Handler }
+
+func setMaxClientsHandler(h http.Handler) http.Handler { return maxClientsHandler{h} }
+
+func (h maxClientsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	pool := globalAPIRequestsPool
+	if pool == nil || !guessIsS3APIReq(r) {
+		h.handler.ServeHTTP(w, r)
+		return
+	}
+	if !pool.acquire(r.Context()) {
+		writeRejectedRequestResponse(w, r, ErrOperationMaxedOut)
+		return
+	}
+	defer pool.release()
+	h.handler.ServeHTTP(w, r)
+}
+
+// guessIsS3APIReq - returns true if the request is not
+// for any of the internal or browser endpoints.
+func guessIsS3APIReq(r *http.Request) bool {
+	return !(guessIsRPCReq(r) || guessIsBrowserReq(r) || guessIsHealthCheckReq(r) ||
+		guessIsMetricsReq(r) || isAdminReq(r))
+}
+
+// writeRejectedRequestResponse - replies with errCode to a
+// request which was not let through.
+func writeRejectedRequestResponse(w http.ResponseWriter, r *http.Request, errCode APIErrorCode) {
+	if r.Method == http.MethodHead {
+		writeErrorResponseHeadersOnly(w, errorCodes.ToAPIErr(errCode))
+		return
+	}
+	writeErrorResponse(context.Background(), w, errorCodes.ToAPIErr(errCode), r.URL, guessIsBrowserReq(r))
+}
@@ -18,6 +18,7 @@ package cmd
 
 import (
 	"crypto/x509"
+	"net"
 	"os"
 	"time"
 
@@ -25,6 +26,11 @@ import (
 
 	etcd "github.com/coreos/etcd/clientv3"
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/config/directio"
+	"github.com/minio/minio/cmd/config/eventbus"
+	"github.com/minio/minio/cmd/config/notify"
+	"github.com/minio/minio/cmd/config/placement"
+	"github.com/minio/minio/cmd/config/transform"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/auth"
@@ -33,6 +39,7 @@ import (
 	"github.com/minio/minio/pkg/iam/openid"
 	iampolicy "github.com/minio/minio/pkg/iam/policy"
 	"github.com/minio/minio/pkg/pubsub"
+	"google.golang.org/grpc"
 )
 
 // minio configuration related constants.
@@ -60,6 +67,7 @@ const (
 	globalMinioModeXL              = "mode-server-xl"
 	globalMinioModeDistXL          = "mode-server-distributed-xl"
 	globalMinioModeGatewayPrefix   = "mode-gateway-"
+	globalMinioModeMemory          = "mode-server-memory"
 
 	// Add new global values here.
 )
@@ -107,6 +115,10 @@ var (
 	// Indicates set drive count.
 	globalXLSetDriveCount int
 
+	// Endpoints of the server pools, more than one server
+	// pool is used when a deployment is expanded.
+	globalServerPools []PoolEndpoints
+
 	// Indicates if the running minio server is distributed setup.
 	globalIsDistXL = false
 
@@ -119,6 +131,16 @@ var (
 	// Name of gateway server, e.g S3, GCS, Azure, etc
 	globalGatewayName = ""
 
+	// Indicates if the running minio server holds all objects in memory.
+	globalIsMemory = false
+
+	// Maximum size of objects held in memory, 0 means unlimited.
+	globalMemoryMaxSize int64
+
+	// Indicates if least recently used objects are evicted once
+	// the memory limit is reached.
+	globalMemoryEvict = false
+
 	// This flag is set to 'true' by default
 	globalIsBrowserEnabled = true
 
@@ -156,6 +178,12 @@ var (
 
 	globalLifecycleSys *LifecycleSys
 
+	globalWebsiteSys *WebsiteSys
+
+	globalBucketDefaultsSys *BucketDefaultsSys
+
+	globalBucketSSEConfigSys *BucketSSEConfigSys
+
 	// CA root certificates, a nil value means system certs pool will be used
 	globalRootCAs *x509.CertPool
 
@@ -167,6 +195,8 @@ var (
 	globalHTTPServer        *xhttp.Server
 	globalHTTPServerErrorCh = make(chan error)
 	globalOSSignalCh        = make(chan os.Signal, 1)
+	globalReloadSignalCh    = make(chan os.Signal, 1)
+	globalProfileSignalCh   = make(chan os.Signal, 1)
 
 	// global Trace system to send HTTP request/response logs to
 	// registered listeners
@@ -184,6 +214,27 @@ var (
 	// Global HTTP request statisitics
 	globalHTTPStats = newHTTPStats()
 
+	// Global S3 API rate limiter, nil when no limits are configured.
+	globalAPIRateLimiter *apiRateLimiter
+
+	// Global pool of S3 API request slots, nil when unlimited.
+	globalAPIRequestsPool *requestsPool
+
+	// Global per bucket bandwidth limits, nil when no bucket is limited.
+	globalBucketBandwidth *bucketBandwidth
+
+	// Global direct I/O and page cache hints for large objects.
+	globalDirectIO directio.Config
+
+	// Global rules pinning object prefixes to erasure sets in XL mode.
+	globalXLPlacement placement.Config
+
+	// Global NATS event bus configuration, used in distributed mode.
+	globalEventBusConfig eventbus.Config
+
+	// Global on-the-fly image transform configuration.
+	globalTransformConfig transform.Config
+
 	// Time when object layer was initialized on start up.
 	globalBootTime time.Time
 
@@ -199,9 +250,64 @@ var (
 	globalHealingTimeout   = newDynamicTimeout(30*time.Minute /*1*/, 30*time.Minute)           // timeout for healing related ops
 
 	globalIsEnvWORM bool
+
+	// Reverse proxies whose X-Forwarded-For, X-Real-IP and Forwarded
+	// headers are trusted for the source IP of policy conditions.
+	globalTrustedProxies []*net.IPNet
 	// Is worm enabled
 	globalWORMEnabled bool
 
+	// Directory used to stage uploads in FS mode, if set
+	// uploads are written here before being renamed into the
+	// backend, which may be on a different device.
+	globalFSTmpDir string
+
+	// Objects of at most this size are packed into slabs
+	// in FS mode, zero disables packing.
+	globalFSPackThreshold int64
+
+	// Objects of at most this size keep their `fs.json` in an
+	// extended attribute in FS mode, zero disables it.
+	globalFSInlineMetaThreshold int64
+
+	// Legacy bucket directories of FS mode with names which are
+	// not valid S3 bucket names are served under lowercase aliases.
+	globalFSBucketAliases bool
+
+	// The entries of large directories are indexed under
+	// `.minio.sys/listing-cache` in FS mode.
+	globalFSListingCache bool
+
+	// Number of erasure blocks of a GET read from the disks ahead
+	// of the block written to the client, zero disables read-ahead.
+	globalXLReadAhead = xlDefaultReadAhead
+
+	// Format of `xl.json` written in XL mode, either format is read.
+	// Older releases only read v1, so v2 has to be enabled explicitly.
+	globalXLMetaFormat = xlMetaFormatV1
+
+	// Serve reads of objects without parity shards left, without
+	// verifying the data shards against their bitrot checksums.
+	globalXLDegradedReads bool
+
+	// Bytes per second read from each local disk by the background
+	// scrub in XL mode, zero disables the scrub.
+	globalXLScrubBandwidth uint64
+
+	// Address on which the admin API is served over gRPC,
+	// empty when it is only served over REST.
+	globalAdminGRPCAddr string
+
+	// Admin gRPC server, nil unless globalAdminGRPCAddr is set.
+	globalAdminGRPCServer *grpc.Server
+
+	// Address on which buckets and objects are served over
+	// SFTP, empty when the SFTP listener is disabled.
+	globalSFTPAddr string
+
+	// SFTP server, nil unless globalSFTPAddr is set.
+	globalSFTPServer *sftpServer
+
 	// Is Disk Caching set up
 	globalIsDiskCacheEnabled bool
 
@@ -236,6 +342,13 @@ var (
 	// configuration must be present.
 	globalAutoEncryption bool
 
+	// Object metadata included in the records of published events.
+	globalNotifyEventMetadata notify.EventMetadata
+
+	// Directory where permanently failed events are written, empty
+	// if failed events are only logged.
+	globalNotifyDeadLetterDir string
+
 	// Is compression enabled?
 	globalIsCompressionEnabled = false
 
@@ -249,6 +362,18 @@ var (
 	// Some standard content-types which we strictly dis-allow for compression.
 	standardExcludeCompressContentTypes = []string{"video/*", "audio/*", "application/zip", "application/x-gzip", "application/x-zip-compressed", " application/x-compress", "application/x-spoon"}
 
+	// Signature V2 acceptance and usage per access key.
+	globalSignatureV2Sys = NewSignatureV2Sys(true, nil)
+
+	// Previous root credential valid until it expires.
+	globalOldCredSys = NewOldCredentialSys()
+
+	// Download limits and revocation of browser share links.
+	globalShareLinkSys = NewShareLinkSys()
+
+	// Claims of recently validated session tokens.
+	globalSTSClaimsCache = newSTSClaimsCache(stsClaimsCacheSize)
+
 	// Authorization validators list.
 	globalOpenIDValidators *openid.Validators
 
@@ -266,6 +391,9 @@ var (
 	globalAllHealState      *allHealState
 	globalSweepHealState    *allHealState
 
+	// Background scrub of the local disks, nil if disabled.
+	globalBackgroundScrub *backgroundScrub
+
 	// Add new variable global values here.
 )
 
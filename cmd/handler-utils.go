@@ -27,6 +27,7 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/minio/minio-go/v6/pkg/set"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/auth"
@@ -92,6 +93,107 @@ func isMetadataReplace(h http.Header) bool {
 	return h.Get(xhttp.AmzMetadataDirective) == "REPLACE"
 }
 
+// isTaggingDirectiveValid - check if tagging-directive is valid.
+func isTaggingDirectiveValid(h http.Header) bool {
+	_, ok := h[http.CanonicalHeaderKey(xhttp.AmzTagDirective)]
+	if ok {
+		return (isTaggingCopy(h) || isTaggingReplace(h))
+	}
+	// Tags are copied when x-amz-tagging-directive is not set.
+	return true
+}
+
+// Check if the tagging COPY is requested.
+func isTaggingCopy(h http.Header) bool {
+	return h.Get(xhttp.AmzTagDirective) == "COPY"
+}
+
+// Check if the tagging REPLACE is requested.
+func isTaggingReplace(h http.Header) bool {
+	return h.Get(xhttp.AmzTagDirective) == "REPLACE"
+}
+
+// ObjectTagsKey - internal metadata entry holding the URL encoded tag set of an object.
+const ObjectTagsKey = ReservedMetadataPrefix + "tags"
+
+// ObjectChecksumKey - internal metadata entry holding the checksum of
+// the data of an object sent on upload, as `<algorithm>:<base64 checksum>`.
+const ObjectChecksumKey = ReservedMetadataPrefix + "checksum"
+
+// ObjectACLKey - internal metadata entry holding the canned ACL of an
+// object, only saved by gateways which forward it to their backend.
+const ObjectACLKey = ReservedMetadataPrefix + "acl"
+
+// Canned ACLs accepted in the x-amz-acl header.
+var validCannedACLs = set.CreateStringSet(
+	"private",
+	"public-read",
+	"public-read-write",
+	"authenticated-read",
+	"aws-exec-read",
+	"bucket-owner-read",
+	"bucket-owner-full-control",
+)
+
+// Limits on the tag set of an object as documented by S3.
+const (
+	maxObjectTags        = 10
+	maxObjectTagKeyLen   = 128
+	maxObjectTagValueLen = 256
+)
+
+// parseObjectTags - parses and validates the URL encoded tag set sent
+// in the x-amz-tagging header.
+func parseObjectTags(v string) (url.Values, error) {
+	values, err := url.ParseQuery(v)
+	if err != nil {
+		return nil, errInvalidTag
+	}
+	if len(values) > maxObjectTags {
+		return nil, errInvalidTag
+	}
+	for k, vs := range values {
+		if k == "" || len(k) > maxObjectTagKeyLen || len(vs) != 1 || len(vs[0]) > maxObjectTagValueLen {
+			return nil, errInvalidTag
+		}
+	}
+	return values, nil
+}
+
+// extractObjectTags - validates the tag set in the x-amz-tagging header
+// and saves it in metadata, any previous tag set is removed.
+func extractObjectTags(h http.Header, metadata map[string]string) error {
+	delete(metadata, ObjectTagsKey)
+	v := h.Get(xhttp.AmzObjectTagging)
+	if v == "" {
+		return nil
+	}
+	tags, err := parseObjectTags(v)
+	if err != nil {
+		return err
+	}
+	metadata[ObjectTagsKey] = tags.Encode()
+	return nil
+}
+
+// extractObjectACL - validates the canned ACL in the x-amz-acl header
+// and saves it in metadata when running as a gateway, any previous ACL
+// is removed.
+func extractObjectACL(h http.Header, metadata map[string]string) error {
+	delete(metadata, ObjectACLKey)
+	v := h.Get(xhttp.AmzACL)
+	if v == "" {
+		return nil
+	}
+	if !validCannedACLs.Contains(v) {
+		return errInvalidCannedACL
+	}
+	if globalIsGateway {
+		metadata[ObjectACLKey] = v
+	}
+	return nil
+}
+
 // Splits an incoming path into bucket and object components.
 func path2BucketAndObject(path string) (bucket, object string) {
 	// Skip the first element if it is '/', split the rest.
@@ -216,6 +318,27 @@ func extractReqParams(r *http.Request) map[string]string {
 	}
 }
 
+// Extract object tags sent with the request, tags are only reported in
+// event notifications and a malformed tag set is ignored.
+func extractTags(r *http.Request) map[string]string {
+	if r == nil {
+		return nil
+	}
+	v := r.Header.Get(xhttp.AmzObjectTagging)
+	if v == "" {
+		return nil
+	}
+	values, err := url.ParseQuery(v)
+	if err != nil {
+		return nil
+	}
+	tags := make(map[string]string, len(values))
+	for k, vs := range values {
+		tags[k] = vs[0]
+	}
+	return tags
+}
+
 // Extract response elements to be sent with event notifiation.
 func extractRespElements(w http.ResponseWriter) map[string]string {
 
@@ -20,12 +20,16 @@ import (
 	"bytes"
 	"context"
 	"encoding/xml"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/url"
 	"os"
 	"reflect"
 	"strings"
 	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Tests validate bucket LocationConstraint.
@@ -208,6 +212,103 @@ func TestExtractMetadataHeaders(t *testing.T) {
 	}
 }
 
+// Tests object tags extraction from the x-amz-tagging header.
+func TestExtractTags(t *testing.T) {
+	testCases := []struct {
+		tagging string
+		tags    map[string]string
+	}{
+		{tagging: "", tags: nil},
+		{tagging: "project=blue", tags: map[string]string{"project": "blue"}},
+		{tagging: "project=blue&team=storage%20ops", tags: map[string]string{"project": "blue", "team": "storage ops"}},
+		{tagging: "project=%zz", tags: nil},
+	}
+
+	for i, testCase := range testCases {
+		r := &http.Request{Header: http.Header{}}
+		if testCase.tagging != "" {
+			r.Header.Set(xhttp.AmzObjectTagging, testCase.tagging)
+		}
+		if tags := extractTags(r); !reflect.DeepEqual(tags, testCase.tags) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase.tags, tags)
+		}
+	}
+}
+
+// Tests validation of the tag set sent in the x-amz-tagging header.
+func TestExtractObjectTags(t *testing.T) {
+	tooMany := make(url.Values)
+	for i := 0; i <= maxObjectTags; i++ {
+		tooMany.Set(fmt.Sprintf("key%d", i), "value")
+	}
+	testCases := []struct {
+		tagging   string
+		tags      string
+		shouldErr bool
+	}{
+		{tagging: "", tags: ""},
+		{tagging: "project=blue", tags: "project=blue"},
+		{tagging: "team=storage%20ops&project=blue", tags: "project=blue&team=storage+ops"},
+		{tagging: "project=", tags: "project="},
+		{tagging: "project=%zz", shouldErr: true},
+		{tagging: "=blue", shouldErr: true},
+		{tagging: "project=blue&project=red", shouldErr: true},
+		{tagging: "project=" + strings.Repeat("a", maxObjectTagValueLen+1), shouldErr: true},
+		{tagging: strings.Repeat("a", maxObjectTagKeyLen+1) + "=blue", shouldErr: true},
+		{tagging: tooMany.Encode(), shouldErr: true},
+	}
+
+	for i, testCase := range testCases {
+		h := http.Header{}
+		if testCase.tagging != "" {
+			h.Set(xhttp.AmzObjectTagging, testCase.tagging)
+		}
+		metadata := map[string]string{ObjectTagsKey: "previous=tags"}
+		err := extractObjectTags(h, metadata)
+		if (err != nil) != testCase.shouldErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && metadata[ObjectTagsKey] != testCase.tags {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.tags, metadata[ObjectTagsKey])
+		}
+	}
+}
+
+// Tests validation of the canned ACL sent in the x-amz-acl header.
+func TestExtractObjectACL(t *testing.T) {
+	defer func(isGateway bool) { globalIsGateway = isGateway }(globalIsGateway)
+
+	testCases := []struct {
+		acl       string
+		isGateway bool
+		saved     string
+		shouldErr bool
+	}{
+		{acl: "", isGateway: true, saved: ""},
+		{acl: "public-read", isGateway: true, saved: "public-read"},
+		{acl: "bucket-owner-full-control", isGateway: true, saved: "bucket-owner-full-control"},
+		{acl: "public-read", isGateway: false, saved: ""},
+		{acl: "public", isGateway: true, shouldErr: true},
+		{acl: "public", isGateway: false, shouldErr: true},
+	}
+
+	for i, testCase := range testCases {
+		globalIsGateway = testCase.isGateway
+		h := http.Header{}
+		if testCase.acl != "" {
+			h.Set(xhttp.AmzACL, testCase.acl)
+		}
+		metadata := map[string]string{ObjectACLKey: "private"}
+		err := extractObjectACL(h, metadata)
+		if (err != nil) != testCase.shouldErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err == nil && metadata[ObjectACLKey] != testCase.saved {
+			t.Errorf("Test %d: expected %q, got %q", i+1, testCase.saved, metadata[ObjectACLKey])
+		}
+	}
+}
+
 // Test getResource()
 func TestGetResource(t *testing.T) {
 	testCases := []struct {
@@ -219,6 +320,8 @@ func TestGetResource(t *testing.T) {
 		{"/a/b/c", "test.mydomain.com", []string{"mydomain.com"}, "/test/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", []string{"notmydomain.com"}, "/a/b/c"},
 		{"/a/b/c", "test.mydomain.com", nil, "/a/b/c"},
+		{"/a/b/c", "test.s3.mydomain.com:9000", []string{"s3.mydomain.com", "mydomain.com"}, "/test/a/b/c"},
+		{"/a/b/c", "test.mydomain.org", []string{"mydomain.com", "mydomain.org"}, "/test/a/b/c"},
 	}
 	for i, test := range testCases {
 		gotResource, err := getResource(test.p, test.host, test.domains)
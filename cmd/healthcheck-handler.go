@@ -30,15 +30,34 @@ const (
 	minioHealthGoroutineThreshold = 10000
 )
 
-// ReadinessCheckHandler -- checks if there are more than threshold
-// number of goroutines running, returns service unavailable.
+// ReadinessCheckHandler -- checks if the object layer is able to serve
+// requests, i.e. backend disks are reachable with quorum, the format lock
+// is held or gateway credentials are valid for the backend. Returns service
+// unavailable also when there are more than threshold number of goroutines
+// running.
 //
 // Readiness probes are used to detect situations where application
 // is under heavy load and temporarily unable to serve. In a orchestrated
 // setup like Kubernetes, containers reporting that they are not ready do
 // not receive traffic through Kubernetes Services.
 func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ReadinessCheckHandler")
+
+	objLayer := newObjectLayerFn()
+	// Service not initialized yet
+	if objLayer == nil {
+		w.Header().Set(xhttp.MinIOServerStatus, "Server-not-initialized")
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+
 	if err := goroutineCountCheck(minioHealthGoroutineThreshold); err != nil {
+		logger.LogOnceIf(ctx, err, struct{}{})
+		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
+		return
+	}
+
+	if !objLayer.IsReady(ctx) {
 		writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
 		return
 	}
@@ -49,6 +68,8 @@ func ReadinessCheckHandler(w http.ResponseWriter, r *http.Request) {
 // If not, server is considered to have failed and needs to be restarted.
 // Liveness probes are used to detect situations where application (minio)
 // has gone into a state where it can not recover except by being restarted.
+// Gateways are always considered live, since restarting them does not help
+// while their backend is unavailable, which is reported by readiness instead.
 func LivenessCheckHandler(w http.ResponseWriter, r *http.Request) {
 	ctx := newContext(r, w, "LivenessCheckHandler")
 
@@ -64,19 +85,10 @@ func LivenessCheckHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if !globalIsXL && !globalIsDistXL {
-		s := objLayer.StorageInfo(ctx)
-		// Gateways don't provide disk info.
-		if s.Backend.Type == Unknown {
-			// ListBuckets to confirm gateway backend is up
-			if _, err := objLayer.ListBuckets(ctx); err != nil {
-				logger.LogOnceIf(ctx, err, struct{}{})
-				writeResponse(w, http.StatusServiceUnavailable, nil, mimeNone)
-				return
-			}
-			writeResponse(w, http.StatusOK, nil, mimeNone)
-			return
-		}
+	// Gateway and memory backends have no local disks to check.
+	if globalIsGateway || globalIsMemory {
+		writeResponse(w, http.StatusOK, nil, mimeNone)
+		return
 	}
 
 	// For FS and Erasure backend, check if local disks are up.
@@ -17,6 +17,10 @@
 package cmd
 
 import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
 	"testing"
 	"time"
 )
@@ -42,3 +46,43 @@ func TestGoroutineCountCheck(t *testing.T) {
 		}
 	}
 }
+
+func TestReadinessCheckHandler(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	readiness := func() int {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, healthCheckPathPrefix+healthCheckReadinessPath, nil)
+		ReadinessCheckHandler(rec, req)
+		return rec.Code
+	}
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = nil
+	globalObjLayerMutex.Unlock()
+	if code := readiness(); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d before initialization, got %d", http.StatusServiceUnavailable, code)
+	}
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = objLayer
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+	if code := readiness(); code != http.StatusOK {
+		t.Fatalf("expected %d, got %d", http.StatusOK, code)
+	}
+
+	// Shutdown releases the lock on format.json.
+	objLayer.Shutdown(context.Background())
+	if code := readiness(); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected %d after shutdown, got %d", http.StatusServiceUnavailable, code)
+	}
+}
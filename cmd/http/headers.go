@@ -49,6 +49,20 @@ const (
 
 	// S3 storage class
 	AmzStorageClass = "x-amz-storage-class"
+	AmzRestore      = "X-Amz-Restore"
+
+	// S3 object tagging
+	AmzObjectTagging = "X-Amz-Tagging"
+	AmzTagCount      = "x-amz-tagging-count"
+	AmzTagDirective  = "X-Amz-Tagging-Directive"
+
+	// S3 canned ACL
+	AmzACL = "X-Amz-Acl"
+
+	// S3 object checksums
+	AmzChecksumPrefix   = "X-Amz-Checksum-"
+	AmzChecksumMode     = "X-Amz-Checksum-Mode"
+	AmzObjectAttributes = "X-Amz-Object-Attributes"
 
 	// S3 extensions
 	AmzCopySourceIfModifiedSince   = "x-amz-copy-source-if-modified-since"
@@ -72,6 +86,7 @@ const (
 	AmzCredential           = "X-Amz-Credential"
 	AmzSecurityToken        = "X-Amz-Security-Token"
 	AmzDecodedContentLength = "X-Amz-Decoded-Content-Length"
+	AmzTrailer              = "X-Amz-Trailer"
 
 	// Signature v2 related constants
 	AmzSignatureV2 = "Signature"
@@ -85,4 +100,13 @@ const (
 
 	// Server-Status
 	MinIOServerStatus = "x-minio-server-status"
+
+	// Position to send the next append of an object at.
+	MinIONextAppendPosition = "x-minio-next-append-position"
+
+	// Deletes a bucket along with all of its objects.
+	MinIOForceDelete = "x-minio-force-delete"
+
+	// Object served without bitrot verification as no parity is left.
+	MinIODegradedRead = "x-minio-degraded-read"
 )
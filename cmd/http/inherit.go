@@ -0,0 +1,99 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// EnvListenFDs - environment variable passing the listening sockets
+// inherited from a previous server process as comma separated
+// address=fd pairs.
+const EnvListenFDs = "_MINIO_LISTEN_FDS"
+
+// inheritedListeners - returns the listening sockets inherited from a
+// previous server process by server address. The environment variable
+// is cleared such that sockets are only inherited once.
+func inheritedListeners() (map[string]*net.TCPListener, error) {
+	value, ok := os.LookupEnv(EnvListenFDs)
+	if !ok {
+		return nil, nil
+	}
+	os.Unsetenv(EnvListenFDs)
+
+	listeners := make(map[string]*net.TCPListener)
+	for _, entry := range strings.Split(value, ",") {
+		i := strings.LastIndex(entry, "=")
+		if i < 0 {
+			return listeners, fmt.Errorf("invalid inherited listener %s found", entry)
+		}
+		addr := entry[:i]
+		fd, err := strconv.Atoi(entry[i+1:])
+		if err != nil {
+			return listeners, fmt.Errorf("invalid inherited listener %s found", entry)
+		}
+
+		f := os.NewFile(uintptr(fd), addr)
+		l, err := net.FileListener(f)
+		// Listener holds its own copy of fd.
+		f.Close()
+		if err != nil {
+			return listeners, err
+		}
+		tcpListener, ok := l.(*net.TCPListener)
+		if !ok {
+			l.Close()
+			return listeners, fmt.Errorf("unexpected listener type found %v, expected net.TCPListener", l)
+		}
+		listeners[addr] = tcpListener
+	}
+	return listeners, nil
+}
+
+// InheritListeners - duplicates the listening sockets of the server such
+// that they are inherited by a new server process replacing the current
+// one using exec, the returned environment entry passes them along.
+// Incoming connections are queued on the inherited sockets until the
+// new process accepts them, hence no connection is refused while the
+// server restarts.
+func (srv *Server) InheritListeners() (string, error) {
+	srv.listenerMutex.Lock()
+	defer srv.listenerMutex.Unlock()
+	if srv.listener == nil {
+		return "", errors.New("server not initialized")
+	}
+
+	var fds []int
+	var entries []string
+	for i, tcpListener := range srv.listener.tcpListeners {
+		fd, err := inheritableFD(tcpListener)
+		if err != nil {
+			for _, fd := range fds {
+				closeFD(fd)
+			}
+			return "", err
+		}
+		fds = append(fds, fd)
+		entries = append(entries, srv.listener.serverAddrs[i]+"="+strconv.Itoa(fd))
+	}
+	return EnvListenFDs + "=" + strings.Join(entries, ","), nil
+}
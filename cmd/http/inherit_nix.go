@@ -0,0 +1,44 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd || rumprun
+// +build linux darwin dragonfly freebsd netbsd openbsd rumprun
+
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"net"
+	"syscall"
+)
+
+// inheritableFD - returns a duplicate of the listener's socket which,
+// unlike the sockets opened by Go, is kept open across exec.
+func inheritableFD(tcpListener *net.TCPListener) (fd int, err error) {
+	rawConn, err := tcpListener.SyscallConn()
+	if err != nil {
+		return -1, err
+	}
+	if cerr := rawConn.Control(func(lfd uintptr) {
+		fd, err = syscall.Dup(int(lfd))
+	}); cerr != nil {
+		return -1, cerr
+	}
+	return fd, err
+}
+
+func closeFD(fd int) {
+	syscall.Close(fd)
+}
@@ -0,0 +1,32 @@
+//go:build windows || plan9 || solaris
+// +build windows plan9 solaris
+
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"errors"
+	"net"
+)
+
+// inheritableFD - sockets cannot be inherited across exec on this platform.
+func inheritableFD(tcpListener *net.TCPListener) (int, error) {
+	return -1, errors.New("inheriting listeners is not supported on this platform")
+}
+
+func closeFD(fd int) {}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package http
+
+import (
+	"net"
+	"os"
+	"runtime"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestInheritedListeners(t *testing.T) {
+	if runtime.GOOS == "windows" || runtime.GOOS == "plan9" || runtime.GOOS == "solaris" {
+		t.Skip("inheriting listeners is not supported on this platform")
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverAddr := l.Addr().String()
+	fd, err := inheritableFD(l.(*net.TCPListener))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The duplicated socket keeps listening on serverAddr.
+	l.Close()
+
+	os.Setenv(EnvListenFDs, serverAddr+"="+strconv.Itoa(fd))
+	listener, err := newHTTPListener([]string{serverAddr}, time.Duration(0), nil, nil)
+	if err != nil {
+		t.Fatalf("error: expected = <nil>, got = %v", err)
+	}
+	defer listener.Close()
+
+	if _, ok := os.LookupEnv(EnvListenFDs); ok {
+		t.Fatalf("expected %s to be cleared", EnvListenFDs)
+	}
+
+	conn, err := net.Dial("tcp", serverAddr)
+	if err != nil {
+		t.Fatalf("error: expected = <nil>, got = %v", err)
+	}
+	defer conn.Close()
+
+	serverConn, err := listener.Accept()
+	if err != nil {
+		t.Fatalf("error: expected = <nil>, got = %v", err)
+	}
+	serverConn.Close()
+
+	os.Setenv(EnvListenFDs, serverAddr+"=invalid")
+	if _, err = inheritedListeners(); err == nil {
+		t.Fatal("expected error for an invalid inherited listener")
+	}
+}
@@ -35,6 +35,7 @@ type acceptResult struct {
 type httpListener struct {
 	mutex                  sync.Mutex         // to guard Close() method.
 	tcpListeners           []*net.TCPListener // underlaying TCP listeners.
+	serverAddrs            []string           // server addresses of TCP listeners.
 	acceptCh               chan acceptResult  // channel where all TCP listeners write accepted connection.
 	doneCh                 chan struct{}      // done channel for TCP listener goroutines.
 	tcpKeepAliveTimeout    time.Duration
@@ -190,7 +191,25 @@ func newHTTPListener(serverAddrs []string,
 		}
 	}()
 
+	// Listeners inherited from a previous server process
+	// are used in place of listening again.
+	inherited, err := inheritedListeners()
+	defer func() {
+		for _, tcpListener := range inherited {
+			tcpListener.Close()
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
 	for _, serverAddr := range serverAddrs {
+		if tcpListener, ok := inherited[serverAddr]; ok {
+			delete(inherited, serverAddr)
+			tcpListeners = append(tcpListeners, tcpListener)
+			continue
+		}
+
 		var l net.Listener
 		if l, err = listen("tcp", serverAddr); err != nil {
 			if l, err = fallbackListen("tcp", serverAddr); err != nil {
@@ -208,6 +227,7 @@ func newHTTPListener(serverAddrs []string,
 
 	listener = &httpListener{
 		tcpListeners:           tcpListeners,
+		serverAddrs:            serverAddrs,
 		tcpKeepAliveTimeout:    tcpKeepAliveTimeout,
 		updateBytesReadFunc:    updateBytesReadFunc,
 		updateBytesWrittenFunc: updateBytesWrittenFunc,
@@ -1116,13 +1116,7 @@ func (sys *IAMSys) IsAllowedSTS(args iampolicy.Args) bool {
 		if len(policies) == 0 {
 			return false
 		}
-		combinedPolicy := policies[0]
-		for i := 1; i < len(policies); i++ {
-			combinedPolicy.Statements =
-				append(combinedPolicy.Statements,
-					policies[i].Statements...)
-		}
-		return combinedPolicy.IsAllowed(args)
+		return combinePolicies(policies).IsAllowed(args)
 	}
 
 	pname, ok := args.Claims[iampolicy.PolicyName]
@@ -1234,12 +1228,104 @@ func (sys *IAMSys) IsAllowed(args iampolicy.Args) bool {
 	if len(availablePolicies) == 0 {
 		return false
 	}
-	combinedPolicy := availablePolicies[0]
-	for i := 1; i < len(availablePolicies); i++ {
-		combinedPolicy.Statements = append(combinedPolicy.Statements,
-			availablePolicies[i].Statements...)
+	return combinePolicies(availablePolicies).IsAllowed(args)
+}
+
+// IsDenied - checks whether the action of args is explicitly denied
+// to the account by a deny statement, as opposed to not being allowed
+// by any statement. Policies are evaluated as for the owner, whom only
+// deny statements apply to.
+func (sys *IAMSys) IsDenied(args iampolicy.Args) bool {
+	// OPA does not tell explicit denials apart.
+	if globalPolicyOPA != nil {
+		return !sys.IsAllowed(args)
+	}
+
+	ownerArgs := args
+	ownerArgs.IsOwner = true
+	if len(args.Claims) > 0 {
+		return !sys.IsAllowedSTS(ownerArgs)
+	}
+
+	// Policies don't apply to the owner.
+	if args.IsOwner {
+		return false
 	}
-	return combinedPolicy.IsAllowed(args)
+
+	policies, err := sys.PolicyDBGet(args.AccountName, false)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+		return true
+	}
+
+	sys.RLock()
+	defer sys.RUnlock()
+
+	var availablePolicies []iampolicy.Policy
+	for _, pname := range policies {
+		p, found := sys.iamPolicyDocsMap[pname]
+		if found {
+			availablePolicies = append(availablePolicies, p)
+		}
+	}
+	if len(availablePolicies) == 0 {
+		return false
+	}
+	return !combinePolicies(availablePolicies).IsAllowed(ownerArgs)
+}
+
+// BucketMatcher - returns a function checking whether the action of
+// args is allowed on a bucket, as IsAllowed does with args.BucketName
+// set to the bucket. The policies of the account are looked up and
+// compiled once, instead of for every bucket, except for OPA and
+// temporary credentials which are checked by IsAllowed.
+func (sys *IAMSys) BucketMatcher(args iampolicy.Args) func(bucket string) bool {
+	if globalPolicyOPA != nil || len(args.Claims) > 0 {
+		return func(bucket string) bool {
+			bucketArgs := args
+			bucketArgs.BucketName = bucket
+			return sys.IsAllowed(bucketArgs)
+		}
+	}
+
+	// Policies don't apply to the owner.
+	if args.IsOwner {
+		return func(string) bool { return true }
+	}
+
+	policies, err := sys.PolicyDBGet(args.AccountName, false)
+	if err != nil {
+		logger.LogIf(context.Background(), err)
+	}
+
+	sys.RLock()
+	var availablePolicies []iampolicy.Policy
+	for _, pname := range policies {
+		p, found := sys.iamPolicyDocsMap[pname]
+		if found {
+			availablePolicies = append(availablePolicies, p)
+		}
+	}
+	sys.RUnlock()
+
+	if len(availablePolicies) == 0 {
+		return func(string) bool { return false }
+	}
+	return combinePolicies(availablePolicies).BucketMatcher(args)
+}
+
+// combinePolicies - returns a policy with the statements of all given
+// policies, i.e. the union of the user's and their groups' policies.
+// The statements are copied so that the policies cached in IAMSys are
+// never modified by concurrent requests.
+func combinePolicies(policies []iampolicy.Policy) iampolicy.Policy {
+	combinedPolicy := policies[0]
+	var statements []iampolicy.Statement
+	for _, p := range policies {
+		statements = append(statements, p.Statements...)
+	}
+	combinedPolicy.Statements = statements
+	return combinedPolicy
 }
 
 // Set default canned policies only if not already overridden by users.
@@ -1256,6 +1342,10 @@ func setDefaultCannedPolicies(policies map[string]iampolicy.Policy) {
 	if !ok {
 		policies["readwrite"] = iampolicy.ReadWrite
 	}
+	_, ok = policies["diagnostics"]
+	if !ok {
+		policies["diagnostics"] = iampolicy.AdminDiagnostics
+	}
 }
 
 // buildUserGroupMemberships - builds the memberships map. IMPORTANT:
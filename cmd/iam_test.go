@@ -0,0 +1,57 @@
+/*
+ * MinIO Cloud Storage, (C) 2018-2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+func TestCombinePolicies(t *testing.T) {
+	readOnly := iampolicy.ReadOnly
+	// Leave spare capacity so that appending to the statements
+	// of the first policy would write into its backing array.
+	readOnly.Statements = append(make([]iampolicy.Statement, 0, 4), readOnly.Statements...)
+	writeOnly := iampolicy.WriteOnly
+
+	combined := combinePolicies([]iampolicy.Policy{readOnly, writeOnly})
+	if len(combined.Statements) != len(readOnly.Statements)+len(writeOnly.Statements) {
+		t.Fatalf("expected %d statements, got %d", len(readOnly.Statements)+len(writeOnly.Statements), len(combined.Statements))
+	}
+
+	for _, action := range []iampolicy.Action{iampolicy.GetObjectAction, iampolicy.PutObjectAction} {
+		args := iampolicy.Args{
+			AccountName:     "user",
+			Action:          action,
+			BucketName:      "mybucket",
+			ObjectName:      "myobject",
+			ConditionValues: map[string][]string{},
+		}
+		if !combined.IsAllowed(args) {
+			t.Fatalf("expected %s to be allowed by combined policy", action)
+		}
+	}
+
+	spare := readOnly.Statements[len(readOnly.Statements):cap(readOnly.Statements)]
+	for _, statement := range spare {
+		if !reflect.DeepEqual(statement, iampolicy.Statement{}) {
+			t.Fatalf("combining policies modified the statements of a cached policy")
+		}
+	}
+}
@@ -48,6 +48,7 @@ var (
 	errAuthentication       = errors.New("Authentication failed, check your access credentials")
 	errNoAuthToken          = errors.New("JWT token missing")
 	errIncorrectCreds       = errors.New("Current access key or secret key is incorrect")
+	errSessionTokenExpired  = errors.New("The provided token has expired")
 )
 
 func authenticateJWTUsers(accessKey, secretKey string, expiry time.Duration) (string, error) {
@@ -57,8 +58,18 @@ func authenticateJWTUsers(accessKey, secretKey string, expiry time.Duration) (st
 	}
 
 	serverCred := globalServerConfig.GetCredential()
-	if serverCred.AccessKey != passedCredential.AccessKey {
-		var ok bool
+	if oldCred, ok := globalOldCredSys.Get(accessKey); ok {
+		// The old root credential logs in as the owner, for no
+		// longer than it remains valid.
+		if !oldCred.Equal(passedCredential) {
+			return "", errAuthentication
+		}
+		if validity := oldCred.Expiration.Sub(UTCNow()); validity < expiry {
+			expiry = validity
+		}
+		accessKey = serverCred.AccessKey
+		passedCredential = serverCred
+	} else if serverCred.AccessKey != passedCredential.AccessKey {
 		serverCred, ok = globalIAMSys.GetUser(accessKey)
 		if !ok {
 			return "", errInvalidAccessKeyID
@@ -116,6 +116,9 @@ func newApp(name string) *cli.App {
 	// Register all commands.
 	registerCommand(serverCmd)
 	registerCommand(gatewayCmd)
+	registerCommand(relayCmd)
+	registerCommand(mountCmd)
+	registerCommand(migrateCmd)
 	registerCommand(versionCmd)
 
 	// Set up app.
@@ -0,0 +1,1082 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	pathutil "path"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/mimedb"
+	"github.com/minio/minio/pkg/policy"
+)
+
+// memObject - an object held in memory, data is never modified
+// in place hence it may be handed out to readers without copying.
+type memObject struct {
+	meta     map[string]string
+	parts    []ObjectPartInfo
+	data     []byte
+	modTime  time.Time
+	accessed time.Time
+}
+
+// memBucket - a bucket held in memory.
+type memBucket struct {
+	created time.Time
+	objects map[string]*memObject
+}
+
+// memPart - a part of an ongoing multipart upload.
+type memPart struct {
+	etag       string
+	actualSize int64
+	data       []byte
+	modTime    time.Time
+}
+
+// memUpload - an ongoing multipart upload.
+type memUpload struct {
+	bucket    string
+	object    string
+	initiated time.Time
+	meta      map[string]string
+	parts     map[int]*memPart
+}
+
+// memObjects - implements ObjectLayer entirely in memory, all
+// state is lost when the server exits. When maxSize is set the
+// total size of objects and parts is capped, once the cap is
+// reached least recently accessed objects are evicted if evict
+// is set, otherwise uploads fail with StorageFull.
+type memObjects struct {
+	mu      sync.RWMutex
+	buckets map[string]*memBucket
+	uploads map[string]*memUpload
+
+	maxSize int64 // 0 means unlimited.
+	evict   bool
+	used    int64
+}
+
+// NewMemObjectLayer - returns a new in-memory object layer holding
+// at most maxSize bytes, 0 means unlimited.
+func NewMemObjectLayer(maxSize int64, evict bool) ObjectLayer {
+	m := &memObjects{
+		buckets: make(map[string]*memBucket),
+		uploads: make(map[string]*memUpload),
+		maxSize: maxSize,
+		evict:   evict,
+	}
+	// Server configuration, IAM and bucket metadata are saved
+	// in the meta bucket.
+	m.buckets[minioMetaBucket] = &memBucket{
+		created: UTCNow(),
+		objects: make(map[string]*memObject),
+	}
+	return m
+}
+
+// reserve - makes room for size additional bytes, caller must hold
+// the write lock. Objects in the meta bucket and the object being
+// replaced are never evicted.
+func (m *memObjects) reserve(size int64, bucket, object string) error {
+	if m.maxSize <= 0 {
+		m.used += size
+		return nil
+	}
+	for m.used+size > m.maxSize {
+		if !m.evict || !m.evictOne(bucket, object) {
+			return StorageFull{}
+		}
+	}
+	m.used += size
+	return nil
+}
+
+// evictOne - removes the least recently accessed object, returns
+// false if there is nothing left to evict.
+func (m *memObjects) evictOne(skipBucket, skipObject string) bool {
+	var (
+		victim     *memObject
+		victimB    *memBucket
+		victimName string
+	)
+	for bucket, b := range m.buckets {
+		if isMinioMetaBucketName(bucket) {
+			continue
+		}
+		for name, obj := range b.objects {
+			if bucket == skipBucket && name == skipObject {
+				continue
+			}
+			if victim == nil || obj.accessed.Before(victim.accessed) {
+				victim, victimB, victimName = obj, b, name
+			}
+		}
+	}
+	if victim == nil {
+		return false
+	}
+	delete(victimB.objects, victimName)
+	m.used -= int64(len(victim.data))
+	return true
+}
+
+func (m *memObjects) toObjectInfo(bucket, object string, obj *memObject) ObjectInfo {
+	meta := make(map[string]string, len(obj.meta))
+	for k, v := range obj.meta {
+		meta[k] = v
+	}
+
+	// Guess content-type from the extension if possible.
+	if meta["content-type"] == "" {
+		meta["content-type"] = mimedb.TypeByExtension(pathutil.Ext(object))
+	}
+
+	objInfo := ObjectInfo{
+		Bucket:  bucket,
+		Name:    object,
+		ModTime: obj.modTime,
+		Size:    int64(len(obj.data)),
+		Parts:   obj.parts,
+	}
+	if hasSuffix(object, SlashSeparator) {
+		meta["etag"] = emptyETag
+		meta["content-type"] = "application/octet-stream"
+		objInfo.IsDir = true
+	}
+
+	objInfo.ETag = extractETag(meta)
+	objInfo.ContentType = meta["content-type"]
+	objInfo.ContentEncoding = meta["content-encoding"]
+	if storageClass, ok := meta[xhttp.AmzStorageClass]; ok {
+		objInfo.StorageClass = storageClass
+	} else {
+		objInfo.StorageClass = globalMinioDefaultStorageClass
+	}
+	if exp, ok := meta["expires"]; ok {
+		if t, e := time.Parse(http.TimeFormat, exp); e == nil {
+			objInfo.Expires = t.UTC()
+		}
+	}
+	objInfo.UserDefined = cleanMetadata(meta)
+	return objInfo
+}
+
+// getObject - returns the object, caller must hold the lock.
+func (m *memObjects) getObject(bucket, object string) (*memObject, error) {
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return nil, BucketNotFound{Bucket: bucket}
+	}
+	obj, ok := b.objects[object]
+	if !ok {
+		return nil, ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	return obj, nil
+}
+
+// Shutdown - nothing to release, all state is dropped with the process.
+func (m *memObjects) Shutdown(ctx context.Context) error {
+	return nil
+}
+
+// StorageInfo - returns the memory used and the configured cap.
+func (m *memObjects) StorageInfo(ctx context.Context) (si StorageInfo) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	si.Used = uint64(m.used)
+	if m.maxSize > 0 {
+		si.Total = uint64(m.maxSize)
+		si.Available = uint64(m.maxSize - m.used)
+	}
+	si.Backend.Type = Unknown
+	return si
+}
+
+// MakeBucketWithLocation - create a new bucket, location is ignored.
+func (m *memObjects) MakeBucketWithLocation(ctx context.Context, bucket, location string) error {
+	if isReservedOrInvalidBucket(bucket, true) {
+		return BucketNameInvalid{Bucket: bucket}
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.buckets[bucket]; ok {
+		return BucketExists{Bucket: bucket}
+	}
+	m.buckets[bucket] = &memBucket{
+		created: UTCNow(),
+		objects: make(map[string]*memObject),
+	}
+	return nil
+}
+
+// GetBucketInfo - returns bucket info.
+func (m *memObjects) GetBucketInfo(ctx context.Context, bucket string) (bi BucketInfo, e error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return bi, BucketNotFound{Bucket: bucket}
+	}
+	return BucketInfo{Name: bucket, Created: b.created}, nil
+}
+
+// ListBuckets - list all buckets except the reserved ones.
+func (m *memObjects) ListBuckets(ctx context.Context) ([]BucketInfo, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	bucketInfos := make([]BucketInfo, 0, len(m.buckets))
+	for bucket, b := range m.buckets {
+		if isReservedOrInvalidBucket(bucket, false) {
+			continue
+		}
+		bucketInfos = append(bucketInfos, BucketInfo{Name: bucket, Created: b.created})
+	}
+
+	// Sort bucket infos by bucket name.
+	sort.Sort(byBucketName(bucketInfos))
+	return bucketInfos, nil
+}
+
+// DeleteBucket - delete an empty bucket and its metadata, when
+// forceDelete is set the objects of the bucket are deleted too.
+func (m *memObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	if isReservedOrInvalidBucket(bucket, false) {
+		return BucketNameInvalid{Bucket: bucket}
+	}
+
+	m.mu.Lock()
+	b, ok := m.buckets[bucket]
+	if !ok {
+		m.mu.Unlock()
+		return BucketNotFound{Bucket: bucket}
+	}
+	if len(b.objects) > 0 && !forceDelete {
+		m.mu.Unlock()
+		return BucketNotEmpty{Bucket: bucket}
+	}
+	for _, obj := range b.objects {
+		m.used -= int64(len(obj.data))
+	}
+	delete(m.buckets, bucket)
+	for uploadID, upload := range m.uploads {
+		if upload.bucket == bucket {
+			m.abortUpload(uploadID)
+		}
+	}
+	m.mu.Unlock()
+
+	// Delete all bucket metadata.
+	deleteBucketMetadata(ctx, bucket, m)
+	return nil
+}
+
+// ListObjects - lists objects in a bucket, any delimiter is supported.
+func (m *memObjects) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, e error) {
+	// Delimiter is not validated since any delimiter is supported.
+	if err := checkListObjsArgs(ctx, bucket, prefix, marker, "", m); err != nil {
+		return loi, err
+	}
+
+	if maxKeys == 0 {
+		return loi, nil
+	}
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return loi, BucketNotFound{Bucket: bucket}
+	}
+
+	names := make([]string, 0, len(b.objects))
+	for name := range b.objects {
+		if hasPrefix(name, prefix) && name > marker {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var count int
+	for _, name := range names {
+		entry := name
+		if delimiter != "" {
+			if i := strings.Index(name[len(prefix):], delimiter); i >= 0 {
+				entry = name[:len(prefix)+i+len(delimiter)]
+			}
+		}
+		// Entries sharing a common prefix are listed once, a common
+		// prefix at or before the marker was already listed.
+		if entry != name && (entry <= marker || entry == loi.NextMarker) {
+			continue
+		}
+		if count == maxKeys {
+			loi.IsTruncated = true
+			break
+		}
+		if entry != name {
+			loi.Prefixes = append(loi.Prefixes, entry)
+		} else {
+			loi.Objects = append(loi.Objects, m.toObjectInfo(bucket, name, b.objects[name]))
+		}
+		loi.NextMarker = entry
+		count++
+	}
+	if !loi.IsTruncated {
+		loi.NextMarker = ""
+	}
+	return loi, nil
+}
+
+// ListObjectsV2 lists all blobs in bucket filtered by prefix
+func (m *memObjects) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+
+	loi, err := m.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	if err != nil {
+		return result, err
+	}
+
+	listObjectsV2Info := ListObjectsV2Info{
+		IsTruncated:           loi.IsTruncated,
+		ContinuationToken:     continuationToken,
+		NextContinuationToken: loi.NextMarker,
+		Objects:               loi.Objects,
+		Prefixes:              loi.Prefixes,
+	}
+	return listObjectsV2Info, err
+}
+
+// GetObjectNInfo - returns object info and a reader for object content.
+func (m *memObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return nil, err
+	}
+
+	m.mu.Lock()
+	obj, err := m.getObject(bucket, object)
+	if err != nil {
+		m.mu.Unlock()
+		return nil, err
+	}
+	obj.accessed = UTCNow()
+	objInfo := m.toObjectInfo(bucket, object, obj)
+	data := obj.data
+	m.mu.Unlock()
+
+	objReaderFn, off, length, rErr := NewGetObjectReader(rs, objInfo, opts.CheckCopyPrecondFn)
+	if rErr != nil {
+		return nil, rErr
+	}
+
+	// Check if range is valid
+	size := int64(len(data))
+	if off > size || off+length > size {
+		err = InvalidRange{off, length, size}
+		logger.LogIf(ctx, err, logger.Application)
+		return nil, err
+	}
+
+	return objReaderFn(bytes.NewReader(data[off:off+length]), h, opts.CheckCopyPrecondFn)
+}
+
+// GetObject - reads an object from memory.
+func (m *memObjects) GetObject(ctx context.Context, bucket, object string, offset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error) {
+	if err = checkGetObjArgs(ctx, bucket, object); err != nil {
+		return err
+	}
+
+	// Offset cannot be negative, writer cannot be nil.
+	if offset < 0 || writer == nil {
+		logger.LogIf(ctx, errUnexpected, logger.Application)
+		return toObjectErr(errUnexpected, bucket, object)
+	}
+
+	m.mu.Lock()
+	obj, err := m.getObject(bucket, object)
+	if err != nil {
+		m.mu.Unlock()
+		return err
+	}
+	obj.accessed = UTCNow()
+	objETag := extractETag(obj.meta)
+	data := obj.data
+	m.mu.Unlock()
+
+	if etag != "" && etag != defaultEtag && etag != objETag {
+		logger.LogIf(ctx, InvalidETag{}, logger.Application)
+		return toObjectErr(InvalidETag{}, bucket, object)
+	}
+
+	// For negative length we read everything.
+	size := int64(len(data))
+	if length < 0 {
+		length = size - offset
+	}
+
+	// Reply back invalid range if the input offset and length fall out of range.
+	if offset > size || offset+length > size {
+		err = InvalidRange{offset, length, size}
+		logger.LogIf(ctx, err, logger.Application)
+		return err
+	}
+
+	bufSize := int64(readSizeV1)
+	if length > 0 && bufSize > length {
+		bufSize = length
+	}
+	buf := make([]byte, int(bufSize))
+	_, err = io.CopyBuffer(writer, io.NewSectionReader(bytes.NewReader(data), offset, length), buf)
+	return toObjectErr(err, bucket, object)
+}
+
+// GetObjectInfo - returns object info.
+func (m *memObjects) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (oi ObjectInfo, e error) {
+	if err := checkGetObjArgs(ctx, bucket, object); err != nil {
+		return oi, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	obj, err := m.getObject(bucket, object)
+	if err != nil {
+		return oi, err
+	}
+	return m.toObjectInfo(bucket, object, obj), nil
+}
+
+// PutObject - saves an object in memory.
+func (m *memObjects) PutObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if err = checkPutObjectArgs(ctx, bucket, object, m, r.Size()); err != nil {
+		return ObjectInfo{}, err
+	}
+	return m.putObject(ctx, bucket, object, r, opts)
+}
+
+func (m *memObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	data := r.Reader
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return ObjectInfo{}, errInvalidArgument
+	}
+
+	// Fail early for objects which can never fit.
+	if m.maxSize > 0 && data.Size() > m.maxSize {
+		return ObjectInfo{}, StorageFull{}
+	}
+
+	buf, err := ioutil.ReadAll(data)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if int64(len(buf)) < data.Size() {
+		return ObjectInfo{}, IncompleteBody{}
+	}
+
+	meta := make(map[string]string, len(opts.UserDefined)+1)
+	for k, v := range opts.UserDefined {
+		meta[k] = v
+	}
+	meta["etag"] = r.MD5CurrentHexString()
+
+	return m.storeObject(bucket, object, &memObject{meta: meta, data: buf}, opts)
+}
+
+// checkPutPreconditions - evaluates the write preconditions of opts,
+// caller must hold the lock.
+func (m *memObjects) checkPutPreconditions(bucket, object string, opts ObjectOptions) error {
+	return checkPutPreconditions(opts, func() (ObjectInfo, error) {
+		obj, err := m.getObject(bucket, object)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		return m.toObjectInfo(bucket, object, obj), nil
+	})
+}
+
+// storeObject - saves obj replacing any existing object.
+func (m *memObjects) storeObject(bucket, object string, obj *memObject, opts ObjectOptions) (ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return ObjectInfo{}, BucketNotFound{Bucket: bucket}
+	}
+
+	if err := m.checkPutPreconditions(bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	var oldSize int64
+	if old, ok := b.objects[object]; ok {
+		// Deny if WORM is enabled
+		if globalWORMEnabled {
+			return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+		oldSize = int64(len(old.data))
+	}
+	if err := m.reserve(int64(len(obj.data))-oldSize, bucket, object); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	obj.modTime = UTCNow()
+	obj.accessed = obj.modTime
+	b.objects[object] = obj
+	return m.toObjectInfo(bucket, object, obj), nil
+}
+
+// CopyObject - copies an object, for metadata only copies on the
+// same object only the metadata is replaced.
+func (m *memObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (oi ObjectInfo, e error) {
+	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
+	if cpSrcDstSame && srcInfo.metadataOnly {
+		m.mu.Lock()
+		defer m.mu.Unlock()
+
+		obj, err := m.getObject(srcBucket, srcObject)
+		if err != nil {
+			return oi, err
+		}
+		meta := make(map[string]string, len(srcInfo.UserDefined)+1)
+		for k, v := range srcInfo.UserDefined {
+			meta[k] = v
+		}
+		meta["etag"] = srcInfo.ETag
+		obj.meta = meta
+		obj.modTime = UTCNow()
+		return m.toObjectInfo(srcBucket, srcObject, obj), nil
+	}
+
+	if err := checkPutObjectArgs(ctx, dstBucket, dstObject, m, srcInfo.PutObjReader.Size()); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	return m.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, ObjectOptions{UserDefined: srcInfo.UserDefined})
+}
+
+// DeleteObject - deletes an object from a bucket.
+func (m *memObjects) DeleteObject(ctx context.Context, bucket, object string) error {
+	if err := checkDelObjArgs(ctx, bucket, object); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return BucketNotFound{Bucket: bucket}
+	}
+	obj, ok := b.objects[object]
+	if !ok {
+		return ObjectNotFound{Bucket: bucket, Object: object}
+	}
+	delete(b.objects, object)
+	m.used -= int64(len(obj.data))
+	return nil
+}
+
+// DeleteObjects - deletes a list of objects from a bucket.
+func (m *memObjects) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+	errs := make([]error, len(objects))
+	for idx, object := range objects {
+		errs[idx] = m.DeleteObject(ctx, bucket, object)
+	}
+	return errs, nil
+}
+
+// ListMultipartUploads - lists all the uploadIDs for the specified object.
+// We do not support prefix based listing.
+func (m *memObjects) ListMultipartUploads(ctx context.Context, bucket, object, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, e error) {
+	if err := checkListMultipartArgs(ctx, bucket, object, keyMarker, uploadIDMarker, delimiter, m); err != nil {
+		return result, err
+	}
+
+	result.MaxUploads = maxUploads
+	result.KeyMarker = keyMarker
+	result.Prefix = object
+	result.Delimiter = delimiter
+	result.NextKeyMarker = object
+	result.UploadIDMarker = uploadIDMarker
+
+	m.mu.RLock()
+	var uploads []MultipartInfo
+	for uploadID, upload := range m.uploads {
+		if upload.bucket == bucket && upload.object == object {
+			uploads = append(uploads, MultipartInfo{
+				Object:    object,
+				UploadID:  uploadID,
+				Initiated: upload.initiated,
+			})
+		}
+	}
+	m.mu.RUnlock()
+
+	// S3 spec says uploadIDs should be sorted based on initiated time.
+	sort.Slice(uploads, func(i int, j int) bool {
+		return uploads[i].Initiated.Before(uploads[j].Initiated)
+	})
+
+	uploadIndex := 0
+	if uploadIDMarker != "" {
+		for uploadIndex < len(uploads) {
+			uploadIndex++
+			if uploads[uploadIndex-1].UploadID == uploadIDMarker {
+				break
+			}
+		}
+	}
+	for uploadIndex < len(uploads) {
+		if len(result.Uploads) == maxUploads {
+			break
+		}
+		result.Uploads = append(result.Uploads, uploads[uploadIndex])
+		result.NextUploadIDMarker = uploads[uploadIndex].UploadID
+		uploadIndex++
+	}
+
+	result.IsTruncated = uploadIndex < len(uploads)
+	if !result.IsTruncated {
+		result.NextKeyMarker = ""
+		result.NextUploadIDMarker = ""
+	}
+	return result, nil
+}
+
+// NewMultipartUpload - initialize a new multipart upload, returns a
+// unique id.
+func (m *memObjects) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (string, error) {
+	if err := checkNewMultipartArgs(ctx, bucket, object, m); err != nil {
+		return "", err
+	}
+
+	meta := make(map[string]string, len(opts.UserDefined))
+	for k, v := range opts.UserDefined {
+		meta[k] = v
+	}
+
+	uploadID := mustGetUUID()
+
+	m.mu.Lock()
+	m.uploads[uploadID] = &memUpload{
+		bucket:    bucket,
+		object:    object,
+		initiated: UTCNow(),
+		meta:      meta,
+		parts:     make(map[int]*memPart),
+	}
+	m.mu.Unlock()
+
+	return uploadID, nil
+}
+
+// getUpload - returns the upload for uploadID, caller must hold the lock.
+func (m *memObjects) getUpload(bucket, object, uploadID string) (*memUpload, error) {
+	upload, ok := m.uploads[uploadID]
+	if !ok || upload.bucket != bucket || upload.object != object {
+		return nil, InvalidUploadID{UploadID: uploadID}
+	}
+	return upload, nil
+}
+
+// abortUpload - drops the upload and its parts, caller must hold the
+// write lock.
+func (m *memObjects) abortUpload(uploadID string) {
+	for _, part := range m.uploads[uploadID].parts {
+		m.used -= int64(len(part.data))
+	}
+	delete(m.uploads, uploadID)
+}
+
+// CopyObjectPart - similar to PutObjectPart but reads data from an
+// existing object.
+func (m *memObjects) CopyObjectPart(ctx context.Context, srcBucket, srcObject, dstBucket, dstObject, uploadID string, partID int,
+	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (pi PartInfo, e error) {
+
+	if err := checkNewMultipartArgs(ctx, srcBucket, srcObject, m); err != nil {
+		return pi, err
+	}
+
+	return m.PutObjectPart(ctx, dstBucket, dstObject, uploadID, partID, srcInfo.PutObjReader, dstOpts)
+}
+
+// PutObjectPart - saves a part of an ongoing multipart upload.
+func (m *memObjects) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, r *PutObjReader, opts ObjectOptions) (pi PartInfo, e error) {
+	data := r.Reader
+	if err := checkPutObjectPartArgs(ctx, bucket, object, m); err != nil {
+		return pi, err
+	}
+
+	// Validate input data size and it can never be less than zero.
+	if data.Size() < -1 {
+		logger.LogIf(ctx, errInvalidArgument, logger.Application)
+		return pi, errInvalidArgument
+	}
+
+	// Fail early for parts which can never fit.
+	if m.maxSize > 0 && data.Size() > m.maxSize {
+		return pi, StorageFull{}
+	}
+
+	buf, err := ioutil.ReadAll(data)
+	if err != nil {
+		return pi, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer
+	// bytes than specified in request header.
+	if int64(len(buf)) < data.Size() {
+		return pi, IncompleteBody{}
+	}
+
+	etag := r.MD5CurrentHexString()
+	if etag == "" {
+		etag = GenETag()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return pi, err
+	}
+
+	var oldSize int64
+	if old, ok := upload.parts[partID]; ok {
+		oldSize = int64(len(old.data))
+	}
+	if err = m.reserve(int64(len(buf))-oldSize, "", ""); err != nil {
+		return pi, err
+	}
+
+	part := &memPart{
+		etag:       etag,
+		actualSize: data.ActualSize(),
+		data:       buf,
+		modTime:    UTCNow(),
+	}
+	upload.parts[partID] = part
+
+	return PartInfo{
+		PartNumber:   partID,
+		LastModified: part.modTime,
+		ETag:         etag,
+		Size:         int64(len(buf)),
+		ActualSize:   part.actualSize,
+	}, nil
+}
+
+// ListObjectParts - lists all previously uploaded parts for a given
+// object and uploadID.
+func (m *memObjects) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker, maxParts int, opts ObjectOptions) (result ListPartsInfo, e error) {
+	if err := checkListPartsArgs(ctx, bucket, object, m); err != nil {
+		return result, err
+	}
+	result.Bucket = bucket
+	result.Object = object
+	result.UploadID = uploadID
+	result.MaxParts = maxParts
+	result.PartNumberMarker = partNumberMarker
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return result, err
+	}
+
+	var parts []PartInfo
+	for partNumber, part := range upload.parts {
+		if partNumber <= partNumberMarker {
+			continue
+		}
+		parts = append(parts, PartInfo{
+			PartNumber:   partNumber,
+			LastModified: part.modTime,
+			ETag:         part.etag,
+			Size:         part.actualSize,
+			ActualSize:   part.actualSize,
+		})
+	}
+	sort.Slice(parts, func(i int, j int) bool {
+		return parts[i].PartNumber < parts[j].PartNumber
+	})
+
+	if len(parts) > maxParts {
+		parts = parts[:maxParts]
+		result.IsTruncated = true
+		if maxParts > 0 {
+			result.NextPartNumberMarker = parts[maxParts-1].PartNumber
+		}
+	}
+	result.Parts = parts
+
+	result.UserDefined = make(map[string]string, len(upload.meta))
+	for k, v := range upload.meta {
+		result.UserDefined[k] = v
+	}
+	return result, nil
+}
+
+// AbortMultipartUpload - aborts an ongoing multipart upload and
+// frees the memory held by its parts.
+func (m *memObjects) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
+	if err := checkAbortMultipartArgs(ctx, bucket, object, m); err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, err := m.getUpload(bucket, object, uploadID); err != nil {
+		return err
+	}
+	m.abortUpload(uploadID)
+	return nil
+}
+
+// CompleteMultipartUpload - completes an ongoing multipart
+// transaction after receiving all the parts indicated by the client.
+func (m *memObjects) CompleteMultipartUpload(ctx context.Context, bucket string, object string, uploadID string, parts []CompletePart, opts ObjectOptions) (oi ObjectInfo, e error) {
+	if err := checkCompleteMultipartArgs(ctx, bucket, object, m); err != nil {
+		return oi, err
+	}
+
+	// Calculate s3 compatible md5sum for complete multipart.
+	s3MD5 := getCompleteMultipartMD5(parts)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	b, ok := m.buckets[bucket]
+	if !ok {
+		return oi, BucketNotFound{Bucket: bucket}
+	}
+	upload, err := m.getUpload(bucket, object, uploadID)
+	if err != nil {
+		return oi, err
+	}
+
+	var (
+		objectSize       int64
+		objectActualSize int64
+		objectParts      = make([]ObjectPartInfo, len(parts))
+	)
+	for i := range parts {
+		// ensure that part ETag is canonicalized to strip off extraneous quotes
+		parts[i].ETag = canonicalizeETag(parts[i].ETag)
+
+		part, ok := upload.parts[parts[i].PartNumber]
+		if !ok || part.etag != parts[i].ETag {
+			return oi, InvalidPart{
+				PartNumber: parts[i].PartNumber,
+				GotETag:    parts[i].ETag,
+			}
+		}
+
+		// All parts except the last part has to be atleast 5MB.
+		if i < len(parts)-1 && !isMinAllowedPartSize(part.actualSize) {
+			return oi, PartTooSmall{
+				PartNumber: parts[i].PartNumber,
+				PartSize:   part.actualSize,
+				PartETag:   parts[i].ETag,
+			}
+		}
+
+		objectParts[i] = ObjectPartInfo{
+			Number:     parts[i].PartNumber,
+			ETag:       parts[i].ETag,
+			Size:       int64(len(part.data)),
+			ActualSize: part.actualSize,
+		}
+		objectSize += int64(len(part.data))
+		objectActualSize += part.actualSize
+	}
+
+	if err = m.checkPutPreconditions(bucket, object, opts); err != nil {
+		return oi, err
+	}
+
+	var oldSize int64
+	if old, ok := b.objects[object]; ok {
+		// Deny if WORM is enabled
+		if globalWORMEnabled {
+			return oi, ObjectAlreadyExists{Bucket: bucket, Object: object}
+		}
+		oldSize = int64(len(old.data))
+	}
+
+	data := make([]byte, 0, objectSize)
+	for _, part := range parts {
+		data = append(data, upload.parts[part.PartNumber].data...)
+	}
+
+	// The upload is dropped before the object is stored, parts
+	// not referenced by the client are freed as well.
+	m.abortUpload(uploadID)
+	if err = m.reserve(objectSize-oldSize, bucket, object); err != nil {
+		return oi, err
+	}
+
+	meta := upload.meta
+	meta["etag"] = s3MD5
+	// Save consolidated actual size.
+	meta[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(objectActualSize, 10)
+
+	obj := &memObject{
+		meta:    meta,
+		parts:   objectParts,
+		data:    data,
+		modTime: UTCNow(),
+	}
+	obj.accessed = obj.modTime
+	b.objects[object] = obj
+
+	return m.toObjectInfo(bucket, object, obj), nil
+}
+
+// ReloadFormat - no-op, there is no format to reload.
+func (m *memObjects) ReloadFormat(ctx context.Context, dryRun bool) error {
+	return nil
+}
+
+// HealFormat - no-op, there is nothing to heal in memory.
+func (m *memObjects) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return madmin.HealResultItem{}, NotImplemented{}
+}
+
+// HealObject - no-op, there is nothing to heal in memory.
+func (m *memObjects) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool, scanMode madmin.HealScanMode) (res madmin.HealResultItem, err error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return res, NotImplemented{}
+}
+
+// HealBucket - no-op, there is nothing to heal in memory.
+func (m *memObjects) HealBucket(ctx context.Context, bucket string, dryRun, remove bool) (madmin.HealResultItem, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return madmin.HealResultItem{}, NotImplemented{}
+}
+
+// HealObjects - no-op, there is nothing to heal in memory.
+func (m *memObjects) HealObjects(ctx context.Context, bucket, prefix string, fn func(string, string) error) (e error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return NotImplemented{}
+}
+
+// ListObjectsHeal - list all objects to be healed.
+func (m *memObjects) ListObjectsHeal(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, e error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return ListObjectsInfo{}, NotImplemented{}
+}
+
+// ListBucketsHeal - list all buckets to be healed.
+func (m *memObjects) ListBucketsHeal(ctx context.Context) ([]BucketInfo, error) {
+	logger.LogIf(ctx, NotImplemented{})
+	return []BucketInfo{}, NotImplemented{}
+}
+
+// SetBucketPolicy sets policy on bucket
+func (m *memObjects) SetBucketPolicy(ctx context.Context, bucket string, policy *policy.Policy) error {
+	return savePolicyConfig(ctx, m, bucket, policy)
+}
+
+// GetBucketPolicy will get policy on bucket
+func (m *memObjects) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
+	return getPolicyConfig(m, bucket)
+}
+
+// DeleteBucketPolicy deletes all policies on bucket
+func (m *memObjects) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	return removePolicyConfig(ctx, m, bucket)
+}
+
+// SetBucketLifecycle sets lifecycle on bucket
+func (m *memObjects) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	return saveLifecycleConfig(ctx, m, bucket, lifecycle)
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (m *memObjects) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return getLifecycleConfig(m, bucket)
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (m *memObjects) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return removeLifecycleConfig(ctx, m, bucket)
+}
+
+// IsNotificationSupported returns whether bucket notification is applicable for this layer.
+func (m *memObjects) IsNotificationSupported() bool {
+	return true
+}
+
+// IsListenBucketSupported returns whether listen bucket notification is applicable for this layer.
+func (m *memObjects) IsListenBucketSupported() bool {
+	return true
+}
+
+// IsEncryptionSupported returns whether server side encryption is implemented for this layer.
+func (m *memObjects) IsEncryptionSupported() bool {
+	return true
+}
+
+// IsCompressionSupported returns whether compression is applicable for this layer.
+func (m *memObjects) IsCompressionSupported() bool {
+	return true
+}
+
+// IsReady returns whether the layer is ready to take requests, which
+// is always the case for memory.
+func (m *memObjects) IsReady(ctx context.Context) bool {
+	return true
+}
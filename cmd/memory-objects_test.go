@@ -0,0 +1,134 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Runs the object layer API suite against the memory object layer.
+func TestMemObjectLayerSuite(t *testing.T) {
+	testFns := []objTestType{
+		testMakeBucket,
+		testMultipartObjectCreation,
+		testMultipartObjectAbort,
+		testMultipleObjectCreation,
+		testPaging,
+		testObjectOverwriteWorks,
+		testNonExistantBucketOperations,
+		testBucketRecreateFails,
+		testPutObject,
+		testPutObjectInSubdir,
+		testListBuckets,
+		testListBucketsOrder,
+		testListObjectsTestsForNonExistantBucket,
+		testNonExistantObjectInBucket,
+		testGetDirectoryReturnsObjectNotFound,
+		testContentType,
+		testGetObject,
+		testGetObjectInfo,
+		testDeleteObject,
+		testListObjects,
+		testObjectAPIPutObject,
+		testObjectAPIPutObjectPart,
+		testListMultipartUploads,
+		testListObjectParts,
+		testObjectCompleteMultipartUpload,
+		testObjectAbortMultipartUpload,
+	}
+	for _, testFn := range testFns {
+		testFn(NewMemObjectLayer(0, false), "Memory", t)
+	}
+}
+
+func TestMemObjectLayerSizeLimit(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("a"), 40)
+
+	obj := NewMemObjectLayer(100, false)
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"obj1", "obj2"} {
+		if _, err := obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Overwriting an object only accounts for the difference.
+	if _, err := obj.PutObject(ctx, "bucket", "obj1", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err := obj.PutObject(ctx, "bucket", "obj3", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if _, ok := err.(StorageFull); !ok {
+		t.Fatalf("expected StorageFull, got %v", err)
+	}
+
+	// Deleting an object frees its memory.
+	if err = obj.DeleteObject(ctx, "bucket", "obj1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, "bucket", "obj3", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if used := obj.StorageInfo(ctx).Used; used != 80 {
+		t.Fatalf("expected 80 bytes used, got %d", used)
+	}
+}
+
+func TestMemObjectLayerEviction(t *testing.T) {
+	ctx := context.Background()
+	data := bytes.Repeat([]byte("a"), 40)
+
+	obj := NewMemObjectLayer(100, true)
+	if err := obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"obj1", "obj2"} {
+		if _, err := obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Reading obj1 makes obj2 the least recently used object.
+	var buf bytes.Buffer
+	if err := obj.GetObject(ctx, "bucket", "obj1", 0, -1, &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := obj.PutObject(ctx, "bucket", "obj3", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := obj.GetObjectInfo(ctx, "bucket", "obj2", ObjectOptions{}); err == nil {
+		t.Fatal("expected obj2 to be evicted")
+	}
+	for _, object := range []string{"obj1", "obj3"} {
+		if _, err := obj.GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); err != nil {
+			t.Fatalf("expected %s to be present, got %v", object, err)
+		}
+	}
+
+	// Objects larger than the limit are never stored.
+	large := bytes.Repeat([]byte("a"), 101)
+	_, err := obj.PutObject(ctx, "bucket", "large", mustGetPutObjReader(t, bytes.NewReader(large), int64(len(large)), "", ""), ObjectOptions{})
+	if _, ok := err.(StorageFull); !ok {
+		t.Fatalf("expected StorageFull, got %v", err)
+	}
+}
@@ -34,6 +34,41 @@ var (
 		},
 		[]string{"request_type"},
 	)
+	httpRequestsThrottled = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "minio_http_requests_throttled_total",
+			Help: "Total number of S3 API requests rejected by the rate limiter",
+		},
+		[]string{"api"},
+	)
+	nsLockWaitDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "minio_namespace_lock_wait_seconds",
+			Help:    "Time spent waiting to acquire namespace locks by current MinIO server instance",
+			Buckets: []float64{.001, .01, .1, .5, 1, 5, 10, 30},
+		},
+		[]string{"type", "result"},
+	)
+	gatewayCleanupErrors = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "minio_gateway_multipart_cleanup_errors_total",
+			Help: "Total number of errors while removing stale multipart uploads from the gateway backend",
+		},
+		[]string{"bucket"},
+	)
+	gatewayCleanupDeleted = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "minio_gateway_multipart_cleanup_deleted_total",
+			Help: "Total number of stale multipart upload objects removed from the gateway backend",
+		},
+		[]string{"bucket"},
+	)
+	gatewayCleanupLastRun = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "minio_gateway_multipart_cleanup_last_run_timestamp_seconds",
+			Help: "Time of the last stale multipart upload cleanup of the gateway backend",
+		},
+	)
 	minioVersionInfo = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Namespace: "minio",
@@ -51,6 +86,11 @@ var (
 
 func init() {
 	prometheus.MustRegister(httpRequestsDuration)
+	prometheus.MustRegister(httpRequestsThrottled)
+	prometheus.MustRegister(nsLockWaitDuration)
+	prometheus.MustRegister(gatewayCleanupErrors)
+	prometheus.MustRegister(gatewayCleanupDeleted)
+	prometheus.MustRegister(gatewayCleanupLastRun)
 	prometheus.MustRegister(newMinioCollector())
 	prometheus.MustRegister(minioVersionInfo)
 }
@@ -101,6 +141,28 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		float64(globalConnStats.getTotalInputBytes()),
 	)
 
+	// Signature V2 requests per access key
+	for _, stat := range globalSignatureV2Sys.Stats() {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "signature_v2", "requests_total"),
+				"Total number of requests signed with signature V2 per access key",
+				[]string{"access_key", "status"}, nil),
+			prometheus.CounterValue,
+			float64(stat.Accepted),
+			stat.AccessKey, "accepted",
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "signature_v2", "requests_total"),
+				"Total number of requests signed with signature V2 per access key",
+				[]string{"access_key", "status"}, nil),
+			prometheus.CounterValue,
+			float64(stat.Rejected),
+			stat.AccessKey, "rejected",
+		)
+	}
+
 	// Expose cache stats only if available
 	cacheObjLayer := newCacheObjectsFn()
 	if cacheObjLayer != nil {
@@ -123,6 +185,88 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		)
 	}
 
+	// Expose notification target stats once notifications are initialized
+	if globalNotificationSys != nil {
+		for _, stat := range globalNotificationSys.TargetStats() {
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "notify", "events_total"),
+					"Total number of events sent to a notification target",
+					[]string{"target"}, nil),
+				prometheus.CounterValue,
+				float64(stat.TotalEvents),
+				stat.ARN,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "notify", "failed_events_total"),
+					"Total number of events a notification target could neither deliver nor queue",
+					[]string{"target"}, nil),
+				prometheus.CounterValue,
+				float64(stat.FailedEvents),
+				stat.ARN,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				prometheus.NewDesc(
+					prometheus.BuildFQName("minio", "notify", "queue_length"),
+					"Number of events waiting in the queue store of a notification target",
+					[]string{"target"}, nil),
+				prometheus.GaugeValue,
+				float64(stat.QueueLength),
+				stat.ARN,
+			)
+		}
+	}
+
+	// Background scrub progress of the local disks
+	for _, st := range globalBackgroundScrub.Status() {
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "scrub", "objects_scanned_total"),
+				"Total number of objects verified by the background scrub of a disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(st.ObjectsScanned),
+			st.Endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "scrub", "verified_bytes_total"),
+				"Total number of bytes read and verified by the background scrub of a disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(st.BytesVerified),
+			st.Endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "scrub", "corrupted_parts_total"),
+				"Total number of corrupted or missing parts found by the background scrub of a disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(st.CorruptedParts),
+			st.Endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "scrub", "heals_scheduled_total"),
+				"Total number of object heals scheduled by the background scrub of a disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(st.HealsScheduled),
+			st.Endpoint,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "scrub", "passes_total"),
+				"Total number of completed background scrubs of a disk",
+				[]string{"disk"}, nil),
+			prometheus.CounterValue,
+			float64(st.Passes),
+			st.Endpoint,
+		)
+	}
+
 	// Expose disk stats only if applicable
 
 	// Fetch disk space info
@@ -196,6 +340,35 @@ func (c *minioCollector) Collect(ch chan<- prometheus.Metric) {
 		prometheus.GaugeValue,
 		float64(offlineDisks),
 	)
+
+	// Files held open by the FS backend for reading `fs.json`
+	if fs, ok := objLayer.(*FSObjects); ok {
+		openFiles, rejected := fs.rwPool.Stats()
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "fs", "open_files"),
+				"Number of metadata files held open by current MinIO server instance",
+				nil, nil),
+			prometheus.GaugeValue,
+			float64(openFiles),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "fs", "open_files_limit"),
+				"Maximum number of metadata files current MinIO server instance holds open",
+				nil, nil),
+			prometheus.GaugeValue,
+			float64(fs.rwPool.maxOpen),
+		)
+		ch <- prometheus.MustNewConstMetric(
+			prometheus.NewDesc(
+				prometheus.BuildFQName("minio", "fs", "open_files_rejected_total"),
+				"Total number of metadata file opens rejected due to the open files limit",
+				nil, nil),
+			prometheus.CounterValue,
+			float64(rejected),
+		)
+	}
 }
 
 func metricsHandler() http.Handler {
@@ -0,0 +1,344 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+
+	"github.com/minio/cli"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/env"
+)
+
+const (
+	migrateDefaultEndpoint = "http://localhost:9000"
+
+	// Progress of a migration from FS to XL, kept in the
+	// meta bucket of the FS backend.
+	migrateFSToXLCheckpointFile = "migrate-fs-to-xl.json"
+
+	// Number of objects migrated between two saves of the
+	// checkpoint.
+	migrateCheckpointInterval = 100
+)
+
+var migrateFSToXLCmd = cli.Command{
+	Name:  "fs-to-xl",
+	Usage: "copy the buckets and objects of an FS backend to an XL deployment",
+	Flags: []cli.Flag{
+		cli.StringFlag{
+			Name:  "endpoint",
+			Value: migrateDefaultEndpoint,
+			Usage: "URL of the MinIO server of the XL deployment",
+		},
+	},
+	Action: migrateFSToXLMain,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  ACCESS:
+     MINIO_ACCESS_KEY: Access key of the XL deployment.
+     MINIO_SECRET_KEY: Secret key of the XL deployment.
+
+EXAMPLES:
+  1. Copy the FS backend "/data" to the XL deployment served by the local server.
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.HelpName}} /data
+
+  2. Copy the FS backend "/data" to a remote XL deployment over TLS.
+     {{.Prompt}} {{.HelpName}} --endpoint https://minio.example.com /data
+`,
+}
+
+var migrateCmd = cli.Command{
+	Name:            "migrate",
+	Usage:           "migrate data to another backend",
+	Subcommands:     []cli.Command{migrateFSToXLCmd},
+	HideHelpCommand: true,
+}
+
+// migrateCheckpoint - progress of a migration, buckets are migrated
+// in lexical order and objects in listing order, hence everything
+// up to Marker in Bucket is already migrated.
+type migrateCheckpoint struct {
+	Bucket string `json:"bucket"`
+	Marker string `json:"marker"`
+
+	// Totals since the migration started.
+	Objects int64 `json:"objects"`
+	Bytes   int64 `json:"bytes"`
+	Skipped int64 `json:"skipped"`
+}
+
+// fsToXLMigration - copies the buckets of an FS backend, opened
+// offline, to a running XL deployment.
+type fsToXLMigration struct {
+	fs             ObjectLayer
+	clnt           *minio.Core
+	checkpointPath string
+	checkpoint     migrateCheckpoint
+}
+
+// newFSToXLMigration - opens the FS backend at fsPath and loads
+// the checkpoint of a previous interrupted migration, if any.
+func newFSToXLMigration(fsPath string, clnt *minio.Core) (*fsToXLMigration, error) {
+	fs, err := NewFSObjectLayer(fsPath)
+	if err != nil {
+		return nil, err
+	}
+	m := &fsToXLMigration{
+		fs:             fs,
+		clnt:           clnt,
+		checkpointPath: pathJoin(fsPath, minioMetaBucket, migrateFSToXLCheckpointFile),
+	}
+	data, err := ioutil.ReadFile(m.checkpointPath)
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		fs.Shutdown(context.Background())
+		return nil, err
+	default:
+		if err = json.Unmarshal(data, &m.checkpoint); err != nil {
+			fs.Shutdown(context.Background())
+			return nil, fmt.Errorf("invalid checkpoint %s: %v", m.checkpointPath, err)
+		}
+	}
+	return m, nil
+}
+
+// saveCheckpoint - atomically replaces the checkpoint file.
+func (m *fsToXLMigration) saveCheckpoint() error {
+	data, err := json.Marshal(m.checkpoint)
+	if err != nil {
+		return err
+	}
+	tmpPath := m.checkpointPath + ".tmp"
+	if err = ioutil.WriteFile(tmpPath, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, m.checkpointPath)
+}
+
+// migrateBucket - creates the bucket with its policy in the XL
+// deployment and copies its objects listed after marker.
+func (m *fsToXLMigration) migrateBucket(ctx context.Context, bucket, marker string) error {
+	if err := m.clnt.MakeBucket(bucket, ""); err != nil {
+		switch minio.ToErrorResponse(err).Code {
+		case "BucketAlreadyOwnedByYou", "BucketAlreadyExists":
+		default:
+			return err
+		}
+	}
+
+	bucketPolicy, err := m.fs.GetBucketPolicy(ctx, bucket)
+	switch err.(type) {
+	case nil:
+		policyJSON, err := json.Marshal(bucketPolicy)
+		if err != nil {
+			return err
+		}
+		if err = m.clnt.SetBucketPolicy(bucket, string(policyJSON)); err != nil {
+			return err
+		}
+	case BucketPolicyNotFound:
+	default:
+		return err
+	}
+
+	for {
+		result, err := m.fs.ListObjects(ctx, bucket, "", marker, "", maxObjectList)
+		if err != nil {
+			return err
+		}
+		for _, objInfo := range result.Objects {
+			if err = m.migrateObject(ctx, objInfo); err != nil {
+				return fmt.Errorf("%s: %v", pathJoin(bucket, objInfo.Name), err)
+			}
+			m.checkpoint.Bucket, m.checkpoint.Marker = bucket, objInfo.Name
+			if (m.checkpoint.Objects+m.checkpoint.Skipped)%migrateCheckpointInterval == 0 {
+				if err = m.saveCheckpoint(); err != nil {
+					return err
+				}
+			}
+		}
+		if !result.IsTruncated {
+			return nil
+		}
+		marker = result.NextMarker
+	}
+}
+
+// objectMD5 - returns the MD5 sum of the data of an object, read
+// from its ETag unless it was uploaded in parts or compressed.
+func (m *fsToXLMigration) objectMD5(ctx context.Context, objInfo ObjectInfo) ([]byte, error) {
+	if sum, err := hex.DecodeString(objInfo.ETag); err == nil && len(sum) == md5.Size && !objInfo.IsCompressed() {
+		return sum, nil
+	}
+	gr, err := m.fs.GetObjectNInfo(ctx, objInfo.Bucket, objInfo.Name, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer gr.Close()
+	h := md5.New()
+	if _, err = io.Copy(h, gr); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// migrateObject - copies an object with its metadata and tags, the
+// XL deployment verifies the copy against the MD5 sum of the data.
+func (m *fsToXLMigration) migrateObject(ctx context.Context, objInfo ObjectInfo) error {
+	// Encrypted objects cannot be decrypted without the keys of
+	// their clients.
+	if crypto.IsEncrypted(objInfo.UserDefined) {
+		logger.Info("Skipping encrypted object %s", pathJoin(objInfo.Bucket, objInfo.Name))
+		m.checkpoint.Skipped++
+		return nil
+	}
+
+	sum, err := m.objectMD5(ctx, objInfo)
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			// Deleted since it was listed.
+			return nil
+		}
+		return err
+	}
+	gr, err := m.fs.GetObjectNInfo(ctx, objInfo.Bucket, objInfo.Name, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		if isErrObjectNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	defer gr.Close()
+
+	objInfo = gr.ObjInfo
+	size := objInfo.Size
+	if objInfo.IsCompressed() {
+		size = objInfo.GetActualSize()
+	}
+
+	metadata := make(map[string]string)
+	for k, v := range objInfo.UserDefined {
+		if hasPrefix(k, ReservedMetadataPrefix) {
+			continue
+		}
+		metadata[k] = v
+	}
+
+	_, err = m.clnt.PutObjectWithContext(ctx, objInfo.Bucket, objInfo.Name, gr, size, base64.StdEncoding.EncodeToString(sum), "", metadata, nil)
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "BadDigest" {
+			return fmt.Errorf("verification failed, the data does not match its MD5 sum %s", hex.EncodeToString(sum))
+		}
+		return err
+	}
+
+	// minio-go sends unknown headers of a PUT as user metadata, the
+	// tags are set by copying the object onto itself instead.
+	if tags, ok := objInfo.UserDefined[ObjectTagsKey]; ok {
+		_, err = m.clnt.CopyObjectWithContext(ctx, objInfo.Bucket, objInfo.Name, objInfo.Bucket, objInfo.Name, map[string]string{
+			xhttp.AmzTagDirective:  "REPLACE",
+			xhttp.AmzObjectTagging: tags,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	m.checkpoint.Objects++
+	m.checkpoint.Bytes += size
+	return nil
+}
+
+// run - migrates all buckets, resuming from the checkpoint, which
+// is removed once the migration is complete.
+func (m *fsToXLMigration) run(ctx context.Context) error {
+	buckets, err := m.fs.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+	for _, bucket := range buckets {
+		if bucket.Name < m.checkpoint.Bucket {
+			continue
+		}
+		marker := ""
+		if bucket.Name == m.checkpoint.Bucket {
+			marker = m.checkpoint.Marker
+		}
+		logger.Info("Migrating bucket %s", bucket.Name)
+		if err = m.migrateBucket(ctx, bucket.Name, marker); err != nil {
+			m.saveCheckpoint()
+			return err
+		}
+	}
+	if err = os.Remove(m.checkpointPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+func migrateFSToXLMain(ctx *cli.Context) {
+	if len(ctx.Args()) != 1 {
+		cli.ShowCommandHelpAndExit(ctx, "fs-to-xl", 1)
+	}
+	fsPath := ctx.Args().First()
+
+	u, err := url.Parse(ctx.String("endpoint"))
+	logger.FatalIf(err, "Invalid endpoint %s", ctx.String("endpoint"))
+	clnt, err := minio.NewCore(u.Host, env.Get(config.EnvAccessKey, ""), env.Get(config.EnvSecretKey, ""), u.Scheme == "https")
+	logger.FatalIf(err, "Unable to connect to %s", u.Host)
+
+	m, err := newFSToXLMigration(fsPath, clnt)
+	logger.FatalIf(err, "Unable to open the FS backend %s", fsPath)
+	defer m.fs.Shutdown(context.Background())
+
+	if m.checkpoint.Bucket != "" {
+		logger.Info("Resuming the migration after %s", pathJoin(m.checkpoint.Bucket, m.checkpoint.Marker))
+	}
+	logger.FatalIf(m.run(context.Background()), "Unable to migrate %s, run the command again to resume", fsPath)
+	logger.Info("Migrated %d objects (%d bytes), skipped %d encrypted objects",
+		m.checkpoint.Objects, m.checkpoint.Bytes, m.checkpoint.Skipped)
+}
@@ -0,0 +1,154 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"reflect"
+	"testing"
+
+	minio "github.com/minio/minio-go/v6"
+)
+
+func TestMigrateFSToXL(t *testing.T) {
+	testServer := StartTestServer(t, "XL")
+	defer testServer.Stop()
+
+	fsDir, err := ioutil.TempDir(globalTestTmpDir, "minio-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	fs, err := NewFSObjectLayer(fsDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string]string{
+		"bucket1/a":   "a",
+		"bucket1/b/c": "hello world",
+		"bucket2/d":   "d",
+		"bucket2/e":   "e",
+	}
+	for _, bucket := range []string{"bucket1", "bucket2"} {
+		if err = fs.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for name, data := range objects {
+		bucket, object := urlPath2BucketObjectName(name)
+		opts := ObjectOptions{UserDefined: map[string]string{
+			"content-type":     "text/plain",
+			"X-Amz-Meta-Color": "blue",
+			ObjectTagsKey:      "project=x",
+		}}
+		if _, err = fs.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bucketPolicy := getAnonReadOnlyBucketPolicy("bucket1")
+	if err = fs.SetBucketPolicy(ctx, "bucket1", bucketPolicy); err != nil {
+		t.Fatal(err)
+	}
+	if err = fs.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	u, err := url.Parse(testServer.Server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt, err := minio.NewCore(u.Host, testServer.AccessKey, testServer.SecretKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Resume a migration interrupted after bucket2/d.
+	checkpoint, err := json.Marshal(migrateCheckpoint{Bucket: "bucket2", Marker: "d", Objects: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkpointPath := pathJoin(fsDir, minioMetaBucket, migrateFSToXLCheckpointFile)
+	if err = ioutil.WriteFile(checkpointPath, checkpoint, 0644); err != nil {
+		t.Fatal(err)
+	}
+	m, err := newFSToXLMigration(fsDir, clnt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = m.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if m.checkpoint.Objects != 4 {
+		t.Fatalf("Expected 4 migrated objects, got %d", m.checkpoint.Objects)
+	}
+	if _, err = os.Stat(checkpointPath); !os.IsNotExist(err) {
+		t.Fatalf("Expected the checkpoint to be removed, got %v", err)
+	}
+	if _, err = testServer.Obj.GetObjectInfo(ctx, "bucket2", "d", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected bucket2/d to be skipped on resume, got %v", err)
+	}
+	objInfo, err := testServer.Obj.GetObjectInfo(ctx, "bucket2", "e", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Size != 1 {
+		t.Fatalf("Expected bucket2/e to be migrated, got size %d", objInfo.Size)
+	}
+	if err = m.fs.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// A complete migration copies everything.
+	if m, err = newFSToXLMigration(fsDir, clnt); err != nil {
+		t.Fatal(err)
+	}
+	defer m.fs.Shutdown(ctx)
+	if err = m.run(ctx); err != nil {
+		t.Fatal(err)
+	}
+	for name, data := range objects {
+		bucket, object := urlPath2BucketObjectName(name)
+		var buf bytes.Buffer
+		if err = testServer.Obj.GetObject(ctx, bucket, object, 0, -1, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if buf.String() != data {
+			t.Errorf("%s: expected %q, got %q", name, data, buf.String())
+		}
+		objInfo, err = testServer.Obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objInfo.ContentType != "text/plain" || objInfo.UserDefined["X-Amz-Meta-Color"] != "blue" || objInfo.UserDefined[ObjectTagsKey] != "project=x" {
+			t.Errorf("%s: metadata not migrated, got %v", name, objInfo.UserDefined)
+		}
+	}
+	migratedPolicy, err := testServer.Obj.GetBucketPolicy(ctx, "bucket1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(migratedPolicy, bucketPolicy) {
+		t.Errorf("Expected policy %v, got %v", bucketPolicy, migratedPolicy)
+	}
+}
@@ -0,0 +1,183 @@
+// +build linux darwin
+
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/hanwen/go-fuse/v2/fs"
+	"github.com/hanwen/go-fuse/v2/fuse"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// Duration for which the kernel caches names and attributes, objects
+// changed on the server are visible after this duration.
+const mountCacheTimeout = time.Second
+
+// toMountErrno - converts errors of the bucket to errno values.
+func toMountErrno(ctx context.Context, err error) syscall.Errno {
+	if err == errFileNotFound {
+		return syscall.ENOENT
+	}
+	switch minio.ToErrorResponse(err).Code {
+	case "NoSuchKey":
+		return syscall.ENOENT
+	case "AccessDenied":
+		return syscall.EACCES
+	}
+	logger.LogIf(ctx, err)
+	return syscall.EIO
+}
+
+// setMountAttr - sets the attributes of the file or directory.
+func setMountAttr(entry mountEntry, out *fuse.Attr) {
+	if entry.isDir {
+		out.Mode = 0555
+		return
+	}
+	out.Mode = 0444
+	out.Size = uint64(entry.size)
+	out.SetTimes(nil, &entry.modTime, &entry.modTime)
+}
+
+// mountDir - directory of a mounted bucket.
+type mountDir struct {
+	fs.Inode
+	b *mountBucket
+	// Prefix of the objects in the directory, empty for the top of
+	// the bucket and ending with a slash otherwise.
+	prefix string
+}
+
+var _ = (fs.NodeGetattrer)((*mountDir)(nil))
+var _ = (fs.NodeLookuper)((*mountDir)(nil))
+var _ = (fs.NodeReaddirer)((*mountDir)(nil))
+
+func (d *mountDir) Getattr(ctx context.Context, f fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	setMountAttr(mountEntry{isDir: true}, &out.Attr)
+	return 0
+}
+
+func (d *mountDir) Lookup(ctx context.Context, name string, out *fuse.EntryOut) (*fs.Inode, syscall.Errno) {
+	entry, err := d.b.stat(ctx, d.prefix+name)
+	if err != nil {
+		return nil, toMountErrno(ctx, err)
+	}
+	setMountAttr(entry, &out.Attr)
+	return d.newChild(ctx, entry), 0
+}
+
+func (d *mountDir) newChild(ctx context.Context, entry mountEntry) *fs.Inode {
+	if entry.isDir {
+		return d.NewInode(ctx, &mountDir{
+			b:      d.b,
+			prefix: d.prefix + entry.name + SlashSeparator,
+		}, fs.StableAttr{Mode: fuse.S_IFDIR})
+	}
+	return d.NewInode(ctx, &mountFile{
+		b:      d.b,
+		object: d.prefix + entry.name,
+		entry:  entry,
+	}, fs.StableAttr{Mode: fuse.S_IFREG})
+}
+
+func (d *mountDir) Readdir(ctx context.Context) (fs.DirStream, syscall.Errno) {
+	entries, err := d.b.readDir(ctx, d.prefix)
+	if err != nil {
+		return nil, toMountErrno(ctx, err)
+	}
+	dirEntries := make([]fuse.DirEntry, 0, len(entries))
+	for _, entry := range entries {
+		mode := uint32(fuse.S_IFREG)
+		if entry.isDir {
+			mode = fuse.S_IFDIR
+		}
+		dirEntries = append(dirEntries, fuse.DirEntry{Name: entry.name, Mode: mode})
+	}
+	return fs.NewListDirStream(dirEntries), 0
+}
+
+// mountFile - object of a mounted bucket.
+type mountFile struct {
+	fs.Inode
+	b      *mountBucket
+	object string
+	entry  mountEntry
+}
+
+var _ = (fs.NodeGetattrer)((*mountFile)(nil))
+var _ = (fs.NodeOpener)((*mountFile)(nil))
+var _ = (fs.NodeReader)((*mountFile)(nil))
+
+func (f *mountFile) Getattr(ctx context.Context, fh fs.FileHandle, out *fuse.AttrOut) syscall.Errno {
+	setMountAttr(f.entry, &out.Attr)
+	return 0
+}
+
+func (f *mountFile) Open(ctx context.Context, flags uint32) (fs.FileHandle, uint32, syscall.Errno) {
+	if flags&(syscall.O_WRONLY|syscall.O_RDWR) != 0 {
+		return nil, 0, syscall.EROFS
+	}
+	return nil, fuse.FOPEN_KEEP_CACHE, 0
+}
+
+func (f *mountFile) Read(ctx context.Context, fh fs.FileHandle, dest []byte, off int64) (fuse.ReadResult, syscall.Errno) {
+	if off >= f.entry.size {
+		return fuse.ReadResultData(nil), 0
+	}
+	n, err := f.b.readAt(ctx, f.object, dest, off)
+	if err != nil {
+		return nil, toMountErrno(ctx, err)
+	}
+	return fuse.ReadResultData(dest[:n]), 0
+}
+
+// mountBucketFS - mounts the bucket read-only on dir and serves it
+// until the process is interrupted.
+func mountBucketFS(b *mountBucket, dir string) error {
+	timeout := mountCacheTimeout
+	server, err := fs.Mount(dir, &mountDir{b: b}, &fs.Options{
+		MountOptions: fuse.MountOptions{
+			FsName:  "minio:" + b.bucket,
+			Name:    "minio",
+			Options: []string{"ro"},
+		},
+		EntryTimeout:    &timeout,
+		AttrTimeout:     &timeout,
+		NegativeTimeout: &timeout,
+	})
+	if err != nil {
+		return err
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalCh
+		logger.LogIf(context.Background(), server.Unmount())
+	}()
+
+	server.Wait()
+	return nil
+}
@@ -0,0 +1,211 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/cli"
+	minio "github.com/minio/minio-go/v6"
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/env"
+)
+
+const mountDefaultEndpoint = "http://localhost:9000"
+
+var mountFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "endpoint",
+		Value: mountDefaultEndpoint,
+		Usage: "URL of the MinIO server serving the bucket",
+	},
+}
+
+var mountCmd = cli.Command{
+	Name:   "mount",
+	Usage:  "mount a bucket as a read-only filesystem",
+	Flags:  mountFlags,
+	Action: mountMain,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}BUCKET DIR
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENVIRONMENT VARIABLES:
+  ACCESS:
+     MINIO_ACCESS_KEY: Username or access key of a user allowed to read the bucket.
+     MINIO_SECRET_KEY: Password or secret key of the user.
+
+EXAMPLES:
+  1. Mount the bucket "datasets" of the local server on "/mnt/datasets".
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_ACCESS_KEY{{.AssignmentOperator}}accesskey
+     {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SECRET_KEY{{.AssignmentOperator}}secretkey
+     {{.Prompt}} {{.HelpName}} datasets /mnt/datasets
+
+  2. Mount the bucket "datasets" of a remote server over TLS.
+     {{.Prompt}} {{.HelpName}} --endpoint https://minio.example.com datasets /mnt/datasets
+`,
+}
+
+// mountBucket - read-only view of a bucket as a tree of directories
+// and files, object names are split into path elements on "/".
+type mountBucket struct {
+	clnt   *minio.Core
+	bucket string
+}
+
+// mountEntry - file or directory of a mounted bucket.
+type mountEntry struct {
+	name    string
+	isDir   bool
+	size    int64
+	modTime time.Time
+}
+
+// stat - returns the file or directory of the name, which is relative
+// to the bucket and has no trailing slash.
+func (b *mountBucket) stat(ctx context.Context, name string) (mountEntry, error) {
+	objInfo, err := b.clnt.StatObjectWithContext(ctx, b.bucket, name, minio.StatObjectOptions{})
+	if err == nil {
+		return mountEntry{
+			name:    path.Base(name),
+			size:    objInfo.Size,
+			modTime: objInfo.LastModified,
+		}, nil
+	}
+	if minio.ToErrorResponse(err).Code != "NoSuchKey" {
+		return mountEntry{}, err
+	}
+
+	// Directories only exist as the common prefix of other objects.
+	result, err := b.clnt.ListObjectsV2(b.bucket, name+SlashSeparator, "", false, SlashSeparator, 1, "")
+	if err != nil {
+		return mountEntry{}, err
+	}
+	if len(result.Contents) != 0 || len(result.CommonPrefixes) != 0 {
+		return mountEntry{name: path.Base(name), isDir: true}, nil
+	}
+
+	// Empty directories exist as an object named after the prefix,
+	// which is not listed by all backends.
+	if _, err = b.clnt.StatObjectWithContext(ctx, b.bucket, name+SlashSeparator, minio.StatObjectOptions{}); err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return mountEntry{}, errFileNotFound
+		}
+		return mountEntry{}, err
+	}
+	return mountEntry{name: path.Base(name), isDir: true}, nil
+}
+
+// readDir - returns the entries of the directory dir, which is empty
+// for the top of the bucket and ends with a slash otherwise.
+func (b *mountBucket) readDir(ctx context.Context, dir string) ([]mountEntry, error) {
+	var entries []mountEntry
+	var token string
+	for {
+		result, err := b.clnt.ListObjectsV2(b.bucket, dir, token, false, SlashSeparator, maxObjectList, "")
+		if err != nil {
+			return nil, err
+		}
+		for _, prefix := range result.CommonPrefixes {
+			name := strings.TrimSuffix(strings.TrimPrefix(prefix.Prefix, dir), SlashSeparator)
+			if name == "" {
+				continue
+			}
+			entries = append(entries, mountEntry{name: name, isDir: true})
+		}
+		for _, object := range result.Contents {
+			// Skip the object marking the directory itself.
+			name := strings.TrimPrefix(object.Key, dir)
+			if name == "" {
+				continue
+			}
+			entries = append(entries, mountEntry{
+				name:    name,
+				size:    object.Size,
+				modTime: object.LastModified,
+			})
+		}
+		if !result.IsTruncated {
+			return entries, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// readAt - reads len(p) bytes of the object at offset, reads at the
+// end of the object return less bytes.
+func (b *mountBucket) readAt(ctx context.Context, object string, p []byte, offset int64) (int, error) {
+	opts := minio.GetObjectOptions{}
+	if err := opts.SetRange(offset, offset+int64(len(p))-1); err != nil {
+		return 0, err
+	}
+	reader, _, _, err := b.clnt.GetObjectWithContext(ctx, b.bucket, object, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer reader.Close()
+
+	n, err := io.ReadFull(reader, p)
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		err = nil
+	}
+	return n, err
+}
+
+// newMountBucket - returns the bucket served by the server at endpoint.
+func newMountBucket(endpoint, accessKey, secretKey, bucket string) (*mountBucket, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return nil, err
+	}
+	clnt, err := minio.NewCore(u.Host, accessKey, secretKey, u.Scheme == "https")
+	if err != nil {
+		return nil, err
+	}
+	ok, err := clnt.BucketExists(bucket)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, BucketNotFound{Bucket: bucket}
+	}
+	return &mountBucket{clnt: clnt, bucket: bucket}, nil
+}
+
+func mountMain(ctx *cli.Context) {
+	if len(ctx.Args()) != 2 {
+		cli.ShowCommandHelpAndExit(ctx, "mount", 1)
+	}
+	bucket, dir := ctx.Args().Get(0), ctx.Args().Get(1)
+
+	b, err := newMountBucket(ctx.String("endpoint"), env.Get(config.EnvAccessKey, ""), env.Get(config.EnvSecretKey, ""), bucket)
+	logger.FatalIf(err, "Unable to access the bucket %s", bucket)
+
+	logger.Info("Mounting the bucket %s read-only on %s", bucket, dir)
+	logger.FatalIf(mountBucketFS(b, dir), "Unable to mount the bucket %s", bucket)
+}
@@ -0,0 +1,99 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestMountBucket(t *testing.T) {
+	testServer := StartTestServer(t, "FS")
+	defer testServer.Stop()
+
+	ctx := context.Background()
+	if err := testServer.Obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	for object, data := range map[string]string{
+		"a/b/c":  "c",
+		"a/d":    "hello world",
+		"e":      "e",
+		"empty/": "",
+	} {
+		if _, err := testServer.Obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader([]byte(data)), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := newMountBucket(testServer.Server.URL, testServer.AccessKey, testServer.SecretKey, "missing"); err != (BucketNotFound{Bucket: "missing"}) {
+		t.Fatalf("Expected %v, got %v", BucketNotFound{Bucket: "missing"}, err)
+	}
+	b, err := newMountBucket(testServer.Server.URL, testServer.AccessKey, testServer.SecretKey, "bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name  string
+		isDir bool
+		size  int64
+		err   error
+	}{
+		{name: "a", isDir: true},
+		{name: "a/b", isDir: true},
+		{name: "a/d", size: 11},
+		{name: "empty", isDir: true},
+		{name: "missing", err: errFileNotFound},
+	}
+	for i, testCase := range testCases {
+		entry, err := b.stat(ctx, testCase.name)
+		if err != testCase.err {
+			t.Fatalf("Test %d: Expected %v, got %v", i+1, testCase.err, err)
+		}
+		if entry.isDir != testCase.isDir || entry.size != testCase.size {
+			t.Errorf("Test %d: Unexpected entry %v", i+1, entry)
+		}
+	}
+
+	entries, err := b.readDir(ctx, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.name)
+	}
+	if !reflect.DeepEqual(names, []string{"a", "empty", "e"}) {
+		t.Errorf("Expected [a empty e], got %v", names)
+	}
+	// The object marking an empty directory is not listed.
+	if entries, err = b.readDir(ctx, "empty/"); err != nil || len(entries) != 0 {
+		t.Errorf("Expected an empty directory, got %v, %v", entries, err)
+	}
+
+	p := make([]byte, 8)
+	n, err := b.readAt(ctx, "a/d", p, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(p[:n]) != "world" {
+		t.Errorf("Expected world, got %s", p[:n])
+	}
+}
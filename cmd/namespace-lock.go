@@ -32,6 +32,7 @@ import (
 	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/logger"
 	xnet "github.com/minio/minio/pkg/net"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // Global name space lock.
@@ -98,7 +99,10 @@ func newNSLock(isDistXL bool) *nsLockMap {
 	if isDistXL {
 		return &nsMutex
 	}
-	nsMutex.lockMap = make(map[nsParam]*nsLock)
+	nsMutex.shards = make([]nsLockMapShard, nsLockMapShards)
+	for i := range nsMutex.shards {
+		nsMutex.shards[i].lockMap = make(map[nsParam]*nsLock)
+	}
 	return &nsMutex
 }
 
@@ -117,35 +121,56 @@ type nsParam struct {
 type nsLock struct {
 	*lsync.LRWMutex
 	ref uint
+	// Holders of the lock, used for listing locks.
+	holders []lockRequesterInfo
+}
+
+// Number of shards of the namespace lock map, locking different
+// resources rarely contends on the same map mutex.
+const nsLockMapShards = 64
+
+// nsLockMapShard - the locks of the resources hashed to a shard.
+type nsLockMapShard struct {
+	lockMap      map[nsParam]*nsLock
+	lockMapMutex sync.RWMutex
 }
 
 // nsLockMap - namespace lock map, provides primitives to Lock,
 // Unlock, RLock and RUnlock.
 type nsLockMap struct {
 	// Indicates if namespace is part of a distributed setup.
-	isDistXL     bool
-	lockMap      map[nsParam]*nsLock
-	lockMapMutex sync.RWMutex
+	isDistXL bool
+	shards   []nsLockMapShard
+
+	// Called before a write lock is released, lets the owner
+	// invalidate what it caches about the resource.
+	onWriteUnlock func(volume, path string)
+}
+
+// shard - returns the shard holding the lock of the resource.
+func (n *nsLockMap) shard(param nsParam) *nsLockMapShard {
+	return &n.shards[crcHashMod(param.volume+SlashSeparator+param.path, len(n.shards))]
 }
 
 // Lock the namespace resource.
 func (n *nsLockMap) lock(ctx context.Context, volume, path string, lockSource, opsID string, readLock bool, timeout time.Duration) (locked bool) {
 	var nsLk *nsLock
 
-	n.lockMapMutex.Lock()
 	param := nsParam{volume, path}
-	nsLk, found := n.lockMap[param]
+	s := n.shard(param)
+	s.lockMapMutex.Lock()
+	nsLk, found := s.lockMap[param]
 	if !found {
-		n.lockMap[param] = &nsLock{
+		s.lockMap[param] = &nsLock{
 			LRWMutex: lsync.NewLRWMutex(ctx),
 			ref:      1,
 		}
-		nsLk = n.lockMap[param]
+		nsLk = s.lockMap[param]
 	} else {
 		// Update ref count here to avoid multiple races.
 		nsLk.ref++
 	}
-	n.lockMapMutex.Unlock()
+	s.lockMapMutex.Unlock()
 
 	// Locking here will block (until timeout).
 	if readLock {
@@ -154,16 +179,25 @@ func (n *nsLockMap) lock(ctx context.Context, volume, path string, lockSource, o
 		locked = nsLk.GetLock(opsID, lockSource, timeout)
 	}
 
-	if !locked { // We failed to get the lock
+	if locked {
+		s.lockMapMutex.Lock()
+		nsLk.holders = append(nsLk.holders, lockRequesterInfo{
+			Writer:    !readLock,
+			UID:       opsID,
+			Timestamp: UTCNow(),
+			Source:    lockSource,
+		})
+		s.lockMapMutex.Unlock()
+	} else { // We failed to get the lock
 
 		// Decrement ref count since we failed to get the lock
-		n.lockMapMutex.Lock()
+		s.lockMapMutex.Lock()
 		nsLk.ref--
 		if nsLk.ref == 0 {
 			// Remove from the map if there are no more references.
-			delete(n.lockMap, param)
+			delete(s.lockMap, param)
 		}
-		n.lockMapMutex.Unlock()
+		s.lockMapMutex.Unlock()
 	}
 	return
 }
@@ -171,28 +205,57 @@ func (n *nsLockMap) lock(ctx context.Context, volume, path string, lockSource, o
 // Unlock the namespace resource.
 func (n *nsLockMap) unlock(volume, path, opsID string, readLock bool) {
 	param := nsParam{volume, path}
-	n.lockMapMutex.RLock()
-	nsLk, found := n.lockMap[param]
-	n.lockMapMutex.RUnlock()
+	s := n.shard(param)
+	s.lockMapMutex.RLock()
+	nsLk, found := s.lockMap[param]
+	s.lockMapMutex.RUnlock()
 	if !found {
 		return
 	}
 	if readLock {
 		nsLk.RUnlock()
 	} else {
+		if n.onWriteUnlock != nil {
+			n.onWriteUnlock(volume, path)
+		}
 		nsLk.Unlock()
 	}
-	n.lockMapMutex.Lock()
+	s.lockMapMutex.Lock()
+	for i, holder := range nsLk.holders {
+		if holder.UID == opsID && holder.Writer == !readLock {
+			nsLk.holders = append(nsLk.holders[:i], nsLk.holders[i+1:]...)
+			break
+		}
+	}
 	if nsLk.ref == 0 {
 		logger.LogIf(context.Background(), errors.New("Namespace reference count cannot be 0"))
 	} else {
 		nsLk.ref--
 		if nsLk.ref == 0 {
 			// Remove from the map if there are no more references.
-			delete(n.lockMap, param)
+			delete(s.lockMap, param)
 		}
 	}
-	n.lockMapMutex.Unlock()
+	s.lockMapMutex.Unlock()
+}
+
+// DupLockMap - returns a copy of the locks currently held on this
+// server, only available when namespace is not distributed.
+func (n *nsLockMap) DupLockMap() GetLocksResp {
+	lockMapCopy := make(GetLocksResp)
+	for i := range n.shards {
+		s := &n.shards[i]
+		s.lockMapMutex.RLock()
+		for param, nsLk := range s.lockMap {
+			if len(nsLk.holders) == 0 {
+				continue
+			}
+			resource := pathJoin(param.volume, param.path)
+			lockMapCopy[resource] = append([]lockRequesterInfo{}, nsLk.holders...)
+		}
+		s.lockMapMutex.RUnlock()
+	}
+	return lockMapCopy
 }
 
 // Lock - locks the given resource for writes, using a previously
@@ -226,8 +289,6 @@ func (n *nsLockMap) RUnlock(volume, path, opsID string) {
 
 // ForceUnlock - forcefully unlock a lock based on name.
 func (n *nsLockMap) ForceUnlock(volume, path string) {
-	n.lockMapMutex.Lock()
-	defer n.lockMapMutex.Unlock()
 
 	// Clarification on operation:
 	// - In case of FS or XL we call ForceUnlock on the local globalNSMutex
@@ -244,10 +305,15 @@ func (n *nsLockMap) ForceUnlock(volume, path string) {
 	//   participate normally.
 	if n.isDistXL { // For distributed mode, broadcast ForceUnlock message.
 		dsync.NewDRWMutex(context.Background(), pathJoin(volume, path), globalDsync).ForceUnlock()
+		return
 	}
 
 	// Remove lock from the map.
-	delete(n.lockMap, nsParam{volume, path})
+	param := nsParam{volume, path}
+	s := n.shard(param)
+	s.lockMapMutex.Lock()
+	delete(s.lockMap, param)
+	s.lockMapMutex.Unlock()
 }
 
 // dsync's distributed lock instance.
@@ -261,7 +327,9 @@ func (di *distLockInstance) GetLock(timeout *dynamicTimeout) (timedOutErr error)
 	lockSource := getSource()
 	start := UTCNow()
 
-	if !di.rwMutex.GetLock(di.opsID, lockSource, timeout.Timeout()) {
+	locked := di.rwMutex.GetLock(di.opsID, lockSource, timeout.Timeout())
+	recordNSLockWait(false, locked, start)
+	if !locked {
 		timeout.LogFailure()
 		return OperationTimedOut{Path: di.path}
 	}
@@ -278,7 +346,9 @@ func (di *distLockInstance) Unlock() {
 func (di *distLockInstance) GetRLock(timeout *dynamicTimeout) (timedOutErr error) {
 	lockSource := getSource()
 	start := UTCNow()
-	if !di.rwMutex.GetRLock(di.opsID, lockSource, timeout.Timeout()) {
+	locked := di.rwMutex.GetRLock(di.opsID, lockSource, timeout.Timeout())
+	recordNSLockWait(true, locked, start)
+	if !locked {
 		timeout.LogFailure()
 		return OperationTimedOut{Path: di.path}
 	}
@@ -314,7 +384,9 @@ func (li *localLockInstance) GetLock(timeout *dynamicTimeout) (timedOutErr error
 	lockSource := getSource()
 	start := UTCNow()
 	readLock := false
-	if !li.ns.lock(li.ctx, li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout()) {
+	locked := li.ns.lock(li.ctx, li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout())
+	recordNSLockWait(readLock, locked, start)
+	if !locked {
 		timeout.LogFailure()
 		return OperationTimedOut{Path: li.path}
 	}
@@ -333,7 +405,9 @@ func (li *localLockInstance) GetRLock(timeout *dynamicTimeout) (timedOutErr erro
 	lockSource := getSource()
 	start := UTCNow()
 	readLock := true
-	if !li.ns.lock(li.ctx, li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout()) {
+	locked := li.ns.lock(li.ctx, li.volume, li.path, lockSource, li.opsID, readLock, timeout.Timeout())
+	recordNSLockWait(readLock, locked, start)
+	if !locked {
 		timeout.LogFailure()
 		return OperationTimedOut{Path: li.path}
 	}
@@ -347,6 +421,19 @@ func (li *localLockInstance) RUnlock() {
 	li.ns.unlock(li.volume, li.path, li.opsID, readLock)
 }
 
+// recordNSLockWait - records the time spent waiting for a namespace
+// lock since start, whether it was acquired or timed out.
+func recordNSLockWait(readLock, locked bool, start time.Time) {
+	lockType, result := "write", "success"
+	if readLock {
+		lockType = "read"
+	}
+	if !locked {
+		result = "timeout"
+	}
+	nsLockWaitDuration.With(prometheus.Labels{"type": lockType, "result": result}).Observe(UTCNow().Sub(start).Seconds())
+}
+
 func getSource() string {
 	var funcName string
 	pc, filename, lineNum, ok := runtime.Caller(2)
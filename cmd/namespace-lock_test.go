@@ -82,7 +82,7 @@ func TestNamespaceLockTest(t *testing.T) {
 	if !testCase.lk("a", "b", "c", 60*time.Second) { // lock once.
 		t.Fatalf("Failed to acquire lock")
 	}
-	nsLk, ok := globalNSMutex.lockMap[nsParam{"a", "b"}]
+	nsLk, ok := globalNSMutex.shard(nsParam{"a", "b"}).lockMap[nsParam{"a", "b"}]
 	if !ok && testCase.shouldPass {
 		t.Errorf("Lock in map missing.")
 	}
@@ -94,7 +94,7 @@ func TestNamespaceLockTest(t *testing.T) {
 	if testCase.unlockedRefCount != nsLk.ref && testCase.shouldPass {
 		t.Errorf("Test %d fails, expected to pass. Wanted ref count is %d, got %d", 1, testCase.unlockedRefCount, nsLk.ref)
 	}
-	_, ok = globalNSMutex.lockMap[nsParam{"a", "b"}]
+	_, ok = globalNSMutex.shard(nsParam{"a", "b"}).lockMap[nsParam{"a", "b"}]
 	if ok && !testCase.shouldPass {
 		t.Errorf("Lock map found after unlock.")
 	}
@@ -113,7 +113,7 @@ func TestNamespaceLockTest(t *testing.T) {
 	if !testCase.rlk("a", "b", "c", 60*time.Second) { // lock fourth time.
 		t.Fatalf("Failed to acquire fourth read lock")
 	}
-	nsLk, ok = globalNSMutex.lockMap[nsParam{"a", "b"}]
+	nsLk, ok = globalNSMutex.shard(nsParam{"a", "b"}).lockMap[nsParam{"a", "b"}]
 	if !ok && testCase.shouldPass {
 		t.Errorf("Lock in map missing.")
 	}
@@ -127,7 +127,7 @@ func TestNamespaceLockTest(t *testing.T) {
 	if testCase.unlockedRefCount != nsLk.ref && testCase.shouldPass {
 		t.Errorf("Test %d fails, expected to pass. Wanted ref count is %d, got %d", 2, testCase.unlockedRefCount, nsLk.ref)
 	}
-	_, ok = globalNSMutex.lockMap[nsParam{"a", "b"}]
+	_, ok = globalNSMutex.shard(nsParam{"a", "b"}).lockMap[nsParam{"a", "b"}]
 	if !ok && testCase.shouldPass {
 		t.Errorf("Lock map not found.")
 	}
@@ -138,7 +138,7 @@ func TestNamespaceLockTest(t *testing.T) {
 		t.Fatalf("Failed to acquire read lock")
 	}
 
-	nsLk, ok = globalNSMutex.lockMap[nsParam{"a", "c"}]
+	nsLk, ok = globalNSMutex.shard(nsParam{"a", "c"}).lockMap[nsParam{"a", "c"}]
 	if !ok && testCase.shouldPass {
 		t.Errorf("Lock in map missing.")
 	}
@@ -150,7 +150,7 @@ func TestNamespaceLockTest(t *testing.T) {
 	if testCase.unlockedRefCount != nsLk.ref && testCase.shouldPass {
 		t.Errorf("Test %d fails, expected to pass. Wanted ref count is %d, got %d", 3, testCase.unlockedRefCount, nsLk.ref)
 	}
-	_, ok = globalNSMutex.lockMap[nsParam{"a", "c"}]
+	_, ok = globalNSMutex.shard(nsParam{"a", "c"}).lockMap[nsParam{"a", "c"}]
 	if ok && !testCase.shouldPass {
 		t.Errorf("Lock map not found.")
 	}
@@ -238,3 +238,78 @@ func TestNamespaceForceUnlockTest(t *testing.T) {
 	// Clean up lock.
 	globalNSMutex.ForceUnlock("bucket", "object")
 }
+
+// Tests listing of locks held on a local namespace.
+func TestNamespaceDupLockMap(t *testing.T) {
+	nsMutex := newNSLock(false)
+
+	writeLock := nsMutex.NewNSLock(context.Background(), "bucket", "object1")
+	if writeLock.GetLock(newDynamicTimeout(time.Second, time.Second)) != nil {
+		t.Fatal("Failed to get write lock")
+	}
+	readLock1 := nsMutex.NewNSLock(context.Background(), "bucket", "object2")
+	readLock2 := nsMutex.NewNSLock(context.Background(), "bucket", "object2")
+	if readLock1.GetRLock(newDynamicTimeout(time.Second, time.Second)) != nil ||
+		readLock2.GetRLock(newDynamicTimeout(time.Second, time.Second)) != nil {
+		t.Fatal("Failed to get read locks")
+	}
+
+	locks := nsMutex.DupLockMap()
+	if len(locks) != 2 {
+		t.Fatalf("Expected 2 locked resources, got %d", len(locks))
+	}
+	if l := locks["bucket/object1"]; len(l) != 1 || !l[0].Writer {
+		t.Errorf("Expected a single write lock on object1, got %v", l)
+	}
+	if l := locks["bucket/object2"]; len(l) != 2 || l[0].Writer || l[1].Writer {
+		t.Errorf("Expected two read locks on object2, got %v", l)
+	}
+
+	writeLock.Unlock()
+	readLock1.RUnlock()
+	locks = nsMutex.DupLockMap()
+	if len(locks) != 1 || len(locks["bucket/object2"]) != 1 {
+		t.Errorf("Expected a single read lock on object2, got %v", locks)
+	}
+
+	readLock2.RUnlock()
+	if locks = nsMutex.DupLockMap(); len(locks) != 0 {
+		t.Errorf("Expected no locks, got %v", locks)
+	}
+}
+
+// Tests locks of different resources are spread over the shards of the
+// namespace lock map and listed together.
+func TestNamespaceLockShards(t *testing.T) {
+	nsMutex := newNSLock(false)
+
+	objects := make([]string, 100)
+	shards := make(map[*nsLockMapShard]struct{})
+	for i := range objects {
+		object := mustGetUUID()
+		objects[i] = object
+		if !nsMutex.Lock("bucket", object, object, 60*time.Second) {
+			t.Fatalf("Failed to acquire lock on %s", object)
+		}
+		shards[nsMutex.shard(nsParam{"bucket", object})] = struct{}{}
+	}
+	if len(shards) < 2 {
+		t.Fatalf("Expected locks to be spread over several shards, got %d", len(shards))
+	}
+
+	if locks := nsMutex.DupLockMap(); len(locks) != 100 {
+		t.Fatalf("Expected 100 locks to be listed, got %d", len(locks))
+	}
+
+	for _, object := range objects {
+		nsMutex.Unlock("bucket", object, object)
+	}
+	if locks := nsMutex.DupLockMap(); len(locks) != 0 {
+		t.Fatalf("Expected no locks to be listed, got %d", len(locks))
+	}
+	for i := range nsMutex.shards {
+		if len(nsMutex.shards[i].lockMap) != 0 {
+			t.Fatalf("Expected shard %d to be empty", i)
+		}
+	}
+}
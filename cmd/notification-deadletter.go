@@ -0,0 +1,70 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// deadLetter - an event which a target could neither deliver nor
+// queue. It is a superset of the event log posted to webhooks, so a
+// dead letter file can be replayed as is.
+type deadLetter struct {
+	event.Log
+	Target string    `json:"target"`
+	Error  string    `json:"error"`
+	Time   time.Time `json:"time"`
+}
+
+// writeDeadLetter - writes the failed event to a JSON file in a
+// directory of the target under dir.
+func writeDeadLetter(dir string, terr event.TargetIDErr, eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(deadLetter{
+		Log: event.Log{
+			EventName: eventData.EventName,
+			Key:       eventData.S3.Bucket.Name + "/" + objectName,
+			Records:   []event.Event{eventData},
+		},
+		Target: terr.ID.String(),
+		Error:  terr.Err.Error(),
+		Time:   UTCNow(),
+	})
+	if err != nil {
+		return err
+	}
+
+	targetDir := filepath.Join(dir, terr.ID.Name+"-"+terr.ID.ID)
+	if err = os.MkdirAll(targetDir, 0700); err != nil {
+		return err
+	}
+
+	// The sequencer is unique per event.
+	name := filepath.Join(targetDir, eventData.S3.Object.Sequencer+".json")
+	return ioutil.WriteFile(name, data, 0600)
+}
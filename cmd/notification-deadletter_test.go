@@ -0,0 +1,69 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestWriteDeadLetter(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-dead-letter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	eventData := event.Event{
+		EventName: event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "images"},
+			Object: event.Object{Key: "my+photo.jpg", Sequencer: "15E2C7A0D6B1C4F2"},
+		},
+	}
+	terr := event.TargetIDErr{ID: event.TargetID{ID: "1", Name: "webhook"}, Err: errors.New("connection refused")}
+	if err = writeDeadLetter(dir, terr, eventData); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "webhook-1", "15E2C7A0D6B1C4F2.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var letter deadLetter
+	if err = json.Unmarshal(data, &letter); err != nil {
+		t.Fatal(err)
+	}
+	if letter.Target != "1:webhook" || letter.Error != "connection refused" || letter.Time.IsZero() {
+		t.Errorf("unexpected dead letter %+v", letter)
+	}
+
+	// A dead letter replays as the event log posted to webhooks.
+	var eventLog event.Log
+	if err = json.Unmarshal(data, &eventLog); err != nil {
+		t.Fatal(err)
+	}
+	if eventLog.EventName != event.ObjectCreatedPut || eventLog.Key != "images/my photo.jpg" || len(eventLog.Records) != 1 {
+		t.Errorf("unexpected event log %+v", eventLog)
+	}
+}
@@ -26,19 +26,25 @@ import (
 	"net"
 	"net/url"
 	"path"
+	"sort"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/klauspost/compress/zip"
+	"github.com/minio/minio/cmd/config/placement"
 	"github.com/minio/minio/cmd/crypto"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucketdefaults"
+	"github.com/minio/minio/pkg/bucketsse"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/lifecycle"
 	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/sync/errgroup"
+	"github.com/minio/minio/pkg/website"
 )
 
 // NotificationSys - notification system.
@@ -48,6 +54,8 @@ type NotificationSys struct {
 	bucketRulesMap             map[string]event.RulesMap
 	bucketRemoteTargetRulesMap map[string]map[event.TargetID]event.RulesMap
 	peerClients                []*peerRESTClient
+	// bus is set when events are fanned out through NATS.
+	bus *eventBus
 }
 
 // GetARNList - returns available ARNs.
@@ -67,6 +75,33 @@ func (sys *NotificationSys) GetARNList() []string {
 	return arns
 }
 
+// NotificationTargetStat - delivery statistics of a notification target.
+type NotificationTargetStat struct {
+	ARN string `json:"arn"`
+	event.TargetStat
+}
+
+// TargetStats - returns the delivery statistics of the notification
+// targets of this node, sorted by ARN.
+func (sys *NotificationSys) TargetStats() []NotificationTargetStat {
+	region := globalServerConfig.GetRegion()
+	stats := []NotificationTargetStat{}
+	for targetID, stat := range sys.targetList.Stats() {
+		// Listen clients are not notification targets, see GetARNList.
+		if strings.HasPrefix(targetID.ID, "httpclient+") {
+			continue
+		}
+		stats = append(stats, NotificationTargetStat{
+			ARN:        targetID.ToARN(region).String(),
+			TargetStat: stat,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].ARN < stats[j].ARN
+	})
+	return stats
+}
+
 // NotificationPeerErr returns error associated for a remote peer.
 type NotificationPeerErr struct {
 	Host xnet.Host // Remote host on which the rpc call was initiated
@@ -142,6 +177,21 @@ func (sys *NotificationSys) ReloadFormat(dryRun bool) []NotificationPeerErr {
 	return ng.Wait()
 }
 
+// LoadPoolsDecommission - reloads decommission status of server pools across all peers.
+func (sys *NotificationSys) LoadPoolsDecommission() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.LoadPoolsDecommission()
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // DeletePolicy - deletes policy across all peers.
 func (sys *NotificationSys) DeletePolicy(policyName string) []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -217,6 +267,34 @@ func (sys *NotificationSys) LoadUser(accessKey string, temp bool) []Notification
 	return ng.Wait()
 }
 
+// SetOldCredential - sets the old root credential on all peers.
+func (sys *NotificationSys) SetOldCredential(cred auth.Credentials) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), func() error {
+			return client.SetOldCredential(cred)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
+// RemoveOldCredential - removes the old root credential on all peers.
+func (sys *NotificationSys) RemoveOldCredential() []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(context.Background(), client.RemoveOldCredential, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // LoadUsers - calls LoadUsers RPC call on all peers.
 func (sys *NotificationSys) LoadUsers() []NotificationPeerErr {
 	ng := WithNPeers(len(sys.peerClients))
@@ -261,6 +339,23 @@ func (sys *NotificationSys) BackgroundHealStatus() []madmin.BgHealState {
 	return states
 }
 
+// ScrubStatus - returns the background scrub status of the disks of all peers
+func (sys *NotificationSys) ScrubStatus() []madmin.ScrubDiskStatus {
+	var status []madmin.ScrubDiskStatus
+	for _, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		st, err := client.ScrubStatus()
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		status = append(status, st...)
+	}
+	return status
+}
+
 // BackgroundOpsStatus - returns the status of all background operations of all peers
 func (sys *NotificationSys) BackgroundOpsStatus() []BgOpsStatus {
 	states := make([]BgOpsStatus, len(sys.peerClients))
@@ -591,8 +686,9 @@ func (sys *NotificationSys) RemoveBucketLifecycle(ctx context.Context, bucketNam
 	}()
 }
 
-// PutBucketNotification - calls PutBucketNotification RPC call on all peers.
-func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketName string, rulesMap event.RulesMap) {
+// SetBucketWebsite - calls SetBucketWebsite on all peers.
+func (sys *NotificationSys) SetBucketWebsite(ctx context.Context, bucketName string,
+	bucketWebsite *website.Website) {
 	go func() {
 		ng := WithNPeers(len(sys.peerClients))
 		for idx, client := range sys.peerClients {
@@ -601,16 +697,50 @@ func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketNam
 			}
 			client := client
 			ng.Go(ctx, func() error {
-				return client.PutBucketNotification(bucketName, rulesMap)
+				return client.SetBucketWebsite(bucketName, bucketWebsite)
 			}, idx, *client.host)
 		}
 		ng.Wait()
 	}()
 }
 
-// ListenBucketNotification - calls ListenBucketNotification RPC call on all peers.
-func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucketName string,
-	eventNames []event.Name, pattern string, targetID event.TargetID, localPeer xnet.Host) {
+// RemoveBucketWebsite - calls RemoveBucketWebsite on all peers.
+func (sys *NotificationSys) RemoveBucketWebsite(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketWebsite(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// SetBucketDefaults - calls SetBucketDefaults on all peers.
+func (sys *NotificationSys) SetBucketDefaults(ctx context.Context, bucketName string,
+	bucketDefaults *bucketdefaults.Config) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketDefaults(bucketName, bucketDefaults)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketDefaults - calls RemoveBucketDefaults on all peers.
+func (sys *NotificationSys) RemoveBucketDefaults(ctx context.Context, bucketName string) {
 	go func() {
 		ng := WithNPeers(len(sys.peerClients))
 		for idx, client := range sys.peerClients {
@@ -619,13 +749,83 @@ func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucket
 			}
 			client := client
 			ng.Go(ctx, func() error {
-				return client.ListenBucketNotification(bucketName, eventNames, pattern, targetID, localPeer)
+				return client.RemoveBucketDefaults(bucketName)
 			}, idx, *client.host)
 		}
 		ng.Wait()
 	}()
 }
 
+// SetBucketSSEConfig - calls SetBucketSSEConfig on all peers.
+func (sys *NotificationSys) SetBucketSSEConfig(ctx context.Context, bucketName string,
+	encConfig *bucketsse.BucketSSEConfig) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.SetBucketSSEConfig(bucketName, encConfig)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// RemoveBucketSSEConfig - calls RemoveBucketSSEConfig on all peers.
+func (sys *NotificationSys) RemoveBucketSSEConfig(ctx context.Context, bucketName string) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.RemoveBucketSSEConfig(bucketName)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// PutBucketNotification - calls PutBucketNotification RPC call on all peers.
+func (sys *NotificationSys) PutBucketNotification(ctx context.Context, bucketName string, rulesMap event.RulesMap) {
+	go func() {
+		ng := WithNPeers(len(sys.peerClients))
+		for idx, client := range sys.peerClients {
+			if client == nil {
+				continue
+			}
+			client := client
+			ng.Go(ctx, func() error {
+				return client.PutBucketNotification(bucketName, rulesMap)
+			}, idx, *client.host)
+		}
+		ng.Wait()
+	}()
+}
+
+// ListenBucketNotification - calls ListenBucketNotification RPC call on all peers,
+// waits until every reachable peer has registered the listener so that events
+// generated on any node after this call returns are fanned in to localPeer.
+func (sys *NotificationSys) ListenBucketNotification(ctx context.Context, bucketName string,
+	eventNames []event.Name, pattern string, targetID event.TargetID, localPeer xnet.Host) []NotificationPeerErr {
+	ng := WithNPeers(len(sys.peerClients))
+	for idx, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		client := client
+		ng.Go(ctx, func() error {
+			return client.ListenBucketNotification(bucketName, eventNames, pattern, targetID, localPeer)
+		}, idx, *client.host)
+	}
+	return ng.Wait()
+}
+
 // AddRemoteTarget - adds event rules map, HTTP/PeerRPC client target to bucket name.
 func (sys *NotificationSys) AddRemoteTarget(bucketName string, target event.Target, rulesMap event.RulesMap) error {
 	if err := sys.targetList.Add(target); err != nil {
@@ -890,24 +1090,46 @@ func (sys *NotificationSys) send(bucketName string, eventData event.Event, targe
 		errs = append(errs, terr)
 		if sys.RemoteTargetExist(bucketName, terr.ID) {
 			sys.RemoveRemoteTarget(bucketName, terr.ID)
+			continue
+		}
+		// Listen clients which went away are not dead letters.
+		if globalNotifyDeadLetterDir != "" && !strings.HasPrefix(terr.ID.ID, "httpclient+") {
+			if err := writeDeadLetter(globalNotifyDeadLetterDir, terr, eventData); err != nil {
+				errs = append(errs, event.TargetIDErr{ID: terr.ID, Err: err})
+			}
 		}
 	}
 
 	return errs
 }
 
-// Send - sends event data to all matching targets.
+// Send - sends event data to all matching targets, and to the
+// other nodes when the event bus is enabled.
 func (sys *NotificationSys) Send(args eventArgs) []event.TargetIDErr {
 	sys.RLock()
 	targetIDSet := sys.bucketRulesMap[args.BucketName].Match(args.EventName, args.Object.Name)
+	bus := sys.bus
 	sys.RUnlock()
 
+	if len(targetIDSet) == 0 && bus == nil {
+		return nil
+	}
+
+	eventData := args.ToEvent()
+	if bus != nil {
+		if err := bus.Publish(args.BucketName, eventData); err != nil {
+			reqInfo := &logger.ReqInfo{BucketName: args.BucketName, ObjectName: args.Object.Name}
+			ctx := logger.SetReqInfo(context.Background(), reqInfo)
+			logger.LogOnceIf(ctx, err, "event-bus-publish")
+		}
+	}
+
 	if len(targetIDSet) == 0 {
 		return nil
 	}
 
 	targetIDs := targetIDSet.ToSlice()
-	return sys.send(args.BucketName, args.ToEvent(), targetIDs...)
+	return sys.send(args.BucketName, eventData, targetIDs...)
 }
 
 // NetReadPerfInfo - Network read performance information.
@@ -1019,6 +1241,33 @@ func (sys *NotificationSys) MemUsageInfo() []ServerMemUsageInfo {
 	return reply
 }
 
+// Placement - returns the placement rules of the peers, keyed by peer
+// address. Peers which cannot be reached are left out.
+func (sys *NotificationSys) Placement() map[string]placement.Config {
+	reply := make([]placement.Config, len(sys.peerClients))
+
+	g := errgroup.WithNErrs(len(sys.peerClients))
+	for index, client := range sys.peerClients {
+		if client == nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			var err error
+			reply[index], err = sys.peerClients[index].Placement()
+			return err
+		}, index)
+	}
+
+	placements := make(map[string]placement.Config)
+	for index, err := range g.Wait() {
+		if err == nil && sys.peerClients[index] != nil {
+			placements[sys.peerClients[index].host.String()] = reply[index]
+		}
+	}
+	return placements
+}
+
 // CPULoadInfo - CPU utilization information
 func (sys *NotificationSys) CPULoadInfo() []ServerCPULoadInfo {
 	reply := make([]ServerCPULoadInfo, len(sys.peerClients))
@@ -1094,6 +1343,7 @@ type eventArgs struct {
 	EventName    event.Name
 	BucketName   string
 	Object       ObjectInfo
+	Tags         map[string]string
 	ReqParams    map[string]string
 	RespElements map[string]string
 	Host         string
@@ -1162,7 +1412,10 @@ func (args eventArgs) ToEvent() event.Event {
 			newEvent.S3.Object.Size = args.Object.GetActualSize()
 		}
 		newEvent.S3.Object.ContentType = args.Object.ContentType
-		newEvent.S3.Object.UserMetadata = args.Object.UserDefined
+		newEvent.S3.Object.UserMetadata = globalNotifyEventMetadata.Filter(args.Object.UserDefined)
+		if !globalNotifyEventMetadata.Disabled && len(args.Tags) > 0 {
+			newEvent.S3.Object.Tags = args.Tags
+		}
 	}
 
 	return newEvent
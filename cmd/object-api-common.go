@@ -94,6 +94,32 @@ func deleteBucketMetadata(ctx context.Context, bucket string, objAPI ObjectLayer
 
 	// Delete listener config, if present - ignore any errors.
 	removeListenerConfig(ctx, objAPI, bucket)
+
+	// Delete website config, if present - ignore any errors.
+	removeWebsiteConfig(ctx, objAPI, bucket)
+
+	// Delete bucket defaults, if present - ignore any errors.
+	removeBucketDefaultsConfig(ctx, objAPI, bucket)
+
+	// Delete bucket encryption config, if present - ignore any errors.
+	removeBucketSSEConfig(ctx, objAPI, bucket)
+}
+
+// checkPutPreconditions - evaluates the write preconditions in opts against
+// the object currently stored, callers must hold the object write lock so
+// that nothing is written in between.
+func checkPutPreconditions(opts ObjectOptions, getObjectInfo func() (ObjectInfo, error)) error {
+	if opts.CheckPutPrecondFn == nil {
+		return nil
+	}
+	objInfo, err := getObjectInfo()
+	if err != nil && !isErrObjectNotFound(err) {
+		return err
+	}
+	if opts.CheckPutPrecondFn(objInfo, err == nil) {
+		return PreConditionFailed{}
+	}
+	return nil
 }
 
 // Depending on the disk type network or local, initialize storage API.
@@ -446,3 +472,24 @@ func listObjects(ctx context.Context, obj ObjectLayer, bucket, prefix, marker, d
 	// Success.
 	return result, nil
 }
+
+// bucketExistsChecker - implemented by object layers which can check
+// whether a bucket exists without taking the bucket namespace lock
+// GetBucketInfo takes, which is a network round trip to all nodes
+// in a distributed setup.
+type bucketExistsChecker interface {
+	BucketExists(ctx context.Context, bucket string) (bool, error)
+}
+
+// bucketExists - returns true if the bucket exists, using the lock
+// free check of the object layer when available.
+func bucketExists(ctx context.Context, objAPI ObjectLayer, bucket string) (bool, error) {
+	if checker, ok := objAPI.(bucketExistsChecker); ok {
+		return checker.BucketExists(ctx, bucket)
+	}
+	_, err := objAPI.GetBucketInfo(ctx, bucket)
+	if _, ok := err.(BucketNotFound); ok {
+		return false, nil
+	}
+	return err == nil, err
+}
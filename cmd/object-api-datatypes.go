@@ -60,6 +60,11 @@ type StorageInfo struct {
 
 		// List of all disk status, this is only meaningful if BackendType is Erasure.
 		Sets [][]madmin.DriveInfo
+
+		// Following fields are only meaningful if BackendType is FS, as of the last disk usage crawl.
+		ObjectsCount          uint64                            // Number of objects in all buckets.
+		ObjectsSizesHistogram map[string]uint64                 // Number of objects per size class.
+		BucketsUsage          map[string]madmin.BucketUsageInfo // Objects count, total size and sizes histogram per bucket.
 	}
 }
 
@@ -106,6 +111,13 @@ type ObjectInfo struct {
 	// Specify object storage class
 	StorageClass string
 
+	// Whether a restore of the archived object is in progress.
+	RestoreOngoing bool
+
+	// Whether the object is served without bitrot verification,
+	// as no parity shard of it is left.
+	DegradedRead bool
+
 	// User-Defined metadata
 	UserDefined map[string]string
 
@@ -192,6 +192,39 @@ func (e ObjectAlreadyExists) Error() string {
 	return "Object: " + e.Bucket + "#" + e.Object + " already exists"
 }
 
+// ObjectNotAppendable object cannot be appended to.
+type ObjectNotAppendable GenericError
+
+func (e ObjectNotAppendable) Error() string {
+	return "Object: " + e.Bucket + "#" + e.Object + " cannot be appended to"
+}
+
+// InvalidAppendPosition append position is not the current size of the object.
+type InvalidAppendPosition struct {
+	Bucket   string
+	Object   string
+	Position int64
+	Size     int64
+}
+
+func (e InvalidAppendPosition) Error() string {
+	return fmt.Sprintf("Append position %d of %s#%s is not the object size %d", e.Position, e.Bucket, e.Object, e.Size)
+}
+
+// ObjectArchived object has to be restored before it can be read.
+type ObjectArchived GenericError
+
+func (e ObjectArchived) Error() string {
+	return "Object: " + e.Bucket + "#" + e.Object + " is archived"
+}
+
+// ObjectRestoreInProgress object is already being restored.
+type ObjectRestoreInProgress GenericError
+
+func (e ObjectRestoreInProgress) Error() string {
+	return "Object: " + e.Bucket + "#" + e.Object + " is already being restored"
+}
+
 // ObjectExistsAsDirectory object already exists as a directory.
 type ObjectExistsAsDirectory GenericError
 
@@ -261,6 +294,27 @@ func (e BucketLifecycleNotFound) Error() string {
 	return "No bucket life cycle found for bucket : " + e.Bucket
 }
 
+// BucketWebsiteNotFound - no bucket website configuration found.
+type BucketWebsiteNotFound GenericError
+
+func (e BucketWebsiteNotFound) Error() string {
+	return "No bucket website configuration found for bucket : " + e.Bucket
+}
+
+// BucketSSEConfigNotFound - no bucket encryption configuration found.
+type BucketSSEConfigNotFound GenericError
+
+func (e BucketSSEConfigNotFound) Error() string {
+	return "No bucket encryption configuration found for bucket : " + e.Bucket
+}
+
+// BucketDefaultsNotFound - no bucket defaults found.
+type BucketDefaultsNotFound GenericError
+
+func (e BucketDefaultsNotFound) Error() string {
+	return "No bucket defaults found for bucket : " + e.Bucket
+}
+
 /// Bucket related errors.
 
 // BucketNameInvalid - bucketname provided is invalid.
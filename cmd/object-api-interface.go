@@ -30,11 +30,16 @@ import (
 // CheckCopyPreconditionFn returns true if copy precondition check failed.
 type CheckCopyPreconditionFn func(o ObjectInfo, encETag string) bool
 
+// CheckPutPreconditionFn returns true if write precondition check failed,
+// exists is false when there is no object to overwrite.
+type CheckPutPreconditionFn func(o ObjectInfo, exists bool) bool
+
 // ObjectOptions represents object options for ObjectLayer operations
 type ObjectOptions struct {
 	ServerSideEncryption encrypt.ServerSide
 	UserDefined          map[string]string
 	CheckCopyPrecondFn   CheckCopyPreconditionFn
+	CheckPutPrecondFn    CheckPutPreconditionFn
 }
 
 // LockType represents required locking for ObjectLayer operations
@@ -56,7 +61,7 @@ type ObjectLayer interface {
 	MakeBucketWithLocation(ctx context.Context, bucket string, location string) error
 	GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error)
 	ListBuckets(ctx context.Context) (buckets []BucketInfo, err error)
-	DeleteBucket(ctx context.Context, bucket string) error
+	DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error
 	ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (result ListObjectsInfo, err error)
 	ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error)
 
@@ -109,6 +114,9 @@ type ObjectLayer interface {
 	// Compression support check.
 	IsCompressionSupported() bool
 
+	// Readiness check, returns true if the layer is able to serve requests.
+	IsReady(ctx context.Context) bool
+
 	// Lifecycle operations
 	SetBucketLifecycle(context.Context, string, *lifecycle.Lifecycle) error
 	GetBucketLifecycle(context.Context, string) (*lifecycle.Lifecycle, error)
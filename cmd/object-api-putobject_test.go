@@ -418,6 +418,76 @@ func testObjectAPIMultipartPutObjectStaleFiles(obj ObjectLayer, instanceType str
 	}
 }
 
+// Wrapper for calling PutObject precondition tests for both XL multiple disks and single node setup.
+func TestObjectAPIPutObjectPreconditions(t *testing.T) {
+	ExecObjectLayerTest(t, testObjectAPIPutObjectPreconditions)
+}
+
+// Tests validate that PutObject and CompleteMultipartUpload enforce write preconditions.
+func testObjectAPIPutObjectPreconditions(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	bucket := "minio-bucket"
+	object := "minio-object"
+
+	// Create bucket.
+	err := obj.MakeBucketWithLocation(context.Background(), bucket, "")
+	if err != nil {
+		// Failed to create newbucket, abort.
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+
+	createOnly := ObjectOptions{
+		CheckPutPrecondFn: func(oi ObjectInfo, exists bool) bool {
+			return exists
+		},
+	}
+	ifMatch := func(etag string) ObjectOptions {
+		return ObjectOptions{
+			CheckPutPrecondFn: func(oi ObjectInfo, exists bool) bool {
+				return !exists || !isETagEqual(oi.ETag, etag)
+			},
+		}
+	}
+
+	data := []byte("hello, world")
+	objInfo, err := obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), createOnly)
+	if err != nil {
+		t.Fatalf("%s: Create-only put of a new object failed: %s", instanceType, err)
+	}
+
+	_, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), createOnly)
+	if !isErrPreconditionFailed(err) {
+		t.Fatalf("%s: Expected create-only put of an existing object to fail with PreConditionFailed, got %v", instanceType, err)
+	}
+
+	newData := []byte("hello, world again")
+	_, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(newData), int64(len(newData)), "", ""), ifMatch("abcd"))
+	if !isErrPreconditionFailed(err) {
+		t.Fatalf("%s: Expected put with a stale ETag to fail with PreConditionFailed, got %v", instanceType, err)
+	}
+
+	if _, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(newData), int64(len(newData)), "", ""), ifMatch(objInfo.ETag)); err != nil {
+		t.Fatalf("%s: Put with the current ETag failed: %s", instanceType, err)
+	}
+
+	// Complete multipart with a failing precondition leaves the upload in place.
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	etag := getMD5Hash(data)
+	if _, err = obj.PutObjectPart(context.Background(), bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), etag, ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s : %s", instanceType, err.Error())
+	}
+	parts := []CompletePart{{ETag: etag, PartNumber: 1}}
+	_, err = obj.CompleteMultipartUpload(context.Background(), bucket, object, uploadID, parts, createOnly)
+	if !isErrPreconditionFailed(err) {
+		t.Fatalf("%s: Expected create-only complete multipart to fail with PreConditionFailed, got %v", instanceType, err)
+	}
+	if _, err = obj.CompleteMultipartUpload(context.Background(), bucket, object, uploadID, parts, ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Complete multipart after a failed precondition failed: %s", instanceType, err)
+	}
+}
+
 // Benchmarks for ObjectLayer.PutObject().
 // The intent is to benchmark PutObject for various sizes ranging from few bytes to 100MB.
 // Also each of these Benchmarks are run both XL and FS backends.
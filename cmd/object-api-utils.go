@@ -74,6 +74,17 @@ func isMinioMetaBucketName(bucket string) bool {
 		bucket == minioMetaTmpBucket
 }
 
+// isObjectImmutable returns true if objects of bucket are never
+// overwritten nor deleted once written, which is the case when WORM
+// is enabled. FS readers of such objects don't need the namespace
+// lock, the data and `fs.json` of a first PUT are only seen once
+// committed since readers wait for the lock on `fs.json` held by the
+// writer. XL readers still take the namespace lock, as a first PUT,
+// a heal or a metadata update is not atomic across disks.
+func isObjectImmutable(bucket string) bool {
+	return globalWORMEnabled && !isMinioMetaBucketName(bucket)
+}
+
 // IsValidBucketName verifies that a bucket name is in accordance with
 // Amazon's requirements (i.e. DNS naming conventions). It must be 3-63
 // characters long, and it must be a sequence of one or more labels
@@ -237,6 +248,15 @@ func cleanMetadata(metadata map[string]string) map[string]string {
 	return cleanMetadataKeys(metadata, "md5Sum", "etag", "expires")
 }
 
+// isValidStorageClass - returns true if the storage class is supported,
+// in gateway mode S3 storage classes are translated by the backend.
+func isValidStorageClass(sc string) bool {
+	if globalIsGateway {
+		return storageclass.IsValidGateway(sc)
+	}
+	return storageclass.IsValid(sc)
+}
+
 // Filter X-Amz-Storage-Class field only if it is set to STANDARD.
 // This is done since AWS S3 doesn't return STANDARD Storage class as response header.
 func removeStandardStorageClass(metadata map[string]string) map[string]string {
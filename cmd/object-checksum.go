@@ -0,0 +1,161 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+	"io"
+	"net/http"
+	"strings"
+
+	xhttp "github.com/minio/minio/cmd/http"
+	sha256 "github.com/minio/sha256-simd"
+)
+
+// Checksum algorithms of the object data, sent by clients in the
+// x-amz-checksum-<algorithm> header or in the trailer of a streaming
+// upload as the base64 encoded checksum.
+var checksumAlgorithms = map[string]func() hash.Hash{
+	"CRC32":  func() hash.Hash { return crc32.NewIEEE() },
+	"CRC32C": func() hash.Hash { return crc32.New(crc32.MakeTable(crc32.Castagnoli)) },
+	"SHA1":   sha1.New,
+	"SHA256": sha256.New,
+}
+
+// Returns the checksum algorithm of a x-amz-checksum-<algorithm>
+// header name, ok is false for unsupported algorithms.
+func checksumAlgorithmFromHeader(name string) (algorithm string, ok bool) {
+	if !strings.HasPrefix(strings.ToLower(name), strings.ToLower(xhttp.AmzChecksumPrefix)) {
+		return "", false
+	}
+	algorithm = strings.ToUpper(name[len(xhttp.AmzChecksumPrefix):])
+	_, ok = checksumAlgorithms[algorithm]
+	return algorithm, ok
+}
+
+// objectChecksum - checksum of the data of an object.
+type objectChecksum struct {
+	Algorithm string
+	Value     string // base64 encoded
+}
+
+// String returns the checksum as saved in the ObjectChecksumKey
+// metadata entry of the object.
+func (c objectChecksum) String() string {
+	return c.Algorithm + ":" + c.Value
+}
+
+// Header returns the x-amz-checksum-<algorithm> header name of the checksum.
+func (c objectChecksum) Header() string {
+	return xhttp.AmzChecksumPrefix + strings.ToLower(c.Algorithm)
+}
+
+// Matches returns true if the checksum is the checksum of the given hash.
+func (c objectChecksum) Matches(h hash.Hash) bool {
+	return base64.StdEncoding.EncodeToString(h.Sum(nil)) == c.Value
+}
+
+// Returns the checksum sent in the x-amz-checksum-<algorithm> header
+// of a request, if any. Only one checksum may be sent and it must be
+// a base64 encoded checksum of the size of the algorithm.
+func getObjectChecksumFromHeader(h http.Header) (c *objectChecksum, s3Err APIErrorCode) {
+	for algorithm, newHash := range checksumAlgorithms {
+		value := h.Get(xhttp.AmzChecksumPrefix + strings.ToLower(algorithm))
+		if value == "" {
+			continue
+		}
+		if c != nil {
+			return nil, ErrInvalidChecksum
+		}
+		sum, err := base64.StdEncoding.DecodeString(value)
+		if err != nil || len(sum) != newHash().Size() {
+			return nil, ErrInvalidChecksum
+		}
+		c = &objectChecksum{Algorithm: algorithm, Value: value}
+	}
+	return c, ErrNone
+}
+
+// Returns the checksum saved in the metadata of an object, if any.
+func getObjectChecksum(metadata map[string]string) (c objectChecksum, ok bool) {
+	value, ok := metadata[ObjectChecksumKey]
+	if !ok {
+		return c, false
+	}
+	i := strings.Index(value, ":")
+	if i < 0 {
+		return c, false
+	}
+	c = objectChecksum{Algorithm: value[:i], Value: value[i+1:]}
+	_, ok = checksumAlgorithms[c.Algorithm]
+	return c, ok
+}
+
+// Sets the x-amz-checksum-<algorithm> header of an object when the
+// client asks for it with the x-amz-checksum-mode header. Like S3 the
+// checksum of the whole object is not returned for range requests.
+func setObjectChecksumHeaders(w http.ResponseWriter, r *http.Request, objInfo ObjectInfo, rs *HTTPRangeSpec) {
+	if rs != nil || !strings.EqualFold(r.Header.Get(xhttp.AmzChecksumMode), "ENABLED") {
+		return
+	}
+	if c, ok := getObjectChecksum(objInfo.UserDefined); ok {
+		w.Header().Set(c.Header(), c.Value)
+	}
+}
+
+// checksumReader - verifies the checksum of the data read from src
+// once size bytes are read. A mismatch fails the last read with
+// errChecksumMismatch, dropping its data so that callers filling a
+// buffer with io.ReadFull see the error and the object is not created.
+type checksumReader struct {
+	src      io.Reader
+	size     int64
+	n        int64
+	h        hash.Hash
+	checksum objectChecksum
+	verified bool
+	err      error
+}
+
+func newChecksumReader(src io.Reader, size int64, checksum objectChecksum) *checksumReader {
+	return &checksumReader{
+		src:      src,
+		size:     size,
+		h:        checksumAlgorithms[checksum.Algorithm](),
+		checksum: checksum,
+	}
+}
+
+func (c *checksumReader) Read(p []byte) (n int, err error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err = c.src.Read(p)
+	c.h.Write(p[:n])
+	c.n += int64(n)
+	if (err == io.EOF || c.n == c.size) && !c.verified {
+		c.verified = true
+		if !c.checksum.Matches(c.h) {
+			c.err = errChecksumMismatch
+			return 0, c.err
+		}
+	}
+	return n, err
+}
@@ -225,6 +225,43 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// getPutPreconditionFn - returns the write precondition check for the
+// If-Match and If-None-Match headers of PutObject and
+// CompleteMultipartUpload requests, nil if neither is set.
+//
+// "If-None-Match: *" only creates the object if there is none, while
+// "If-Match: <etag>" only overwrites the object holding that ETag, which
+// together give compare-and-swap semantics as they are evaluated under
+// the object write lock.
+func getPutPreconditionFn(r *http.Request) CheckPutPreconditionFn {
+	ifMatchETagHeader := r.Header.Get(xhttp.IfMatch)
+	ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch)
+	if ifMatchETagHeader == "" && ifNoneMatchETagHeader == "" {
+		return nil
+	}
+	return func(objInfo ObjectInfo, exists bool) bool {
+		// If-Match : Write the object only if it exists and its entity tag (ETag)
+		// is the same as the one specified, "*" matches any object.
+		if ifMatchETagHeader != "" {
+			if !exists {
+				return true
+			}
+			if ifMatchETagHeader != "*" && !isETagEqual(objInfo.ETag, ifMatchETagHeader) {
+				return true
+			}
+		}
+
+		// If-None-Match : Write the object only if its entity tag (ETag) is different
+		// from the one specified, "*" fails if there is any object.
+		if ifNoneMatchETagHeader != "" && exists {
+			if ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -17,7 +17,10 @@
 package cmd
 
 import (
+	"net/http"
 	"testing"
+
+	xhttp "github.com/minio/minio/cmd/http"
 )
 
 // Tests - canonicalizeETag()
@@ -51,3 +54,51 @@ func TestCanonicalizeETag(t *testing.T) {
 		}
 	}
 }
+
+// Tests - getPutPreconditionFn()
+func TestGetPutPreconditionFn(t *testing.T) {
+	objInfo := ObjectInfo{ETag: "abcd"}
+	testCases := []struct {
+		ifMatch     string
+		ifNoneMatch string
+		exists      bool
+		failed      bool
+	}{
+		{ifNoneMatch: "*", exists: false, failed: false},
+		{ifNoneMatch: "*", exists: true, failed: true},
+		{ifNoneMatch: "\"abcd\"", exists: true, failed: true},
+		{ifNoneMatch: "efgh", exists: true, failed: false},
+		{ifMatch: "\"abcd\"", exists: true, failed: false},
+		{ifMatch: "efgh", exists: true, failed: true},
+		{ifMatch: "abcd", exists: false, failed: true},
+		{ifMatch: "*", exists: true, failed: false},
+		{ifMatch: "*", exists: false, failed: true},
+	}
+	for i, testCase := range testCases {
+		r, err := http.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if testCase.ifMatch != "" {
+			r.Header.Set(xhttp.IfMatch, testCase.ifMatch)
+		}
+		if testCase.ifNoneMatch != "" {
+			r.Header.Set(xhttp.IfNoneMatch, testCase.ifNoneMatch)
+		}
+		fn := getPutPreconditionFn(r)
+		if fn == nil {
+			t.Fatalf("Test %d: expected a precondition check", i+1)
+		}
+		if failed := fn(objInfo, testCase.exists); failed != testCase.failed {
+			t.Errorf("Test %d: expected failed to be %v, got %v", i+1, testCase.failed, failed)
+		}
+	}
+
+	r, err := http.NewRequest(http.MethodPut, "http://localhost:9000/bucket/object", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if getPutPreconditionFn(r) != nil {
+		t.Fatal("Expected no precondition check without conditional headers")
+	}
+}
@@ -26,6 +26,7 @@ import (
 	goioutil "io/ioutil"
 	"net/http"
 	"net/url"
+	"path"
 	"sort"
 	"strconv"
 	"strings"
@@ -35,7 +36,7 @@ import (
 	"github.com/gorilla/mux"
 	miniogo "github.com/minio/minio-go/v6"
 	"github.com/minio/minio-go/v6/pkg/encrypt"
-	"github.com/minio/minio/cmd/config/storageclass"
+	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -266,6 +267,15 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Anonymous requests on a bucket hosting a website are served
+	// with its index document, redirects and error document.
+	if config, ok := getWebsiteRequest(r, bucket); ok {
+		var handled bool
+		if object, handled = api.resolveWebsiteObject(ctx, w, r, objectAPI, config, bucket, object); handled {
+			return
+		}
+	}
+
 	// get gateway encryption options
 	opts, err := getOpts(ctx, r, bucket, object)
 	if err != nil {
@@ -311,6 +321,11 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if _, ok := r.URL.Query()[transformResizeParam]; ok {
+		api.getTransformedObject(ctx, w, r, objectAPI, bucket, object, opts)
+		return
+	}
+
 	getObjectNInfo := objectAPI.GetObjectNInfo
 	if api.CacheAPI() != nil {
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
@@ -367,13 +382,29 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		}
 	}
 
+	// Set the bucket defaults of headers the object lacks.
+	objInfo = globalBucketDefaultsSys.Apply(bucket, object, objInfo)
+
 	if err = setObjectHeaders(w, objInfo, rs); err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		return
 	}
+	setObjectChecksumHeaders(w, r, objInfo, rs)
 
 	setHeadGetRespHeaders(w, r.URL.Query())
 
+	// Every completed GET of a share link, ranged or not, counts
+	// against its download limit.
+	downloaded := false
+	if shareID := getShareLinkID(r); shareID != "" {
+		finishDownload, s3Error := globalShareLinkSys.StartDownload(ctx, objectAPI, shareID)
+		if s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		defer func() { finishDownload(downloaded) }()
+	}
+
 	statusCodeWritten := false
 	httpWriter := ioutil.WriteOnClose(w)
 	if rs != nil {
@@ -381,7 +412,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 		w.WriteHeader(http.StatusPartialContent)
 	}
 	// Write object content to response body
-	if _, err = io.Copy(httpWriter, gr); err != nil {
+	if _, err = io.Copy(httpWriter, globalBucketBandwidth.egressReader(ctx, bucket, gr)); err != nil {
 		if !httpWriter.HasWritten() && !statusCodeWritten { // write error response only if no data or headers has been written to client yet
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
 		}
@@ -394,6 +425,7 @@ func (api objectAPIHandlers) GetObjectHandler(w http.ResponseWriter, r *http.Req
 			return
 		}
 	}
+	downloaded = err == nil
 
 	// Notify object accessed via a GET request.
 	sendEvent(eventArgs{
@@ -437,6 +469,15 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Anonymous requests on a bucket hosting a website are served
+	// with its index document, redirects and error document.
+	if config, ok := getWebsiteRequest(r, bucket); ok {
+		var handled bool
+		if object, handled = api.resolveWebsiteObject(ctx, w, r, objectAPI, config, bucket, object); handled {
+			return
+		}
+	}
+
 	getObjectInfo := objectAPI.GetObjectInfo
 	if api.CacheAPI() != nil {
 		getObjectInfo = api.CacheAPI().GetObjectInfo
@@ -532,11 +573,15 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Set the bucket defaults of headers the object lacks.
+	objInfo = globalBucketDefaultsSys.Apply(bucket, object, objInfo)
+
 	// Set standard object headers.
 	if err = setObjectHeaders(w, objInfo, rs); err != nil {
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	setObjectChecksumHeaders(w, r, objInfo, rs)
 
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.URL.Query())
@@ -560,6 +605,95 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// Attributes of an object which can be requested in the
+// x-amz-object-attributes header of GetObjectAttributes.
+var objectAttributes = set.CreateStringSet("ETag", "Checksum", "ObjectParts", "StorageClass", "ObjectSize")
+
+// GetObjectAttributesHandler - GET Object?attributes
+// ----------
+// Returns the attributes of an object listed in the comma separated
+// x-amz-object-attributes header without returning the object itself.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(w, r, "GetObjectAttributes", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if !api.EncryptionEnabled() && crypto.IsRequested(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	attributes := set.NewStringSet()
+	for _, attribute := range strings.Split(r.Header.Get(xhttp.AmzObjectAttributes), ",") {
+		if attribute = strings.TrimSpace(attribute); !objectAttributes.Contains(attribute) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidObjectAttributes), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		attributes.Add(attribute)
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r.Header); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		objInfo.UserDefined = CleanMinioInternalMetadataKeys(objInfo.UserDefined)
+	}
+
+	// Validate pre-conditions if any.
+	if checkPreconditions(ctx, w, r, objInfo) {
+		return
+	}
+
+	size, err := getObjectContentSize(objInfo)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	response := generateGetObjectAttributesResponse(objInfo, size, attributes)
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -574,19 +708,39 @@ func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta m
 	crypto.RemoveSSEHeaders(defaultMeta)
 
 	// if x-amz-metadata-directive says REPLACE then
-	// we extract metadata from the input headers.
+	// we extract metadata from the input headers, COPY
+	// is the default behavior and returns the default
+	// metadata.
+	metadata := defaultMeta
 	if isMetadataReplace(r.Header) {
-		return extractMetadata(ctx, r)
+		var err error
+		if metadata, err = extractMetadata(ctx, r); err != nil {
+			return nil, err
+		}
+	}
+
+	// The data is copied as is, so is the checksum sent on its upload.
+	if checksum, ok := userMeta[ObjectChecksumKey]; ok {
+		metadata[ObjectChecksumKey] = checksum
 	}
 
-	// if x-amz-metadata-directive says COPY then we
-	// return the default metadata.
-	if isMetadataCopy(r.Header) {
-		return defaultMeta, nil
+	// The canned ACL of the source is never copied.
+	if err := extractObjectACL(r.Header, metadata); err != nil {
+		return nil, err
 	}
 
-	// Copy is default behavior if not x-amz-metadata-directive is set.
-	return defaultMeta, nil
+	// Tags follow x-amz-tagging-directive independently of the
+	// metadata directive, the source tags are copied by default.
+	if isTaggingReplace(r.Header) {
+		if err := extractObjectTags(r.Header, metadata); err != nil {
+			return nil, err
+		}
+		return metadata, nil
+	}
+	if tags, ok := userMeta[ObjectTagsKey]; ok {
+		metadata[ObjectTagsKey] = tags
+	}
+	return metadata, nil
 }
 
 // Returns a minio-go Client configured to access remote host described by destDNSRecord
@@ -710,8 +864,14 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	// Check if tagging directive is valid.
+	if !isTaggingDirectiveValid(r.Header) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidTagDirective), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// This request header needs to be set prior to setting ObjectOptions
-	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
+	if isBucketAutoEncrypted(dstBucket) && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 
@@ -956,12 +1116,13 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// Ensure that metadata does not contain sensitive information
 	crypto.RemoveSensitiveEntries(srcInfo.UserDefined)
-	// Check if x-amz-metadata-directive was not set to REPLACE and source,
-	// desination are same objects. Apply this restriction also when
-	// metadataOnly is true indicating that we are not overwriting the object.
-	// if encryption is enabled we do not need explicit "REPLACE" metadata to
-	// be enabled as well - this is to allow for key-rotation.
-	if !isMetadataReplace(r.Header) && srcInfo.metadataOnly && !crypto.IsEncrypted(srcInfo.UserDefined) {
+	// Check if neither x-amz-metadata-directive nor x-amz-tagging-directive
+	// was set to REPLACE and source, desination are same objects. Apply this
+	// restriction also when metadataOnly is true indicating that we are not
+	// overwriting the object. if encryption is enabled we do not need explicit
+	// "REPLACE" metadata to be enabled as well - this is to allow for key-rotation.
+	if !isMetadataReplace(r.Header) && !isTaggingReplace(r.Header) &&
+		srcInfo.metadataOnly && !crypto.IsEncrypted(srcInfo.UserDefined) {
 		// If x-amz-metadata-directive is not set to REPLACE then we need
 		// to error out if source and destination are same.
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidCopyDest), r.URL, guessIsBrowserReq(r))
@@ -1017,6 +1178,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		EventName:    event.ObjectCreatedCopy,
 		BucketName:   dstBucket,
 		Object:       objInfo,
+		Tags:         extractTags(r),
 		ReqParams:    extractReqParams(r),
 		RespElements: extractRespElements(w),
 		UserAgent:    r.UserAgent(),
@@ -1063,9 +1225,17 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Write preconditions are enforced under the object namespace lock,
+	// which gateways do not hold.
+	putPrecondFn := getPutPreconditionFn(r)
+	if putPrecondFn != nil && globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !isValidStorageClass(sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -1110,6 +1280,27 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if err = extractObjectTags(r.Header, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = extractObjectACL(r.Header, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The checksum sent in a x-amz-checksum header is verified while
+	// the data is read and saved along with the object.
+	checksum, s3Error := getObjectChecksumFromHeader(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if checksum != nil {
+		metadata[ObjectChecksumKey] = checksum.String()
+	}
+
 	if rAuthType == authTypeStreamingSigned {
 		if contentEncoding, ok := metadata["content-encoding"]; ok {
 			contentEncoding = trimAwsChunkedContentEncoding(contentEncoding)
@@ -1171,12 +1362,22 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	// This request header needs to be set prior to setting ObjectOptions
-	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
+	if isBucketAutoEncrypted(bucket) && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 
+	reader = globalBucketBandwidth.ingressReader(ctx, bucket, reader)
 	actualSize := size
 
+	if checksum != nil {
+		// Empty objects are never read, verify their checksum upfront.
+		if size == 0 && !checksum.Matches(checksumAlgorithms[checksum.Algorithm]()) {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrChecksumMismatch), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		reader = newChecksumReader(reader, size, *checksum)
+	}
+
 	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
@@ -1218,6 +1419,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponseHeadersOnly(w, toAPIError(ctx, err))
 		return
 	}
+	opts.CheckPutPrecondFn = putPrecondFn
 
 	// Deny if WORM is enabled
 	if globalWORMEnabled {
@@ -1269,6 +1471,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		etag = getDecryptedETag(r.Header, objInfo, false)
 	}
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	if checksum != nil {
+		w.Header().Set(checksum.Header(), checksum.Value)
+	}
 
 	if objectAPI.IsEncryptionSupported() {
 		if crypto.IsEncrypted(objInfo.UserDefined) {
@@ -1285,6 +1490,174 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	writeSuccessResponseHeadersOnly(w)
 
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedPut,
+		BucketName:   bucket,
+		Object:       objInfo,
+		Tags:         extractTags(r),
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
+// AppendObjectHandler - POST Object?append&position=<position>
+// ----------
+// MinIO extension which appends the request body to the object at
+// position, the current size of the object. An object which does
+// not exist yet is created when position is 0. The position for the
+// next append is returned in the x-minio-next-append-position header.
+func (api objectAPIHandlers) AppendObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "AppendObject")
+
+	defer logger.AuditLog(w, r, "AppendObject", mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	appender, ok := objectAPI.(objectAppender)
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	// Appended data is stored as is, encrypted objects cannot be appended to.
+	if crypto.IsRequested(r.Header) || isBucketAutoEncrypted(bucket) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// To detect if the client has disconnected.
+	r.Body = &detectDisconnect{r.Body, r.Context().Done()}
+
+	position, err := strconv.ParseInt(r.URL.Query().Get("position"), 10, 64)
+	if err != nil || position < 0 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Get Content-Md5 sent by client and verify if valid
+	md5Bytes, err := checkValidMD5(r.Header)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidDigest), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	/// if Content-Length is unknown/missing, deny the request
+	size := r.ContentLength
+	rAuthType := getRequestAuthType(r)
+	if rAuthType == authTypeStreamingSigned {
+		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
+			if sizeStr[0] == "" {
+				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+				return
+			}
+			size, err = strconv.ParseInt(sizeStr[0], 10, 64)
+			if err != nil {
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+				return
+			}
+		}
+	}
+	if size == -1 {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	/// maximum Upload size for objects in a single operation
+	if isMaxObjectSize(size) || isMaxObjectSize(position+size) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrEntityTooLarge), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Metadata is only used when the append creates the object.
+	metadata, err := extractMetadata(ctx, r)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if rAuthType == authTypeStreamingSigned {
+		if contentEncoding, ok := metadata["content-encoding"]; ok {
+			contentEncoding = trimAwsChunkedContentEncoding(contentEncoding)
+			if contentEncoding != "" {
+				metadata["content-encoding"] = contentEncoding
+			} else {
+				delete(metadata, "content-encoding")
+			}
+		}
+	}
+
+	var (
+		md5hex    = hex.EncodeToString(md5Bytes)
+		sha256hex = ""
+		reader    io.Reader
+		s3Err     APIErrorCode
+	)
+	reader = r.Body
+
+	// Check if put is allowed
+	if s3Err = isPutAllowed(rAuthType, bucket, object, r); s3Err != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	switch rAuthType {
+	case authTypeStreamingSigned:
+		// Initialize stream signature verifier.
+		reader, s3Err = newSignV4ChunkedReader(r)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	case authTypeSignedV2, authTypePresignedV2:
+		s3Err = isReqAuthenticatedV2(r)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+
+	case authTypePresigned, authTypeSigned:
+		if s3Err = reqSignatureV4Verify(r, globalServerConfig.GetRegion(), serviceS3); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if !skipContentSha256Cksum(r) {
+			sha256hex = getContentSha256Cksum(r, serviceS3)
+		}
+	}
+
+	reader = globalBucketBandwidth.ingressReader(ctx, bucket, reader)
+	hashReader, err := hash.NewReader(reader, size, md5hex, sha256hex, size, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	opts, err := putOpts(ctx, r, bucket, object, metadata)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	objInfo, err := appender.AppendObject(ctx, bucket, object, position, NewPutObjReader(hashReader, nil, nil), opts)
+	if err != nil {
+		if e, ok := err.(InvalidAppendPosition); ok {
+			w.Header().Set(xhttp.MinIONextAppendPosition, strconv.FormatInt(e.Size, 10))
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	w.Header()[xhttp.ETag] = []string{"\"" + objInfo.ETag + "\""}
+	w.Header().Set(xhttp.MinIONextAppendPosition, strconv.FormatInt(objInfo.Size, 10))
+	writeSuccessResponseHeadersOnly(w)
+
 	// Notify object created event.
 	sendEvent(eventArgs{
 		EventName:    event.ObjectCreatedPut,
@@ -1333,7 +1706,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	}
 
 	// This request header needs to be set prior to setting ObjectOptions
-	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
+	if isBucketAutoEncrypted(bucket) && !crypto.SSEC.IsRequested(r.Header) && !crypto.S3KMS.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 
@@ -1357,7 +1730,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !isValidStorageClass(sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL, guessIsBrowserReq(r))
 			return
 		}
@@ -1384,6 +1757,16 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if err = extractObjectTags(r.Header, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if err = extractObjectACL(r.Header, metadata); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -1871,6 +2254,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, compressPart := li.UserDefined[ReservedMetadataPrefix+"compression"]
 
+	reader = globalBucketBandwidth.ingressReader(ctx, bucket, reader)
 	isCompressed := false
 	if objectAPI.IsCompressionSupported() && compressPart {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize, globalCLIContext.StrictS3Compat)
@@ -2190,6 +2574,14 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		}
 	}
 
+	// Write preconditions are enforced under the object namespace lock,
+	// which gateways do not hold.
+	putPrecondFn := getPutPreconditionFn(r)
+	if putPrecondFn != nil && globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
 	// Get upload id.
 	uploadID, _, _, _, s3Error := getObjectResources(r.URL.Query())
 	if s3Error != ErrNone {
@@ -2279,6 +2671,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 		completeParts = append(completeParts, part)
 	}
 
+	opts.CheckPutPrecondFn = putPrecondFn
 	completeMultiPartUpload := objectAPI.CompleteMultipartUpload
 
 	// This code is specifically to handle the requirements for slow
@@ -2412,3 +2805,119 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	}
 	writeSuccessNoContent(w)
 }
+
+// RestoreObjectHandler - POST Object?restore
+// ----------
+// Starts the restore of an archived object, replies 202 Accepted when
+// the restore is started and 200 OK when the object can already be
+// read. Only supported by object layers which archive objects.
+func (api objectAPIHandlers) RestoreObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RestoreObject")
+
+	defer logger.AuditLog(w, r, "RestoreObject", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	restorer, ok := objectAPI.(objectRestorer)
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.RestoreObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if vid := r.URL.Query().Get("versionId"); vid != "" && vid != "null" {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNoSuchVersion), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	req, err := parseRestoreRequest(r.Body, r.ContentLength)
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMalformedXML), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	restored, err := restorer.RestoreObject(ctx, bucket, object, req)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if restored {
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+	writeResponse(w, http.StatusAccepted, nil, mimeNone)
+}
+
+// GetObjectTorrentHandler - GET Object?torrent
+// ----------
+// Returns a torrent of the object with the server as web seed, only
+// objects anonymous users are allowed to read can be shared this way.
+func (api objectAPIHandlers) GetObjectTorrentHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectTorrent")
+
+	defer logger.AuditLog(w, r, "GetObjectTorrent", mustGetClaimsFromToken(r))
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object := vars["object"]
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// Peers download the object anonymously from the web seed.
+	if !globalPolicySys.IsAllowed(policy.Args{
+		Action:          policy.GetObjectAction,
+		BucketName:      bucket,
+		ConditionValues: getConditionValues(r, "", ""),
+		IsOwner:         false,
+		ObjectName:      object,
+	}) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrAccessDenied), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	if api.CacheAPI() != nil {
+		getObjectNInfo = api.CacheAPI().GetObjectNInfo
+	}
+
+	// Objects encrypted with a customer key cannot be read anonymously
+	// and fail here.
+	gr, err := getObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	defer gr.Close()
+	objInfo := gr.ObjInfo
+
+	size := objInfo.Size
+	if actualSize := objInfo.GetActualSize(); actualSize >= 0 {
+		size = actualSize
+	}
+	torrent, err := newObjectTorrent(gr, path.Base(object), size, objInfo.ModTime, getObjectLocation(r, globalDomainNames, bucket, object))
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	writeResponse(w, http.StatusOK, torrent, mimeTorrent)
+}
@@ -20,10 +20,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/xml"
 	"fmt"
+	"hash/crc32"
 	"io"
 	"runtime"
 	"strings"
@@ -1162,6 +1164,285 @@ func testAPIPutObjectStreamSigV4Handler(obj ObjectLayer, instanceType, bucketNam
 	}
 }
 
+// Wrapper for calling AppendObject API handler tests for both XL multiple disks and FS single drive setup.
+// Wrapper for calling PutObject with a streaming signature followed by
+// a trailing checksum.
+func TestAPIPutObjectStreamSigV4TrailerHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectStreamSigV4TrailerHandler, []string{"PutObject"})
+}
+
+func testAPIPutObjectStreamSigV4TrailerHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+	data := bytes.Repeat([]byte("a"), 65*humanize.KiByte)
+	crc32c := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	crc32c.Write(data)
+	sha256sum := sha256.Sum256(data)
+
+	testCases := []struct {
+		trailerName        string
+		trailerValue       string
+		expectedRespStatus int
+		expectedErrCode    APIErrorCode
+	}{
+		// Test case - 1.
+		// Valid CRC32C trailer.
+		{"x-amz-checksum-crc32c", base64.StdEncoding.EncodeToString(crc32c.Sum(nil)), http.StatusOK, ErrNone},
+		// Test case - 2.
+		// Valid SHA256 trailer.
+		{"x-amz-checksum-sha256", base64.StdEncoding.EncodeToString(sha256sum[:]), http.StatusOK, ErrNone},
+		// Test case - 3.
+		// Checksum not matching the data.
+		{"x-amz-checksum-crc32c", "AAAAAA==", http.StatusBadRequest, ErrChecksumMismatch},
+		// Test case - 4.
+		// Unsupported trailer.
+		{"x-amz-checksum-md4", "AAAAAA==", http.StatusBadRequest, ErrInvalidTrailer},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestStreamingSignedTrailerRequest(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), 64*humanize.KiByte, bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+			testCase.trailerName, testCase.trailerValue)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+		if testCase.expectedErrCode != ErrNone {
+			errCode := errorCodes.ToAPIErr(testCase.expectedErrCode).Code
+			if !bytes.Contains(rec.Body.Bytes(), []byte(errCode)) {
+				t.Errorf("Test %d: %s: Expected error %s, got %s", i+1, instanceType, errCode, rec.Body.String())
+			}
+			continue
+		}
+
+		var buffer bytes.Buffer
+		if err = obj.GetObject(context.Background(), bucketName, objectName, 0, int64(len(data)), &buffer, "", ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: %s: Failed to fetch the uploaded object: <ERROR> %v", i+1, instanceType, err)
+		}
+		if !bytes.Equal(buffer.Bytes(), data) {
+			t.Errorf("Test %d: %s: Data mismatch, uploaded object differs from the sent data", i+1, instanceType)
+		}
+	}
+
+	// A tampered trailer signature is rejected.
+	req, err := newTestStreamingSignedTrailerRequest(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+		int64(len(data)), 64*humanize.KiByte, bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+		"x-amz-checksum-crc32c", base64.StdEncoding.EncodeToString(crc32c.Sum(nil)))
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for PutObject: <ERROR> %v", instanceType, err)
+	}
+	stream, _ := ioutil.ReadAll(req.Body)
+	stream = bytes.Replace(stream, []byte(trailerSignatureKey+":"), []byte(trailerSignatureKey+":0"), 1)
+	req.Body = ioutil.NopCloser(bytes.NewReader(stream))
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("%s: Expected the response status to be `%d` for a tampered trailer, but instead found `%d`",
+			instanceType, http.StatusForbidden, rec.Code)
+	}
+}
+
+// Wrapper for calling PutObject with a x-amz-checksum header, and
+// fetching the checksum back, for both XL and FS.
+func TestAPIPutObjectChecksumHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIPutObjectChecksumHandler, []string{"PutObject", "HeadObject", "GetObjectAttributes", "GetObject"})
+}
+
+func testAPIPutObjectChecksumHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+	data := bytes.Repeat([]byte("a"), 65*humanize.KiByte)
+	crc32c := crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	crc32c.Write(data)
+	crc32cValue := base64.StdEncoding.EncodeToString(crc32c.Sum(nil))
+	sha256sum := sha256.Sum256(data)
+	sha256Value := base64.StdEncoding.EncodeToString(sha256sum[:])
+
+	testCases := []struct {
+		headers            map[string]string
+		expectedRespStatus int
+		expectedErrCode    APIErrorCode
+	}{
+		// Test case - 1.
+		// Checksum not matching the data.
+		{map[string]string{"x-amz-checksum-crc32c": "AAAAAA=="}, http.StatusBadRequest, ErrChecksumMismatch},
+		// Test case - 2.
+		// Checksum not of the size of the algorithm.
+		{map[string]string{"x-amz-checksum-sha256": crc32cValue}, http.StatusBadRequest, ErrInvalidChecksum},
+		// Test case - 3.
+		// More than one checksum.
+		{map[string]string{"x-amz-checksum-crc32c": crc32cValue, "x-amz-checksum-sha256": sha256Value}, http.StatusBadRequest, ErrInvalidChecksum},
+		// Test case - 4.
+		// Valid SHA256 checksum.
+		{map[string]string{"x-amz-checksum-sha256": sha256Value}, http.StatusOK, ErrNone},
+		// Test case - 5.
+		// Valid CRC32C checksum, the checksum of the object is replaced.
+		{map[string]string{"x-amz-checksum-crc32c": crc32cValue}, http.StatusOK, ErrNone},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, testCase.headers)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+				i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+		if testCase.expectedErrCode != ErrNone {
+			errCode := errorCodes.ToAPIErr(testCase.expectedErrCode).Code
+			if !bytes.Contains(rec.Body.Bytes(), []byte(errCode)) {
+				t.Errorf("Test %d: %s: Expected error %s, got %s", i+1, instanceType, errCode, rec.Body.String())
+			}
+		}
+	}
+
+	// The checksum of the object is only returned when asked for.
+	for _, checksumMode := range []string{"", "ENABLED"} {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodHead, getHeadObjectURL("", bucketName, objectName),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzChecksumMode: checksumMode})
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for HeadObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		expected := ""
+		if checksumMode != "" {
+			expected = crc32cValue
+		}
+		if got := rec.Header().Get("x-amz-checksum-crc32c"); got != expected {
+			t.Errorf("%s: x-amz-checksum-mode %q: expected checksum %q, got %q", instanceType, checksumMode, expected, got)
+		}
+	}
+
+	// Range requests do not return the checksum of the whole object.
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodGet, getGetObjectURL("", bucketName, objectName),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzChecksumMode: "ENABLED", "Range": "bytes=0-9"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for GetObject: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusPartialContent || rec.Header().Get("x-amz-checksum-crc32c") != "" {
+		t.Errorf("%s: Expected a partial content without checksum, got `%d` with checksum %q",
+			instanceType, rec.Code, rec.Header().Get("x-amz-checksum-crc32c"))
+	}
+
+	rec = httptest.NewRecorder()
+	req, err = newTestSignedRequestV4(http.MethodGet, getGetObjectAttributesURL("", bucketName, objectName),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: "Checksum,ObjectSize"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s",
+			instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	var attributes GetObjectAttributesResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &attributes); err != nil {
+		t.Fatalf("%s: Failed to decode the GetObjectAttributes response: <ERROR> %v", instanceType, err)
+	}
+	if attributes.Checksum == nil || attributes.Checksum.ChecksumCRC32C != crc32cValue {
+		t.Errorf("%s: Expected the CRC32C checksum %q, got %+v", instanceType, crc32cValue, attributes.Checksum)
+	}
+	if attributes.ObjectSize == nil || *attributes.ObjectSize != int64(len(data)) {
+		t.Errorf("%s: Expected the object size %d, got %v", instanceType, len(data), attributes.ObjectSize)
+	}
+	if attributes.ETag != "" || attributes.StorageClass != "" {
+		t.Errorf("%s: Expected only the requested attributes, got %+v", instanceType, attributes)
+	}
+
+	// Unknown attributes are rejected.
+	rec = httptest.NewRecorder()
+	req, err = newTestSignedRequestV4(http.MethodGet, getGetObjectAttributesURL("", bucketName, objectName),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzObjectAttributes: "ETag,Owner"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d` for an unknown attribute, but instead found `%d`",
+			instanceType, http.StatusBadRequest, rec.Code)
+	}
+}
+
+func TestAPIAppendObjectHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIAppendObjectHandler, []string{"AppendObject"})
+}
+
+func testAPIAppendObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "test-object"
+
+	testCases := []struct {
+		position             string
+		data                 []byte
+		expectedRespStatus   int
+		expectedNextPosition string
+	}{
+		// Test case - 1.
+		// Creating the object with the first append.
+		{"0", []byte("hello "), http.StatusOK, "6"},
+		// Test case - 2.
+		// Appending at the end of the object.
+		{"6", []byte("world"), http.StatusOK, "11"},
+		// Test case - 3.
+		// Appending at a stale position.
+		{"6", []byte("world"), http.StatusConflict, "11"},
+		// Test case - 4.
+		// Invalid position.
+		{"-1", []byte("world"), http.StatusBadRequest, ""},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPost, getAppendObjectURL("", bucketName, objectName, testCase.position),
+			int64(len(testCase.data)), bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for AppendObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+
+		// Appending is only supported in FS mode.
+		if instanceType != FSTestStr {
+			if rec.Code != http.StatusNotImplemented {
+				t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusNotImplemented, rec.Code)
+			}
+			continue
+		}
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if nextPosition := rec.Header().Get(xhttp.MinIONextAppendPosition); nextPosition != testCase.expectedNextPosition {
+			t.Fatalf("Test %d: %s: Expected next append position `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedNextPosition, nextPosition)
+		}
+	}
+
+	if instanceType != FSTestStr {
+		return
+	}
+	var buffer bytes.Buffer
+	if err := obj.GetObject(context.Background(), bucketName, objectName, 0, -1, &buffer, "", ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to fetch the appended object: <ERROR> %v", instanceType, err)
+	}
+	if buffer.String() != "hello world" {
+		t.Fatalf("%s: Expected object content `hello world`, but instead found `%s`", instanceType, buffer.String())
+	}
+}
+
 // Wrapper for calling PutObject API handler tests for both XL multiple disks and FS single drive setup.
 func TestAPIPutObjectHandler(t *testing.T) {
 	defer DetectTestLeak(t)()
@@ -2319,6 +2600,107 @@ func testAPICopyObjectHandler(obj ObjectLayer, instanceType, bucketName string,
 
 }
 
+// Wrapper for calling Copy Object API handler tests with the metadata and
+// tagging directives for both XL multiple disks and FS single drive setup.
+func TestAPICopyObjectDirectivesHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPICopyObjectDirectivesHandler, []string{"CopyObject"})
+}
+
+func testAPICopyObjectDirectivesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+
+	objectName := "test-object"
+	data := []byte("hello, world")
+	_, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+		UserDefined: map[string]string{
+			"X-Amz-Meta-Team": "storage",
+			ObjectTagsKey:     "project=blue",
+		},
+	})
+	if err != nil {
+		t.Fatalf("%s: Failed to upload the object: <ERROR> %v", instanceType, err)
+	}
+
+	testCases := []struct {
+		newObjectName     string
+		metadataDirective string
+		taggingDirective  string
+		header            map[string]string
+
+		expectedRespStatus int
+		expectedTeam       string
+		expectedTags       string
+	}{
+		// Test case - 1, metadata and tags are copied by default.
+		{"object-1", "", "", map[string]string{"X-Amz-Meta-Team": "ops", xhttp.AmzObjectTagging: "project=red"},
+			http.StatusOK, "storage", "project=blue"},
+		// Test case - 2, tags are replaced, metadata is copied.
+		{"object-2", "COPY", "REPLACE", map[string]string{"X-Amz-Meta-Team": "ops", xhttp.AmzObjectTagging: "project=red&env=prod"},
+			http.StatusOK, "storage", "env=prod&project=red"},
+		// Test case - 3, replacing with an empty tag set removes the tags.
+		{"object-3", "", "REPLACE", nil,
+			http.StatusOK, "storage", ""},
+		// Test case - 4, metadata is replaced, tags are copied.
+		{"object-4", "REPLACE", "COPY", map[string]string{"X-Amz-Meta-Team": "ops", xhttp.AmzObjectTagging: "project=red"},
+			http.StatusOK, "ops", "project=blue"},
+		// Test case - 5, unknown tagging directive.
+		{"object-5", "", "Unknown", nil,
+			http.StatusBadRequest, "", ""},
+		// Test case - 6, unknown metadata directive.
+		{"object-6", "Unknown", "", nil,
+			http.StatusBadRequest, "", ""},
+		// Test case - 7, malformed tag set.
+		{"object-7", "", "REPLACE", map[string]string{xhttp.AmzObjectTagging: "=red"},
+			http.StatusBadRequest, "", ""},
+		// Test case - 8, copying an object onto itself without any directive.
+		{objectName, "", "", nil,
+			http.StatusBadRequest, "", ""},
+		// Test case - 9, copying an object onto itself replacing the tags.
+		{objectName, "", "REPLACE", map[string]string{xhttp.AmzObjectTagging: "project=green"},
+			http.StatusOK, "storage", "project=green"},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestRequest(http.MethodPut, getCopyObjectURL("", bucketName, testCase.newObjectName), 0, nil)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request for copy Object: <ERROR> %v", i+1, err)
+		}
+		req.Header.Set("X-Amz-Copy-Source", url.QueryEscape(SlashSeparator+bucketName+SlashSeparator+objectName))
+		if testCase.metadataDirective != "" {
+			req.Header.Set(xhttp.AmzMetadataDirective, testCase.metadataDirective)
+		}
+		if testCase.taggingDirective != "" {
+			req.Header.Set(xhttp.AmzTagDirective, testCase.taggingDirective)
+		}
+		for k, v := range testCase.header {
+			req.Header.Set(k, v)
+		}
+		if err = signRequestV4(req, credentials.AccessKey, credentials.SecretKey); err != nil {
+			t.Fatalf("Test %d: Failed to sign the HTTP request: %v", i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if rec.Code != http.StatusOK {
+			continue
+		}
+
+		objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, testCase.newObjectName, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to stat the copied object: <ERROR> %v", i+1, instanceType, err)
+		}
+		if team := objInfo.UserDefined["X-Amz-Meta-Team"]; team != testCase.expectedTeam {
+			t.Errorf("Test %d: %s: Expected metadata `%s`, but found `%s`", i+1, instanceType, testCase.expectedTeam, team)
+		}
+		if tags := objInfo.UserDefined[ObjectTagsKey]; tags != testCase.expectedTags {
+			t.Errorf("Test %d: %s: Expected tags `%s`, but found `%s`", i+1, instanceType, testCase.expectedTags, tags)
+		}
+	}
+}
+
 // Wrapper for calling NewMultipartUpload tests for both XL multiple disks and single node setup.
 // First register the HTTP handler for NewMutlipartUpload, then a HTTP request for NewMultipart upload is made.
 // The UploadID from the response body is parsed and its existence is asserted with an attempt to ListParts using it.
@@ -3930,3 +4312,53 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling GetObjectTorrent HTTP handler tests for both XL multiple disks and single node setup.
+func TestAPIGetObjectTorrentHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectTorrentHandler, []string{"GetObjectTorrent"})
+}
+
+func testAPIGetObjectTorrentHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	objectName := "dir/test-object"
+	data := generateBytesData(2*torrentMinPieceLength + 1)
+	objInfo, err := obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("MinIO %s: Error uploading object: <ERROR> %v", instanceType, err)
+	}
+
+	getTorrent := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4("GET", makeTestTargetURL("", bucketName, objectName, url.Values{"torrent": []string{""}}),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("MinIO %s: Failed to create HTTP request for GetObjectTorrent: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Objects anonymous users cannot read are not shared.
+	if rec := getTorrent(); rec.Code != http.StatusForbidden {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusForbidden, rec.Code)
+	}
+
+	globalPolicySys.Set(bucketName, *getAnonReadOnlyObjectPolicy(bucketName, "dir/*"))
+	defer globalPolicySys.Remove(bucketName)
+
+	rec := getTorrent()
+	if rec.Code != http.StatusOK {
+		t.Fatalf("MinIO %s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if contentType := rec.Header().Get(xhttp.ContentType); contentType != string(mimeTorrent) {
+		t.Errorf("MinIO %s: Expected content type %s, got %s", instanceType, mimeTorrent, contentType)
+	}
+	expected, err := newObjectTorrent(bytes.NewReader(data), "test-object", int64(len(data)), objInfo.ModTime, "/"+bucketName+"/"+objectName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rec.Body.Bytes(), expected) {
+		t.Errorf("MinIO %s: Unexpected torrent %q", instanceType, rec.Body.String())
+	}
+}
@@ -0,0 +1,80 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+)
+
+// Retrieval tiers of a RestoreObject request, the tier decides how
+// fast an archived object is restored.
+const (
+	RestoreTierStandard  = "Standard"
+	RestoreTierBulk      = "Bulk"
+	RestoreTierExpedited = "Expedited"
+)
+
+// Maximum size of the body of a RestoreObject request.
+const maxRestoreRequestSize = 4096
+
+// RestoreRequest - body of a RestoreObject request.
+type RestoreRequest struct {
+	XMLName              xml.Name `xml:"RestoreRequest" json:"-"`
+	Days                 int      `xml:"Days,omitempty"`
+	GlacierJobParameters struct {
+		Tier string `xml:"Tier"`
+	} `xml:"GlacierJobParameters"`
+}
+
+// Tier - returns the retrieval tier of the request, Standard when
+// none is given.
+func (r RestoreRequest) Tier() string {
+	if r.GlacierJobParameters.Tier == "" {
+		return RestoreTierStandard
+	}
+	return r.GlacierJobParameters.Tier
+}
+
+// objectRestorer - implemented by object layers which archive objects
+// and can restore them to be read again.
+type objectRestorer interface {
+	// RestoreObject starts the restore of an archived object, restored
+	// is true when the object can already be read and nothing was done.
+	RestoreObject(ctx context.Context, bucket, object string, req RestoreRequest) (restored bool, err error)
+}
+
+// parseRestoreRequest - parses and validates the body of a
+// RestoreObject request, an empty body restores with the defaults.
+func parseRestoreRequest(body io.Reader, size int64) (req RestoreRequest, err error) {
+	if size == 0 {
+		return req, nil
+	}
+	if err = xmlDecoder(io.LimitReader(body, maxRestoreRequestSize), &req, size); err != nil {
+		return req, err
+	}
+	if req.Days < 0 {
+		return req, errInvalidArgument
+	}
+	switch req.Tier() {
+	case RestoreTierStandard, RestoreTierBulk, RestoreTierExpedited:
+	default:
+		return req, errInvalidArgument
+	}
+	return req, nil
+}
@@ -0,0 +1,54 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRestoreRequest(t *testing.T) {
+	testCases := []struct {
+		body         string
+		expectedDays int
+		expectedTier string
+		expectErr    bool
+	}{
+		{"", 0, RestoreTierStandard, false},
+		{`<RestoreRequest><Days>2</Days></RestoreRequest>`, 2, RestoreTierStandard, false},
+		{`<RestoreRequest xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Days>1</Days><GlacierJobParameters><Tier>Expedited</Tier></GlacierJobParameters></RestoreRequest>`, 1, RestoreTierExpedited, false},
+		{`<RestoreRequest><GlacierJobParameters><Tier>Bulk</Tier></GlacierJobParameters></RestoreRequest>`, 0, RestoreTierBulk, false},
+		{`<RestoreRequest><GlacierJobParameters><Tier>Fast</Tier></GlacierJobParameters></RestoreRequest>`, 0, "", true},
+		{`<RestoreRequest><Days>-1</Days></RestoreRequest>`, 0, "", true},
+		{`<RestoreRequest><Days>1</Days>`, 0, "", true},
+	}
+	for i, tc := range testCases {
+		req, err := parseRestoreRequest(strings.NewReader(tc.body), int64(len(tc.body)))
+		if (err != nil) != tc.expectErr {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if tc.expectErr {
+			continue
+		}
+		if req.Days != tc.expectedDays {
+			t.Errorf("Test %d: expected days %d, got %d", i+1, tc.expectedDays, req.Days)
+		}
+		if req.Tier() != tc.expectedTier {
+			t.Errorf("Test %d: expected tier %s, got %s", i+1, tc.expectedTier, req.Tier())
+		}
+	}
+}
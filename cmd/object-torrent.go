@@ -0,0 +1,121 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+// Content type of torrent files.
+const mimeTorrent mimeType = "application/x-bittorrent"
+
+// Piece length of generated torrents, doubled for large objects until
+// the torrent has at most torrentMaxPieces pieces.
+const (
+	torrentMinPieceLength = 256 * humanize.KiByte
+	torrentMaxPieceLength = 16 * humanize.MiByte
+	torrentMaxPieces      = 2048
+)
+
+// torrentPieceLength - returns the piece length of a torrent of an
+// object of size bytes.
+func torrentPieceLength(size int64) int64 {
+	pieceLength := int64(torrentMinPieceLength)
+	for pieceLength < torrentMaxPieceLength && size > pieceLength*torrentMaxPieces {
+		pieceLength *= 2
+	}
+	return pieceLength
+}
+
+// bencode - writes v bencoded to buf, v is a string, an integer, a
+// list or a dictionary with string keys.
+func bencode(buf *bytes.Buffer, v interface{}) {
+	switch v := v.(type) {
+	case string:
+		fmt.Fprintf(buf, "%d:%s", len(v), v)
+	case int64:
+		fmt.Fprintf(buf, "i%de", v)
+	case []interface{}:
+		buf.WriteByte('l')
+		for _, e := range v {
+			bencode(buf, e)
+		}
+		buf.WriteByte('e')
+	case map[string]interface{}:
+		// Keys of dictionaries are sorted.
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		buf.WriteByte('d')
+		for _, k := range keys {
+			bencode(buf, k)
+			bencode(buf, v[k])
+		}
+		buf.WriteByte('e')
+	default:
+		panic(fmt.Sprintf("bencode: unsupported type %T", v))
+	}
+}
+
+// newObjectTorrent - returns a single file torrent of the object read
+// from r, which is downloaded from the web seed webSeed. The size of
+// the object, which may be approximate, decides the piece length.
+func newObjectTorrent(r io.Reader, name string, size int64, modTime time.Time, webSeed string) ([]byte, error) {
+	pieceLength := torrentPieceLength(size)
+
+	var pieces bytes.Buffer
+	var length int64
+	h := sha1.New()
+	for {
+		h.Reset()
+		n, err := io.CopyN(h, r, pieceLength)
+		if n > 0 {
+			pieces.Write(h.Sum(nil))
+			length += n
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	bencode(&buf, map[string]interface{}{
+		"created by":    "MinIO",
+		"creation date": modTime.Unix(),
+		"info": map[string]interface{}{
+			"length":       length,
+			"name":         name,
+			"piece length": pieceLength,
+			"pieces":       pieces.String(),
+		},
+		// BEP 19 web seed, the object is downloaded from the server.
+		"url-list": []interface{}{webSeed},
+	})
+	return buf.Bytes(), nil
+}
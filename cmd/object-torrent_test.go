@@ -0,0 +1,91 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"strings"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+)
+
+func TestBencode(t *testing.T) {
+	testCases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{"spam", "4:spam"},
+		{"", "0:"},
+		{int64(-3), "i-3e"},
+		{[]interface{}{"spam", int64(42)}, "l4:spami42ee"},
+		{map[string]interface{}{"spam": []interface{}{}, "cow": "moo"}, "d3:cow3:moo4:spamlee"},
+	}
+	for i, tc := range testCases {
+		var buf bytes.Buffer
+		bencode(&buf, tc.value)
+		if buf.String() != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, buf.String())
+		}
+	}
+}
+
+func TestTorrentPieceLength(t *testing.T) {
+	testCases := []struct {
+		size     int64
+		expected int64
+	}{
+		{0, torrentMinPieceLength},
+		{torrentMinPieceLength * torrentMaxPieces, torrentMinPieceLength},
+		{torrentMinPieceLength*torrentMaxPieces + 1, 2 * torrentMinPieceLength},
+		{humanize.TiByte, torrentMaxPieceLength},
+	}
+	for i, tc := range testCases {
+		if pieceLength := torrentPieceLength(tc.size); pieceLength != tc.expected {
+			t.Errorf("Test %d: expected %d, got %d", i+1, tc.expected, pieceLength)
+		}
+	}
+}
+
+func TestNewObjectTorrent(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), torrentMinPieceLength+1)
+	modTime := time.Unix(1570000000, 0)
+	torrent, err := newObjectTorrent(bytes.NewReader(data), "object", int64(len(data)), modTime, "http://localhost:9000/bucket/object")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	piece1 := sha1.Sum(data[:torrentMinPieceLength])
+	piece2 := sha1.Sum(data[torrentMinPieceLength:])
+	expected := "d10:created by5:MinIO13:creation datei1570000000e" +
+		"4:infod6:lengthi262145e4:name6:object12:piece lengthi262144e6:pieces40:" + string(piece1[:]) + string(piece2[:]) + "e" +
+		"8:url-listl35:http://localhost:9000/bucket/objectee"
+	if string(torrent) != expected {
+		t.Fatalf("expected %q, got %q", expected, torrent)
+	}
+
+	// Empty objects have no pieces.
+	torrent, err = newObjectTorrent(strings.NewReader(""), "empty", 0, modTime, "http://localhost:9000/bucket/empty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(torrent), "6:lengthi0e") || !strings.Contains(string(torrent), "6:pieces0:") {
+		t.Fatalf("unexpected torrent %q", torrent)
+	}
+}
@@ -0,0 +1,241 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/crypto"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/thumbnail"
+)
+
+const (
+	// Query parameter requesting a resized image, e.g. ?x-minio-resize=200x150
+	transformResizeParam = "x-minio-resize"
+
+	// Largest object which is transformed, transformations are done
+	// in memory.
+	maxTransformSourceSize = 32 * humanize.MiByte
+
+	// Largest response accepted from an external transform service.
+	maxTransformResultSize = 32 * humanize.MiByte
+
+	// Time allowed for an external transform service to respond.
+	transformTimeout = 30 * time.Second
+)
+
+var (
+	transformClientOnce sync.Once
+	transformClient     *http.Client
+)
+
+// getTransformClient - returns the HTTP client used to reach the
+// external transform service, created on first use so that it
+// picks up the configured root CAs.
+func getTransformClient() *http.Client {
+	transformClientOnce.Do(func() {
+		transformClient = &http.Client{
+			Transport: NewCustomHTTPTransport(),
+			Timeout:   transformTimeout,
+		}
+	})
+	return transformClient
+}
+
+// transformObject - resizes the object data to spec, using the external
+// transform service when one is configured, returns the transformed
+// data along with its content type.
+func transformObject(ctx context.Context, r io.Reader, contentType string, spec thumbnail.Spec) ([]byte, string, error) {
+	if globalTransformConfig.Endpoint == "" {
+		data, err := thumbnail.Resize(r, contentType, spec)
+		return data, contentType, err
+	}
+
+	u, err := url.Parse(globalTransformConfig.Endpoint)
+	if err != nil {
+		return nil, "", err
+	}
+	q := u.Query()
+	q.Set(transformResizeParam, spec.String())
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, u.String(), r)
+	if err != nil {
+		return nil, "", err
+	}
+	req = req.WithContext(ctx)
+	req.Header.Set(xhttp.ContentType, contentType)
+
+	resp, err := getTransformClient().Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusUnsupportedMediaType:
+		return nil, "", thumbnail.ErrUnsupportedImage
+	case resp.StatusCode != http.StatusOK:
+		return nil, "", fmt.Errorf("transform service %s returned %s", globalTransformConfig.Endpoint, resp.Status)
+	}
+
+	data, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxTransformResultSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if len(data) > maxTransformResultSize {
+		return nil, "", thumbnail.ErrImageTooLarge
+	}
+	if ct := resp.Header.Get(xhttp.ContentType); ct != "" {
+		contentType = ct
+	}
+	return data, contentType, nil
+}
+
+// getTransformedObject - serves the resized image requested with the
+// x-minio-resize query parameter of GetObject. The transformed image
+// is cached in the disk cache, keyed on the ETag of the source object.
+func (api objectAPIHandlers) getTransformedObject(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, bucket, object string, opts ObjectOptions) {
+	if !globalTransformConfig.Enabled {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	spec, err := thumbnail.ParseSpec(r.URL.Query().Get(transformResizeParam))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidQueryParams), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	getObjectNInfo := objectAPI.GetObjectNInfo
+	cacheAPI := api.CacheAPI()
+	if cacheAPI != nil {
+		getObjectInfo = cacheAPI.GetObjectInfo
+		getObjectNInfo = cacheAPI.GetObjectNInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r.Header); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	}
+	objInfo = globalBucketDefaultsSys.Apply(bucket, object, objInfo)
+
+	size := objInfo.Size
+	switch {
+	case crypto.IsEncrypted(objInfo.UserDefined):
+		if size, err = objInfo.DecryptedSize(); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+	case objInfo.IsCompressed():
+		size = objInfo.GetActualSize()
+	}
+	if size > maxTransformSourceSize ||
+		(globalTransformConfig.Endpoint == "" && !thumbnail.IsSupported(objInfo.ContentType)) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotTransformable), r.URL, guessIsBrowserReq(r))
+		return
+	}
+
+	// The transformed image gets its own ETag derived from the source
+	// ETag, so that conditional requests and clients caches work.
+	variant := spec.String()
+	thumbInfo := ObjectInfo{
+		Bucket:  bucket,
+		Name:    object,
+		ETag:    getMD5Hash([]byte(objInfo.ETag + "/" + variant)),
+		ModTime: objInfo.ModTime,
+	}
+	if checkPreconditions(ctx, w, r, thumbInfo) {
+		return
+	}
+
+	// Never cache images derived from SSE-C objects, they would be
+	// served without the customer key.
+	cacheable := cacheAPI != nil && objInfo.IsCacheable() && !crypto.SSEC.IsEncrypted(objInfo.UserDefined)
+
+	var data []byte
+	if cacheable {
+		if cr, cerr := cacheAPI.GetObjectVariant(ctx, bucket, object, variant, objInfo.ETag); cerr == nil {
+			data, err = ioutil.ReadAll(cr)
+			cr.Close()
+			if err == nil {
+				thumbInfo.ContentType = cr.ObjInfo.ContentType
+			} else {
+				data = nil
+			}
+		}
+	}
+
+	if data == nil {
+		gr, err := getObjectNInfo(ctx, bucket, object, nil, r.Header, readLock, opts)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		data, thumbInfo.ContentType, err = transformObject(ctx, gr, objInfo.ContentType, spec)
+		gr.Close()
+		switch err {
+		case nil:
+		case thumbnail.ErrUnsupportedImage, thumbnail.ErrImageTooLarge:
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrObjectNotTransformable), r.URL, guessIsBrowserReq(r))
+			return
+		default:
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+			return
+		}
+		if gr.ObjInfo.ETag != objInfo.ETag {
+			// Object was replaced meanwhile, do not cache the
+			// result under the old ETag.
+			cacheable = false
+		}
+		if cacheable {
+			if err = cacheAPI.PutObjectVariant(ctx, bucket, object, variant, objInfo.ETag, data, thumbInfo.ContentType); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		}
+	}
+
+	thumbInfo.Size = int64(len(data))
+	if err = setObjectHeaders(w, thumbInfo, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL, guessIsBrowserReq(r))
+		return
+	}
+	setHeadGetRespHeaders(w, r.URL.Query())
+
+	if _, err = io.Copy(w, globalBucketBandwidth.egressReader(ctx, bucket, bytes.NewReader(data))); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
@@ -0,0 +1,168 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/transform"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/thumbnail"
+)
+
+func getTransformObjectURL(endPoint, bucketName, objectName, spec string) string {
+	queryValue := url.Values{}
+	queryValue.Set(transformResizeParam, spec)
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
+// Wrapper for calling GetObject with the resize query parameter.
+func TestAPIGetObjectTransformHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectTransformHandler, []string{"GetObject"})
+}
+
+func testAPIGetObjectTransformHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 64, 32))); err != nil {
+		t.Fatal(err)
+	}
+	imageData := buf.Bytes()
+	textData := []byte("hello, world")
+
+	objects := []struct {
+		name        string
+		data        []byte
+		contentType string
+	}{
+		{"image.png", imageData, "image/png"},
+		{"text.txt", textData, "text/plain"},
+	}
+	for _, object := range objects {
+		_, err := obj.PutObject(context.Background(), bucketName, object.name,
+			mustGetPutObjReader(t, bytes.NewReader(object.data), int64(len(object.data)), "", ""),
+			ObjectOptions{UserDefined: map[string]string{"content-type": object.contentType}})
+		if err != nil {
+			t.Fatalf("%s: Failed to create object %s: <ERROR> %v", instanceType, object.name, err)
+		}
+	}
+
+	get := func(object, spec string, header http.Header) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet, getTransformObjectURL("", bucketName, object, spec),
+			0, nil, credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for GetObject: <ERROR> %v", instanceType, err)
+		}
+		for k, v := range header {
+			req.Header[k] = v
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	defer func(cfg transform.Config) { globalTransformConfig = cfg }(globalTransformConfig)
+
+	// Transforms are off by default.
+	globalTransformConfig = transform.Config{}
+	if rec := get("image.png", "16x", nil); rec.Code != http.StatusNotImplemented {
+		t.Fatalf("%s: Expected %d when transforms are disabled, got %d", instanceType, http.StatusNotImplemented, rec.Code)
+	}
+
+	globalTransformConfig = transform.Config{Enabled: true}
+
+	rec := get("image.png", "16x", nil)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected %d, got %d: %s", instanceType, http.StatusOK, rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "image/png" {
+		t.Errorf("%s: Expected content type image/png, got %s", instanceType, ct)
+	}
+	cfg, err := png.DecodeConfig(bytes.NewReader(rec.Body.Bytes()))
+	if err != nil {
+		t.Fatalf("%s: Unable to decode the thumbnail: <ERROR> %v", instanceType, err)
+	}
+	if cfg.Width != 16 || cfg.Height != 8 {
+		t.Errorf("%s: Expected a 16x8 thumbnail, got %dx%d", instanceType, cfg.Width, cfg.Height)
+	}
+
+	etag := rec.Header()["ETag"]
+	if len(etag) == 0 {
+		t.Fatalf("%s: Expected an ETag for the thumbnail", instanceType)
+	}
+	if rec = get("image.png", "16x", http.Header{"If-None-Match": etag}); rec.Code != http.StatusNotModified {
+		t.Errorf("%s: Expected %d for a matching If-None-Match, got %d", instanceType, http.StatusNotModified, rec.Code)
+	}
+	if rec = get("image.png", "32x", http.Header{"If-None-Match": etag}); rec.Code != http.StatusOK {
+		t.Errorf("%s: Expected %d for a different size, got %d", instanceType, http.StatusOK, rec.Code)
+	}
+
+	if rec = get("image.png", "sixteen", nil); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected %d for an invalid size, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	rec = get("text.txt", "16x", nil)
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected %d for a non image object, got %d", instanceType, http.StatusBadRequest, rec.Code)
+	}
+	errCode := errorCodes.ToAPIErr(ErrObjectNotTransformable).Code
+	if !bytes.Contains(rec.Body.Bytes(), []byte(errCode)) {
+		t.Errorf("%s: Expected error %s, got %s", instanceType, errCode, rec.Body.String())
+	}
+}
+
+func TestTransformObjectEndpoint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Query().Get(transformResizeParam) != "16x8" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Content-Type") != "image/tiff" {
+			w.WriteHeader(http.StatusUnsupportedMediaType)
+			return
+		}
+		data, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("Content-Type", "image/jpeg")
+		w.Write(append([]byte("resized:"), data...))
+	}))
+	defer server.Close()
+
+	defer func(cfg transform.Config) { globalTransformConfig = cfg }(globalTransformConfig)
+	globalTransformConfig = transform.Config{Enabled: true, Endpoint: server.URL}
+
+	spec := thumbnail.Spec{Width: 16, Height: 8}
+	data, contentType, err := transformObject(context.Background(), bytes.NewReader([]byte("image")), "image/tiff", spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "resized:image" || contentType != "image/jpeg" {
+		t.Errorf("Unexpected transform result %q, %q", data, contentType)
+	}
+
+	if _, _, err = transformObject(context.Background(), bytes.NewReader([]byte("image")), "image/bmp", spec); err != thumbnail.ErrUnsupportedImage {
+		t.Errorf("Expected %v, got %v", thumbnail.ErrUnsupportedImage, err)
+	}
+}
@@ -0,0 +1,156 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Default time the old root credential remains valid for.
+	defaultOldCredentialExpiry = 24 * time.Hour
+
+	// The old credential set with the admin API is stored in
+	// config/old-credential.json, encrypted with the root secret key.
+	oldCredentialFile = minioConfigPrefix + "/old-credential.json"
+)
+
+var errInvalidOldCredential = errors.New("Old credential must be valid and differ from the current credential")
+
+// OldCredentialSys - holds the previous root credential, which remains
+// valid in addition to the current root credential until it expires.
+// This allows rotating the root credential without breaking clients
+// which have not been migrated to the new credential yet.
+type OldCredentialSys struct {
+	sync.RWMutex
+	cred auth.Credentials
+}
+
+// Get - returns the old credential if it has the given access key and
+// has not expired.
+func (sys *OldCredentialSys) Get(accessKey string) (auth.Credentials, bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	if accessKey == "" || sys.cred.AccessKey != accessKey || !sys.cred.IsValid() {
+		return auth.Credentials{}, false
+	}
+	return sys.cred, true
+}
+
+// Set - sets the old credential, which must have an expiry.
+func (sys *OldCredentialSys) Set(cred auth.Credentials) error {
+	if !cred.IsValid() || cred.Expiration.IsZero() || cred.Expiration.Equal(timeSentinel) {
+		return errInvalidOldCredential
+	}
+	if globalServerConfig != nil && cred.AccessKey == globalServerConfig.GetCredential().AccessKey {
+		return errInvalidOldCredential
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.cred = cred
+	return nil
+}
+
+// Remove - removes the old credential before it expires.
+func (sys *OldCredentialSys) Remove() {
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.cred = auth.Credentials{}
+}
+
+// Init - loads the old credential set with the admin API, so that
+// it survives restarts and is known to nodes joining later on. An
+// old credential set in the environment takes precedence.
+func (sys *OldCredentialSys) Init(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	sys.RLock()
+	isEnvCred := sys.cred.AccessKey != ""
+	sys.RUnlock()
+	if isEnvCred {
+		return nil
+	}
+
+	ctx := context.Background()
+	data, err := readConfig(ctx, objAPI, oldCredentialFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return nil
+		}
+		return err
+	}
+	data, err = madmin.DecryptData(globalServerConfig.GetCredential().SecretKey, bytes.NewReader(data))
+	if err != nil {
+		// The old credential was saved before the root credential
+		// was rotated again, it is not valid anymore.
+		logger.Info("Ignoring the old credential saved with a previous root credential")
+		return nil
+	}
+	var cred auth.Credentials
+	if err = json.Unmarshal(data, &cred); err != nil {
+		return err
+	}
+	// The old credential is not set if it expired since.
+	if err = sys.Set(cred); err == errInvalidOldCredential {
+		return nil
+	}
+	return err
+}
+
+// Save - stores the old credential, encrypted with the root secret key.
+func (sys *OldCredentialSys) Save(ctx context.Context, objAPI ObjectLayer) error {
+	sys.RLock()
+	data, err := json.Marshal(sys.cred)
+	sys.RUnlock()
+	if err != nil {
+		return err
+	}
+	data, err = madmin.EncryptData(globalServerConfig.GetCredential().SecretKey, data)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, oldCredentialFile, data)
+}
+
+// Delete - removes the stored old credential.
+func (sys *OldCredentialSys) Delete(ctx context.Context, objAPI ObjectLayer) error {
+	err := deleteConfig(ctx, objAPI, oldCredentialFile)
+	if isErrObjectNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// NewOldCredentialSys - creates new old credential system.
+func NewOldCredentialSys() *OldCredentialSys {
+	return &OldCredentialSys{}
+}
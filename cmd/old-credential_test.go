@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+)
+
+func TestOldCredentialSys(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+
+	creds, err := auth.CreateCredentials("myuser", "mypassword")
+	if err != nil {
+		t.Fatalf("unable create credential, %s", err)
+	}
+	globalServerConfig.SetCredential(creds)
+
+	oldCreds, err := auth.CreateCredentials("myolduser", "myoldpassword")
+	if err != nil {
+		t.Fatalf("unable create credential, %s", err)
+	}
+
+	oldCredSys := globalOldCredSys
+	defer func() { globalOldCredSys = oldCredSys }()
+	globalOldCredSys = NewOldCredentialSys()
+
+	// The old credential must expire and differ from the current one.
+	if err = globalOldCredSys.Set(oldCreds); err != errInvalidOldCredential {
+		t.Fatalf("expected %v, got %v", errInvalidOldCredential, err)
+	}
+	sameCreds := creds
+	sameCreds.Expiration = UTCNow().Add(time.Hour)
+	if err = globalOldCredSys.Set(sameCreds); err != errInvalidOldCredential {
+		t.Fatalf("expected %v, got %v", errInvalidOldCredential, err)
+	}
+
+	if _, _, s3Err := checkKeyValid(oldCreds.AccessKey); s3Err != ErrInvalidAccessKeyID {
+		t.Fatalf("expected old access key to be invalid, got %v", s3Err)
+	}
+
+	oldCreds.Expiration = UTCNow().Add(time.Hour)
+	if err = globalOldCredSys.Set(oldCreds); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both the current and the old credential are valid owner credentials.
+	for _, c := range []auth.Credentials{creds, oldCreds} {
+		cred, owner, s3Err := checkKeyValid(c.AccessKey)
+		if s3Err != ErrNone || !owner || cred.SecretKey != c.SecretKey {
+			t.Fatalf("expected %s to be a valid owner credential, got %v %v", c.AccessKey, owner, s3Err)
+		}
+	}
+
+	// The old credential logs in to the browser as the owner.
+	token, err := authenticateWeb(oldCreds.AccessKey, oldCreds.SecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, owner, err := webTokenAuthenticate(token); err != nil || !owner {
+		t.Fatalf("expected an owner token, got %v %v", owner, err)
+	}
+	if _, err = authenticateWeb(oldCreds.AccessKey, "wrongpassword"); err != errAuthentication {
+		t.Fatalf("expected %v, got %v", errAuthentication, err)
+	}
+
+	// The stored old credential is loaded on restart.
+	ctx := context.Background()
+	if err = globalOldCredSys.Save(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	loadedSys := NewOldCredentialSys()
+	if err = loadedSys.Init(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if cred, ok := loadedSys.Get(oldCreds.AccessKey); !ok || !cred.Equal(oldCreds) {
+		t.Fatalf("expected the stored old credential to be loaded")
+	}
+
+	// It is ignored once the root credential is rotated again.
+	newCreds, err := auth.CreateCredentials("mynewuser", "mynewpassword")
+	if err != nil {
+		t.Fatalf("unable create credential, %s", err)
+	}
+	globalServerConfig.SetCredential(newCreds)
+	loadedSys = NewOldCredentialSys()
+	if err = loadedSys.Init(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadedSys.Get(oldCreds.AccessKey); ok {
+		t.Fatalf("expected the old credential of a previous root credential to be ignored")
+	}
+	globalServerConfig.SetCredential(creds)
+
+	if err = globalOldCredSys.Delete(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+	loadedSys = NewOldCredentialSys()
+	if err = loadedSys.Init(objLayer); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := loadedSys.Get(oldCreds.AccessKey); ok {
+		t.Fatalf("expected the deleted old credential not to be loaded")
+	}
+	if err = globalOldCredSys.Delete(ctx, objLayer); err != nil {
+		t.Fatal(err)
+	}
+
+	globalOldCredSys.Remove()
+	if _, _, s3Err := checkKeyValid(oldCreds.AccessKey); s3Err != ErrInvalidAccessKeyID {
+		t.Fatalf("expected removed old access key to be invalid, got %v", s3Err)
+	}
+
+	// Expired old credentials are not valid anymore.
+	oldCreds.Expiration = UTCNow().Add(time.Second)
+	if err = globalOldCredSys.Set(oldCreds); err != nil {
+		t.Fatal(err)
+	}
+	globalOldCredSys.cred.Expiration = UTCNow().Add(-time.Second)
+	if _, _, s3Err := checkKeyValid(oldCreds.AccessKey); s3Err != ErrInvalidAccessKeyID {
+		t.Fatalf("expected expired old access key to be invalid, got %v", s3Err)
+	}
+}
@@ -28,15 +28,20 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/minio/minio/cmd/config/placement"
 	"github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/cmd/rest"
+	"github.com/minio/minio/pkg/auth"
+	"github.com/minio/minio/pkg/bucketdefaults"
+	"github.com/minio/minio/pkg/bucketsse"
 	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/lifecycle"
 	"github.com/minio/minio/pkg/madmin"
 	xnet "github.com/minio/minio/pkg/net"
 	"github.com/minio/minio/pkg/policy"
 	trace "github.com/minio/minio/pkg/trace"
+	"github.com/minio/minio/pkg/website"
 )
 
 // client to talk to peer Nodes.
@@ -171,6 +176,17 @@ func (client *peerRESTClient) CPULoadInfo() (info ServerCPULoadInfo, err error)
 	return info, err
 }
 
+// Placement - fetch the placement rules of a remote node.
+func (client *peerRESTClient) Placement() (cfg placement.Config, err error) {
+	respBody, err := client.call(peerRESTMethodPlacement, nil, nil, -1)
+	if err != nil {
+		return
+	}
+	defer http.DrainBody(respBody)
+	err = gob.NewDecoder(respBody).Decode(&cfg)
+	return cfg, err
+}
+
 // CpuInfo - fetch CPU hardware information for a remote node.
 func (client *peerRESTClient) CPUInfo() (info madmin.ServerCPUHardwareInfo, err error) {
 	respBody, err := client.call(peerRESTMethodHardwareCPUInfo, nil, nil, -1)
@@ -258,6 +274,16 @@ func (client *peerRESTClient) ReloadFormat(dryRun bool) error {
 	return nil
 }
 
+// LoadPoolsDecommission - reload decommission status of server pools on the peer node.
+func (client *peerRESTClient) LoadPoolsDecommission() error {
+	respBody, err := client.call(peerRESTMethodLoadPoolsDecommission, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // ListenBucketNotification - send listen bucket notification to peer nodes.
 func (client *peerRESTClient) ListenBucketNotification(bucket string, eventNames []event.Name,
 	pattern string, targetID event.TargetID, addr xnet.Host) error {
@@ -405,6 +431,99 @@ func (client *peerRESTClient) SetBucketLifecycle(bucket string, bucketLifecycle
 	return nil
 }
 
+// RemoveBucketWebsite - Remove bucket website configuration on the peer node
+func (client *peerRESTClient) RemoveBucketWebsite(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketWebsiteRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketWebsite - Set bucket website configuration on the peer node
+func (client *peerRESTClient) SetBucketWebsite(bucket string, bucketWebsite *website.Website) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(bucketWebsite)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketWebsiteSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketDefaults - Remove bucket defaults on the peer node
+func (client *peerRESTClient) RemoveBucketDefaults(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketDefaultsRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketDefaults - Set bucket defaults on the peer node
+func (client *peerRESTClient) SetBucketDefaults(bucket string, bucketDefaults *bucketdefaults.Config) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(bucketDefaults)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketDefaultsSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveBucketSSEConfig - Remove bucket encryption configuration on the peer node
+func (client *peerRESTClient) RemoveBucketSSEConfig(bucket string) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+	respBody, err := client.call(peerRESTMethodBucketEncryptionRemove, values, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// SetBucketSSEConfig - Set bucket encryption configuration on the peer node
+func (client *peerRESTClient) SetBucketSSEConfig(bucket string, encConfig *bucketsse.BucketSSEConfig) error {
+	values := make(url.Values)
+	values.Set(peerRESTBucket, bucket)
+
+	var reader bytes.Buffer
+	err := gob.NewEncoder(&reader).Encode(encConfig)
+	if err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodBucketEncryptionSet, values, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // PutBucketNotification - Put bucket notification on the peer node.
 func (client *peerRESTClient) PutBucketNotification(bucket string, rulesMap event.RulesMap) error {
 	values := make(url.Values)
@@ -493,6 +612,31 @@ func (client *peerRESTClient) LoadUser(accessKey string, temp bool) (err error)
 	return nil
 }
 
+// SetOldCredential - set the old root credential on the peer node.
+func (client *peerRESTClient) SetOldCredential(cred auth.Credentials) error {
+	var reader bytes.Buffer
+	if err := gob.NewEncoder(&reader).Encode(cred); err != nil {
+		return err
+	}
+
+	respBody, err := client.call(peerRESTMethodOldCredentialSet, nil, &reader, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// RemoveOldCredential - remove the old root credential on the peer node.
+func (client *peerRESTClient) RemoveOldCredential() error {
+	respBody, err := client.call(peerRESTMethodOldCredentialRemove, nil, nil, -1)
+	if err != nil {
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 // LoadUsers - send load users command to peer nodes.
 func (client *peerRESTClient) LoadUsers() (err error) {
 	respBody, err := client.call(peerRESTMethodLoadUsers, nil, nil, -1)
@@ -557,6 +701,20 @@ func (client *peerRESTClient) BackgroundHealStatus() (madmin.BgHealState, error)
 	return state, err
 }
 
+// ScrubStatus - returns the background scrub status of the disks
+// of the peer, nil if the peer does not scrub its disks.
+func (client *peerRESTClient) ScrubStatus() ([]madmin.ScrubDiskStatus, error) {
+	respBody, err := client.call(peerRESTMethodScrubStatus, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+	defer http.DrainBody(respBody)
+
+	var status []madmin.ScrubDiskStatus
+	err = gob.NewDecoder(respBody).Decode(&status)
+	return status, err
+}
+
 // BgLifecycleOpsStatus describes the status
 // of the background lifecycle operations
 type BgLifecycleOpsStatus struct {
@@ -31,6 +31,7 @@ const (
 	peerRESTMethodSignalService            = "signalservice"
 	peerRESTMethodBackgroundHealStatus     = "backgroundhealstatus"
 	peerRESTMethodBackgroundOpsStatus      = "backgroundopsstatus"
+	peerRESTMethodScrubStatus              = "scrubstatus"
 	peerRESTMethodGetLocks                 = "getlocks"
 	peerRESTMethodBucketPolicyRemove       = "removebucketpolicy"
 	peerRESTMethodLoadUser                 = "loaduser"
@@ -40,19 +41,29 @@ const (
 	peerRESTMethodDeletePolicy             = "deletepolicy"
 	peerRESTMethodLoadUsers                = "loadusers"
 	peerRESTMethodLoadGroup                = "loadgroup"
+	peerRESTMethodOldCredentialSet         = "setoldcredential"
+	peerRESTMethodOldCredentialRemove      = "removeoldcredential"
 	peerRESTMethodStartProfiling           = "startprofiling"
 	peerRESTMethodDownloadProfilingData    = "downloadprofilingdata"
 	peerRESTMethodBucketPolicySet          = "setbucketpolicy"
 	peerRESTMethodBucketNotificationPut    = "putbucketnotification"
 	peerRESTMethodBucketNotificationListen = "listenbucketnotification"
 	peerRESTMethodReloadFormat             = "reloadformat"
+	peerRESTMethodLoadPoolsDecommission    = "loadpoolsdecommission"
 	peerRESTMethodTargetExists             = "targetexists"
 	peerRESTMethodSendEvent                = "sendevent"
 	peerRESTMethodTrace                    = "trace"
 	peerRESTMethodBucketLifecycleSet       = "setbucketlifecycle"
 	peerRESTMethodBucketLifecycleRemove    = "removebucketlifecycle"
+	peerRESTMethodBucketWebsiteSet         = "setbucketwebsite"
+	peerRESTMethodBucketWebsiteRemove      = "removebucketwebsite"
+	peerRESTMethodBucketDefaultsSet        = "setbucketdefaults"
+	peerRESTMethodBucketDefaultsRemove     = "removebucketdefaults"
+	peerRESTMethodBucketEncryptionSet      = "setbucketencryption"
+	peerRESTMethodBucketEncryptionRemove   = "removebucketencryption"
 	peerRESTMethodLog                      = "log"
 	peerRESTMethodHardwareCPUInfo          = "cpuhardwareinfo"
+	peerRESTMethodPlacement                = "placement"
 )
 
 const (
@@ -21,6 +21,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/http"
 	"path"
 	"strings"
@@ -167,6 +168,74 @@ func NewPolicySys() *PolicySys {
 	}
 }
 
+// parseTrustedProxies - parses a comma separated list of IP addresses
+// and CIDR networks, addresses are single host networks.
+func parseTrustedProxies(s string) ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, proxy := range strings.Split(s, ",") {
+		proxy = strings.TrimSpace(proxy)
+		if !strings.Contains(proxy, "/") {
+			ip := net.ParseIP(proxy)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid IP address %s", proxy)
+			}
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			proxies = append(proxies, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(proxy)
+		if err != nil {
+			return nil, err
+		}
+		proxies = append(proxies, ipNet)
+	}
+	return proxies, nil
+}
+
+// isTrustedProxy - returns true if addr is one of the trusted proxies.
+func isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+	for _, proxy := range globalTrustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// getSourceIP - returns the address of the client of a request for
+// policy conditions. The forwarding headers are only used when the
+// request comes from a trusted proxy, any client can set them. The
+// X-Forwarded-For chain is walked back from the last proxy up to the
+// first address which is not a trusted proxy.
+func getSourceIP(r *http.Request) string {
+	addr, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		addr = r.RemoteAddr
+	}
+	if !isTrustedProxy(addr) {
+		return addr
+	}
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		hops := strings.Split(fwd, ",")
+		for i := len(hops) - 1; i >= 0; i-- {
+			addr = strings.TrimSpace(hops[i])
+			if !isTrustedProxy(addr) {
+				break
+			}
+		}
+		return addr
+	}
+	return handlers.GetSourceIP(r)
+}
+
 func getConditionValues(request *http.Request, locationConstraint string, username string) map[string][]string {
 	currTime := UTCNow()
 	principalType := func() string {
@@ -180,14 +249,24 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 		"EpochTime":       {fmt.Sprintf("%d", currTime.Unix())},
 		"principaltype":   {principalType},
 		"SecureTransport": {fmt.Sprintf("%t", request.TLS != nil)},
-		"SourceIp":        {handlers.GetSourceIP(request)},
+		"SourceIp":        {getSourceIP(request)},
 		"UserAgent":       {request.UserAgent()},
 		"Referer":         {request.Referer()},
 		"userid":          {username},
 		"username":        {username},
 	}
 
+	// Values computed by the server, like the source IP and the referer,
+	// must not be overridden by request headers or query parameters.
+	reserved := make(map[string]struct{}, len(args))
+	for key := range args {
+		reserved[strings.ToLower(key)] = struct{}{}
+	}
+
 	for key, values := range request.Header {
+		if _, found := reserved[strings.ToLower(key)]; found {
+			continue
+		}
 		if existingValues, found := args[key]; found {
 			args[key] = append(existingValues, values...)
 		} else {
@@ -196,6 +275,9 @@ func getConditionValues(request *http.Request, locationConstraint string, userna
 	}
 
 	for key, values := range request.URL.Query() {
+		if _, found := reserved[strings.ToLower(key)]; found {
+			continue
+		}
 		if existingValues, found := args[key]; found {
 			args[key] = append(existingValues, values...)
 		} else {
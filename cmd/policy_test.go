@@ -17,6 +17,8 @@
 package cmd
 
 import (
+	"net"
+	"net/http/httptest"
 	"reflect"
 	"testing"
 
@@ -422,3 +424,90 @@ func TestBucketAccessPolicyToPolicy(t *testing.T) {
 		}
 	}
 }
+
+func TestGetConditionValuesSourceIPReferer(t *testing.T) {
+	req := httptest.NewRequest("GET", "http://localhost:9000/mybucket/myobject?SourceIp=192.168.1.10", nil)
+	req.RemoteAddr = "10.1.1.1:34512"
+	req.Header.Set("Referer", "http://www.example.com/index.html")
+	req.Header.Set("Sourceip", "192.168.1.10")
+
+	func1, err := condition.NewIPAddressFunc(condition.AWSSourceIP, mustParseCIDR(t, "192.168.1.0/24"))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func2, err := condition.NewStringLikeFunc(condition.AWSReferer, "http://www.example.com/*")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		functions      condition.Functions
+		expectedResult bool
+	}{
+		// Source IP is taken from the connection, not from the request.
+		{condition.NewFunctions(func1), false},
+		{condition.NewFunctions(func2), true},
+	}
+
+	values := getConditionValues(req, "", "")
+	for i, testCase := range testCases {
+		if result := testCase.functions.Evaluate(values); result != testCase.expectedResult {
+			t.Fatalf("case %v: expected: %v, got: %v\n", i+1, testCase.expectedResult, result)
+		}
+	}
+
+	if !reflect.DeepEqual(values["SourceIp"], []string{"10.1.1.1"}) {
+		t.Fatalf("unexpected source IP %v", values["SourceIp"])
+	}
+	if !reflect.DeepEqual(values["Referer"], []string{"http://www.example.com/index.html"}) {
+		t.Fatalf("unexpected referer %v", values["Referer"])
+	}
+}
+
+func TestGetConditionValuesTrustedProxies(t *testing.T) {
+	defer func() { globalTrustedProxies = nil }()
+
+	proxies, err := parseTrustedProxies("10.1.1.1, 172.16.0.0/12")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = parseTrustedProxies("10.1.1.1,proxy"); err == nil {
+		t.Fatal("expected an error for an invalid proxy")
+	}
+
+	testCases := []struct {
+		trustedProxies []*net.IPNet
+		remoteAddr     string
+		forwardedFor   string
+		expectedIP     string
+	}{
+		// Forged header from a client which is not a proxy.
+		{nil, "10.1.1.1:34512", "192.168.1.10", "10.1.1.1"},
+		{proxies, "10.1.1.2:34512", "192.168.1.10", "10.1.1.2"},
+		// Header set by a trusted proxy.
+		{proxies, "10.1.1.1:34512", "192.168.1.10", "192.168.1.10"},
+		// Addresses prepended by the client are skipped.
+		{proxies, "10.1.1.1:34512", "192.168.1.10, 8.8.8.8, 172.16.5.4", "8.8.8.8"},
+		// Every hop is a trusted proxy.
+		{proxies, "10.1.1.1:34512", "172.16.5.4", "172.16.5.4"},
+	}
+
+	for i, testCase := range testCases {
+		globalTrustedProxies = testCase.trustedProxies
+		req := httptest.NewRequest("GET", "http://localhost:9000/mybucket/myobject", nil)
+		req.RemoteAddr = testCase.remoteAddr
+		req.Header.Set("X-Forwarded-For", testCase.forwardedFor)
+		values := getConditionValues(req, "", "")
+		if !reflect.DeepEqual(values["SourceIp"], []string{testCase.expectedIP}) {
+			t.Fatalf("case %v: expected source IP %v, got %v", i+1, testCase.expectedIP, values["SourceIp"])
+		}
+	}
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	_, IPNet, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return IPNet
+}
@@ -0,0 +1,108 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Number of recent operations the latency percentiles of a disk are
+// computed from.
+const diskLatencySamples = 1024
+
+// latencyWindow - rolling window of the latency of recent operations.
+type latencyWindow struct {
+	mu      sync.Mutex
+	samples [diskLatencySamples]time.Duration
+	count   int
+	next    int
+}
+
+func (w *latencyWindow) add(d time.Duration) {
+	w.mu.Lock()
+	w.samples[w.next] = d
+	w.next = (w.next + 1) % diskLatencySamples
+	if w.count < diskLatencySamples {
+		w.count++
+	}
+	w.mu.Unlock()
+}
+
+func (w *latencyWindow) percentiles() (l madmin.DiskLatency) {
+	w.mu.Lock()
+	samples := make([]time.Duration, w.count)
+	copy(samples, w.samples[:w.count])
+	w.mu.Unlock()
+
+	if len(samples) == 0 {
+		return l
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+	// Nearest rank percentile.
+	percentile := func(p int) time.Duration {
+		return samples[(p*len(samples)+99)/100-1]
+	}
+	l.P50 = percentile(50)
+	l.P90 = percentile(90)
+	l.P99 = percentile(99)
+	return l
+}
+
+// diskMetrics - read and write latency and IO error counters of a disk.
+type diskMetrics struct {
+	readErrors  uint64 // ref: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+	writeErrors uint64 // ref: https://golang.org/pkg/sync/atomic/#pkg-note-BUG
+
+	readLatency  latencyWindow
+	writeLatency latencyWindow
+}
+
+// isDiskIOErr - returns whether the error of a disk operation is an
+// error of the disk itself, instead of e.g. a missing file.
+func isDiskIOErr(err error) bool {
+	return err == errFaultyDisk || err == errUnexpected
+}
+
+// read - records a read operation started at start.
+func (m *diskMetrics) read(start time.Time, err error) {
+	m.readLatency.add(time.Since(start))
+	if isDiskIOErr(err) {
+		atomic.AddUint64(&m.readErrors, 1)
+	}
+}
+
+// write - records a write operation started at start.
+func (m *diskMetrics) write(start time.Time, err error) {
+	m.writeLatency.add(time.Since(start))
+	if isDiskIOErr(err) {
+		atomic.AddUint64(&m.writeErrors, 1)
+	}
+}
+
+func (m *diskMetrics) toMetrics() madmin.DiskMetrics {
+	return madmin.DiskMetrics{
+		ReadLatency:  m.readLatency.percentiles(),
+		WriteLatency: m.writeLatency.percentiles(),
+		ReadErrors:   atomic.LoadUint64(&m.readErrors),
+		WriteErrors:  atomic.LoadUint64(&m.writeErrors),
+	}
+}
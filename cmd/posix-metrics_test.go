@@ -0,0 +1,92 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyWindowPercentiles(t *testing.T) {
+	var w latencyWindow
+	if l := w.percentiles(); l.P50 != 0 || l.P90 != 0 || l.P99 != 0 {
+		t.Fatalf("expected zero percentiles for an empty window, got %+v", l)
+	}
+
+	for i := 100; i >= 1; i-- {
+		w.add(time.Duration(i) * time.Millisecond)
+	}
+	l := w.percentiles()
+	if l.P50 != 50*time.Millisecond || l.P90 != 90*time.Millisecond || l.P99 != 99*time.Millisecond {
+		t.Fatalf("unexpected percentiles %+v", l)
+	}
+
+	// Old samples are dropped once the window is full.
+	for i := 0; i < diskLatencySamples; i++ {
+		w.add(time.Second)
+	}
+	if l = w.percentiles(); l.P50 != time.Second || l.P99 != time.Second {
+		t.Fatalf("expected old samples to be dropped, got %+v", l)
+	}
+}
+
+func TestDiskMetrics(t *testing.T) {
+	var m diskMetrics
+	start := time.Now()
+	m.read(start, nil)
+	m.read(start, errFileNotFound)
+	m.read(start, errFaultyDisk)
+	m.write(start, errUnexpected)
+	m.write(start, errDiskFull)
+
+	metrics := m.toMetrics()
+	if metrics.ReadErrors != 1 {
+		t.Fatalf("expected 1 read error, got %d", metrics.ReadErrors)
+	}
+	if metrics.WriteErrors != 1 {
+		t.Fatalf("expected 1 write error, got %d", metrics.WriteErrors)
+	}
+	if metrics.ReadLatency.P99 <= 0 || metrics.WriteLatency.P99 <= 0 {
+		t.Fatalf("expected latencies to be recorded, got %+v", metrics)
+	}
+}
+
+func TestPosixDiskInfoMetrics(t *testing.T) {
+	posixStorage, path, err := newPosixTestSetup()
+	if err != nil {
+		t.Fatalf("Unable to create posix test setup, %s", err)
+	}
+	defer removeAll(path)
+
+	if err = posixStorage.MakeVol("exists"); err != nil {
+		t.Fatal(err)
+	}
+	if err = posixStorage.AppendFile("exists", "object", []byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = posixStorage.ReadAll("exists", "object"); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := posixStorage.DiskInfo()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Metrics.ReadLatency.P50 <= 0 || info.Metrics.WriteLatency.P50 <= 0 {
+		t.Fatalf("expected disk info to report latencies, got %+v", info.Metrics)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"github.com/minio/minio/cmd/logger"
 	"github.com/minio/minio/pkg/disk"
 	xioutil "github.com/minio/minio/pkg/ioutil"
+	"github.com/minio/minio/pkg/madmin"
 	"github.com/minio/minio/pkg/mountinfo"
 	"github.com/ncw/directio"
 )
@@ -80,6 +81,9 @@ type posix struct {
 	diskFileInfo os.FileInfo
 	// Disk usage metrics
 	stopUsageCh chan struct{}
+
+	// Disk latency and IO error metrics
+	metrics diskMetrics
 }
 
 // checkPathLength - returns error if given path name length more than 255
@@ -313,6 +317,7 @@ type DiskInfo struct {
 	Free     uint64
 	Used     uint64
 	RootDisk bool
+	Metrics  madmin.DiskMetrics
 }
 
 // DiskInfo provides current information about disk space usage,
@@ -352,6 +357,7 @@ func (s *posix) DiskInfo() (info DiskInfo, err error) {
 		Free:     di.Free,
 		Used:     used,
 		RootDisk: rootDisk,
+		Metrics:  s.metrics.toMetrics(),
 	}, nil
 }
 
@@ -628,8 +634,9 @@ func (s *posix) StatVol(volume string) (volInfo VolInfo, err error) {
 	}, nil
 }
 
-// DeleteVol - delete a volume.
-func (s *posix) DeleteVol(volume string) (err error) {
+// DeleteVol - delete a volume, when forceDelete is set the volume
+// is removed along with all of its contents.
+func (s *posix) DeleteVol(volume string, forceDelete bool) (err error) {
 	defer func() {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
@@ -649,7 +656,20 @@ func (s *posix) DeleteVol(volume string) (err error) {
 	if err != nil {
 		return err
 	}
-	err = os.Remove((volumeDir))
+	if forceDelete {
+		// Move the volume out of the namespace first, such that
+		// it disappears at once, before removing its contents.
+		tmpVolumeDir := pathJoin(s.diskPath, minioMetaTmpBucket, mustGetUUID())
+		if _, err = os.Stat(volumeDir); err == nil {
+			if err = renameAll(volumeDir, tmpVolumeDir); err == nil {
+				err = removeAll(tmpVolumeDir)
+			} else if err == errFileNotFound {
+				return errVolumeNotFound
+			}
+		}
+	} else {
+		err = os.Remove((volumeDir))
+	}
 	if err != nil {
 		switch {
 		case os.IsNotExist(err):
@@ -805,11 +825,12 @@ func (s *posix) ListDir(volume, dirPath string, count int, leafFile string) (ent
 // This API is meant to be used on files which have small memory footprint, do
 // not use this on large files as it would cause server to crash.
 func (s *posix) ReadAll(volume, path string) (buf []byte, err error) {
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.read(start, err)
+	}(time.Now())
 
 	if atomic.LoadInt32(&s.ioErrCount) > maxAllowedIOError {
 		return nil, errFaultyDisk
@@ -878,11 +899,12 @@ func (s *posix) ReadAll(volume, path string) (buf []byte, err error) {
 func (s *posix) ReadFile(volume, path string, offset int64, buffer []byte, verifier *BitrotVerifier) (int64, error) {
 	var n int
 	var err error
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.read(start, err)
+	}(time.Now())
 
 	if offset < 0 {
 		return 0, errInvalidArgument
@@ -1054,11 +1076,12 @@ func (s *posix) openFile(volume, path string, mode int) (f *os.File, err error)
 // ReadFileStream - Returns the read stream of the file.
 func (s *posix) ReadFileStream(volume, path string, offset, length int64) (io.ReadCloser, error) {
 	var err error
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.read(start, err)
+	}(time.Now())
 
 	if offset < 0 {
 		return nil, errInvalidArgument
@@ -1131,6 +1154,9 @@ func (s *posix) ReadFileStream(volume, path string, offset, length int64) (io.Re
 		io.Reader
 		io.Closer
 	}{Reader: io.LimitReader(file, length), Closer: file}
+	if globalDirectIO.DropCache {
+		r.Closer = dropCacheFile{file}
+	}
 
 	return readahead.NewReadCloser(r), nil
 }
@@ -1140,11 +1166,12 @@ func (s *posix) CreateFile(volume, path string, fileSize int64, r io.Reader) (er
 	if fileSize < -1 {
 		return errInvalidArgument
 	}
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.write(start, err)
+	}(time.Now())
 
 	if atomic.LoadInt32(&s.ioErrCount) > maxAllowedIOError {
 		return errFaultyDisk
@@ -1243,11 +1270,12 @@ func (s *posix) CreateFile(volume, path string, fileSize int64, r io.Reader) (er
 }
 
 func (s *posix) WriteAll(volume, path string, reader io.Reader) (err error) {
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.write(start, err)
+	}(time.Now())
 
 	if atomic.LoadInt32(&s.ioErrCount) > maxAllowedIOError {
 		return errFaultyDisk
@@ -1272,11 +1300,12 @@ func (s *posix) WriteAll(volume, path string, reader io.Reader) (err error) {
 // AppendFile - append a byte array at path, if file doesn't exist at
 // path this call explicitly creates it.
 func (s *posix) AppendFile(volume, path string, buf []byte) (err error) {
-	defer func() {
+	defer func(start time.Time) {
 		if err == errFaultyDisk {
 			atomic.AddInt32(&s.ioErrCount, 1)
 		}
-	}()
+		s.metrics.write(start, err)
+	}(time.Now())
 
 	if atomic.LoadInt32(&s.ioErrCount) > maxAllowedIOError {
 		return errFaultyDisk
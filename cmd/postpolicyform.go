@@ -185,6 +185,10 @@ func parsePostPolicyForm(policy string) (ppf PostPolicyForm, e error) {
 					return parsedPolicy, err
 				}
 
+				if min < 0 || min > max {
+					return parsedPolicy, fmt.Errorf("Invalid content-length-range [%d, %d] found in POST policy form", min, max)
+				}
+
 				parsedPolicy.Conditions.ContentLengthRange = contentLengthRange{
 					Min:   min,
 					Max:   max,
@@ -261,13 +265,12 @@ func checkPostPolicy(formValues http.Header, postPolicyForm PostPolicyForm) erro
 				return fmt.Errorf("Invalid according to Policy: Policy Condition failed")
 			}
 		} else {
-			// This covers all conditions X-Amz-Meta-* and X-Amz-*
-			if strings.HasPrefix(policy.Key, "$x-amz-meta-") || strings.HasPrefix(policy.Key, "$x-amz-") {
-				// Check if policy condition is satisfied
-				condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), policy.Value)
-				if !condPassed {
-					return fmt.Errorf("Invalid according to Policy: Policy Condition failed: [%s, %s, %s]", op, policy.Key, policy.Value)
-				}
+			// This covers all conditions X-Amz-Meta-*, X-Amz-* and any
+			// other form field, a condition on a field which is not
+			// part of the form only passes when it expects an empty value.
+			condPassed = checkPolicyCond(op, formValues.Get(formCanonicalName), policy.Value)
+			if !condPassed {
+				return fmt.Errorf("Invalid according to Policy: Policy Condition failed: [%s, %s, %s]", op, policy.Key, policy.Value)
 			}
 		}
 	}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"net/http"
 	"testing"
+	"time"
 
 	minio "github.com/minio/minio-go/v6"
 )
@@ -105,3 +106,56 @@ func TestPostPolicyForm(t *testing.T) {
 		}
 	}
 }
+
+// Test content-length-range validation while parsing Post Policy.
+func TestParsePostPolicyFormContentLengthRange(t *testing.T) {
+	expiration := UTCNow().AddDate(0, 0, 10).Format(time.RFC3339Nano)
+	testCases := []struct {
+		cond      string
+		expectErr bool
+	}{
+		{`["content-length-range", 0, 1024]`, false},
+		{`["content-length-range", 1024, 1024]`, false},
+		{`["content-length-range", -1, 1024]`, true},
+		{`["content-length-range", 1024, 10]`, true},
+		{`["content-length-range", "a", 10]`, true},
+	}
+	for i, testCase := range testCases {
+		policy := fmt.Sprintf(`{"expiration": "%s", "conditions": [%s]}`, expiration, testCase.cond)
+		_, err := parsePostPolicyForm(policy)
+		if testCase.expectErr && err == nil {
+			t.Errorf("Test %d: expected %s to fail", i+1, testCase.cond)
+		}
+		if !testCase.expectErr && err != nil {
+			t.Errorf("Test %d: expected %s to succeed, got %s", i+1, testCase.cond, err)
+		}
+	}
+}
+
+// Test conditions on form fields not known to the policy checker.
+func TestCheckPostPolicyExtraConditions(t *testing.T) {
+	expiration := UTCNow().AddDate(0, 0, 10).Format(time.RFC3339Nano)
+	policy := fmt.Sprintf(`{"expiration": "%s", "conditions": [["starts-with", "$x-amz-meta-tag", "prod-"], ["eq", "$tagging", "x"]]}`, expiration)
+	postPolicyForm, err := parsePostPolicyForm(policy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formValues := make(http.Header)
+	formValues.Set("X-Amz-Meta-Tag", "prod-1")
+	formValues.Set("Tagging", "x")
+	if err = checkPostPolicy(formValues, postPolicyForm); err != nil {
+		t.Fatal(err)
+	}
+
+	formValues.Set("X-Amz-Meta-Tag", "dev-1")
+	if err = checkPostPolicy(formValues, postPolicyForm); err == nil {
+		t.Fatal("expected starts-with condition on metadata to fail")
+	}
+
+	formValues.Set("X-Amz-Meta-Tag", "prod-1")
+	formValues.Del("Tagging")
+	if err = checkPostPolicy(formValues, postPolicyForm); err == nil {
+		t.Fatal("expected condition on missing form field to fail")
+	}
+}
@@ -212,7 +212,7 @@ func connectLoadInitFormats(retryCount int, firstDisk bool, endpoints EndpointLi
 	// All disks report unformatted we should initialized everyone.
 	if shouldInitXLDisks(sErrs) && firstDisk {
 		// Initialize erasure code format on disks
-		format, err := initFormatXL(context.Background(), storageDisks, setCount, drivesPerSet)
+		format, err := initFormatXL(context.Background(), storageDisks, setCount, drivesPerSet, globalDeploymentID)
 		if err != nil {
 			return nil, err
 		}
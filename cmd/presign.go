@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v6/pkg/s3utils"
+	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// maxPresignExpiry - presigned URLs expire after at most 7 days.
+const maxPresignExpiry = 604800
+
+// presignExpiry - returns expiry in seconds, values out of range
+// default to the maximum expiry.
+func presignExpiry(expiry int64) int64 {
+	if expiry <= 0 || expiry > maxPresignExpiry {
+		return maxPresignExpiry
+	}
+	return expiry
+}
+
+// presignedURL - returns a presigned url for method on bucket and
+// object, host is the only header signed unless headers are passed,
+// clients must send those headers with the exact same values.
+func presignedURL(method, host, bucket, object string, expiry int64, headers http.Header, creds auth.Credentials, region string) string {
+	return presignedURLWithQuery(method, host, bucket, object, nil, expiry, headers, creds, region)
+}
+
+// presignedURLWithQuery - same as presignedURL, additionally signs the
+// given query parameters such as the uploadId of a multipart upload.
+func presignedURLWithQuery(method, host, bucket, object string, params url.Values, expiry int64, headers http.Header, creds auth.Credentials, region string) string {
+	date := UTCNow()
+	credential := fmt.Sprintf("%s/%s", creds.AccessKey, getScope(date, region))
+
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set(xhttp.AmzAlgorithm, signV4Algorithm)
+	query.Set(xhttp.AmzCredential, credential)
+	query.Set(xhttp.AmzDate, date.Format(iso8601Format))
+	query.Set(xhttp.AmzExpires, strconv.FormatInt(presignExpiry(expiry), 10))
+
+	extractedSignedHeaders := make(http.Header)
+	for k, v := range headers {
+		extractedSignedHeaders[k] = v
+	}
+	extractedSignedHeaders.Set("host", host)
+	query.Set(xhttp.AmzSignedHeaders, getSignedHeaders(extractedSignedHeaders))
+	queryStr := s3utils.QueryEncode(query)
+
+	path := SlashSeparator + path.Join(bucket, object)
+
+	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, path, method)
+	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region))
+	signingKey := getSigningKey(creds.SecretKey, date, region, serviceS3)
+	signature := getSignature(signingKey, stringToSign)
+
+	// Construct the final presigned URL, the session token of
+	// temporary credentials is not part of the signature.
+	presigned := host + s3utils.EncodePath(path) + "?" + queryStr + "&" + xhttp.AmzSignature + "=" + signature
+	if creds.SessionToken != "" {
+		presigned += "&" + xhttp.AmzSecurityToken + "=" + url.QueryEscape(creds.SessionToken)
+	}
+	return presigned
+}
+
+// presignedPostPolicy - returns the form fields for a browser POST
+// upload of object to bucket. The signed policy restricts uploads to
+// the given content type and to at most maxSize bytes when set.
+func presignedPostPolicy(bucket, object string, expiry int64, contentType string, maxSize int64, creds auth.Credentials, region string) (map[string]string, error) {
+	date := UTCNow()
+	credential := fmt.Sprintf("%s/%s", creds.AccessKey, getScope(date, region))
+
+	formData := map[string]string{
+		"key": object,
+	}
+	conditions := []interface{}{
+		[]string{policyCondEqual, "$bucket", bucket},
+		[]string{policyCondEqual, "$key", object},
+	}
+	for _, field := range []struct{ name, value string }{
+		{xhttp.AmzAlgorithm, signV4Algorithm},
+		{xhttp.AmzCredential, credential},
+		{xhttp.AmzDate, date.Format(iso8601Format)},
+		{xhttp.AmzSecurityToken, creds.SessionToken},
+		{xhttp.ContentType, contentType},
+	} {
+		if field.value == "" {
+			continue
+		}
+		formData[field.name] = field.value
+		conditions = append(conditions, []string{policyCondEqual, "$" + strings.ToLower(field.name), field.value})
+	}
+	if maxSize > 0 {
+		conditions = append(conditions, []interface{}{policyCondContentLength, 0, maxSize})
+	}
+
+	policy, err := json.Marshal(map[string]interface{}{
+		"expiration": date.Add(time.Duration(presignExpiry(expiry)) * time.Second).Format(time.RFC3339Nano),
+		"conditions": conditions,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	formData["policy"] = base64.StdEncoding.EncodeToString(policy)
+	signingKey := getSigningKey(creds.SecretKey, date, region, serviceS3)
+	formData[xhttp.AmzSignature] = getSignature(signingKey, formData["policy"])
+	return formData, nil
+}
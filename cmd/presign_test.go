@@ -0,0 +1,109 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"encoding/base64"
+	"net/http"
+	"os"
+	"testing"
+)
+
+func TestPresignedURL(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	region := globalServerConfig.GetRegion()
+	headers := http.Header{"Content-Type": []string{"text/plain"}}
+	u := "http://" + presignedURL(http.MethodPut, "localhost:9000", "bucket", "dir/object", 3600, headers, globalServerConfig.GetCredential(), region)
+
+	req, err := http.NewRequest(http.MethodPut, u, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+	if errCode := doesPresignedSignatureMatch(unsignedPayload, req, region, serviceS3); errCode != ErrNone {
+		t.Fatalf("expected signature to match, got %s", niceError(errCode))
+	}
+
+	// Signed headers must be sent with the same values.
+	req.Header.Set("Content-Type", "text/html")
+	if errCode := doesPresignedSignatureMatch(unsignedPayload, req, region, serviceS3); errCode != ErrSignatureDoesNotMatch {
+		t.Fatalf("expected %s, got %s", niceError(ErrSignatureDoesNotMatch), niceError(errCode))
+	}
+
+	// Expiry is capped to 7 days.
+	if expiry := req.URL.Query().Get("X-Amz-Expires"); expiry != "3600" {
+		t.Fatalf("expected expiry 3600, got %s", expiry)
+	}
+	if expiry := presignExpiry(maxPresignExpiry + 1); expiry != maxPresignExpiry {
+		t.Fatalf("expected expiry %d, got %d", maxPresignExpiry, expiry)
+	}
+}
+
+func TestPresignedPostPolicy(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	formData, err := presignedPostPolicy("bucket", "object", 3600, "image/png", 1024, globalServerConfig.GetCredential(), globalServerConfig.GetRegion())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	formValues := make(http.Header)
+	for k, v := range formData {
+		formValues.Set(k, v)
+	}
+	if errCode := doesPolicySignatureV4Match(formValues); errCode != ErrNone {
+		t.Fatalf("expected signature to match, got %s", niceError(errCode))
+	}
+
+	// Bucket is taken from the upload URL.
+	formValues.Set("Bucket", "bucket")
+	policyBytes, err := base64.StdEncoding.DecodeString(formValues.Get("Policy"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	postPolicyForm, err := parsePostPolicyForm(string(policyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = checkPostPolicy(formValues, postPolicyForm); err != nil {
+		t.Fatal(err)
+	}
+	if lengthRange := postPolicyForm.Conditions.ContentLengthRange; !lengthRange.Valid || lengthRange.Max != 1024 {
+		t.Fatalf("expected content length range up to 1024, got %+v", lengthRange)
+	}
+
+	// The upload must be sent with the signed content type.
+	formValues.Set("Content-Type", "text/html")
+	if err = checkPostPolicy(formValues, postPolicyForm); err == nil {
+		t.Fatal("expected policy check to fail for a different content type")
+	}
+}
@@ -0,0 +1,176 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/cmd/config/ratelimit"
+	xhttp "github.com/minio/minio/cmd/http"
+	xratelimit "github.com/minio/minio/pkg/ratelimit"
+)
+
+// API classes which are rate limited independently.
+const (
+	apiClassRead  = "read"
+	apiClassWrite = "write"
+	apiClassList  = "list"
+	apiClassUser  = "user"
+)
+
+// Limiters of access keys idle for longer than this are dropped, a
+// limiter idle for a second is full again so nothing is lost.
+const userLimiterIdleTimeout = 1 * time.Minute
+
+// userLimiter - the limiter of an access key.
+type userLimiter struct {
+	*xratelimit.Limiter
+	lastUsed time.Time
+}
+
+// apiRateLimiter - throttles S3 API requests per API class
+// and per access key.
+type apiRateLimiter struct {
+	classes map[string]*xratelimit.Limiter
+
+	userRate  float64
+	usersMu   sync.Mutex
+	users     map[string]*userLimiter
+	lastSweep time.Time
+}
+
+// newLimiter - returns a limiter for rate requests per second,
+// allowing bursts of up to one second worth of requests.
+func newLimiter(rate float64) *xratelimit.Limiter {
+	return xratelimit.NewLimiter(rate, int(math.Ceil(rate)))
+}
+
+// newAPIRateLimiter - returns a rate limiter for the given
+// config, nil if no limit is configured.
+func newAPIRateLimiter(cfg ratelimit.Config) *apiRateLimiter {
+	if !cfg.Enabled() {
+		return nil
+	}
+	l := &apiRateLimiter{
+		classes:  make(map[string]*xratelimit.Limiter),
+		userRate: cfg.User,
+		users:    make(map[string]*userLimiter),
+	}
+	for class, rate := range map[string]float64{
+		apiClassRead:  cfg.Read,
+		apiClassWrite: cfg.Write,
+		apiClassList:  cfg.List,
+	} {
+		if rate > 0 {
+			l.classes[class] = newLimiter(rate)
+		}
+	}
+	return l
+}
+
+// userLimiter - returns the limiter of the access key,
+// creating it on first use. Idle limiters are dropped.
+func (l *apiRateLimiter) userLimiter(accessKey string) *xratelimit.Limiter {
+	now := UTCNow()
+	l.usersMu.Lock()
+	defer l.usersMu.Unlock()
+	if now.Sub(l.lastSweep) > userLimiterIdleTimeout {
+		for key, ul := range l.users {
+			if now.Sub(ul.lastUsed) > userLimiterIdleTimeout {
+				delete(l.users, key)
+			}
+		}
+		l.lastSweep = now
+	}
+	ul, ok := l.users[accessKey]
+	if !ok {
+		ul = &userLimiter{Limiter: newLimiter(l.userRate)}
+		l.users[accessKey] = ul
+	}
+	ul.lastUsed = now
+	return ul.Limiter
+}
+
+// allow - reports whether a request of the API class by the
+// access key may proceed, if not the limit which was exceeded
+// is returned.
+func (l *apiRateLimiter) allow(class, accessKey string) (string, bool) {
+	if cl, ok := l.classes[class]; ok && !cl.Allow() {
+		return class, false
+	}
+	if l.userRate > 0 && accessKey != "" && !l.userLimiter(accessKey).Allow() {
+		return apiClassUser, false
+	}
+	return "", true
+}
+
+// getReqAccessKey - returns the access key a request claims to be
+// made with, its signature is not verified. Requests are throttled
+// before being authenticated by their handler, verifying signatures
+// here as well would do it twice for every request.
+func getReqAccessKey(r *http.Request) string {
+	switch getRequestAuthType(r) {
+	case authTypePresigned:
+		ch, s3Err := parseCredentialHeader("Credential="+r.URL.Query().Get(xhttp.AmzCredential), "", serviceS3)
+		if s3Err != ErrNone {
+			return ""
+		}
+		return ch.accessKey
+	case authTypeSigned, authTypeStreamingSigned:
+		// Authorization = "AWS4-HMAC-SHA256" + " " + "Credential=" +
+		// AccessKey + "/" + Scope + ", " + SignedHeaders + ", " + Signature
+		v4Auth := strings.TrimPrefix(r.Header.Get(xhttp.Authorization), signV4Algorithm)
+		ch, s3Err := parseCredentialHeader(strings.Split(strings.TrimSpace(v4Auth), ",")[0], "", serviceS3)
+		if s3Err != ErrNone {
+			return ""
+		}
+		return ch.accessKey
+	case authTypePresignedV2:
+		return r.URL.Query().Get(xhttp.AmzAccessKeyID)
+	case authTypeSignedV2:
+		// Authorization = "AWS" + " " + AWSAccessKeyId + ":" + Signature
+		authFields := strings.Split(r.Header.Get(xhttp.Authorization), " ")
+		if len(authFields) != 2 {
+			return ""
+		}
+		keySignFields := strings.Split(strings.TrimSpace(authFields[1]), ":")
+		if len(keySignFields) != 2 {
+			return ""
+		}
+		return keySignFields[0]
+	}
+	return ""
+}
+
+// getAPIClass - returns the rate limit class of an S3 API request.
+func getAPIClass(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet:
+		if _, object := request2BucketObjectName(r); object == "" {
+			return apiClassList
+		}
+		return apiClassRead
+	case http.MethodHead:
+		return apiClassRead
+	default:
+		return apiClassWrite
+	}
+}
@@ -0,0 +1,182 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/ratelimit"
+	xhttp "github.com/minio/minio/cmd/http"
+)
+
+func TestGetAPIClass(t *testing.T) {
+	testCases := []struct {
+		method   string
+		path     string
+		expected string
+	}{
+		{http.MethodGet, "/", apiClassList},
+		{http.MethodGet, "/bucket", apiClassList},
+		{http.MethodGet, "/bucket/object", apiClassRead},
+		{http.MethodHead, "/bucket/object", apiClassRead},
+		{http.MethodPut, "/bucket/object", apiClassWrite},
+		{http.MethodPost, "/bucket/object", apiClassWrite},
+		{http.MethodDelete, "/bucket", apiClassWrite},
+	}
+	for i, testCase := range testCases {
+		req := httptest.NewRequest(testCase.method, testCase.path, nil)
+		if class := getAPIClass(req); class != testCase.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, testCase.expected, class)
+		}
+	}
+}
+
+func TestAPIRateLimiter(t *testing.T) {
+	if l := newAPIRateLimiter(ratelimit.Config{}); l != nil {
+		t.Fatal("expected no rate limiter without limits")
+	}
+
+	l := newAPIRateLimiter(ratelimit.Config{Write: 1, User: 2})
+
+	// Reads are not limited.
+	for i := 0; i < 10; i++ {
+		if _, ok := l.allow(apiClassRead, ""); !ok {
+			t.Fatal("expected reads to be allowed")
+		}
+	}
+
+	if _, ok := l.allow(apiClassWrite, ""); !ok {
+		t.Fatal("expected first write to be allowed")
+	}
+	if class, ok := l.allow(apiClassWrite, ""); ok || class != apiClassWrite {
+		t.Fatalf("expected second write to be throttled by %s, got %s", apiClassWrite, class)
+	}
+
+	// Each access key has its own bucket.
+	for _, accessKey := range []string{"user1", "user2"} {
+		for i := 0; i < 2; i++ {
+			if _, ok := l.allow(apiClassRead, accessKey); !ok {
+				t.Fatalf("expected request %d of %s to be allowed", i+1, accessKey)
+			}
+		}
+		if class, ok := l.allow(apiClassRead, accessKey); ok || class != apiClassUser {
+			t.Fatalf("expected %s to be throttled by %s, got %s", accessKey, apiClassUser, class)
+		}
+	}
+}
+
+func TestAPIRateLimiterIdleUsers(t *testing.T) {
+	l := newAPIRateLimiter(ratelimit.Config{User: 1})
+	l.allow(apiClassRead, "user1")
+	l.allow(apiClassRead, "user2")
+
+	// Idle limiters are dropped on the next sweep.
+	l.users["user1"].lastUsed = UTCNow().Add(-2 * userLimiterIdleTimeout)
+	l.lastSweep = time.Time{}
+	l.allow(apiClassRead, "user2")
+	if _, ok := l.users["user1"]; ok {
+		t.Fatal("expected the idle limiter of user1 to be dropped")
+	}
+	if _, ok := l.users["user2"]; !ok {
+		t.Fatal("expected the limiter of user2 to be kept")
+	}
+}
+
+func TestGetReqAccessKey(t *testing.T) {
+	const (
+		accessKey = "accesskey"
+		secretKey = "secretkey"
+		url       = "http://127.0.0.1:9000/bucket/object"
+	)
+	newRequest := func(sign func(*http.Request) error) *http.Request {
+		req, err := newTestRequest(http.MethodGet, url, 0, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sign != nil {
+			if err = sign(req); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return req
+	}
+	signedV4, err := newTestSignedRequestV4(http.MethodGet, url, 0, nil, accessKey, secretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedV2, err := newTestSignedRequestV2(http.MethodGet, url, 0, nil, accessKey, secretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	streaming, err := newTestStreamingSignedRequest(http.MethodPut, url, 5, 5, bytes.NewReader([]byte("hello")), accessKey, secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	malformed := newRequest(nil)
+	malformed.Header.Set(xhttp.Authorization, signV4Algorithm+" Credential=accesskey")
+
+	testCases := []struct {
+		name      string
+		req       *http.Request
+		accessKey string
+	}{
+		{"anonymous", newRequest(nil), ""},
+		{"signed V4", signedV4, accessKey},
+		{"streaming signed V4", streaming, accessKey},
+		{"presigned V4", newRequest(func(r *http.Request) error { return preSignV4(r, accessKey, secretKey, 60) }), accessKey},
+		{"signed V2", signedV2, accessKey},
+		{"presigned V2", newRequest(func(r *http.Request) error { return preSignV2(r, accessKey, secretKey, 60) }), accessKey},
+		{"malformed V4", malformed, ""},
+	}
+	for _, testCase := range testCases {
+		if got := getReqAccessKey(testCase.req); got != testCase.accessKey {
+			t.Errorf("%s: expected access key %q, got %q", testCase.name, testCase.accessKey, got)
+		}
+	}
+}
+
+func TestRateLimitHandlerAccessKey(t *testing.T) {
+	globalAPIRateLimiter = newAPIRateLimiter(ratelimit.Config{User: 1})
+	defer func() { globalAPIRateLimiter = nil }()
+
+	handler := setRateLimitHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serve := func(accessKey, secretKey string) int {
+		req, err := newTestSignedRequestV4(http.MethodGet, "http://127.0.0.1:9000/bucket/object", 0, nil, accessKey, secretKey, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	// Requests are charged to the access key they claim to be made
+	// with, their signature is verified by the handler afterwards.
+	if code := serve("accesskey1", "secretkey"); code != http.StatusOK {
+		t.Fatalf("expected the first request to be allowed, got %d", code)
+	}
+	if code := serve("accesskey1", "other-secret-key"); code != http.StatusServiceUnavailable {
+		t.Fatalf("expected the second request to be throttled, got %d", code)
+	}
+	if code := serve("accesskey2", "secretkey"); code != http.StatusOK {
+		t.Fatalf("expected the request of another access key to be allowed, got %d", code)
+	}
+}
@@ -0,0 +1,324 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/minio/cli"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/event/target"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	relayDefaultAddress = ":9010"
+
+	// Interval before the first retry of a failed relay, doubled
+	// after every further attempt.
+	relayRetryInterval = time.Second
+)
+
+var relayFlags = []cli.Flag{
+	cli.StringFlag{
+		Name:  "address",
+		Value: relayDefaultAddress,
+		Usage: "bind to a specific ADDRESS:PORT to receive webhook events, ADDRESS can be an IP or hostname",
+	},
+	cli.StringFlag{
+		Name:  "config",
+		Usage: "path to the relay configuration FILE",
+	},
+}
+
+var relayCmd = cli.Command{
+	Name:   "relay",
+	Usage:  "relay webhook events to MQTT, Kafka and NATS targets",
+	Flags:  relayFlags,
+	Action: relayMain,
+	CustomHelpTemplate: `NAME:
+  {{.HelpName}} - {{.Usage}}
+
+USAGE:
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}--config FILE
+{{if .VisibleFlags}}
+FLAGS:
+  {{range .VisibleFlags}}{{.}}
+  {{end}}{{end}}
+ENDPOINTS:
+  POST /          Webhook events, as sent by the MinIO webhook notification target.
+  GET  /metrics   Relay metrics in Prometheus format.
+
+EXAMPLES:
+  1. Relay the webhook events posted to port 9010 to the targets of "/etc/minio/relay.json".
+     {{.Prompt}} {{.HelpName}} --config /etc/minio/relay.json
+
+  2. Relay the webhook events posted to a specific ADDRESS:PORT.
+     {{.Prompt}} {{.HelpName}} --address 192.168.1.101:9010 --config /etc/minio/relay.json
+`,
+}
+
+// relayConfig - configuration file of the relay, the targets use the
+// same format as in the notify section of the server configuration.
+type relayConfig struct {
+	// Number of retries of a failed relay to a target before
+	// the event is rejected.
+	Retries int                         `json:"retries"`
+	MQTT    map[string]target.MQTTArgs  `json:"mqtt"`
+	Kafka   map[string]target.KafkaArgs `json:"kafka"`
+	NATS    map[string]target.NATSArgs  `json:"nats"`
+}
+
+// Validate - validates the relay configuration.
+func (cfg relayConfig) Validate() error {
+	if cfg.Retries < 0 {
+		return errors.New("retries cannot be negative")
+	}
+	var enabled bool
+	for k, v := range cfg.MQTT {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("mqtt(%s): %s", k, err)
+		}
+		enabled = enabled || v.Enable
+	}
+	for k, v := range cfg.Kafka {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("kafka(%s): %s", k, err)
+		}
+		enabled = enabled || v.Enable
+	}
+	for k, v := range cfg.NATS {
+		if err := v.Validate(); err != nil {
+			return fmt.Errorf("nats(%s): %s", k, err)
+		}
+		enabled = enabled || v.Enable
+	}
+	if !enabled {
+		return errors.New("no target enabled")
+	}
+	return nil
+}
+
+// loadRelayConfig - reads and validates the relay configuration file.
+func loadRelayConfig(filename string) (cfg relayConfig, err error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return cfg, err
+	}
+	if err = json.Unmarshal(data, &cfg); err != nil {
+		return cfg, err
+	}
+	return cfg, cfg.Validate()
+}
+
+// newRelayTargets - creates the enabled targets of the relay configuration.
+func newRelayTargets(cfg relayConfig, doneCh <-chan struct{}) (targets []event.Target, err error) {
+	defer func() {
+		if err != nil {
+			for _, t := range targets {
+				t.Close()
+			}
+		}
+	}()
+
+	for k, v := range cfg.MQTT {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewMQTTTarget(k, v, doneCh, logger.LogOnceIf)
+		if err != nil {
+			return targets, fmt.Errorf("mqtt(%s): %s", k, err)
+		}
+		targets = append(targets, t)
+	}
+	for k, v := range cfg.Kafka {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewKafkaTarget(k, v, doneCh, logger.LogOnceIf)
+		if err != nil {
+			return targets, fmt.Errorf("kafka(%s): %s", k, err)
+		}
+		targets = append(targets, t)
+	}
+	for k, v := range cfg.NATS {
+		if !v.Enable {
+			continue
+		}
+		t, err := target.NewNATSTarget(k, v, doneCh, logger.LogOnceIf)
+		if err != nil {
+			return targets, fmt.Errorf("nats(%s): %s", k, err)
+		}
+		targets = append(targets, t)
+	}
+	return targets, nil
+}
+
+// relayMetrics - metrics of the relay, kept apart from the metrics
+// of the server.
+type relayMetrics struct {
+	registry *prometheus.Registry
+	received prometheus.Counter
+	relayed  *prometheus.CounterVec
+	retried  *prometheus.CounterVec
+	failed   *prometheus.CounterVec
+}
+
+func newRelayMetrics() *relayMetrics {
+	m := &relayMetrics{
+		registry: prometheus.NewRegistry(),
+		received: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "minio_relay_events_received_total",
+			Help: "Total number of events received from webhooks",
+		}),
+		relayed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "minio_relay_events_relayed_total",
+			Help: "Total number of events relayed to a target",
+		}, []string{"target"}),
+		retried: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "minio_relay_events_retried_total",
+			Help: "Total number of retries of events to a target",
+		}, []string{"target"}),
+		failed: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "minio_relay_events_failed_total",
+			Help: "Total number of events which could not be relayed to a target",
+		}, []string{"target"}),
+	}
+	m.registry.MustRegister(m.received, m.relayed, m.retried, m.failed)
+	return m
+}
+
+// relayHandler - receives the events posted by webhook targets and
+// saves them to all relay targets.
+type relayHandler struct {
+	targets       []event.Target
+	retries       int
+	retryInterval time.Duration
+	metrics       *relayMetrics
+}
+
+// save - saves the event to the target, retrying on failure.
+func (h *relayHandler) save(t event.Target, eventData event.Event) (err error) {
+	interval := h.retryInterval
+	for i := 0; ; i++ {
+		if err = t.Save(eventData); err == nil || i >= h.retries {
+			return err
+		}
+		h.metrics.retried.WithLabelValues(t.ID().String()).Inc()
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
+
+func (h *relayHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var eventLog event.Log
+	if err := json.NewDecoder(r.Body).Decode(&eventLog); err != nil {
+		// Webhook targets check connectivity with an empty post.
+		if err == io.EOF {
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var failed bool
+	for _, eventData := range eventLog.Records {
+		h.metrics.received.Inc()
+		for _, t := range h.targets {
+			if err := h.save(t, eventData); err != nil {
+				logger.LogIf(r.Context(), fmt.Errorf("unable to relay event to %s: %s", t.ID(), err))
+				h.metrics.failed.WithLabelValues(t.ID().String()).Inc()
+				failed = true
+				continue
+			}
+			h.metrics.relayed.WithLabelValues(t.ID().String()).Inc()
+		}
+	}
+
+	// Let the webhook target keep the event in its queue store and
+	// send it again, the event is then relayed at least once.
+	if failed {
+		http.Error(w, "unable to relay event to all targets", http.StatusServiceUnavailable)
+	}
+}
+
+// newRelayRouter - returns the handler of the relay endpoints.
+func newRelayRouter(h *relayHandler) http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(h.metrics.registry, promhttp.HandlerOpts{}))
+	mux.Handle("/", h)
+	return mux
+}
+
+func relayMain(ctx *cli.Context) {
+	if ctx.Args().Present() || ctx.String("config") == "" {
+		cli.ShowCommandHelpAndExit(ctx, "relay", 1)
+	}
+
+	cfg, err := loadRelayConfig(ctx.String("config"))
+	logger.FatalIf(err, "Unable to load the relay configuration")
+
+	doneCh := make(chan struct{})
+	targets, err := newRelayTargets(cfg, doneCh)
+	logger.FatalIf(err, "Unable to initialize the relay targets")
+
+	h := &relayHandler{
+		targets:       targets,
+		retries:       cfg.Retries,
+		retryInterval: relayRetryInterval,
+		metrics:       newRelayMetrics(),
+	}
+	server := &http.Server{
+		Addr:    ctx.String("address"),
+		Handler: newRelayRouter(h),
+	}
+
+	signalCh := make(chan os.Signal, 1)
+	signal.Notify(signalCh, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signalCh
+		close(doneCh)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		logger.LogIf(shutdownCtx, server.Shutdown(shutdownCtx))
+	}()
+
+	logger.Info("Relaying webhook events posted to %s to %d target(s)", server.Addr, len(targets))
+	if err = server.ListenAndServe(); err != http.ErrServerClosed {
+		logger.FatalIf(err, "Unable to start the relay")
+	}
+	for _, t := range targets {
+		logger.LogIf(context.Background(), t.Close())
+	}
+}
@@ -0,0 +1,163 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+// relayTestTarget - target failing the first failures saves.
+type relayTestTarget struct {
+	failures int
+	saved    []event.Event
+}
+
+func (t *relayTestTarget) ID() event.TargetID {
+	return event.TargetID{ID: "1", Name: "test"}
+}
+
+func (t *relayTestTarget) Save(eventData event.Event) error {
+	if t.failures > 0 {
+		t.failures--
+		return errors.New("not connected")
+	}
+	t.saved = append(t.saved, eventData)
+	return nil
+}
+
+func (t *relayTestTarget) Send(string) error { return nil }
+
+func (t *relayTestTarget) Close() error { return nil }
+
+func TestRelayHandler(t *testing.T) {
+	eventLog := event.Log{
+		EventName: event.ObjectCreatedPut,
+		Key:       "images/myphoto.jpg",
+		Records: []event.Event{{
+			EventName: event.ObjectCreatedPut,
+			S3: event.Metadata{
+				Bucket: event.Bucket{Name: "images"},
+				Object: event.Object{Key: "myphoto.jpg"},
+			},
+		}},
+	}
+	data, err := json.Marshal(eventLog)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		method         string
+		body           []byte
+		failures       int
+		expectedStatus int
+		expectedSaved  int
+	}{
+		{http.MethodPost, data, 0, http.StatusOK, 1},
+		{http.MethodPost, data, 2, http.StatusOK, 1},
+		{http.MethodPost, data, 3, http.StatusServiceUnavailable, 0},
+		{http.MethodPost, nil, 0, http.StatusOK, 0},
+		{http.MethodPost, []byte("{"), 0, http.StatusBadRequest, 0},
+		{http.MethodGet, data, 0, http.StatusMethodNotAllowed, 0},
+	}
+
+	for i, testCase := range testCases {
+		testTarget := &relayTestTarget{failures: testCase.failures}
+		router := newRelayRouter(&relayHandler{
+			targets: []event.Target{testTarget},
+			retries: 2,
+			metrics: newRelayMetrics(),
+		})
+
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(testCase.method, "/", bytes.NewReader(testCase.body)))
+		if rec.Code != testCase.expectedStatus {
+			t.Fatalf("test %v: status: expected: %v, got: %v", i+1, testCase.expectedStatus, rec.Code)
+		}
+		if len(testTarget.saved) != testCase.expectedSaved {
+			t.Fatalf("test %v: saved events: expected: %v, got: %v", i+1, testCase.expectedSaved, len(testTarget.saved))
+		}
+	}
+}
+
+func TestRelayMetrics(t *testing.T) {
+	h := &relayHandler{
+		targets: []event.Target{&relayTestTarget{failures: 1}},
+		retries: 1,
+		metrics: newRelayMetrics(),
+	}
+	router := newRelayRouter(h)
+
+	data, err := json.Marshal(event.Log{EventName: event.ObjectRemovedDelete, Records: []event.Event{{EventName: event.ObjectRemovedDelete}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(data)))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	for _, metric := range []string{
+		"minio_relay_events_received_total 1",
+		`minio_relay_events_relayed_total{target="1:test"} 1`,
+		`minio_relay_events_retried_total{target="1:test"} 1`,
+	} {
+		if !strings.Contains(rec.Body.String(), metric) {
+			t.Fatalf("expected metric %s, got: %s", metric, rec.Body.String())
+		}
+	}
+}
+
+func TestLoadRelayConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-relay")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	testCases := []struct {
+		config    string
+		expectErr bool
+	}{
+		{`{"retries": 3, "nats": {"1": {"enable": true, "address": "localhost:4222", "subject": "minio"}}}`, false},
+		{`{"retries": -1, "nats": {"1": {"enable": true, "address": "localhost:4222", "subject": "minio"}}}`, true},
+		{`{"nats": {"1": {"enable": true, "address": "localhost:4222"}}}`, true},
+		{`{"mqtt": {"1": {"enable": false}}}`, true},
+		{`{"mqtt": `, true},
+	}
+
+	for i, testCase := range testCases {
+		filename := filepath.Join(dir, "relay.json")
+		if err = ioutil.WriteFile(filename, []byte(testCase.config), 0600); err != nil {
+			t.Fatal(err)
+		}
+		_, err = loadRelayConfig(filename)
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/minio/minio/cmd/config/api"
+)
+
+// requestsPool - caps the number of S3 API requests served
+// concurrently. Requests beyond the cap wait in a queue of the
+// same size for at most deadline, requests arriving when the
+// queue is full are rejected right away.
+type requestsPool struct {
+	slots    chan struct{}
+	waiting  int32
+	deadline time.Duration
+}
+
+// newRequestsPool - returns a requests pool for the given config,
+// nil if the number of requests is unlimited.
+func newRequestsPool(cfg api.Config) *requestsPool {
+	if cfg.RequestsMax <= 0 {
+		return nil
+	}
+	return &requestsPool{
+		slots:    make(chan struct{}, cfg.RequestsMax),
+		deadline: cfg.RequestsDeadline,
+	}
+}
+
+// acquire - waits for a free slot, returns false if none became
+// available before the deadline or the request was canceled. On
+// success the slot must be released with release.
+func (p *requestsPool) acquire(ctx context.Context) bool {
+	// Fast path, a slot is available.
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	if atomic.AddInt32(&p.waiting, 1) > int32(cap(p.slots)) {
+		atomic.AddInt32(&p.waiting, -1)
+		return false
+	}
+	defer atomic.AddInt32(&p.waiting, -1)
+
+	deadlineTimer := time.NewTimer(p.deadline)
+	defer deadlineTimer.Stop()
+
+	select {
+	case p.slots <- struct{}{}:
+		return true
+	case <-deadlineTimer.C:
+		return false
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release - frees a slot taken by acquire.
+func (p *requestsPool) release() {
+	<-p.slots
+}
@@ -0,0 +1,84 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/config/api"
+)
+
+func TestRequestsPool(t *testing.T) {
+	if p := newRequestsPool(api.Config{}); p != nil {
+		t.Fatal("expected no pool for unlimited requests")
+	}
+
+	p := newRequestsPool(api.Config{RequestsMax: 1, RequestsDeadline: 50 * time.Millisecond})
+	if !p.acquire(context.Background()) {
+		t.Fatal("expected first request to acquire a slot")
+	}
+
+	// Times out waiting for the slot.
+	if p.acquire(context.Background()) {
+		t.Fatal("expected second request to time out")
+	}
+
+	// Canceled while waiting for the slot.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if p.acquire(ctx) {
+		t.Fatal("expected canceled request to give up")
+	}
+
+	// Gets the slot once it is released.
+	done := make(chan bool)
+	go func() { done <- p.acquire(context.Background()) }()
+	time.Sleep(10 * time.Millisecond)
+	p.release()
+	if !<-done {
+		t.Fatal("expected waiting request to acquire the released slot")
+	}
+	p.release()
+}
+
+func TestMaxClientsHandler(t *testing.T) {
+	defer func(p *requestsPool) { globalAPIRequestsPool = p }(globalAPIRequestsPool)
+	globalAPIRequestsPool = newRequestsPool(api.Config{RequestsMax: 1, RequestsDeadline: 10 * time.Millisecond})
+
+	handler := setMaxClientsHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	// Take the only slot.
+	globalAPIRequestsPool.acquire(context.Background())
+	defer globalAPIRequestsPool.release()
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/bucket/object", nil))
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected %d, got %d", http.StatusServiceUnavailable, w.Code)
+	}
+
+	// Admin requests are not limited.
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, adminAPIPathPrefix+"/v1/info", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("expected %d, got %d", http.StatusOK, w.Code)
+	}
+}
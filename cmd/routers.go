@@ -56,6 +56,10 @@ var globalHandlers = []HandlerFunc{
 	setBucketForwardingHandler,
 	// Validate all the incoming requests.
 	setRequestValidityHandler,
+	// Throttle S3 API requests exceeding the configured rates.
+	setRateLimitHandler,
+	// Limit the number of S3 API requests served concurrently.
+	setMaxClientsHandler,
 	// Network statistics
 	setHTTPStatsHandler,
 	// Limits all requests size to a maximum fixed limit
@@ -26,6 +26,7 @@ import (
 	"strings"
 	"syscall"
 
+	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/cli"
 	"github.com/minio/dsync/v2"
 	"github.com/minio/minio/cmd/config"
@@ -50,6 +51,14 @@ var ServerFlags = []cli.Flag{
 		Value: ":" + globalMinioDefaultPort,
 		Usage: "bind to a specific ADDRESS:PORT, ADDRESS can be an IP or hostname",
 	},
+	cli.StringFlag{
+		Name:  "memory",
+		Usage: "hold all objects in memory up to SIZE instead of using DIR, 0 for no limit",
+	},
+	cli.BoolFlag{
+		Name:  "memory-evict",
+		Usage: "evict least recently used objects once the memory limit is reached",
+	},
 }
 
 var serverCmd = cli.Command{
@@ -63,6 +72,7 @@ var serverCmd = cli.Command{
 USAGE:
   {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR1 [DIR2..]
   {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}DIR{1...64}
+  {{.HelpName}} {{if .VisibleFlags}}[FLAGS] {{end}}--memory SIZE
 
 DIR:
   DIR points to a directory on a filesystem. When you want to combine
@@ -130,15 +140,22 @@ EXAMPLES:
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SSE_VAULT_ENDPOINT{{.AssignmentOperator}}https://vault-endpoint-ip:8200
      {{.Prompt}} {{.EnvVarSetCommand}} MINIO_SSE_VAULT_KEY_NAME{{.AssignmentOperator}}my-minio-key
      {{.Prompt}} {{.HelpName}} /home/shared
+
+  7. Start minio server holding up to 1GiB of objects in memory, useful for tests and CI.
+     {{.Prompt}} {{.HelpName}} --memory 1GiB --memory-evict
+
+  8. Expand the distributed minio server of example 5 with another server pool of 32 nodes. Run following command on all the 64 nodes.
+     {{.Prompt}} {{.HelpName}} http://node{1...32}.example.com/mnt/export/{1...32} + http://node{33...64}.example.com/mnt/export/{1...32}
 `,
 }
 
 // Checks if endpoints are either available through environment
-// or command line, returns false if both fails.
+// or command line, returns false if both fails. Memory mode
+// requires no endpoints.
 func endpointsPresent(ctx *cli.Context) bool {
 	_, ok := env.Lookup(config.EnvEndpoints)
 	if !ok {
-		ok = ctx.Args().Present()
+		ok = ctx.Args().Present() || ctx.String("memory") != ""
 	}
 	return ok
 }
@@ -159,14 +176,36 @@ func serverHandleCmdArgs(ctx *cli.Context) {
 	}
 
 	endpoints := strings.Fields(env.Get(config.EnvEndpoints, ""))
-	if len(endpoints) > 0 {
-		globalMinioAddr, globalEndpoints, setupType, globalXLSetCount, globalXLSetDriveCount, err = createServerEndpoints(globalCLIContext.Addr, endpoints...)
+	if memory := ctx.String("memory"); memory != "" {
+		if len(endpoints) > 0 || ctx.Args().Present() {
+			logger.Fatal(config.ErrMemoryWithEndpoints(nil), "Invalid command line arguments")
+		}
+		size, err := humanize.ParseBytes(memory)
+		if err != nil {
+			logger.Fatal(config.ErrInvalidMemoryValue(err).Msg("Unknown value `%s`", memory), "Invalid command line arguments")
+		}
+		globalIsMemory = true
+		globalMemoryMaxSize = int64(size)
+		globalMemoryEvict = ctx.Bool("memory-evict")
+		globalMinioAddr = globalCLIContext.Addr
 	} else {
-		globalMinioAddr, globalEndpoints, setupType, globalXLSetCount, globalXLSetDriveCount, err = createServerEndpoints(globalCLIContext.Addr, ctx.Args()...)
-	}
-	logger.FatalIf(err, "Invalid command line arguments")
+		if len(endpoints) > 0 {
+			globalMinioAddr, globalServerPools, globalEndpoints, setupType, err = createServerPoolEndpoints(globalCLIContext.Addr, endpoints...)
+		} else {
+			globalMinioAddr, globalServerPools, globalEndpoints, setupType, err = createServerPoolEndpoints(globalCLIContext.Addr, ctx.Args()...)
+		}
+		logger.FatalIf(err, "Invalid command line arguments")
 
-	logger.LogIf(context.Background(), checkEndpointsSubOptimal(ctx, setupType, globalEndpoints))
+		// Set count is the total across all server pools, all
+		// pools have the same number of drives per set.
+		globalXLSetCount = 0
+		for _, pool := range globalServerPools {
+			globalXLSetCount += pool.SetCount
+		}
+		globalXLSetDriveCount = globalServerPools[0].DrivesPerSet
+
+		logger.LogIf(context.Background(), checkEndpointsSubOptimal(ctx, setupType, globalEndpoints))
+	}
 
 	globalMinioHost, globalMinioPort = mustSplitHostPort(globalMinioAddr)
 
@@ -202,6 +241,8 @@ func serverMain(ctx *cli.Context) {
 	}
 
 	signal.Notify(globalOSSignalCh, os.Interrupt, syscall.SIGTERM)
+	signal.Notify(globalReloadSignalCh, syscall.SIGHUP)
+	notifyProfileSignal()
 
 	// Disable logging until server initialization is complete, any
 	// error during initialization will be shown as a fatal message
@@ -349,6 +390,11 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize IAM system")
 	}
 
+	// Load the old root credential set with the admin API.
+	if err = globalOldCredSys.Init(newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize old credential system")
+	}
+
 	buckets, err := newObject.ListBuckets(context.Background())
 	if err != nil {
 		logger.Fatal(err, "Unable to list buckets on your backend")
@@ -370,6 +416,30 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize lifecycle system")
 	}
 
+	// Create new website system.
+	globalWebsiteSys = NewWebsiteSys()
+
+	// Initialize website system.
+	if err = globalWebsiteSys.Init(buckets, newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize website system")
+	}
+
+	// Create new bucket defaults system.
+	globalBucketDefaultsSys = NewBucketDefaultsSys()
+
+	// Initialize bucket defaults system.
+	if err = globalBucketDefaultsSys.Init(buckets, newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket defaults system")
+	}
+
+	// Create new bucket encryption system.
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+
+	// Initialize bucket encryption system.
+	if err = globalBucketSSEConfigSys.Init(buckets, newObject); err != nil {
+		logger.Fatal(err, "Unable to initialize bucket encryption system")
+	}
+
 	// Create new notification system.
 	globalNotificationSys = NewNotificationSys(globalServerConfig, globalEndpoints)
 
@@ -378,23 +448,55 @@ func serverMain(ctx *cli.Context) {
 		logger.Fatal(err, "Unable to initialize notification system")
 	}
 
+	// Fan out bucket events to the other nodes through NATS.
+	if globalIsDistXL && globalEventBusConfig.Enabled() {
+		if err = globalNotificationSys.InitEventBus(globalEventBusConfig); err != nil {
+			logger.Fatal(err, "Unable to connect to the event bus")
+		}
+	}
+
+	// Check the placement rules before objects are placed with them.
+	if globalIsXL {
+		if err = initXLPlacement(newObject); err != nil {
+			logger.Fatal(err, "Unable to initialize placement rules")
+		}
+	}
+
 	// Verify if object layer supports
 	// - encryption
 	// - compression
 	verifyObjectLayerFeatures("server", newObject)
 
 	initDailyLifecycle()
+	initDataUsageStats()
 
 	if globalIsXL {
 		initBackgroundHealing()
 		initDailyHeal()
 		initDailySweeper()
+		if globalXLScrubBandwidth > 0 {
+			initBackgroundScrub()
+		}
 	}
 
 	globalObjLayerMutex.Lock()
 	globalObjectAPI = newObject
 	globalObjLayerMutex.Unlock()
 
+	// Serve the admin API over gRPC as well, if requested.
+	if globalAdminGRPCAddr != "" {
+		if err = startAdminGRPCServer(globalAdminGRPCAddr); err != nil {
+			logger.Fatal(config.ErrInvalidAdminGRPCAddress(err), "Unable to serve the admin API over gRPC")
+		}
+	}
+
+	// Serve buckets and objects over SFTP as well, if requested.
+	if globalSFTPAddr != "" {
+		if err = startSFTPServer(globalSFTPAddr); err != nil {
+			logger.Fatal(config.ErrInvalidSFTPAddress(err), "Unable to serve buckets and objects over SFTP")
+		}
+	}
+
 	// Prints the formatted startup message once object layer is initialized.
 	printStartupMessage(getAPIEndpoints())
 
@@ -406,6 +508,11 @@ func serverMain(ctx *cli.Context) {
 
 // Initialize object layer with the supplied disks, objectLayer is nil upon any error.
 func newObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err error) {
+	// For memory mode no disks are used at all.
+	if globalIsMemory {
+		return NewMemObjectLayer(globalMemoryMaxSize, globalMemoryEvict), nil
+	}
+
 	// For FS only, directly use the disk.
 
 	isFS := len(endpoints) == 1
@@ -414,6 +521,11 @@ func newObjectLayer(endpoints EndpointList) (newObject ObjectLayer, err error) {
 		return NewFSObjectLayer(endpoints[0].Path)
 	}
 
+	// Expanded deployments combine the object layers of all server pools.
+	if len(globalServerPools) > 1 {
+		return newXLServerPools(globalServerPools)
+	}
+
 	format, err := waitForFormatXL(context.Background(), endpoints[0].IsLocal, endpoints, globalXLSetCount, globalXLSetDriveCount)
 	if err != nil {
 		return nil, err
@@ -17,9 +17,11 @@
 package cmd
 
 import (
+	"bufio"
 	"bytes"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io"
@@ -34,7 +36,9 @@ import (
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/cmd/crypto"
 	xhttp "github.com/minio/minio/cmd/http"
+	"github.com/minio/minio/pkg/event"
 	"github.com/minio/minio/pkg/policy"
 )
 
@@ -77,8 +81,11 @@ func runAllTests(suite *TestSuiteCommon, c *check) {
 	suite.SetUpSuite(c)
 	suite.TestObjectDir(c)
 	suite.TestBucketPolicy(c)
+	suite.TestBucketWebsite(c)
+	suite.TestBucketEncryption(c)
 	suite.TestDeleteBucket(c)
 	suite.TestDeleteBucketNotEmpty(c)
+	suite.TestListenBucketNotificationHandler(c)
 	suite.TestDeleteMultipleObjects(c)
 	suite.TestDeleteObject(c)
 	suite.TestNonExistentBucket(c)
@@ -381,6 +388,220 @@ func (s *TestSuiteCommon) TestBucketPolicy(c *check) {
 	c.Assert(response.StatusCode, http.StatusNotFound)
 }
 
+// TestBucketWebsite - validates anonymous website hosting of a bucket.
+func (s *TestSuiteCommon) TestBucketWebsite(c *check) {
+	bucketPolicyBuf := `{"Version":"2012-10-17","Statement":[{"Action":["s3:GetObject"],"Effect":"Allow","Principal":{"AWS":["*"]},"Resource":["arn:aws:s3:::%s/*"]}]}`
+	websiteConfig := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`
+
+	// generate a random bucket Name.
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest("PUT", getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	// Do not follow redirects to verify them.
+	client := http.Client{
+		Transport: s.transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	response, err := client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	for object, content := range map[string]string{
+		"index.html":      "home",
+		"docs/index.html": "docs",
+		"404.html":        "missing",
+	} {
+		request, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, object),
+			int64(len(content)), bytes.NewReader([]byte(content)), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		response, err = client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, http.StatusOK)
+	}
+
+	bucketPolicyStr := fmt.Sprintf(bucketPolicyBuf, bucketName)
+	request, err = newTestSignedRequest("PUT", getPutPolicyURL(s.endPoint, bucketName),
+		int64(len(bucketPolicyStr)), bytes.NewReader([]byte(bucketPolicyStr)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+
+	// Put the website configuration.
+	request, err = newTestSignedRequest("PUT", getBucketWebsiteURL(s.endPoint, bucketName),
+		int64(len(websiteConfig)), bytes.NewReader([]byte(websiteConfig)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Fetch the website configuration.
+	request, err = newTestSignedRequest("GET", getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	websiteData, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(websiteData), websiteConfig)
+
+	testCases := []struct {
+		object           string
+		expectedStatus   int
+		expectedContent  string
+		expectedLocation string
+	}{
+		// Index document of the bucket root.
+		{"", http.StatusOK, "home", ""},
+		// Index document of a directory.
+		{"docs/", http.StatusOK, "docs", ""},
+		// Directory without trailing slash is redirected.
+		{"docs", http.StatusFound, "", "/" + bucketName + "/docs/"},
+		// Regular object.
+		{"docs/index.html", http.StatusOK, "docs", ""},
+		// Missing object serves the error document.
+		{"missing.html", http.StatusNotFound, "missing", ""},
+	}
+	for _, testCase := range testCases {
+		request, err = newTestRequest("GET", getGetObjectURL(s.endPoint, bucketName, testCase.object), 0, nil)
+		c.Assert(err, nil)
+		response, err = client.Do(request)
+		c.Assert(err, nil)
+		c.Assert(response.StatusCode, testCase.expectedStatus)
+		if testCase.expectedLocation != "" {
+			c.Assert(response.Header.Get("Location"), testCase.expectedLocation)
+			continue
+		}
+		content, err := ioutil.ReadAll(response.Body)
+		c.Assert(err, nil)
+		c.Assert(string(content), testCase.expectedContent)
+	}
+
+	// Authenticated requests on the bucket root still list objects.
+	request, err = newTestSignedRequest("GET", getListObjectsV1URL(s.endPoint, bucketName, "", "", ""),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Delete the website configuration.
+	request, err = newTestSignedRequest("DELETE", getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+
+	// Bucket root is no longer served with its index document,
+	// anonymous listing is not allowed by the bucket policy.
+	request, err = newTestRequest("GET", getGetObjectURL(s.endPoint, bucketName, ""), 0, nil)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusForbidden)
+
+	request, err = newTestSignedRequest("GET", getBucketWebsiteURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNotFound)
+}
+
+// TestBucketEncryption - validates default encryption of objects
+// uploaded to a bucket with an encryption configuration.
+func (s *TestSuiteCommon) TestBucketEncryption(c *check) {
+	encConfig := `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`
+
+	bucketName := getRandomBucketName()
+	request, err := newTestSignedRequest("PUT", getMakeBucketURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client := http.Client{Transport: s.transport}
+	response, err := client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Objects are encrypted with keys of the KMS.
+	request, err = newTestSignedRequest("PUT", getBucketEncryptionURL(s.endPoint, bucketName),
+		int64(len(encConfig)), bytes.NewReader([]byte(encConfig)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusBadRequest)
+
+	GlobalKMS = crypto.NewMasterKey("my-minio-key", [32]byte{})
+	defer func() { GlobalKMS = nil }()
+
+	request, err = newTestSignedRequest("PUT", getBucketEncryptionURL(s.endPoint, bucketName),
+		int64(len(encConfig)), bytes.NewReader([]byte(encConfig)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	request, err = newTestSignedRequest("GET", getBucketEncryptionURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	configData, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(configData), encConfig)
+
+	// Objects uploaded without encryption headers are encrypted.
+	content := "encrypted by default"
+	request, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, "encrypted"),
+		int64(len(content)), bytes.NewReader([]byte(content)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(crypto.SSEHeader), crypto.SSEAlgorithmAES256)
+
+	request, err = newTestSignedRequest("GET", getGetObjectURL(s.endPoint, bucketName, "encrypted"),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(crypto.SSEHeader), crypto.SSEAlgorithmAES256)
+	data, err := ioutil.ReadAll(response.Body)
+	c.Assert(err, nil)
+	c.Assert(string(data), content)
+
+	request, err = newTestSignedRequest("DELETE", getBucketEncryptionURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNoContent)
+
+	// Objects are no longer encrypted by default.
+	request, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, "plain"),
+		int64(len(content)), bytes.NewReader([]byte(content)), s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusOK)
+	c.Assert(response.Header.Get(crypto.SSEHeader), "")
+
+	request, err = newTestSignedRequest("GET", getBucketEncryptionURL(s.endPoint, bucketName),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+	response, err = client.Do(request)
+	c.Assert(err, nil)
+	c.Assert(response.StatusCode, http.StatusNotFound)
+}
+
 // TestDeleteBucket - validates DELETE bucket operation.
 func (s *TestSuiteCommon) TestDeleteBucket(c *check) {
 	bucketName := getRandomBucketName()
@@ -475,7 +696,7 @@ func (s *TestSuiteCommon) TestListenBucketNotificationHandler(c *check) {
 	invalidEvents := []string{"invalidEvent"}
 
 	req, err = newTestSignedRequest("GET",
-		getListenBucketNotificationURL(s.endPoint, invalidBucket, []string{}, []string{}, []string{}),
+		getListenBucketNotificationURL(s.endPoint, invalidBucket, []string{}, []string{}, validEvents),
 		0, nil, s.accessKey, s.secretKey, s.signer)
 	c.Assert(err, nil)
 
@@ -519,6 +740,39 @@ func (s *TestSuiteCommon) TestListenBucketNotificationHandler(c *check) {
 	if s.signer == signerV4 {
 		verifyError(c, response, "XAmzContentSHA256Mismatch", "The provided 'x-amz-content-sha256' header does not match what was computed.", http.StatusBadRequest)
 	}
+
+	// Listen for new objects matching the filters.
+	req, err = newTestSignedRequest("GET",
+		getListenBucketNotificationURL(s.endPoint, bucketName, []string{"photos/"}, []string{".jpg"}, []string{"s3:ObjectCreated:*"}),
+		0, nil, s.accessKey, s.secretKey, s.signer)
+	c.Assert(err, nil)
+
+	client = http.Client{Transport: s.transport, Timeout: 10 * time.Second}
+	response, err = client.Do(req)
+	c.Assert(err, nil)
+	defer response.Body.Close()
+	c.Assert(response.StatusCode, http.StatusOK)
+
+	// Only the last object matches both filters.
+	for _, objectName := range []string{"photos/a.png", "videos/a.jpg", "photos/a.jpg"} {
+		req, err = newTestSignedRequest("PUT", getPutObjectURL(s.endPoint, bucketName, objectName),
+			int64(len("hello")), bytes.NewReader([]byte("hello")), s.accessKey, s.secretKey, s.signer)
+		c.Assert(err, nil)
+		putClient := http.Client{Transport: s.transport}
+		putResponse, err := putClient.Do(req)
+		c.Assert(err, nil)
+		c.Assert(putResponse.StatusCode, http.StatusOK)
+	}
+
+	// Events are separated by new lines, spaces are sent to keep
+	// the connection alive.
+	line, err := bufio.NewReader(response.Body).ReadBytes('\n')
+	c.Assert(err, nil)
+	var notification struct{ Records []event.Event }
+	c.Assert(json.Unmarshal(bytes.TrimSpace(line), &notification), nil)
+	c.Assert(len(notification.Records), 1)
+	c.Assert(notification.Records[0].EventName, event.ObjectCreatedPut)
+	c.Assert(notification.Records[0].S3.Object.Key, url.QueryEscape("photos/a.jpg"))
 }
 
 // Test deletes multple objects and verifies server resonse.
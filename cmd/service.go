@@ -47,8 +47,9 @@ func init() {
 // doesn't fork, but starts a new process using the same environment and
 // arguments as when it was originally started. This allows for a newly
 // deployed binary to be started. It returns the pid of the newly started
-// process when successful.
-func restartProcess() error {
+// process when successful. env is added to the environment of the new
+// process, e.g. to pass it the inherited listeners.
+func restartProcess(env ...string) error {
 	// Use the original binary location. This works with symlinks such that if
 	// the file it points to has been changed we will use the updated symlink.
 	argv0, err := exec.LookPath(os.Args[0])
@@ -58,5 +59,5 @@ func restartProcess() error {
 
 	// Invokes the execve system call.
 	// Re-uses the same pid. This preserves the pid over multiple server-respawns.
-	return syscall.Exec(argv0, os.Args, os.Environ())
+	return syscall.Exec(argv0, os.Args, append(os.Environ(), env...))
 }
@@ -0,0 +1,816 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/hash"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	"github.com/minio/minio/pkg/mimedb"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// Host key of the SFTP listener in the certs directory, it is
+	// generated on first start if it does not exist.
+	sftpHostKeyFile = "sftp_host_key"
+
+	// Objects are read in blocks of this size, the last blocks read
+	// are kept to serve the out of order reads of pipelining clients.
+	sftpReadBlockSize   = 1 << 20
+	sftpReadCacheBlocks = 4
+)
+
+// sftpServer - serves buckets and objects over SFTP, users log in
+// with their access key and secret key.
+type sftpServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+}
+
+// loadSFTPHostKey - returns the host key saved in file, a new ECDSA key
+// is generated and saved if the file does not exist.
+func loadSFTPHostKey(file string) (ssh.Signer, error) {
+	data, err := ioutil.ReadFile(file)
+	if os.IsNotExist(err) {
+		var key *ecdsa.PrivateKey
+		if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+			return nil, err
+		}
+		var der []byte
+		if der, err = x509.MarshalECPrivateKey(key); err != nil {
+			return nil, err
+		}
+		data = pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der})
+		err = ioutil.WriteFile(file, data, 0600)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ssh.ParsePrivateKey(data)
+}
+
+// sftpPasswordCallback - authenticates users with their access key as
+// user name and their secret key as password. Temporary credentials
+// are refused as SFTP has no way to send the session token.
+func sftpPasswordCallback(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+	cred, owner, s3Err := checkKeyValid(conn.User())
+	if s3Err != ErrNone || cred.SessionToken != "" {
+		return nil, errAuthentication
+	}
+	if subtle.ConstantTimeCompare([]byte(cred.SecretKey), password) != 1 {
+		return nil, errAuthentication
+	}
+	return &ssh.Permissions{
+		Extensions: map[string]string{"owner": strconv.FormatBool(owner)},
+	}, nil
+}
+
+// newSFTPServer - returns an SFTP server authenticating itself with hostKey.
+func newSFTPServer(hostKey ssh.Signer) *sftpServer {
+	config := &ssh.ServerConfig{
+		PasswordCallback: sftpPasswordCallback,
+	}
+	config.AddHostKey(hostKey)
+	return &sftpServer{config: config}
+}
+
+// startSFTPServer - serves buckets and objects over SFTP on the given address.
+func startSFTPServer(addr string) error {
+	hostKey, err := loadSFTPHostKey(filepath.Join(globalCertsDir.Get(), sftpHostKeyFile))
+	if err != nil {
+		return err
+	}
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	globalSFTPServer = newSFTPServer(hostKey)
+	go func() {
+		logger.LogIf(context.Background(), globalSFTPServer.Serve(l))
+	}()
+	return nil
+}
+
+// Serve - accepts connections on l until Stop is called.
+func (s *sftpServer) Serve(l net.Listener) error {
+	s.listener = l
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Temporary() {
+				time.Sleep(100 * time.Millisecond)
+				continue
+			}
+			// Accept fails once the listener is closed by Stop.
+			if strings.Contains(err.Error(), "use of closed network connection") {
+				return nil
+			}
+			return err
+		}
+		go s.serveConn(conn)
+	}
+}
+
+// Stop - stops accepting new connections.
+func (s *sftpServer) Stop() error {
+	if s.listener == nil {
+		return nil
+	}
+	return s.listener.Close()
+}
+
+// serveConn - runs the SSH handshake and serves the sftp subsystem
+// requested on the session channels of the connection.
+func (s *sftpServer) serveConn(conn net.Conn) {
+	sconn, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+	if err != nil {
+		// Failed handshakes, including failed logins, are not logged.
+		conn.Close()
+		return
+	}
+	defer sconn.Close()
+	go ssh.DiscardRequests(reqs)
+
+	sourceIP := sconn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(sourceIP); err == nil {
+		sourceIP = host
+	}
+	h := &sftpHandler{
+		accessKey: sconn.User(),
+		owner:     sconn.Permissions.Extensions["owner"] == "true",
+		sourceIP:  sourceIP,
+	}
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			logger.LogIf(context.Background(), err)
+			continue
+		}
+		go func(requests <-chan *ssh.Request) {
+			for req := range requests {
+				// The payload of a subsystem request is the
+				// length prefixed name of the subsystem.
+				ok := req.Type == "subsystem" && len(req.Payload) >= 4 &&
+					string(req.Payload[4:]) == "sftp" &&
+					binary.BigEndian.Uint32(req.Payload) == 4
+				req.Reply(ok, nil)
+				if ok {
+					go h.serve(channel)
+				}
+			}
+		}(requests)
+	}
+}
+
+// sftpHandler - implements the sftp request handlers for an
+// authenticated user, every request is checked against the IAM
+// policies of the user.
+type sftpHandler struct {
+	accessKey string
+	owner     bool
+	sourceIP  string
+}
+
+// serve - serves sftp requests on channel until the client disconnects.
+func (h *sftpHandler) serve(channel ssh.Channel) {
+	server := sftp.NewRequestServer(channel, sftp.Handlers{
+		FileGet:  h,
+		FilePut:  h,
+		FileCmd:  h,
+		FileList: h,
+	})
+	if err := server.Serve(); err != nil && err != io.EOF {
+		logger.LogIf(context.Background(), err)
+	}
+	server.Close()
+}
+
+// isAllowed - returns whether the user may run action on bucket and object.
+func (h *sftpHandler) isAllowed(action iampolicy.Action, bucket, object string) bool {
+	currTime := UTCNow()
+	return globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName: h.accessKey,
+		Action:      action,
+		BucketName:  bucket,
+		ConditionValues: map[string][]string{
+			"CurrenTime":      {currTime.Format(event.AMZTimeFormat)},
+			"EpochTime":       {fmt.Sprintf("%d", currTime.Unix())},
+			"principaltype":   {"User"},
+			"SecureTransport": {"true"},
+			"SourceIp":        {h.sourceIP},
+			"userid":          {h.accessKey},
+			"username":        {h.accessKey},
+		},
+		IsOwner:    h.owner,
+		ObjectName: object,
+	})
+}
+
+// sendEvent - notifies a change of an object made by the user.
+func (h *sftpHandler) sendEvent(eventName event.Name, bucket string, objInfo ObjectInfo) {
+	sendEvent(eventArgs{
+		EventName:  eventName,
+		BucketName: bucket,
+		Object:     objInfo,
+		ReqParams: map[string]string{
+			"region":          globalServerConfig.GetRegion(),
+			"accessKey":       h.accessKey,
+			"sourceIPAddress": h.sourceIP,
+		},
+		UserAgent: "MinIO SFTP",
+		Host:      h.sourceIP,
+	})
+}
+
+// putObject - saves size bytes of reader as the object and notifies it.
+func (h *sftpHandler) putObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, reader io.Reader, size int64) error {
+	objInfo, err := sftpPutObject(ctx, objAPI, bucket, object, reader, size)
+	if err != nil {
+		return err
+	}
+	h.sendEvent(event.ObjectCreatedPut, bucket, objInfo)
+	return nil
+}
+
+// deleteObject - deletes the object and notifies it.
+func (h *sftpHandler) deleteObject(ctx context.Context, objAPI ObjectLayer, bucket, object string) error {
+	if err := objAPI.DeleteObject(ctx, bucket, object); err != nil {
+		return toSFTPErr(err)
+	}
+	h.sendEvent(event.ObjectRemovedDelete, bucket, ObjectInfo{Name: object})
+	return nil
+}
+
+// sftpSplitPath - returns the bucket and object of an sftp path, which
+// is always absolute and cleaned by the sftp server.
+func sftpSplitPath(p string) (bucket, object string) {
+	p = strings.TrimPrefix(path.Clean("/"+p), SlashSeparator)
+	if i := strings.Index(p, SlashSeparator); i >= 0 {
+		return p[:i], p[i+1:]
+	}
+	return p, ""
+}
+
+// toSFTPErr - converts object layer errors to errors understood by
+// the sftp server, unknown errors are sent as a generic failure.
+func toSFTPErr(err error) error {
+	switch err.(type) {
+	case BucketNotFound, ObjectNotFound, BucketNameInvalid, ObjectNameInvalid:
+		return syscall.ENOENT
+	case PrefixAccessDenied:
+		return syscall.EPERM
+	}
+	return err
+}
+
+// sftpFileInfo - os.FileInfo of a bucket, an object or a prefix.
+type sftpFileInfo struct {
+	name    string
+	size    int64
+	modTime time.Time
+	dir     bool
+}
+
+func (fi sftpFileInfo) Name() string       { return fi.name }
+func (fi sftpFileInfo) Size() int64        { return fi.size }
+func (fi sftpFileInfo) ModTime() time.Time { return fi.modTime }
+func (fi sftpFileInfo) IsDir() bool        { return fi.dir }
+func (fi sftpFileInfo) Sys() interface{}   { return nil }
+
+func (fi sftpFileInfo) Mode() os.FileMode {
+	if fi.dir {
+		return os.ModeDir | 0755
+	}
+	return 0644
+}
+
+// sftpFileInfos - implements sftp.ListerAt on a listing.
+type sftpFileInfos []os.FileInfo
+
+func (fis sftpFileInfos) ListAt(ls []os.FileInfo, offset int64) (int, error) {
+	if offset >= int64(len(fis)) {
+		return 0, io.EOF
+	}
+	n := copy(ls, fis[offset:])
+	if n < len(ls) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+// Filelist - lists the buckets, or the objects and prefixes below a
+// prefix, and returns the file info of a single path on Stat.
+func (h *sftpHandler) Filelist(r *sftp.Request) (sftp.ListerAt, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	ctx := r.Context()
+	bucket, object := sftpSplitPath(r.Filepath)
+
+	switch r.Method {
+	case "List":
+		if bucket == "" {
+			return h.listBuckets(ctx, objAPI)
+		}
+		return h.listObjects(ctx, objAPI, bucket, object)
+	case "Stat":
+		fi, err := h.stat(ctx, objAPI, bucket, object)
+		if err != nil {
+			return nil, err
+		}
+		return sftpFileInfos{fi}, nil
+	}
+	return nil, sftp.ErrSshFxOpUnsupported
+}
+
+func (h *sftpHandler) listBuckets(ctx context.Context, objAPI ObjectLayer) (sftp.ListerAt, error) {
+	if !h.isAllowed(iampolicy.ListAllMyBucketsAction, "", "") {
+		return nil, syscall.EPERM
+	}
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, toSFTPErr(err)
+	}
+	fis := make(sftpFileInfos, 0, len(buckets))
+	for _, bucket := range buckets {
+		fis = append(fis, sftpFileInfo{name: bucket.Name, modTime: bucket.Created, dir: true})
+	}
+	return fis, nil
+}
+
+func (h *sftpHandler) listObjects(ctx context.Context, objAPI ObjectLayer, bucket, prefix string) (sftp.ListerAt, error) {
+	if !h.isAllowed(iampolicy.ListBucketAction, bucket, "") {
+		return nil, syscall.EPERM
+	}
+	if prefix != "" {
+		prefix += SlashSeparator
+	}
+	var fis sftpFileInfos
+	marker := ""
+	for {
+		result, err := objAPI.ListObjects(ctx, bucket, prefix, marker, SlashSeparator, maxObjectList)
+		if err != nil {
+			return nil, toSFTPErr(err)
+		}
+		for _, prefix := range result.Prefixes {
+			fis = append(fis, sftpFileInfo{name: path.Base(prefix), dir: true})
+		}
+		for _, obj := range result.Objects {
+			// Skip the object of the listed directory itself.
+			if obj.Name == prefix {
+				continue
+			}
+			size, err := getObjectContentSize(obj)
+			if err != nil {
+				return nil, toSFTPErr(err)
+			}
+			fis = append(fis, sftpFileInfo{name: path.Base(obj.Name), size: size, modTime: obj.ModTime})
+		}
+		if !result.IsTruncated {
+			break
+		}
+		marker = result.NextMarker
+	}
+	if len(fis) == 0 && prefix != "" {
+		// Listing a prefix without objects is listing a missing directory.
+		return nil, syscall.ENOENT
+	}
+	return fis, nil
+}
+
+// stat - returns the file info of the root, a bucket, an object or a
+// prefix, a prefix with objects below it is a directory.
+func (h *sftpHandler) stat(ctx context.Context, objAPI ObjectLayer, bucket, object string) (os.FileInfo, error) {
+	if bucket == "" {
+		return sftpFileInfo{name: SlashSeparator, dir: true}, nil
+	}
+	if object == "" {
+		if !h.isAllowed(iampolicy.ListBucketAction, bucket, "") {
+			return nil, syscall.EPERM
+		}
+		bi, err := objAPI.GetBucketInfo(ctx, bucket)
+		if err != nil {
+			return nil, toSFTPErr(err)
+		}
+		return sftpFileInfo{name: bi.Name, modTime: bi.Created, dir: true}, nil
+	}
+
+	if h.isAllowed(iampolicy.GetObjectAction, bucket, object) {
+		oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if err == nil {
+			size, err := getObjectContentSize(oi)
+			if err != nil {
+				return nil, toSFTPErr(err)
+			}
+			return sftpFileInfo{name: path.Base(object), size: size, modTime: oi.ModTime}, nil
+		}
+		if !isErrObjectNotFound(err) {
+			return nil, toSFTPErr(err)
+		}
+	}
+
+	if !h.isAllowed(iampolicy.ListBucketAction, bucket, "") {
+		return nil, syscall.EPERM
+	}
+	result, err := objAPI.ListObjects(ctx, bucket, object+SlashSeparator, "", SlashSeparator, 1)
+	if err != nil {
+		return nil, toSFTPErr(err)
+	}
+	if len(result.Objects) == 0 && len(result.Prefixes) == 0 {
+		return nil, syscall.ENOENT
+	}
+	return sftpFileInfo{name: path.Base(object), dir: true}, nil
+}
+
+// Fileread - returns a reader of the object.
+func (h *sftpHandler) Fileread(r *sftp.Request) (io.ReaderAt, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	bucket, object := sftpSplitPath(r.Filepath)
+	if object == "" {
+		return nil, syscall.ENOENT
+	}
+	if !h.isAllowed(iampolicy.GetObjectAction, bucket, object) {
+		return nil, syscall.EPERM
+	}
+	ctx := context.Background()
+	oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		return nil, toSFTPErr(err)
+	}
+	// Objects are read decrypted and decompressed.
+	size, err := getObjectContentSize(oi)
+	if err != nil {
+		return nil, toSFTPErr(err)
+	}
+	return &sftpObjectReader{
+		ctx:    ctx,
+		objAPI: objAPI,
+		bucket: bucket,
+		object: object,
+		size:   size,
+		blocks: make(map[int64][]byte),
+	}, nil
+}
+
+// sftpObjectReader - implements io.ReaderAt on an object. Clients
+// send many reads at once which the server runs concurrently, the
+// object is read sequentially in blocks and the last blocks are kept
+// so that reads arriving out of order do not restart the read.
+type sftpObjectReader struct {
+	ctx            context.Context
+	objAPI         ObjectLayer
+	bucket, object string
+	size           int64
+
+	mu     sync.Mutex
+	rd     *GetObjectReader
+	offset int64 // Offset of rd in the object.
+	blocks map[int64][]byte
+	order  []int64
+}
+
+// block - returns the block with index idx.
+func (o *sftpObjectReader) block(idx int64) ([]byte, error) {
+	if b, ok := o.blocks[idx]; ok {
+		return b, nil
+	}
+	start := idx * sftpReadBlockSize
+	if o.rd == nil || o.offset != start {
+		if o.rd != nil {
+			o.rd.Close()
+		}
+		rs := &HTTPRangeSpec{Start: start, End: o.size - 1}
+		rd, err := o.objAPI.GetObjectNInfo(o.ctx, o.bucket, o.object, rs, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			o.rd = nil
+			return nil, toSFTPErr(err)
+		}
+		o.rd, o.offset = rd, start
+	}
+
+	length := o.size - start
+	if length > sftpReadBlockSize {
+		length = sftpReadBlockSize
+	}
+	b := make([]byte, length)
+	if _, err := io.ReadFull(o.rd, b); err != nil {
+		o.rd.Close()
+		o.rd = nil
+		return nil, err
+	}
+	o.offset += length
+
+	o.blocks[idx] = b
+	o.order = append(o.order, idx)
+	if len(o.order) > sftpReadCacheBlocks {
+		delete(o.blocks, o.order[0])
+		o.order = o.order[1:]
+	}
+	return b, nil
+}
+
+func (o *sftpObjectReader) ReadAt(p []byte, off int64) (n int, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for n < len(p) && off < o.size {
+		idx := off / sftpReadBlockSize
+		b, err := o.block(idx)
+		if err != nil {
+			return n, err
+		}
+		m := copy(p[n:], b[off-idx*sftpReadBlockSize:])
+		n += m
+		off += int64(m)
+	}
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (o *sftpObjectReader) Close() error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.rd != nil {
+		return o.rd.Close()
+	}
+	return nil
+}
+
+// Filewrite - returns a writer saving the object once closed.
+func (h *sftpHandler) Filewrite(r *sftp.Request) (io.WriterAt, error) {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return nil, errServerNotInitialized
+	}
+	bucket, object := sftpSplitPath(r.Filepath)
+	if object == "" {
+		return nil, syscall.EPERM
+	}
+	if !h.isAllowed(iampolicy.PutObjectAction, bucket, object) {
+		return nil, syscall.EPERM
+	}
+	if _, err := objAPI.GetBucketInfo(context.Background(), bucket); err != nil {
+		return nil, toSFTPErr(err)
+	}
+	f, err := ioutil.TempFile(sftpStagingDir(objAPI), "sftp-")
+	if err != nil {
+		return nil, err
+	}
+	return &sftpObjectWriter{File: f, h: h, objAPI: objAPI, bucket: bucket, object: object}, nil
+}
+
+// sftpStagingDir - returns the directory uploads are staged in until
+// they are saved, the tmp directory of the backend, which is on the
+// disks of the objects and is cleaned up on restarts.
+func sftpStagingDir(objAPI ObjectLayer) string {
+	if fs, ok := objAPI.(*FSObjects); ok {
+		return fs.fsTmpDir
+	}
+	for _, endpoint := range globalEndpoints {
+		if endpoint.IsLocal {
+			return pathJoin(endpoint.Path, minioMetaTmpBucket)
+		}
+	}
+	return os.TempDir()
+}
+
+// sftpObjectWriter - implements io.WriterAt on a temporary file, as
+// writes arrive out of order and the object size is only known once
+// the client closes the file, which saves the object.
+type sftpObjectWriter struct {
+	*os.File
+	h              *sftpHandler
+	objAPI         ObjectLayer
+	bucket, object string
+}
+
+// WriteAt - writes to the temporary file, uploads larger than the
+// maximum object size are rejected.
+func (w *sftpObjectWriter) WriteAt(p []byte, off int64) (int, error) {
+	if isMaxObjectSize(off + int64(len(p))) {
+		return 0, syscall.EFBIG
+	}
+	return w.File.WriteAt(p, off)
+}
+
+func (w *sftpObjectWriter) Close() error {
+	defer os.Remove(w.File.Name())
+	defer w.File.Close()
+
+	fi, err := w.File.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err = w.File.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	return w.h.putObject(context.Background(), w.objAPI, w.bucket, w.object, w.File, fi.Size())
+}
+
+// sftpPutObject - saves size bytes of reader as the object, encrypted
+// and compressed like objects uploaded through the browser.
+func sftpPutObject(ctx context.Context, objAPI ObjectLayer, bucket, object string, reader io.Reader, size int64) (ObjectInfo, error) {
+	// Deny if WORM is enabled
+	if globalWORMEnabled {
+		if _, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+			return ObjectInfo{}, syscall.EPERM
+		}
+	}
+
+	metadata := map[string]string{"content-type": mimedb.TypeByExtension(path.Ext(object))}
+	header := http.Header{}
+	if isBucketAutoEncrypted(bucket) {
+		header.Set(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
+	}
+
+	actualSize := size
+	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if objAPI.IsCompressionSupported() && isCompressible(header, object) && size > 0 {
+		// Storing the compression metadata.
+		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
+		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
+
+		// Compressed size is un-predictable.
+		size = -1
+		s2c := newS2CompressReader(hashReader)
+		defer s2c.Close()
+		if hashReader, err = hash.NewReader(s2c, size, "", "", actualSize, globalCLIContext.StrictS3Compat); err != nil {
+			return ObjectInfo{}, err
+		}
+	}
+
+	pReader := NewPutObjReader(hashReader, nil, nil)
+	if objAPI.IsEncryptionSupported() && crypto.IsRequested(header) && !hasSuffix(object, SlashSeparator) {
+		rawReader := hashReader
+		var objectEncryptionKey []byte
+		encReader, objectEncryptionKey, err := EncryptRequest(hashReader, &http.Request{Header: header}, bucket, object, metadata)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		info := ObjectInfo{Size: size}
+		// do not try to verify encrypted content
+		hashReader, err = hash.NewReader(encReader, info.EncryptedSize(), "", "", size, globalCLIContext.StrictS3Compat)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		pReader = NewPutObjReader(rawReader, hashReader, objectEncryptionKey)
+	}
+
+	// Ensure that metadata does not contain sensitive information
+	crypto.RemoveSensitiveEntries(metadata)
+
+	objInfo, err := objAPI.PutObject(ctx, bucket, object, pReader, ObjectOptions{UserDefined: metadata})
+	return objInfo, toSFTPErr(err)
+}
+
+// Filecmd - runs the commands changing buckets and objects. Directories
+// in a bucket are prefixes, Mkdir saves an empty directory object.
+func (h *sftpHandler) Filecmd(r *sftp.Request) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	ctx := r.Context()
+	bucket, object := sftpSplitPath(r.Filepath)
+
+	switch r.Method {
+	case "Setstat":
+		// Permissions and times of objects cannot be changed,
+		// ignore them as clients set them after uploads.
+		return nil
+	case "Mkdir":
+		if object == "" {
+			if !h.isAllowed(iampolicy.CreateBucketAction, bucket, "") {
+				return syscall.EPERM
+			}
+			return toSFTPErr(objAPI.MakeBucketWithLocation(ctx, bucket, globalServerConfig.GetRegion()))
+		}
+		if !h.isAllowed(iampolicy.PutObjectAction, bucket, object+SlashSeparator) {
+			return syscall.EPERM
+		}
+		return h.putObject(ctx, objAPI, bucket, object+SlashSeparator, strings.NewReader(""), 0)
+	case "Rmdir":
+		if object == "" {
+			if !h.isAllowed(iampolicy.DeleteBucketAction, bucket, "") {
+				return syscall.EPERM
+			}
+			return toSFTPErr(objAPI.DeleteBucket(ctx, bucket, false))
+		}
+		return h.removeDir(ctx, objAPI, bucket, object)
+	case "Remove":
+		if object == "" {
+			return syscall.EPERM
+		}
+		if !h.isAllowed(iampolicy.DeleteObjectAction, bucket, object) {
+			return syscall.EPERM
+		}
+		return h.deleteObject(ctx, objAPI, bucket, object)
+	case "Rename":
+		dstBucket, dstObject := sftpSplitPath(r.Target)
+		return h.rename(ctx, objAPI, bucket, object, dstBucket, dstObject)
+	}
+	return sftp.ErrSshFxOpUnsupported
+}
+
+// errSFTPDirNotEmpty - a directory with objects below it is removed.
+var errSFTPDirNotEmpty = errors.New("Directory not empty")
+
+// removeDir - removes the directory object of an empty prefix.
+func (h *sftpHandler) removeDir(ctx context.Context, objAPI ObjectLayer, bucket, object string) error {
+	if !h.isAllowed(iampolicy.DeleteObjectAction, bucket, object+SlashSeparator) {
+		return syscall.EPERM
+	}
+	result, err := objAPI.ListObjects(ctx, bucket, object+SlashSeparator, "", SlashSeparator, 2)
+	if err != nil {
+		return toSFTPErr(err)
+	}
+	if len(result.Prefixes) > 0 || len(result.Objects) > 1 ||
+		(len(result.Objects) == 1 && result.Objects[0].Name != object+SlashSeparator) {
+		return errSFTPDirNotEmpty
+	}
+	return h.deleteObject(ctx, objAPI, bucket, object+SlashSeparator)
+}
+
+// rename - copies the object to its new name, then deletes it.
+// Buckets and directories cannot be renamed.
+func (h *sftpHandler) rename(ctx context.Context, objAPI ObjectLayer, srcBucket, srcObject, dstBucket, dstObject string) error {
+	if srcObject == "" || dstObject == "" {
+		return sftp.ErrSshFxOpUnsupported
+	}
+	if !h.isAllowed(iampolicy.GetObjectAction, srcBucket, srcObject) ||
+		!h.isAllowed(iampolicy.DeleteObjectAction, srcBucket, srcObject) ||
+		!h.isAllowed(iampolicy.PutObjectAction, dstBucket, dstObject) {
+		return syscall.EPERM
+	}
+
+	gr, err := objAPI.GetObjectNInfo(ctx, srcBucket, srcObject, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		return toSFTPErr(err)
+	}
+	size, err := getObjectContentSize(gr.ObjInfo)
+	if err != nil {
+		gr.Close()
+		return toSFTPErr(err)
+	}
+	err = h.putObject(ctx, objAPI, dstBucket, dstObject, gr, size)
+	gr.Close()
+	if err != nil {
+		return err
+	}
+	return h.deleteObject(ctx, objAPI, srcBucket, srcObject)
+}
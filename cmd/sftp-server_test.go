@@ -0,0 +1,324 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/cmd/crypto"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSFTPServer(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	globalObjLayerMutex.Lock()
+	globalObjectAPI = obj
+	globalObjLayerMutex.Unlock()
+	defer func() {
+		globalObjLayerMutex.Lock()
+		globalObjectAPI = nil
+		globalObjLayerMutex.Unlock()
+	}()
+
+	globalIAMSys = NewIAMSys()
+	globalIAMSys.Init(obj)
+	globalPolicySys = NewPolicySys()
+
+	dir, err := ioutil.TempDir("", "minio-sftp-server")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	// The host key is generated once and loaded afterwards.
+	hostKeyFile := filepath.Join(dir, sftpHostKeyFile)
+	hostKey, err := loadSFTPHostKey(hostKeyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded, err := loadSFTPHostKey(hostKeyFile); err != nil || !bytes.Equal(reloaded.PublicKey().Marshal(), hostKey.PublicKey().Marshal()) {
+		t.Fatalf("Expected the saved host key, got %v", err)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := newSFTPServer(hostKey)
+	go s.Serve(l)
+	defer s.Stop()
+
+	dial := func(user, password string) (*sftp.Client, error) {
+		conn, err := ssh.Dial("tcp", l.Addr().String(), &ssh.ClientConfig{
+			User:            user,
+			Auth:            []ssh.AuthMethod{ssh.Password(password)},
+			HostKeyCallback: ssh.FixedHostKey(hostKey.PublicKey()),
+		})
+		if err != nil {
+			return nil, err
+		}
+		return sftp.NewClient(conn)
+	}
+
+	cred := globalServerConfig.GetCredential()
+	if _, err = dial(cred.AccessKey, "wrong-secret-key"); err == nil {
+		t.Fatal("Expected the login with a wrong secret key to fail")
+	}
+
+	client, err := dial(cred.AccessKey, cred.SecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	if err = client.Mkdir("/bucket"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Changes made over SFTP are notified like uploads and deletes.
+	target := &gatewayEventTarget{id: event.TargetID{ID: "1", Name: "webhook"}, events: make(chan event.Event, 100)}
+	targetList := event.NewTargetList()
+	if err = targetList.Add(target); err != nil {
+		t.Fatal(err)
+	}
+	notificationSys := globalNotificationSys
+	defer func() { globalNotificationSys = notificationSys }()
+	globalNotificationSys = &NotificationSys{
+		targetList:                 targetList,
+		bucketRulesMap:             make(map[string]event.RulesMap),
+		bucketRemoteTargetRulesMap: make(map[string]map[event.TargetID]event.RulesMap),
+	}
+	globalNotificationSys.AddRulesMap("bucket", event.NewRulesMap([]event.Name{event.ObjectCreatedAll, event.ObjectRemovedAll}, "*", target.ID()))
+	expectEvent := func(name event.Name, key string) {
+		t.Helper()
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case e := <-target.events:
+				if e.EventName == name && e.S3.Object.Key == key {
+					if e.UserIdentity.PrincipalID != cred.AccessKey {
+						t.Fatalf("Expected the event of %s by %s, got %s", key, cred.AccessKey, e.UserIdentity.PrincipalID)
+					}
+					return
+				}
+			case <-timeout:
+				t.Fatalf("Expected %s event of %s", name, key)
+			}
+		}
+	}
+
+	// Write more than a read block so that reads span blocks.
+	data := bytes.Repeat([]byte("0123456789"), sftpReadBlockSize/4)
+	f, err := client.Create("/bucket/dir/object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = f.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(event.ObjectCreatedPut, "dir%2Fobject")
+
+	f, err = client.Open("/bucket/dir/object")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := ioutil.ReadAll(f)
+	f.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("Unexpected object content")
+	}
+
+	fi, err := client.Stat("/bucket/dir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fi.IsDir() {
+		t.Fatal("Expected dir to be a directory")
+	}
+	if _, err = client.Stat("/bucket/missing"); !os.IsNotExist(err) {
+		t.Fatalf("Expected %v, got %v", os.ErrNotExist, err)
+	}
+
+	if err = client.Mkdir("/bucket/empty"); err != nil {
+		t.Fatal(err)
+	}
+	if err = client.Rename("/bucket/dir/object", "/bucket/renamed"); err != nil {
+		t.Fatal(err)
+	}
+	fis, err := client.ReadDir("/bucket")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, fi := range fis {
+		names = append(names, fi.Name())
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "empty" || names[1] != "renamed" {
+		t.Fatalf("Expected [empty renamed], got %v", names)
+	}
+	if err = client.RemoveDirectory("/bucket/empty"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Users are limited to the actions their policy allows.
+	if err = globalIAMSys.SetUser("sftpuser", madmin.UserInfo{
+		SecretKey: "sftpuser-secret",
+		Status:    madmin.AccountEnabled,
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalIAMSys.PolicyDBSet("sftpuser", "readonly", false); err != nil {
+		t.Fatal(err)
+	}
+	userClient, err := dial("sftpuser", "sftpuser-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer userClient.Close()
+
+	if _, err = userClient.Stat("/bucket/renamed"); err != nil {
+		t.Fatal(err)
+	}
+	// The client does not map permission errors to os.ErrPermission,
+	// check for SSH_FX_PERMISSION_DENIED.
+	isPermissionDenied := func(err error) bool {
+		statusErr, ok := err.(*sftp.StatusError)
+		return ok && statusErr.Code == 3
+	}
+	if _, err = userClient.Create("/bucket/object"); !isPermissionDenied(err) {
+		t.Fatalf("Expected permission denied, got %v", err)
+	}
+	if err = userClient.Remove("/bucket/renamed"); !isPermissionDenied(err) {
+		t.Fatalf("Expected permission denied, got %v", err)
+	}
+
+	if err = client.Remove("/bucket/renamed"); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(event.ObjectRemovedDelete, "renamed")
+
+	// Compressed and encrypted objects are stored with another size
+	// than the size of their content.
+	roundTrip := func(name string) {
+		t.Helper()
+		data := bytes.Repeat([]byte("0123456789"), sftpReadBlockSize/4)
+		f, err := client.Create("/bucket/" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err = f.Write(data); err != nil {
+			t.Fatal(err)
+		}
+		if err = f.Close(); err != nil {
+			t.Fatal(err)
+		}
+		if err = client.Rename("/bucket/"+name, "/bucket/renamed-"+name); err != nil {
+			t.Fatal(err)
+		}
+		fi, err := client.Stat("/bucket/renamed-" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() != int64(len(data)) {
+			t.Fatalf("Expected size %d of %s, got %d", len(data), name, fi.Size())
+		}
+		f, err = client.Open("/bucket/renamed-" + name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := ioutil.ReadAll(f)
+		f.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, data) {
+			t.Fatalf("Unexpected content of %s", name)
+		}
+		if err = client.Remove("/bucket/renamed-" + name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	globalIsCompressionEnabled = true
+	roundTrip("compressed.txt")
+	globalIsCompressionEnabled = false
+
+	GlobalKMS = crypto.NewMasterKey("my-minio-key", [32]byte{})
+	globalAutoEncryption = true
+	roundTrip("encrypted.txt")
+	GlobalKMS = nil
+	globalAutoEncryption = false
+
+	if err = client.RemoveDirectory("/bucket"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSFTPObjectWriter(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	// Uploads are staged in the tmp directory of the backend.
+	dir := sftpStagingDir(obj)
+	if dir != obj.(*FSObjects).fsTmpDir {
+		t.Fatalf("Expected uploads to be staged in %s, got %s", obj.(*FSObjects).fsTmpDir, dir)
+	}
+
+	f, err := ioutil.TempFile(dir, "sftp-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	defer f.Close()
+
+	w := &sftpObjectWriter{File: f, objAPI: obj, bucket: "bucket", object: "object"}
+	if _, err = w.WriteAt([]byte("data"), 0); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.WriteAt([]byte("data"), globalMaxObjectSize-2); err != syscall.EFBIG {
+		t.Fatalf("Expected uploads larger than the maximum object size to be rejected, got %v", err)
+	}
+}
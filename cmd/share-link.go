@@ -0,0 +1,245 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/minio/minio/cmd/logger"
+)
+
+const (
+	// Query parameter carrying the ID of a tracked share link,
+	// it is part of the presigned signature.
+	shareLinkQueryKey = "x-minio-share-id"
+
+	// Share links are stored as config/share-links/<id>.json
+	shareLinksPrefix = minioConfigPrefix + "/share-links"
+)
+
+var (
+	errShareLinkNotFound = errors.New("Specified share link does not exist")
+	errShareLinkUsedUp   = errors.New("Specified share link has no downloads left")
+)
+
+// shareLink - a tracked presigned download URL created by the browser.
+type shareLink struct {
+	ID           string    `json:"id"`
+	AccessKey    string    `json:"accessKey"`
+	Bucket       string    `json:"bucket"`
+	Object       string    `json:"object"`
+	Expiry       time.Time `json:"expiry"`
+	MaxDownloads int       `json:"maxDownloads,omitempty"`
+	Downloads    int       `json:"downloads"`
+	Revoked      bool      `json:"revoked,omitempty"`
+}
+
+// usable - returns whether the share link still allows downloads.
+func (link shareLink) usable() bool {
+	if link.Revoked || UTCNow().After(link.Expiry) {
+		return false
+	}
+	return link.MaxDownloads <= 0 || link.Downloads < link.MaxDownloads
+}
+
+func getShareLinkPath(id string) string {
+	return path.Join(shareLinksPrefix, id+".json")
+}
+
+// getShareLinkID - returns the ID of the share link a request is
+// made with, if any. The ID is only trusted on presigned requests,
+// whose signature covers it.
+func getShareLinkID(r *http.Request) string {
+	if getRequestAuthType(r) != authTypePresigned {
+		return ""
+	}
+	return r.URL.Query().Get(shareLinkQueryKey)
+}
+
+// ShareLinkSys - enforces download limits and revocation of share
+// links. Links are read from the backend on every use so that all
+// nodes of a distributed setup agree on their state.
+type ShareLinkSys struct{}
+
+func (sys *ShareLinkSys) load(ctx context.Context, objAPI ObjectLayer, id string) (shareLink, error) {
+	var link shareLink
+	data, err := readConfig(ctx, objAPI, getShareLinkPath(id))
+	if err != nil {
+		if err == errConfigNotFound {
+			err = errShareLinkNotFound
+		}
+		return link, err
+	}
+	err = json.Unmarshal(data, &link)
+	return link, err
+}
+
+func (sys *ShareLinkSys) save(ctx context.Context, objAPI ObjectLayer, link shareLink) error {
+	data, err := json.Marshal(link)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, getShareLinkPath(link.ID), data)
+}
+
+// Create - stores a new share link and returns its ID.
+func (sys *ShareLinkSys) Create(ctx context.Context, objAPI ObjectLayer, link shareLink) (string, error) {
+	link.ID = mustGetUUID()
+	link.Downloads = 0
+	return link.ID, sys.save(ctx, objAPI, link)
+}
+
+// List - returns the share links created with accessKey, all share
+// links if accessKey is empty. Expired links are removed.
+func (sys *ShareLinkSys) List(ctx context.Context, objAPI ObjectLayer, accessKey string) ([]shareLink, error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	var links []shareLink
+	for item := range listIAMConfigItems(objAPI, shareLinksPrefix+SlashSeparator, false, doneCh) {
+		if item.Err != nil {
+			return nil, item.Err
+		}
+		link, err := sys.load(ctx, objAPI, strings.TrimSuffix(item.Item, ".json"))
+		if err != nil {
+			if err == errShareLinkNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if UTCNow().After(link.Expiry) {
+			// Presigned URLs never outlive their expiry.
+			if err = deleteConfig(ctx, objAPI, getShareLinkPath(link.ID)); err != nil && !isErrObjectNotFound(err) {
+				return nil, err
+			}
+			continue
+		}
+		if accessKey == "" || link.AccessKey == accessKey {
+			links = append(links, link)
+		}
+	}
+	return links, nil
+}
+
+// update - loads, modifies and saves a share link under a namespace
+// lock, which is distributed across the nodes of a distributed setup.
+func (sys *ShareLinkSys) update(ctx context.Context, objAPI ObjectLayer, id string, fn func(link *shareLink) error) error {
+	// As readConfig() and saveConfig() take the lock of the config
+	// file itself, a transaction lock is taken around both.
+	linkLock := globalNSMutex.NewNSLock(ctx, minioMetaBucket, getShareLinkPath(id)+".transaction")
+	if err := linkLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer linkLock.Unlock()
+
+	link, err := sys.load(ctx, objAPI, id)
+	if err != nil {
+		return err
+	}
+	if err = fn(&link); err != nil {
+		return err
+	}
+	return sys.save(ctx, objAPI, link)
+}
+
+// Revoke - revokes a share link, only the creator of a link may
+// revoke it unless accessKey is empty.
+func (sys *ShareLinkSys) Revoke(ctx context.Context, objAPI ObjectLayer, id, accessKey string) error {
+	return sys.update(ctx, objAPI, id, func(link *shareLink) error {
+		if accessKey != "" && link.AccessKey != accessKey {
+			return errShareLinkNotFound
+		}
+		link.Revoked = true
+		return nil
+	})
+}
+
+// Check - validates a request made with a share link, which is
+// denied once the link is revoked, expired or used up.
+func (sys *ShareLinkSys) Check(ctx context.Context, objAPI ObjectLayer, id string) APIErrorCode {
+	if objAPI == nil {
+		return ErrServerNotInitialized
+	}
+	link, err := sys.load(ctx, objAPI, id)
+	if err != nil {
+		if err == errShareLinkNotFound {
+			return ErrAccessDenied
+		}
+		return toAPIErrorCode(ctx, err)
+	}
+	if !link.usable() {
+		return ErrAccessDenied
+	}
+	return ErrNone
+}
+
+// StartDownload - reserves a download of a share link before the
+// whole object is sent, so that concurrent downloads on any node never
+// exceed the limit of the link. The returned function must be called
+// once the download is over, the reservation is given back unless the
+// download completed.
+func (sys *ShareLinkSys) StartDownload(ctx context.Context, objAPI ObjectLayer, id string) (func(completed bool), APIErrorCode) {
+	link, err := sys.load(ctx, objAPI, id)
+	if err != nil {
+		if err == errShareLinkNotFound {
+			return nil, ErrAccessDenied
+		}
+		return nil, toAPIErrorCode(ctx, err)
+	}
+	// Downloads of links without a limit are not counted.
+	if link.MaxDownloads <= 0 {
+		return func(bool) {}, ErrNone
+	}
+
+	err = sys.update(ctx, objAPI, id, func(link *shareLink) error {
+		if !link.usable() {
+			return errShareLinkUsedUp
+		}
+		link.Downloads++
+		return nil
+	})
+	switch err {
+	case nil:
+	case errShareLinkNotFound, errShareLinkUsedUp:
+		return nil, ErrAccessDenied
+	default:
+		return nil, toAPIErrorCode(ctx, err)
+	}
+
+	return func(completed bool) {
+		if completed {
+			return
+		}
+		logger.LogIf(ctx, sys.update(ctx, objAPI, id, func(link *shareLink) error {
+			if link.Downloads > 0 {
+				link.Downloads--
+			}
+			return nil
+		}))
+	}, ErrNone
+}
+
+// NewShareLinkSys - creates new share link system.
+func NewShareLinkSys() *ShareLinkSys {
+	return &ShareLinkSys{}
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+)
+
+// signalProfiler - profiles the server between two profiling
+// signals, without going through the admin API, writing CPU, heap,
+// block and mutex profiles to a temporary directory.
+type signalProfiler struct {
+	mu      sync.Mutex
+	dir     string
+	cpuFile *os.File
+}
+
+var globalSignalProfiler = &signalProfiler{}
+
+// Profiles written when the signal profiling stops.
+var signalProfiles = []string{"heap", "block", "mutex"}
+
+// toggle - starts profiling if it is not in progress, otherwise
+// stops it. Returns the directory holding the profiles and whether
+// profiling was started.
+func (p *signalProfiler) toggle() (dir string, started bool, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.cpuFile == nil {
+		dir, err = p.start()
+		return dir, err == nil, err
+	}
+	dir, err = p.stop()
+	return dir, false, err
+}
+
+func (p *signalProfiler) start() (string, error) {
+	dir, err := ioutil.TempDir("", "minio-profile-")
+	if err != nil {
+		return "", err
+	}
+
+	cpuFile, err := os.Create(filepath.Join(dir, "cpu.pprof"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if err = pprof.StartCPUProfile(cpuFile); err != nil {
+		cpuFile.Close()
+		os.RemoveAll(dir)
+		return "", errors.New("unable to start CPU profiling, a profiler is already active")
+	}
+
+	runtime.SetBlockProfileRate(1)
+	runtime.SetMutexProfileFraction(1)
+
+	p.dir = dir
+	p.cpuFile = cpuFile
+	return dir, nil
+}
+
+func (p *signalProfiler) stop() (string, error) {
+	pprof.StopCPUProfile()
+	err := p.cpuFile.Close()
+
+	// Collect the up to date statistics of the heap profile.
+	runtime.GC()
+	for _, name := range signalProfiles {
+		if werr := writeProfile(filepath.Join(p.dir, name+".pprof"), name); werr != nil && err == nil {
+			err = werr
+		}
+	}
+
+	runtime.SetBlockProfileRate(0)
+	runtime.SetMutexProfileFraction(0)
+
+	dir := p.dir
+	p.dir = ""
+	p.cpuFile = nil
+	return dir, err
+}
+
+// writeProfile - writes the named runtime profile to path.
+func writeProfile(path, name string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err = pprof.Lookup(name).WriteTo(f, 0); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
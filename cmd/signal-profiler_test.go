@@ -0,0 +1,57 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestSignalProfilerToggle(t *testing.T) {
+	p := &signalProfiler{}
+
+	dir, started, err := p.toggle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if !started {
+		t.Fatal("expected the first toggle to start profiling")
+	}
+
+	stopDir, started, err := p.toggle()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if started {
+		t.Fatal("expected the second toggle to stop profiling")
+	}
+	if stopDir != dir {
+		t.Fatalf("expected profiles in %s, got %s", dir, stopDir)
+	}
+
+	for _, name := range []string{"cpu.pprof", "heap.pprof", "block.pprof", "mutex.pprof"} {
+		fi, err := os.Stat(filepath.Join(dir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Size() == 0 {
+			t.Errorf("expected %s not to be empty", name)
+		}
+	}
+}
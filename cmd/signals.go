@@ -44,6 +44,7 @@ func handleSignals() {
 
 		if globalNotificationSys != nil {
 			globalNotificationSys.RemoveAllRemoteTargets()
+			globalNotificationSys.CloseEventBus()
 		}
 
 		// Stop watching for any certificate changes.
@@ -52,6 +53,14 @@ func handleSignals() {
 		err = globalHTTPServer.Shutdown()
 		logger.LogIf(context.Background(), err)
 
+		if globalAdminGRPCServer != nil {
+			globalAdminGRPCServer.Stop()
+		}
+
+		if globalSFTPServer != nil {
+			logger.LogIf(context.Background(), globalSFTPServer.Stop())
+		}
+
 		// send signal to various go-routines that they need to quit.
 		close(GlobalServiceDoneCh)
 
@@ -63,6 +72,19 @@ func handleSignals() {
 		return (err == nil && oerr == nil)
 	}
 
+	reloadProcess := func() {
+		// Reload certificates, e.g. renewed certificates which
+		// are not noticed by watching for changes.
+		logger.LogIf(context.Background(), globalTLSCerts.Reload())
+
+		if globalConfigSys == nil {
+			return
+		}
+		if objAPI := newObjectLayerFn(); objAPI != nil {
+			logger.LogIf(context.Background(), loadConfig(objAPI))
+		}
+	}
+
 	for {
 		select {
 		case err := <-globalHTTPServerErrorCh:
@@ -76,12 +98,33 @@ func handleSignals() {
 		case osSignal := <-globalOSSignalCh:
 			logger.Info("Exiting on signal: %s", strings.ToUpper(osSignal.String()))
 			exit(stopProcess())
+		case <-globalReloadSignalCh:
+			logger.Info("Reloading certificates and configuration on signal: SIGHUP")
+			reloadProcess()
+		case <-globalProfileSignalCh:
+			dir, started, err := globalSignalProfiler.toggle()
+			if err != nil {
+				logger.LogIf(context.Background(), err)
+			} else if started {
+				logger.Info("Profiling started on signal: SIGUSR1, profiles will be written to %s on the next SIGUSR1", dir)
+			} else {
+				logger.Info("Profiling stopped on signal: SIGUSR1, profiles written to %s", dir)
+			}
 		case signal := <-globalServiceSignalCh:
 			switch signal {
 			case serviceRestart:
 				logger.Info("Restarting on service signal")
+				// Pass the listening sockets on to the restarted server such
+				// that new connections are queued, instead of being refused,
+				// while in-flight requests are finished.
+				var listenEnv []string
+				if env, lerr := globalHTTPServer.InheritListeners(); lerr == nil {
+					listenEnv = append(listenEnv, env)
+				} else {
+					logger.LogIf(context.Background(), lerr)
+				}
 				stop := stopProcess()
-				rerr := restartProcess()
+				rerr := restartProcess(listenEnv...)
 				logger.LogIf(context.Background(), rerr)
 				exit(stop && rerr == nil)
 			case serviceStop:
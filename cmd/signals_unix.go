@@ -0,0 +1,30 @@
+//go:build !windows
+// +build !windows
+
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"os/signal"
+	"syscall"
+)
+
+// notifyProfileSignal - relays SIGUSR1 to the profiling signal channel.
+func notifyProfileSignal() {
+	signal.Notify(globalProfileSignalCh, syscall.SIGUSR1)
+}
@@ -0,0 +1,128 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/minio/minio-go/v6/pkg/set"
+	"github.com/minio/minio/pkg/auth"
+)
+
+// Requests are counted for at most this many access keys, the
+// requests of further access keys are counted without access key.
+const signatureV2MaxStats = 1000
+
+// signatureV2KeyStats - number of signature V2 requests of an access key.
+type signatureV2KeyStats struct {
+	AccessKey string
+	Accepted  uint64
+	Rejected  uint64
+}
+
+// SignatureV2Sys - decides whether requests signed with signature V2
+// are accepted, and counts them per access key so that operators can
+// find the remaining signature V2 clients before disabling it.
+type SignatureV2Sys struct {
+	// Signature V2 is accepted for all access keys.
+	enabled bool
+	// Access keys still allowed to use signature V2 when disabled.
+	allowedKeys set.StringSet
+
+	mu    sync.Mutex
+	stats map[string]*signatureV2KeyStats
+}
+
+// getSignatureV2Account - returns the access key the requests made
+// with cred are accounted to. Temporary credentials are accounted to
+// the MinIO or LDAP user they were issued to, others such as those of
+// OpenID users to no access key.
+func getSignatureV2Account(cred auth.Credentials) string {
+	if cred.SessionToken == "" {
+		return cred.AccessKey
+	}
+	claims, err := getClaimsFromSessionToken(cred.SessionToken)
+	if err != nil {
+		return ""
+	}
+	for _, key := range []string{parentUser, ldapUser} {
+		if user, ok := claims[key].(string); ok && user != "" {
+			return user
+		}
+	}
+	return ""
+}
+
+// Check - returns whether a request correctly signed with signature V2
+// with cred is accepted. Temporary credentials may use signature V2 if
+// the user they were issued to may.
+func (sys *SignatureV2Sys) Check(cred auth.Credentials) APIErrorCode {
+	accessKey := getSignatureV2Account(cred)
+	allowed := sys.enabled || sys.allowedKeys.Contains(cred.AccessKey) ||
+		(accessKey != "" && sys.allowedKeys.Contains(accessKey))
+
+	sys.mu.Lock()
+	stats, ok := sys.stats[accessKey]
+	if !ok {
+		if len(sys.stats) >= signatureV2MaxStats {
+			accessKey = ""
+			stats, ok = sys.stats[accessKey]
+		}
+		if !ok {
+			stats = &signatureV2KeyStats{AccessKey: accessKey}
+			sys.stats[accessKey] = stats
+		}
+	}
+	if allowed {
+		stats.Accepted++
+	} else {
+		stats.Rejected++
+	}
+	sys.mu.Unlock()
+
+	if !allowed {
+		return ErrSignatureVersionNotSupported
+	}
+	return ErrNone
+}
+
+// Stats - returns the number of signature V2 requests per access key,
+// sorted by access key.
+func (sys *SignatureV2Sys) Stats() []signatureV2KeyStats {
+	sys.mu.Lock()
+	defer sys.mu.Unlock()
+
+	stats := make([]signatureV2KeyStats, 0, len(sys.stats))
+	for _, s := range sys.stats {
+		stats = append(stats, *s)
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].AccessKey < stats[j].AccessKey
+	})
+	return stats
+}
+
+// NewSignatureV2Sys - creates new signature V2 system, if signature V2
+// is not enabled only the given access keys may still use it.
+func NewSignatureV2Sys(enabled bool, allowedKeys []string) *SignatureV2Sys {
+	return &SignatureV2Sys{
+		enabled:     enabled,
+		allowedKeys: set.CreateStringSet(allowedKeys...),
+		stats:       make(map[string]*signatureV2KeyStats),
+	}
+}
@@ -80,7 +80,7 @@ func doesPolicySignatureV2Match(formValues http.Header) APIErrorCode {
 	if !compareSignatureV2(signature, calculateSignatureV2(policy, cred.SecretKey)) {
 		return ErrSignatureDoesNotMatch
 	}
-	return ErrNone
+	return globalSignatureV2Sys.Check(cred)
 }
 
 // Escape encodedQuery string into unescaped list of query params, returns error
@@ -175,7 +175,7 @@ func doesPresignV2SignatureMatch(r *http.Request) APIErrorCode {
 		return ErrSignatureDoesNotMatch
 	}
 
-	return ErrNone
+	return globalSignatureV2Sys.Check(cred)
 }
 
 func getReqAccessKeyV2(r *http.Request) (auth.Credentials, bool, APIErrorCode) {
@@ -273,7 +273,7 @@ func doesSignV2Match(r *http.Request) APIErrorCode {
 	if !compareSignatureV2(v2Auth, expectedAuth) {
 		return ErrSignatureDoesNotMatch
 	}
-	return ErrNone
+	return globalSignatureV2Sys.Check(cred)
 }
 
 func calculateSignatureV2(stringToSign string, secret string) string {
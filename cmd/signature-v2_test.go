@@ -21,8 +21,13 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"sort"
 	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/auth"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
 )
 
 // Tests for 'func TestResourceListSorting(t *testing.T)'.
@@ -271,3 +276,127 @@ func TestDoesPolicySignatureV2Match(t *testing.T) {
 		}
 	}
 }
+
+func TestSignatureV2Disabled(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	signatureV2Sys := globalSignatureV2Sys
+	defer func() { globalSignatureV2Sys = signatureV2Sys }()
+
+	creds := globalServerConfig.GetCredential()
+	policy := "policy"
+	formValues := make(http.Header)
+	formValues.Set("Awsaccesskeyid", creds.AccessKey)
+	formValues.Set("Signature", calculateSignatureV2(policy, creds.SecretKey))
+	formValues.Set("Policy", policy)
+
+	testCases := []struct {
+		enabled     bool
+		allowedKeys []string
+		errCode     APIErrorCode
+	}{
+		{true, nil, ErrNone},
+		{false, nil, ErrSignatureVersionNotSupported},
+		{false, []string{"otheraccesskey"}, ErrSignatureVersionNotSupported},
+		{false, []string{"otheraccesskey", creds.AccessKey}, ErrNone},
+	}
+	for i, test := range testCases {
+		globalSignatureV2Sys = NewSignatureV2Sys(test.enabled, test.allowedKeys)
+		if errCode := doesPolicySignatureV2Match(formValues); errCode != test.errCode {
+			t.Fatalf("(%d) expected to get %s, instead got %s", i+1, niceError(test.errCode), niceError(errCode))
+		}
+
+		var expected signatureV2KeyStats
+		expected.AccessKey = creds.AccessKey
+		if test.errCode == ErrNone {
+			expected.Accepted = 1
+		} else {
+			expected.Rejected = 1
+		}
+		if stats := globalSignatureV2Sys.Stats(); len(stats) != 1 || stats[0] != expected {
+			t.Fatalf("(%d) expected stats %v, got %v", i+1, expected, stats)
+		}
+	}
+
+	// Requests with an invalid signature are neither accepted nor rejected.
+	globalSignatureV2Sys = NewSignatureV2Sys(false, nil)
+	formValues.Set("Signature", calculateSignatureV2("random", creds.SecretKey))
+	if errCode := doesPolicySignatureV2Match(formValues); errCode != ErrSignatureDoesNotMatch {
+		t.Fatalf("expected to get %s, instead got %s", niceError(ErrSignatureDoesNotMatch), niceError(errCode))
+	}
+	if stats := globalSignatureV2Sys.Stats(); len(stats) != 0 {
+		t.Fatalf("expected no stats, got %v", stats)
+	}
+}
+
+func TestSignatureV2Stats(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	sys := NewSignatureV2Sys(false, []string{"user"})
+
+	// Temporary credentials are accounted to their parent user,
+	// which they inherit the permission to use signature V2 from.
+	secretKey := globalServerConfig.GetCredential().SecretKey
+	for i := 0; i < 3; i++ {
+		cred, err := auth.GetNewCredentialsWithMetadata(map[string]interface{}{
+			"exp":                UTCNow().Add(time.Hour).Unix(),
+			iampolicy.PolicyName: "readwrite",
+			parentUser:           "user",
+		}, secretKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if errCode := sys.Check(cred); errCode != ErrNone {
+			t.Fatalf("expected temporary credentials to be accepted, got %s", niceError(errCode))
+		}
+	}
+	expected := []signatureV2KeyStats{{AccessKey: "user", Accepted: 3}}
+	if stats := sys.Stats(); !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected stats %v, got %v", expected, stats)
+	}
+
+	// Other temporary credentials are accounted to no access key, the
+	// subject of OpenID users is not an access key.
+	openIDSys := NewSignatureV2Sys(false, []string{"user"})
+	cred, err := auth.GetNewCredentialsWithMetadata(map[string]interface{}{
+		"exp":                UTCNow().Add(time.Hour).Unix(),
+		iampolicy.PolicyName: "readwrite",
+		"sub":                "user",
+	}, secretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if errCode := openIDSys.Check(cred); errCode != ErrSignatureVersionNotSupported {
+		t.Fatalf("expected OpenID credentials to be rejected, got %s", niceError(errCode))
+	}
+	expected = []signatureV2KeyStats{{AccessKey: "", Rejected: 1}}
+	if stats := openIDSys.Stats(); !reflect.DeepEqual(stats, expected) {
+		t.Fatalf("expected stats %v, got %v", expected, stats)
+	}
+
+	// The number of access keys is capped.
+	for i := 0; i < signatureV2MaxStats+10; i++ {
+		sys.Check(auth.Credentials{AccessKey: fmt.Sprintf("accesskey%d", i)})
+	}
+	stats := sys.Stats()
+	if len(stats) != signatureV2MaxStats+1 {
+		t.Fatalf("expected %d stats, got %d", signatureV2MaxStats+1, len(stats))
+	}
+	if stats[0].AccessKey != "" || stats[0].Rejected != 11 {
+		t.Fatalf("expected 11 rejected requests without access key, got %v", stats[0])
+	}
+}
@@ -125,12 +125,21 @@ func checkKeyValid(accessKey string) (auth.Credentials, bool, APIErrorCode) {
 	var owner = true
 	var cred = globalServerConfig.GetCredential()
 	if cred.AccessKey != accessKey {
+		// Check if the access key is the old root credential which
+		// is still valid while clients migrate to the new one.
+		if oldCred, ok := globalOldCredSys.Get(accessKey); ok {
+			return oldCred, true, ErrNone
+		}
 		if globalIAMSys == nil {
 			return cred, false, ErrInvalidAccessKeyID
 		}
 		// Check if the access key is part of users credentials.
 		var ok bool
 		if cred, ok = globalIAMSys.GetUser(accessKey); !ok {
+			if cred.AccessKey != "" && cred.IsExpired() {
+				// Temporary credentials of an expired session.
+				return cred, false, ErrExpiredToken
+			}
 			return cred, false, ErrInvalidAccessKeyID
 		}
 		owner = false
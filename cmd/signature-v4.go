@@ -315,6 +315,11 @@ func doesPresignedSignatureMatch(hashedPayload string, r *http.Request, region s
 	if !compareSignatureV4(req.URL.Query().Get(xhttp.AmzSignature), newSignature) {
 		return ErrSignatureDoesNotMatch
 	}
+
+	// Enforce download limits and revocation of share links.
+	if shareID := req.URL.Query().Get(shareLinkQueryKey); shareID != "" {
+		return globalShareLinkSys.Check(r.Context(), newObjectLayerFn(), shareID)
+	}
 	return ErrNone
 }
 
@@ -59,6 +59,9 @@ var errVolumeExists = errors.New("volume already exists")
 // errIsNotRegular - not of regular file type.
 var errIsNotRegular = errors.New("not of regular file type")
 
+// errFSXattrNotSupported - extended attributes are not supported by the filesystem.
+var errFSXattrNotSupported = errors.New("extended attributes are not supported")
+
 // errVolumeNotFound - cannot find the volume.
 var errVolumeNotFound = errors.New("volume not found")
 
@@ -17,7 +17,7 @@
 package cmd
 
 const (
-	storageRESTVersion = "v9"
+	storageRESTVersion = "v11"
 	storageRESTPath    = minioReservedBucketPath + "/storage/" + storageRESTVersion + SlashSeparator
 )
 
@@ -45,21 +45,22 @@ const (
 )
 
 const (
-	storageRESTVolume     = "volume"
-	storageRESTDirPath    = "dir-path"
-	storageRESTFilePath   = "file-path"
-	storageRESTSrcVolume  = "source-volume"
-	storageRESTSrcPath    = "source-path"
-	storageRESTDstVolume  = "destination-volume"
-	storageRESTDstPath    = "destination-path"
-	storageRESTOffset     = "offset"
-	storageRESTLength     = "length"
-	storageRESTShardSize  = "shard-size"
-	storageRESTCount      = "count"
-	storageRESTMarkerPath = "marker"
-	storageRESTLeafFile   = "leaf-file"
-	storageRESTRecursive  = "recursive"
-	storageRESTBitrotAlgo = "bitrot-algo"
-	storageRESTBitrotHash = "bitrot-hash"
-	storageRESTInstanceID = "instance-id"
+	storageRESTVolume      = "volume"
+	storageRESTDirPath     = "dir-path"
+	storageRESTFilePath    = "file-path"
+	storageRESTSrcVolume   = "source-volume"
+	storageRESTSrcPath     = "source-path"
+	storageRESTDstVolume   = "destination-volume"
+	storageRESTDstPath     = "destination-path"
+	storageRESTOffset      = "offset"
+	storageRESTLength      = "length"
+	storageRESTShardSize   = "shard-size"
+	storageRESTCount       = "count"
+	storageRESTMarkerPath  = "marker"
+	storageRESTLeafFile    = "leaf-file"
+	storageRESTRecursive   = "recursive"
+	storageRESTBitrotAlgo  = "bitrot-algo"
+	storageRESTBitrotHash  = "bitrot-hash"
+	storageRESTInstanceID  = "instance-id"
+	storageRESTForceDelete = "force-delete"
 )
@@ -21,11 +21,13 @@ package cmd
 import (
 	"bufio"
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"errors"
 	"hash"
 	"io"
 	"net/http"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -40,6 +42,11 @@ const (
 	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
 	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
 	streamingContentEncoding = "aws-chunked"
+
+	// Streaming payload followed by a signed trailing checksum.
+	streamingContentSHA256Trailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	signV4TrailerAlgorithm        = "AWS4-HMAC-SHA256-TRAILER"
+	trailerSignatureKey           = "x-amz-trailer-signature"
 )
 
 // getChunkSignature - get chunk signature.
@@ -61,6 +68,22 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 	return newSignature
 }
 
+// getTrailerSignature - get the signature of the trailing headers,
+// chained to the signature of the final chunk.
+func getTrailerSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedTrailer string) string {
+	// Calculate string to sign.
+	stringToSign := signV4TrailerAlgorithm + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		hashedTrailer
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	return getSignature(signingKey, stringToSign)
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
@@ -79,10 +102,11 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get(xhttp.AmzContentSha256)
 
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get(xhttp.AmzContentSha256) {
+	// or 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER'
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
 		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
@@ -159,7 +183,7 @@ func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 		return nil, errCode
 	}
 
-	return &s3ChunkedReader{
+	cr := &s3ChunkedReader{
 		reader:            bufio.NewReader(req.Body),
 		cred:              cred,
 		seedSignature:     seedSignature,
@@ -167,7 +191,20 @@ func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 		region:            region,
 		chunkSHA256Writer: sha256.New(),
 		state:             readChunkHeader,
-	}, ErrNone
+	}
+
+	// The payload is followed by a trailing checksum, which is
+	// verified once the final chunk is read.
+	if req.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256Trailer {
+		trailer := strings.ToLower(strings.TrimSpace(req.Header.Get(xhttp.AmzTrailer)))
+		algorithm, ok := checksumAlgorithmFromHeader(trailer)
+		if !ok {
+			return nil, ErrInvalidTrailer
+		}
+		cr.trailerName = trailer
+		cr.checksumWriter = checksumAlgorithms[algorithm]()
+	}
+	return cr, ErrNone
 }
 
 // Represents the overall state that is required for decoding a
@@ -184,6 +221,12 @@ type s3ChunkedReader struct {
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	n                 uint64    // Unread bytes in chunk
 	err               error
+
+	// Trailing checksum, only set for payloads with a trailer.
+	trailerName      string
+	trailerValue     string
+	trailerSignature string
+	checksumWriter   hash.Hash // Calculates the checksum of the payload.
 }
 
 // Read chunk reads the chunk token signature portion.
@@ -256,7 +299,11 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			}
 			cr.state = readChunk
 		case readChunkTrailer:
-			cr.err = readCRLF(cr.reader)
+			if cr.lastChunk && cr.checksumWriter != nil {
+				cr.err = cr.readTrailer()
+			} else {
+				cr.err = readCRLF(cr.reader)
+			}
 			if cr.err != nil {
 				return 0, errMalformedEncoding
 			}
@@ -284,6 +331,9 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 
 			// Calculate sha256.
 			cr.chunkSHA256Writer.Write(rbuf[:n0])
+			if cr.checksumWriter != nil {
+				cr.checksumWriter.Write(rbuf[:n0])
+			}
 			// Update the bytes read into request buffer so far.
 			n += n0
 			buf = buf[n0:]
@@ -309,6 +359,11 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			// this follows the chaining.
 			cr.seedSignature = newSignature
 			cr.chunkSHA256Writer.Reset()
+			if cr.lastChunk && cr.checksumWriter != nil {
+				if cr.err = cr.verifyTrailer(); cr.err != nil {
+					return 0, cr.err
+				}
+			}
 			if cr.lastChunk {
 				cr.state = eofChunk
 			} else {
@@ -320,6 +375,67 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 	}
 }
 
+// readTrailer - reads the trailing checksum and its signature which
+// follow the final chunk, up to the empty line ending the payload.
+func (cr *s3ChunkedReader) readTrailer() error {
+	for {
+		line, err := cr.reader.ReadSlice('\n')
+		if err == io.EOF && len(line) == 0 && cr.trailerSignature != "" {
+			// Some clients omit the final CRLF.
+			break
+		}
+		if err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			} else if err == bufio.ErrBufferFull {
+				err = errLineTooLong
+			}
+			return err
+		}
+		if len(line) >= maxLineLength {
+			return errLineTooLong
+		}
+		if !bytes.HasSuffix(line, []byte("\r\n")) {
+			return errMalformedEncoding
+		}
+		line = line[:len(line)-2]
+		if len(line) == 0 {
+			break
+		}
+		i := bytes.IndexByte(line, ':')
+		if i < 0 {
+			return errMalformedEncoding
+		}
+		key, value := strings.ToLower(string(line[:i])), string(bytes.TrimSpace(line[i+1:]))
+		switch key {
+		case cr.trailerName:
+			cr.trailerValue = value
+		case trailerSignatureKey:
+			cr.trailerSignature = value
+		default:
+			return errMalformedEncoding
+		}
+	}
+	if cr.trailerValue == "" || cr.trailerSignature == "" {
+		return errMalformedEncoding
+	}
+	return nil
+}
+
+// verifyTrailer - verifies the signature of the trailer and the
+// trailing checksum against the received payload.
+func (cr *s3ChunkedReader) verifyTrailer() error {
+	hashedTrailer := getSHA256Hash([]byte(cr.trailerName + ":" + cr.trailerValue + "\n"))
+	newSignature := getTrailerSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hashedTrailer)
+	if !compareSignatureV4(cr.trailerSignature, newSignature) {
+		return errSignatureMismatch
+	}
+	if base64.StdEncoding.EncodeToString(cr.checksumWriter.Sum(nil)) != cr.trailerValue {
+		return errChecksumMismatch
+	}
+	return nil
+}
+
 // readCRLF - check if reader only has '\r\n' CRLF character.
 // returns malformed encoding if it doesn't.
 func readCRLF(reader io.Reader) error {
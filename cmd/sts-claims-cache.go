@@ -0,0 +1,131 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+const (
+	// Maximum number of entries in the session token claims cache.
+	stsClaimsCacheSize = 10000
+
+	// Time an entry of the claims cache is served for if the session
+	// token has no expiry.
+	stsClaimsCacheTTL = 15 * time.Minute
+)
+
+type stsClaimsCacheEntry struct {
+	token     string
+	claims    map[string]interface{}
+	secretKey string
+	expiry    time.Time
+}
+
+// stsClaimsCache - LRU of the claims of recently validated session
+// tokens, avoids verifying the signature and parsing the claims of a
+// session token on every request. Entries are never served beyond the
+// expiry of their token or after the secret key signing the tokens
+// has changed.
+type stsClaimsCache struct {
+	mu      sync.Mutex
+	entries map[string]*list.Element
+	lru     *list.List
+	size    int
+}
+
+func newSTSClaimsCache(size int) *stsClaimsCache {
+	return &stsClaimsCache{
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+		size:    size,
+	}
+}
+
+// Get - returns a copy of the cached claims of token, if it was signed
+// with secretKey and has not expired yet.
+func (c *stsClaimsCache) Get(token, secretKey string) (map[string]interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[token]
+	if !ok {
+		return nil, false
+	}
+	entry := elem.Value.(*stsClaimsCacheEntry)
+	if entry.secretKey != secretKey || !UTCNow().Before(entry.expiry) {
+		c.remove(elem)
+		return nil, false
+	}
+	c.lru.MoveToFront(elem)
+
+	// Callers are free to modify the claims.
+	return cloneClaims(entry.claims), true
+}
+
+// Set - caches the claims of token signed with secretKey until expiry.
+func (c *stsClaimsCache) Set(token, secretKey string, claims map[string]interface{}, expiry time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[token]; ok {
+		c.remove(elem)
+	}
+	c.entries[token] = c.lru.PushFront(&stsClaimsCacheEntry{
+		token:     token,
+		claims:    cloneClaims(claims),
+		secretKey: secretKey,
+		expiry:    expiry,
+	})
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *stsClaimsCache) remove(elem *list.Element) {
+	c.lru.Remove(elem)
+	delete(c.entries, elem.Value.(*stsClaimsCacheEntry).token)
+}
+
+func cloneClaims(m map[string]interface{}) map[string]interface{} {
+	clone := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		clone[k] = v
+	}
+	return clone
+}
+
+// claimsExpiry - returns the time until which claims may be cached,
+// which is the expiry of the session token if it has one.
+func claimsExpiry(claims map[string]interface{}) time.Time {
+	expiry := UTCNow().Add(stsClaimsCacheTTL)
+	var exp int64
+	switch v := claims["exp"].(type) {
+	case float64:
+		exp = int64(v)
+	case int64:
+		exp = v
+	default:
+		return expiry
+	}
+	if t := time.Unix(exp, 0).UTC(); t.Before(expiry) {
+		return t
+	}
+	return expiry
+}
@@ -0,0 +1,115 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	jwtgo "github.com/dgrijalva/jwt-go"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+)
+
+func TestSTSClaimsCache(t *testing.T) {
+	cache := newSTSClaimsCache(2)
+	claims := map[string]interface{}{"accessKey": "access"}
+	expiry := UTCNow().Add(time.Minute)
+
+	cache.Set("token1", "secret", claims, expiry)
+	got, ok := cache.Get("token1", "secret")
+	if !ok || got["accessKey"] != "access" {
+		t.Fatalf("expected cached claims, got %v %v", got, ok)
+	}
+
+	// Callers must not be able to modify cached claims.
+	got["accessKey"] = "modified"
+	if got, _ = cache.Get("token1", "secret"); got["accessKey"] != "access" {
+		t.Fatalf("cached claims modified, got %v", got)
+	}
+
+	// Claims are not served after the secret key changed.
+	if _, ok = cache.Get("token1", "newsecret"); ok {
+		t.Fatal("expected cache miss after secret key change")
+	}
+	if _, ok = cache.Get("token1", "secret"); ok {
+		t.Fatal("expected entry to be removed after secret key change")
+	}
+
+	// Claims are not served after the token expired.
+	cache.Set("token2", "secret", claims, UTCNow().Add(-time.Second))
+	if _, ok = cache.Get("token2", "secret"); ok {
+		t.Fatal("expected cache miss for expired token")
+	}
+
+	// Least recently used entries are evicted.
+	cache.Set("token1", "secret", claims, expiry)
+	cache.Set("token2", "secret", claims, expiry)
+	cache.Get("token1", "secret")
+	cache.Set("token3", "secret", claims, expiry)
+	if _, ok = cache.Get("token2", "secret"); ok {
+		t.Fatal("expected least recently used entry to be evicted")
+	}
+	if _, ok = cache.Get("token1", "secret"); !ok {
+		t.Fatal("expected recently used entry to be cached")
+	}
+}
+
+func TestParseClaimsFromToken(t *testing.T) {
+	secret := "minio123"
+	sign := func(exp int64) string {
+		token, err := jwtgo.NewWithClaims(jwtgo.SigningMethodHS512, jwtgo.MapClaims{
+			"accessKey":          "access",
+			"exp":                exp,
+			iampolicy.PolicyName: "readonly",
+		}).SignedString([]byte(secret))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return token
+	}
+
+	exp := UTCNow().Add(time.Hour).Unix()
+	claims, err := parseClaimsFromToken(sign(exp), secret)
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if claimsExpiry(claims).After(time.Unix(exp, 0)) {
+		t.Fatal("claims must not be cached beyond token expiry")
+	}
+
+	// Tokens expiring soon are cached until they expire.
+	exp = UTCNow().Add(time.Minute).Unix()
+	if claims, err = parseClaimsFromToken(sign(exp), secret); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if !claimsExpiry(claims).Equal(time.Unix(exp, 0).UTC()) {
+		t.Fatalf("expected claims to be cached until %v, got %v", time.Unix(exp, 0).UTC(), claimsExpiry(claims))
+	}
+
+	if _, err = parseClaimsFromToken(sign(UTCNow().Add(-time.Hour).Unix()), secret); err != errSessionTokenExpired {
+		t.Fatalf("expected %v, got %v", errSessionTokenExpired, err)
+	}
+
+	if _, err = parseClaimsFromToken(sign(exp), "wrongsecret"); err == nil {
+		t.Fatal("expected token signed with a different secret key to be rejected")
+	}
+
+	if toAPIErrorCode(context.Background(), errSessionTokenExpired) != ErrExpiredToken {
+		t.Fatalf("expected %v to map to ErrExpiredToken", errSessionTokenExpired)
+	}
+}
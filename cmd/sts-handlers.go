@@ -49,6 +49,9 @@ const (
 	// LDAP claim keys
 	ldapUser   = "ldapUser"
 	ldapGroups = "ldapGroups"
+
+	// Claim key of the user who requested credentials with AssumeRole.
+	parentUser = "parent"
 )
 
 // stsAPIHandlers implements and provides http handlers for AWS STS API.
@@ -203,6 +206,7 @@ func (sts *stsAPIHandlers) AssumeRole(w http.ResponseWriter, r *http.Request) {
 	// requesting for temporary credentials. The temporary
 	// credentials will inherit the same policy requirements.
 	m[iampolicy.PolicyName] = policyName
+	m[parentUser] = user.AccessKey
 
 	if len(sessionPolicyStr) > 0 {
 		m[iampolicy.SessionPolicyName] = base64.StdEncoding.EncodeToString([]byte(sessionPolicyStr))
@@ -462,6 +466,11 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		}
 	}
 
+	if !globalServerConfig.LDAPServerConfig.IsEnabled {
+		writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, fmt.Errorf("LDAP server not configured"))
+		return
+	}
+
 	ldapConn, err := globalServerConfig.LDAPServerConfig.Connect()
 	if err != nil {
 		writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, fmt.Errorf("LDAP server connection failure: %v", err))
@@ -471,8 +480,11 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 		writeSTSErrorResponse(ctx, w, ErrSTSInvalidParameterValue, fmt.Errorf("LDAP server not configured: %v", err))
 		return
 	}
+	defer ldapConn.Close()
 
-	usernameSubs, _ := xldap.NewSubstituter("username", ldapUsername)
+	// The username is escaped so that it cannot change the
+	// structure of the bind DN or of the group search filter.
+	usernameSubs, _ := xldap.NewSubstituter("username", xldap.EscapeDN(ldapUsername))
 	// We ignore error below as we already validated the username
 	// format string at startup.
 	usernameDN, _ := usernameSubs.Substitute(globalServerConfig.LDAPServerConfig.UsernameFormat)
@@ -487,14 +499,18 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 	if globalServerConfig.LDAPServerConfig.GroupSearchFilter != "" {
 		// Verified user credentials. Now we find the groups they are
 		// a member of.
-		searchSubs, _ := xldap.NewSubstituter(
-			"username", ldapUsername,
+		filterSubs, _ := xldap.NewSubstituter(
+			"username", ldap.EscapeFilter(ldapUsername),
+			"usernamedn", ldap.EscapeFilter(usernameDN),
+		)
+		baseDNSubs, _ := xldap.NewSubstituter(
+			"username", xldap.EscapeDN(ldapUsername),
 			"usernamedn", usernameDN,
 		)
 		// We ignore error below as we already validated the search string
 		// at startup.
-		groupSearchFilter, _ := searchSubs.Substitute(globalServerConfig.LDAPServerConfig.GroupSearchFilter)
-		baseDN, _ := searchSubs.Substitute(globalServerConfig.LDAPServerConfig.GroupSearchBaseDN)
+		groupSearchFilter, _ := filterSubs.Substitute(globalServerConfig.LDAPServerConfig.GroupSearchFilter)
+		baseDN, _ := baseDNSubs.Substitute(globalServerConfig.LDAPServerConfig.GroupSearchBaseDN)
 		searchRequest := ldap.NewSearchRequest(
 			baseDN,
 			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, 0, false,
@@ -509,9 +525,8 @@ func (sts *stsAPIHandlers) AssumeRoleWithLDAPIdentity(w http.ResponseWriter, r *
 			return
 		}
 		for _, entry := range sr.Entries {
-			// We only queried one attribute, so we only look up
-			// the first one.
-			groups = append(groups, entry.Attributes[0].Values...)
+			// Entries without the group name attribute are skipped.
+			groups = append(groups, entry.GetAttributeValues(globalServerConfig.LDAPServerConfig.GroupNameAttribute)...)
 		}
 	}
 	expiryDur := globalServerConfig.LDAPServerConfig.GetExpiryDuration()
@@ -373,6 +373,15 @@ func UnstartedTestServer(t TestErrHandler, instanceType string) TestServer {
 	globalLifecycleSys = NewLifecycleSys()
 	globalLifecycleSys.Init(buckets, objLayer)
 
+	globalWebsiteSys = NewWebsiteSys()
+	globalWebsiteSys.Init(buckets, objLayer)
+
+	globalBucketDefaultsSys = NewBucketDefaultsSys()
+	globalBucketDefaultsSys.Init(buckets, objLayer)
+
+	globalBucketSSEConfigSys = NewBucketSSEConfigSys()
+	globalBucketSSEConfigSys.Init(buckets, objLayer)
+
 	return testServer
 }
 
@@ -872,6 +881,50 @@ func newTestStreamingSignedRequest(method, urlStr string, contentLength, chunkSi
 	return req, err
 }
 
+// Returns new HTTP request object signed with streaming signature v4,
+// followed by the given checksum as a signed trailer.
+func newTestStreamingSignedTrailerRequest(method, urlStr string, contentLength, chunkSize int64, body io.ReadSeeker, accessKey, secretKey, trailerName, trailerValue string) (*http.Request, error) {
+	req, err := newTestStreamingRequest(method, urlStr, contentLength, chunkSize, body)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("x-amz-content-sha256", streamingContentSHA256Trailer)
+	req.Header.Set("x-amz-trailer", trailerName)
+
+	// The trailer, with its 64 hex digits signature, replaces the CRLF
+	// ending the final chunk.
+	trailer := trailerName + ":" + trailerValue
+	req.ContentLength += int64(len(trailer+"\r\n"+trailerSignatureKey+":\r\n\r\n")+64) - 2
+	req.Header.Set("content-length", strconv.FormatInt(req.ContentLength, 10))
+
+	currTime := UTCNow()
+	signature, err := signStreamingRequest(req, accessKey, secretKey, currTime)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err = assembleStreamingChunks(req, body, chunkSize, secretKey, signature, currTime)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// Replace the CRLF ending the final chunk with the trailer, its
+	// signature is chained to the signature of the final chunk.
+	stream = bytes.TrimSuffix(stream, []byte("\r\n"))
+	i := bytes.LastIndex(stream, []byte(s3ChunkSignatureStr))
+	lastSignature := string(bytes.TrimSuffix(stream[i+len(s3ChunkSignatureStr):], []byte("\r\n")))
+	trailerSignature := getTrailerSignature(auth.Credentials{SecretKey: secretKey}, lastSignature,
+		globalServerConfig.GetRegion(), currTime, getSHA256Hash([]byte(trailer+"\n")))
+	stream = append(stream, []byte(trailer+"\r\n"+trailerSignatureKey+":"+trailerSignature+"\r\n\r\n")...)
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(stream))
+	return req, nil
+}
+
 // preSignV4 presign the request, in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
 func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires int64) error {
@@ -1381,6 +1434,13 @@ func getPutObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+func getAppendObjectURL(endPoint, bucketName, objectName, position string) string {
+	queryValues := url.Values{}
+	queryValues.Set("append", "")
+	queryValues.Set("position", position)
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValues)
+}
+
 func getPutObjectPartURL(endPoint, bucketName, objectName, uploadID, partNumber string) string {
 	queryValues := url.Values{}
 	queryValues.Set("uploadId", uploadID)
@@ -1400,6 +1460,13 @@ func getGetObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+// return URL for fetching the attributes of an object.
+func getGetObjectAttributesURL(endPoint, bucketName, objectName string) string {
+	queryValues := url.Values{}
+	queryValues.Set("attributes", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValues)
+}
+
 // return URL for deleting the object from the bucket.
 func getDeleteObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
@@ -1451,6 +1518,20 @@ func getDeletePolicyURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
 }
 
+// return URL for bucket encryption configuration.
+func getBucketEncryptionURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("encryption", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+// return URL for bucket website configuration.
+func getBucketWebsiteURL(endPoint, bucketName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 // return URL for creating the bucket.
 func getMakeBucketURL(endPoint, bucketName string) string {
 	return makeTestTargetURL(endPoint, bucketName, "", url.Values{})
@@ -2080,6 +2161,12 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadObject":
 			// Register HeadObject handler.
 			bucket.Methods("Head").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+		case "GetObjectTorrent":
+			// Register GetObjectTorrent handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectTorrentHandler).Queries("torrent", "")
+		case "GetObjectAttributes":
+			// Register GetObjectAttributes handler.
+			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectAttributesHandler).Queries("attributes", "")
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods("GET").Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
@@ -2107,6 +2194,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadBucket":
 			// Register HeadBucket handler.
 			bucket.Methods("HEAD").HandlerFunc(api.HeadBucketHandler)
+		case "DeleteBucket":
+			// Register DeleteBucket handler.
+			bucket.Methods("DELETE").HandlerFunc(api.DeleteBucketHandler)
 		case "DeleteMultipleObjects":
 			// Register DeleteMultipleObjects handler.
 			bucket.Methods("POST").HandlerFunc(api.DeleteMultipleObjectsHandler).Queries("delete", "")
@@ -2116,6 +2206,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "CopyObjectPart":
 			// Register CopyObjectPart handler.
 			bucket.Methods("PUT").Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectPartHandler).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
+		case "AppendObject":
+			// Register AppendObject handler.
+			bucket.Methods("POST").Path("/{object:.+}").HandlerFunc(api.AppendObjectHandler).Queries("append", "")
 		case "PutObjectPart":
 			// Register PutObjectPart handler.
 			bucket.Methods("PUT").Path("/{object:.+}").HandlerFunc(api.PutObjectPartHandler).Queries("partNumber", "{partNumber:[0-9]+}", "uploadId", "{uploadId:.*}")
@@ -29,6 +29,10 @@ var errMethodNotAllowed = errors.New("Method not allowed")
 // errSignatureMismatch means signature did not match.
 var errSignatureMismatch = errors.New("Signature does not match")
 
+// errChecksumMismatch means the x-amz-checksum header or the trailing
+// checksum of a streaming upload did not match the received data.
+var errChecksumMismatch = errors.New("Checksum does not match")
+
 // used when we deal with data larger than expected
 var errSizeUnexpected = errors.New("Data size larger than expected")
 
@@ -93,5 +97,11 @@ var errNoSuchPolicy = errors.New("Specified canned policy does not exist")
 // error returned in IAM subsystem when an external users systems is configured.
 var errIAMActionNotAllowed = errors.New("Specified IAM action is not allowed under the current configuration")
 
+// error returned when the tag set of an object is malformed.
+var errInvalidTag = errors.New("The TagKey or TagValue you have provided is invalid")
+
+// error returned when the canned ACL of an object is unknown.
+var errInvalidCannedACL = errors.New("The canned ACL you have provided is invalid")
+
 // error returned when access is denied.
 var errAccessDenied = errors.New("Do not have enough permissions to access this resource")
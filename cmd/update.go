@@ -20,7 +20,11 @@ import (
 	"bufio"
 	"context"
 	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
 	"encoding/hex"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"io/ioutil"
@@ -58,6 +62,10 @@ var (
 		minioReleaseURL + "minio.exe.sha256sum",
 		minioReleaseURL + "minio.exe.shasum",
 	}
+
+	// Public key verifying the signature of the binary on in-place update,
+	// set from MINIO_UPDATE_PUBLIC_KEY. Only checksums are verified if nil.
+	globalUpdatePublicKey crypto.PublicKey
 )
 
 // minioVersionToReleaseTime - parses a standard official release
@@ -477,6 +485,45 @@ func getUpdateInfo(timeout time.Duration, mode string) (updateMsg string, sha256
 	return prepareUpdateMessage(downloadURL, older), sha256Hex, currentReleaseTime, latestReleaseTime, nil
 }
 
+// parseUpdatePublicKey - parses the PEM encoded ECDSA or RSA public
+// key verifying the signature of updated binaries.
+func parseUpdatePublicKey(pemBytes []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(pemBytes)
+	if block == nil {
+		return nil, errors.New("no PEM encoded public key found")
+	}
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey:
+		return publicKey, nil
+	}
+	return nil, fmt.Errorf("unsupported public key type %T, expected ECDSA or RSA", publicKey)
+}
+
+// getUpdateSignature - downloads the signature of the binary at
+// updateURL, published next to it with the .sig extension.
+func getUpdateSignature(clnt *http.Client, updateURL, mode string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, updateURL+".sig", nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", getUserAgent(mode))
+
+	resp, err := clnt.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer xhttp.DrainBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("Error downloading signature URL %s. Response: %v", updateURL+".sig", resp.Status)
+	}
+	return ioutil.ReadAll(resp.Body)
+}
+
 func doUpdate(updateURL, sha256Hex, mode string) (err error) {
 	var sha256Sum []byte
 	sha256Sum, err = hex.DecodeString(sha256Hex)
@@ -500,6 +547,33 @@ func doUpdate(updateURL, sha256Hex, mode string) (err error) {
 
 	req.Header.Set("User-Agent", getUserAgent(mode))
 
+	opts := update.Options{
+		Hash:     crypto.SHA256,
+		Checksum: sha256Sum,
+	}
+	if globalUpdatePublicKey != nil {
+		opts.PublicKey = globalUpdatePublicKey
+		if _, ok := globalUpdatePublicKey.(*rsa.PublicKey); ok {
+			opts.Verifier = update.NewRSAVerifier()
+		} else {
+			opts.Verifier = update.NewECDSAVerifier()
+		}
+		if opts.Signature, err = getUpdateSignature(clnt, updateURL, mode); err != nil {
+			if xnet.IsNetworkOrHostDown(err) {
+				return AdminError{
+					Code:       AdminUpdateURLNotReachable,
+					Message:    err.Error(),
+					StatusCode: http.StatusServiceUnavailable,
+				}
+			}
+			return AdminError{
+				Code:       AdminUpdateUnexpectedFailure,
+				Message:    err.Error(),
+				StatusCode: http.StatusInternalServerError,
+			}
+		}
+	}
+
 	resp, err := clnt.Do(req)
 	if err != nil {
 		if xnet.IsNetworkOrHostDown(err) {
@@ -517,13 +591,7 @@ func doUpdate(updateURL, sha256Hex, mode string) (err error) {
 	}
 	defer xhttp.DrainBody(resp.Body)
 
-	// FIXME: add support for gpg verification as well.
-	if err = update.Apply(resp.Body,
-		update.Options{
-			Hash:     crypto.SHA256,
-			Checksum: sha256Sum,
-		},
-	); err != nil {
+	if err = update.Apply(resp.Body, opts); err != nil {
 		if rerr := update.RollbackError(err); rerr != nil {
 			return AdminError{
 				Code:       AdminUpdateApplyFailure,
@@ -17,6 +17,13 @@
 package cmd
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -309,3 +316,72 @@ func TestParseReleaseData(t *testing.T) {
 		}
 	}
 }
+
+func TestParseUpdatePublicKey(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodePublicKey := func(publicKey crypto.PublicKey) []byte {
+		der, err := x509.MarshalPKIXPublicKey(publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+	}
+
+	testCases := []struct {
+		pemBytes  []byte
+		expectErr bool
+	}{
+		{encodePublicKey(&ecdsaKey.PublicKey), false},
+		{encodePublicKey(&rsaKey.PublicKey), false},
+		{[]byte("not a public key"), true},
+		{pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: []byte("garbage")}), true},
+	}
+	for i, testCase := range testCases {
+		_, err := parseUpdatePublicKey(testCase.pemBytes)
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestGetUpdateSignature(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/minio.sig" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		fmt.Fprint(w, "signature")
+	}))
+	defer ts.Close()
+
+	clnt := &http.Client{Transport: getUpdateTransport(time.Second)}
+	signature, err := getUpdateSignature(clnt, ts.URL+"/minio", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(signature) != "signature" {
+		t.Fatalf("expected signature, got %s", signature)
+	}
+
+	if _, err = getUpdateSignature(clnt, ts.URL+"/minio.exe", ""); err == nil {
+		t.Fatal("expected an error for a missing signature")
+	}
+}
+
+func TestCheckServerUpdate(t *testing.T) {
+	releaseTime := time.Date(2019, 10, 12, 17, 23, 23, 0, time.UTC)
+	us, err := checkServerUpdate("https://example.com/minio", releaseTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !us.DryRun || us.CurrentVersion != Version || us.UpdatedVersion != "2019-10-12T17-23-23Z" {
+		t.Fatalf("unexpected update status %+v", us)
+	}
+}
@@ -37,6 +37,7 @@ import (
 
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/disk"
 	"github.com/minio/minio/pkg/handlers"
 
 	humanize "github.com/dustin/go-humanize"
@@ -484,6 +485,21 @@ func getMinioMode() string {
 		mode = globalMinioModeXL
 	} else if globalIsGateway {
 		mode = globalMinioModeGatewayPrefix + globalGatewayName
+	} else if globalIsMemory {
+		mode = globalMinioModeMemory
 	}
 	return mode
 }
+
+// dropCacheFile drops the cached pages of the file once it is
+// closed, so that streaming large objects does not evict the
+// rest of the page cache.
+type dropCacheFile struct {
+	*os.File
+}
+
+func (f dropCacheFile) Close() error {
+	// Best effort, the hint is not supported on all platforms.
+	disk.FadviseDontNeed(f.File)
+	return f.File.Close()
+}
@@ -0,0 +1,183 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/event"
+	"github.com/minio/minio/pkg/event/target"
+	iampolicy "github.com/minio/minio/pkg/iam/policy"
+	xnet "github.com/minio/minio/pkg/net"
+	"github.com/minio/minio/pkg/policy"
+	"golang.org/x/net/websocket"
+)
+
+// webEventsWriter - adapts a websocket connection to the response
+// writer of the HTTP client target, every event is sent as a text
+// message and keep alives are sent as ping frames.
+type webEventsWriter struct {
+	conn   *websocket.Conn
+	header http.Header
+}
+
+func (w *webEventsWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *webEventsWriter) WriteHeader(statusCode int) {}
+
+func (w *webEventsWriter) Flush() {}
+
+func (w *webEventsWriter) Write(p []byte) (int, error) {
+	msg := bytes.TrimSpace(p)
+	if len(msg) == 0 {
+		// Browsers answer pings on their own, the listing
+		// code never sees them.
+		w.conn.PayloadType = websocket.PingFrame
+		defer func() { w.conn.PayloadType = websocket.TextFrame }()
+	}
+	if _, err := w.conn.Write(msg); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Events - streams the events of a bucket to the browser over a
+// websocket, allowing a live updating object listing. Events are
+// filtered by the optional prefix, suffix and events query values,
+// by default object created and removed events are sent.
+func (web *webAPIHandlers) Events(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "WebEvents")
+
+	defer logger.AuditLog(w, r, "WebEvents", mustGetClaimsFromToken(r))
+
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		writeWebErrorResponse(w, errServerNotInitialized)
+		return
+	}
+
+	if !objectAPI.IsNotificationSupported() || !objectAPI.IsListenBucketSupported() {
+		writeWebErrorResponse(w, NotImplemented{})
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	token := r.URL.Query().Get("token")
+
+	claims, owner, authErr := webTokenAuthenticate(token)
+	if authErr != nil {
+		if authErr == errNoAuthToken {
+			// Check if anonymous (non-owner) has access to listen to events.
+			if !globalPolicySys.IsAllowed(policy.Args{
+				Action:          policy.ListenBucketNotificationAction,
+				BucketName:      bucket,
+				ConditionValues: getConditionValues(r, "", ""),
+				IsOwner:         false,
+			}) {
+				writeWebErrorResponse(w, errAuthentication)
+				return
+			}
+		} else {
+			writeWebErrorResponse(w, authErr)
+			return
+		}
+	}
+
+	// For authenticated users apply IAM policy.
+	if authErr == nil {
+		if !globalIAMSys.IsAllowed(iampolicy.Args{
+			AccountName:     claims.Subject,
+			Action:          iampolicy.ListenBucketNotificationAction,
+			BucketName:      bucket,
+			ConditionValues: getConditionValues(r, "", claims.Subject),
+			IsOwner:         owner,
+		}) {
+			writeWebErrorResponse(w, errAuthentication)
+			return
+		}
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(bucket, false) {
+		writeWebErrorResponse(w, errInvalidBucketName)
+		return
+	}
+
+	values := r.URL.Query()
+	prefix, suffix := values.Get("prefix"), values.Get("suffix")
+	if event.ValidateFilterRuleValue(prefix) != nil || event.ValidateFilterRuleValue(suffix) != nil {
+		writeWebErrorResponse(w, errInvalidArgument)
+		return
+	}
+
+	eventNames := []event.Name{event.ObjectCreatedAll, event.ObjectRemovedAll}
+	if len(values["events"]) > 0 {
+		eventNames = nil
+		for _, s := range values["events"] {
+			eventName, err := event.ParseName(s)
+			if err != nil {
+				writeWebErrorResponse(w, errInvalidArgument)
+				return
+			}
+			eventNames = append(eventNames, eventName)
+		}
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	host, err := xnet.ParseHost(r.RemoteAddr)
+	if err != nil {
+		writeWebErrorResponse(w, err)
+		return
+	}
+
+	server := websocket.Server{
+		// Requests are authenticated by the token, not by cookies,
+		// so there is no need to restrict the origin.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			clientTarget, err := target.NewHTTPClientTarget(*host, &webEventsWriter{conn: conn, header: make(http.Header)})
+			if err != nil {
+				logger.LogIf(ctx, err)
+				return
+			}
+
+			// The browser does not send anything, stop listening
+			// once it closes the connection.
+			go func() {
+				io.Copy(ioutil.Discard, conn)
+				clientTarget.Close()
+			}()
+
+			if err = listenBucketNotification(ctx, objectAPI, bucket, eventNames, event.NewPattern(prefix, suffix), clientTarget); err != nil {
+				logger.LogIf(ctx, err)
+			}
+		},
+	}
+	server.ServeHTTP(w, r)
+}
@@ -0,0 +1,120 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebEvents(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	globalIAMSys = NewIAMSys()
+	globalIAMSys.Init(obj)
+	globalPolicySys = NewPolicySys()
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(initTestWebRPCEndPoint(obj))
+	defer server.Close()
+
+	dial := func(token string) (*websocket.Conn, error) {
+		wsURL := "ws" + strings.TrimPrefix(server.URL, "http") + "/minio/events/" + bucketName + "?prefix=photos/&token=" + token
+		config, err := websocket.NewConfig(wsURL, server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		config.Header.Set("User-Agent", "Mozilla")
+		return websocket.DialConfig(config)
+	}
+
+	if _, err = dial("invalid"); err == nil {
+		t.Fatal("Expected an invalid token to be rejected")
+	}
+
+	credentials := globalServerConfig.GetCredential()
+	token, err := authenticateURL(credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := dial(token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	// Wait for the listener to be registered before sending events.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(globalNotificationSys.targetList.List()) == 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Listener was not registered")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	for _, object := range []string{"other/object", "photos/object"} {
+		sendEvent(eventArgs{
+			EventName:  event.ObjectCreatedPut,
+			BucketName: bucketName,
+			Object:     ObjectInfo{Bucket: bucketName, Name: object},
+			ReqParams:  map[string]string{},
+		})
+	}
+
+	conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var msg string
+	if err = websocket.Message.Receive(conn, &msg); err != nil {
+		t.Fatal(err)
+	}
+	var records struct{ Records []event.Event }
+	if err = json.Unmarshal([]byte(msg), &records); err != nil {
+		t.Fatal(err)
+	}
+	if len(records.Records) != 1 || records.Records[0].S3.Object.Key != "photos%2Fobject" {
+		t.Fatalf("Unexpected event %s", msg)
+	}
+
+	// Closing the connection removes the listener.
+	conn.Close()
+	deadline = time.Now().Add(5 * time.Second)
+	for len(globalNotificationSys.targetList.List()) != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Listener was not removed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -203,6 +203,34 @@ func (args *SetBucketPolicyWebArgs) ToKeyValue() KeyValueMap {
 	return km
 }
 
+// ToKeyValue implementation for GetBucketPolicyDocumentArgs
+func (args *GetBucketPolicyDocumentArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	return km
+}
+
+// ToKeyValue implementation for SetBucketPolicyDocumentArgs
+func (args *SetBucketPolicyDocumentArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	return km
+}
+
+// ToKeyValue implementation for GetBucketNotificationArgs
+func (args *GetBucketNotificationArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	return km
+}
+
+// ToKeyValue implementation for SetBucketNotificationArgs
+func (args *SetBucketNotificationArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetBucket(args.BucketName)
+	return km
+}
+
 // ToKeyValue implementation for SetAuthArgs
 // SetAuthArgs doesn't implement the ToKeyValue interface that will be
 // used by logger subsystem down the line, to avoid leaking
@@ -221,6 +249,21 @@ func (args *PresignedGetArgs) ToKeyValue() KeyValueMap {
 	return km
 }
 
+// ToKeyValue implementation for RevokeShareLinkArgs
+func (args *RevokeShareLinkArgs) ToKeyValue() KeyValueMap {
+	return KeyValueMap{}
+}
+
+// ToKeyValue implementation for PresignedMultipartArgs
+func (args *PresignedMultipartArgs) ToKeyValue() KeyValueMap {
+	km := KeyValueMap{}
+	km.SetHostname(args.HostName)
+	km.SetBucket(args.BucketName)
+	km.SetObject(args.ObjectName)
+	km.SetExpiry(args.Expiry)
+	return km
+}
+
 // newWebContext creates a context with ReqInfo values from the given
 // http request and api name.
 func newWebContext(r *http.Request, args ToKeyValuer, api string) context.Context {
@@ -19,6 +19,7 @@ package cmd
 import (
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"net/http"
@@ -35,7 +36,6 @@ import (
 	"github.com/gorilla/rpc/v2/json2"
 	"github.com/klauspost/compress/zip"
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
-	"github.com/minio/minio-go/v6/pkg/s3utils"
 	"github.com/minio/minio-go/v6/pkg/set"
 	"github.com/minio/minio/browser"
 	"github.com/minio/minio/cmd/crypto"
@@ -179,7 +179,7 @@ func (web *webAPIHandlers) MakeBucket(r *http.Request, args *MakeBucketArgs, rep
 					return toJSONError(ctx, err)
 				}
 				if err = globalDNSConfig.Put(args.BucketName); err != nil {
-					objectAPI.DeleteBucket(ctx, args.BucketName)
+					objectAPI.DeleteBucket(ctx, args.BucketName, false)
 					return toJSONError(ctx, err)
 				}
 
@@ -256,7 +256,7 @@ func (web *webAPIHandlers) DeleteBucket(r *http.Request, args *RemoveBucketArgs,
 
 	deleteBucket := objectAPI.DeleteBucket
 
-	if err := deleteBucket(ctx, args.BucketName); err != nil {
+	if err := deleteBucket(ctx, args.BucketName, false); err != nil {
 		return toJSONError(ctx, err, args.BucketName)
 	}
 
@@ -309,6 +309,13 @@ func (web *webAPIHandlers) ListBuckets(r *http.Request, args *WebGenericArgs, re
 	// Set delimiter value for "s3:delimiter" policy conditionals.
 	r.Header.Set("delimiter", SlashSeparator)
 
+	isAllowed := globalIAMSys.BucketMatcher(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.ListBucketAction,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	})
+
 	// If etcd, dns federation configured list buckets from etcd.
 	if globalDNSConfig != nil {
 		dnsBuckets, err := globalDNSConfig.List()
@@ -321,14 +328,7 @@ func (web *webAPIHandlers) ListBuckets(r *http.Request, args *WebGenericArgs, re
 				continue
 			}
 
-			if globalIAMSys.IsAllowed(iampolicy.Args{
-				AccountName:     claims.Subject,
-				Action:          iampolicy.ListBucketAction,
-				BucketName:      dnsRecord.Key,
-				ConditionValues: getConditionValues(r, "", claims.Subject),
-				IsOwner:         owner,
-				ObjectName:      "",
-			}) {
+			if isAllowed(dnsRecord.Key) {
 				reply.Buckets = append(reply.Buckets, WebBucketInfo{
 					Name:         dnsRecord.Key,
 					CreationDate: dnsRecord.CreationDate,
@@ -343,14 +343,7 @@ func (web *webAPIHandlers) ListBuckets(r *http.Request, args *WebGenericArgs, re
 			return toJSONError(ctx, err)
 		}
 		for _, bucket := range buckets {
-			if globalIAMSys.IsAllowed(iampolicy.Args{
-				AccountName:     claims.Subject,
-				Action:          iampolicy.ListBucketAction,
-				BucketName:      bucket.Name,
-				ConditionValues: getConditionValues(r, "", claims.Subject),
-				IsOwner:         owner,
-				ObjectName:      "",
-			}) {
+			if isAllowed(bucket.Name) {
 				reply.Buckets = append(reply.Buckets, WebBucketInfo{
 					Name:         bucket.Name,
 					CreationDate: bucket.Created,
@@ -964,7 +957,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if globalAutoEncryption && !crypto.SSEC.IsRequested(r.Header) {
+	if isBucketAutoEncrypted(bucket) && !crypto.SSEC.IsRequested(r.Header) {
 		r.Header.Add(crypto.SSEHeader, crypto.SSEAlgorithmAES256)
 	}
 
@@ -983,7 +976,7 @@ func (web *webAPIHandlers) Upload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	var pReader *PutObjReader
-	var reader io.Reader = r.Body
+	reader := globalBucketBandwidth.ingressReader(ctx, bucket, r.Body)
 	actualSize := size
 
 	hashReader, err := hash.NewReader(reader, size, "", "", actualSize, globalCLIContext.StrictS3Compat)
@@ -1179,6 +1172,9 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Set the bucket defaults of headers the object lacks.
+	objInfo = globalBucketDefaultsSys.Apply(bucket, object, objInfo)
+
 	if err = setObjectHeaders(w, objInfo, nil); err != nil {
 		writeWebErrorResponse(w, err)
 		return
@@ -1192,7 +1188,7 @@ func (web *webAPIHandlers) Download(w http.ResponseWriter, r *http.Request) {
 	httpWriter := ioutil.WriteOnClose(w)
 
 	// Write object content to response body
-	if _, err = io.Copy(httpWriter, gr); err != nil {
+	if _, err = io.Copy(httpWriter, globalBucketBandwidth.egressReader(ctx, bucket, gr)); err != nil {
 		if !httpWriter.HasWritten() { // write error response only if no data or headers has been written to client yet
 			writeWebErrorResponse(w, err)
 		}
@@ -1723,6 +1719,381 @@ func (web *webAPIHandlers) SetBucketPolicy(r *http.Request, args *SetBucketPolic
 	return nil
 }
 
+// GetBucketPolicyDocumentArgs - get bucket policy document args.
+type GetBucketPolicyDocumentArgs struct {
+	BucketName string `json:"bucketName"`
+}
+
+// GetBucketPolicyDocumentRep - get bucket policy document reply.
+type GetBucketPolicyDocumentRep struct {
+	UIVersion string `json:"uiVersion"`
+	// Policy is the JSON bucket policy, empty if none is set.
+	Policy string `json:"policy"`
+}
+
+// GetBucketPolicyDocument - get the complete bucket policy document,
+// unlike GetBucketPolicy which only reports canned prefix policies.
+func (web *webAPIHandlers) GetBucketPolicyDocument(r *http.Request, args *GetBucketPolicyDocumentArgs, reply *GetBucketPolicyDocumentRep) error {
+	ctx := newWebContext(r, args, "webGetBucketPolicyDocument")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// For authenticated users apply IAM policy.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.GetBucketPolicyAction,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
+		sr, err := globalDNSConfig.Get(args.BucketName)
+		if err != nil {
+			if err == dns.ErrNoEntriesFound {
+				return toJSONError(ctx, BucketNotFound{
+					Bucket: args.BucketName,
+				}, args.BucketName)
+			}
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		core, rerr := getRemoteInstanceClient(r, getHostFromSrv(sr))
+		if rerr != nil {
+			return toJSONError(ctx, rerr, args.BucketName)
+		}
+		// Use the abstracted API instead of core, such that
+		// NoSuchBucketPolicy errors are automatically handled.
+		reply.Policy, err = core.Client.GetBucketPolicy(args.BucketName)
+		if err != nil {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		return nil
+	}
+
+	bucketPolicy, err := objectAPI.GetBucketPolicy(ctx, args.BucketName)
+	if err != nil {
+		if _, ok := err.(BucketPolicyNotFound); ok {
+			return nil
+		}
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	policyData, err := json.Marshal(bucketPolicy)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+	reply.Policy = string(policyData)
+	return nil
+}
+
+// SetBucketPolicyDocumentArgs - set bucket policy document args.
+type SetBucketPolicyDocumentArgs struct {
+	BucketName string `json:"bucketName"`
+	// Policy is the JSON bucket policy, an empty policy
+	// removes the current bucket policy.
+	Policy string `json:"policy"`
+}
+
+// SetBucketPolicyDocument - replace the complete bucket policy document.
+func (web *webAPIHandlers) SetBucketPolicyDocument(r *http.Request, args *SetBucketPolicyDocumentArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "webSetBucketPolicyDocument")
+	objectAPI := web.ObjectAPI()
+	reply.UIVersion = browser.UIVersion
+
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// A blank policy removes the bucket policy.
+	var action iampolicy.Action = iampolicy.PutBucketPolicyAction
+	if strings.TrimSpace(args.Policy) == "" {
+		action = iampolicy.DeleteBucketPolicyAction
+	}
+
+	// For authenticated users apply IAM policy.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          action,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName)
+	}
+
+	var bucketPolicy *policy.Policy
+	if action == iampolicy.PutBucketPolicyAction {
+		var err error
+		bucketPolicy, err = policy.ParseConfig(strings.NewReader(args.Policy), args.BucketName)
+		if err != nil {
+			return &json2.Error{
+				Message: "Invalid bucket policy: " + err.Error(),
+			}
+		}
+	}
+
+	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
+		sr, err := globalDNSConfig.Get(args.BucketName)
+		if err != nil {
+			if err == dns.ErrNoEntriesFound {
+				return toJSONError(ctx, BucketNotFound{
+					Bucket: args.BucketName,
+				}, args.BucketName)
+			}
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		core, rerr := getRemoteInstanceClient(r, getHostFromSrv(sr))
+		if rerr != nil {
+			return toJSONError(ctx, rerr, args.BucketName)
+		}
+		var policyStr string
+		if bucketPolicy != nil {
+			policyData, err := json.Marshal(bucketPolicy)
+			if err != nil {
+				return toJSONError(ctx, err, args.BucketName)
+			}
+			policyStr = string(policyData)
+		}
+		if err = core.SetBucketPolicy(args.BucketName, policyStr); err != nil {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		return nil
+	}
+
+	if bucketPolicy == nil {
+		if err := objectAPI.DeleteBucketPolicy(ctx, args.BucketName); err != nil {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+
+		globalPolicySys.Remove(args.BucketName)
+		globalNotificationSys.RemoveBucketPolicy(ctx, args.BucketName)
+		return nil
+	}
+
+	if err := objectAPI.SetBucketPolicy(ctx, args.BucketName, bucketPolicy); err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	globalPolicySys.Set(args.BucketName, *bucketPolicy)
+	globalNotificationSys.SetBucketPolicy(ctx, args.BucketName, bucketPolicy)
+	return nil
+}
+
+// GetBucketNotificationArgs - get bucket notification args.
+type GetBucketNotificationArgs struct {
+	BucketName string `json:"bucketName"`
+}
+
+// GetBucketNotificationRep - get bucket notification reply.
+type GetBucketNotificationRep struct {
+	UIVersion string `json:"uiVersion"`
+	// Config is the S3 NotificationConfiguration XML document.
+	Config string `json:"config"`
+}
+
+// GetBucketNotification - get the notification configuration of a bucket.
+func (web *webAPIHandlers) GetBucketNotification(r *http.Request, args *GetBucketNotificationArgs, reply *GetBucketNotificationRep) error {
+	ctx := newWebContext(r, args, "webGetBucketNotification")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	if !objectAPI.IsNotificationSupported() {
+		return toJSONError(ctx, NotImplemented{})
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// For authenticated users apply IAM policy.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.GetBucketNotificationAction,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName)
+	}
+
+	// Notification configuration of remote buckets is
+	// managed by the instance owning the bucket.
+	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
+		return toJSONError(ctx, NotImplemented{}, args.BucketName)
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, args.BucketName); err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	config, err := readNotificationConfig(ctx, objectAPI, args.BucketName)
+	if err != nil {
+		if err != errNoSuchNotifications {
+			return toJSONError(ctx, err, args.BucketName)
+		}
+		config = &event.Config{}
+	}
+	config.SetRegion(globalServerConfig.GetRegion())
+
+	// If xml namespace is empty, set a default value before returning.
+	if config.XMLNS == "" {
+		config.XMLNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	reply.Config = string(configData)
+	return nil
+}
+
+// SetBucketNotificationArgs - set bucket notification args.
+type SetBucketNotificationArgs struct {
+	BucketName string `json:"bucketName"`
+	// Config is the S3 NotificationConfiguration XML document.
+	Config string `json:"config"`
+}
+
+// SetBucketNotification - replace the notification configuration of a bucket.
+func (web *webAPIHandlers) SetBucketNotification(r *http.Request, args *SetBucketNotificationArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "webSetBucketNotification")
+	objectAPI := web.ObjectAPI()
+	reply.UIVersion = browser.UIVersion
+
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	if !objectAPI.IsNotificationSupported() {
+		return toJSONError(ctx, NotImplemented{})
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// For authenticated users apply IAM policy.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.PutBucketNotificationAction,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName)
+	}
+
+	// Notification configuration of remote buckets is
+	// managed by the instance owning the bucket.
+	if isRemoteCallRequired(ctx, args.BucketName, objectAPI) {
+		return toJSONError(ctx, NotImplemented{}, args.BucketName)
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, args.BucketName); err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	// Unlike PutBucketNotification unknown ARNs are rejected,
+	// so that typos in the browser are reported back to the user.
+	config, err := event.ParseConfig(strings.NewReader(args.Config), globalServerConfig.GetRegion(), globalNotificationSys.targetList)
+	if err != nil {
+		return &json2.Error{
+			Message: "Invalid notification configuration: " + err.Error(),
+		}
+	}
+
+	if err = saveNotificationConfig(ctx, objectAPI, args.BucketName, config); err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	rulesMap := config.ToRulesMap()
+	globalNotificationSys.AddRulesMap(args.BucketName, rulesMap)
+	globalNotificationSys.PutBucketNotification(ctx, args.BucketName, rulesMap)
+	return nil
+}
+
+// ListNotificationTargetsRep - list notification targets reply.
+type ListNotificationTargetsRep struct {
+	UIVersion string   `json:"uiVersion"`
+	ARNs      []string `json:"arns"`
+}
+
+// ListNotificationTargets - list the ARNs of the notification targets
+// configured on this server, for use in SetBucketNotification.
+func (web *webAPIHandlers) ListNotificationTargets(r *http.Request, args *WebGenericArgs, reply *ListNotificationTargetsRep) error {
+	ctx := newWebContext(r, args, "webListNotificationTargets")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	if !objectAPI.IsNotificationSupported() {
+		return toJSONError(ctx, NotImplemented{})
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	// Listing targets is only useful to those allowed to
+	// configure bucket notifications.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.PutBucketNotificationAction,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	reply.ARNs = globalNotificationSys.GetARNList()
+	return nil
+}
+
 // PresignedGetArgs - presigned-get API args.
 type PresignedGetArgs struct {
 	// Host header required for signed headers.
@@ -1736,6 +2107,14 @@ type PresignedGetArgs struct {
 
 	// Expiry in seconds.
 	Expiry int64 `json:"expiry"`
+
+	// MaxDownloads limits the number of completed downloads, range
+	// requests included, zero for no limit.
+	MaxDownloads int `json:"maxDownloads"`
+
+	// Revocable tracks the URL as a share link, which can be revoked
+	// with RevokeShareLink. Always set when MaxDownloads is set.
+	Revocable bool `json:"revocable"`
 }
 
 // PresignedGetRep - presigned-get URL reply.
@@ -1743,6 +2122,8 @@ type PresignedGetRep struct {
 	UIVersion string `json:"uiVersion"`
 	// Presigned URL of the object.
 	URL string `json:"url"`
+	// ShareID of a tracked share link.
+	ShareID string `json:"shareId,omitempty"`
 }
 
 // PresignedGET - returns presigned-Get url.
@@ -1775,45 +2156,228 @@ func (web *webAPIHandlers) PresignedGet(r *http.Request, args *PresignedGetArgs,
 		return toJSONError(ctx, errInvalidBucketName)
 	}
 
+	if args.MaxDownloads < 0 {
+		return toJSONError(ctx, errInvalidArgument)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	if args.MaxDownloads == 0 && !args.Revocable {
+		reply.URL = presignedURL(http.MethodGet, args.HostName, args.BucketName, args.ObjectName, args.Expiry, nil, creds, region)
+		return nil
+	}
+
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	shareID, err := globalShareLinkSys.Create(ctx, objectAPI, shareLink{
+		AccessKey:    creds.AccessKey,
+		Bucket:       args.BucketName,
+		Object:       args.ObjectName,
+		Expiry:       UTCNow().Add(time.Duration(presignExpiry(args.Expiry)) * time.Second),
+		MaxDownloads: args.MaxDownloads,
+	})
+	if err != nil {
+		return toJSONError(ctx, err, args.BucketName)
+	}
+
+	reply.ShareID = shareID
+	reply.URL = presignedURLWithQuery(http.MethodGet, args.HostName, args.BucketName, args.ObjectName,
+		url.Values{shareLinkQueryKey: []string{shareID}}, args.Expiry, nil, creds, region)
+	return nil
+}
+
+// ListShareLinksRep - list share links reply.
+type ListShareLinksRep struct {
+	UIVersion string      `json:"uiVersion"`
+	Links     []shareLink `json:"links"`
+}
+
+// ListShareLinks - lists the tracked share links of the user, the
+// owner sees the share links of all users.
+func (web *webAPIHandlers) ListShareLinks(r *http.Request, args *WebGenericArgs, reply *ListShareLinksRep) error {
+	ctx := newWebContext(r, args, "webListShareLinks")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
+
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+
+	accessKey := claims.Subject
+	if owner {
+		accessKey = ""
+	}
+
+	links, err := globalShareLinkSys.List(ctx, objectAPI, accessKey)
+	if err != nil {
+		return toJSONError(ctx, err)
+	}
+
 	reply.UIVersion = browser.UIVersion
-	reply.URL = presignedGet(args.HostName, args.BucketName, args.ObjectName, args.Expiry, creds, region)
+	reply.Links = links
 	return nil
 }
 
-// Returns presigned url for GET method.
-func presignedGet(host, bucket, object string, expiry int64, creds auth.Credentials, region string) string {
-	accessKey := creds.AccessKey
-	secretKey := creds.SecretKey
+// RevokeShareLinkArgs - revoke share link args.
+type RevokeShareLinkArgs struct {
+	ShareID string `json:"shareId"`
+}
 
-	date := UTCNow()
-	dateStr := date.Format(iso8601Format)
-	credential := fmt.Sprintf("%s/%s", accessKey, getScope(date, region))
+// RevokeShareLink - revokes a tracked share link, downloads with the
+// share link are denied afterwards.
+func (web *webAPIHandlers) RevokeShareLink(r *http.Request, args *RevokeShareLinkArgs, reply *WebGenericRep) error {
+	ctx := newWebContext(r, args, "webRevokeShareLink")
+	objectAPI := web.ObjectAPI()
+	if objectAPI == nil {
+		return toJSONError(ctx, errServerNotInitialized)
+	}
 
-	var expiryStr = "604800" // Default set to be expire in 7days.
-	if expiry < 604800 && expiry > 0 {
-		expiryStr = strconv.FormatInt(expiry, 10)
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
 	}
 
-	query := url.Values{}
-	query.Set(xhttp.AmzAlgorithm, signV4Algorithm)
-	query.Set(xhttp.AmzCredential, credential)
-	query.Set(xhttp.AmzDate, dateStr)
-	query.Set(xhttp.AmzExpires, expiryStr)
-	query.Set(xhttp.AmzSignedHeaders, "host")
-	queryStr := s3utils.QueryEncode(query)
+	// Owner may revoke the share links of all users.
+	accessKey := claims.Subject
+	if owner {
+		accessKey = ""
+	}
 
-	path := SlashSeparator + path.Join(bucket, object)
+	if err := globalShareLinkSys.Revoke(ctx, objectAPI, args.ShareID, accessKey); err != nil {
+		if err == errShareLinkNotFound {
+			return &json2.Error{Message: err.Error()}
+		}
+		return toJSONError(ctx, err)
+	}
 
-	// "host" is the only header required to be signed for Presigned URLs.
-	extractedSignedHeaders := make(http.Header)
-	extractedSignedHeaders.Set("host", host)
-	canonicalRequest := getCanonicalRequest(extractedSignedHeaders, unsignedPayload, queryStr, path, "GET")
-	stringToSign := getStringToSign(canonicalRequest, date, getScope(date, region))
-	signingKey := getSigningKey(secretKey, date, region, serviceS3)
-	signature := getSignature(signingKey, stringToSign)
+	reply.UIVersion = browser.UIVersion
+	return nil
+}
 
-	// Construct the final presigned URL.
-	return host + s3utils.EncodePath(path) + "?" + queryStr + "&" + xhttp.AmzSignature + "=" + signature
+// PresignedMultipartArgs - presigned-multipart API args.
+type PresignedMultipartArgs struct {
+	// Host header required for signed headers.
+	HostName string `json:"host"`
+
+	// Bucket name of the object to be uploaded.
+	BucketName string `json:"bucket"`
+
+	// Object name to be uploaded.
+	ObjectName string `json:"object"`
+
+	// UploadID of an initiated multipart upload, empty
+	// to initiate a new upload or to look up existing ones.
+	UploadID string `json:"uploadId"`
+
+	// PartNumbers to presign upload URLs for.
+	PartNumbers []int `json:"partNumbers"`
+
+	// Expiry in seconds.
+	Expiry int64 `json:"expiry"`
+}
+
+// PresignedPartURL - presigned URL to upload a single part.
+type PresignedPartURL struct {
+	PartNumber int    `json:"partNumber"`
+	URL        string `json:"url"`
+}
+
+// PresignedMultipartRep - presigned-multipart URLs reply.
+type PresignedMultipartRep struct {
+	UIVersion string `json:"uiVersion"`
+
+	// Set when no UploadID is passed, the browser initiates
+	// a new upload with NewUploadURL or resumes one of the
+	// uploads listed by ListUploadsURL.
+	NewUploadURL   string `json:"newUploadUrl,omitempty"`
+	ListUploadsURL string `json:"listUploadsUrl,omitempty"`
+
+	// Set for the passed UploadID, parts already uploaded
+	// are listed by ListPartsURL so that an interrupted
+	// upload only needs to send the missing parts.
+	PartURLs     []PresignedPartURL `json:"partUrls,omitempty"`
+	ListPartsURL string             `json:"listPartsUrl,omitempty"`
+	CompleteURL  string             `json:"completeUrl,omitempty"`
+	AbortURL     string             `json:"abortUrl,omitempty"`
+}
+
+// PresignedMultipart - returns presigned URLs for the S3 multipart
+// upload API, allowing the browser to upload objects larger than the
+// single PUT limit in resumable parts.
+func (web *webAPIHandlers) PresignedMultipart(r *http.Request, args *PresignedMultipartArgs, reply *PresignedMultipartRep) error {
+	ctx := newWebContext(r, args, "webPresignedMultipart")
+	claims, owner, authErr := webRequestAuthenticate(r)
+	if authErr != nil {
+		return toJSONError(ctx, authErr)
+	}
+	var creds auth.Credentials
+	if !owner {
+		var ok bool
+		creds, ok = globalIAMSys.GetUser(claims.Subject)
+		if !ok {
+			return toJSONError(ctx, errInvalidAccessKeyID)
+		}
+	} else {
+		creds = globalServerConfig.GetCredential()
+	}
+
+	region := globalServerConfig.GetRegion()
+	if args.BucketName == "" || args.ObjectName == "" {
+		return &json2.Error{
+			Message: "Bucket and Object are mandatory arguments.",
+		}
+	}
+
+	// Check if bucket is a reserved bucket name or invalid.
+	if isReservedOrInvalidBucket(args.BucketName, false) {
+		return toJSONError(ctx, errInvalidBucketName)
+	}
+
+	// The presigned URLs are authorized again when used, this
+	// only reports missing permissions before any data is sent.
+	if !globalIAMSys.IsAllowed(iampolicy.Args{
+		AccountName:     claims.Subject,
+		Action:          iampolicy.PutObjectAction,
+		BucketName:      args.BucketName,
+		ConditionValues: getConditionValues(r, "", claims.Subject),
+		IsOwner:         owner,
+		ObjectName:      args.ObjectName,
+	}) {
+		return toJSONError(ctx, errAccessDenied)
+	}
+
+	reply.UIVersion = browser.UIVersion
+	if args.UploadID == "" {
+		reply.NewUploadURL = presignedURLWithQuery(http.MethodPost, args.HostName, args.BucketName, args.ObjectName,
+			url.Values{"uploads": []string{""}}, args.Expiry, nil, creds, region)
+		reply.ListUploadsURL = presignedURLWithQuery(http.MethodGet, args.HostName, args.BucketName, "",
+			url.Values{"uploads": []string{""}, "prefix": []string{args.ObjectName}}, args.Expiry, nil, creds, region)
+		return nil
+	}
+
+	for _, partNumber := range args.PartNumbers {
+		if partNumber < 1 || partNumber > globalMaxPartID {
+			return toJSONError(ctx, errInvalidArgument)
+		}
+		reply.PartURLs = append(reply.PartURLs, PresignedPartURL{
+			PartNumber: partNumber,
+			URL: presignedURLWithQuery(http.MethodPut, args.HostName, args.BucketName, args.ObjectName, url.Values{
+				"partNumber": []string{strconv.Itoa(partNumber)},
+				"uploadId":   []string{args.UploadID},
+			}, args.Expiry, nil, creds, region),
+		})
+	}
+
+	uploadQuery := url.Values{"uploadId": []string{args.UploadID}}
+	reply.ListPartsURL = presignedURLWithQuery(http.MethodGet, args.HostName, args.BucketName, args.ObjectName, uploadQuery, args.Expiry, nil, creds, region)
+	reply.CompleteURL = presignedURLWithQuery(http.MethodPost, args.HostName, args.BucketName, args.ObjectName, uploadQuery, args.Expiry, nil, creds, region)
+	reply.AbortURL = presignedURLWithQuery(http.MethodDelete, args.HostName, args.BucketName, args.ObjectName, uploadQuery, args.Expiry, nil, creds, region)
+	return nil
 }
 
 // toJSONError converts regular errors into more user friendly
@@ -23,6 +23,7 @@ import (
 	"crypto/md5"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -36,6 +37,7 @@ import (
 	jwtgo "github.com/dgrijalva/jwt-go"
 	humanize "github.com/dustin/go-humanize"
 	miniogopolicy "github.com/minio/minio-go/v6/pkg/policy"
+	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/pkg/hash"
 	"github.com/minio/minio/pkg/policy"
 	"github.com/minio/minio/pkg/policy/condition"
@@ -1219,6 +1221,224 @@ func testWebPresignedGetHandler(obj ObjectLayer, instanceType string, t TestErrH
 	}
 }
 
+// Wrapper for calling PresignedGet handler with tracked share links
+func TestWebHandlerShareLinks(t *testing.T) {
+	ExecObjectLayerTest(t, testWebShareLinks)
+}
+
+func testWebShareLinks(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with XL/FS object layer.
+	webRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+
+	authorization, err := getWebRPCToken(webRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "object"
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+	data := []byte("hello")
+	if _, err = obj.PutObject(context.Background(), bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("Was not able to upload an object, %v", err)
+	}
+
+	callWebRPC := func(method string, args, reply interface{}) error {
+		rec := httptest.NewRecorder()
+		req, err := newTestWebRPCRequest("Web."+method, authorization, args)
+		if err != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+		}
+		webRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		return getTestWebRPCResponse(rec, &reply)
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"GetObject"})
+	request := func(presignedURL, rangeHeader string) int {
+		req, err := newTestRequest(http.MethodGet, presignedURL, 0, nil)
+		if err != nil {
+			t.Fatal("Failed to initialized a new request", err)
+		}
+		req.Header.Del("x-amz-content-sha256")
+		if rangeHeader != "" {
+			req.Header.Set("Range", rangeHeader)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	download := func(presignedURL string) int {
+		return request(presignedURL, "")
+	}
+
+	if err = callWebRPC("PresignedGet", PresignedGetArgs{BucketName: bucketName, ObjectName: objectName, MaxDownloads: -1}, &PresignedGetRep{}); err == nil {
+		t.Fatal("Expected negative download limit to fail")
+	}
+
+	limited := &PresignedGetRep{}
+	if err = callWebRPC("PresignedGet", PresignedGetArgs{BucketName: bucketName, ObjectName: objectName, Expiry: 1000, MaxDownloads: 1}, limited); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if code := download(limited.URL); code != http.StatusOK {
+		t.Fatalf("Expected the first download to succeed, got %d", code)
+	}
+	if code := download(limited.URL); code != http.StatusForbidden {
+		t.Fatalf("Expected the download limit to be enforced, got %d", code)
+	}
+
+	// Range GETs count against the limit as well.
+	ranged := &PresignedGetRep{}
+	if err = callWebRPC("PresignedGet", PresignedGetArgs{BucketName: bucketName, ObjectName: objectName, Expiry: 1000, MaxDownloads: 1}, ranged); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if code := request(ranged.URL, "bytes=0-"); code != http.StatusPartialContent {
+		t.Fatalf("Expected the first range GET to succeed, got %d", code)
+	}
+	if code := request(ranged.URL, "bytes=0-1"); code != http.StatusForbidden {
+		t.Fatalf("Expected the download limit to be enforced on range GETs, got %d", code)
+	}
+	if code := download(ranged.URL); code != http.StatusForbidden {
+		t.Fatalf("Expected the download limit to be enforced, got %d", code)
+	}
+
+	revocable := &PresignedGetRep{}
+	if err = callWebRPC("PresignedGet", PresignedGetArgs{BucketName: bucketName, ObjectName: objectName, Expiry: 1000, Revocable: true}, revocable); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+
+	links := &ListShareLinksRep{}
+	if err = callWebRPC("ListShareLinks", WebGenericArgs{}, links); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if len(links.Links) != 3 {
+		t.Fatalf("Expected 3 share links, got %d", len(links.Links))
+	}
+
+	for i := 0; i < 2; i++ {
+		if code := download(revocable.URL); code != http.StatusOK {
+			t.Fatalf("Expected download to succeed, got %d", code)
+		}
+	}
+	if err = callWebRPC("RevokeShareLink", RevokeShareLinkArgs{ShareID: revocable.ShareID}, &WebGenericRep{}); err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if code := download(revocable.URL); code != http.StatusForbidden {
+		t.Fatalf("Expected revoked share link to be denied, got %d", code)
+	}
+	if err = callWebRPC("RevokeShareLink", RevokeShareLinkArgs{ShareID: "unknown"}, &WebGenericRep{}); err == nil {
+		t.Fatal("Expected revoking an unknown share link to fail")
+	}
+}
+
+// Wrapper for calling PresignedMultipart handler
+func TestWebHandlerPresignedMultipartHandler(t *testing.T) {
+	ExecObjectLayerTest(t, testWebPresignedMultipartHandler)
+}
+
+func testWebPresignedMultipartHandler(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with XL/FS object layer.
+	webRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+
+	authorization, err := getWebRPCToken(webRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	objectName := "dir/object"
+
+	// Create bucket.
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatalf("%s : %s", instanceType, err)
+	}
+
+	presignedMultipart := func(uploadID string, partNumbers ...int) (*PresignedMultipartRep, error) {
+		rec := httptest.NewRecorder()
+		req, err := newTestWebRPCRequest("Web.PresignedMultipart", authorization, PresignedMultipartArgs{
+			BucketName:  bucketName,
+			ObjectName:  objectName,
+			UploadID:    uploadID,
+			PartNumbers: partNumbers,
+			Expiry:      1000,
+		})
+		if err != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+		}
+		webRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be 200, but instead found `%d`", rec.Code)
+		}
+		reply := &PresignedMultipartRep{}
+		err = getTestWebRPCResponse(rec, &reply)
+		return reply, err
+	}
+
+	apiRouter := initTestAPIEndPoints(obj, []string{"NewMultipart", "PutObjectPart", "CompleteMultipart", "GetObject"})
+	doPresigned := func(method, presignedURL string, body []byte) *httptest.ResponseRecorder {
+		req, err := newTestRequest(method, presignedURL, int64(len(body)), bytes.NewReader(body))
+		if err != nil {
+			t.Fatal("Failed to initialized a new request", err)
+		}
+		req.Header.Del("x-amz-content-sha256")
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s %s: expected the response status to be 200, but instead found `%d`: %s", method, presignedURL, rec.Code, rec.Body)
+		}
+		return rec
+	}
+
+	reply, err := presignedMultipart("")
+	if err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if reply.NewUploadURL == "" || reply.ListUploadsURL == "" {
+		t.Fatalf("Expected URLs to initiate and list uploads, got %#v", reply)
+	}
+
+	var initiated InitiateMultipartUploadResponse
+	if err = xml.NewDecoder(doPresigned(http.MethodPost, reply.NewUploadURL, nil).Body).Decode(&initiated); err != nil {
+		t.Fatal(err)
+	}
+
+	// Part numbers must be within the S3 limits.
+	if _, err = presignedMultipart(initiated.UploadID, 0); err == nil {
+		t.Fatal("Expected invalid part number to fail")
+	}
+
+	reply, err = presignedMultipart(initiated.UploadID, 1)
+	if err != nil {
+		t.Fatalf("Failed, %v", err)
+	}
+	if len(reply.PartURLs) != 1 || reply.PartURLs[0].PartNumber != 1 {
+		t.Fatalf("Expected a single part URL, got %#v", reply.PartURLs)
+	}
+
+	data := bytes.Repeat([]byte("a"), humanize.KiByte)
+	etag := doPresigned(http.MethodPut, reply.PartURLs[0].URL, data).Header()[xhttp.ETag][0]
+
+	completeData, err := xml.Marshal(CompleteMultipartUpload{Parts: []CompletePart{{PartNumber: 1, ETag: etag}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	doPresigned(http.MethodPost, reply.CompleteURL, completeData)
+
+	var buf bytes.Buffer
+	if err = obj.GetObject(context.Background(), bucketName, objectName, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, buf.Bytes()) {
+		t.Fatal("Read data is not equal was what was expected")
+	}
+}
+
 // Wrapper for calling GetBucketPolicy Handler
 func TestWebHandlerGetBucketPolicyHandler(t *testing.T) {
 	ExecObjectLayerTest(t, testWebGetBucketPolicyHandler)
@@ -1464,6 +1684,181 @@ func testWebSetBucketPolicyHandler(obj ObjectLayer, instanceType string, t TestE
 	}
 }
 
+// Wrapper for calling GetBucketPolicyDocument and SetBucketPolicyDocument handlers
+func TestWebHandlerBucketPolicyDocument(t *testing.T) {
+	ExecObjectLayerTest(t, testWebBucketPolicyDocument)
+}
+
+// testWebBucketPolicyDocument - Test GetBucketPolicyDocument and SetBucketPolicyDocument web handlers
+func testWebBucketPolicyDocument(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	// Register the API end points with XL/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	getPolicy := func() string {
+		rec := httptest.NewRecorder()
+		req, err := newTestWebRPCRequest("Web.GetBucketPolicyDocument", authorization, &GetBucketPolicyDocumentArgs{BucketName: bucketName})
+		if err != nil {
+			t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		reply := &GetBucketPolicyDocumentRep{}
+		if err = getTestWebRPCResponse(rec, &reply); err != nil {
+			t.Fatalf("GetBucketPolicyDocument should succeed but it didn't, %v", err)
+		}
+		return reply.Policy
+	}
+
+	if p := getPolicy(); p != "" {
+		t.Fatalf("Expected no bucket policy, found %s", p)
+	}
+
+	policyDoc := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/public/*"]}]}`, bucketName)
+
+	testCases := []struct {
+		bucketName string
+		policy     string
+		pass       bool
+	}{
+		// Invalid bucket name
+		{"", policyDoc, false},
+		// Malformed policy
+		{bucketName, "{", false},
+		// Policy for another bucket
+		{bucketName, strings.Replace(policyDoc, bucketName, "otherbucket", 1), false},
+		// Valid policy
+		{bucketName, policyDoc, true},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		args := &SetBucketPolicyDocumentArgs{BucketName: testCase.bucketName, Policy: testCase.policy}
+		req, err := newTestWebRPCRequest("Web.SetBucketPolicyDocument", authorization, args)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request: <ERROR> %v", i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		reply := &WebGenericRep{}
+		err = getTestWebRPCResponse(rec, &reply)
+		if testCase.pass && err != nil {
+			t.Fatalf("Test %d: Should succeed but it didn't, %#v", i+1, err)
+		}
+		if !testCase.pass && err == nil {
+			t.Fatalf("Test %d: Should fail it didn't", i+1)
+		}
+	}
+
+	if p := getPolicy(); !strings.Contains(p, bucketName+"/public/*") {
+		t.Fatalf("Expected the stored bucket policy, found %s", p)
+	}
+
+	// An empty policy removes the bucket policy.
+	rec := httptest.NewRecorder()
+	req, err := newTestWebRPCRequest("Web.SetBucketPolicyDocument", authorization, &SetBucketPolicyDocumentArgs{BucketName: bucketName})
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if err = getTestWebRPCResponse(rec, &WebGenericRep{}); err != nil {
+		t.Fatalf("Removing the bucket policy should succeed but it didn't, %v", err)
+	}
+	if p := getPolicy(); p != "" {
+		t.Fatalf("Expected no bucket policy, found %s", p)
+	}
+}
+
+// Wrapper for calling GetBucketNotification and SetBucketNotification handlers
+func TestWebHandlerBucketNotification(t *testing.T) {
+	ExecObjectLayerTest(t, testWebBucketNotification)
+}
+
+// testWebBucketNotification - Test GetBucketNotification and SetBucketNotification web handlers
+func testWebBucketNotification(obj ObjectLayer, instanceType string, t TestErrHandler) {
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	// Register the API end points with XL/FS object layer.
+	apiRouter := initTestWebRPCEndPoint(obj)
+	credentials := globalServerConfig.GetCredential()
+
+	authorization, err := getWebRPCToken(apiRouter, credentials.AccessKey, credentials.SecretKey)
+	if err != nil {
+		t.Fatal("Cannot authenticate")
+	}
+
+	bucketName := getRandomBucketName()
+	if err = obj.MakeBucketWithLocation(context.Background(), bucketName, ""); err != nil {
+		t.Fatal("Unexpected error: ", err)
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := newTestWebRPCRequest("Web.GetBucketNotification", authorization, &GetBucketNotificationArgs{BucketName: bucketName})
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	getReply := &GetBucketNotificationRep{}
+	if err = getTestWebRPCResponse(rec, &getReply); err != nil {
+		t.Fatalf("GetBucketNotification should succeed but it didn't, %v", err)
+	}
+	if !strings.Contains(getReply.Config, "NotificationConfiguration") {
+		t.Fatalf("Expected an empty notification configuration, found %s", getReply.Config)
+	}
+
+	testCases := []struct {
+		bucketName string
+		config     string
+		pass       bool
+	}{
+		// Invalid bucket name
+		{"", getReply.Config, false},
+		// Non-existent bucket
+		{"nonexistent-bucket", getReply.Config, false},
+		// Malformed configuration
+		{bucketName, "<NotificationConfiguration>", false},
+		// Unknown target ARN
+		{bucketName, `<NotificationConfiguration><QueueConfiguration><Event>s3:ObjectCreated:*</Event><Queue>arn:minio:sqs:us-east-1:1:webhook</Queue></QueueConfiguration></NotificationConfiguration>`, false},
+		// Empty configuration
+		{bucketName, getReply.Config, true},
+	}
+
+	for i, testCase := range testCases {
+		rec = httptest.NewRecorder()
+		args := &SetBucketNotificationArgs{BucketName: testCase.bucketName, Config: testCase.config}
+		req, err = newTestWebRPCRequest("Web.SetBucketNotification", authorization, args)
+		if err != nil {
+			t.Fatalf("Test %d: Failed to create HTTP request: <ERROR> %v", i+1, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		err = getTestWebRPCResponse(rec, &WebGenericRep{})
+		if testCase.pass && err != nil {
+			t.Fatalf("Test %d: Should succeed but it didn't, %#v", i+1, err)
+		}
+		if !testCase.pass && err == nil {
+			t.Fatalf("Test %d: Should fail it didn't", i+1)
+		}
+	}
+
+	rec = httptest.NewRecorder()
+	req, err = newTestWebRPCRequest("Web.ListNotificationTargets", authorization, &WebGenericArgs{})
+	if err != nil {
+		t.Fatalf("Failed to create HTTP request: <ERROR> %v", err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if err = getTestWebRPCResponse(rec, &ListNotificationTargetsRep{}); err != nil {
+		t.Fatalf("ListNotificationTargets should succeed but it didn't, %v", err)
+	}
+}
+
 // TestWebCheckAuthorization - Test Authorization for all web handlers
 func TestWebCheckAuthorization(t *testing.T) {
 	// Prepare XL backend
@@ -1492,7 +1887,9 @@ func TestWebCheckAuthorization(t *testing.T) {
 		"ListBuckets", "ListObjects", "RemoveObject",
 		"GenerateAuth", "SetAuth",
 		"GetBucketPolicy", "SetBucketPolicy", "ListAllBucketPolicies",
-		"PresignedGet",
+		"GetBucketPolicyDocument", "SetBucketPolicyDocument",
+		"GetBucketNotification", "SetBucketNotification", "ListNotificationTargets",
+		"PresignedGet", "PresignedMultipart", "ListShareLinks", "RevokeShareLink",
 	}
 	for _, rpcCall := range webRPCs {
 		reply := &WebGenericRep{}
@@ -91,6 +91,10 @@ func registerWebRouter(router *mux.Router) error {
 	webBrowserRouter.Methods("GET").Path("/download/{bucket}/{object:.+}").Queries("token", "{token:.*}").HandlerFunc(httpTraceHdrs(web.Download))
 	webBrowserRouter.Methods("POST").Path("/zip").Queries("token", "{token:.*}").HandlerFunc(httpTraceHdrs(web.DownloadZip))
 
+	// Websocket upgrades need the raw connection, which the
+	// trace recorder does not provide.
+	webBrowserRouter.Methods("GET").Path("/events/{bucket}").Queries("token", "{token:.*}").HandlerFunc(web.Events)
+
 	// Create compressed assets handler
 	compressAssets := handlers.CompressHandler(http.StripPrefix(minioReservedBucketPath, http.FileServer(assetFS())))
 
@@ -0,0 +1,174 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/website"
+)
+
+const (
+	// Website configuration file.
+	bucketWebsiteConfig = "website.xml"
+)
+
+// WebsiteSys - Bucket website subsystem.
+type WebsiteSys struct {
+	sync.RWMutex
+	bucketWebsiteMap map[string]website.Website
+}
+
+// Set - sets website config to given bucket name.
+func (sys *WebsiteSys) Set(bucketName string, config website.Website) {
+	if globalIsGateway {
+		// no-op
+		return
+	}
+
+	sys.Lock()
+	defer sys.Unlock()
+
+	sys.bucketWebsiteMap[bucketName] = config
+}
+
+// Get - gets website config associated to a given bucket name.
+func (sys *WebsiteSys) Get(bucketName string) (config website.Website, ok bool) {
+	sys.RLock()
+	defer sys.RUnlock()
+
+	config, ok = sys.bucketWebsiteMap[bucketName]
+	return config, ok
+}
+
+// Remove - removes website config for given bucket name.
+func (sys *WebsiteSys) Remove(bucketName string) {
+	sys.Lock()
+	defer sys.Unlock()
+
+	delete(sys.bucketWebsiteMap, bucketName)
+}
+
+func saveWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucketName string, config *website.Website) error {
+	data, err := xml.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+	return saveConfig(ctx, objAPI, configFile, data)
+}
+
+// getWebsiteConfig - get website config for given bucket name.
+func getWebsiteConfig(objAPI ObjectLayer, bucketName string) (*website.Website, error) {
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+	configData, err := readConfig(context.Background(), objAPI, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			err = BucketWebsiteNotFound{Bucket: bucketName}
+		}
+		return nil, err
+	}
+
+	return website.ParseWebsiteConfig(bytes.NewReader(configData))
+}
+
+func removeWebsiteConfig(ctx context.Context, objAPI ObjectLayer, bucketName string) error {
+	// Construct path to website.xml for the given bucket.
+	configFile := path.Join(bucketConfigPrefix, bucketName, bucketWebsiteConfig)
+
+	if err := objAPI.DeleteObject(ctx, minioMetaBucket, configFile); err != nil {
+		if _, ok := err.(ObjectNotFound); ok {
+			return BucketWebsiteNotFound{Bucket: bucketName}
+		}
+		return err
+	}
+	return nil
+}
+
+// NewWebsiteSys - creates new website system.
+func NewWebsiteSys() *WebsiteSys {
+	return &WebsiteSys{
+		bucketWebsiteMap: make(map[string]website.Website),
+	}
+}
+
+// Init - initializes website system from website.xml of all buckets.
+func (sys *WebsiteSys) Init(buckets []BucketInfo, objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	// Website configuration is not supported in gateway mode.
+	if globalIsGateway {
+		return nil
+	}
+
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Initializing website configuration needs a retry mechanism
+	// for the following reasons:
+	//  - Read quorum is lost just after the initialization
+	//    of the object layer.
+	retryTimerCh := newRetryTimerSimple(doneCh)
+	for {
+		select {
+		case <-retryTimerCh:
+			// Load WebsiteSys once during boot.
+			if err := sys.load(buckets, objAPI); err != nil {
+				if err == errDiskNotFound ||
+					strings.Contains(err.Error(), InsufficientReadQuorum{}.Error()) ||
+					strings.Contains(err.Error(), InsufficientWriteQuorum{}.Error()) {
+					logger.Info("Waiting for website subsystem to be initialized..")
+					continue
+				}
+				return err
+			}
+			return nil
+		case <-globalOSSignalCh:
+			return fmt.Errorf("Initializing Website sub-system gracefully stopped")
+		}
+	}
+}
+
+// Loads website configuration for all buckets into WebsiteSys.
+func (sys *WebsiteSys) load(buckets []BucketInfo, objAPI ObjectLayer) error {
+	for _, bucket := range buckets {
+		config, err := getWebsiteConfig(objAPI, bucket.Name)
+		if err != nil {
+			if _, ok := err.(BucketWebsiteNotFound); ok {
+				sys.Remove(bucket.Name)
+				continue
+			}
+			return err
+		}
+
+		sys.Set(bucket.Name, *config)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"path"
+	"testing"
+)
+
+func TestWebsiteSysLoad(t *testing.T) {
+	obj, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+
+	ctx := context.Background()
+	for _, bucket := range []string{"website", "nowebsite"} {
+		if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	buckets, err := obj.ListBuckets(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Buckets without website configuration are skipped.
+	sys := NewWebsiteSys()
+	if err = sys.load(buckets, obj); err != nil {
+		t.Fatal(err)
+	}
+
+	// Other errors are returned, Init retries those caused by
+	// unavailable disks.
+	configFile := path.Join(bucketConfigPrefix, "website", bucketWebsiteConfig)
+	if err = saveConfig(ctx, obj, configFile, []byte("<WebsiteConfiguration>")); err != nil {
+		t.Fatal(err)
+	}
+	if err = sys.load(buckets, obj); err == nil {
+		t.Fatal("Expected the invalid website configuration to fail loading")
+	}
+}
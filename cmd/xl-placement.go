@@ -0,0 +1,87 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/minio/minio/cmd/config"
+	"github.com/minio/minio/cmd/config/placement"
+	"github.com/minio/minio/cmd/logger"
+)
+
+// The placement rules in effect are stored in config/placement.json.
+const xlPlacementFile = minioConfigPrefix + "/placement.json"
+
+// initXLPlacement - checks that the placement rules of the server are
+// the rules of the other servers, and that they don't move objects
+// written under the rules stored in the backend to other sets, before
+// storing them.
+func initXLPlacement(objAPI ObjectLayer) error {
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+
+	for addr, cfg := range globalNotificationSys.Placement() {
+		if !cfg.Equal(globalXLPlacement) {
+			return config.ErrXLPlacementMismatch(nil).Msg("%s differs from the value of server %s", placement.EnvXLPlacement, addr)
+		}
+	}
+
+	ctx := context.Background()
+
+	// Servers starting at the same time check the rules one after the other.
+	objLock := globalNSMutex.NewNSLock(ctx, minioMetaBucket, xlPlacementFile+".transaction")
+	if err := objLock.GetLock(globalOperationTimeout); err != nil {
+		return err
+	}
+	defer objLock.Unlock()
+
+	var prev placement.Config
+	data, err := readConfig(ctx, objAPI, xlPlacementFile)
+	if err != nil && err != errConfigNotFound {
+		return err
+	}
+	if err == nil {
+		if err = json.Unmarshal(data, &prev); err != nil {
+			return err
+		}
+	}
+	if prev.Equal(globalXLPlacement) {
+		return nil
+	}
+
+	for _, rule := range globalXLPlacement.Moved(prev) {
+		result, err := objAPI.ListObjects(ctx, rule.Bucket, rule.Prefix, "", "", 1)
+		if err != nil {
+			if _, ok := err.(BucketNotFound); ok {
+				continue
+			}
+			return err
+		}
+		if len(result.Objects) > 0 {
+			return config.ErrXLPlacementMoved(nil).Msg("%s: %s/%s holds objects placed by the previous rules", placement.EnvXLPlacement, rule.Bucket, rule.Prefix)
+		}
+	}
+
+	logger.Info("Placement rules changed, new objects of the pinned prefixes are placed according to the new rules")
+	if data, err = json.Marshal(globalXLPlacement); err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, xlPlacementFile, data)
+}
@@ -0,0 +1,89 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/cmd/config/placement"
+)
+
+func TestInitXLPlacement(t *testing.T) {
+	objLayer, fsDir, err := prepareFS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(fsDir)
+	if err = newTestConfig(globalMinioDefaultRegion, objLayer); err != nil {
+		t.Fatalf("unable initialize config file, %s", err)
+	}
+	globalNotificationSys = NewNotificationSys(globalServerConfig, EndpointList{})
+
+	defer func(cfg placement.Config) { globalXLPlacement = cfg }(globalXLPlacement)
+
+	ctx := context.Background()
+	if err = objLayer.MakeBucketWithLocation(ctx, "finance", ""); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("report")
+	if _, err = objLayer.PutObject(ctx, "finance", "eu/report.csv", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	rule := func(bucket, prefix string, set int) placement.Rule {
+		return placement.Rule{Bucket: bucket, Prefix: prefix, Set: set}
+	}
+	testCases := []struct {
+		rules       []placement.Rule
+		expectedErr bool
+	}{
+		// Objects written before a prefix is pinned are still found.
+		{[]placement.Rule{rule("finance", "eu/", 2)}, false},
+		{[]placement.Rule{rule("finance", "eu/", 2), rule("logs", "", 1)}, false},
+		// Rules of prefixes holding objects cannot be moved nor removed.
+		{[]placement.Rule{rule("finance", "eu/", 1), rule("logs", "", 1)}, true},
+		{[]placement.Rule{rule("logs", "", 1)}, true},
+		// Rules of empty prefixes can.
+		{[]placement.Rule{rule("finance", "eu/", 2), rule("logs", "", 2)}, false},
+		{[]placement.Rule{rule("finance", "eu/", 2)}, false},
+	}
+	for i, testCase := range testCases {
+		globalXLPlacement = placement.Config{Rules: testCase.rules}
+		err = initXLPlacement(objLayer)
+		if testCase.expectedErr != (err != nil) {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		data, err := readConfig(ctx, objLayer, xlPlacementFile)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var stored placement.Config
+		if err = json.Unmarshal(data, &stored); err != nil {
+			t.Fatal(err)
+		}
+		if !stored.Equal(globalXLPlacement) {
+			t.Fatalf("Test %d: expected the rules to be stored, got %v", i+1, stored)
+		}
+	}
+}
@@ -0,0 +1,564 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/hash"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+const (
+	// Decommission status of server pools.
+	poolsDecommissionConfig = "pools-decommission.json"
+
+	// Prefix of the locks serializing moving objects off a
+	// draining server pool with writes to the same objects.
+	decommissionLockPrefix = "decommission"
+)
+
+// Decommissioning errors.
+var (
+	errDecommissionPoolInvalid = AdminError{
+		Code:       "XMinioAdminDecommissionPoolInvalid",
+		Message:    "Server pool does not exist",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionInProgress = AdminError{
+		Code:       "XMinioAdminDecommissionInProgress",
+		Message:    "A server pool is already being decommissioned",
+		StatusCode: http.StatusConflict,
+	}
+	errDecommissionLastPool = AdminError{
+		Code:       "XMinioAdminDecommissionLastPool",
+		Message:    "The last server pool accepting writes cannot be decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	errDecommissionNotDraining = AdminError{
+		Code:       "XMinioAdminDecommissionNotDraining",
+		Message:    "Server pool is not being decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// xlServerPoolsDecommission - decommission status of all server pools,
+// indexed by pool. Objects are moved by the server local to the first
+// endpoint of the first server pool.
+type xlServerPoolsDecommission struct {
+	sync.RWMutex
+	status []madmin.PoolDecommissionStatus
+
+	// Stops moving objects, set while objects are moved.
+	cancel context.CancelFunc
+}
+
+func isPoolDraining(status madmin.PoolDecommissionStatus) bool {
+	return status.State == madmin.DecommissionDraining || status.State == madmin.DecommissionComplete
+}
+
+// isDraining - returns true if new objects are not written
+// to the server pool.
+func (z *xlServerPools) isDraining(index int) bool {
+	z.decom.RLock()
+	defer z.decom.RUnlock()
+	return isPoolDraining(z.decom.status[index])
+}
+
+// isDecommissioning - returns true if objects are being moved
+// off a server pool.
+func (z *xlServerPools) isDecommissioning() bool {
+	z.decom.RLock()
+	defer z.decom.RUnlock()
+	for _, status := range z.decom.status {
+		if status.State == madmin.DecommissionDraining {
+			return true
+		}
+	}
+	return false
+}
+
+// availablePools - returns the server pools new objects are written to.
+func (z *xlServerPools) availablePools() []int {
+	z.decom.RLock()
+	defer z.decom.RUnlock()
+	var available []int
+	for index, status := range z.decom.status {
+		if !isPoolDraining(status) {
+			available = append(available, index)
+		}
+	}
+	return available
+}
+
+// poolOrder - returns the order server pools are looked up in,
+// draining pools are looked up last such that objects written
+// to other pools in the meantime take precedence.
+func (z *xlServerPools) poolOrder() []int {
+	z.decom.RLock()
+	defer z.decom.RUnlock()
+	order := make([]int, 0, len(z.pools))
+	for index, status := range z.decom.status {
+		if !isPoolDraining(status) {
+			order = append(order, index)
+		}
+	}
+	for index, status := range z.decom.status {
+		if isPoolDraining(status) {
+			order = append(order, index)
+		}
+	}
+	return order
+}
+
+func (z *xlServerPools) newDecommissionLock(ctx context.Context, bucket, object string) RWLocker {
+	return z.nsMutex.NewNSLock(ctx, minioMetaBucket, pathJoin(decommissionLockPrefix, bucket, object))
+}
+
+// deleteDrainingObject - deletes an object just written to the server
+// pool at index from the server pools being decommissioned.
+func (z *xlServerPools) deleteDrainingObject(ctx context.Context, bucket, object string, index int) {
+	for i, pool := range z.pools {
+		if i == index || !z.isDraining(i) {
+			continue
+		}
+		if err := pool.DeleteObject(ctx, bucket, object); err != nil && !isErrObjectNotFound(err) {
+			logger.LogIf(ctx, err)
+		}
+	}
+}
+
+// isDecommissionLeader - returns true if objects are moved by this server.
+func (z *xlServerPools) isDecommissionLeader() bool {
+	return z.endpoints[0].Endpoints[0].IsLocal
+}
+
+// readDecommission - reads the decommission status of server pools,
+// status of server pools no longer part of the deployment is dropped.
+func (z *xlServerPools) readDecommission(ctx context.Context) ([]madmin.PoolDecommissionStatus, error) {
+	status := make([]madmin.PoolDecommissionStatus, len(z.pools))
+
+	configFile := pathJoin(minioConfigPrefix, poolsDecommissionConfig)
+	data, err := readConfig(ctx, z, configFile)
+	if err != nil {
+		if err == errConfigNotFound {
+			return status, nil
+		}
+		return nil, err
+	}
+
+	var saved []madmin.PoolDecommissionStatus
+	if err = json.Unmarshal(data, &saved); err != nil {
+		return nil, err
+	}
+	for _, s := range saved {
+		for index, pool := range z.endpoints {
+			if pool.Endpoints[0].String() == s.Endpoint {
+				s.Pool = index + 1
+				status[index] = s
+			}
+		}
+	}
+	return status, nil
+}
+
+// saveDecommission - saves the decommission status of server pools.
+func (z *xlServerPools) saveDecommission(ctx context.Context, status []madmin.PoolDecommissionStatus) error {
+	var saved []madmin.PoolDecommissionStatus
+	for _, s := range status {
+		if s.State != "" {
+			saved = append(saved, s)
+		}
+	}
+	data, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, z, pathJoin(minioConfigPrefix, poolsDecommissionConfig), data)
+}
+
+// loadDecommission - loads the decommission status of server pools,
+// starts or stops moving objects on the decommission leader.
+func (z *xlServerPools) loadDecommission(ctx context.Context) error {
+	status, err := z.readDecommission(ctx)
+	if err != nil {
+		return err
+	}
+
+	z.decom.Lock()
+	defer z.decom.Unlock()
+	z.decom.status = status
+
+	if !z.isDecommissionLeader() {
+		return nil
+	}
+
+	draining := -1
+	for index, s := range status {
+		if s.State == madmin.DecommissionDraining {
+			draining = index
+		}
+	}
+	if draining == -1 && z.decom.cancel != nil {
+		z.decom.cancel()
+		z.decom.cancel = nil
+	}
+	if draining != -1 && z.decom.cancel == nil {
+		var decomCtx context.Context
+		decomCtx, z.decom.cancel = context.WithCancel(context.Background())
+		go z.decommissionPool(decomCtx, draining)
+	}
+	return nil
+}
+
+// updateDecommission - updates the decommission status of the server
+// pool at index with fn, saves it and notifies all peers.
+func (z *xlServerPools) updateDecommission(ctx context.Context, index int, fn func(*madmin.PoolDecommissionStatus) error) error {
+	status, err := z.readDecommission(ctx)
+	if err != nil {
+		return err
+	}
+	if err = fn(&status[index]); err != nil {
+		return err
+	}
+	if err = z.saveDecommission(ctx, status); err != nil {
+		return err
+	}
+
+	// Notify all other MinIO peers to reload the decommission status.
+	if globalNotificationSys != nil {
+		for _, nerr := range globalNotificationSys.LoadPoolsDecommission() {
+			if nerr.Err != nil {
+				logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+				logger.LogIf(ctx, nerr.Err)
+			}
+		}
+	}
+	return nil
+}
+
+// Decommission - starts decommissioning the server pool, pools are
+// numbered from 1 in the order of the command line.
+func (z *xlServerPools) Decommission(ctx context.Context, pool int) error {
+	if pool < 1 || pool > len(z.pools) {
+		return errDecommissionPoolInvalid
+	}
+	index := pool - 1
+
+	status, err := z.readDecommission(ctx)
+	if err != nil {
+		return err
+	}
+	available := 0
+	for i, s := range status {
+		if s.State == madmin.DecommissionDraining {
+			return errDecommissionInProgress
+		}
+		if i != index && !isPoolDraining(s) {
+			available++
+		}
+	}
+	if isPoolDraining(status[index]) {
+		return errDecommissionInProgress
+	}
+	if available == 0 {
+		return errDecommissionLastPool
+	}
+
+	err = z.updateDecommission(ctx, index, func(s *madmin.PoolDecommissionStatus) error {
+		*s = madmin.PoolDecommissionStatus{
+			Pool:      pool,
+			Endpoint:  z.endpoints[index].Endpoints[0].String(),
+			State:     madmin.DecommissionDraining,
+			StartTime: UTCNow(),
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return z.loadDecommission(ctx)
+}
+
+// CancelDecommission - stops decommissioning the server pool,
+// new objects are written to the pool again.
+func (z *xlServerPools) CancelDecommission(ctx context.Context, pool int) error {
+	if pool < 1 || pool > len(z.pools) {
+		return errDecommissionPoolInvalid
+	}
+
+	err := z.updateDecommission(ctx, pool-1, func(s *madmin.PoolDecommissionStatus) error {
+		if s.State != madmin.DecommissionDraining {
+			return errDecommissionNotDraining
+		}
+		s.State = madmin.DecommissionCanceled
+		s.EndTime = UTCNow()
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return z.loadDecommission(ctx)
+}
+
+// DecommissionStatus - returns the status of all server
+// pools which are or were decommissioned.
+func (z *xlServerPools) DecommissionStatus(ctx context.Context) ([]madmin.PoolDecommissionStatus, error) {
+	status, err := z.readDecommission(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := []madmin.PoolDecommissionStatus{}
+	for _, s := range status {
+		if s.State != "" {
+			result = append(result, s)
+		}
+	}
+	return result, nil
+}
+
+// decommissionProgress - objects moved since the last status update.
+type decommissionProgress struct {
+	objectsMoved  int64
+	bytesMoved    int64
+	objectsFailed int64
+	lastErr       error
+}
+
+// saveDecommissionProgress - adds progress to the decommission status
+// of the server pool, and completes decommissioning with state if set.
+func (z *xlServerPools) saveDecommissionProgress(ctx context.Context, index int, progress decommissionProgress, state madmin.DecommissionState) error {
+	return z.updateDecommission(ctx, index, func(s *madmin.PoolDecommissionStatus) error {
+		// Decommissioning was canceled in the meantime.
+		if s.State != madmin.DecommissionDraining {
+			return nil
+		}
+		s.ObjectsMoved += progress.objectsMoved
+		s.BytesMoved += progress.bytesMoved
+		s.ObjectsFailed += progress.objectsFailed
+		if progress.lastErr != nil {
+			s.Error = progress.lastErr.Error()
+		}
+		if state != "" {
+			s.State = state
+			s.EndTime = UTCNow()
+		}
+		return nil
+	})
+}
+
+// decommissionPool - moves all objects off the server pool at index,
+// until a listing of the pool returns no more objects.
+func (z *xlServerPools) decommissionPool(ctx context.Context, index int) {
+	defer func() {
+		// Canceled movers are already replaced by loadDecommission.
+		z.decom.Lock()
+		if ctx.Err() == nil {
+			z.decom.cancel()
+			z.decom.cancel = nil
+		}
+		z.decom.Unlock()
+		logger.LogIf(context.Background(), z.loadDecommission(context.Background()))
+	}()
+
+	pool := z.pools[index]
+
+	type listTarget struct {
+		bucket, prefix string
+	}
+
+	for {
+		// Configuration of the deployment and of buckets is
+		// moved along with the objects.
+		targets := []listTarget{
+			{minioMetaBucket, minioConfigPrefix},
+			{minioMetaBucket, bucketConfigPrefix},
+		}
+		buckets, err := pool.ListBuckets(ctx)
+		if err != nil {
+			logger.LogIf(ctx, z.saveDecommissionProgress(ctx, index, decommissionProgress{lastErr: err}, madmin.DecommissionFailed))
+			return
+		}
+		for _, bucket := range buckets {
+			targets = append(targets, listTarget{bucket: bucket.Name})
+		}
+
+		var listed, moved int64
+		for _, target := range targets {
+			marker := ""
+			for {
+				if ctx.Err() != nil {
+					return
+				}
+
+				loi, err := pool.ListObjects(ctx, target.bucket, target.prefix, marker, "", maxObjectList)
+				if err != nil {
+					logger.LogIf(ctx, z.saveDecommissionProgress(ctx, index, decommissionProgress{lastErr: err}, madmin.DecommissionFailed))
+					return
+				}
+
+				var progress decommissionProgress
+				for _, object := range loi.Objects {
+					listed++
+					size, err := z.decommissionObject(ctx, index, target.bucket, object.Name)
+					if err != nil {
+						logger.LogIf(ctx, err)
+						progress.objectsFailed++
+						progress.lastErr = err
+						continue
+					}
+					progress.objectsMoved++
+					progress.bytesMoved += size
+				}
+				moved += progress.objectsMoved
+				if len(loi.Objects) > 0 {
+					logger.LogIf(ctx, z.saveDecommissionProgress(ctx, index, progress, ""))
+				}
+
+				if !loi.IsTruncated {
+					break
+				}
+				marker = loi.NextMarker
+			}
+		}
+
+		switch {
+		case listed == 0:
+			logger.LogIf(ctx, z.saveDecommissionProgress(ctx, index, decommissionProgress{}, madmin.DecommissionComplete))
+			return
+		case moved == 0:
+			err = fmt.Errorf("Unable to move %d objects off server pool %d", listed, index+1)
+			logger.LogIf(ctx, z.saveDecommissionProgress(ctx, index, decommissionProgress{lastErr: err}, madmin.DecommissionFailed))
+			return
+		}
+	}
+}
+
+// decommissionObject - moves an object off the server pool at index
+// to the hashed server pool, returns the number of bytes moved.
+func (z *xlServerPools) decommissionObject(ctx context.Context, index int, bucket, object string) (int64, error) {
+	objectLock := z.newDecommissionLock(ctx, bucket, object)
+	if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+		return 0, err
+	}
+	defer objectLock.Unlock()
+
+	pool := z.pools[index]
+	srcInfo, err := pool.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		// Object was removed in the meantime.
+		if isErrObjectNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+
+	target := z.pools[z.getHashedPoolIndex(object)]
+
+	// Objects are moved as stored, with the same parts, such that
+	// encrypted and compressed objects remain readable.
+	var objInfo ObjectInfo
+	if len(srcInfo.Parts) > 1 {
+		objInfo, err = decommissionMultipartObject(ctx, pool, target, bucket, object, srcInfo)
+	} else {
+		actualSize := srcInfo.Size
+		if len(srcInfo.Parts) == 1 && srcInfo.Parts[0].ActualSize > 0 {
+			actualSize = srcInfo.Parts[0].ActualSize
+		}
+		var reader *PutObjReader
+		var closer io.Closer
+		reader, closer, err = newDecommissionReader(ctx, pool, bucket, object, srcInfo.ETag, 0, srcInfo.Size, actualSize)
+		if err != nil {
+			return 0, err
+		}
+		objInfo, err = target.PutObject(ctx, bucket, object, reader, ObjectOptions{UserDefined: srcInfo.UserDefined})
+		closer.Close()
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	// Keep the ETag of the object, the ETag of objects written again
+	// may differ, for example of objects uploaded in multiple parts.
+	if !hasSuffix(object, SlashSeparator) {
+		objInfo.ETag = srcInfo.ETag
+		objInfo.metadataOnly = true
+		if _, err = target.CopyObject(ctx, bucket, object, bucket, object, objInfo, ObjectOptions{}, ObjectOptions{}); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = pool.DeleteObject(ctx, bucket, object); err != nil && !isErrObjectNotFound(err) {
+		return 0, err
+	}
+	return srcInfo.Size, nil
+}
+
+// newDecommissionReader - streams length bytes at offset of an object
+// as stored on a server pool, the returned closer stops reading.
+func newDecommissionReader(ctx context.Context, pool ObjectLayer, bucket, object, etag string, offset, length, actualSize int64) (*PutObjReader, io.Closer, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(pool.GetObject(ctx, bucket, object, offset, length, pw, etag, ObjectOptions{}))
+	}()
+	hashReader, err := hash.NewReader(pr, length, "", "", actualSize, false)
+	if err != nil {
+		pr.Close()
+		return nil, nil, err
+	}
+	return NewPutObjReader(hashReader, nil, nil), pr, nil
+}
+
+// decommissionMultipartObject - moves an object uploaded in multiple parts.
+func decommissionMultipartObject(ctx context.Context, pool, target ObjectLayer, bucket, object string, srcInfo ObjectInfo) (objInfo ObjectInfo, err error) {
+	uploadID, err := target.NewMultipartUpload(ctx, bucket, object, ObjectOptions{UserDefined: srcInfo.UserDefined})
+	if err != nil {
+		return objInfo, err
+	}
+	defer func() {
+		if err != nil {
+			target.AbortMultipartUpload(ctx, bucket, object, uploadID)
+		}
+	}()
+
+	var offset int64
+	var parts []CompletePart
+	for _, part := range srcInfo.Parts {
+		var reader *PutObjReader
+		var closer io.Closer
+		reader, closer, err = newDecommissionReader(ctx, pool, bucket, object, srcInfo.ETag, offset, part.Size, part.ActualSize)
+		if err != nil {
+			return objInfo, err
+		}
+		var partInfo PartInfo
+		partInfo, err = target.PutObjectPart(ctx, bucket, object, uploadID, part.Number, reader, ObjectOptions{})
+		closer.Close()
+		if err != nil {
+			return objInfo, err
+		}
+		parts = append(parts, CompletePart{PartNumber: part.Number, ETag: partInfo.ETag})
+		offset += part.Size
+	}
+
+	return target.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ObjectOptions{})
+}
@@ -0,0 +1,162 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/pkg/madmin"
+)
+
+// Tests moving all objects off a decommissioned server pool.
+func TestXLServerPoolsDecommission(t *testing.T) {
+	ctx := context.Background()
+
+	var pools []PoolEndpoints
+	for i := 0; i < 2; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(disks)
+		pools = append(pools, PoolEndpoints{
+			SetCount:     1,
+			DrivesPerSet: 4,
+			Endpoints:    mustGetNewEndpointList(disks...),
+		})
+	}
+
+	// Storage class defaults depend on the drives per set.
+	defer func(setCount, driveCount int) {
+		globalXLSetCount, globalXLSetDriveCount = setCount, driveCount
+	}(globalXLSetCount, globalXLSetDriveCount)
+	globalXLSetCount, globalXLSetDriveCount = 2, 4
+
+	obj, err := newXLServerPools(pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	z := obj.(*xlServerPools)
+
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	objects := map[string]ObjectInfo{}
+	for _, object := range []string{"a", "b", "c", "d", "e", "f", "g", "h", "dir/i", "dir/j"} {
+		data := []byte(object)
+		objInfo, err := z.pools[0].PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		objects[object] = objInfo
+	}
+
+	// Objects uploaded in multiple parts keep their parts.
+	uploadID, err := z.pools[0].NewMultipartUpload(ctx, "bucket", "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i, size := range []int64{5 * humanize.MiByte, humanize.KiByte} {
+		data := bytes.Repeat([]byte{byte('a' + i)}, int(size))
+		partInfo, err := z.pools[0].PutObjectPart(ctx, "bucket", "multipart", uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(data), size, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: partInfo.PartNumber, ETag: partInfo.ETag})
+	}
+	objInfo, err := z.pools[0].CompleteMultipartUpload(ctx, "bucket", "multipart", uploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	objects["multipart"] = objInfo
+	for object := range objects {
+		if objects[object], err = z.pools[0].GetObjectInfo(ctx, "bucket", object, ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = z.CancelDecommission(ctx, 1); err != errDecommissionNotDraining {
+		t.Fatalf("Expected %v, got %v", errDecommissionNotDraining, err)
+	}
+	if err = z.Decommission(ctx, 3); err != errDecommissionPoolInvalid {
+		t.Fatalf("Expected %v, got %v", errDecommissionPoolInvalid, err)
+	}
+	if err = z.Decommission(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.Decommission(ctx, 2); err != errDecommissionInProgress {
+		t.Fatalf("Expected %v, got %v", errDecommissionInProgress, err)
+	}
+
+	var status []madmin.PoolDecommissionStatus
+	for i := 0; i < 100; i++ {
+		status, err = z.DecommissionStatus(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(status) == 1 && status[0].State != madmin.DecommissionDraining {
+			break
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	if len(status) != 1 || status[0].State != madmin.DecommissionComplete || status[0].Pool != 1 {
+		t.Fatalf("Unexpected decommission status %+v", status)
+	}
+	// Configuration stored on the server pool is moved as well.
+	if status[0].ObjectsMoved < int64(len(objects)) || status[0].ObjectsFailed != 0 {
+		t.Fatalf("Expected at least %d objects to be moved, got %+v", len(objects), status[0])
+	}
+
+	// All objects are moved to the second server pool.
+	loi, err := z.pools[0].ListObjects(ctx, "bucket", "", "", "", maxObjectList)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 0 {
+		t.Fatalf("Expected no objects on the decommissioned server pool, got %d", len(loi.Objects))
+	}
+	for object, expected := range objects {
+		objInfo, err := z.pools[1].GetObjectInfo(ctx, "bucket", object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if objInfo.ETag != expected.ETag || objInfo.Size != expected.Size || len(objInfo.Parts) != len(expected.Parts) {
+			t.Fatalf("Object %s: expected %+v, got %+v", object, expected, objInfo)
+		}
+	}
+
+	// New objects are not written to the decommissioned server pool.
+	data := []byte("new")
+	if _, err = obj.PutObject(ctx, "bucket", "a", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.pools[0].GetObjectInfo(ctx, "bucket", "a", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not to be written to the decommissioned server pool, got %v", err)
+	}
+	if err = z.Decommission(ctx, 2); err != errDecommissionLastPool {
+		t.Fatalf("Expected %v, got %v", errDecommissionLastPool, err)
+	}
+}
@@ -0,0 +1,794 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/minio/minio/pkg/lifecycle"
+	"github.com/minio/minio/pkg/madmin"
+	"github.com/minio/minio/pkg/policy"
+	"github.com/minio/minio/pkg/sync/errgroup"
+)
+
+// xlServerPools - implements ObjectLayer combining several server pools,
+// i.e. independently formatted erasure coded sets of sets. Pools are
+// added to an existing deployment to expand its capacity, objects are
+// kept on the pool they were written to and new objects are placed on
+// a pool hashed from the object name.
+type xlServerPools struct {
+	pools     []ObjectLayer
+	endpoints []PoolEndpoints
+
+	// Serializes moving objects off a draining server pool
+	// with writes to the same objects.
+	nsMutex *nsLockMap
+
+	// Decommission state of all server pools.
+	decom xlServerPoolsDecommission
+}
+
+// newXLServerPools - initializes the object layer of all server pools,
+// formatting pools added to an existing deployment.
+func newXLServerPools(pools []PoolEndpoints) (ObjectLayer, error) {
+	z := &xlServerPools{
+		endpoints: pools,
+		nsMutex:   newNSLock(globalIsDistXL),
+	}
+	z.decom.status = make([]madmin.PoolDecommissionStatus, len(pools))
+	var deploymentID string
+	for i, pool := range pools {
+		format, err := waitForFormatXL(context.Background(), pool.Endpoints[0].IsLocal, pool.Endpoints, pool.SetCount, pool.DrivesPerSet)
+		if err != nil {
+			return nil, err
+		}
+		if deploymentID == "" {
+			deploymentID = format.ID
+		} else if format.ID != deploymentID {
+			return nil, fmt.Errorf("Server pool %d belongs to deployment %s, expected %s", i+1, format.ID, deploymentID)
+		}
+		objLayer, err := newXLSets(pool.Endpoints, format, len(format.XL.Sets), len(format.XL.Sets[0]))
+		if err != nil {
+			return nil, err
+		}
+		z.pools = append(z.pools, objLayer)
+	}
+	globalDeploymentID = deploymentID
+
+	// Buckets created before a pool was added are created on it.
+	if err := z.syncBuckets(context.Background()); err != nil {
+		z.Shutdown(context.Background())
+		return nil, err
+	}
+
+	// Resume decommissioning server pools across restarts.
+	logger.LogIf(context.Background(), z.loadDecommission(context.Background()))
+	return z, nil
+}
+
+// syncBuckets - creates buckets which are missing on some of the pools.
+func (z *xlServerPools) syncBuckets(ctx context.Context) error {
+	poolBuckets := make([]map[string]struct{}, len(z.pools))
+	allBuckets := make(map[string]struct{})
+	for i, pool := range z.pools {
+		buckets, err := pool.ListBuckets(ctx)
+		if err != nil {
+			return err
+		}
+		poolBuckets[i] = make(map[string]struct{})
+		for _, bucket := range buckets {
+			poolBuckets[i][bucket.Name] = struct{}{}
+			allBuckets[bucket.Name] = struct{}{}
+		}
+	}
+	for i, pool := range z.pools {
+		for bucket := range allBuckets {
+			if _, ok := poolBuckets[i][bucket]; ok {
+				continue
+			}
+			if err := pool.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+				if _, ok := err.(BucketExists); !ok {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// getHashedPoolIndex - returns the pool new objects are placed on,
+// server pools being decommissioned are skipped.
+func (z *xlServerPools) getHashedPoolIndex(object string) int {
+	available := z.availablePools()
+	return available[crcHashMod(object, len(available))]
+}
+
+// getObjectPoolIndex - returns the pool the object is stored on.
+func (z *xlServerPools) getObjectPoolIndex(ctx context.Context, bucket, object string) (int, error) {
+	var err error
+	for _, index := range z.poolOrder() {
+		_, err = z.pools[index].GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+		if !isErrObjectNotFound(err) {
+			return index, err
+		}
+	}
+	return -1, err
+}
+
+// getPoolIndex - returns the pool the object is written to, which is
+// the pool the object is stored on unless that pool is decommissioned,
+// otherwise the hashed pool.
+func (z *xlServerPools) getPoolIndex(ctx context.Context, bucket, object string) (int, error) {
+	if len(z.pools) == 1 {
+		return 0, nil
+	}
+	index, err := z.getObjectPoolIndex(ctx, bucket, object)
+	if err != nil && !isErrObjectNotFound(err) {
+		return -1, err
+	}
+	if err == nil && !z.isDraining(index) {
+		return index, nil
+	}
+	return z.getHashedPoolIndex(object), nil
+}
+
+// getUploadPoolIndex - returns the pool holding the multipart upload.
+func (z *xlServerPools) getUploadPoolIndex(ctx context.Context, bucket, object, uploadID string) (int, error) {
+	if len(z.pools) == 1 {
+		return 0, nil
+	}
+	for i, pool := range z.pools {
+		_, err := pool.ListObjectParts(ctx, bucket, object, uploadID, 0, 1, ObjectOptions{})
+		if err == nil {
+			return i, nil
+		}
+		if _, ok := err.(InvalidUploadID); !ok {
+			return -1, err
+		}
+	}
+	return -1, InvalidUploadID{Bucket: bucket, Object: object, UploadID: uploadID}
+}
+
+// StorageInfo - combines output of StorageInfo across all server pools.
+func (z *xlServerPools) StorageInfo(ctx context.Context) StorageInfo {
+	var storageInfo StorageInfo
+	storageInfo.Backend.Type = BackendErasure
+
+	storageInfos := make([]StorageInfo, len(z.pools))
+	g := errgroup.WithNErrs(len(z.pools))
+	for index := range z.pools {
+		index := index
+		g.Go(func() error {
+			storageInfos[index] = z.pools[index].StorageInfo(ctx)
+			return nil
+		}, index)
+	}
+
+	// Wait for the go routines.
+	g.Wait()
+
+	for _, lstorageInfo := range storageInfos {
+		storageInfo.Used += lstorageInfo.Used
+		storageInfo.Total += lstorageInfo.Total
+		storageInfo.Available += lstorageInfo.Available
+		storageInfo.Backend.OnlineDisks += lstorageInfo.Backend.OnlineDisks
+		storageInfo.Backend.OfflineDisks += lstorageInfo.Backend.OfflineDisks
+		storageInfo.Backend.Sets = append(storageInfo.Backend.Sets, lstorageInfo.Backend.Sets...)
+	}
+	storageInfo.Backend.StandardSCData = storageInfos[0].Backend.StandardSCData
+	storageInfo.Backend.StandardSCParity = storageInfos[0].Backend.StandardSCParity
+	storageInfo.Backend.RRSCData = storageInfos[0].Backend.RRSCData
+	storageInfo.Backend.RRSCParity = storageInfos[0].Backend.RRSCParity
+
+	return storageInfo
+}
+
+// Shutdown shutsdown all server pools in parallel
+// returns error upon first error.
+func (z *xlServerPools) Shutdown(ctx context.Context) error {
+	g := errgroup.WithNErrs(len(z.pools))
+	for index := range z.pools {
+		index := index
+		g.Go(func() error {
+			return z.pools[index].Shutdown(ctx)
+		}, index)
+	}
+
+	for _, err := range g.Wait() {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MakeBucketWithLocation - creates a new bucket on all server pools,
+// the bucket is removed again from all pools if one of them fails.
+func (z *xlServerPools) MakeBucketWithLocation(ctx context.Context, bucket, location string) error {
+	g := errgroup.WithNErrs(len(z.pools))
+	for index := range z.pools {
+		index := index
+		g.Go(func() error {
+			return z.pools[index].MakeBucketWithLocation(ctx, bucket, location)
+		}, index)
+	}
+
+	errs := g.Wait()
+	for _, err := range errs {
+		if err != nil {
+			for index := range z.pools {
+				if errs[index] == nil {
+					z.pools[index].DeleteBucket(context.Background(), bucket, false)
+				}
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// GetBucketInfo - returns bucket info from the first server pool.
+func (z *xlServerPools) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
+	return z.pools[0].GetBucketInfo(ctx, bucket)
+}
+
+// BucketExists - returns true if the bucket exists on the first
+// server pool.
+func (z *xlServerPools) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	return bucketExists(ctx, z.pools[0], bucket)
+}
+
+// ListBuckets - lists buckets of the first server pool, buckets
+// are created on all pools.
+func (z *xlServerPools) ListBuckets(ctx context.Context) (buckets []BucketInfo, err error) {
+	return z.pools[0].ListBuckets(ctx)
+}
+
+// DeleteBucket - deletes a bucket on all server pools.
+func (z *xlServerPools) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
+	// Check all pools first, such that the bucket is not
+	// removed from some pools only.
+	for _, pool := range z.pools {
+		if forceDelete {
+			break
+		}
+		if empty, err := isBucketEmpty(ctx, pool, bucket); err != nil {
+			return err
+		} else if !empty {
+			return BucketNotEmpty{Bucket: bucket}
+		}
+	}
+
+	for _, pool := range z.pools {
+		if err := pool.DeleteBucket(ctx, bucket, forceDelete); err != nil {
+			if _, ok := err.(BucketNotFound); !ok {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isBucketEmpty - returns true if bucket has no objects.
+func isBucketEmpty(ctx context.Context, objAPI ObjectLayer, bucket string) (bool, error) {
+	loi, err := objAPI.ListObjects(ctx, bucket, "", "", "", 1)
+	if err != nil {
+		return false, err
+	}
+	return len(loi.Objects) == 0 && len(loi.Prefixes) == 0, nil
+}
+
+// mergeListObjects - merges the listings of all pools, sorted by name,
+// keeping up to maxKeys objects and prefixes.
+func mergeListObjects(results []ListObjectsInfo, maxKeys int) (loi ListObjectsInfo) {
+	type listEntry struct {
+		name   string
+		object *ObjectInfo
+	}
+
+	seen := make(map[string]struct{})
+	var entries []listEntry
+	for i := range results {
+		loi.IsTruncated = loi.IsTruncated || results[i].IsTruncated
+		for j := range results[i].Objects {
+			object := &results[i].Objects[j]
+			if _, ok := seen[object.Name]; ok {
+				continue
+			}
+			seen[object.Name] = struct{}{}
+			entries = append(entries, listEntry{name: object.Name, object: object})
+		}
+		for _, prefix := range results[i].Prefixes {
+			if _, ok := seen[prefix]; ok {
+				continue
+			}
+			seen[prefix] = struct{}{}
+			entries = append(entries, listEntry{name: prefix})
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].name < entries[j].name
+	})
+
+	if len(entries) > maxKeys {
+		entries = entries[:maxKeys]
+		loi.IsTruncated = true
+	}
+	for _, entry := range entries {
+		if entry.object != nil {
+			loi.Objects = append(loi.Objects, *entry.object)
+		} else {
+			loi.Prefixes = append(loi.Prefixes, entry.name)
+		}
+	}
+	if loi.IsTruncated && len(entries) > 0 {
+		loi.NextMarker = entries[len(entries)-1].name
+	}
+	return loi
+}
+
+func (z *xlServerPools) listObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int, heal bool) (loi ListObjectsInfo, err error) {
+	if len(z.pools) == 1 {
+		if heal {
+			return z.pools[0].ListObjectsHeal(ctx, bucket, prefix, marker, delimiter, maxKeys)
+		}
+		return z.pools[0].ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	}
+
+	// With max keys of zero we have reached eof, return right here.
+	if maxKeys == 0 {
+		return loi, nil
+	}
+
+	// Over flowing count - reset to maxObjectList.
+	if maxKeys < 0 || maxKeys > maxObjectList {
+		maxKeys = maxObjectList
+	}
+
+	results := make([]ListObjectsInfo, len(z.pools))
+	for i, pool := range z.pools {
+		if heal {
+			results[i], err = pool.ListObjectsHeal(ctx, bucket, prefix, marker, delimiter, maxKeys)
+		} else {
+			results[i], err = pool.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+		}
+		if err != nil {
+			return loi, err
+		}
+	}
+	return mergeListObjects(results, maxKeys), nil
+}
+
+// ListObjects - lists objects of all server pools.
+func (z *xlServerPools) ListObjects(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (loi ListObjectsInfo, err error) {
+	return z.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, false)
+}
+
+// ListObjectsV2 lists all objects in bucket filtered by prefix
+func (z *xlServerPools) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
+	marker := continuationToken
+	if marker == "" {
+		marker = startAfter
+	}
+
+	loi, err := z.ListObjects(ctx, bucket, prefix, marker, delimiter, maxKeys)
+	if err != nil {
+		return result, err
+	}
+
+	listObjectsV2Info := ListObjectsV2Info{
+		IsTruncated:           loi.IsTruncated,
+		ContinuationToken:     continuationToken,
+		NextContinuationToken: loi.NextMarker,
+		Objects:               loi.Objects,
+		Prefixes:              loi.Prefixes,
+	}
+	return listObjectsV2Info, err
+}
+
+// --- Object Operations ---
+
+// GetObjectNInfo - returns object info and locked object ReadCloser
+// from the server pool the object is stored on.
+func (z *xlServerPools) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
+	for _, index := range z.poolOrder() {
+		gr, err = z.pools[index].GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+		if !isErrObjectNotFound(err) {
+			return gr, err
+		}
+	}
+	return gr, err
+}
+
+// GetObject - reads an object from the server pool it is stored on.
+func (z *xlServerPools) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error) {
+	for _, index := range z.poolOrder() {
+		err = z.pools[index].GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+		if !isErrObjectNotFound(err) {
+			return err
+		}
+	}
+	return err
+}
+
+// GetObjectInfo - reads object metadata from the server pool it is stored on.
+func (z *xlServerPools) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	for _, index := range z.poolOrder() {
+		objInfo, err = z.pools[index].GetObjectInfo(ctx, bucket, object, opts)
+		if !isErrObjectNotFound(err) {
+			return objInfo, err
+		}
+	}
+	return objInfo, err
+}
+
+// PutObject - writes an object to the server pool it is stored on,
+// new objects are written to the hashed server pool.
+func (z *xlServerPools) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	if z.isDecommissioning() {
+		objectLock := z.newDecommissionLock(ctx, bucket, object)
+		if err := objectLock.GetLock(globalObjectTimeout); err != nil {
+			return ObjectInfo{}, err
+		}
+		defer objectLock.Unlock()
+	}
+
+	index, err := z.getPoolIndex(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	objInfo, err := z.pools[index].PutObject(ctx, bucket, object, data, opts)
+	if err != nil {
+		return objInfo, err
+	}
+	z.deleteDrainingObject(ctx, bucket, object, index)
+	return objInfo, nil
+}
+
+// DeleteObject - deletes an object from all server pools it is stored on.
+func (z *xlServerPools) DeleteObject(ctx context.Context, bucket string, object string) (err error) {
+	if z.isDecommissioning() {
+		objectLock := z.newDecommissionLock(ctx, bucket, object)
+		if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+			return err
+		}
+		defer objectLock.Unlock()
+	}
+
+	found := false
+	for _, pool := range z.pools {
+		err = pool.DeleteObject(ctx, bucket, object)
+		if isErrObjectNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		found = true
+	}
+	if found {
+		return nil
+	}
+	return err
+}
+
+// DeleteObjects - bulk delete of objects, the error response
+// of each delete is returned.
+func (z *xlServerPools) DeleteObjects(ctx context.Context, bucket string, objects []string) ([]error, error) {
+	if len(z.pools) == 1 {
+		return z.pools[0].DeleteObjects(ctx, bucket, objects)
+	}
+	errs := make([]error, len(objects))
+	for i, object := range objects {
+		errs[i] = z.DeleteObject(ctx, bucket, object)
+	}
+	return errs, nil
+}
+
+// CopyObject - copies objects from one server pool to another, on server side.
+func (z *xlServerPools) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if len(z.pools) == 1 {
+		return z.pools[0].CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
+	}
+
+	// Metadata updates are done on the server pool storing the object.
+	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
+	if cpSrcDstSame && srcInfo.metadataOnly {
+		index, err := z.getObjectPoolIndex(ctx, srcBucket, srcObject)
+		if err != nil {
+			return objInfo, err
+		}
+		return z.pools[index].CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
+	}
+
+	putOpts := ObjectOptions{ServerSideEncryption: dstOpts.ServerSideEncryption, UserDefined: srcInfo.UserDefined}
+	return z.PutObject(ctx, destBucket, destObject, srcInfo.PutObjReader, putOpts)
+}
+
+// ListMultipartUploads - lists multipart uploads of all server pools.
+func (z *xlServerPools) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
+	if len(z.pools) == 1 {
+		return z.pools[0].ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	}
+
+	for _, pool := range z.pools {
+		poolResult, err := pool.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+		if err != nil {
+			return result, err
+		}
+		result.KeyMarker = poolResult.KeyMarker
+		result.UploadIDMarker = poolResult.UploadIDMarker
+		result.MaxUploads = poolResult.MaxUploads
+		result.Prefix = poolResult.Prefix
+		result.Delimiter = poolResult.Delimiter
+		result.IsTruncated = result.IsTruncated || poolResult.IsTruncated
+		result.Uploads = append(result.Uploads, poolResult.Uploads...)
+	}
+
+	sort.Slice(result.Uploads, func(i, j int) bool {
+		if result.Uploads[i].Object == result.Uploads[j].Object {
+			return result.Uploads[i].Initiated.Before(result.Uploads[j].Initiated)
+		}
+		return result.Uploads[i].Object < result.Uploads[j].Object
+	})
+	if maxUploads >= 0 && len(result.Uploads) > maxUploads {
+		result.Uploads = result.Uploads[:maxUploads]
+		result.IsTruncated = true
+	}
+	if result.IsTruncated && len(result.Uploads) > 0 {
+		lastUpload := result.Uploads[len(result.Uploads)-1]
+		result.NextKeyMarker = lastUpload.Object
+		result.NextUploadIDMarker = lastUpload.UploadID
+	}
+	return result, nil
+}
+
+// NewMultipartUpload - initiates a new multipart upload on the server
+// pool storing the object, or on the hashed server pool for new objects.
+func (z *xlServerPools) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
+	index, err := z.getPoolIndex(ctx, bucket, object)
+	if err != nil {
+		return "", err
+	}
+	return z.pools[index].NewMultipartUpload(ctx, bucket, object, opts)
+}
+
+// CopyObjectPart - copies a part of an object to the server pool of the upload.
+func (z *xlServerPools) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
+	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (partInfo PartInfo, err error) {
+	index, err := z.getUploadPoolIndex(ctx, destBucket, destObject, uploadID)
+	if err != nil {
+		return partInfo, err
+	}
+	return z.pools[index].CopyObjectPart(ctx, srcBucket, srcObject, destBucket, destObject, uploadID, partID,
+		startOffset, length, srcInfo, srcOpts, dstOpts)
+}
+
+// PutObjectPart - writes part of an object to the server pool of the upload.
+func (z *xlServerPools) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error) {
+	index, err := z.getUploadPoolIndex(ctx, bucket, object, uploadID)
+	if err != nil {
+		return info, err
+	}
+	return z.pools[index].PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+}
+
+// ListObjectParts - lists all uploaded parts of an upload.
+func (z *xlServerPools) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
+	index, err := z.getUploadPoolIndex(ctx, bucket, object, uploadID)
+	if err != nil {
+		return result, err
+	}
+	return z.pools[index].ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+}
+
+// AbortMultipartUpload - aborts an in-progress multipart upload.
+func (z *xlServerPools) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
+	index, err := z.getUploadPoolIndex(ctx, bucket, object, uploadID)
+	if err != nil {
+		return err
+	}
+	return z.pools[index].AbortMultipartUpload(ctx, bucket, object, uploadID)
+}
+
+// CompleteMultipartUpload - completes a pending multipart upload.
+func (z *xlServerPools) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if z.isDecommissioning() {
+		objectLock := z.newDecommissionLock(ctx, bucket, object)
+		if err = objectLock.GetLock(globalObjectTimeout); err != nil {
+			return objInfo, err
+		}
+		defer objectLock.Unlock()
+	}
+
+	index, err := z.getUploadPoolIndex(ctx, bucket, object, uploadID)
+	if err != nil {
+		return objInfo, err
+	}
+	objInfo, err = z.pools[index].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+	if err != nil {
+		return objInfo, err
+	}
+	z.deleteDrainingObject(ctx, bucket, object, index)
+	return objInfo, nil
+}
+
+// ReloadFormat - reloads the format of all server pools.
+func (z *xlServerPools) ReloadFormat(ctx context.Context, dryRun bool) error {
+	for _, pool := range z.pools {
+		if err := pool.ReloadFormat(ctx, dryRun); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeHealResult - adds the result of healing a server pool to result.
+func mergeHealResult(result *madmin.HealResultItem, poolResult madmin.HealResultItem) {
+	result.Type = poolResult.Type
+	result.Bucket = poolResult.Bucket
+	result.Object = poolResult.Object
+	result.Detail = poolResult.Detail
+	result.DiskCount += poolResult.DiskCount
+	result.SetCount += poolResult.SetCount
+	result.Before.Drives = append(result.Before.Drives, poolResult.Before.Drives...)
+	result.After.Drives = append(result.After.Drives, poolResult.After.Drives...)
+}
+
+// HealFormat - heals the format of all server pools.
+func (z *xlServerPools) HealFormat(ctx context.Context, dryRun bool) (madmin.HealResultItem, error) {
+	var result madmin.HealResultItem
+	var noHealRequired int
+	for _, pool := range z.pools {
+		poolResult, err := pool.HealFormat(ctx, dryRun)
+		if err == errNoHealRequired {
+			noHealRequired++
+		} else if err != nil {
+			return result, err
+		}
+		mergeHealResult(&result, poolResult)
+	}
+	if noHealRequired == len(z.pools) {
+		return result, errNoHealRequired
+	}
+	return result, nil
+}
+
+// HealBucket - heals the bucket on all server pools.
+func (z *xlServerPools) HealBucket(ctx context.Context, bucket string, dryRun, remove bool) (madmin.HealResultItem, error) {
+	var result madmin.HealResultItem
+	for _, pool := range z.pools {
+		poolResult, err := pool.HealBucket(ctx, bucket, dryRun, remove)
+		if err != nil {
+			return result, err
+		}
+		mergeHealResult(&result, poolResult)
+	}
+	return result, nil
+}
+
+// HealObject - heals the object on the server pool it is stored on.
+func (z *xlServerPools) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool, scanMode madmin.HealScanMode) (result madmin.HealResultItem, err error) {
+	for _, index := range z.poolOrder() {
+		result, err = z.pools[index].HealObject(ctx, bucket, object, dryRun, remove, scanMode)
+		if !isErrObjectNotFound(err) {
+			return result, err
+		}
+	}
+	return result, err
+}
+
+// HealObjects - heals all objects recursively at a specified prefix
+// on all server pools.
+func (z *xlServerPools) HealObjects(ctx context.Context, bucket, prefix string, healObjectFn func(string, string) error) error {
+	for _, pool := range z.pools {
+		if err := pool.HealObjects(ctx, bucket, prefix, healObjectFn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListBucketsHeal - lists all buckets which need healing on any server pool.
+func (z *xlServerPools) ListBucketsHeal(ctx context.Context) ([]BucketInfo, error) {
+	var listBuckets []BucketInfo
+	var healBuckets = map[string]struct{}{}
+	for _, pool := range z.pools {
+		buckets, err := pool.ListBucketsHeal(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, bucket := range buckets {
+			if _, ok := healBuckets[bucket.Name]; ok {
+				continue
+			}
+			healBuckets[bucket.Name] = struct{}{}
+			listBuckets = append(listBuckets, bucket)
+		}
+	}
+	return listBuckets, nil
+}
+
+// ListObjectsHeal - lists objects of all server pools for healing.
+func (z *xlServerPools) ListObjectsHeal(ctx context.Context, bucket, prefix, marker, delimiter string, maxKeys int) (ListObjectsInfo, error) {
+	return z.listObjects(ctx, bucket, prefix, marker, delimiter, maxKeys, true)
+}
+
+// SetBucketPolicy persist the new policy on the bucket.
+func (z *xlServerPools) SetBucketPolicy(ctx context.Context, bucket string, policy *policy.Policy) error {
+	return savePolicyConfig(ctx, z, bucket, policy)
+}
+
+// GetBucketPolicy will return a policy on a bucket
+func (z *xlServerPools) GetBucketPolicy(ctx context.Context, bucket string) (*policy.Policy, error) {
+	return getPolicyConfig(z, bucket)
+}
+
+// DeleteBucketPolicy deletes all policies on bucket
+func (z *xlServerPools) DeleteBucketPolicy(ctx context.Context, bucket string) error {
+	return removePolicyConfig(ctx, z, bucket)
+}
+
+// SetBucketLifecycle sets lifecycle on bucket
+func (z *xlServerPools) SetBucketLifecycle(ctx context.Context, bucket string, lifecycle *lifecycle.Lifecycle) error {
+	return saveLifecycleConfig(ctx, z, bucket, lifecycle)
+}
+
+// GetBucketLifecycle will get lifecycle on bucket
+func (z *xlServerPools) GetBucketLifecycle(ctx context.Context, bucket string) (*lifecycle.Lifecycle, error) {
+	return getLifecycleConfig(z, bucket)
+}
+
+// DeleteBucketLifecycle deletes all lifecycle on bucket
+func (z *xlServerPools) DeleteBucketLifecycle(ctx context.Context, bucket string) error {
+	return removeLifecycleConfig(ctx, z, bucket)
+}
+
+// IsNotificationSupported returns whether bucket notification is applicable for this layer.
+func (z *xlServerPools) IsNotificationSupported() bool {
+	return z.pools[0].IsNotificationSupported()
+}
+
+// IsListenBucketSupported returns whether listen bucket notification is applicable for this layer.
+func (z *xlServerPools) IsListenBucketSupported() bool {
+	return true
+}
+
+// IsEncryptionSupported returns whether server side encryption is implemented for this layer.
+func (z *xlServerPools) IsEncryptionSupported() bool {
+	return z.pools[0].IsEncryptionSupported()
+}
+
+// IsCompressionSupported returns whether compression is applicable for this layer.
+func (z *xlServerPools) IsCompressionSupported() bool {
+	return z.pools[0].IsCompressionSupported()
+}
+
+// IsReady returns whether all server pools are ready.
+func (z *xlServerPools) IsReady(ctx context.Context) bool {
+	for _, pool := range z.pools {
+		if !pool.IsReady(ctx) {
+			return false
+		}
+	}
+	return true
+}
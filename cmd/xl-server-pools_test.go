@@ -0,0 +1,127 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"testing"
+)
+
+// Tests expanding a deployment with a new server pool.
+func TestXLServerPoolsExpansion(t *testing.T) {
+	ctx := context.Background()
+
+	var pools []PoolEndpoints
+	for i := 0; i < 2; i++ {
+		disks, err := getRandomDisks(4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer removeRoots(disks)
+		pools = append(pools, PoolEndpoints{
+			SetCount:     1,
+			DrivesPerSet: 4,
+			Endpoints:    mustGetNewEndpointList(disks...),
+		})
+	}
+
+	// Storage class defaults depend on the drives per set.
+	defer func(setCount, driveCount int) {
+		globalXLSetCount, globalXLSetDriveCount = setCount, driveCount
+	}(globalXLSetCount, globalXLSetDriveCount)
+	globalXLSetCount, globalXLSetDriveCount = 2, 4
+
+	// Start with the first server pool only.
+	obj, err := newXLServerPools(pools[:1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	if err = obj.MakeBucketWithLocation(ctx, "bucket", ""); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("hello")
+	if _, err = obj.PutObject(ctx, "bucket", "existing", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	deploymentID := globalDeploymentID
+	obj.Shutdown(ctx)
+
+	// Expand the deployment with the second server pool.
+	obj, err = newXLServerPools(pools)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(ctx)
+	if globalDeploymentID != deploymentID {
+		t.Fatalf("Expected deployment ID %s, got %s", deploymentID, globalDeploymentID)
+	}
+
+	z := obj.(*xlServerPools)
+	if _, err = z.pools[1].GetBucketInfo(ctx, "bucket"); err != nil {
+		t.Fatalf("Expected bucket to be created on the new server pool, got %s", err)
+	}
+
+	// Existing objects are kept on the first server pool.
+	if _, err = obj.GetObjectInfo(ctx, "bucket", "existing", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.PutObject(ctx, "bucket", "existing", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.pools[1].GetObjectInfo(ctx, "bucket", "existing", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected object not to be written to the new server pool, got %v", err)
+	}
+
+	objects := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	for _, object := range objects {
+		if _, err = obj.PutObject(ctx, "bucket", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Listing merges the objects of all server pools.
+	loi, err := obj.ListObjects(ctx, "bucket", "", "", "", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 5 || !loi.IsTruncated || loi.NextMarker != "e" {
+		t.Fatalf("Unexpected listing %+v", loi)
+	}
+	loi, err = obj.ListObjects(ctx, "bucket", "", loi.NextMarker, "", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 4 || loi.IsTruncated || loi.Objects[3].Name != "h" {
+		t.Fatalf("Unexpected listing %+v", loi)
+	}
+
+	if err = obj.DeleteBucket(ctx, "bucket", false); err == nil {
+		t.Fatal("Expected non-empty bucket not to be deleted")
+	}
+	for _, object := range append(objects, "existing") {
+		if err = obj.DeleteObject(ctx, "bucket", object); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = obj.DeleteBucket(ctx, "bucket", false); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/minio/minio/cmd/config/placement"
 	"github.com/minio/minio/cmd/config/storageclass"
 	xhttp "github.com/minio/minio/cmd/http"
 	"github.com/minio/minio/cmd/logger"
@@ -79,6 +80,10 @@ type xlSets struct {
 	// Distribution algorithm of choice.
 	distributionAlgo string
 
+	// Rules pinning object prefixes to sets, overriding the
+	// distribution algorithm.
+	placement placement.Config
+
 	// Merge tree walk
 	pool *MergeWalkPool
 }
@@ -273,9 +278,14 @@ func newXLSets(endpoints EndpointList, format *formatXLV3, setCount int, drivesP
 		format:             format,
 		disksConnectDoneCh: make(chan struct{}),
 		distributionAlgo:   format.XL.DistributionAlgo,
+		placement:          globalXLPlacement,
 		pool:               NewMergeWalkPool(globalMergeLookupTimeout),
 	}
 
+	if err := s.placement.Validate(setCount); err != nil {
+		return nil, err
+	}
+
 	mutex := newNSLock(globalIsDistXL)
 
 	// Initialize byte pool once for all sets, bpool size is set to
@@ -308,13 +318,14 @@ func (s *xlSets) StorageInfo(ctx context.Context) StorageInfo {
 	var storageInfo StorageInfo
 
 	storageInfos := make([]StorageInfo, len(s.sets))
+	disksInfos := make([][]DiskInfo, len(s.sets))
 	storageInfo.Backend.Type = BackendErasure
 
 	g := errgroup.WithNErrs(len(s.sets))
 	for index := range s.sets {
 		index := index
 		g.Go(func() error {
-			storageInfos[index] = s.sets[index].StorageInfo(ctx)
+			storageInfos[index], disksInfos[index] = getStorageInfo(s.sets[index].getDisks())
 			return nil
 		}, index)
 	}
@@ -400,6 +411,20 @@ func (s *xlSets) StorageInfo(ctx context.Context) StorageInfo {
 		}
 	}
 
+	// fill the latency and error metrics of the online endpoints.
+	for i := range storageInfo.Backend.Sets {
+		for j := range storageInfo.Backend.Sets[i] {
+			if storageInfo.Backend.Sets[i][j].State != madmin.DriveStateOk {
+				continue
+			}
+			if j >= len(disksInfos[i]) || disksInfos[i][j].Total == 0 {
+				continue
+			}
+			metrics := disksInfos[i][j].Metrics
+			storageInfo.Backend.Sets[i][j].Metrics = &metrics
+		}
+	}
+
 	return storageInfo
 }
 
@@ -462,7 +487,7 @@ func undoMakeBucketSets(bucket string, sets []*xlObjects, errs []error) {
 		index := index
 		g.Go(func() error {
 			if errs[index] == nil {
-				return sets[index].DeleteBucket(context.Background(), bucket)
+				return sets[index].DeleteBucket(context.Background(), bucket, false)
 			}
 			return nil
 		}, index)
@@ -504,11 +529,78 @@ func (s *xlSets) getHashedSet(input string) (set *xlObjects) {
 	return s.sets[s.getHashedSetIndex(input)]
 }
 
+// getObjectSetIndex - returns the set the object is placed on, which
+// is the set its prefix is pinned to or the set hashed from its name.
+func (s *xlSets) getObjectSetIndex(bucket, object string) int {
+	if set, ok := s.placement.Lookup(bucket, object); ok {
+		return set - 1
+	}
+	return s.getHashedSetIndex(object)
+}
+
+// getObjectSet - returns the set the object is placed on.
+func (s *xlSets) getObjectSet(bucket, object string) *xlObjects {
+	return s.sets[s.getObjectSetIndex(bucket, object)]
+}
+
+// getObjectSets - returns the sets the object may be found on, the
+// set it is placed on first, followed by the set hashed from its name
+// when its prefix is pinned to another set. The hashed set holds the
+// objects written before their prefix was pinned.
+func (s *xlSets) getObjectSets(bucket, object string) []*xlObjects {
+	index := s.getObjectSetIndex(bucket, object)
+	if hashedIndex := s.getHashedSetIndex(object); hashedIndex != index {
+		return []*xlObjects{s.sets[index], s.sets[hashedIndex]}
+	}
+	return []*xlObjects{s.sets[index]}
+}
+
+// getWriteObjectSet - returns the set an object is written to, which
+// is the set already holding the object if it was written before its
+// prefix was pinned, so that an object never has more than one copy.
+func (s *xlSets) getWriteObjectSet(ctx context.Context, bucket, object string) *xlObjects {
+	sets := s.getObjectSets(bucket, object)
+	if len(sets) > 1 {
+		if _, err := sets[1].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err == nil {
+			return sets[1]
+		}
+	}
+	return sets[0]
+}
+
+// prefixPlacements - returns the sets the prefixes pinned by the
+// placement rules are stored on, offline drives are not listed.
+func (s *xlSets) prefixPlacements(pool int) []madmin.PrefixPlacement {
+	placements := make([]madmin.PrefixPlacement, 0, len(s.placement.Rules))
+	for _, rule := range s.placement.Rules {
+		drives := []string{}
+		for _, disk := range s.GetDisks(rule.Set - 1)() {
+			if disk != nil {
+				drives = append(drives, disk.String())
+			}
+		}
+		placements = append(placements, madmin.PrefixPlacement{
+			Bucket: rule.Bucket,
+			Prefix: rule.Prefix,
+			Pool:   pool,
+			Set:    rule.Set,
+			Drives: drives,
+		})
+	}
+	return placements
+}
+
 // GetBucketInfo - returns bucket info from one of the erasure coded set.
 func (s *xlSets) GetBucketInfo(ctx context.Context, bucket string) (bucketInfo BucketInfo, err error) {
 	return s.getHashedSet(bucket).GetBucketInfo(ctx, bucket)
 }
 
+// BucketExists - returns true if the bucket exists on the erasure
+// coded set of the bucket.
+func (s *xlSets) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	return s.getHashedSet(bucket).BucketExists(ctx, bucket)
+}
+
 // ListObjectsV2 lists all objects in bucket filtered by prefix
 func (s *xlSets) ListObjectsV2(ctx context.Context, bucket, prefix, continuationToken, delimiter string, maxKeys int, fetchOwner bool, startAfter string) (result ListObjectsV2Info, err error) {
 	marker := continuationToken
@@ -581,17 +673,27 @@ func (s *xlSets) IsCompressionSupported() bool {
 	return s.getHashedSet("").IsCompressionSupported()
 }
 
+// IsReady returns whether every set has read quorum of disks online.
+func (s *xlSets) IsReady(ctx context.Context) bool {
+	for _, set := range s.sets {
+		if !set.IsReady(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
 // DeleteBucket - deletes a bucket on all sets simultaneously,
 // even if one of the sets fail to delete buckets, we proceed to
 // undo a successful operation.
-func (s *xlSets) DeleteBucket(ctx context.Context, bucket string) error {
+func (s *xlSets) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	g := errgroup.WithNErrs(len(s.sets))
 
 	// Delete buckets in parallel across all sets.
 	for index := range s.sets {
 		index := index
 		g.Go(func() error {
-			return s.sets[index].DeleteBucket(ctx, bucket)
+			return s.sets[index].DeleteBucket(ctx, bucket, forceDelete)
 		}, index)
 	}
 
@@ -644,27 +746,47 @@ func (s *xlSets) ListBuckets(ctx context.Context) (buckets []BucketInfo, err err
 
 // GetObjectNInfo - returns object info and locked object ReadCloser
 func (s *xlSets) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
-	return s.getHashedSet(object).GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts)
+	for _, set := range s.getObjectSets(bucket, object) {
+		if gr, err = set.GetObjectNInfo(ctx, bucket, object, rs, h, lockType, opts); !isErrObjectNotFound(err) {
+			break
+		}
+	}
+	return gr, err
 }
 
 // GetObject - reads an object from the hashedSet based on the object name.
-func (s *xlSets) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) error {
-	return s.getHashedSet(object).GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts)
+func (s *xlSets) GetObject(ctx context.Context, bucket, object string, startOffset int64, length int64, writer io.Writer, etag string, opts ObjectOptions) (err error) {
+	for _, set := range s.getObjectSets(bucket, object) {
+		if err = set.GetObject(ctx, bucket, object, startOffset, length, writer, etag, opts); !isErrObjectNotFound(err) {
+			break
+		}
+	}
+	return err
 }
 
 // PutObject - writes an object to hashedSet based on the object name.
 func (s *xlSets) PutObject(ctx context.Context, bucket string, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).PutObject(ctx, bucket, object, data, opts)
+	return s.getWriteObjectSet(ctx, bucket, object).PutObject(ctx, bucket, object, data, opts)
 }
 
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *xlSets) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).GetObjectInfo(ctx, bucket, object, opts)
+	for _, set := range s.getObjectSets(bucket, object) {
+		if objInfo, err = set.GetObjectInfo(ctx, bucket, object, opts); !isErrObjectNotFound(err) {
+			break
+		}
+	}
+	return objInfo, err
 }
 
 // DeleteObject - deletes an object from the hashedSet based on the object name.
 func (s *xlSets) DeleteObject(ctx context.Context, bucket string, object string) (err error) {
-	return s.getHashedSet(object).DeleteObject(ctx, bucket, object)
+	for _, set := range s.getObjectSets(bucket, object) {
+		if err = set.DeleteObject(ctx, bucket, object); !isErrObjectNotFound(err) {
+			break
+		}
+	}
+	return err
 }
 
 // DeleteObjects - bulk delete of objects
@@ -700,14 +822,14 @@ func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []str
 
 	// Group objects by set index
 	for i, object := range objects {
-		index := s.getHashedSetIndex(object)
+		index := s.getObjectSetIndex(bucket, object)
 		objSetMap[index] = append(objSetMap[index], delObj{setIndex: index, origIndex: i, name: object})
 	}
 
 	// Invoke bulk delete on objects per set and save
 	// the result of the delete operation
 	for _, objsGroup := range objSetMap {
-		errs, err := s.getHashedSet(objsGroup[0].name).DeleteObjects(ctx, bucket, toNames(objsGroup))
+		errs, err := s.sets[objsGroup[0].setIndex].DeleteObjects(ctx, bucket, toNames(objsGroup))
 		if err != nil {
 			return nil, err
 		}
@@ -716,18 +838,25 @@ func (s *xlSets) DeleteObjects(ctx context.Context, bucket string, objects []str
 		}
 	}
 
+	// Objects not found on the set of their pinned prefix may
+	// have been written before the prefix was pinned.
+	for i, object := range objects {
+		if sets := s.getObjectSets(bucket, object); len(sets) > 1 && isErrObjectNotFound(delErrs[i]) {
+			delErrs[i] = sets[1].DeleteObject(ctx, bucket, object)
+		}
+	}
+
 	return delErrs, nil
 }
 
 // CopyObject - copies objects from one hashedSet to another hashedSet, on server side.
 func (s *xlSets) CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error) {
-	srcSet := s.getHashedSet(srcObject)
-	destSet := s.getHashedSet(destObject)
+	destSet := s.getWriteObjectSet(ctx, destBucket, destObject)
 
 	// Check if this request is only metadata update.
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(destBucket, destObject))
 	if cpSrcDstSame && srcInfo.metadataOnly {
-		return srcSet.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
+		return destSet.CopyObject(ctx, srcBucket, srcObject, destBucket, destObject, srcInfo, srcOpts, dstOpts)
 	}
 
 	if !cpSrcDstSame {
@@ -1210,40 +1339,60 @@ func (s *xlSets) ListObjects(ctx context.Context, bucket, prefix, marker, delimi
 func (s *xlSets) ListMultipartUploads(ctx context.Context, bucket, prefix, keyMarker, uploadIDMarker, delimiter string, maxUploads int) (result ListMultipartsInfo, err error) {
 	// In list multipart uploads we are going to treat input prefix as the object,
 	// this means that we are not supporting directory navigation.
-	return s.getHashedSet(prefix).ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads)
+	for _, set := range s.getObjectSets(bucket, prefix) {
+		if result, err = set.ListMultipartUploads(ctx, bucket, prefix, keyMarker, uploadIDMarker, delimiter, maxUploads); err != nil || len(result.Uploads) > 0 {
+			break
+		}
+	}
+	return result, err
 }
 
 // Initiate a new multipart upload on a hashedSet based on object name.
 func (s *xlSets) NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error) {
-	return s.getHashedSet(object).NewMultipartUpload(ctx, bucket, object, opts)
+	return s.getWriteObjectSet(ctx, bucket, object).NewMultipartUpload(ctx, bucket, object, opts)
+}
+
+// getUploadObjectSet - returns the set holding the multipart upload,
+// which is started on the set holding the object if the object was
+// written before its prefix was pinned.
+func (s *xlSets) getUploadObjectSet(ctx context.Context, bucket, object, uploadID string) *xlObjects {
+	sets := s.getObjectSets(bucket, object)
+	if len(sets) > 1 {
+		if _, err := sets[0].ListObjectParts(ctx, bucket, object, uploadID, 0, 1, ObjectOptions{}); err != nil {
+			if _, ok := err.(InvalidUploadID); ok {
+				return sets[1]
+			}
+		}
+	}
+	return sets[0]
 }
 
 // Copies a part of an object from source hashedSet to destination hashedSet.
 func (s *xlSets) CopyObjectPart(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, uploadID string, partID int,
 	startOffset int64, length int64, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (partInfo PartInfo, err error) {
-	destSet := s.getHashedSet(destObject)
+	destSet := s.getUploadObjectSet(ctx, destBucket, destObject, uploadID)
 
 	return destSet.PutObjectPart(ctx, destBucket, destObject, uploadID, partID, NewPutObjReader(srcInfo.Reader, nil, nil), dstOpts)
 }
 
 // PutObjectPart - writes part of an object to hashedSet based on the object name.
 func (s *xlSets) PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error) {
-	return s.getHashedSet(object).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
+	return s.getUploadObjectSet(ctx, bucket, object, uploadID).PutObjectPart(ctx, bucket, object, uploadID, partID, data, opts)
 }
 
 // ListObjectParts - lists all uploaded parts to an object in hashedSet.
 func (s *xlSets) ListObjectParts(ctx context.Context, bucket, object, uploadID string, partNumberMarker int, maxParts int, opts ObjectOptions) (result ListPartsInfo, err error) {
-	return s.getHashedSet(object).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
+	return s.getUploadObjectSet(ctx, bucket, object, uploadID).ListObjectParts(ctx, bucket, object, uploadID, partNumberMarker, maxParts, opts)
 }
 
 // Aborts an in-progress multipart operation on hashedSet based on the object name.
 func (s *xlSets) AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string) error {
-	return s.getHashedSet(object).AbortMultipartUpload(ctx, bucket, object, uploadID)
+	return s.getUploadObjectSet(ctx, bucket, object, uploadID).AbortMultipartUpload(ctx, bucket, object, uploadID)
 }
 
 // CompleteMultipartUpload - completes a pending multipart transaction, on hashedSet based on object name.
 func (s *xlSets) CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error) {
-	return s.getHashedSet(object).CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+	return s.getUploadObjectSet(ctx, bucket, object, uploadID).CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 }
 
 /*
@@ -1687,7 +1836,15 @@ func (s *xlSets) HealBucket(ctx context.Context, bucket string, dryRun, remove b
 
 // HealObject - heals inconsistent object on a hashedSet based on object name.
 func (s *xlSets) HealObject(ctx context.Context, bucket, object string, dryRun, remove bool, scanMode madmin.HealScanMode) (madmin.HealResultItem, error) {
-	return s.getHashedSet(object).HealObject(ctx, bucket, object, dryRun, remove, scanMode)
+	set := s.getObjectSet(bucket, object)
+	// Objects written before their prefix was pinned are
+	// healed on the set hashed from their name.
+	if sets := s.getObjectSets(bucket, object); len(sets) > 1 {
+		if _, err := sets[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); isErrObjectNotFound(err) {
+			set = sets[1]
+		}
+	}
+	return set.HealObject(ctx, bucket, object, dryRun, remove, scanMode)
 }
 
 // Lists all buckets which need healing.
@@ -17,10 +17,13 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"os"
 	"path/filepath"
 	"testing"
+
+	"github.com/minio/minio/cmd/config/placement"
 )
 
 // TestCrcHashMod - test crc hash.
@@ -144,3 +147,121 @@ func TestHashedLayer(t *testing.T) {
 		}
 	}
 }
+
+// TestObjectSetPlacement - tests that objects of pinned prefixes are
+// placed on the set of the rule instead of the hashed set.
+func TestObjectSetPlacement(t *testing.T) {
+	sets := &xlSets{
+		sets:             make([]*xlObjects, 16),
+		distributionAlgo: "CRCMOD",
+		placement: placement.Config{Rules: []placement.Rule{
+			{Bucket: "finance", Prefix: "eu/", Set: 3},
+			{Bucket: "finance", Prefix: "eu/archive/", Set: 16},
+		}},
+	}
+
+	testCases := []struct {
+		bucket, object string
+		expectedIndex  int
+	}{
+		{"finance", "eu/report.csv", 2},
+		{"finance", "eu/archive/report.csv", 15},
+		// Not pinned, placed on the hashed set.
+		{"finance", "object", 12},
+		{"logs", "eu/archive/report.csv", sets.getHashedSetIndex("eu/archive/report.csv")},
+	}
+	for i, testCase := range testCases {
+		if index := sets.getObjectSetIndex(testCase.bucket, testCase.object); index != testCase.expectedIndex {
+			t.Errorf("Test case %d: Expected set %d, got %d", i+1, testCase.expectedIndex, index)
+		}
+	}
+
+	// Rules pinning prefixes to missing sets are rejected.
+	if err := sets.placement.Validate(8); err == nil {
+		t.Error("Expected placement on set 16 of 8 sets to fail")
+	}
+}
+
+// TestObjectSetPlacementFallback - tests that objects written before
+// their prefix was pinned are still found on their hashed set.
+func TestObjectSetPlacementFallback(t *testing.T) {
+	var disks []string
+	for i := 0; i < 32; i++ {
+		disk := filepath.Join(globalTestTmpDir, "minio-"+nextSuffix())
+		disks = append(disks, disk)
+		defer os.RemoveAll(disk)
+	}
+	endpoints := mustGetNewEndpointList(disks...)
+	format, err := waitForFormatXL(context.Background(), true, endpoints, 2, 16)
+	if err != nil {
+		t.Fatalf("Unable to format disks for erasure, %s", err)
+	}
+	objLayer, err := newXLSets(endpoints, format, 2, 16)
+	if err != nil {
+		t.Fatalf("Unable to initialize erasure, %s", err)
+	}
+	sets := objLayer.(*xlSets)
+	defer sets.Shutdown(context.Background())
+
+	ctx := context.Background()
+	if err = sets.MakeBucketWithLocation(ctx, "finance", ""); err != nil {
+		t.Fatal(err)
+	}
+	putObject := func(object string) {
+		t.Helper()
+		data := []byte("report")
+		if _, err := sets.PutObject(ctx, "finance", object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	putObject("eu/old.csv")
+	hashed := sets.getHashedSetIndex("eu/old.csv")
+	pinned := 1 - hashed
+	sets.placement = placement.Config{Rules: []placement.Rule{{Bucket: "finance", Prefix: "eu/", Set: pinned + 1}}}
+
+	if _, err = sets.GetObjectInfo(ctx, "finance", "eu/old.csv", ObjectOptions{}); err != nil {
+		t.Fatalf("Expected the object written before its prefix was pinned to be found, got %v", err)
+	}
+	var buf bytes.Buffer
+	if err = sets.GetObject(ctx, "finance", "eu/old.csv", 0, -1, &buf, "", ObjectOptions{}); err != nil || buf.String() != "report" {
+		t.Fatalf("Expected to read the object, got %q, %v", buf.String(), err)
+	}
+
+	// Overwrites and uploads stay on the set holding the object.
+	putObject("eu/old.csv")
+	uploadID, err := sets.NewMultipartUpload(ctx, "finance", "eu/old.csv", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("part")
+	part, err := sets.PutObjectPart(ctx, "finance", "eu/old.csv", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sets.CompleteMultipartUpload(ctx, "finance", "eu/old.csv", uploadID, []CompletePart{{PartNumber: 1, ETag: part.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sets.sets[pinned].GetObjectInfo(ctx, "finance", "eu/old.csv", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected no copy of the object on the pinned set, got %v", err)
+	}
+
+	// New objects are placed on the pinned set.
+	putObject("eu/new.csv")
+	if _, err = sets.sets[pinned].GetObjectInfo(ctx, "finance", "eu/new.csv", ObjectOptions{}); err != nil {
+		t.Fatalf("Expected the new object on the pinned set, got %v", err)
+	}
+
+	errs, err := sets.DeleteObjects(ctx, "finance", []string{"eu/old.csv", "eu/new.csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, err := range errs {
+		if err != nil {
+			t.Fatalf("Delete %d: %v", i, err)
+		}
+	}
+	if _, err = sets.GetObjectInfo(ctx, "finance", "eu/old.csv", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected the object to be deleted, got %v", err)
+	}
+}
@@ -100,7 +100,7 @@ func undoMakeBucket(storageDisks []StorageAPI, bucket string) {
 		}
 		index := index
 		g.Go(func() error {
-			_ = storageDisks[index].DeleteVol(bucket)
+			_ = storageDisks[index].DeleteVol(bucket, false)
 			return nil
 		}, index)
 	}
@@ -152,6 +152,18 @@ func (xl xlObjects) GetBucketInfo(ctx context.Context, bucket string) (bi Bucket
 	return bucketInfo, nil
 }
 
+// BucketExists - returns true if the bucket exists on one of the load
+// balanced disks, unlike GetBucketInfo the bucket lock is not taken.
+func (xl xlObjects) BucketExists(ctx context.Context, bucket string) (bool, error) {
+	if _, err := xl.getBucketInfo(ctx, bucket); err != nil {
+		if err == errVolumeNotFound {
+			return false, nil
+		}
+		return false, toObjectErr(err, bucket)
+	}
+	return true, nil
+}
+
 // listBuckets - returns list of all buckets from a disk picked at random.
 func (xl xlObjects) listBuckets(ctx context.Context) (bucketsInfo []BucketInfo, err error) {
 	for _, disk := range xl.getLoadBalancedDisks() {
@@ -212,10 +224,10 @@ func deleteDanglingBucket(ctx context.Context, storageDisks []StorageAPI, dErrs
 	for index, err := range dErrs {
 		if err == errVolumeNotEmpty {
 			// Attempt to delete bucket again.
-			if derr := storageDisks[index].DeleteVol(bucket); derr == errVolumeNotEmpty {
+			if derr := storageDisks[index].DeleteVol(bucket, false); derr == errVolumeNotEmpty {
 				_ = cleanupDir(ctx, storageDisks[index], bucket, "")
 
-				_ = storageDisks[index].DeleteVol(bucket)
+				_ = storageDisks[index].DeleteVol(bucket, false)
 
 				// Cleanup all the previously incomplete multiparts.
 				_ = cleanupDir(ctx, storageDisks[index], minioMetaMultipartBucket, bucket)
@@ -225,7 +237,7 @@ func deleteDanglingBucket(ctx context.Context, storageDisks []StorageAPI, dErrs
 }
 
 // DeleteBucket - deletes a bucket.
-func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string) error {
+func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string, forceDelete bool) error {
 	bucketLock := xl.nsMutex.NewNSLock(ctx, bucket, "")
 	if err := bucketLock.GetLock(globalObjectTimeout); err != nil {
 		return err
@@ -241,7 +253,7 @@ func (xl xlObjects) DeleteBucket(ctx context.Context, bucket string) error {
 		index := index
 		g.Go(func() error {
 			if storageDisks[index] != nil {
-				if err := storageDisks[index].DeleteVol(bucket); err != nil {
+				if err := storageDisks[index].DeleteVol(bucket, forceDelete); err != nil {
 					return err
 				}
 				err := cleanupDir(ctx, storageDisks[index], minioMetaMultipartBucket, bucket)
@@ -323,3 +335,15 @@ func (xl xlObjects) IsEncryptionSupported() bool {
 func (xl xlObjects) IsCompressionSupported() bool {
 	return true
 }
+
+// IsReady returns whether read quorum of disks is online.
+func (xl xlObjects) IsReady(ctx context.Context) bool {
+	disks := xl.getDisks()
+	var onlineDisks int
+	for _, disk := range disks {
+		if disk != nil && disk.IsOnline() {
+			onlineDisks++
+		}
+	}
+	return onlineDisks >= len(disks)/2
+}
@@ -257,6 +257,11 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 		if storageDisks[i] != nil {
 			drive = storageDisks[i].String()
 		}
+		// The i-th disk holds the erasure block at Distribution[i].
+		var block int
+		if i < len(latestXLMeta.Erasure.Distribution) {
+			block = latestXLMeta.Erasure.Distribution[i]
+		}
 		if shouldHealObjectOnDisk(errs[i], dataErrs[i], partsMetadata[i], modTime) {
 			outDatedDisks[i] = storageDisks[i]
 			disksToHealCount++
@@ -264,11 +269,13 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 				UUID:     "",
 				Endpoint: drive,
 				State:    driveState,
+				Block:    block,
 			})
 			result.After.Drives = append(result.After.Drives, madmin.HealDriveInfo{
 				UUID:     "",
 				Endpoint: drive,
 				State:    driveState,
+				Block:    block,
 			})
 			continue
 		}
@@ -276,11 +283,13 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 			UUID:     "",
 			Endpoint: drive,
 			State:    driveState,
+			Block:    block,
 		})
 		result.After.Drives = append(result.After.Drives, madmin.HealDriveInfo{
 			UUID:     "",
 			Endpoint: drive,
 			State:    driveState,
+			Block:    block,
 		})
 	}
 
@@ -302,7 +311,11 @@ func (xl xlObjects) healObject(ctx context.Context, bucket string, object string
 	}
 
 	if disksToHealCount == 0 {
-		// Nothing to heal!
+		// Nothing to heal, migrate metadata written in v1 once
+		// the v2 format is enabled.
+		if !dryRun && globalXLMetaFormat == xlMetaFormatV2 {
+			migrateXLMetadata(ctx, storageDisks, bucket, object, partsMetadata, errs)
+		}
 		return result, nil
 	}
 
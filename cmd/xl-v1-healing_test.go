@@ -254,6 +254,23 @@ func TestHealObjectXL(t *testing.T) {
 		t.Fatalf("Failed to delete a file - %v", err)
 	}
 
+	// A dry run reports the first disk, missing its erasure block,
+	// as to be healed.
+	hr, err := obj.HealObject(context.Background(), bucket, object, true, false, madmin.HealNormalScan)
+	if err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	diff := hr.GetDiff(true)
+	if len(diff.Drives) != nDisks || !diff.Drives[0].Heal || diff.Drives[0].Before != madmin.DriveStateMissing || diff.Drives[0].Block == 0 {
+		t.Fatalf("Unexpected heal diff of the first disk %+v", diff.Drives[0])
+	}
+	if total := diff.Before.Data + diff.Before.Parity; total != nDisks-1 {
+		t.Errorf("Expected %d erasure blocks before heal, got %d", nDisks-1, total)
+	}
+	if total := diff.After.Data + diff.After.Parity; total != nDisks {
+		t.Errorf("Expected %d erasure blocks after heal, got %d", nDisks, total)
+	}
+
 	_, err = obj.HealObject(context.Background(), bucket, object, false, false, madmin.HealNormalScan)
 	if err != nil {
 		t.Fatalf("Failed to heal object - %v", err)
@@ -352,3 +369,80 @@ func TestHealEmptyDirectoryXL(t *testing.T) {
 		}
 	}
 }
+
+// Tests that healing rewrites `xl.json` written in the v1 format in
+// the v2 format once it is enabled, the object stays readable.
+func TestHealObjectMigratesXLMeta(t *testing.T) {
+	nDisks := 16
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	objLayer, _, err := initObjectLayer(mustGetNewEndpointList(fsDirs...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	object := "object"
+	data := bytes.Repeat([]byte("a"), 1024)
+	if err = objLayer.MakeBucketWithLocation(context.Background(), bucket, ""); err != nil {
+		t.Fatalf("Failed to make a bucket - %v", err)
+	}
+
+	// Write the object in the v1 format.
+	globalXLMetaFormat = xlMetaFormatV1
+	defer func() { globalXLMetaFormat = xlMetaFormatV1 }()
+	_, err = objLayer.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("Failed to put an object - %v", err)
+	}
+
+	xl := objLayer.(*xlObjects)
+	isV2 := func() bool {
+		for _, disk := range xl.storageDisks {
+			xlMetaBuf, rerr := disk.ReadAll(bucket, filepath.Join(object, xlMetaJSONFile))
+			if rerr != nil {
+				t.Fatal(rerr)
+			}
+			if !isXLMetaV2(xlMetaBuf) {
+				return false
+			}
+		}
+		return true
+	}
+
+	// Metadata is not migrated unless v2 is enabled.
+	if _, err = objLayer.HealObject(context.Background(), bucket, object, false, false, madmin.HealNormalScan); err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	if isV2() {
+		t.Fatal("Expected xl.json in the v1 format")
+	}
+
+	// A dry run leaves the metadata untouched.
+	globalXLMetaFormat = xlMetaFormatV2
+	if _, err = objLayer.HealObject(context.Background(), bucket, object, true, false, madmin.HealNormalScan); err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	if isV2() {
+		t.Fatal("Expected xl.json in the v1 format after a dry run")
+	}
+
+	if _, err = objLayer.HealObject(context.Background(), bucket, object, false, false, madmin.HealNormalScan); err != nil {
+		t.Fatalf("Failed to heal object - %v", err)
+	}
+	if !isV2() {
+		t.Fatal("Expected xl.json in the v2 format after healing")
+	}
+
+	var buf bytes.Buffer
+	if err = objLayer.GetObject(context.Background(), bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("Failed to get a migrated object - %v", err)
+	}
+	if !bytes.Equal(buf.Bytes(), data) {
+		t.Fatal("Migrated object has unexpected content")
+	}
+}
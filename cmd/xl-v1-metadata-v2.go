@@ -0,0 +1,323 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path"
+
+	"github.com/minio/minio/cmd/logger"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// XL metadata formats of `xl.json`, the format of a file is detected
+// from its content so that both formats are read in any deployment.
+const (
+	// xlMetaFormatV1 - JSON encoded metadata.
+	xlMetaFormatV1 = "v1"
+	// xlMetaFormatV2 - msgpack encoded metadata following the
+	// xlMetaV2Header, cheaper to parse when listing objects.
+	xlMetaFormatV2 = "v2"
+)
+
+// xlMetaV2Header - prefix of `xl.json` in the v2 format, never a
+// valid prefix of a JSON document.
+var xlMetaV2Header = []byte("XL2 ")
+
+// isXLMetaV2 - returns true if the `xl.json` content is in the v2 format.
+func isXLMetaV2(buf []byte) bool {
+	return bytes.HasPrefix(buf, xlMetaV2Header)
+}
+
+// xlMetaV1Unmarshal - parses `xl.json` content of either format.
+func xlMetaV1Unmarshal(ctx context.Context, xlMetaBuf []byte) (xlMetaV1, error) {
+	if isXLMetaV2(xlMetaBuf) {
+		return xlMetaV2Unmarshal(xlMetaBuf[len(xlMetaV2Header):])
+	}
+	return xlMetaV1UnmarshalJSON(ctx, xlMetaBuf)
+}
+
+// xlMetaMarshal - returns the `xl.json` content of the metadata in
+// the format configured for new metadata.
+func xlMetaMarshal(xlMeta xlMetaV1) ([]byte, error) {
+	if globalXLMetaFormat == xlMetaFormatV1 {
+		return json.Marshal(&xlMeta)
+	}
+	return xlMetaV2Marshal(xlMeta), nil
+}
+
+// xlMetaV2Marshal - encodes the metadata in the v2 format. Fields are
+// keyed by name, so that fields added later are skipped by readers
+// not knowing them.
+func xlMetaV2Marshal(m xlMetaV1) []byte {
+	b := make([]byte, 0, 512)
+	b = append(b, xlMetaV2Header...)
+	b = msgp.AppendMapHeader(b, 7)
+	b = msgp.AppendString(b, "Version")
+	b = msgp.AppendString(b, m.Version)
+	b = msgp.AppendString(b, "Format")
+	b = msgp.AppendString(b, m.Format)
+
+	b = msgp.AppendString(b, "Stat")
+	b = msgp.AppendMapHeader(b, 2)
+	b = msgp.AppendString(b, "Size")
+	b = msgp.AppendInt64(b, m.Stat.Size)
+	b = msgp.AppendString(b, "ModTime")
+	b = msgp.AppendTime(b, m.Stat.ModTime)
+
+	b = msgp.AppendString(b, "Erasure")
+	b = msgp.AppendMapHeader(b, 7)
+	b = msgp.AppendString(b, "Algorithm")
+	b = msgp.AppendString(b, m.Erasure.Algorithm)
+	b = msgp.AppendString(b, "Data")
+	b = msgp.AppendInt(b, m.Erasure.DataBlocks)
+	b = msgp.AppendString(b, "Parity")
+	b = msgp.AppendInt(b, m.Erasure.ParityBlocks)
+	b = msgp.AppendString(b, "BlockSize")
+	b = msgp.AppendInt64(b, m.Erasure.BlockSize)
+	b = msgp.AppendString(b, "Index")
+	b = msgp.AppendInt(b, m.Erasure.Index)
+	b = msgp.AppendString(b, "Distribution")
+	b = msgp.AppendArrayHeader(b, uint32(len(m.Erasure.Distribution)))
+	for _, index := range m.Erasure.Distribution {
+		b = msgp.AppendInt(b, index)
+	}
+	b = msgp.AppendString(b, "Checksums")
+	b = msgp.AppendArrayHeader(b, uint32(len(m.Erasure.Checksums)))
+	for _, sum := range m.Erasure.Checksums {
+		b = msgp.AppendMapHeader(b, 3)
+		b = msgp.AppendString(b, "Name")
+		b = msgp.AppendString(b, sum.Name)
+		b = msgp.AppendString(b, "Algorithm")
+		b = msgp.AppendString(b, sum.Algorithm.String())
+		b = msgp.AppendString(b, "Hash")
+		b = msgp.AppendBytes(b, sum.Hash)
+	}
+
+	b = msgp.AppendString(b, "Release")
+	b = msgp.AppendString(b, m.Minio.Release)
+	b = msgp.AppendString(b, "Meta")
+	b = msgp.AppendMapStrStr(b, m.Meta)
+
+	b = msgp.AppendString(b, "Parts")
+	b = msgp.AppendArrayHeader(b, uint32(len(m.Parts)))
+	for _, part := range m.Parts {
+		b = msgp.AppendMapHeader(b, 5)
+		b = msgp.AppendString(b, "Number")
+		b = msgp.AppendInt(b, part.Number)
+		b = msgp.AppendString(b, "Name")
+		b = msgp.AppendString(b, part.Name)
+		b = msgp.AppendString(b, "ETag")
+		b = msgp.AppendString(b, part.ETag)
+		b = msgp.AppendString(b, "Size")
+		b = msgp.AppendInt64(b, part.Size)
+		b = msgp.AppendString(b, "ActualSize")
+		b = msgp.AppendInt64(b, part.ActualSize)
+	}
+	return b
+}
+
+// readMsgpMap - calls fn for every key of the map at the start of b,
+// fn reads the value of the key and returns the remaining bytes.
+func readMsgpMap(b []byte, fn func(key string, b []byte) ([]byte, error)) ([]byte, error) {
+	n, b, err := msgp.ReadMapHeaderBytes(b)
+	if err != nil {
+		return b, err
+	}
+	for i := uint32(0); i < n; i++ {
+		var key string
+		if key, b, err = msgp.ReadStringBytes(b); err != nil {
+			return b, err
+		}
+		if b, err = fn(key, b); err != nil {
+			return b, err
+		}
+	}
+	return b, nil
+}
+
+// xlMetaV2Unmarshal - decodes metadata in the v2 format, without the
+// xlMetaV2Header.
+func xlMetaV2Unmarshal(b []byte) (m xlMetaV1, err error) {
+	_, err = readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+		var err error
+		switch key {
+		case "Version":
+			m.Version, b, err = msgp.ReadStringBytes(b)
+		case "Format":
+			m.Format, b, err = msgp.ReadStringBytes(b)
+		case "Stat":
+			b, err = readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+				var err error
+				switch key {
+				case "Size":
+					m.Stat.Size, b, err = msgp.ReadInt64Bytes(b)
+				case "ModTime":
+					m.Stat.ModTime, b, err = msgp.ReadTimeBytes(b)
+					m.Stat.ModTime = m.Stat.ModTime.UTC()
+				default:
+					b, err = msgp.Skip(b)
+				}
+				return b, err
+			})
+		case "Erasure":
+			b, err = xlMetaV2UnmarshalErasure(b, &m.Erasure)
+		case "Release":
+			m.Minio.Release, b, err = msgp.ReadStringBytes(b)
+		case "Meta":
+			b, err = readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+				value, b, err := msgp.ReadStringBytes(b)
+				if err != nil {
+					return b, err
+				}
+				if m.Meta == nil {
+					m.Meta = make(map[string]string)
+				}
+				m.Meta[key] = value
+				return b, nil
+			})
+		case "Parts":
+			var n uint32
+			if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+				return b, err
+			}
+			for i := uint32(0); i < n; i++ {
+				var part ObjectPartInfo
+				b, err = readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+					var err error
+					switch key {
+					case "Number":
+						part.Number, b, err = msgp.ReadIntBytes(b)
+					case "Name":
+						part.Name, b, err = msgp.ReadStringBytes(b)
+					case "ETag":
+						part.ETag, b, err = msgp.ReadStringBytes(b)
+					case "Size":
+						part.Size, b, err = msgp.ReadInt64Bytes(b)
+					case "ActualSize":
+						part.ActualSize, b, err = msgp.ReadInt64Bytes(b)
+					default:
+						b, err = msgp.Skip(b)
+					}
+					return b, err
+				})
+				if err != nil {
+					return b, err
+				}
+				m.Parts = append(m.Parts, part)
+			}
+		default:
+			b, err = msgp.Skip(b)
+		}
+		return b, err
+	})
+	return m, err
+}
+
+// xlMetaV2UnmarshalErasure - decodes the erasure info of metadata in
+// the v2 format.
+func xlMetaV2UnmarshalErasure(b []byte, e *ErasureInfo) ([]byte, error) {
+	return readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+		var err error
+		switch key {
+		case "Algorithm":
+			e.Algorithm, b, err = msgp.ReadStringBytes(b)
+		case "Data":
+			e.DataBlocks, b, err = msgp.ReadIntBytes(b)
+		case "Parity":
+			e.ParityBlocks, b, err = msgp.ReadIntBytes(b)
+		case "BlockSize":
+			e.BlockSize, b, err = msgp.ReadInt64Bytes(b)
+		case "Index":
+			e.Index, b, err = msgp.ReadIntBytes(b)
+		case "Distribution":
+			var n uint32
+			if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+				return b, err
+			}
+			e.Distribution = make([]int, n)
+			for i := range e.Distribution {
+				if e.Distribution[i], b, err = msgp.ReadIntBytes(b); err != nil {
+					return b, err
+				}
+			}
+		case "Checksums":
+			var n uint32
+			if n, b, err = msgp.ReadArrayHeaderBytes(b); err != nil {
+				return b, err
+			}
+			for i := uint32(0); i < n; i++ {
+				var sum ChecksumInfo
+				b, err = readMsgpMap(b, func(key string, b []byte) ([]byte, error) {
+					var err error
+					switch key {
+					case "Name":
+						sum.Name, b, err = msgp.ReadStringBytes(b)
+					case "Algorithm":
+						var algorithm string
+						algorithm, b, err = msgp.ReadStringBytes(b)
+						sum.Algorithm = BitrotAlgorithmFromString(algorithm)
+					case "Hash":
+						sum.Hash, b, err = msgp.ReadBytesBytes(b, nil)
+					default:
+						b, err = msgp.Skip(b)
+					}
+					return b, err
+				})
+				if err != nil {
+					return b, err
+				}
+				if !sum.Algorithm.Available() {
+					logger.LogIf(context.Background(), errBitrotHashAlgoInvalid)
+					return b, errBitrotHashAlgoInvalid
+				}
+				e.Checksums = append(e.Checksums, sum)
+			}
+		default:
+			b, err = msgp.Skip(b)
+		}
+		return b, err
+	})
+}
+
+// migrateXLMetadata - rewrites `xl.json` of the object on all disks
+// whose metadata is not in the format configured for new metadata,
+// the caller holds a lock on the object.
+func migrateXLMetadata(ctx context.Context, disks []StorageAPI, bucket, object string, partsMetadata []xlMetaV1, errs []error) {
+	for i, disk := range disks {
+		if disk == nil || errs[i] != nil {
+			continue
+		}
+		xlMetaBuf, err := disk.ReadAll(bucket, path.Join(object, xlMetaJSONFile))
+		if err != nil || isXLMetaV2(xlMetaBuf) == (globalXLMetaFormat == xlMetaFormatV2) {
+			continue
+		}
+
+		// Write to a temporary location and rename over the
+		// existing `xl.json`, readers never see a partial file.
+		tmpID := mustGetUUID()
+		if err = writeXLMetadata(ctx, disk, minioMetaTmpBucket, tmpID, partsMetadata[i]); err != nil {
+			continue
+		}
+		if err = disk.RenameFile(minioMetaTmpBucket, path.Join(tmpID, xlMetaJSONFile), bucket, path.Join(object, xlMetaJSONFile)); err != nil {
+			logger.LogIf(ctx, err)
+			disk.DeleteFile(minioMetaTmpBucket, path.Join(tmpID, xlMetaJSONFile))
+		}
+	}
+}
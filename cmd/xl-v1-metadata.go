@@ -429,8 +429,8 @@ func (xl xlObjects) readXLMetaStat(ctx context.Context, bucket, object string) (
 func writeXLMetadata(ctx context.Context, disk StorageAPI, bucket, prefix string, xlMeta xlMetaV1) error {
 	jsonFile := path.Join(prefix, xlMetaJSONFile)
 
-	// Marshal json.
-	metadataBytes, err := json.Marshal(&xlMeta)
+	// Marshal in the configured format.
+	metadataBytes, err := xlMetaMarshal(xlMeta)
 	if err != nil {
 		logger.LogIf(ctx, err)
 		return err
@@ -198,6 +198,9 @@ func (xl xlObjects) newMultipartUpload(ctx context.Context, bucket string, objec
 	dataBlocks := len(onlineDisks) - parityBlocks
 
 	xlMeta := newXLMetaV1(object, dataBlocks, parityBlocks)
+	if blockSize := scfg.GetBlockSizeForSC(meta[xhttp.AmzStorageClass]); blockSize > 0 {
+		xlMeta.Erasure.BlockSize = blockSize
+	}
 
 	// we now know the number of blocks this object needs for data and parity.
 	// establish the writeQuorum using this data
@@ -629,6 +632,10 @@ func (xl xlObjects) CompleteMultipartUpload(ctx context.Context, bucket string,
 		return oi, toObjectErr(err, bucket, object, uploadID)
 	}
 
+	if err := xl.checkPutPreconditions(ctx, bucket, object, opts); err != nil {
+		return oi, err
+	}
+
 	// Check if an object is present as one of the parent dir.
 	// -- FIXME. (needs a new kind of lock).
 	if xl.parentDirIsObject(ctx, bucket, path.Dir(object)) {
@@ -246,6 +246,10 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 		return err
 	}
 
+	// Without parity shards left the data shards cannot be
+	// verified, serve them as they are if allowed to.
+	degraded := isDegradedRead(onlineDisks, xlMeta)
+
 	// Reorder online disks based on erasure distribution order.
 	onlineDisks = shuffleDisks(onlineDisks, xlMeta.Erasure.Distribution)
 
@@ -309,6 +313,10 @@ func (xl xlObjects) getObject(ctx context.Context, bucket, object string, startO
 				continue
 			}
 			checksumInfo := metaArr[index].Erasure.GetChecksumInfo(partName)
+			if degraded {
+				readers[index] = newDegradedBitrotReader(disk, bucket, pathJoin(object, partName), tillOffset, checksumInfo.Algorithm, erasure.ShardSize())
+				continue
+			}
 			readers[index] = newBitrotReader(disk, bucket, pathJoin(object, partName), tillOffset, checksumInfo.Algorithm, checksumInfo.Hash, erasure.ShardSize())
 		}
 		err := erasure.Decode(ctx, writer, readers, partOffset, partLength, partSize)
@@ -419,7 +427,19 @@ func (xl xlObjects) getObjectInfo(ctx context.Context, bucket, object string) (o
 		return objInfo, err
 	}
 
-	return xlMeta.ToObjectInfo(bucket, object), nil
+	objInfo = xlMeta.ToObjectInfo(bucket, object)
+	if globalXLDegradedReads {
+		onlineDisks, _ := listOnlineDisks(disks, metaArr, errs)
+		objInfo.DegradedRead = isDegradedRead(onlineDisks, xlMeta)
+	}
+	return objInfo, nil
+}
+
+// isDegradedRead - returns true if degraded reads are enabled and
+// the online disks hold no parity shards of the object, in which
+// case the object is read without bitrot verification.
+func isDegradedRead(onlineDisks []StorageAPI, xlMeta xlMetaV1) bool {
+	return globalXLDegradedReads && diskCount(onlineDisks) <= xlMeta.Erasure.DataBlocks
 }
 
 func undoRename(disks []StorageAPI, srcBucket, srcEntry, dstBucket, dstEntry string, isDir bool, errs []error) {
@@ -504,9 +524,22 @@ func (xl xlObjects) PutObject(ctx context.Context, bucket string, object string,
 	}
 	defer objectLock.Unlock()
 
+	if err = xl.checkPutPreconditions(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
 	return xl.putObject(ctx, bucket, object, data, opts)
 }
 
+// checkPutPreconditions - evaluates the write preconditions of opts,
+// callers must hold the object write lock.
+func (xl xlObjects) checkPutPreconditions(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	return checkPutPreconditions(opts, func() (ObjectInfo, error) {
+		objInfo, err := xl.getObjectInfo(ctx, bucket, object)
+		return objInfo, toObjectErr(err, bucket, object)
+	})
+}
+
 // putObject wrapper for xl PutObject
 func (xl xlObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	data := r.Reader
@@ -578,6 +611,9 @@ func (xl xlObjects) putObject(ctx context.Context, bucket string, object string,
 	partsMetadata := make([]xlMetaV1, len(xl.getDisks()))
 
 	xlMeta := newXLMetaV1(object, dataDrives, parityDrives)
+	if blockSize := scfg.GetBlockSizeForSC(opts.UserDefined[xhttp.AmzStorageClass]); blockSize > 0 {
+		xlMeta.Erasure.BlockSize = blockSize
+	}
 
 	// Initialize xl meta.
 	for index := range partsMetadata {
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -599,3 +600,158 @@ func testObjectQuorumFromMeta(obj ObjectLayer, instanceType string, dirs []strin
 		}
 	}
 }
+
+func TestObjectBlockSizeFromStorageClass(t *testing.T) {
+	ExecObjectLayerTestWithDirs(t, testObjectBlockSizeFromStorageClass)
+}
+
+func testObjectBlockSizeFromStorageClass(obj ObjectLayer, instanceType string, dirs []string, t TestErrHandler) {
+	bucket := getRandomBucketName()
+	xl := obj.(*xlObjects)
+
+	err := obj.MakeBucketWithLocation(context.Background(), bucket, globalMinioDefaultRegion)
+	if err != nil {
+		t.Fatalf("Failed to make a bucket %v", err)
+	}
+
+	globalServerConfig.StorageClass = storageclass.Config{
+		RRS: storageclass.StorageClass{
+			Parity:    2,
+			BlockSize: humanize.MiByte,
+		},
+	}
+	defer func() {
+		globalServerConfig.StorageClass = storageclass.Config{}
+	}()
+
+	data := make([]byte, 5*humanize.MiByte+37)
+	rand.Read(data)
+
+	testCases := []struct {
+		storageClass      string
+		multipart         bool
+		expectedBlockSize int64
+	}{
+		{storageclass.STANDARD, false, blockSizeV1},
+		{storageclass.RRS, false, humanize.MiByte},
+		{storageclass.RRS, true, humanize.MiByte},
+	}
+	for i, testCase := range testCases {
+		object := "object" + strconv.Itoa(i+1)
+		opts := ObjectOptions{UserDefined: map[string]string{"x-amz-storage-class": testCase.storageClass}}
+		if testCase.multipart {
+			uploadID, err := obj.NewMultipartUpload(context.Background(), bucket, object, opts)
+			if err != nil {
+				t.Fatalf("Test %d: failed to create a multipart upload %v", i+1, err)
+			}
+			pInfo, err := obj.PutObjectPart(context.Background(), bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+			if err != nil {
+				t.Fatalf("Test %d: failed to upload a part %v", i+1, err)
+			}
+			_, err = obj.CompleteMultipartUpload(context.Background(), bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: pInfo.ETag}}, ObjectOptions{})
+			if err != nil {
+				t.Fatalf("Test %d: failed to complete multipart upload %v", i+1, err)
+			}
+		} else {
+			_, err = obj.PutObject(context.Background(), bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+			if err != nil {
+				t.Fatalf("Test %d: failed to putObject %v", i+1, err)
+			}
+		}
+
+		xlMeta, err := readXLMeta(context.Background(), xl.storageDisks[0], bucket, object)
+		if err != nil {
+			t.Fatalf("Test %d: failed to read xl.json %v", i+1, err)
+		}
+		if xlMeta.Erasure.BlockSize != testCase.expectedBlockSize {
+			t.Errorf("Test %d: expected block size %d, got %d", i+1, testCase.expectedBlockSize, xlMeta.Erasure.BlockSize)
+		}
+
+		// Ranges across several blocks are read back.
+		var buf bytes.Buffer
+		offset, length := int64(humanize.MiByte-5), int64(3*humanize.MiByte)
+		if err = obj.GetObject(context.Background(), bucket, object, offset, length, &buf, "", ObjectOptions{}); err != nil {
+			t.Fatalf("Test %d: failed to getObject %v", i+1, err)
+		}
+		if !bytes.Equal(buf.Bytes(), data[offset:offset+length]) {
+			t.Errorf("Test %d: unexpected content", i+1)
+		}
+	}
+}
+
+// Tests that objects without parity shards left are served without
+// bitrot verification, and flagged as such, only when degraded reads
+// are enabled.
+func TestGetObjectDegradedRead(t *testing.T) {
+	defer func(degraded bool) { globalXLDegradedReads = degraded }(globalXLDegradedReads)
+
+	obj, fsDirs, err := prepareXL16()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer removeRoots(fsDirs)
+
+	xl := obj.(*xlObjects)
+	ctx := context.Background()
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, ""); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 64*humanize.KiByte)
+	if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Take the disks holding parity shards offline and corrupt a
+	// data shard, which can no longer be reconstructed.
+	metaArr, _ := readAllXLMetadata(ctx, xl.getDisks(), bucket, object)
+	corrupted := false
+	for i, meta := range metaArr {
+		if meta.Erasure.Index > meta.Erasure.DataBlocks {
+			xl.storageDisks[i] = nil
+			continue
+		}
+		if corrupted {
+			continue
+		}
+		partPath := pathJoin(xl.storageDisks[i].String(), bucket, object, "part.1")
+		part, rerr := ioutil.ReadFile(partPath)
+		if rerr != nil {
+			t.Fatal(rerr)
+		}
+		part[len(part)-1] ^= 0xff
+		if err = ioutil.WriteFile(partPath, part, 0644); err != nil {
+			t.Fatal(err)
+		}
+		corrupted = true
+	}
+
+	globalXLDegradedReads = false
+	if err = xl.GetObject(ctx, bucket, object, 0, int64(len(data)), ioutil.Discard, "", ObjectOptions{}); err == nil {
+		t.Fatal("Expected reading a corrupted object without parity to fail")
+	}
+	objInfo, err := xl.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.DegradedRead {
+		t.Fatal("Expected object not to be flagged as a degraded read")
+	}
+
+	globalXLDegradedReads = true
+	var buf bytes.Buffer
+	if err = xl.GetObject(ctx, bucket, object, 0, int64(len(data)), &buf, "", ObjectOptions{}); err != nil {
+		t.Fatalf("Expected degraded read to succeed, got %v", err)
+	}
+	if buf.Len() != len(data) {
+		t.Fatalf("Expected %d bytes, got %d", len(data), buf.Len())
+	}
+	objInfo, err = xl.GetObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !objInfo.DegradedRead {
+		t.Fatal("Expected object to be flagged as a degraded read")
+	}
+}
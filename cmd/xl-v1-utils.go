@@ -132,7 +132,7 @@ func readXLMetaParts(ctx context.Context, disk StorageAPI, bucket string, object
 	}
 
 	var xlMeta xlMetaV1
-	xlMeta, err = xlMetaV1UnmarshalJSON(ctx, xlMetaBuf)
+	xlMeta, err = xlMetaV1Unmarshal(ctx, xlMetaBuf)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -151,7 +151,7 @@ func readXLMetaStat(ctx context.Context, disk StorageAPI, bucket string, object
 	}
 
 	var xlMeta xlMetaV1
-	xlMeta, err = xlMetaV1UnmarshalJSON(ctx, xlMetaBuf)
+	xlMeta, err = xlMetaV1Unmarshal(ctx, xlMetaBuf)
 	if err != nil {
 		return si, mp, err
 	}
@@ -174,7 +174,7 @@ func readXLMeta(ctx context.Context, disk StorageAPI, bucket string, object stri
 	if len(xlMetaBuf) == 0 {
 		return xlMetaV1{}, errFileNotFound
 	}
-	return xlMetaV1UnmarshalJSON(ctx, xlMetaBuf)
+	return xlMetaV1Unmarshal(ctx, xlMetaBuf)
 }
 
 // Reads all `xl.json` metadata as a xlMetaV1 slice.
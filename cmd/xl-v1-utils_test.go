@@ -336,6 +336,52 @@ func TestGetXLMetaV1Jsoniter10(t *testing.T) {
 	compareXLMetaV1(t, unMarshalXLMeta, jsoniterXLMeta)
 }
 
+// Tests that XLMetaV1 encoded in the v2 format is decoded to the same
+// metadata as its JSON encoding, and that both formats are detected.
+func TestXLMetaV2Unmarshal(t *testing.T) {
+	for _, totalParts := range []int{0, 1, 10} {
+		xlMeta := getSampleXLMeta(totalParts)
+		xlMeta.Minio.Release = "DEVELOPMENT.GOGET"
+
+		xlMetaJSON, err := json.Marshal(xlMeta)
+		if err != nil {
+			t.Fatalf("Marshalling failed: %v", err)
+		}
+		var unMarshalXLMeta xlMetaV1
+		if err = json.Unmarshal(xlMetaJSON, &unMarshalXLMeta); err != nil {
+			t.Fatalf("Unmarshalling failed: %v", err)
+		}
+
+		xlMetaBuf := xlMetaV2Marshal(xlMeta)
+		if !isXLMetaV2(xlMetaBuf) {
+			t.Fatalf("Expected %d parts to be encoded in the v2 format", totalParts)
+		}
+		v2XLMeta, err := xlMetaV1Unmarshal(context.Background(), xlMetaBuf)
+		if err != nil {
+			t.Fatalf("Parsing of v2 XLMeta with %d parts failed: %v", totalParts, err)
+		}
+		compareXLMetaV1(t, unMarshalXLMeta, v2XLMeta)
+		if v2XLMeta.Minio.Release != xlMeta.Minio.Release {
+			t.Errorf("Expected release %s, got %s", xlMeta.Minio.Release, v2XLMeta.Minio.Release)
+		}
+
+		// Truncated metadata is rejected.
+		if _, err = xlMetaV1Unmarshal(context.Background(), xlMetaBuf[:len(xlMetaBuf)/2]); err == nil {
+			t.Errorf("Expected truncated v2 XLMeta with %d parts to fail", totalParts)
+		}
+
+		// JSON metadata is still read.
+		if isXLMetaV2(xlMetaJSON) {
+			t.Fatalf("Expected %d parts not to be encoded in the v2 format", totalParts)
+		}
+		jsonXLMeta, err := xlMetaV1Unmarshal(context.Background(), xlMetaJSON)
+		if err != nil {
+			t.Fatalf("Parsing of v1 XLMeta with %d parts failed: %v", totalParts, err)
+		}
+		compareXLMetaV1(t, unMarshalXLMeta, jsonXLMeta)
+	}
+}
+
 // Test the predicted part size from the part index
 func TestGetPartSizeFromIdx(t *testing.T) {
 	// Create test cases
@@ -122,15 +122,16 @@ func sortValidDisksInfo(disksInfo []DiskInfo) []DiskInfo {
 	return validDisksInfo
 }
 
-// Get an aggregated storage info across all disks.
-func getStorageInfo(disks []StorageAPI) StorageInfo {
+// Get an aggregated storage info across all disks, along with
+// the info of each disk in the order of disks.
+func getStorageInfo(disks []StorageAPI) (StorageInfo, []DiskInfo) {
 	disksInfo, onlineDisks, offlineDisks := getDisksInfo(disks)
 
 	// Sort so that the first element is the smallest.
 	validDisksInfo := sortValidDisksInfo(disksInfo)
 	// If there are no valid disks, set total and free disks to 0
 	if len(validDisksInfo) == 0 {
-		return StorageInfo{}
+		return StorageInfo{}, disksInfo
 	}
 
 	// Combine all disks to get total usage
@@ -151,10 +152,11 @@ func getStorageInfo(disks []StorageAPI) StorageInfo {
 	storageInfo.Backend.OnlineDisks = onlineDisks
 	storageInfo.Backend.OfflineDisks = offlineDisks
 
-	return storageInfo
+	return storageInfo, disksInfo
 }
 
 // StorageInfo - returns underlying storage statistics.
 func (xl xlObjects) StorageInfo(ctx context.Context) StorageInfo {
-	return getStorageInfo(xl.getDisks())
+	storageInfo, _ := getStorageInfo(xl.getDisks())
+	return storageInfo
 }
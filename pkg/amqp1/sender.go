@@ -0,0 +1,684 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package amqp1 implements a minimal AMQP 1.0 client, publishing
+// messages over a single sender link, as needed by notification
+// targets. Messages are sent unsettled and Send returns once the
+// broker has accepted them.
+package amqp1
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"sync"
+	"time"
+)
+
+// Frame types.
+const (
+	frameAMQP = 0x00
+	frameSASL = 0x01
+)
+
+// Performative, SASL frame, delivery state and message section codes.
+const (
+	codeOpen        = 0x10
+	codeBegin       = 0x11
+	codeAttach      = 0x12
+	codeFlow        = 0x13
+	codeTransfer    = 0x14
+	codeDisposition = 0x15
+	codeDetach      = 0x16
+	codeEnd         = 0x17
+	codeClose       = 0x18
+	codeError       = 0x1d
+	codeAccepted    = 0x24
+	codeRejected    = 0x25
+	codeReleased    = 0x26
+	codeModified    = 0x27
+	codeSource      = 0x28
+	codeTarget      = 0x29
+
+	codeSASLMechanisms = 0x40
+	codeSASLInit       = 0x41
+	codeSASLOutcome    = 0x44
+
+	codeProperties = 0x73
+	codeData       = 0x75
+)
+
+const (
+	// Size of the frame header, frames use no extended header.
+	frameHeaderSize = 8
+
+	// Maximum size of the frames received.
+	maxFrameSize = 64 * 1024
+
+	// Smallest maximum frame size allowed by the specification.
+	minMaxFrameSize = 512
+)
+
+var (
+	protoHeaderAMQP = []byte{'A', 'M', 'Q', 'P', 0, 1, 0, 0}
+	protoHeaderSASL = []byte{'A', 'M', 'Q', 'P', 3, 1, 0, 0}
+)
+
+// ErrClosed is returned when sending over a closed sender.
+var ErrClosed = errors.New("amqp1: sender closed")
+
+// Error - error condition sent by the broker, on closing the
+// connection, session or link, or on rejecting a message.
+type Error struct {
+	Condition   string
+	Description string
+}
+
+func (e *Error) Error() string {
+	if e.Description == "" {
+		return "amqp1: " + e.Condition
+	}
+	return fmt.Sprintf("amqp1: %s: %s", e.Condition, e.Description)
+}
+
+// newError - converts an AMQP error to *Error, nil if v is not set.
+func newError(v interface{}, fallback string) error {
+	d, ok := v.(described)
+	if !ok || d.code != codeError {
+		if fallback == "" {
+			return nil
+		}
+		return &Error{Condition: fallback}
+	}
+	e := &Error{}
+	if c, ok := d.field(0).(symbol); ok {
+		e.Condition = string(c)
+	}
+	e.Description, _ = d.field(1).(string)
+	return e
+}
+
+// Options - options of the connection to the broker.
+type Options struct {
+	// Username and Password of SASL PLAIN authentication, SASL
+	// ANONYMOUS is used when Username is empty.
+	Username string
+	Password string
+
+	// TLSConfig enables TLS when set.
+	TLSConfig *tls.Config
+
+	// Hostname sent to the broker, as the virtual host, defaults
+	// to the host of the address.
+	Hostname string
+
+	// ContainerID identifies the client to the broker.
+	ContainerID string
+
+	// DialTimeout bounds the time taken to connect, authenticate
+	// and attach the link.
+	DialTimeout time.Duration
+}
+
+// Message - message sent to the broker.
+type Message struct {
+	Subject     string
+	ContentType string
+	Data        []byte
+}
+
+// frame - AMQP or SASL frame, body is nil for empty frames.
+type frame struct {
+	typ     byte
+	channel uint16
+	body    *described
+	payload []byte
+}
+
+func writeFrame(w io.Writer, typ byte, body interface{}, payload []byte) error {
+	var buf bytes.Buffer
+	buf.Write(make([]byte, frameHeaderSize))
+	if body != nil {
+		if err := encode(&buf, body); err != nil {
+			return err
+		}
+	}
+	buf.Write(payload)
+
+	data := buf.Bytes()
+	binary.BigEndian.PutUint32(data[0:4], uint32(len(data)))
+	data[4] = frameHeaderSize / 4
+	data[5] = typ
+	// Channel 0 only, in bytes 6 and 7.
+	_, err := w.Write(data)
+	return err
+}
+
+func readFrame(r io.Reader) (f frame, err error) {
+	header := make([]byte, frameHeaderSize)
+	if _, err = io.ReadFull(r, header); err != nil {
+		return f, err
+	}
+	size := binary.BigEndian.Uint32(header[0:4])
+	offset := uint32(header[4]) * 4
+	if offset < frameHeaderSize || size < offset || size > maxFrameSize {
+		return f, errMalformed
+	}
+	f.typ = header[5]
+	f.channel = binary.BigEndian.Uint16(header[6:8])
+
+	data := make([]byte, size-frameHeaderSize)
+	if _, err = io.ReadFull(r, data); err != nil {
+		return f, err
+	}
+	data = data[offset-frameHeaderSize:]
+	if len(data) == 0 {
+		return f, nil
+	}
+
+	body := bytes.NewReader(data)
+	v, err := decode(body)
+	if err != nil {
+		return f, err
+	}
+	d, ok := v.(described)
+	if !ok {
+		return f, errMalformed
+	}
+	f.body = &d
+	f.payload = data[len(data)-body.Len():]
+	return f, nil
+}
+
+// Sender - AMQP 1.0 connection with a single session and sender link.
+type Sender struct {
+	conn net.Conn
+
+	// Serializes the frames written, a message split over several
+	// transfer frames is written at once.
+	writeMu sync.Mutex
+
+	mu             sync.Mutex
+	err            error
+	remoteMaxFrame uint32
+	deliveryCount  uint32
+	credit         uint32
+	nextDeliveryID uint32
+	pending        map[uint32]chan error
+
+	// Signaled when credit is granted.
+	creditCh chan struct{}
+
+	// Closed when the connection fails or is closed.
+	doneCh chan struct{}
+}
+
+// Dial - connects to the broker at addr and attaches a sender link
+// to the node address, a queue or a topic.
+func Dial(addr, address string, opts Options) (*Sender, error) {
+	if opts.DialTimeout == 0 {
+		opts.DialTimeout = 30 * time.Second
+	}
+	if opts.Hostname == "" {
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			return nil, err
+		}
+		opts.Hostname = host
+	}
+	if opts.ContainerID == "" {
+		opts.ContainerID = "minio"
+	}
+
+	conn, err := net.DialTimeout("tcp", addr, opts.DialTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if opts.TLSConfig != nil {
+		tlsConfig := opts.TLSConfig.Clone()
+		if tlsConfig.ServerName == "" {
+			tlsConfig.ServerName = opts.Hostname
+		}
+		conn = tls.Client(conn, tlsConfig)
+	}
+
+	s := &Sender{
+		conn:           conn,
+		remoteMaxFrame: minMaxFrameSize,
+		pending:        make(map[uint32]chan error),
+		creditCh:       make(chan struct{}, 1),
+		doneCh:         make(chan struct{}),
+	}
+
+	conn.SetDeadline(time.Now().Add(opts.DialTimeout))
+	if err = s.authenticate(opts); err == nil {
+		err = s.attach(opts, address)
+	}
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	conn.SetDeadline(time.Time{})
+
+	go s.read()
+	return s, nil
+}
+
+// exchangeHeaders - sends the protocol header and checks the one of
+// the broker.
+func (s *Sender) exchangeHeaders(header []byte) error {
+	if _, err := s.conn.Write(header); err != nil {
+		return err
+	}
+	remote := make([]byte, len(header))
+	if _, err := io.ReadFull(s.conn, remote); err != nil {
+		return err
+	}
+	if !bytes.Equal(header, remote) {
+		return fmt.Errorf("amqp1: unsupported protocol header %q", remote)
+	}
+	return nil
+}
+
+// authenticate - performs the SASL exchange.
+func (s *Sender) authenticate(opts Options) error {
+	if err := s.exchangeHeaders(protoHeaderSASL); err != nil {
+		return err
+	}
+
+	f, err := readFrame(s.conn)
+	if err != nil {
+		return err
+	}
+	if f.body == nil || f.body.code != codeSASLMechanisms {
+		return errors.New("amqp1: expected SASL mechanisms")
+	}
+
+	mechanism := symbol("ANONYMOUS")
+	var response []byte
+	if opts.Username != "" {
+		mechanism = "PLAIN"
+		response = []byte("\x00" + opts.Username + "\x00" + opts.Password)
+	}
+	var supported bool
+	switch mechanisms := f.body.field(0).(type) {
+	case symbol:
+		supported = mechanisms == mechanism
+	case []interface{}:
+		for _, m := range mechanisms {
+			supported = supported || m == mechanism
+		}
+	}
+	if !supported {
+		return fmt.Errorf("amqp1: SASL mechanism %s not supported by the broker", mechanism)
+	}
+
+	init := described{codeSASLInit, []interface{}{mechanism, response, opts.Hostname}}
+	if err = writeFrame(s.conn, frameSASL, init, nil); err != nil {
+		return err
+	}
+	if f, err = readFrame(s.conn); err != nil {
+		return err
+	}
+	if f.body == nil || f.body.code != codeSASLOutcome {
+		return errors.New("amqp1: expected SASL outcome")
+	}
+	if code, _ := f.body.field(0).(uint8); code != 0 {
+		return fmt.Errorf("amqp1: SASL authentication failed with code %d", code)
+	}
+	return nil
+}
+
+// attach - opens the connection and the session, and attaches the
+// sender link.
+func (s *Sender) attach(opts Options, address string) error {
+	if err := s.exchangeHeaders(protoHeaderAMQP); err != nil {
+		return err
+	}
+
+	linkName := opts.ContainerID + "-" + address
+	performatives := []described{
+		{codeOpen, []interface{}{opts.ContainerID, opts.Hostname, uint32(maxFrameSize)}},
+		{codeBegin, []interface{}{nil, uint32(0), uint32(math.MaxUint32), uint32(math.MaxUint32)}},
+		{codeAttach, []interface{}{
+			linkName,
+			uint32(0), // handle
+			false,     // role: sender
+			uint8(0),  // sender settle mode: unsettled
+			uint8(0),  // receiver settle mode: first
+			described{codeSource, []interface{}{opts.ContainerID}},
+			described{codeTarget, []interface{}{address}},
+			nil,       // unsettled
+			false,     // incomplete unsettled
+			uint32(0), // initial delivery count
+		}},
+	}
+	for _, p := range performatives {
+		if err := writeFrame(s.conn, frameAMQP, p, nil); err != nil {
+			return err
+		}
+	}
+
+	var opened, begun, attached bool
+	for !(opened && begun && attached) {
+		f, err := readFrame(s.conn)
+		if err != nil {
+			return err
+		}
+		if f.body == nil {
+			continue
+		}
+		switch f.body.code {
+		case codeOpen:
+			opened = true
+			if size, ok := f.body.field(2).(uint32); ok && size >= minMaxFrameSize {
+				s.remoteMaxFrame = size
+			}
+			if timeout, ok := f.body.field(4).(uint32); ok && timeout > 0 {
+				go s.heartbeat(time.Duration(timeout) * time.Millisecond / 2)
+			}
+		case codeBegin:
+			begun = true
+		case codeAttach:
+			// A refused link is attached without target, and
+			// detached right away with the error.
+			if f.body.field(6) == nil {
+				continue
+			}
+			attached = true
+		case codeFlow:
+			s.flow(f.body)
+		case codeDetach:
+			return newError(f.body.field(2), "amqp:link:detach-forced")
+		case codeEnd:
+			return newError(f.body.field(0), "amqp:session:unattached")
+		case codeClose:
+			return newError(f.body.field(0), "amqp:connection:forced")
+		}
+	}
+	return nil
+}
+
+// heartbeat - sends empty frames to keep the connection open within
+// the idle timeout of the broker.
+func (s *Sender) heartbeat(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-s.doneCh:
+			return
+		case <-ticker.C:
+			s.writeMu.Lock()
+			err := writeFrame(s.conn, frameAMQP, nil, nil)
+			s.writeMu.Unlock()
+			if err != nil {
+				s.fail(err)
+				return
+			}
+		}
+	}
+}
+
+// flow - updates the credit of the link.
+func (s *Sender) flow(body *described) {
+	if _, ok := body.field(4).(uint32); !ok {
+		// Session flow only.
+		return
+	}
+	deliveryCount, _ := body.field(5).(uint32)
+	linkCredit, _ := body.field(6).(uint32)
+
+	s.mu.Lock()
+	// Credit is granted relative to the delivery count of the
+	// receiver, which lags behind while transfers are in flight.
+	s.credit = deliveryCount + linkCredit - s.deliveryCount
+	if deliveryCount+linkCredit < s.deliveryCount {
+		s.credit = 0
+	}
+	credit := s.credit
+	s.mu.Unlock()
+
+	if credit > 0 {
+		select {
+		case s.creditCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// disposition - completes the deliveries acknowledged by the broker.
+func (s *Sender) disposition(body *described) {
+	first, ok := body.field(1).(uint32)
+	if !ok {
+		return
+	}
+	last, ok := body.field(2).(uint32)
+	if !ok {
+		last = first
+	}
+
+	var err error
+	state, _ := body.field(4).(described)
+	switch state.code {
+	case codeAccepted:
+	case codeRejected:
+		err = newError(state.field(0), "amqp:rejected")
+	case codeReleased:
+		err = &Error{Condition: "amqp:released"}
+	case codeModified:
+		err = &Error{Condition: "amqp:modified"}
+	default:
+		// Not a terminal state, wait for the next disposition.
+		return
+	}
+
+	s.mu.Lock()
+	for id := first; ; id++ {
+		if ch, ok := s.pending[id]; ok {
+			ch <- err
+			delete(s.pending, id)
+		}
+		if id == last {
+			break
+		}
+	}
+	s.mu.Unlock()
+
+	if settled, _ := body.field(3).(bool); !settled {
+		s.writeMu.Lock()
+		werr := writeFrame(s.conn, frameAMQP, described{codeDisposition, []interface{}{false, first, last, true, state}}, nil)
+		s.writeMu.Unlock()
+		if werr != nil {
+			s.fail(werr)
+		}
+	}
+}
+
+// read - handles the frames of the broker until the connection fails.
+func (s *Sender) read() {
+	for {
+		f, err := readFrame(s.conn)
+		if err != nil {
+			s.fail(err)
+			return
+		}
+		if f.body == nil {
+			continue
+		}
+		switch f.body.code {
+		case codeFlow:
+			s.flow(f.body)
+		case codeDisposition:
+			s.disposition(f.body)
+		case codeDetach:
+			s.fail(newError(f.body.field(2), "amqp:link:detach-forced"))
+			return
+		case codeEnd:
+			s.fail(newError(f.body.field(0), "amqp:session:unattached"))
+			return
+		case codeClose:
+			s.fail(newError(f.body.field(0), "amqp:connection:forced"))
+			return
+		}
+	}
+}
+
+// fail - closes the connection and fails the pending deliveries.
+func (s *Sender) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.err != nil {
+		return
+	}
+	s.err = err
+	close(s.doneCh)
+	s.conn.Close()
+	for id, ch := range s.pending {
+		ch <- err
+		delete(s.pending, id)
+	}
+}
+
+// Err - returns the error which closed the sender, nil while it is
+// connected.
+func (s *Sender) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Send - sends msg and waits until the broker accepts it.
+func (s *Sender) Send(ctx context.Context, msg Message) error {
+	var payload bytes.Buffer
+	properties := make([]interface{}, 7)
+	if msg.Subject != "" {
+		properties[3] = msg.Subject
+	}
+	if msg.ContentType != "" {
+		properties[6] = symbol(msg.ContentType)
+	}
+	if err := encode(&payload, described{codeProperties, properties}); err != nil {
+		return err
+	}
+	if err := encode(&payload, described{codeData, msg.Data}); err != nil {
+		return err
+	}
+
+	// Wait for credit to send.
+	var id uint32
+	ackCh := make(chan error, 1)
+	for {
+		s.mu.Lock()
+		if s.err != nil {
+			s.mu.Unlock()
+			return s.err
+		}
+		if s.credit > 0 {
+			s.credit--
+			id = s.nextDeliveryID
+			s.nextDeliveryID++
+			s.deliveryCount++
+			s.pending[id] = ackCh
+			s.mu.Unlock()
+			break
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.creditCh:
+		case <-s.doneCh:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	if err := s.transfer(id, payload.Bytes()); err != nil {
+		s.fail(err)
+		return err
+	}
+
+	select {
+	case err := <-ackCh:
+		return err
+	case <-ctx.Done():
+		s.mu.Lock()
+		delete(s.pending, id)
+		s.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+// transfer - writes the message payload, split over as many transfer
+// frames as the maximum frame size of the broker requires.
+func (s *Sender) transfer(id uint32, payload []byte) error {
+	tag := make([]byte, 4)
+	binary.BigEndian.PutUint32(tag, id)
+
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+
+	for first := true; first || len(payload) > 0; first = false {
+		fields := []interface{}{uint32(0), nil, nil, nil, nil, false}
+		if first {
+			fields[1], fields[2], fields[3], fields[4] = id, tag, uint32(0), false
+		}
+
+		var body bytes.Buffer
+		if err := encode(&body, described{codeTransfer, fields}); err != nil {
+			return err
+		}
+		size := int(s.remoteMaxFrame) - frameHeaderSize - body.Len()
+		if size <= 0 {
+			return errors.New("amqp1: maximum frame size of the broker too small")
+		}
+
+		chunk := payload
+		if len(chunk) > size {
+			chunk = chunk[:size]
+			fields[5] = true
+		}
+		if err := writeFrame(s.conn, frameAMQP, described{codeTransfer, fields}, chunk); err != nil {
+			return err
+		}
+		payload = payload[len(chunk):]
+	}
+	return nil
+}
+
+// Close - detaches the link and closes the connection.
+func (s *Sender) Close() error {
+	s.writeMu.Lock()
+	for _, p := range []described{
+		{codeDetach, []interface{}{uint32(0), true}},
+		{codeEnd, []interface{}{}},
+		{codeClose, []interface{}{}},
+	} {
+		if err := writeFrame(s.conn, frameAMQP, p, nil); err != nil {
+			break
+		}
+	}
+	s.writeMu.Unlock()
+
+	s.fail(ErrClosed)
+	return nil
+}
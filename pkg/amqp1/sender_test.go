@@ -0,0 +1,273 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package amqp1
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEncodeDecode(t *testing.T) {
+	testCases := []interface{}{
+		nil,
+		true,
+		false,
+		uint8(7),
+		uint16(5672),
+		uint32(1 << 20),
+		uint64(1 << 40),
+		"minio",
+		strings.Repeat("x", 300),
+		symbol("PLAIN"),
+		[]byte{1, 2, 3},
+		[]interface{}{},
+		[]interface{}{"container", nil, uint32(512)},
+		described{codeOpen, []interface{}{"container", "localhost"}},
+		described{codeTransfer, []interface{}{uint32(0), uint32(1), []byte{0, 0, 0, 1}, uint32(0), false, false}},
+	}
+
+	for i, testCase := range testCases {
+		var buf bytes.Buffer
+		if err := encode(&buf, testCase); err != nil {
+			t.Fatalf("test %v: %v", i+1, err)
+		}
+		r := bytes.NewReader(buf.Bytes())
+		v, err := decode(r)
+		if err != nil {
+			t.Fatalf("test %v: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(v, testCase) {
+			t.Fatalf("test %v: expected: %#v, got: %#v", i+1, testCase, v)
+		}
+		if r.Len() != 0 {
+			t.Fatalf("test %v: %d trailing bytes", i+1, r.Len())
+		}
+	}
+}
+
+func TestDecodeCompact(t *testing.T) {
+	testCases := []struct {
+		data     []byte
+		expected interface{}
+	}{
+		{[]byte{typeUint0}, uint32(0)},
+		{[]byte{typeSmallUint, 9}, uint32(9)},
+		{[]byte{typeSmallUlong, 0x10}, uint64(0x10)},
+		{[]byte{typeList8, 3, 2, typeTrue, typeNull}, []interface{}{true, nil}},
+		{[]byte{typeMap8, 5, 2, typeSym8, 1, 'k', typeFalse}, amqpMap{symbol("k"), false}},
+		{[]byte{typeArray8, 9, 2, typeSym8, 5, 'P', 'L', 'A', 'I', 'N', 0}, []interface{}{symbol("PLAIN"), symbol("")}},
+	}
+
+	for i, testCase := range testCases {
+		v, err := decode(bytes.NewReader(testCase.data))
+		if err != nil {
+			t.Fatalf("test %v: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(v, testCase.expected) {
+			t.Fatalf("test %v: expected: %#v, got: %#v", i+1, testCase.expected, v)
+		}
+	}
+
+	for _, data := range [][]byte{{}, {typeStr8, 10, 'a'}, {typeList8, 2, 5, typeNull}, {0xff}} {
+		if _, err := decode(bytes.NewReader(data)); err == nil {
+			t.Fatalf("expected error decoding %v", data)
+		}
+	}
+}
+
+// testBroker - accepts a single connection, grants credit and settles
+// the transfers with the outcome of reject.
+type testBroker struct {
+	listener net.Listener
+	maxFrame uint32
+	reject   func(data []byte) bool
+	received chan []byte
+	username chan string
+}
+
+func newTestBroker(t *testing.T, maxFrame uint32) *testBroker {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &testBroker{
+		listener: l,
+		maxFrame: maxFrame,
+		reject:   func([]byte) bool { return false },
+		received: make(chan []byte, 10),
+		username: make(chan string, 1),
+	}
+	go b.serve()
+	return b
+}
+
+func (b *testBroker) serve() {
+	conn, err := b.listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	header := make([]byte, 8)
+	if _, err = io.ReadFull(conn, header); err != nil {
+		return
+	}
+	conn.Write(protoHeaderSASL)
+	writeFrame(conn, frameSASL, described{codeSASLMechanisms, []interface{}{symbol("PLAIN")}}, nil)
+	f, err := readFrame(conn)
+	if err != nil {
+		return
+	}
+	response, _ := f.body.field(1).([]byte)
+	b.username <- strings.Split(string(response), "\x00")[1]
+	writeFrame(conn, frameSASL, described{codeSASLOutcome, []interface{}{uint8(0)}}, nil)
+
+	if _, err = io.ReadFull(conn, header); err != nil {
+		return
+	}
+	conn.Write(protoHeaderAMQP)
+
+	var message []byte
+	var id uint32
+	for {
+		f, err := readFrame(conn)
+		if err != nil || f.body == nil {
+			return
+		}
+		switch f.body.code {
+		case codeOpen:
+			writeFrame(conn, frameAMQP, described{codeOpen, []interface{}{"broker", nil, b.maxFrame}}, nil)
+		case codeBegin:
+			writeFrame(conn, frameAMQP, described{codeBegin, []interface{}{uint16(0), uint32(0), uint32(100), uint32(100)}}, nil)
+		case codeAttach:
+			attach := f.body.value.([]interface{})
+			attach[2] = true
+			writeFrame(conn, frameAMQP, described{codeAttach, attach}, nil)
+			writeFrame(conn, frameAMQP, described{codeFlow, []interface{}{
+				uint32(0), uint32(100), uint32(0), uint32(100), uint32(0), uint32(0), uint32(1),
+			}}, nil)
+		case codeTransfer:
+			// The delivery id is only set on the first frame.
+			if deliveryID, ok := f.body.field(1).(uint32); ok {
+				id = deliveryID
+			}
+			message = append(message, f.payload...)
+			if more, _ := f.body.field(5).(bool); more {
+				continue
+			}
+			b.received <- message
+			state := described{codeAccepted, []interface{}{}}
+			if b.reject(message) {
+				state = described{codeRejected, []interface{}{described{codeError, []interface{}{symbol("amqp:not-allowed"), "rejected"}}}}
+			}
+			writeFrame(conn, frameAMQP, described{codeDisposition, []interface{}{true, id, id, true, state}}, nil)
+			// Grant credit for the next message.
+			writeFrame(conn, frameAMQP, described{codeFlow, []interface{}{
+				uint32(0), uint32(100), uint32(0), uint32(100), uint32(0), id + 1, uint32(1),
+			}}, nil)
+			message = nil
+		case codeClose:
+			return
+		}
+	}
+}
+
+// messageData - returns the data section of an encoded message.
+func messageData(t *testing.T, message []byte) []byte {
+	r := bytes.NewReader(message)
+	for r.Len() > 0 {
+		v, err := decode(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if d := v.(described); d.code == codeData {
+			return d.value.([]byte)
+		}
+	}
+	t.Fatal("no data section")
+	return nil
+}
+
+func TestSender(t *testing.T) {
+	b := newTestBroker(t, 1024)
+	defer b.listener.Close()
+	b.reject = func(message []byte) bool {
+		return bytes.Contains(message, []byte("reject"))
+	}
+
+	s, err := Dial(b.listener.Addr().String(), "events", Options{
+		Username:    "minio",
+		Password:    "minio123",
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	if username := <-b.username; username != "minio" {
+		t.Fatalf("expected username minio, got %s", username)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Larger than the maximum frame size of the broker.
+	data := bytes.Repeat([]byte("event"), 1000)
+	if err = s.Send(ctx, Message{Subject: "s3:ObjectCreated:Put", ContentType: "application/json", Data: data}); err != nil {
+		t.Fatal(err)
+	}
+	if received := messageData(t, <-b.received); !bytes.Equal(received, data) {
+		t.Fatalf("unexpected data received, %d bytes", len(received))
+	}
+
+	err = s.Send(ctx, Message{Data: []byte("reject")})
+	if e, ok := err.(*Error); !ok || e.Condition != "amqp:not-allowed" {
+		t.Fatalf("expected rejection, got %v", err)
+	}
+	<-b.received
+
+	if err = s.Send(ctx, Message{Data: []byte("accept")}); err != nil {
+		t.Fatal(err)
+	}
+	<-b.received
+}
+
+func TestSenderClosed(t *testing.T) {
+	b := newTestBroker(t, maxFrameSize)
+	defer b.listener.Close()
+
+	s, err := Dial(b.listener.Addr().String(), "events", Options{Username: "minio", DialTimeout: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	<-b.username
+	s.Close()
+
+	if err = s.Send(context.Background(), Message{Data: []byte("event")}); err != ErrClosed {
+		t.Fatalf("expected %v, got %v", ErrClosed, err)
+	}
+	if s.Err() != ErrClosed {
+		t.Fatalf("expected %v, got %v", ErrClosed, s.Err())
+	}
+}
@@ -0,0 +1,342 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package amqp1
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Type constructors of the AMQP 1.0 type system, as defined in
+// section 1.6 of the specification.
+const (
+	typeDescribed  = 0x00
+	typeNull       = 0x40
+	typeTrue       = 0x41
+	typeFalse      = 0x42
+	typeUint0      = 0x43
+	typeUlong0     = 0x44
+	typeList0      = 0x45
+	typeUbyte      = 0x50
+	typeByte       = 0x51
+	typeSmallUint  = 0x52
+	typeSmallUlong = 0x53
+	typeSmallInt   = 0x54
+	typeSmallLong  = 0x55
+	typeBoolean    = 0x56
+	typeUshort     = 0x60
+	typeShort      = 0x61
+	typeUint       = 0x70
+	typeInt        = 0x71
+	typeFloat      = 0x72
+	typeChar       = 0x73
+	typeDecimal32  = 0x74
+	typeUlong      = 0x80
+	typeLong       = 0x81
+	typeDouble     = 0x82
+	typeTimestamp  = 0x83
+	typeDecimal64  = 0x84
+	typeDecimal128 = 0x94
+	typeUUID       = 0x98
+	typeVbin8      = 0xa0
+	typeStr8       = 0xa1
+	typeSym8       = 0xa3
+	typeVbin32     = 0xb0
+	typeStr32      = 0xb1
+	typeSym32      = 0xb3
+	typeList8      = 0xc0
+	typeMap8       = 0xc1
+	typeList32     = 0xd0
+	typeMap32      = 0xd1
+	typeArray8     = 0xe0
+	typeArray32    = 0xf0
+)
+
+var errMalformed = errors.New("amqp1: malformed data")
+
+// symbol - AMQP symbolic value, an ASCII string.
+type symbol string
+
+// described - AMQP described type, performatives and message sections
+// are lists described by a numeric code. Symbolic descriptors are not
+// used by this package and are decoded with code 0.
+type described struct {
+	code  uint64
+	value interface{}
+}
+
+// amqpMap - decoded AMQP map, as alternating keys and values.
+type amqpMap []interface{}
+
+// field - returns the i-th field of a described list, nil if the list
+// is shorter, as trailing null fields may be omitted.
+func (d described) field(i int) interface{} {
+	list, ok := d.value.([]interface{})
+	if !ok || i >= len(list) {
+		return nil
+	}
+	return list[i]
+}
+
+// encode - appends the AMQP encoding of v to buf.
+func encode(buf *bytes.Buffer, v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteByte(typeNull)
+	case bool:
+		if v {
+			buf.WriteByte(typeTrue)
+		} else {
+			buf.WriteByte(typeFalse)
+		}
+	case uint8:
+		buf.WriteByte(typeUbyte)
+		buf.WriteByte(v)
+	case uint16:
+		buf.WriteByte(typeUshort)
+		binary.Write(buf, binary.BigEndian, v)
+	case uint32:
+		buf.WriteByte(typeUint)
+		binary.Write(buf, binary.BigEndian, v)
+	case uint64:
+		buf.WriteByte(typeUlong)
+		binary.Write(buf, binary.BigEndian, v)
+	case string:
+		writeVariable(buf, typeStr8, typeStr32, []byte(v))
+	case symbol:
+		writeVariable(buf, typeSym8, typeSym32, []byte(v))
+	case []byte:
+		writeVariable(buf, typeVbin8, typeVbin32, v)
+	case []interface{}:
+		if len(v) == 0 {
+			buf.WriteByte(typeList0)
+			return nil
+		}
+		var elems bytes.Buffer
+		for _, elem := range v {
+			if err := encode(&elems, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(typeList32)
+		binary.Write(buf, binary.BigEndian, uint32(elems.Len()+4))
+		binary.Write(buf, binary.BigEndian, uint32(len(v)))
+		buf.Write(elems.Bytes())
+	case described:
+		buf.WriteByte(typeDescribed)
+		buf.WriteByte(typeSmallUlong)
+		if v.code > math.MaxUint8 {
+			return fmt.Errorf("amqp1: descriptor 0x%x out of range", v.code)
+		}
+		buf.WriteByte(byte(v.code))
+		return encode(buf, v.value)
+	default:
+		return fmt.Errorf("amqp1: unsupported type %T", v)
+	}
+	return nil
+}
+
+func writeVariable(buf *bytes.Buffer, small, large byte, data []byte) {
+	if len(data) <= math.MaxUint8 {
+		buf.WriteByte(small)
+		buf.WriteByte(byte(len(data)))
+	} else {
+		buf.WriteByte(large)
+		binary.Write(buf, binary.BigEndian, uint32(len(data)))
+	}
+	buf.Write(data)
+}
+
+// decode - decodes the next AMQP value of r.
+func decode(r *bytes.Reader) (interface{}, error) {
+	c, err := r.ReadByte()
+	if err != nil {
+		return nil, errMalformed
+	}
+	if c == typeDescribed {
+		descriptor, err := decode(r)
+		if err != nil {
+			return nil, err
+		}
+		value, err := decode(r)
+		if err != nil {
+			return nil, err
+		}
+		code, _ := descriptor.(uint64)
+		return described{code: code, value: value}, nil
+	}
+	return decodeValue(r, c)
+}
+
+// decodeValue - decodes the AMQP value of constructor c.
+func decodeValue(r *bytes.Reader, c byte) (interface{}, error) {
+	switch c {
+	case typeNull:
+		return nil, nil
+	case typeTrue:
+		return true, nil
+	case typeFalse:
+		return false, nil
+	case typeUint0:
+		return uint32(0), nil
+	case typeUlong0:
+		return uint64(0), nil
+	case typeList0:
+		return []interface{}{}, nil
+	}
+
+	// Fixed width values, the width is given by the subcategory
+	// of the constructor.
+	var width int
+	switch c >> 4 {
+	case 0x5:
+		width = 1
+	case 0x6:
+		width = 2
+	case 0x7:
+		width = 4
+	case 0x8:
+		width = 8
+	case 0x9:
+		width = 16
+	}
+	if width > 0 {
+		data := make([]byte, width)
+		if _, err := io.ReadFull(r, data); err != nil {
+			return nil, errMalformed
+		}
+		switch c {
+		case typeBoolean:
+			return data[0] != 0, nil
+		case typeUbyte:
+			return data[0], nil
+		case typeByte:
+			return int8(data[0]), nil
+		case typeSmallUint:
+			return uint32(data[0]), nil
+		case typeSmallUlong:
+			return uint64(data[0]), nil
+		case typeSmallInt:
+			return int32(int8(data[0])), nil
+		case typeSmallLong:
+			return int64(int8(data[0])), nil
+		case typeUshort:
+			return binary.BigEndian.Uint16(data), nil
+		case typeShort:
+			return int16(binary.BigEndian.Uint16(data)), nil
+		case typeUint, typeChar:
+			return binary.BigEndian.Uint32(data), nil
+		case typeInt:
+			return int32(binary.BigEndian.Uint32(data)), nil
+		case typeFloat:
+			return math.Float32frombits(binary.BigEndian.Uint32(data)), nil
+		case typeUlong:
+			return binary.BigEndian.Uint64(data), nil
+		case typeLong, typeTimestamp:
+			return int64(binary.BigEndian.Uint64(data)), nil
+		case typeDouble:
+			return math.Float64frombits(binary.BigEndian.Uint64(data)), nil
+		case typeDecimal32, typeDecimal64, typeDecimal128, typeUUID:
+			return data, nil
+		}
+		return nil, fmt.Errorf("amqp1: unknown type constructor 0x%x", c)
+	}
+
+	// Variable width and compound values, preceded by their size.
+	var size uint32
+	switch c {
+	case typeVbin8, typeStr8, typeSym8, typeList8, typeMap8, typeArray8:
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, errMalformed
+		}
+		size = uint32(b)
+	case typeVbin32, typeStr32, typeSym32, typeList32, typeMap32, typeArray32:
+		if err := binary.Read(r, binary.BigEndian, &size); err != nil {
+			return nil, errMalformed
+		}
+	default:
+		return nil, fmt.Errorf("amqp1: unknown type constructor 0x%x", c)
+	}
+	if int64(size) > int64(r.Len()) {
+		return nil, errMalformed
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, errMalformed
+	}
+
+	switch c {
+	case typeVbin8, typeVbin32:
+		return data, nil
+	case typeStr8, typeStr32:
+		return string(data), nil
+	case typeSym8, typeSym32:
+		return symbol(data), nil
+	}
+
+	// Compound values start with the count of their elements.
+	body := bytes.NewReader(data)
+	var count uint32
+	if c == typeList8 || c == typeMap8 || c == typeArray8 {
+		b, err := body.ReadByte()
+		if err != nil {
+			return nil, errMalformed
+		}
+		count = uint32(b)
+	} else if err := binary.Read(body, binary.BigEndian, &count); err != nil {
+		return nil, errMalformed
+	}
+	if int64(count) > int64(body.Len()) {
+		return nil, errMalformed
+	}
+
+	// Elements of an array share a single constructor.
+	var elemConstructor byte
+	if c == typeArray8 || c == typeArray32 {
+		var err error
+		if elemConstructor, err = body.ReadByte(); err != nil {
+			return nil, errMalformed
+		}
+		if elemConstructor == typeDescribed {
+			return nil, errors.New("amqp1: arrays of described types are not supported")
+		}
+	}
+
+	elems := make([]interface{}, 0, count)
+	for i := uint32(0); i < count; i++ {
+		var elem interface{}
+		var err error
+		if elemConstructor != 0 {
+			elem, err = decodeValue(body, elemConstructor)
+		} else {
+			elem, err = decode(body)
+		}
+		if err != nil {
+			return nil, err
+		}
+		elems = append(elems, elem)
+	}
+	if c == typeMap8 || c == typeMap32 {
+		return amqpMap(elems), nil
+	}
+	return elems, nil
+}
@@ -0,0 +1,89 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucketdefaults
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"path"
+	"strings"
+)
+
+var (
+	errDefaultsEmpty            = errors.New("Bucket defaults should set a cache control, a content disposition or content types")
+	errDefaultsInvalidExtension = errors.New("Bucket defaults content type extensions should start with a dot and not contain a slash")
+)
+
+// Config - default response headers of the objects of a bucket, used
+// when an object was stored without them.
+type Config struct {
+	CacheControl       string `json:"cacheControl,omitempty"`
+	ContentDisposition string `json:"contentDisposition,omitempty"`
+	// ContentTypes - content types by object name extension, e.g. ".md".
+	ContentTypes map[string]string `json:"contentTypes,omitempty"`
+}
+
+// Validate - validates the bucket defaults.
+func (c Config) Validate() error {
+	if c.CacheControl == "" && c.ContentDisposition == "" && len(c.ContentTypes) == 0 {
+		return errDefaultsEmpty
+	}
+	if c.ContentDisposition != "" {
+		if _, _, err := mime.ParseMediaType(c.ContentDisposition); err != nil {
+			return fmt.Errorf("Bucket defaults content disposition %s is invalid: %v", c.ContentDisposition, err)
+		}
+	}
+	for ext, contentType := range c.ContentTypes {
+		if !strings.HasPrefix(ext, ".") || len(ext) == 1 || strings.Contains(ext, "/") {
+			return errDefaultsInvalidExtension
+		}
+		if _, _, err := mime.ParseMediaType(contentType); err != nil {
+			return fmt.Errorf("Bucket defaults content type %s of %s is invalid: %v", contentType, ext, err)
+		}
+	}
+	return nil
+}
+
+// ContentType - returns the default content type of object,
+// matching its extension case insensitively.
+func (c Config) ContentType(object string) (string, bool) {
+	ext := path.Ext(object)
+	if ext == "" {
+		return "", false
+	}
+	for e, contentType := range c.ContentTypes {
+		if strings.EqualFold(e, ext) {
+			return contentType, true
+		}
+	}
+	return "", false
+}
+
+// ParseConfig - parses bucket defaults from JSON.
+func ParseConfig(reader io.Reader) (*Config, error) {
+	var config Config
+	if err := json.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
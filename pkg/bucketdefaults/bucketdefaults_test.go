@@ -0,0 +1,67 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucketdefaults
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		{`{"cacheControl": "max-age=3600"}`, false},
+		{`{"contentDisposition": "attachment; filename=\"report.pdf\""}`, false},
+		{`{"contentTypes": {".md": "text/markdown; charset=utf-8"}}`, false},
+		{`{}`, true},
+		{`{"contentDisposition": "attachment; filename="}`, true},
+		{`{"contentTypes": {"md": "text/markdown"}}`, true},
+		{`{"contentTypes": {".": "text/markdown"}}`, true},
+		{`{"contentTypes": {".md/x": "text/markdown"}}`, true},
+		{`{"contentTypes": {".md": "text/"}}`, true},
+		{`not json`, true},
+	}
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if (err != nil) != testCase.expectErr {
+			t.Errorf("Test %d: expected error %v, got %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestConfigContentType(t *testing.T) {
+	config := Config{ContentTypes: map[string]string{".md": "text/markdown"}}
+	testCases := []struct {
+		object      string
+		contentType string
+		found       bool
+	}{
+		{"docs/README.md", "text/markdown", true},
+		{"docs/README.MD", "text/markdown", true},
+		{"docs/README", "", false},
+		{"docs.md/README", "", false},
+		{"image.png", "", false},
+	}
+	for i, testCase := range testCases {
+		contentType, found := config.ContentType(testCase.object)
+		if contentType != testCase.contentType || found != testCase.found {
+			t.Errorf("Test %d: expected (%s, %v), got (%s, %v)", i+1, testCase.contentType, testCase.found, contentType, found)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucketsse
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+)
+
+const (
+	// AES256 - SSE-S3 encryption with keys derived by the server.
+	AES256 = "AES256"
+
+	// AWSKms - SSE-KMS encryption with a key of the KMS.
+	AWSKms = "aws:kms"
+)
+
+var (
+	errBucketSSEOneRule          = errors.New("Bucket encryption configuration should have exactly one rule")
+	errBucketSSEInvalidAlgo      = errors.New("Bucket encryption algorithm should be either AES256 or aws:kms")
+	errBucketSSEKeyIDNotAllowed  = errors.New("Bucket encryption KMS master key ID is only allowed with the aws:kms algorithm")
+	errBucketSSEMissingByDefault = errors.New("Bucket encryption rule should apply server side encryption by default")
+)
+
+// EncryptionAction - default server side encryption of a rule.
+type EncryptionAction struct {
+	Algorithm   string `xml:"SSEAlgorithm"`
+	MasterKeyID string `xml:"KMSMasterKeyID,omitempty"`
+}
+
+// Rule - rule of a bucket encryption configuration.
+type Rule struct {
+	DefaultEncryptionAction *EncryptionAction `xml:"ApplyServerSideEncryptionByDefault"`
+}
+
+// BucketSSEConfig - default server side encryption configuration of a bucket.
+type BucketSSEConfig struct {
+	XMLName xml.Name `xml:"ServerSideEncryptionConfiguration"`
+	Rules   []Rule   `xml:"Rule"`
+}
+
+// ParseBucketSSEConfig - parses data in given reader to BucketSSEConfig.
+func ParseBucketSSEConfig(reader io.Reader) (*BucketSSEConfig, error) {
+	var config BucketSSEConfig
+	if err := xml.NewDecoder(reader).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// Validate - validates the bucket encryption configuration.
+func (c BucketSSEConfig) Validate() error {
+	if len(c.Rules) != 1 {
+		return errBucketSSEOneRule
+	}
+	action := c.Rules[0].DefaultEncryptionAction
+	if action == nil {
+		return errBucketSSEMissingByDefault
+	}
+	switch action.Algorithm {
+	case AES256:
+		if action.MasterKeyID != "" {
+			return errBucketSSEKeyIDNotAllowed
+		}
+	case AWSKms:
+	default:
+		return errBucketSSEInvalidAlgo
+	}
+	return nil
+}
+
+// Algo - returns the default server side encryption algorithm.
+func (c BucketSSEConfig) Algo() string {
+	for _, rule := range c.Rules {
+		if rule.DefaultEncryptionAction != nil {
+			return rule.DefaultEncryptionAction.Algorithm
+		}
+	}
+	return ""
+}
+
+// KeyID - returns the KMS master key ID, empty when the default
+// key of the KMS is used.
+func (c BucketSSEConfig) KeyID() string {
+	for _, rule := range c.Rules {
+		if rule.DefaultEncryptionAction != nil {
+			return rule.DefaultEncryptionAction.MasterKeyID
+		}
+	}
+	return ""
+}
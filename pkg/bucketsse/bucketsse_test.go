@@ -0,0 +1,87 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package bucketsse
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+)
+
+func TestParseBucketSSEConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig   string
+		expectedErr   error
+		expectedAlgo  string
+		expectedKeyID string
+	}{
+		{ // SSE-S3
+			inputConfig:  `<ServerSideEncryptionConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedAlgo: AES256,
+		},
+		{ // SSE-KMS with a master key
+			inputConfig:   `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>aws:kms</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedAlgo:  AWSKms,
+			expectedKeyID: "my-key",
+		},
+		{ // No rules
+			inputConfig: `<ServerSideEncryptionConfiguration></ServerSideEncryptionConfiguration>`,
+			expectedErr: errBucketSSEOneRule,
+		},
+		{ // More than one rule
+			inputConfig: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errBucketSSEOneRule,
+		},
+		{ // Rule without default encryption
+			inputConfig: `<ServerSideEncryptionConfiguration><Rule></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errBucketSSEMissingByDefault,
+		},
+		{ // Unknown algorithm
+			inputConfig: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>DES</SSEAlgorithm></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errBucketSSEInvalidAlgo,
+		},
+		{ // Master key with SSE-S3
+			inputConfig: `<ServerSideEncryptionConfiguration><Rule><ApplyServerSideEncryptionByDefault><SSEAlgorithm>AES256</SSEAlgorithm><KMSMasterKeyID>my-key</KMSMasterKeyID></ApplyServerSideEncryptionByDefault></Rule></ServerSideEncryptionConfiguration>`,
+			expectedErr: errBucketSSEKeyIDNotAllowed,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseBucketSSEConfig(bytes.NewReader([]byte(tc.inputConfig)))
+		if err != tc.expectedErr {
+			t.Fatalf("%d: Expected %v but got %v", i+1, tc.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if config.Algo() != tc.expectedAlgo {
+			t.Errorf("%d: Expected algorithm %s but got %s", i+1, tc.expectedAlgo, config.Algo())
+		}
+		if config.KeyID() != tc.expectedKeyID {
+			t.Errorf("%d: Expected key ID %s but got %s", i+1, tc.expectedKeyID, config.KeyID())
+		}
+
+		// The configuration must survive a round trip.
+		data, err := xml.Marshal(config)
+		if err != nil {
+			t.Fatalf("%d: Unexpected error %v", i+1, err)
+		}
+		if _, err = ParseBucketSSEConfig(bytes.NewReader(data)); err != nil {
+			t.Fatalf("%d: Unexpected error %v", i+1, err)
+		}
+	}
+}
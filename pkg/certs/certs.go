@@ -18,6 +18,8 @@ package certs
 
 import (
 	"crypto/tls"
+	"crypto/x509"
+	"errors"
 	"os"
 	"path/filepath"
 	"sync"
@@ -27,13 +29,26 @@ import (
 )
 
 // A Certs represents a certificate manager able to watch certificate
-// and key pairs for changes.
+// and key pairs for changes. Additional pairs can be added to serve
+// TLS connections for other domains, they are selected by matching
+// the SNI server name of the client against their DNS names.
 type Certs struct {
 	sync.RWMutex
+	loadCert LoadX509KeyPairFunc
+
+	// default certificate pair, served when no other pair
+	// matches the server name requested by the client.
+	defaultPair *keyPair
+
+	// additional certificate pairs, in the order they were added.
+	pairs []*keyPair
+}
+
+// keyPair is a watched certificate and key file pair.
+type keyPair struct {
 	// user input params.
 	certFile string
 	keyFile  string
-	loadCert LoadX509KeyPairFunc
 
 	// points to the latest certificate.
 	cert tls.Certificate
@@ -48,6 +63,32 @@ type LoadX509KeyPairFunc func(certFile, keyFile string) (tls.Certificate, error)
 
 // New initializes a new certs monitor.
 func New(certFile, keyFile string, loadCert LoadX509KeyPairFunc) (*Certs, error) {
+	c := &Certs{
+		loadCert: loadCert,
+	}
+	pair, err := c.newKeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, err
+	}
+	c.defaultPair = pair
+	return c, nil
+}
+
+// AddCertificate adds a certificate and key pair to be served for the
+// DNS names it is issued for, the pair is watched for changes too.
+func (c *Certs) AddCertificate(certFile, keyFile string) error {
+	pair, err := c.newKeyPair(certFile, keyFile)
+	if err != nil {
+		return err
+	}
+	c.Lock()
+	c.pairs = append(c.pairs, pair)
+	c.Unlock()
+	return nil
+}
+
+// newKeyPair loads and starts watching certFile and keyFile.
+func (c *Certs) newKeyPair(certFile, keyFile string) (*keyPair, error) {
 	certFileIsLink, err := checkSymlink(certFile)
 	if err != nil {
 		return nil, err
@@ -56,26 +97,25 @@ func New(certFile, keyFile string, loadCert LoadX509KeyPairFunc) (*Certs, error)
 	if err != nil {
 		return nil, err
 	}
-	c := &Certs{
+	pair := &keyPair{
 		certFile: certFile,
 		keyFile:  keyFile,
-		loadCert: loadCert,
 		// Make the channel buffered to ensure no event is dropped. Notify will drop
 		// an event if the receiver is not able to keep up the sending pace.
 		e: make(chan notify.EventInfo, 1),
 	}
 
 	if certFileIsLink && keyFileIsLink {
-		if err := c.watchSymlinks(); err != nil {
+		if err := c.watchSymlinks(pair); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := c.watch(); err != nil {
+		if err := c.watch(pair); err != nil {
 			return nil, err
 		}
 	}
 
-	return c, nil
+	return pair, nil
 }
 
 func checkSymlink(file string) (bool, error) {
@@ -86,29 +126,41 @@ func checkSymlink(file string) (bool, error) {
 	return st.Mode()&os.ModeSymlink == os.ModeSymlink, nil
 }
 
-// watchSymlinks reloads symlinked files since fsnotify cannot watch
-// on symbolic links.
-func (c *Certs) watchSymlinks() (err error) {
+// load loads the certificate of pair, the leaf certificate is
+// parsed to match server names against.
+func (c *Certs) load(pair *keyPair) error {
+	cert, err := c.loadCert(pair.certFile, pair.keyFile)
+	if err != nil {
+		return err
+	}
+	if len(cert.Certificate) == 0 {
+		return errors.New("no certificate found in " + pair.certFile)
+	}
+	if cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0]); err != nil {
+		return err
+	}
 	c.Lock()
-	c.cert, err = c.loadCert(c.certFile, c.keyFile)
+	pair.cert = cert
 	c.Unlock()
-	if err != nil {
+	return nil
+}
+
+// watchSymlinks reloads symlinked files since fsnotify cannot watch
+// on symbolic links.
+func (c *Certs) watchSymlinks(pair *keyPair) (err error) {
+	if err = c.load(pair); err != nil {
 		return err
 	}
 	go func() {
 		for {
 			select {
-			case <-c.e:
+			case <-pair.e:
 				// Once stopped exits this routine.
 				return
 			case <-time.After(24 * time.Hour):
-				cert, cerr := c.loadCert(c.certFile, c.keyFile)
-				if cerr != nil {
-					continue
-				}
-				c.Lock()
-				c.cert = cert
-				c.Unlock()
+				// ignore the error continue to use
+				// old certificates.
+				c.load(pair)
 			}
 		}
 	}()
@@ -120,11 +172,11 @@ func (c *Certs) watchSymlinks() (err error) {
 // are reloaded. If there is an issue the loading will fail
 // and the old (if any) certificates and keys will continue
 // to be used.
-func (c *Certs) watch() (err error) {
+func (c *Certs) watch(pair *keyPair) (err error) {
 	defer func() {
 		if err != nil {
 			// Stop any watches previously setup after an error.
-			notify.Stop(c.e)
+			notify.Stop(pair.e)
 		}
 	}()
 
@@ -132,38 +184,29 @@ func (c *Certs) watch() (err error) {
 	// for directory changes only, while we can still watch for changes
 	// on files on other platforms. Watch parent directory on all platforms
 	// for simplicity.
-	if err = notify.Watch(filepath.Dir(c.certFile), c.e, eventWrite...); err != nil {
+	if err = notify.Watch(filepath.Dir(pair.certFile), pair.e, eventWrite...); err != nil {
 		return err
 	}
-	if err = notify.Watch(filepath.Dir(c.keyFile), c.e, eventWrite...); err != nil {
+	if err = notify.Watch(filepath.Dir(pair.keyFile), pair.e, eventWrite...); err != nil {
 		return err
 	}
-	c.Lock()
-	c.cert, err = c.loadCert(c.certFile, c.keyFile)
-	c.Unlock()
-	if err != nil {
+	if err = c.load(pair); err != nil {
 		return err
 	}
-	go c.run()
+	go c.run(pair)
 	return nil
 }
 
-func (c *Certs) run() {
-	for event := range c.e {
+func (c *Certs) run(pair *keyPair) {
+	for event := range pair.e {
 		base := filepath.Base(event.Path())
 		if isWriteEvent(event.Event()) {
-			certChanged := base == filepath.Base(c.certFile)
-			keyChanged := base == filepath.Base(c.keyFile)
+			certChanged := base == filepath.Base(pair.certFile)
+			keyChanged := base == filepath.Base(pair.keyFile)
 			if certChanged || keyChanged {
-				cert, err := c.loadCert(c.certFile, c.keyFile)
-				if err != nil {
-					// ignore the error continue to use
-					// old certificates.
-					continue
-				}
-				c.Lock()
-				c.cert = cert
-				c.Unlock()
+				// ignore the error continue to use
+				// old certificates.
+				c.load(pair)
 			}
 		}
 	}
@@ -174,16 +217,49 @@ type GetCertificateFunc func(hello *tls.ClientHelloInfo) (*tls.Certificate, erro
 
 // GetCertificate returns the loaded certificate for use by
 // the TLSConfig fields GetCertificate field in a http.Server.
+// The first added certificate valid for the server name sent
+// by the client is returned, the default certificate otherwise.
 func (c *Certs) GetCertificate(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
 	c.RLock()
 	defer c.RUnlock()
-	return &c.cert, nil
+	if hello != nil && hello.ServerName != "" {
+		for _, pair := range c.pairs {
+			if pair.cert.Leaf != nil && pair.cert.Leaf.VerifyHostname(hello.ServerName) == nil {
+				return &pair.cert, nil
+			}
+		}
+	}
+	return &c.defaultPair.cert, nil
+}
+
+// Reload reloads all certificates and keys from disk, for instance
+// when certificates are renewed in a way which is not noticed by
+// watching for changes. On error the old certificates continue to
+// be used.
+func (c *Certs) Reload() error {
+	if c == nil {
+		return nil
+	}
+	c.RLock()
+	pairs := append([]*keyPair{c.defaultPair}, c.pairs...)
+	c.RUnlock()
+	for _, pair := range pairs {
+		if err := c.load(pair); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // Stop tells loader to stop watching for changes to the
 // certificate and key files.
 func (c *Certs) Stop() {
 	if c != nil {
-		notify.Stop(c.e)
+		c.RLock()
+		defer c.RUnlock()
+		notify.Stop(c.defaultPair.e)
+		for _, pair := range c.pairs {
+			notify.Stop(pair.e)
+		}
 	}
 }
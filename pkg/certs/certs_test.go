@@ -17,9 +17,18 @@
 package certs_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
 	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
 	"io"
+	"io/ioutil"
+	"math/big"
 	"os"
+	"path/filepath"
 	"reflect"
 	"testing"
 	"time"
@@ -122,3 +131,119 @@ func TestStop(t *testing.T) {
 		t.Error("certificate shouldn't match, but matched")
 	}
 }
+
+func TestReload(t *testing.T) {
+	expectedCert, err := tls.LoadX509KeyPair("server2.crt", "server2.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := certs.New("server.crt", "server.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	c.Stop()
+
+	// Certificates are reloaded on demand even
+	// if no one is watching for changes.
+	updateCerts("server2.crt", "server2.key")
+	defer updateCerts("server1.crt", "server1.key")
+
+	if err = c.Reload(); err != nil {
+		t.Fatal(err)
+	}
+
+	gcert, err := c.GetCertificate(&tls.ClientHelloInfo{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gcert.Certificate, expectedCert.Certificate) {
+		t.Error("certificate doesn't match expected certificate")
+	}
+}
+
+// generateCert writes a self-signed certificate for dnsNames to dir.
+func generateCert(dir string, dnsNames ...string) (certFile, keyFile string, err error) {
+	priv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return "", "", err
+	}
+	template := x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, &template, &template, &priv.PublicKey, priv)
+	if err != nil {
+		return "", "", err
+	}
+	keyDER, err := x509.MarshalECPrivateKey(priv)
+	if err != nil {
+		return "", "", err
+	}
+	certFile = filepath.Join(dir, "public.crt")
+	keyFile = filepath.Join(dir, "private.key")
+	if err = ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600); err != nil {
+		return "", "", err
+	}
+	if err = ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0600); err != nil {
+		return "", "", err
+	}
+	return certFile, keyFile, nil
+}
+
+func TestGetCertificateSNI(t *testing.T) {
+	dir, err := ioutil.TempDir("", "certs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	certFile, keyFile, err := generateCert(dir, "*.example.com", "example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := certs.New("server.crt", "server.key", tls.LoadX509KeyPair)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer c.Stop()
+	if err = c.AddCertificate(certFile, keyFile); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.AddCertificate("server.crt", "server2.key"); err == nil {
+		t.Fatal("Expected to fail but got success")
+	}
+
+	defaultCert, err := tls.LoadX509KeyPair("server.crt", "server.key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	domainCert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		serverName   string
+		expectedCert tls.Certificate
+	}{
+		{"", defaultCert},
+		{"example.com", domainCert},
+		{"bucket.example.com", domainCert},
+		{"bucket.example.org", defaultCert},
+		{"a.bucket.example.com", defaultCert},
+	}
+	for i, testCase := range testCases {
+		gcert, err := c.GetCertificate(&tls.ClientHelloInfo{ServerName: testCase.serverName})
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(gcert.Certificate, testCase.expectedCert.Certificate) {
+			t.Errorf("Test %d: certificate for %q doesn't match expected certificate", i+1, testCase.serverName)
+		}
+	}
+}
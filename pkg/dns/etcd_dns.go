@@ -52,7 +52,7 @@ func newCoreDNSMsg(bucket, ip string, port int, ttl uint32) ([]byte, error) {
 func (c *coreDNS) List() ([]SrvRecord, error) {
 	var srvRecords []SrvRecord
 	for _, domainName := range c.domainNames {
-		key := msg.Path(fmt.Sprintf("%s.", domainName), defaultPrefixPath)
+		key := msg.Path(fmt.Sprintf("%s.", domainName), c.prefixPath)
 		records, err := c.list(key)
 		if err != nil {
 			return nil, err
@@ -71,7 +71,7 @@ func (c *coreDNS) List() ([]SrvRecord, error) {
 func (c *coreDNS) Get(bucket string) ([]SrvRecord, error) {
 	var srvRecords []SrvRecord
 	for _, domainName := range c.domainNames {
-		key := msg.Path(fmt.Sprintf("%s.%s.", bucket, domainName), defaultPrefixPath)
+		key := msg.Path(fmt.Sprintf("%s.%s.", bucket, domainName), c.prefixPath)
 		records, err := c.list(key)
 		if err != nil {
 			return nil, err
@@ -161,7 +161,7 @@ func (c *coreDNS) Put(bucket string) error {
 			return err
 		}
 		for _, domainName := range c.domainNames {
-			key := msg.Path(fmt.Sprintf("%s.%s", bucket, domainName), defaultPrefixPath)
+			key := msg.Path(fmt.Sprintf("%s.%s", bucket, domainName), c.prefixPath)
 			key = key + etcdPathSeparator + ip
 			ctx, cancel := context.WithTimeout(context.Background(), defaultContextTimeout)
 			_, err = c.etcdClient.Put(ctx, key, string(bucketMsg))
@@ -180,7 +180,7 @@ func (c *coreDNS) Put(bucket string) error {
 // Removes DNS entries added in Put().
 func (c *coreDNS) Delete(bucket string) error {
 	for _, domainName := range c.domainNames {
-		key := msg.Path(fmt.Sprintf("%s.%s.", bucket, domainName), defaultPrefixPath)
+		key := msg.Path(fmt.Sprintf("%s.%s.", bucket, domainName), c.prefixPath)
 		srvRecords, err := c.list(key)
 		if err != nil {
 			return err
@@ -200,7 +200,7 @@ func (c *coreDNS) Delete(bucket string) error {
 // Removes a specific DNS entry
 func (c *coreDNS) DeleteRecord(record SrvRecord) error {
 	for _, domainName := range c.domainNames {
-		key := msg.Path(fmt.Sprintf("%s.%s.", record.Key, domainName), defaultPrefixPath)
+		key := msg.Path(fmt.Sprintf("%s.%s.", record.Key, domainName), c.prefixPath)
 
 		dctx, dcancel := context.WithTimeout(context.Background(), defaultContextTimeout)
 		if _, err := c.etcdClient.Delete(dctx, key+etcdPathSeparator+record.Host); err != nil {
@@ -217,11 +217,26 @@ type coreDNS struct {
 	domainNames []string
 	domainIPs   set.StringSet
 	domainPort  int
+	prefixPath  string
 	etcdClient  *etcd.Client
 }
 
+// CoreDNSOption - functional options pattern style
+type CoreDNSOption func(*coreDNS)
+
+// CoreDNSPath - custom prefix on etcd to populate DNS service
+// records, it must match the path of the CoreDNS etcd plugin.
+// If empty the default "/skydns" is used.
+func CoreDNSPath(prefix string) CoreDNSOption {
+	return func(args *coreDNS) {
+		if prefix != "" {
+			args.prefixPath = prefix
+		}
+	}
+}
+
 // NewCoreDNS - initialize a new coreDNS set/unset values.
-func NewCoreDNS(domainNames []string, domainIPs set.StringSet, domainPort string, etcdClient *etcd.Client) (Config, error) {
+func NewCoreDNS(domainNames []string, domainIPs set.StringSet, domainPort string, etcdClient *etcd.Client, setters ...CoreDNSOption) (Config, error) {
 	if len(domainNames) == 0 || domainIPs.IsEmpty() {
 		return nil, errors.New("invalid argument")
 	}
@@ -242,10 +257,15 @@ func NewCoreDNS(domainNames []string, domainIPs set.StringSet, domainPort string
 		return host
 	})
 
-	return &coreDNS{
+	c := &coreDNS{
 		domainNames: domainNames,
 		domainIPs:   domainIPsWithoutPorts,
 		domainPort:  port,
+		prefixPath:  defaultPrefixPath,
 		etcdClient:  etcdClient,
-	}, nil
+	}
+	for _, setter := range setters {
+		setter(c)
+	}
+	return c, nil
 }
@@ -46,6 +46,7 @@ type Object struct {
 	ETag         string            `json:"eTag,omitempty"`
 	ContentType  string            `json:"contentType,omitempty"`
 	UserMetadata map[string]string `json:"userMetadata,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
 	VersionID    string            `json:"versionId,omitempty"`
 	Sequencer    string            `json:"sequencer"`
 }
@@ -84,6 +84,11 @@ func (target *AMQPTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *AMQPTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 func (target *AMQPTarget) channel() (*amqp.Channel, error) {
 	var err error
 	var conn *amqp.Connection
@@ -0,0 +1,293 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/pkg/amqp1"
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// amqp1SendTimeout - time to wait for the broker to accept an event.
+const amqp1SendTimeout = 30 * time.Second
+
+// AMQP1Args - AMQP 1.0 target arguments.
+type AMQP1Args struct {
+	Enable   bool     `json:"enable"`
+	URL      xnet.URL `json:"url"`
+	Address  string   `json:"address"`
+	Username string   `json:"username"`
+	Password string   `json:"password"`
+	TLS      struct {
+		SkipVerify    bool           `json:"skipVerify"`
+		ClientTLSCert string         `json:"clientTLSCert"`
+		ClientTLSKey  string         `json:"clientTLSKey"`
+		RootCAs       *x509.CertPool `json:"-"`
+	} `json:"tls"`
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// Validate AMQP1Args fields
+func (a AMQP1Args) Validate() error {
+	if !a.Enable {
+		return nil
+	}
+	switch a.URL.Scheme {
+	case "amqp", "amqps":
+	default:
+		return errors.New("unknown protocol in url, should be amqp or amqps")
+	}
+	if (*url.URL)(&a.URL).Hostname() == "" {
+		return errors.New("empty host in url")
+	}
+	if a.Address == "" {
+		return errors.New("empty address")
+	}
+	if (a.TLS.ClientTLSCert == "") != (a.TLS.ClientTLSKey == "") {
+		return errors.New("clientTLSCert and clientTLSKey should be set together")
+	}
+	if a.QueueDir != "" {
+		if !filepath.IsAbs(a.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if a.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// addr - returns the address of the broker, with the default port of
+// the scheme if none is set.
+func (a AMQP1Args) addr() string {
+	u := (*url.URL)(&a.URL)
+	if u.Port() != "" {
+		return u.Host
+	}
+	port := "5672"
+	if u.Scheme == "amqps" {
+		port = "5671"
+	}
+	return net.JoinHostPort(u.Hostname(), port)
+}
+
+// options - returns the connection options, the credentials may also
+// be set in the url.
+func (a AMQP1Args) options() (opts amqp1.Options, err error) {
+	opts.Username, opts.Password = a.Username, a.Password
+	if opts.Username == "" && a.URL.User != nil {
+		opts.Username = a.URL.User.Username()
+		opts.Password, _ = a.URL.User.Password()
+	}
+	opts.Hostname = (*url.URL)(&a.URL).Hostname()
+	opts.ContainerID = "minio"
+
+	if a.URL.Scheme == "amqps" {
+		opts.TLSConfig = &tls.Config{
+			RootCAs:            a.TLS.RootCAs,
+			InsecureSkipVerify: a.TLS.SkipVerify,
+		}
+		if a.TLS.ClientTLSCert != "" {
+			cert, err := tls.LoadX509KeyPair(a.TLS.ClientTLSCert, a.TLS.ClientTLSKey)
+			if err != nil {
+				return opts, err
+			}
+			opts.TLSConfig.Certificates = []tls.Certificate{cert}
+		}
+	}
+	return opts, nil
+}
+
+// AMQP1Target - AMQP 1.0 target.
+type AMQP1Target struct {
+	id         event.TargetID
+	args       AMQP1Args
+	opts       amqp1.Options
+	sender     *amqp1.Sender
+	senderMu   sync.Mutex
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})
+}
+
+// ID - returns TargetID.
+func (target *AMQP1Target) ID() event.TargetID {
+	return target.id
+}
+
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *AMQP1Target) QueueLength() int {
+	return queueLength(target.store)
+}
+
+// getSender - returns the sender of the connection to the broker,
+// connecting again if the previous connection failed.
+func (target *AMQP1Target) getSender() (*amqp1.Sender, error) {
+	target.senderMu.Lock()
+	defer target.senderMu.Unlock()
+
+	if target.sender != nil && target.sender.Err() == nil {
+		return target.sender, nil
+	}
+
+	sender, err := amqp1.Dial(target.args.addr(), target.args.Address, target.opts)
+	if err != nil {
+		if isAMQP1ConnErr(err) {
+			return nil, errNotConnected
+		}
+		return nil, err
+	}
+	target.sender = sender
+	return sender, nil
+}
+
+// isAMQP1ConnErr - checks if err is a network error, as opposed to an
+// error sent by the broker.
+func isAMQP1ConnErr(err error) bool {
+	if _, ok := err.(net.Error); ok {
+		return true
+	}
+	return IsConnRefusedErr(err) || IsConnResetErr(err)
+}
+
+// send - sends an event to the AMQP 1.0 broker.
+func (target *AMQP1Target) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	sender, err := target.getSender()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), amqp1SendTimeout)
+	defer cancel()
+
+	err = sender.Send(ctx, amqp1.Message{
+		Subject:     eventData.EventName.String(),
+		ContentType: "application/json",
+		Data:        data,
+	})
+	if err != nil && sender.Err() != nil {
+		// The connection failed, the event is kept in the store
+		// until the next connection.
+		return errNotConnected
+	}
+	return err
+}
+
+// Save - saves the events to the store which will be replayed when the AMQP 1.0 connection is active.
+func (target *AMQP1Target) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	return target.send(eventData)
+}
+
+// Send - sends event to AMQP 1.0.
+func (target *AMQP1Target) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - closes the connection to the broker.
+func (target *AMQP1Target) Close() error {
+	target.senderMu.Lock()
+	defer target.senderMu.Unlock()
+
+	if target.sender != nil {
+		return target.sender.Close()
+	}
+	return nil
+}
+
+// NewAMQP1Target - creates new AMQP 1.0 target.
+func NewAMQP1Target(id string, args AMQP1Args, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, errKind ...interface{})) (*AMQP1Target, error) {
+	opts, err := args.options()
+	if err != nil {
+		return nil, err
+	}
+
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-amqp1-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	target := &AMQP1Target{
+		id:         event.TargetID{ID: id, Name: "amqp1"},
+		args:       args,
+		opts:       opts,
+		store:      store,
+		loggerOnce: loggerOnce,
+	}
+
+	if _, err = target.getSender(); err != nil {
+		if store == nil || err != errNotConnected {
+			return nil, err
+		}
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, loggerOnce, target.ID())
+
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, doneCh, loggerOnce)
+	}
+
+	return target, nil
+}
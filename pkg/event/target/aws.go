@@ -0,0 +1,129 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/credentials/stscreds"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/minio/minio/pkg/event"
+)
+
+// AWSAuthArgs - authentication of the AWS targets. Without access
+// key, the credentials are looked up in the environment, the shared
+// credentials file and the IAM role of the EC2 instance or ECS task.
+// RoleARN is then assumed with these credentials, if set.
+type AWSAuthArgs struct {
+	Region    string `json:"region"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+	RoleARN   string `json:"roleARN"`
+	Endpoint  string `json:"endpoint"`
+}
+
+// Validate AWSAuthArgs fields
+func (a AWSAuthArgs) Validate() error {
+	if (a.AccessKey == "") != (a.SecretKey == "") {
+		return errors.New("accessKey and secretKey should be set together")
+	}
+	if a.RoleARN != "" {
+		if _, err := arn.Parse(a.RoleARN); err != nil {
+			return errors.New("invalid roleARN")
+		}
+	}
+	if a.Endpoint != "" {
+		u, err := url.Parse(a.Endpoint)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.New("invalid endpoint")
+		}
+	}
+	return nil
+}
+
+// session - returns the AWS session of region.
+func (a AWSAuthArgs) session(region string) (*session.Session, error) {
+	if a.Region != "" {
+		region = a.Region
+	}
+	cfg := aws.NewConfig().WithRegion(region)
+	if a.AccessKey != "" {
+		cfg = cfg.WithCredentials(credentials.NewStaticCredentials(a.AccessKey, a.SecretKey, ""))
+	}
+	if a.Endpoint != "" {
+		cfg = cfg.WithEndpoint(a.Endpoint)
+	}
+
+	sess, err := session.NewSession(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if a.RoleARN != "" {
+		sess = sess.Copy(aws.NewConfig().WithCredentials(stscreds.NewCredentials(sess, a.RoleARN)))
+	}
+	return sess, nil
+}
+
+// isAWSConnErr - checks if err is a failure to reach the AWS service.
+func isAWSConnErr(err error) bool {
+	if aerr, ok := err.(awserr.Error); ok {
+		// The SDK fails requests with RequestError if the
+		// service cannot be reached.
+		return aerr.Code() == "RequestError" || IsConnRefusedErr(aerr.OrigErr())
+	}
+	return IsConnRefusedErr(err)
+}
+
+// awsMessage - returns the message of eventData, its key and the
+// hash of the message for deduplication.
+func awsMessage(eventData event.Event) (data []byte, key string, hash string, err error) {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return nil, "", "", err
+	}
+	key = eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err = json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return data, key, hex.EncodeToString(sum[:]), nil
+}
+
+// regionFromHost - returns the region of an AWS service host name,
+// e.g. us-east-1 for sqs.us-east-1.amazonaws.com.
+func regionFromHost(host string) string {
+	parts := strings.Split(host, ".")
+	if len(parts) < 4 || parts[2] != "amazonaws" {
+		return ""
+	}
+	return parts[1]
+}
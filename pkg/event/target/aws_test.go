@@ -0,0 +1,136 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestAWSArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args      interface{ Validate() error }
+		expectErr bool
+	}{
+		{SQSArgs{Enable: true, QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events"}, false},
+		{SQSArgs{Enable: true, QueueURL: "https://sqs.example.com/events"}, true},
+		{SQSArgs{Enable: true, QueueURL: "https://sqs.example.com/events", AWSAuthArgs: AWSAuthArgs{Region: "us-east-1"}}, false},
+		{SQSArgs{Enable: true, QueueURL: "sqs.us-east-1.amazonaws.com/123456789012/events"}, true},
+		{SQSArgs{Enable: true, QueueURL: "https://sqs.us-east-1.amazonaws.com/123456789012/events", AWSAuthArgs: AWSAuthArgs{AccessKey: "access"}}, true},
+		{SNSArgs{Enable: true, TopicARN: "arn:aws:sns:us-east-1:123456789012:events"}, false},
+		{SNSArgs{Enable: true, TopicARN: "arn:aws:sns:us-east-1:123456789012:events", AWSAuthArgs: AWSAuthArgs{RoleARN: "arn:aws:iam::123456789012:role/minio"}}, false},
+		{SNSArgs{Enable: true, TopicARN: "arn:aws:sns:us-east-1:123456789012:events", AWSAuthArgs: AWSAuthArgs{RoleARN: "minio"}}, true},
+		{SNSArgs{Enable: true, TopicARN: "events"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+// newAWSTestServer - returns a server answering the SQS and SNS
+// actions used by the targets, recording the published messages.
+func newAWSTestServer(published chan<- url.Values) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.Form.Get("Action") {
+		case "GetQueueAttributes":
+			fmt.Fprint(w, `<GetQueueAttributesResponse><GetQueueAttributesResult></GetQueueAttributesResult></GetQueueAttributesResponse>`)
+		case "GetTopicAttributes":
+			fmt.Fprint(w, `<GetTopicAttributesResponse><GetTopicAttributesResult></GetTopicAttributesResult></GetTopicAttributesResponse>`)
+		case "SendMessage":
+			published <- r.Form
+			sum := md5.Sum([]byte(r.Form.Get("MessageBody")))
+			fmt.Fprintf(w, `<SendMessageResponse><SendMessageResult><MessageId>1</MessageId><MD5OfMessageBody>%s</MD5OfMessageBody></SendMessageResult></SendMessageResponse>`,
+				hex.EncodeToString(sum[:]))
+		case "Publish":
+			published <- r.Form
+			fmt.Fprint(w, `<PublishResponse><PublishResult><MessageId>1</MessageId></PublishResult></PublishResponse>`)
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+}
+
+func TestAWSTargets(t *testing.T) {
+	published := make(chan url.Values, 1)
+	server := newAWSTestServer(published)
+	defer server.Close()
+
+	auth := AWSAuthArgs{Region: "us-east-1", AccessKey: "access", SecretKey: "secret", Endpoint: server.URL}
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+
+	eventData := event.Event{
+		EventName: event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "images"},
+			Object: event.Object{Key: "myphoto.jpg"},
+		},
+	}
+
+	sqsTarget, err := NewSQSTarget("1", SQSArgs{Enable: true, QueueURL: server.URL + "/123456789012/events.fifo", AWSAuthArgs: auth}, nil, loggerOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = sqsTarget.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+	form := <-published
+	if form.Get("MessageGroupId") != "images/myphoto.jpg" || form.Get("MessageDeduplicationId") == "" {
+		t.Fatalf("unexpected FIFO parameters %v", form)
+	}
+
+	snsTarget, err := NewSNSTarget("1", SNSArgs{Enable: true, TopicARN: "arn:aws:sns:us-east-1:123456789012:events", AWSAuthArgs: auth}, nil, loggerOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = snsTarget.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+	form = <-published
+	if form.Get("Subject") != "s3:ObjectCreated:Put" || form.Get("TopicArn") != "arn:aws:sns:us-east-1:123456789012:events" {
+		t.Fatalf("unexpected publish parameters %v", form)
+	}
+}
+
+func TestRegionFromHost(t *testing.T) {
+	testCases := map[string]string{
+		"sqs.us-east-1.amazonaws.com":     "us-east-1",
+		"sqs.cn-north-1.amazonaws.com.cn": "cn-north-1",
+		"sqs.example.com":                 "",
+		"localhost":                       "",
+	}
+	for host, expected := range testCases {
+		if region := regionFromHost(host); region != expected {
+			t.Fatalf("%s: expected %s, got %s", host, expected, region)
+		}
+	}
+}
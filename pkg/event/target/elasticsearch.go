@@ -17,28 +17,52 @@
 package target
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/minio/minio/pkg/event"
 	xnet "github.com/minio/minio/pkg/net"
-	"github.com/pkg/errors"
+)
+
+const (
+	// Mapping type of the documents, only used with Elasticsearch
+	// versions older than 7 which still have mapping types.
+	esDocumentType = "event"
 
-	"gopkg.in/olivere/elastic.v5"
+	esDefaultFlushInterval = time.Second
+	esMaxBatchSize         = 10000
 )
 
 // ElasticsearchArgs - Elasticsearch target arguments.
 type ElasticsearchArgs struct {
-	Enable     bool     `json:"enable"`
-	Format     string   `json:"format"`
-	URL        xnet.URL `json:"url"`
-	Index      string   `json:"index"`
-	QueueDir   string   `json:"queueDir"`
-	QueueLimit uint64   `json:"queueLimit"`
+	Enable        bool           `json:"enable"`
+	Format        string         `json:"format"`
+	URL           xnet.URL       `json:"url"`
+	Index         string         `json:"index"`
+	Username      string         `json:"username"`
+	Password      string         `json:"password"`
+	APIKey        string         `json:"apiKey"`
+	BatchSize     int            `json:"batchSize"`
+	FlushInterval string         `json:"flushInterval"`
+	QueueDir      string         `json:"queueDir"`
+	QueueLimit    uint64         `json:"queueLimit"`
+	RootCAs       *x509.CertPool `json:"-"`
 }
 
 // Validate ElasticsearchArgs fields
@@ -58,6 +82,24 @@ func (a ElasticsearchArgs) Validate() error {
 	if a.Index == "" {
 		return errors.New("empty index value")
 	}
+	if a.APIKey != "" && a.Username != "" {
+		return errors.New("apiKey and username cannot be used together")
+	}
+	if a.Password != "" && a.Username == "" {
+		return errors.New("empty username")
+	}
+	if a.BatchSize < 0 || a.BatchSize > esMaxBatchSize {
+		return errors.New("batchSize should be between 0 and 10000")
+	}
+	if a.FlushInterval != "" {
+		d, err := time.ParseDuration(a.FlushInterval)
+		if err != nil {
+			return err
+		}
+		if d <= 0 {
+			return errors.New("flushInterval should be positive")
+		}
+	}
 	if a.QueueLimit > 10000 {
 		return errors.New("queueLimit should not exceed 10000")
 	}
@@ -66,10 +108,24 @@ func (a ElasticsearchArgs) Validate() error {
 
 // ElasticsearchTarget - Elasticsearch target.
 type ElasticsearchTarget struct {
-	id     event.TargetID
-	args   ElasticsearchArgs
-	client *elastic.Client
-	store  Store
+	id         event.TargetID
+	args       ElasticsearchArgs
+	httpClient *http.Client
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
+
+	// docType is set once the index is initialized, for
+	// Elasticsearch versions which still have mapping types.
+	initMu      sync.Mutex
+	initialized bool
+	docType     string
+
+	// Events waiting for the next bulk request.
+	batchMu  sync.Mutex
+	batch    []event.Event
+	stopCh   chan struct{}
+	stopOnce sync.Once
+	stopWg   sync.WaitGroup
 }
 
 // ID - returns target ID.
@@ -77,82 +133,252 @@ func (target *ElasticsearchTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *ElasticsearchTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // Save - saves the events to the store if queuestore is configured, which will be replayed when the elasticsearch connection is active.
 func (target *ElasticsearchTarget) Save(eventData event.Event) error {
 	if target.store != nil {
 		return target.store.Put(eventData)
 	}
+
+	if target.args.BatchSize > 1 {
+		target.batchMu.Lock()
+		target.batch = append(target.batch, eventData)
+		if len(target.batch) < target.args.BatchSize {
+			target.batchMu.Unlock()
+			return nil
+		}
+		events := target.batch
+		target.batch = nil
+		target.batchMu.Unlock()
+		return target.send(events)
+	}
+
 	if dErr := target.args.URL.DialHTTP(); dErr != nil {
 		if xnet.IsNetworkOrHostDown(dErr) {
 			return errNotConnected
 		}
 		return dErr
 	}
-	return target.send(eventData)
+	return target.send([]event.Event{eventData})
 }
 
-// send - sends the event to the target.
-func (target *ElasticsearchTarget) send(eventData event.Event) error {
+// do - sends a request to Elasticsearch with the configured credentials.
+func (target *ElasticsearchTarget) do(method, path string, body []byte, contentType string) (*http.Response, error) {
+	req, err := http.NewRequest(method, strings.TrimSuffix(target.args.URL.String(), "/")+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	switch {
+	case target.args.APIKey != "":
+		req.Header.Set("Authorization", "ApiKey "+target.args.APIKey)
+	case target.args.Username != "":
+		req.SetBasicAuth(target.args.Username, target.args.Password)
+	}
+	return target.httpClient.Do(req)
+}
+
+// readError - returns the error of a failed Elasticsearch response.
+func readError(resp *http.Response) error {
+	data, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	return fmt.Errorf("elasticsearch responded with %v: %s", resp.Status, bytes.TrimSpace(data))
+}
 
-	var key string
+// initIndex - detects the Elasticsearch version and creates the index
+// if it does not exist.
+func (target *ElasticsearchTarget) initIndex() error {
+	target.initMu.Lock()
+	defer target.initMu.Unlock()
 
-	exists := func() (bool, error) {
-		return target.client.Exists().Index(target.args.Index).Type("event").Id(key).Do(context.Background())
+	if target.initialized {
+		return nil
 	}
 
-	remove := func() error {
-		exists, err := exists()
-		if err == nil && exists {
-			_, err = target.client.Delete().Index(target.args.Index).Type("event").Id(key).Do(context.Background())
-		}
+	resp, err := target.do(http.MethodGet, "/", nil, "")
+	if err != nil {
 		return err
 	}
-
-	update := func() error {
-		_, err := target.client.Index().Index(target.args.Index).Type("event").BodyJson(map[string]interface{}{"Records": []event.Event{eventData}}).Id(key).Do(context.Background())
+	defer drainBody(resp)
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
+	}
+	var info struct {
+		Version struct {
+			Number string `json:"number"`
+		} `json:"version"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&info); err != nil {
 		return err
 	}
+	major, err := strconv.Atoi(strings.SplitN(info.Version.Number, ".", 2)[0])
+	if err != nil {
+		return fmt.Errorf("unrecognized elasticsearch version %q", info.Version.Number)
+	}
+	var docType string
+	if major < 7 {
+		docType = esDocumentType
+	}
 
-	add := func() error {
-		_, err := target.client.Index().Index(target.args.Index).Type("event").BodyJson(map[string]interface{}{"Records": []event.Event{eventData}}).Do(context.Background())
+	indexPath := "/" + url.PathEscape(target.args.Index)
+	resp, err = target.do(http.MethodHead, indexPath, nil, "")
+	if err != nil {
 		return err
 	}
-
-	if target.args.Format == event.NamespaceFormat {
-		objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	drainBody(resp)
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		resp, err = target.do(http.MethodPut, indexPath, nil, "")
 		if err != nil {
 			return err
 		}
+		defer drainBody(resp)
+		if resp.StatusCode != http.StatusOK {
+			// The index may have been created concurrently by another server.
+			if err = readError(resp); !strings.Contains(err.Error(), "resource_already_exists_exception") {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("elasticsearch responded with %v for index %s", resp.Status, target.args.Index)
+	}
 
-		key = eventData.S3.Bucket.Name + "/" + objectName
-		if eventData.EventName == event.ObjectRemovedDelete {
-			err = remove()
-		} else {
-			err = update()
+	target.docType = docType
+	target.initialized = true
+	return nil
+}
+
+// bulkBody - returns the body of a bulk request indexing the events.
+func (target *ElasticsearchTarget) bulkBody(events []event.Event) ([]byte, error) {
+	type meta struct {
+		Index string `json:"_index"`
+		Type  string `json:"_type,omitempty"`
+		ID    string `json:"_id,omitempty"`
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, eventData := range events {
+		m := meta{Index: target.args.Index, Type: target.docType}
+		action := "index"
+		if target.args.Format == event.NamespaceFormat {
+			objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+			if err != nil {
+				return nil, err
+			}
+			m.ID = eventData.S3.Bucket.Name + "/" + objectName
+			if eventData.EventName == event.ObjectRemovedDelete {
+				action = "delete"
+			}
+		}
+		if err := enc.Encode(map[string]meta{action: m}); err != nil {
+			return nil, err
+		}
+		if action == "delete" {
+			continue
+		}
+		if err := enc.Encode(map[string]interface{}{"Records": []event.Event{eventData}}); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// send - indexes the events with a single bulk request.
+func (target *ElasticsearchTarget) send(events []event.Event) error {
+	if len(events) == 0 {
+		return nil
+	}
+	if err := target.initIndex(); err != nil {
+		if xnet.IsNetworkOrHostDown(err) {
+			return errNotConnected
 		}
+		return err
+	}
 
+	body, err := target.bulkBody(events)
+	if err != nil {
 		return err
 	}
 
-	if target.args.Format == event.AccessFormat {
-		return add()
+	resp, err := target.do(http.MethodPost, "/_bulk", body, "application/x-ndjson")
+	if err != nil {
+		if xnet.IsNetworkOrHostDown(err) {
+			return errNotConnected
+		}
+		return err
+	}
+	defer drainBody(resp)
+	if resp.StatusCode != http.StatusOK {
+		return readError(resp)
 	}
 
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return err
+	}
+	if !result.Errors {
+		return nil
+	}
+	for _, item := range result.Items {
+		for action, res := range item {
+			// Deleting a document which was never indexed.
+			if action == "delete" && res.Status == http.StatusNotFound {
+				continue
+			}
+			if res.Status < 200 || res.Status > 299 {
+				return fmt.Errorf("elasticsearch %s failed with status %d: %s", action, res.Status, res.Error)
+			}
+		}
+	}
 	return nil
 }
 
-// Send - reads an event from store and sends it to Elasticsearch.
-func (target *ElasticsearchTarget) Send(eventKey string) error {
+// flush - sends the batched events.
+func (target *ElasticsearchTarget) flush() error {
+	target.batchMu.Lock()
+	events := target.batch
+	target.batch = nil
+	target.batchMu.Unlock()
 
-	var err error
+	return target.send(events)
+}
 
-	if target.client == nil {
-		target.client, err = newClient(target.args)
-		if err != nil {
-			return err
+// flushEvents - periodically sends the events of incomplete batches.
+func (target *ElasticsearchTarget) flushEvents(interval time.Duration, doneCh <-chan struct{}) {
+	defer target.stopWg.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := target.flush(); err != nil {
+				target.loggerOnce(context.Background(), err, target.ID())
+			}
+		case <-target.stopCh:
+			return
+		case <-doneCh:
+			return
 		}
 	}
+}
 
+// Send - reads an event from store and sends it to Elasticsearch.
+func (target *ElasticsearchTarget) Send(eventKey string) error {
 	if dErr := target.args.URL.DialHTTP(); dErr != nil {
 		if xnet.IsNetworkOrHostDown(dErr) {
 			return errNotConnected
@@ -170,10 +396,7 @@ func (target *ElasticsearchTarget) Send(eventKey string) error {
 		return eErr
 	}
 
-	if err := target.send(eventData); err != nil {
-		if xnet.IsNetworkOrHostDown(err) {
-			return errNotConnected
-		}
+	if err := target.send([]event.Event{eventData}); err != nil {
 		return err
 	}
 
@@ -181,50 +404,26 @@ func (target *ElasticsearchTarget) Send(eventKey string) error {
 	return target.store.Del(eventKey)
 }
 
-// Close - does nothing and available for interface compatibility.
+// Close - stops the periodic flush and sends the batched events.
 func (target *ElasticsearchTarget) Close() error {
-	return nil
-}
-
-// createIndex - creates the index if it does not exist.
-func createIndex(client *elastic.Client, args ElasticsearchArgs) error {
-	exists, err := client.IndexExists(args.Index).Do(context.Background())
-	if err != nil {
-		return err
-	}
-	if !exists {
-		var createIndex *elastic.IndicesCreateResult
-		if createIndex, err = client.CreateIndex(args.Index).Do(context.Background()); err != nil {
-			return err
-		}
-
-		if !createIndex.Acknowledged {
-			return fmt.Errorf("index %v not created", args.Index)
-		}
+	if target.stopCh != nil {
+		target.stopOnce.Do(func() {
+			close(target.stopCh)
+		})
+		target.stopWg.Wait()
 	}
-	return nil
+	return target.flush()
 }
 
-// newClient - creates a new elastic client with args provided.
-func newClient(args ElasticsearchArgs) (*elastic.Client, error) {
-	client, clientErr := elastic.NewClient(elastic.SetURL(args.URL.String()), elastic.SetSniff(false), elastic.SetMaxRetries(10))
-	if clientErr != nil {
-		if !(errors.Cause(clientErr) == elastic.ErrNoClient) {
-			return nil, clientErr
-		}
-	} else {
-		if err := createIndex(client, args); err != nil {
-			return nil, err
-		}
-	}
-	return client, nil
+// drainBody - drains and closes the body of a response so that
+// the connection can be reused.
+func drainBody(resp *http.Response) {
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
 }
 
 // NewElasticsearchTarget - creates new Elasticsearch target.
 func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*ElasticsearchTarget, error) {
-	var client *elastic.Client
-	var err error
-
 	var store Store
 
 	if args.QueueDir != "" {
@@ -235,23 +434,34 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 		}
 	}
 
+	target := &ElasticsearchTarget{
+		id:   event.TargetID{ID: id, Name: "elasticsearch"},
+		args: args,
+		httpClient: &http.Client{
+			Transport: &http.Transport{
+				Proxy:           http.ProxyFromEnvironment,
+				TLSClientConfig: &tls.Config{RootCAs: args.RootCAs},
+				DialContext: (&net.Dialer{
+					Timeout:   5 * time.Second,
+					KeepAlive: 30 * time.Second,
+				}).DialContext,
+				MaxIdleConnsPerHost:   16,
+				TLSHandshakeTimeout:   3 * time.Second,
+				ResponseHeaderTimeout: 30 * time.Second,
+				ExpectContinueTimeout: 2 * time.Second,
+			},
+		},
+		store:      store,
+		loggerOnce: loggerOnce,
+	}
+
 	dErr := args.URL.DialHTTP()
 	if dErr != nil {
 		if store == nil {
 			return nil, dErr
 		}
-	} else {
-		client, err = newClient(args)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	target := &ElasticsearchTarget{
-		id:     event.TargetID{ID: id, Name: "elasticsearch"},
-		args:   args,
-		client: client,
-		store:  store,
+	} else if err := target.initIndex(); err != nil {
+		return nil, err
 	}
 
 	if target.store != nil {
@@ -259,6 +469,18 @@ func NewElasticsearchTarget(id string, args ElasticsearchArgs, doneCh <-chan str
 		eventKeyCh := replayEvents(target.store, doneCh, loggerOnce, target.ID())
 		// Start replaying events from the store.
 		go sendEvents(target, eventKeyCh, doneCh, loggerOnce)
+	} else if args.BatchSize > 1 {
+		interval := esDefaultFlushInterval
+		if args.FlushInterval != "" {
+			d, err := time.ParseDuration(args.FlushInterval)
+			if err != nil {
+				return nil, err
+			}
+			interval = d
+		}
+		target.stopCh = make(chan struct{})
+		target.stopWg.Add(1)
+		go target.flushEvents(interval, doneCh)
 	}
 
 	return target, nil
@@ -0,0 +1,273 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/pkg/event"
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+// esTestServer - fake Elasticsearch server recording bulk requests.
+type esTestServer struct {
+	*httptest.Server
+	version string
+
+	mu           sync.Mutex
+	indexCreated bool
+	auth         []string
+	bulk         [][]map[string]interface{}
+}
+
+func newESTestServer(version string) *esTestServer {
+	s := &esTestServer{version: version}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Connectivity checks of URL.DialHTTP.
+		if r.Method == http.MethodPost && r.URL.Path == "/" {
+			return
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		s.auth = append(s.auth, r.Header.Get("Authorization"))
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/":
+			w.Write([]byte(`{"version":{"number":"` + s.version + `"}}`))
+		case r.Method == http.MethodHead && r.URL.Path == "/minio":
+			if !s.indexCreated {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case r.Method == http.MethodPut && r.URL.Path == "/minio":
+			s.indexCreated = true
+			w.Write([]byte(`{"acknowledged":true}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/_bulk":
+			var lines []map[string]interface{}
+			scanner := bufio.NewScanner(r.Body)
+			for scanner.Scan() {
+				var line map[string]interface{}
+				if err := json.Unmarshal(scanner.Bytes(), &line); err != nil {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				lines = append(lines, line)
+			}
+			s.bulk = append(s.bulk, lines)
+			w.Write([]byte(`{"errors":true,"items":[{"delete":{"status":404}}]}`))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	return s
+}
+
+func (s *esTestServer) bulkRequests() [][]map[string]interface{} {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.bulk
+}
+
+func newESTestEvent(name event.Name, key string) event.Event {
+	return event.Event{
+		EventName: name,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "bucket"},
+			Object: event.Object{Key: key},
+		},
+	}
+}
+
+func TestElasticsearchArgsValidate(t *testing.T) {
+	u, err := xnet.ParseURL("http://localhost:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	testCases := []struct {
+		args    ElasticsearchArgs
+		success bool
+	}{
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio"}, true},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", APIKey: "key", BatchSize: 100, FlushInterval: "5s"}, true},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", Username: "user", Password: "pass"}, true},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", Username: "user", APIKey: "key"}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", Password: "pass"}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", BatchSize: -1}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", BatchSize: 10001}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", FlushInterval: "soon"}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u, Index: "minio", FlushInterval: "0s"}, false},
+		{ElasticsearchArgs{Enable: true, URL: *u}, false},
+	}
+	for i, testCase := range testCases {
+		if err := testCase.args.Validate(); (err == nil) != testCase.success {
+			t.Errorf("Test %d: expected success %v, got %v", i+1, testCase.success, err)
+		}
+	}
+}
+
+func TestElasticsearchTarget(t *testing.T) {
+	testCases := []struct {
+		version string
+		docType interface{}
+	}{
+		{"6.8.0", "event"},
+		{"7.10.2", nil},
+		{"8.1.0", nil},
+	}
+
+	for i, testCase := range testCases {
+		server := newESTestServer(testCase.version)
+		u, err := xnet.ParseURL(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		args := ElasticsearchArgs{Enable: true, Format: event.NamespaceFormat, URL: *u, Index: "minio", APIKey: "a2V5"}
+		target, err := NewElasticsearchTarget("1", args, make(chan struct{}), nil)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !server.indexCreated {
+			t.Errorf("Test %d: expected index to be created", i+1)
+		}
+
+		if err = target.Save(newESTestEvent(event.ObjectCreatedPut, "dir%2Fobject")); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		// Deleting a missing document is not an error.
+		if err = target.Save(newESTestEvent(event.ObjectRemovedDelete, "dir%2Fobject")); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+
+		bulk := server.bulkRequests()
+		if len(bulk) != 2 || len(bulk[0]) != 2 || len(bulk[1]) != 1 {
+			t.Fatalf("Test %d: unexpected bulk requests %v", i+1, bulk)
+		}
+		index := bulk[0][0]["index"].(map[string]interface{})
+		if index["_index"] != "minio" || index["_id"] != "bucket/dir/object" || index["_type"] != testCase.docType {
+			t.Errorf("Test %d: unexpected index action %v", i+1, index)
+		}
+		if _, ok := bulk[0][1]["Records"]; !ok {
+			t.Errorf("Test %d: expected document, got %v", i+1, bulk[0][1])
+		}
+		if _, ok := bulk[1][0]["delete"]; !ok {
+			t.Errorf("Test %d: expected delete action, got %v", i+1, bulk[1][0])
+		}
+		for _, auth := range server.auth {
+			if auth != "ApiKey a2V5" {
+				t.Errorf("Test %d: unexpected Authorization %q", i+1, auth)
+			}
+		}
+		target.Close()
+		server.Close()
+	}
+}
+
+func TestElasticsearchTargetBatch(t *testing.T) {
+	server := newESTestServer("7.10.2")
+	defer server.Close()
+	u, err := xnet.ParseURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := ElasticsearchArgs{
+		Enable:        true,
+		Format:        event.AccessFormat,
+		URL:           *u,
+		Index:         "minio",
+		Username:      "user",
+		Password:      "pass",
+		BatchSize:     3,
+		FlushInterval: "50ms",
+	}
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {
+		t.Error(err)
+	}
+	target, err := NewElasticsearchTarget("1", args, make(chan struct{}), loggerOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer target.Close()
+
+	// A full batch is sent right away.
+	for i := 0; i < 4; i++ {
+		if err = target.Save(newESTestEvent(event.ObjectAccessedGet, "object")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if bulk := server.bulkRequests(); len(bulk) != 1 || len(bulk[0]) != 6 {
+		t.Fatalf("expected a bulk request of 3 events, got %v", bulk)
+	}
+
+	// The rest is sent after the flush interval.
+	deadline := time.Now().Add(5 * time.Second)
+	for len(server.bulkRequests()) < 2 {
+		if time.Now().After(deadline) {
+			t.Fatal("batched event not flushed")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if bulk := server.bulkRequests(); len(bulk[1]) != 2 {
+		t.Errorf("expected a bulk request of 1 event, got %v", bulk[1])
+	}
+
+	req, _ := http.NewRequest(http.MethodGet, "/", nil)
+	req.SetBasicAuth("user", "pass")
+	server.mu.Lock()
+	for _, auth := range server.auth {
+		if auth != req.Header.Get("Authorization") {
+			t.Errorf("unexpected Authorization %q", auth)
+		}
+	}
+	server.mu.Unlock()
+}
+
+func TestElasticsearchBulkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			w.Write([]byte(`{"version":{"number":"7.0.0"}}`))
+		case "/_bulk":
+			ioutil.ReadAll(r.Body)
+			w.Write([]byte(`{"errors":true,"items":[{"index":{"status":400,"error":{"type":"mapper_parsing_exception"}}}]}`))
+		}
+	}))
+	defer server.Close()
+	u, err := xnet.ParseURL(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := ElasticsearchArgs{Enable: true, Format: event.AccessFormat, URL: *u, Index: "minio"}
+	target, err := NewElasticsearchTarget("1", args, make(chan struct{}), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = target.Save(newESTestEvent(event.ObjectCreatedPut, "object"))
+	if err == nil || !bytes.Contains([]byte(err.Error()), []byte("mapper_parsing_exception")) {
+		t.Errorf("expected bulk item error, got %v", err)
+	}
+}
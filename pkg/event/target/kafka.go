@@ -91,6 +91,11 @@ func (target *KafkaTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *KafkaTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // Save - saves the events to the store which will be replayed when the Kafka connection is active.
 func (target *KafkaTarget) Save(eventData event.Event) error {
 	if target.store != nil {
@@ -49,6 +49,11 @@ type MQTTArgs struct {
 	MaxReconnectInterval time.Duration  `json:"reconnectInterval"`
 	KeepAlive            time.Duration  `json:"keepAliveInterval"`
 	RootCAs              *x509.CertPool `json:"-"`
+	ClientTLSCert        string         `json:"clientTLSCert"`
+	ClientTLSKey         string         `json:"clientTLSKey"`
+	ClientID             string         `json:"clientID"`
+	PersistentSession    bool           `json:"persistentSession"`
+	MaxInflight          int            `json:"maxInflight"`
 	QueueDir             string         `json:"queueDir"`
 	QueueLimit           uint64         `json:"queueLimit"`
 }
@@ -67,6 +72,15 @@ func (m MQTTArgs) Validate() error {
 	default:
 		return errors.New("unknown protocol in broker address")
 	}
+	if (m.ClientTLSCert == "") != (m.ClientTLSKey == "") {
+		return errors.New("clientTLSCert and clientTLSKey should be set together")
+	}
+	if m.PersistentSession && m.ClientID == "" {
+		return errors.New("clientID should be set if persistentSession is enabled")
+	}
+	if m.MaxInflight < 0 || m.MaxInflight > 65535 {
+		return errors.New("maxInflight should be between 0 and 65535")
+	}
 	if m.QueueDir != "" {
 		if !filepath.IsAbs(m.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -88,6 +102,7 @@ type MQTTTarget struct {
 	args       MQTTArgs
 	client     mqtt.Client
 	store      Store
+	inflight   chan struct{}
 	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
 }
 
@@ -96,6 +111,11 @@ func (target *MQTTTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *MQTTTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // send - sends an event to the mqtt.
 func (target *MQTTTarget) send(eventData event.Event) error {
 	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
@@ -109,6 +129,11 @@ func (target *MQTTTarget) send(eventData event.Event) error {
 		return err
 	}
 
+	if target.inflight != nil {
+		target.inflight <- struct{}{}
+		defer func() { <-target.inflight }()
+	}
+
 	token := target.client.Publish(target.args.Topic, target.args.QoS, false, string(data))
 	token.Wait()
 	if token.Error() != nil {
@@ -163,16 +188,32 @@ func (target *MQTTTarget) Close() error {
 
 // NewMQTTTarget - creates new MQTT target.
 func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*MQTTTarget, error) {
+	tlsConfig := &tls.Config{RootCAs: args.RootCAs}
+	if args.ClientTLSCert != "" {
+		cert, err := tls.LoadX509KeyPair(args.ClientTLSCert, args.ClientTLSKey)
+		if err != nil {
+			return nil, err
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
 	options := mqtt.NewClientOptions().
-		SetClientID("").
-		SetCleanSession(true).
+		SetClientID(args.ClientID).
+		SetCleanSession(!args.PersistentSession).
 		SetUsername(args.User).
 		SetPassword(args.Password).
 		SetMaxReconnectInterval(args.MaxReconnectInterval).
 		SetKeepAlive(args.KeepAlive).
-		SetTLSConfig(&tls.Config{RootCAs: args.RootCAs}).
+		SetTLSConfig(tlsConfig).
 		AddBroker(args.Broker.String())
 
+	// With a persistent session, the QoS 1 and 2 messages not yet
+	// acknowledged by the broker are kept on disk and resent when
+	// the session is resumed, also across server restarts.
+	if args.PersistentSession && args.QueueDir != "" {
+		options.SetStore(mqtt.NewFileStore(filepath.Join(args.QueueDir, storePrefix+"-mqtt-session-"+id)))
+	}
+
 	client := mqtt.NewClient(options)
 
 	// The client should establish a first time connection.
@@ -185,6 +226,9 @@ func NewMQTTTarget(id string, args MQTTArgs, doneCh <-chan struct{}, loggerOnce
 		client:     client,
 		loggerOnce: loggerOnce,
 	}
+	if args.MaxInflight > 0 {
+		target.inflight = make(chan struct{}, args.MaxInflight)
+	}
 
 	// Retries until the clientID gets registered.
 	retryRegister := func() {
@@ -0,0 +1,55 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"testing"
+
+	xnet "github.com/minio/minio/pkg/net"
+)
+
+func TestMQTTArgsValidate(t *testing.T) {
+	parseURL := func(s string) xnet.URL {
+		u, err := xnet.ParseURL(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return *u
+	}
+
+	testCases := []struct {
+		args      MQTTArgs
+		expectErr bool
+	}{
+		{MQTTArgs{Enable: true, Broker: parseURL("tcp://localhost:1883")}, false},
+		{MQTTArgs{Enable: true, Broker: parseURL("ws://localhost:8080/mqtt")}, false},
+		{MQTTArgs{Enable: true, Broker: parseURL("wss://localhost:443/mqtt"), ClientTLSCert: "/certs/client.crt", ClientTLSKey: "/certs/client.key"}, false},
+		{MQTTArgs{Enable: true, Broker: parseURL("http://localhost:8080")}, true},
+		{MQTTArgs{Enable: true, Broker: parseURL("tls://localhost:8883"), ClientTLSCert: "/certs/client.crt"}, true},
+		{MQTTArgs{Enable: true, Broker: parseURL("tcp://localhost:1883"), ClientID: "minio", PersistentSession: true}, false},
+		{MQTTArgs{Enable: true, Broker: parseURL("tcp://localhost:1883"), PersistentSession: true}, true},
+		{MQTTArgs{Enable: true, Broker: parseURL("tcp://localhost:1883"), MaxInflight: 100}, false},
+		{MQTTArgs{Enable: true, Broker: parseURL("tcp://localhost:1883"), MaxInflight: -1}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
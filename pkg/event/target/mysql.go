@@ -159,6 +159,11 @@ func (target *MySQLTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *MySQLTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // Save - saves the events to the store which will be replayed when the SQL connection is active.
 func (target *MySQLTarget) Save(eventData event.Event) error {
 	if target.store != nil {
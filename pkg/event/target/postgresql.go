@@ -50,6 +50,11 @@
 //     event_time TIMESTAMP WITH TIME ZONE NOT NULL,
 //     event_data JSONB
 // );
+//
+// When a partition interval is configured, the access table is
+// created partitioned by range of event_time, which requires
+// PostgreSQL 10, and a partition is created for each day or month
+// as events arrive, e.g. myminio_20191231 or myminio_201912.
 
 package target
 
@@ -64,6 +69,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	_ "github.com/lib/pq" // Register postgres driver
@@ -77,24 +83,35 @@ const (
 	psqlCreateNamespaceTable = `CREATE TABLE %s (key VARCHAR PRIMARY KEY, value JSONB);`
 	psqlCreateAccessTable    = `CREATE TABLE %s (event_time TIMESTAMP WITH TIME ZONE NOT NULL, event_data JSONB);`
 
+	psqlCreatePartitionedAccessTable = `CREATE TABLE %s (event_time TIMESTAMP WITH TIME ZONE NOT NULL, event_data JSONB) PARTITION BY RANGE (event_time);`
+	psqlCreatePartition              = `CREATE TABLE IF NOT EXISTS %s PARTITION OF %s FOR VALUES FROM ('%s') TO ('%s');`
+
 	psqlUpdateRow = `INSERT INTO %s (key, value) VALUES ($1, $2) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value;`
 	psqlDeleteRow = `DELETE FROM %s WHERE key = $1;`
 	psqlInsertRow = `INSERT INTO %s (event_time, event_data) VALUES ($1, $2);`
 )
 
+// Partition intervals of the access format table.
+const (
+	PostgreSQLPartitionDaily   = "daily"
+	PostgreSQLPartitionMonthly = "monthly"
+)
+
 // PostgreSQLArgs - PostgreSQL target arguments.
 type PostgreSQLArgs struct {
-	Enable           bool      `json:"enable"`
-	Format           string    `json:"format"`
-	ConnectionString string    `json:"connectionString"`
-	Table            string    `json:"table"`
-	Host             xnet.Host `json:"host"`     // default: localhost
-	Port             string    `json:"port"`     // default: 5432
-	User             string    `json:"user"`     // default: user running minio
-	Password         string    `json:"password"` // default: no password
-	Database         string    `json:"database"` // default: same as user
-	QueueDir         string    `json:"queueDir"`
-	QueueLimit       uint64    `json:"queueLimit"`
+	Enable             bool      `json:"enable"`
+	Format             string    `json:"format"`
+	ConnectionString   string    `json:"connectionString"`
+	Table              string    `json:"table"`
+	Host               xnet.Host `json:"host"`               // default: localhost
+	Port               string    `json:"port"`               // default: 5432
+	User               string    `json:"user"`               // default: user running minio
+	Password           string    `json:"password"`           // default: no password
+	Database           string    `json:"database"`           // default: same as user
+	MaxOpenConnections int       `json:"maxOpenConnections"` // default: no limit
+	PartitionInterval  string    `json:"partitionInterval"`  // default: not partitioned
+	QueueDir           string    `json:"queueDir"`
+	QueueLimit         uint64    `json:"queueLimit"`
 }
 
 // Validate PostgreSQLArgs fields
@@ -128,6 +145,19 @@ func (p PostgreSQLArgs) Validate() error {
 		}
 	}
 
+	if p.MaxOpenConnections < 0 {
+		return errors.New("maxOpenConnections cannot be negative")
+	}
+	switch p.PartitionInterval {
+	case "":
+	case PostgreSQLPartitionDaily, PostgreSQLPartitionMonthly:
+		if strings.ToLower(p.Format) != event.AccessFormat {
+			return errors.New("partitionInterval is only supported with access format")
+		}
+	default:
+		return errors.New("unrecognized partitionInterval value")
+	}
+
 	if p.QueueDir != "" {
 		if !filepath.IsAbs(p.QueueDir) {
 			return errors.New("queueDir path should be absolute")
@@ -150,6 +180,10 @@ type PostgreSQLTarget struct {
 	db         *sql.DB
 	store      Store
 	firstPing  bool
+
+	// Partitions of the access table known to exist.
+	partitionsMu sync.Mutex
+	partitions   map[string]struct{}
 }
 
 // ID - returns target ID.
@@ -157,18 +191,23 @@ func (target *PostgreSQLTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *PostgreSQLTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // Save - saves the events to the store if questore is configured, which will be replayed when the PostgreSQL connection is active.
 func (target *PostgreSQLTarget) Save(eventData event.Event) error {
 	if target.store != nil {
 		return target.store.Put(eventData)
 	}
-	if err := target.db.Ping(); err != nil {
+	if err := target.send(eventData); err != nil {
 		if IsConnErr(err) {
 			return errNotConnected
 		}
 		return err
 	}
-	return target.send(eventData)
+	return nil
 }
 
 // IsConnErr - To detect a connection error.
@@ -209,6 +248,12 @@ func (target *PostgreSQLTarget) send(eventData event.Event) error {
 			return err
 		}
 
+		if target.args.PartitionInterval != "" {
+			if err = target.createPartition(eventTime); err != nil {
+				return err
+			}
+		}
+
 		if _, err = target.insertStmt.Exec(eventTime, data); err != nil {
 			return err
 		}
@@ -277,6 +322,38 @@ func (target *PostgreSQLTarget) Close() error {
 	return target.db.Close()
 }
 
+// partition - returns the name of the access table partition holding
+// the events of t, and its time range.
+func (target *PostgreSQLTarget) partition(t time.Time) (name string, from, to time.Time) {
+	t = t.UTC()
+	if target.args.PartitionInterval == PostgreSQLPartitionMonthly {
+		from = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+		return target.args.Table + "_" + from.Format("200601"), from, from.AddDate(0, 1, 0)
+	}
+	from = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return target.args.Table + "_" + from.Format("20060102"), from, from.AddDate(0, 0, 1)
+}
+
+// createPartition - creates the access table partition for the
+// events of t, if it was not created already.
+func (target *PostgreSQLTarget) createPartition(t time.Time) error {
+	name, from, to := target.partition(t)
+
+	target.partitionsMu.Lock()
+	defer target.partitionsMu.Unlock()
+
+	if _, ok := target.partitions[name]; ok {
+		return nil
+	}
+	_, err := target.db.Exec(fmt.Sprintf(psqlCreatePartition, name, target.args.Table,
+		from.Format(time.RFC3339), to.Format(time.RFC3339)))
+	if err != nil {
+		return err
+	}
+	target.partitions[name] = struct{}{}
+	return nil
+}
+
 // Executes the table creation statements.
 func (target *PostgreSQLTarget) executeStmts() error {
 
@@ -285,6 +362,9 @@ func (target *PostgreSQLTarget) executeStmts() error {
 		createStmt := psqlCreateNamespaceTable
 		if target.args.Format == event.AccessFormat {
 			createStmt = psqlCreateAccessTable
+			if target.args.PartitionInterval != "" {
+				createStmt = psqlCreatePartitionedAccessTable
+			}
 		}
 
 		if _, dbErr := target.db.Exec(fmt.Sprintf(createStmt, target.args.Table)); dbErr != nil {
@@ -338,6 +418,11 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{},
 	if err != nil {
 		return nil, err
 	}
+	if args.MaxOpenConnections > 0 {
+		db.SetMaxOpenConns(args.MaxOpenConnections)
+		// Keep the connections open in between bursts of events.
+		db.SetMaxIdleConns(args.MaxOpenConnections)
+	}
 
 	var store Store
 
@@ -350,11 +435,12 @@ func NewPostgreSQLTarget(id string, args PostgreSQLArgs, doneCh <-chan struct{},
 	}
 
 	target := &PostgreSQLTarget{
-		id:        event.TargetID{ID: id, Name: "postgresql"},
-		args:      args,
-		db:        db,
-		store:     store,
-		firstPing: firstPing,
+		id:         event.TargetID{ID: id, Name: "postgresql"},
+		args:       args,
+		db:         db,
+		store:      store,
+		firstPing:  firstPing,
+		partitions: make(map[string]struct{}),
 	}
 
 	err = target.db.Ping()
@@ -19,6 +19,7 @@ package target
 import (
 	"database/sql"
 	"testing"
+	"time"
 )
 
 // TestPostgreSQLRegistration checks if postgres driver
@@ -35,3 +36,53 @@ func TestPostgreSQLRegistration(t *testing.T) {
 		t.Fatal("postgres driver not registered")
 	}
 }
+
+func TestPostgreSQLArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args      PostgreSQLArgs
+		expectErr bool
+	}{
+		{PostgreSQLArgs{Enable: true, Format: "access", Table: "events", ConnectionString: "sslmode=disable", PartitionInterval: "daily"}, false},
+		{PostgreSQLArgs{Enable: true, Format: "access", Table: "events", ConnectionString: "sslmode=disable", PartitionInterval: "monthly"}, false},
+		{PostgreSQLArgs{Enable: true, Format: "namespace", Table: "events", ConnectionString: "sslmode=disable", PartitionInterval: "daily"}, true},
+		{PostgreSQLArgs{Enable: true, Format: "access", Table: "events", ConnectionString: "sslmode=disable", PartitionInterval: "yearly"}, true},
+		{PostgreSQLArgs{Enable: true, Format: "access", Table: "events", ConnectionString: "sslmode=disable", MaxOpenConnections: 10}, false},
+		{PostgreSQLArgs{Enable: true, Format: "access", Table: "events", ConnectionString: "sslmode=disable", MaxOpenConnections: -1}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestPostgreSQLPartition(t *testing.T) {
+	eventTime := time.Date(2019, time.December, 31, 23, 30, 0, 0, time.FixedZone("", -3600))
+
+	testCases := []struct {
+		interval     string
+		expectedName string
+		expectedFrom time.Time
+		expectedTo   time.Time
+	}{
+		{PostgreSQLPartitionDaily, "events_20200101",
+			time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, time.January, 2, 0, 0, 0, 0, time.UTC)},
+		{PostgreSQLPartitionMonthly, "events_202001",
+			time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC),
+			time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for i, testCase := range testCases {
+		target := &PostgreSQLTarget{args: PostgreSQLArgs{Table: "events", PartitionInterval: testCase.interval}}
+		name, from, to := target.partition(eventTime)
+		if name != testCase.expectedName {
+			t.Fatalf("test %v: name: expected: %v, got: %v", i+1, testCase.expectedName, name)
+		}
+		if !from.Equal(testCase.expectedFrom) || !to.Equal(testCase.expectedTo) {
+			t.Fatalf("test %v: range: expected: [%v, %v), got: [%v, %v)", i+1, testCase.expectedFrom, testCase.expectedTo, from, to)
+		}
+	}
+}
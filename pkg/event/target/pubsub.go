@@ -0,0 +1,296 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/minio/minio/pkg/event"
+	"google.golang.org/api/option"
+	htransport "google.golang.org/api/transport/http"
+)
+
+const (
+	pubsubDefaultEndpoint = "https://pubsub.googleapis.com"
+	pubsubScope           = "https://www.googleapis.com/auth/pubsub"
+)
+
+// PubSubArgs - Google Cloud Pub/Sub target arguments.
+type PubSubArgs struct {
+	Enable          bool   `json:"enable"`
+	ProjectID       string `json:"projectID"`
+	Topic           string `json:"topic"`
+	CredentialsFile string `json:"credentialsFile"` // default: application default credentials
+	Endpoint        string `json:"endpoint"`        // default: https://pubsub.googleapis.com
+	OrderingKey     bool   `json:"orderingKey"`
+	QueueDir        string `json:"queueDir"`
+	QueueLimit      uint64 `json:"queueLimit"`
+}
+
+// Validate PubSubArgs fields
+func (p PubSubArgs) Validate() error {
+	if !p.Enable {
+		return nil
+	}
+	if p.ProjectID == "" {
+		return errors.New("empty projectID")
+	}
+	if p.Topic == "" {
+		return errors.New("empty topic")
+	}
+	if p.Endpoint != "" {
+		u, err := url.Parse(p.Endpoint)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			return errors.New("invalid endpoint")
+		}
+	}
+	if p.QueueDir != "" {
+		if !filepath.IsAbs(p.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if p.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// topicURL - returns the URL of the topic in the Pub/Sub REST API.
+func (p PubSubArgs) topicURL() string {
+	endpoint := p.Endpoint
+	if endpoint == "" {
+		endpoint = pubsubDefaultEndpoint
+	}
+	return fmt.Sprintf("%s/v1/projects/%s/topics/%s", strings.TrimSuffix(endpoint, "/"),
+		url.PathEscape(p.ProjectID), url.PathEscape(p.Topic))
+}
+
+// pubsubMessage - message of the Pub/Sub publish request.
+type pubsubMessage struct {
+	Data        []byte            `json:"data"`
+	Attributes  map[string]string `json:"attributes,omitempty"`
+	OrderingKey string            `json:"orderingKey,omitempty"`
+}
+
+// PubSubTarget - Google Cloud Pub/Sub target.
+type PubSubTarget struct {
+	id         event.TargetID
+	args       PubSubArgs
+	httpClient *http.Client
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *PubSubTarget) ID() event.TargetID {
+	return target.id
+}
+
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *PubSubTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
+// pubsubError - returns the error of a Pub/Sub REST API response.
+func pubsubError(resp *http.Response) error {
+	var errResp struct {
+		Error struct {
+			Message string `json:"message"`
+			Status  string `json:"status"`
+		} `json:"error"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&errResp) != nil || errResp.Error.Message == "" {
+		return fmt.Errorf("pubsub request failed with %s", resp.Status)
+	}
+	return fmt.Errorf("pubsub request failed with %s: %s", resp.Status, errResp.Error.Message)
+}
+
+// do - sends a request to the Pub/Sub REST API.
+func (target *PubSubTarget) do(method, urlStr string, body interface{}) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, urlStr, reqBody)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		// Network errors, as opposed to failures to obtain a token.
+		if uerr, ok := err.(*url.Error); ok {
+			if _, ok = uerr.Err.(net.Error); ok {
+				return errNotConnected
+			}
+		}
+		return err
+	}
+	defer func() {
+		io.Copy(ioutil.Discard, resp.Body)
+		resp.Body.Close()
+	}()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode <= 299:
+		return nil
+	case resp.StatusCode == http.StatusServiceUnavailable || resp.StatusCode == http.StatusGatewayTimeout:
+		return errNotConnected
+	}
+	return pubsubError(resp)
+}
+
+// send - sends an event to the Pub/Sub topic.
+func (target *PubSubTarget) send(eventData event.Event) error {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return err
+	}
+
+	msg := pubsubMessage{
+		Data: data,
+		Attributes: map[string]string{
+			"eventName": eventData.EventName.String(),
+			"bucket":    eventData.S3.Bucket.Name,
+			"key":       objectName,
+		},
+	}
+	// Events with the same ordering key are delivered in the order
+	// they were published, when the subscription enables ordering.
+	if target.args.OrderingKey {
+		msg.OrderingKey = key
+	}
+
+	return target.do(http.MethodPost, target.args.topicURL()+":publish", struct {
+		Messages []pubsubMessage `json:"messages"`
+	}{[]pubsubMessage{msg}})
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when the Pub/Sub endpoint is reachable.
+func (target *PubSubTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	return target.send(eventData)
+}
+
+// Send - reads an event from store and sends it to Pub/Sub.
+func (target *PubSubTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *PubSubTarget) Close() error {
+	return nil
+}
+
+// newPubSubTarget - creates new Pub/Sub target sending its requests
+// with httpClient.
+func newPubSubTarget(id string, args PubSubArgs, httpClient *http.Client, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*PubSubTarget, error) {
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-pubsub-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	target := &PubSubTarget{
+		id:         event.TargetID{ID: id, Name: "pubsub"},
+		args:       args,
+		httpClient: httpClient,
+		store:      store,
+		loggerOnce: loggerOnce,
+	}
+
+	// Check that the topic exists and can be accessed.
+	if err := target.do(http.MethodGet, args.topicURL(), nil); err != nil {
+		if store == nil || err != errNotConnected {
+			return nil, err
+		}
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, loggerOnce, target.ID())
+
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, doneCh, loggerOnce)
+	}
+
+	return target, nil
+}
+
+// NewPubSubTarget - creates new Google Cloud Pub/Sub target.
+func NewPubSubTarget(id string, args PubSubArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*PubSubTarget, error) {
+	opts := []option.ClientOption{option.WithScopes(pubsubScope)}
+	if args.CredentialsFile != "" {
+		opts = append(opts, option.WithCredentialsFile(args.CredentialsFile))
+	}
+	httpClient, _, err := htransport.NewClient(context.Background(), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return newPubSubTarget(id, args, httpClient, doneCh, loggerOnce)
+}
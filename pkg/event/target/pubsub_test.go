@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestPubSubArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args      PubSubArgs
+		expectErr bool
+	}{
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "events"}, false},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "events", Endpoint: "https://us-east1-pubsub.googleapis.com"}, false},
+		{PubSubArgs{Enable: true, Topic: "events"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "events", Endpoint: "pubsub.googleapis.com"}, true},
+		{PubSubArgs{Enable: true, ProjectID: "project", Topic: "events", QueueDir: "events"}, true},
+		{PubSubArgs{Enable: false}, false},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestPubSubTarget(t *testing.T) {
+	var published []pubsubMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/v1/projects/project/topics/events":
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/projects/project/topics/events:publish":
+			var req struct {
+				Messages []pubsubMessage `json:"messages"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			published = append(published, req.Messages...)
+			w.Write([]byte(`{"messageIds": ["1"]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"code": 404, "message": "Resource not found", "status": "NOT_FOUND"}}`))
+		}
+	}))
+	defer server.Close()
+
+	loggerOnce := func(ctx context.Context, err error, id interface{}, kind ...interface{}) {}
+	args := PubSubArgs{Enable: true, ProjectID: "project", Topic: "events", Endpoint: server.URL, OrderingKey: true}
+	target, err := newPubSubTarget("1", args, server.Client(), nil, loggerOnce)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	eventData := event.Event{
+		EventName: event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "images"},
+			Object: event.Object{Key: "photos%2Fmyphoto.jpg"},
+		},
+	}
+	if err = target.Save(eventData); err != nil {
+		t.Fatal(err)
+	}
+	if len(published) != 1 {
+		t.Fatalf("expected 1 published message, got %d", len(published))
+	}
+	msg := published[0]
+	if msg.OrderingKey != "images/photos/myphoto.jpg" {
+		t.Fatalf("unexpected ordering key %s", msg.OrderingKey)
+	}
+	if msg.Attributes["eventName"] != "s3:ObjectCreated:Put" || msg.Attributes["key"] != "photos/myphoto.jpg" {
+		t.Fatalf("unexpected attributes %v", msg.Attributes)
+	}
+	var eventLog event.Log
+	if err = json.Unmarshal(msg.Data, &eventLog); err != nil {
+		t.Fatal(err)
+	}
+	if eventLog.Key != "images/photos/myphoto.jpg" {
+		t.Fatalf("unexpected event key %s", eventLog.Key)
+	}
+
+	args.Topic = "missing"
+	if _, err = newPubSubTarget("1", args, server.Client(), nil, loggerOnce); err == nil {
+		t.Fatal("expected error for missing topic")
+	}
+}
@@ -211,3 +211,27 @@ func TestQueueStoreListN(t *testing.T) {
 		t.Fatalf("Expected List() to fail with os.ErrNotExist, %s", err)
 	}
 }
+
+// TestQueueLength - tests the queue length reported for a store.
+func TestQueueLength(t *testing.T) {
+	defer func() {
+		if err := tearDownStore(); err != nil {
+			t.Fatal("Failed to tear down store ", err)
+		}
+	}()
+	if n := queueLength(nil); n != 0 {
+		t.Fatalf("queueLength(nil) Expected: 0, got %d", n)
+	}
+	store, err := setUpStore(queueDir, 10)
+	if err != nil {
+		t.Fatal("Failed to create a queue store ", err)
+	}
+	for i := 0; i < 4; i++ {
+		if err := store.Put(testEvent); err != nil {
+			t.Fatal("Failed to put to queue store ", err)
+		}
+	}
+	if n := queueLength(store); n != 4 {
+		t.Fatalf("queueLength() Expected: 4, got %d", n)
+	}
+}
@@ -32,6 +32,10 @@ import (
 	xnet "github.com/minio/minio/pkg/net"
 )
 
+// RedisStreamFormat - events are added to a Redis Stream, in addition
+// to the namespace and access formats of all targets.
+const RedisStreamFormat = "stream"
+
 // RedisArgs - Redis target arguments.
 type RedisArgs struct {
 	Enable     bool      `json:"enable"`
@@ -39,6 +43,7 @@ type RedisArgs struct {
 	Addr       xnet.Host `json:"address"`
 	Password   string    `json:"password"`
 	Key        string    `json:"key"`
+	MaxLen     int64     `json:"maxLen"` // stream format only, default: no trimming
 	QueueDir   string    `json:"queueDir"`
 	QueueLimit uint64    `json:"queueLimit"`
 }
@@ -51,11 +56,18 @@ func (r RedisArgs) Validate() error {
 
 	if r.Format != "" {
 		f := strings.ToLower(r.Format)
-		if f != event.NamespaceFormat && f != event.AccessFormat {
+		if f != event.NamespaceFormat && f != event.AccessFormat && f != RedisStreamFormat {
 			return fmt.Errorf("unrecognized format")
 		}
 	}
 
+	if r.MaxLen < 0 {
+		return errors.New("maxLen cannot be negative")
+	}
+	if r.MaxLen > 0 && strings.ToLower(r.Format) != RedisStreamFormat {
+		return errors.New("maxLen is only supported with stream format")
+	}
+
 	if r.Key == "" {
 		return fmt.Errorf("empty key")
 	}
@@ -80,8 +92,11 @@ func (r RedisArgs) validateFormat(c redis.Conn) error {
 
 	if typeAvailable != "none" {
 		expectedType := "hash"
-		if r.Format == event.AccessFormat {
+		switch r.Format {
+		case event.AccessFormat:
 			expectedType = "list"
+		case RedisStreamFormat:
+			expectedType = "stream"
 		}
 
 		if typeAvailable != expectedType {
@@ -107,6 +122,11 @@ func (target *RedisTarget) ID() event.TargetID {
 	return target.id
 }
 
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *RedisTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
 // Save - saves the events to the store if questore is configured, which will be replayed when the redis connection is active.
 func (target *RedisTarget) Save(eventData event.Event) error {
 	if target.store != nil {
@@ -167,9 +187,41 @@ func (target *RedisTarget) send(eventData event.Event) error {
 		}
 	}
 
+	if target.args.Format == RedisStreamFormat {
+		args, err := target.xaddArgs(eventData)
+		if err != nil {
+			return err
+		}
+		if _, err := conn.Do("XADD", args...); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// xaddArgs - returns the arguments of the XADD command adding an event
+// to the stream, trimmed to about MaxLen entries if set.
+func (target *RedisTarget) xaddArgs(eventData event.Event) ([]interface{}, error) {
+	objectName, err := url.QueryUnescape(eventData.S3.Object.Key)
+	if err != nil {
+		return nil, err
+	}
+	key := eventData.S3.Bucket.Name + "/" + objectName
+
+	data, err := json.Marshal(event.Log{EventName: eventData.EventName, Key: key, Records: []event.Event{eventData}})
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{target.args.Key}
+	if target.args.MaxLen > 0 {
+		// Approximate trimming is much cheaper for Redis.
+		args = append(args, "MAXLEN", "~", target.args.MaxLen)
+	}
+	return append(args, "*", "eventName", eventData.EventName.String(), "key", key, "event", data), nil
+}
+
 // Send - reads an event from store and sends it to redis.
 func (target *RedisTarget) Send(eventKey string) error {
 	conn := target.pool.Get()
@@ -0,0 +1,76 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/minio/minio/pkg/event"
+)
+
+func TestRedisArgsValidate(t *testing.T) {
+	testCases := []struct {
+		args      RedisArgs
+		expectErr bool
+	}{
+		{RedisArgs{Enable: true, Format: "namespace", Key: "events"}, false},
+		{RedisArgs{Enable: true, Format: "stream", Key: "events"}, false},
+		{RedisArgs{Enable: true, Format: "stream", Key: "events", MaxLen: 1000}, false},
+		{RedisArgs{Enable: true, Format: "stream", Key: "events", MaxLen: -1}, true},
+		{RedisArgs{Enable: true, Format: "access", Key: "events", MaxLen: 1000}, true},
+		{RedisArgs{Enable: true, Format: "set", Key: "events"}, true},
+	}
+
+	for i, testCase := range testCases {
+		err := testCase.args.Validate()
+		if testCase.expectErr != (err != nil) {
+			t.Fatalf("test %v: error: expected: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestRedisXAddArgs(t *testing.T) {
+	eventData := event.Event{
+		EventName: event.ObjectCreatedPut,
+		S3: event.Metadata{
+			Bucket: event.Bucket{Name: "images"},
+			Object: event.Object{Key: "myphoto.jpg"},
+		},
+	}
+
+	target := &RedisTarget{args: RedisArgs{Format: RedisStreamFormat, Key: "events", MaxLen: 1000}}
+	args, err := target.xaddArgs(eventData)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []interface{}{"events", "MAXLEN", "~", int64(1000), "*", "eventName", "s3:ObjectCreated:Put", "key", "images/myphoto.jpg"}
+	if !reflect.DeepEqual(args[:len(expected)], expected) {
+		t.Fatalf("expected: %v, got: %v", expected, args[:len(expected)])
+	}
+	if len(args) != len(expected)+2 || args[len(expected)] != "event" {
+		t.Fatalf("expected event field, got: %v", args[len(expected):])
+	}
+
+	target.args.MaxLen = 0
+	if args, err = target.xaddArgs(eventData); err != nil {
+		t.Fatal(err)
+	}
+	if args[1] != "*" {
+		t.Fatalf("expected no trimming, got: %v", args)
+	}
+}
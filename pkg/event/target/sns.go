@@ -0,0 +1,191 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/service/sns"
+	"github.com/minio/minio/pkg/event"
+)
+
+// SNSArgs - AWS SNS target arguments.
+type SNSArgs struct {
+	Enable   bool   `json:"enable"`
+	TopicARN string `json:"topicARN"`
+	AWSAuthArgs
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// Validate SNSArgs fields
+func (s SNSArgs) Validate() error {
+	if !s.Enable {
+		return nil
+	}
+	if _, err := arn.Parse(s.TopicARN); err != nil {
+		return errors.New("invalid topicARN")
+	}
+	if err := s.AWSAuthArgs.Validate(); err != nil {
+		return err
+	}
+	if s.QueueDir != "" {
+		if !filepath.IsAbs(s.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if s.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// SNSTarget - AWS SNS target.
+type SNSTarget struct {
+	id         event.TargetID
+	args       SNSArgs
+	client     *sns.SNS
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *SNSTarget) ID() event.TargetID {
+	return target.id
+}
+
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *SNSTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
+// send - publishes an event to the SNS topic.
+func (target *SNSTarget) send(eventData event.Event) error {
+	data, _, _, err := awsMessage(eventData)
+	if err != nil {
+		return err
+	}
+
+	_, err = target.client.Publish(&sns.PublishInput{
+		TopicArn: aws.String(target.args.TopicARN),
+		Message:  aws.String(string(data)),
+		Subject:  aws.String(eventData.EventName.String()),
+		// Allows subscription filter policies on the event name.
+		MessageAttributes: map[string]*sns.MessageAttributeValue{
+			"eventName": {
+				DataType:    aws.String("String"),
+				StringValue: aws.String(eventData.EventName.String()),
+			},
+		},
+	})
+	if err != nil {
+		if isAWSConnErr(err) {
+			return errNotConnected
+		}
+		return err
+	}
+	return nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when SNS is reachable.
+func (target *SNSTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	return target.send(eventData)
+}
+
+// Send - reads an event from store and publishes it to SNS.
+func (target *SNSTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *SNSTarget) Close() error {
+	return nil
+}
+
+// NewSNSTarget - creates new AWS SNS target.
+func NewSNSTarget(id string, args SNSArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*SNSTarget, error) {
+	topicARN, err := arn.Parse(args.TopicARN)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := args.session(topicARN.Region)
+	if err != nil {
+		return nil, err
+	}
+
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-sns-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	target := &SNSTarget{
+		id:         event.TargetID{ID: id, Name: "sns"},
+		args:       args,
+		client:     sns.New(sess),
+		store:      store,
+		loggerOnce: loggerOnce,
+	}
+
+	// Check that the topic exists and can be accessed.
+	_, err = target.client.GetTopicAttributes(&sns.GetTopicAttributesInput{
+		TopicArn: aws.String(args.TopicARN),
+	})
+	if err != nil {
+		if store == nil || !isAWSConnErr(err) {
+			return nil, err
+		}
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, loggerOnce, target.ID())
+
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, doneCh, loggerOnce)
+	}
+
+	return target, nil
+}
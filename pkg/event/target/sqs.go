@@ -0,0 +1,201 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package target
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sqs"
+	"github.com/minio/minio/pkg/event"
+)
+
+// SQSArgs - AWS SQS target arguments.
+type SQSArgs struct {
+	Enable   bool   `json:"enable"`
+	QueueURL string `json:"queueURL"`
+	AWSAuthArgs
+	QueueDir   string `json:"queueDir"`
+	QueueLimit uint64 `json:"queueLimit"`
+}
+
+// Validate SQSArgs fields
+func (s SQSArgs) Validate() error {
+	if !s.Enable {
+		return nil
+	}
+	u, err := url.Parse(s.QueueURL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return errors.New("invalid queueURL")
+	}
+	if s.Region == "" && regionFromHost(u.Hostname()) == "" {
+		return errors.New("region should be set if not part of queueURL")
+	}
+	if err = s.AWSAuthArgs.Validate(); err != nil {
+		return err
+	}
+	if s.QueueDir != "" {
+		if !filepath.IsAbs(s.QueueDir) {
+			return errors.New("queueDir path should be absolute")
+		}
+	}
+	if s.QueueLimit > 10000 {
+		return errors.New("queueLimit should not exceed 10000")
+	}
+
+	return nil
+}
+
+// SQSTarget - AWS SQS target.
+type SQSTarget struct {
+	id         event.TargetID
+	args       SQSArgs
+	client     *sqs.SQS
+	fifo       bool
+	store      Store
+	loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})
+}
+
+// ID - returns target ID.
+func (target *SQSTarget) ID() event.TargetID {
+	return target.id
+}
+
+// QueueLength - returns the number of events waiting in the queue store.
+func (target *SQSTarget) QueueLength() int {
+	return queueLength(target.store)
+}
+
+// send - sends an event to the SQS queue.
+func (target *SQSTarget) send(eventData event.Event) error {
+	data, key, hash, err := awsMessage(eventData)
+	if err != nil {
+		return err
+	}
+
+	input := &sqs.SendMessageInput{
+		QueueUrl:    aws.String(target.args.QueueURL),
+		MessageBody: aws.String(string(data)),
+	}
+	// FIFO queues deliver the events of an object in order, a
+	// replayed event is dropped as duplicate within 5 minutes.
+	if target.fifo {
+		input.MessageGroupId = aws.String(key)
+		input.MessageDeduplicationId = aws.String(hash)
+	}
+
+	if _, err = target.client.SendMessage(input); err != nil {
+		if isAWSConnErr(err) {
+			return errNotConnected
+		}
+		return err
+	}
+	return nil
+}
+
+// Save - saves the events to the store if queuestore is configured, which will be replayed when SQS is reachable.
+func (target *SQSTarget) Save(eventData event.Event) error {
+	if target.store != nil {
+		return target.store.Put(eventData)
+	}
+	return target.send(eventData)
+}
+
+// Send - reads an event from store and sends it to SQS.
+func (target *SQSTarget) Send(eventKey string) error {
+	eventData, eErr := target.store.Get(eventKey)
+	if eErr != nil {
+		// The last event key in a successful batch will be sent in the channel atmost once by the replayEvents()
+		// Such events will not exist and wouldve been already been sent successfully.
+		if os.IsNotExist(eErr) {
+			return nil
+		}
+		return eErr
+	}
+
+	if err := target.send(eventData); err != nil {
+		return err
+	}
+
+	// Delete the event from store.
+	return target.store.Del(eventKey)
+}
+
+// Close - does nothing and available for interface compatibility.
+func (target *SQSTarget) Close() error {
+	return nil
+}
+
+// NewSQSTarget - creates new AWS SQS target.
+func NewSQSTarget(id string, args SQSArgs, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{})) (*SQSTarget, error) {
+	u, err := url.Parse(args.QueueURL)
+	if err != nil {
+		return nil, err
+	}
+	sess, err := args.session(regionFromHost(u.Hostname()))
+	if err != nil {
+		return nil, err
+	}
+
+	var store Store
+
+	if args.QueueDir != "" {
+		queueDir := filepath.Join(args.QueueDir, storePrefix+"-sqs-"+id)
+		store = NewQueueStore(queueDir, args.QueueLimit)
+		if oErr := store.Open(); oErr != nil {
+			return nil, oErr
+		}
+	}
+
+	target := &SQSTarget{
+		id:         event.TargetID{ID: id, Name: "sqs"},
+		args:       args,
+		client:     sqs.New(sess),
+		fifo:       strings.HasSuffix(u.Path, ".fifo"),
+		store:      store,
+		loggerOnce: loggerOnce,
+	}
+
+	// Check that the queue exists and can be accessed.
+	_, err = target.client.GetQueueAttributes(&sqs.GetQueueAttributesInput{
+		QueueUrl:       aws.String(args.QueueURL),
+		AttributeNames: aws.StringSlice([]string{sqs.QueueAttributeNameQueueArn}),
+	})
+	if err != nil {
+		if store == nil || !isAWSConnErr(err) {
+			return nil, err
+		}
+	}
+
+	if target.store != nil {
+		// Replays the events from the store.
+		eventKeyCh := replayEvents(target.store, doneCh, loggerOnce, target.ID())
+
+		// Start replaying events from the store.
+		go sendEvents(target, eventKeyCh, doneCh, loggerOnce)
+	}
+
+	return target, nil
+}
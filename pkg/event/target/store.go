@@ -44,6 +44,19 @@ type Store interface {
 	Open() error
 }
 
+// queueLength - returns the number of events in the store, zero if
+// events are not queued.
+func queueLength(store Store) int {
+	if store == nil {
+		return 0
+	}
+	names, err := store.List()
+	if err != nil {
+		return 0
+	}
+	return len(names)
+}
+
 // replayEvents - Reads the events from the store and replays.
 func replayEvents(store Store, doneCh <-chan struct{}, loggerOnce func(ctx context.Context, err error, id interface{}, kind ...interface{}), id event.TargetID) <-chan string {
 	eventKeyCh := make(chan string)
@@ -19,6 +19,7 @@ package event
 import (
 	"fmt"
 	"sync"
+	"time"
 )
 
 // Target - event target interface
@@ -29,10 +30,46 @@ type Target interface {
 	Close() error
 }
 
+// QueuedTarget - implemented by targets which persist events in a
+// queue store before delivering them.
+type QueuedTarget interface {
+	QueueLength() int
+}
+
+// TargetStat - delivery statistics of a target.
+type TargetStat struct {
+	// TotalEvents is the number of events sent to the target.
+	TotalEvents uint64 `json:"totalEvents"`
+	// FailedEvents is the number of events neither delivered nor queued.
+	FailedEvents  uint64    `json:"failedEvents"`
+	LastError     string    `json:"lastError,omitempty"`
+	LastErrorTime time.Time `json:"lastErrorTime"`
+	// QueueLength is the number of events waiting in the queue store.
+	QueueLength int `json:"queueLength"`
+}
+
+type targetStat struct {
+	sync.Mutex
+	TargetStat
+}
+
+func (stat *targetStat) record(err error) {
+	stat.Lock()
+	defer stat.Unlock()
+
+	stat.TotalEvents++
+	if err != nil {
+		stat.FailedEvents++
+		stat.LastError = err.Error()
+		stat.LastErrorTime = time.Now().UTC()
+	}
+}
+
 // TargetList - holds list of targets indexed by target ID.
 type TargetList struct {
 	sync.RWMutex
 	targets map[TargetID]Target
+	stats   map[TargetID]*targetStat
 }
 
 // Add - adds unique target to target list.
@@ -45,6 +82,7 @@ func (list *TargetList) Add(target Target) error {
 	}
 
 	list.targets[target.ID()] = target
+	list.stats[target.ID()] = &targetStat{}
 	return nil
 }
 
@@ -95,6 +133,7 @@ func (list *TargetList) Remove(targetids ...TargetID) <-chan TargetIDErr {
 		list.Lock()
 		for _, id := range targetids {
 			delete(list.targets, id)
+			delete(list.stats, id)
 		}
 		list.Unlock()
 	}()
@@ -126,18 +165,21 @@ func (list *TargetList) Send(event Event, targetIDs ...TargetID) <-chan TargetID
 		for _, id := range targetIDs {
 			list.RLock()
 			target, ok := list.targets[id]
+			stat := list.stats[id]
 			list.RUnlock()
 			if ok {
 				wg.Add(1)
-				go func(id TargetID, target Target) {
+				go func(id TargetID, target Target, stat *targetStat) {
 					defer wg.Done()
-					if err := target.Save(event); err != nil {
+					err := target.Save(event)
+					stat.record(err)
+					if err != nil {
 						errCh <- TargetIDErr{
 							ID:  id,
 							Err: err,
 						}
 					}
-				}(id, target)
+				}(id, target, stat)
 			}
 		}
 		wg.Wait()
@@ -146,7 +188,35 @@ func (list *TargetList) Send(event Event, targetIDs ...TargetID) <-chan TargetID
 	return errCh
 }
 
+// Stats - returns the delivery statistics of all targets.
+func (list *TargetList) Stats() map[TargetID]TargetStat {
+	list.RLock()
+	targets := make(map[TargetID]Target, len(list.targets))
+	stats := make(map[TargetID]TargetStat, len(list.stats))
+	for id, target := range list.targets {
+		targets[id] = target
+		stat := list.stats[id]
+		stat.Lock()
+		stats[id] = stat.TargetStat
+		stat.Unlock()
+	}
+	list.RUnlock()
+
+	// Queue lengths are read outside the lock, as they list the store.
+	for id, target := range targets {
+		if queued, ok := target.(QueuedTarget); ok {
+			stat := stats[id]
+			stat.QueueLength = queued.QueueLength()
+			stats[id] = stat
+		}
+	}
+	return stats
+}
+
 // NewTargetList - creates TargetList.
 func NewTargetList() *TargetList {
-	return &TargetList{targets: make(map[TargetID]Target)}
+	return &TargetList{
+		targets: make(map[TargetID]Target),
+		stats:   make(map[TargetID]*targetStat),
+	}
 }
@@ -275,6 +275,52 @@ func TestTargetListSend(t *testing.T) {
 	}
 }
 
+type queuedExampleTarget struct {
+	ExampleTarget
+}
+
+func (target queuedExampleTarget) QueueLength() int {
+	return 3
+}
+
+func TestTargetListStats(t *testing.T) {
+	targetList := NewTargetList()
+	okID := TargetID{"1", "testcase"}
+	failID := TargetID{"2", "testcase"}
+	queuedID := TargetID{"3", "testcase"}
+	for _, target := range []Target{
+		&ExampleTarget{okID, false, false},
+		&ExampleTarget{failID, true, false},
+		&queuedExampleTarget{ExampleTarget{queuedID, false, false}},
+	} {
+		if err := targetList.Add(target); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i := 0; i < 2; i++ {
+		for range targetList.Send(Event{}, okID, failID, queuedID) {
+		}
+	}
+
+	stats := targetList.Stats()
+	if stat := stats[okID]; stat.TotalEvents != 2 || stat.FailedEvents != 0 || stat.LastError != "" {
+		t.Errorf("%v: unexpected stats %+v", okID, stat)
+	}
+	if stat := stats[failID]; stat.TotalEvents != 2 || stat.FailedEvents != 2 || stat.LastError != "send error" || stat.LastErrorTime.IsZero() {
+		t.Errorf("%v: unexpected stats %+v", failID, stat)
+	}
+	if stat := stats[queuedID]; stat.TotalEvents != 2 || stat.QueueLength != 3 {
+		t.Errorf("%v: unexpected stats %+v", queuedID, stat)
+	}
+
+	for range targetList.Remove(failID) {
+	}
+	if _, ok := targetList.Stats()[failID]; ok {
+		t.Errorf("%v: stats not removed with the target", failID)
+	}
+}
+
 func TestNewTargetList(t *testing.T) {
 	if result := NewTargetList(); result == nil {
 		t.Fatalf("test: result: expected: <non-nil>, got: <nil>")
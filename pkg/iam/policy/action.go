@@ -82,6 +82,21 @@ const (
 	// GetBucketLifecycleAction - GetBucketLifecycle Rest API action.
 	GetBucketLifecycleAction = "s3:GetBucketLifecycle"
 
+	// PutBucketWebsiteAction - PutBucketWebsite Rest API action.
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
+	// GetBucketWebsiteAction - GetBucketWebsite Rest API action.
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite Rest API action.
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketEncryptionAction - PutBucketEncryption and DeleteBucketEncryption Rest API action.
+	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
+
+	// GetBucketEncryptionAction - GetBucketEncryption Rest API action.
+	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
 	// PutBucketNotificationAction - PutObjectNotification Rest API action.
 	PutBucketNotificationAction = "s3:PutBucketNotification"
 
@@ -91,6 +106,9 @@ const (
 	// PutObjectAction - PutObject Rest API action.
 	PutObjectAction = "s3:PutObject"
 
+	// RestoreObjectAction - RestoreObject Rest API action.
+	RestoreObjectAction = "s3:RestoreObject"
+
 	// AllActions - all API actions
 	AllActions = "s3:*"
 )
@@ -118,6 +136,12 @@ var supportedActions = map[Action]struct{}{
 	PutObjectAction:                  {},
 	GetBucketLifecycleAction:         {},
 	PutBucketLifecycleAction:         {},
+	PutBucketWebsiteAction:           {},
+	GetBucketWebsiteAction:           {},
+	DeleteBucketWebsiteAction:        {},
+	PutBucketEncryptionAction:        {},
+	GetBucketEncryptionAction:        {},
+	RestoreObjectAction:              {},
 }
 
 // isObjectAction - returns whether action is object type or not.
@@ -125,7 +149,7 @@ func (action Action) isObjectAction() bool {
 	switch action {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
-	case ListMultipartUploadPartsAction, PutObjectAction, AllActions:
+	case ListMultipartUploadPartsAction, PutObjectAction, RestoreObjectAction, AllActions:
 		return true
 	}
 
@@ -137,10 +161,17 @@ func (action Action) Match(a Action) bool {
 	return wildcard.Match(string(action), string(a))
 }
 
+// isAdminAction - returns whether action is an admin action or not.
+func (action Action) isAdminAction() bool {
+	return AdminAction(action).IsValid()
+}
+
 // IsValid - checks if action is valid or not.
 func (action Action) IsValid() bool {
-	_, ok := supportedActions[action]
-	return ok
+	if _, ok := supportedActions[action]; ok {
+		return true
+	}
+	return action.isAdminAction()
 }
 
 // MarshalJSON - encodes Action to JSON data.
@@ -234,4 +265,6 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 		}, condition.CommonKeys...)...),
+
+	RestoreObjectAction: condition.NewKeySet(condition.CommonKeys...),
 }
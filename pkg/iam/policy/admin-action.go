@@ -0,0 +1,182 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iampolicy
+
+import (
+	"github.com/minio/minio/pkg/policy/condition"
+)
+
+// AdminAction - admin policy action.
+type AdminAction string
+
+const (
+	// HealAdminAction - allows heal command
+	HealAdminAction = "admin:Heal"
+
+	// Service Actions
+
+	// ServerInfoAdminAction - allow listing server info
+	ServerInfoAdminAction = "admin:ServerInfo"
+	// DataUsageInfoAdminAction - allow listing data usage info
+	DataUsageInfoAdminAction = "admin:DataUsageInfo"
+	// PerfInfoAdminAction - allow listing performance and hardware info
+	PerfInfoAdminAction = "admin:PerfInfo"
+	// TopLocksAdminAction - allow listing top locks
+	TopLocksAdminAction = "admin:TopLocksInfo"
+	// ProfilingAdminAction - allow profiling
+	ProfilingAdminAction = "admin:Profiling"
+	// TraceAdminAction - allow listing server trace
+	TraceAdminAction = "admin:ServerTrace"
+	// ConsoleLogAdminAction - allow listing console logs on terminal
+	ConsoleLogAdminAction = "admin:ConsoleLog"
+	// KMSKeyStatusAdminAction - allow getting KMS key status
+	KMSKeyStatusAdminAction = "admin:KMSKeyStatus"
+	// ServerUpdateAdminAction - allow MinIO binary update
+	ServerUpdateAdminAction = "admin:ServerUpdate"
+	// ServiceRestartAdminAction - allow restart of MinIO service.
+	ServiceRestartAdminAction = "admin:ServiceRestart"
+	// ServiceStopAdminAction - allow stopping MinIO service.
+	ServiceStopAdminAction = "admin:ServiceStop"
+
+	// ConfigUpdateAdminAction - allow MinIO config management
+	ConfigUpdateAdminAction = "admin:ConfigUpdate"
+
+	// PresignAdminAction - allow generating presigned URLs
+	PresignAdminAction = "admin:Presign"
+
+	// DecommissionAdminAction - allow decommissioning server pools
+	DecommissionAdminAction = "admin:Decommission"
+
+	// GatewayCleanupAdminAction - allow removing stale multipart uploads from the gateway backend
+	GatewayCleanupAdminAction = "admin:GatewayCleanup"
+
+	// NotificationTestAdminAction - allow sending test events to notification targets
+	NotificationTestAdminAction = "admin:NotificationTest"
+
+	// BucketDefaultsAdminAction - allow managing the default object headers of buckets
+	BucketDefaultsAdminAction = "admin:BucketDefaults"
+
+	// BucketMetadataAdminAction - allow exporting and importing the metadata bundles of buckets
+	BucketMetadataAdminAction = "admin:BucketMetadata"
+
+	// BucketSnapshotAdminAction - allow creating, removing and restoring the snapshots of buckets
+	BucketSnapshotAdminAction = "admin:BucketSnapshot"
+
+	// ForceDeleteBucketAdminAction - allow deleting buckets along with all of their objects
+	ForceDeleteBucketAdminAction = "admin:ForceDeleteBucket"
+
+	// User Actions
+
+	// CreateUserAdminAction - allow creating MinIO user
+	CreateUserAdminAction = "admin:CreateUser"
+	// DeleteUserAdminAction - allow deleting MinIO user
+	DeleteUserAdminAction = "admin:DeleteUser"
+	// ListUsersAdminAction - allow list users permission
+	ListUsersAdminAction = "admin:ListUsers"
+	// EnableUserAdminAction - allow enable user permission
+	EnableUserAdminAction = "admin:EnableUser"
+	// DisableUserAdminAction - allow disable user permission
+	DisableUserAdminAction = "admin:DisableUser"
+	// GetUserAdminAction - allows GET permission on user info
+	GetUserAdminAction = "admin:GetUser"
+
+	// Group Actions
+
+	// AddUserToGroupAdminAction - allow adding and removing users from a group
+	AddUserToGroupAdminAction = "admin:AddUserToGroup"
+	// GetGroupAdminAction - allow getting group info
+	GetGroupAdminAction = "admin:GetGroup"
+	// ListGroupsAdminAction - allow list groups permission
+	ListGroupsAdminAction = "admin:ListGroups"
+	// EnableGroupAdminAction - allow enable group permission
+	EnableGroupAdminAction = "admin:EnableGroup"
+	// DisableGroupAdminAction - allow disable group permission
+	DisableGroupAdminAction = "admin:DisableGroup"
+
+	// Policy Actions
+
+	// CreatePolicyAdminAction - allow create policy permission
+	CreatePolicyAdminAction = "admin:CreatePolicy"
+	// DeletePolicyAdminAction - allow delete policy permission
+	DeletePolicyAdminAction = "admin:DeletePolicy"
+	// GetPolicyAdminAction - allow get policy permission
+	GetPolicyAdminAction = "admin:GetPolicy"
+	// AttachPolicyAdminAction - allows attaching a policy to a user/group
+	AttachPolicyAdminAction = "admin:AttachUserOrGroupPolicy"
+	// ListUserPoliciesAdminAction - allows listing user policies
+	ListUserPoliciesAdminAction = "admin:ListUserPolicies"
+
+	// AllAdminActions - provides all admin permissions
+	AllAdminActions = "admin:*"
+)
+
+// List of all supported admin actions.
+var supportedAdminActions = map[AdminAction]struct{}{
+	HealAdminAction:              {},
+	ServerInfoAdminAction:        {},
+	DataUsageInfoAdminAction:     {},
+	PerfInfoAdminAction:          {},
+	TopLocksAdminAction:          {},
+	ProfilingAdminAction:         {},
+	TraceAdminAction:             {},
+	ConsoleLogAdminAction:        {},
+	KMSKeyStatusAdminAction:      {},
+	ServerUpdateAdminAction:      {},
+	ServiceRestartAdminAction:    {},
+	ServiceStopAdminAction:       {},
+	ConfigUpdateAdminAction:      {},
+	PresignAdminAction:           {},
+	DecommissionAdminAction:      {},
+	GatewayCleanupAdminAction:    {},
+	NotificationTestAdminAction:  {},
+	BucketDefaultsAdminAction:    {},
+	BucketMetadataAdminAction:    {},
+	BucketSnapshotAdminAction:    {},
+	ForceDeleteBucketAdminAction: {},
+	CreateUserAdminAction:        {},
+	DeleteUserAdminAction:        {},
+	ListUsersAdminAction:         {},
+	EnableUserAdminAction:        {},
+	DisableUserAdminAction:       {},
+	GetUserAdminAction:           {},
+	AddUserToGroupAdminAction:    {},
+	GetGroupAdminAction:          {},
+	ListGroupsAdminAction:        {},
+	EnableGroupAdminAction:       {},
+	DisableGroupAdminAction:      {},
+	CreatePolicyAdminAction:      {},
+	DeletePolicyAdminAction:      {},
+	GetPolicyAdminAction:         {},
+	AttachPolicyAdminAction:      {},
+	ListUserPoliciesAdminAction:  {},
+	AllAdminActions:              {},
+}
+
+// IsValid - checks if action is valid or not.
+func (action AdminAction) IsValid() bool {
+	_, ok := supportedAdminActions[action]
+	return ok
+}
+
+// adminActionConditionKeyMap - holds mapping of supported condition keys for admin actions.
+var adminActionConditionKeyMap = func() map[Action]condition.KeySet {
+	keyMap := make(map[Action]condition.KeySet, len(supportedAdminActions))
+	for action := range supportedAdminActions {
+		keyMap[Action(action)] = condition.NewKeySet(condition.CommonKeys...)
+	}
+	return keyMap
+}()
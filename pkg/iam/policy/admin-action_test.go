@@ -0,0 +1,80 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package iampolicy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAdminPolicyParse(t *testing.T) {
+	testCases := []struct {
+		data      string
+		expectErr bool
+	}{
+		// Admin actions without resource.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Heal","admin:ServerTrace"]}]}`, false},
+		// All admin actions.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:*"]}]}`, false},
+		// Admin actions don't take a resource.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Heal"],"Resource":["arn:aws:s3:::*"]}]}`, true},
+		// Admin and S3 actions can't be mixed in a statement.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Heal","s3:GetObject"]}]}`, true},
+		// Unknown admin action.
+		{`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Unknown"]}]}`, true},
+	}
+
+	for i, testCase := range testCases {
+		_, err := ParseConfig(strings.NewReader(testCase.data))
+		if testCase.expectErr != (err != nil) {
+			t.Errorf("case %v: expected error: %v, got: %v", i+1, testCase.expectErr, err)
+		}
+	}
+}
+
+func TestAdminPolicyIsAllowed(t *testing.T) {
+	p, err := ParseConfig(strings.NewReader(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Action":["admin:Heal","admin:ServerTrace"]}]}`))
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testCases := []struct {
+		policy         Policy
+		action         Action
+		expectedResult bool
+	}{
+		{*p, HealAdminAction, true},
+		{*p, TraceAdminAction, true},
+		{*p, CreateUserAdminAction, false},
+		{*p, GetObjectAction, false},
+		{AdminDiagnostics, ProfilingAdminAction, true},
+		{AdminDiagnostics, ConfigUpdateAdminAction, false},
+		// S3 wildcard doesn't grant admin actions.
+		{ReadWrite, HealAdminAction, false},
+	}
+
+	for i, testCase := range testCases {
+		result := testCase.policy.IsAllowed(Args{
+			AccountName:     "Q3AM3UQ867SPQQA43P2F",
+			Action:          testCase.action,
+			ConditionValues: map[string][]string{},
+		})
+		if result != testCase.expectedResult {
+			t.Errorf("case %v: expected: %v, got: %v", i+1, testCase.expectedResult, result)
+		}
+	}
+}
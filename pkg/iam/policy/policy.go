@@ -73,6 +73,52 @@ func (iamp Policy) IsAllowed(args Args) bool {
 	return false
 }
 
+// BucketMatcher - returns a function checking whether args.Action is
+// allowed on a bucket, like IsAllowed with args.BucketName set to the
+// bucket. The statements applying to the action and to the condition
+// values of args are selected once, only their resources are matched
+// for each bucket.
+func (iamp Policy) BucketMatcher(args Args) func(bucket string) bool {
+	type bucketStatement struct {
+		resources ResourceSet
+		anyBucket bool
+	}
+	var allow, deny []bucketStatement
+	for _, statement := range iamp.Statements {
+		if !statement.Actions.Match(args.Action) || !statement.Conditions.Evaluate(args.ConditionValues) {
+			continue
+		}
+		// Admin actions are not scoped to any resource.
+		s := bucketStatement{resources: statement.Resources, anyBucket: statement.isAdmin()}
+		if statement.Effect == policy.Deny {
+			deny = append(deny, s)
+		} else {
+			allow = append(allow, s)
+		}
+	}
+
+	return func(bucket string) bool {
+		resource := bucket + "/"
+		for _, s := range deny {
+			if s.anyBucket || s.resources.Match(resource, args.ConditionValues) {
+				return false
+			}
+		}
+
+		// For owner, its allowed by default.
+		if args.IsOwner {
+			return true
+		}
+
+		for _, s := range allow {
+			if s.anyBucket || s.resources.Match(resource, args.ConditionValues) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
 // IsEmpty - returns whether policy is empty or not.
 func (iamp Policy) IsEmpty() bool {
 	return len(iamp.Statements) == 0
@@ -178,6 +178,72 @@ func TestPolicyIsAllowed(t *testing.T) {
 	}
 }
 
+func TestPolicyBucketMatcher(t *testing.T) {
+	_, IPNet, err := net.ParseCIDR("192.168.1.0/24")
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+	func1, err := condition.NewIPAddressFunc(
+		condition.AWSSourceIP,
+		IPNet,
+	)
+	if err != nil {
+		t.Fatalf("unexpected error. %v\n", err)
+	}
+
+	testPolicy := Policy{
+		Version: DefaultVersion,
+		Statements: []Statement{
+			NewStatement(
+				policy.Allow,
+				NewActionSet(ListBucketAction),
+				NewResourceSet(NewResource("logs-*", "")),
+				condition.NewFunctions(),
+			),
+			NewStatement(
+				policy.Deny,
+				NewActionSet(ListBucketAction),
+				NewResourceSet(NewResource("logs-secret", "")),
+				condition.NewFunctions(),
+			),
+			NewStatement(
+				policy.Allow,
+				NewActionSet(ListBucketAction),
+				NewResourceSet(NewResource("office", "")),
+				condition.NewFunctions(func1),
+			),
+			NewStatement(
+				policy.Allow,
+				NewActionSet(GetObjectAction),
+				NewResourceSet(NewResource("photos", "/*")),
+				condition.NewFunctions(),
+			),
+		},
+	}
+
+	buckets := []string{"logs-2019", "logs-secret", "office", "photos", "other"}
+	for i, conditionValues := range []map[string][]string{
+		{"SourceIp": {"192.168.1.10"}},
+		{"SourceIp": {"10.0.0.1"}},
+	} {
+		for _, isOwner := range []bool{false, true} {
+			args := Args{
+				AccountName:     "Q3AM3UQ867SPQQA43P2F",
+				Action:          ListBucketAction,
+				ConditionValues: conditionValues,
+				IsOwner:         isOwner,
+			}
+			matcher := testPolicy.BucketMatcher(args)
+			for _, bucket := range buckets {
+				args.BucketName = bucket
+				if expected, result := testPolicy.IsAllowed(args), matcher(bucket); result != expected {
+					t.Errorf("case %v: owner %v: bucket %v: expected: %v, got: %v\n", i+1, isOwner, bucket, expected, result)
+				}
+			}
+		}
+	}
+}
+
 func TestPolicyIsEmpty(t *testing.T) {
 	case1Policy := Policy{
 		Version: DefaultVersion,
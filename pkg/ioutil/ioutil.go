@@ -19,6 +19,7 @@
 package ioutil
 
 import (
+	"context"
 	"io"
 	"os"
 
@@ -160,6 +161,27 @@ func NewSkipReader(r io.Reader, n int64) io.Reader {
 	return &SkipReader{r, n}
 }
 
+// ContextReader stops reading from the encapsulated reader once
+// its context is canceled.
+type ContextReader struct {
+	io.Reader
+
+	ctx context.Context
+}
+
+func (c *ContextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.Reader.Read(p)
+}
+
+// NewContextReader - creates a ContextReader, reads fail with the
+// error of ctx once it is canceled.
+func NewContextReader(ctx context.Context, r io.Reader) io.Reader {
+	return &ContextReader{r, ctx}
+}
+
 // DirectIO alignment needs to be 4K. Defined here as
 // directio.AlignSize is defined as 0 in MacOS causing divide by 0 error.
 const directioAlignSize = 4096
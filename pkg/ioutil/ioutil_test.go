@@ -18,6 +18,7 @@ package ioutil
 
 import (
 	"bytes"
+	"context"
 	"io"
 	goioutil "io/ioutil"
 	"os"
@@ -101,3 +102,17 @@ func TestSkipReader(t *testing.T) {
 		}
 	}
 }
+
+func TestContextReader(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	r := NewContextReader(ctx, bytes.NewBufferString("abc"))
+
+	b := make([]byte, 1)
+	if _, err := r.Read(b); err != nil {
+		t.Fatalf("Unexpected err %v", err)
+	}
+	cancel()
+	if _, err := r.Read(b); err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
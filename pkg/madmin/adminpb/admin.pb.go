@@ -0,0 +1,1617 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// source: admin.proto
+
+package adminpb
+
+import (
+	context "context"
+	fmt "fmt"
+	proto "github.com/golang/protobuf/proto"
+	grpc "google.golang.org/grpc"
+	math "math"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ = proto.Marshal
+var _ = fmt.Errorf
+var _ = math.Inf
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the proto package it is being compiled against.
+// A compilation error at this line likely means your copy of the
+// proto package needs to be updated.
+const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
+
+type ServiceActionRequest_Action int32
+
+const (
+	ServiceActionRequest_ACTION_UNSPECIFIED ServiceActionRequest_Action = 0
+	ServiceActionRequest_RESTART            ServiceActionRequest_Action = 1
+	ServiceActionRequest_STOP               ServiceActionRequest_Action = 2
+)
+
+var ServiceActionRequest_Action_name = map[int32]string{
+	0: "ACTION_UNSPECIFIED",
+	1: "RESTART",
+	2: "STOP",
+}
+
+var ServiceActionRequest_Action_value = map[string]int32{
+	"ACTION_UNSPECIFIED": 0,
+	"RESTART":            1,
+	"STOP":               2,
+}
+
+func (x ServiceActionRequest_Action) String() string {
+	return proto.EnumName(ServiceActionRequest_Action_name, int32(x))
+}
+
+func (ServiceActionRequest_Action) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3, 0}
+}
+
+type ServerInfoRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServerInfoRequest) Reset()         { *m = ServerInfoRequest{} }
+func (m *ServerInfoRequest) String() string { return proto.CompactTextString(m) }
+func (*ServerInfoRequest) ProtoMessage()    {}
+func (*ServerInfoRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{0}
+}
+
+func (m *ServerInfoRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerInfoRequest.Unmarshal(m, b)
+}
+func (m *ServerInfoRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerInfoRequest.Marshal(b, m, deterministic)
+}
+func (m *ServerInfoRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfoRequest.Merge(m, src)
+}
+func (m *ServerInfoRequest) XXX_Size() int {
+	return xxx_messageInfo_ServerInfoRequest.Size(m)
+}
+func (m *ServerInfoRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfoRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerInfoRequest proto.InternalMessageInfo
+
+type ServerInfoResponse struct {
+	Servers              []*ServerInfo `protobuf:"bytes,1,rep,name=servers,proto3" json:"servers,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *ServerInfoResponse) Reset()         { *m = ServerInfoResponse{} }
+func (m *ServerInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*ServerInfoResponse) ProtoMessage()    {}
+func (*ServerInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{1}
+}
+
+func (m *ServerInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerInfoResponse.Unmarshal(m, b)
+}
+func (m *ServerInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *ServerInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfoResponse.Merge(m, src)
+}
+func (m *ServerInfoResponse) XXX_Size() int {
+	return xxx_messageInfo_ServerInfoResponse.Size(m)
+}
+func (m *ServerInfoResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfoResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerInfoResponse proto.InternalMessageInfo
+
+func (m *ServerInfoResponse) GetServers() []*ServerInfo {
+	if m != nil {
+		return m.Servers
+	}
+	return nil
+}
+
+type ServerInfo struct {
+	Addr                 string   `protobuf:"bytes,1,opt,name=addr,proto3" json:"addr,omitempty"`
+	Error                string   `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	UptimeSeconds        int64    `protobuf:"varint,3,opt,name=uptime_seconds,json=uptimeSeconds,proto3" json:"uptime_seconds,omitempty"`
+	Version              string   `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	CommitId             string   `protobuf:"bytes,5,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	DeploymentId         string   `protobuf:"bytes,6,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Region               string   `protobuf:"bytes,7,opt,name=region,proto3" json:"region,omitempty"`
+	SqsArns              []string `protobuf:"bytes,8,rep,name=sqs_arns,json=sqsArns,proto3" json:"sqs_arns,omitempty"`
+	UsedBytes            uint64   `protobuf:"varint,9,opt,name=used_bytes,json=usedBytes,proto3" json:"used_bytes,omitempty"`
+	TotalBytes           uint64   `protobuf:"varint,10,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	AvailableBytes       uint64   `protobuf:"varint,11,opt,name=available_bytes,json=availableBytes,proto3" json:"available_bytes,omitempty"`
+	OnlineDisks          int32    `protobuf:"varint,12,opt,name=online_disks,json=onlineDisks,proto3" json:"online_disks,omitempty"`
+	OfflineDisks         int32    `protobuf:"varint,13,opt,name=offline_disks,json=offlineDisks,proto3" json:"offline_disks,omitempty"`
+	TotalInputBytes      uint64   `protobuf:"varint,14,opt,name=total_input_bytes,json=totalInputBytes,proto3" json:"total_input_bytes,omitempty"`
+	TotalOutputBytes     uint64   `protobuf:"varint,15,opt,name=total_output_bytes,json=totalOutputBytes,proto3" json:"total_output_bytes,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServerInfo) Reset()         { *m = ServerInfo{} }
+func (m *ServerInfo) String() string { return proto.CompactTextString(m) }
+func (*ServerInfo) ProtoMessage()    {}
+func (*ServerInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{2}
+}
+
+func (m *ServerInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServerInfo.Unmarshal(m, b)
+}
+func (m *ServerInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServerInfo.Marshal(b, m, deterministic)
+}
+func (m *ServerInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServerInfo.Merge(m, src)
+}
+func (m *ServerInfo) XXX_Size() int {
+	return xxx_messageInfo_ServerInfo.Size(m)
+}
+func (m *ServerInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServerInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServerInfo proto.InternalMessageInfo
+
+func (m *ServerInfo) GetAddr() string {
+	if m != nil {
+		return m.Addr
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetUptimeSeconds() int64 {
+	if m != nil {
+		return m.UptimeSeconds
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetVersion() string {
+	if m != nil {
+		return m.Version
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetCommitId() string {
+	if m != nil {
+		return m.CommitId
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetDeploymentId() string {
+	if m != nil {
+		return m.DeploymentId
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetRegion() string {
+	if m != nil {
+		return m.Region
+	}
+	return ""
+}
+
+func (m *ServerInfo) GetSqsArns() []string {
+	if m != nil {
+		return m.SqsArns
+	}
+	return nil
+}
+
+func (m *ServerInfo) GetUsedBytes() uint64 {
+	if m != nil {
+		return m.UsedBytes
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetTotalBytes() uint64 {
+	if m != nil {
+		return m.TotalBytes
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetAvailableBytes() uint64 {
+	if m != nil {
+		return m.AvailableBytes
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetOnlineDisks() int32 {
+	if m != nil {
+		return m.OnlineDisks
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetOfflineDisks() int32 {
+	if m != nil {
+		return m.OfflineDisks
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetTotalInputBytes() uint64 {
+	if m != nil {
+		return m.TotalInputBytes
+	}
+	return 0
+}
+
+func (m *ServerInfo) GetTotalOutputBytes() uint64 {
+	if m != nil {
+		return m.TotalOutputBytes
+	}
+	return 0
+}
+
+type ServiceActionRequest struct {
+	Action               ServiceActionRequest_Action `protobuf:"varint,1,opt,name=action,proto3,enum=minio.admin.v1.ServiceActionRequest_Action" json:"action,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                    `json:"-"`
+	XXX_unrecognized     []byte                      `json:"-"`
+	XXX_sizecache        int32                       `json:"-"`
+}
+
+func (m *ServiceActionRequest) Reset()         { *m = ServiceActionRequest{} }
+func (m *ServiceActionRequest) String() string { return proto.CompactTextString(m) }
+func (*ServiceActionRequest) ProtoMessage()    {}
+func (*ServiceActionRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{3}
+}
+
+func (m *ServiceActionRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceActionRequest.Unmarshal(m, b)
+}
+func (m *ServiceActionRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceActionRequest.Marshal(b, m, deterministic)
+}
+func (m *ServiceActionRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceActionRequest.Merge(m, src)
+}
+func (m *ServiceActionRequest) XXX_Size() int {
+	return xxx_messageInfo_ServiceActionRequest.Size(m)
+}
+func (m *ServiceActionRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceActionRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceActionRequest proto.InternalMessageInfo
+
+func (m *ServiceActionRequest) GetAction() ServiceActionRequest_Action {
+	if m != nil {
+		return m.Action
+	}
+	return ServiceActionRequest_ACTION_UNSPECIFIED
+}
+
+type ServiceActionResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ServiceActionResponse) Reset()         { *m = ServiceActionResponse{} }
+func (m *ServiceActionResponse) String() string { return proto.CompactTextString(m) }
+func (*ServiceActionResponse) ProtoMessage()    {}
+func (*ServiceActionResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{4}
+}
+
+func (m *ServiceActionResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ServiceActionResponse.Unmarshal(m, b)
+}
+func (m *ServiceActionResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ServiceActionResponse.Marshal(b, m, deterministic)
+}
+func (m *ServiceActionResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ServiceActionResponse.Merge(m, src)
+}
+func (m *ServiceActionResponse) XXX_Size() int {
+	return xxx_messageInfo_ServiceActionResponse.Size(m)
+}
+func (m *ServiceActionResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ServiceActionResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ServiceActionResponse proto.InternalMessageInfo
+
+type GetConfigRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetConfigRequest) Reset()         { *m = GetConfigRequest{} }
+func (m *GetConfigRequest) String() string { return proto.CompactTextString(m) }
+func (*GetConfigRequest) ProtoMessage()    {}
+func (*GetConfigRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{5}
+}
+
+func (m *GetConfigRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetConfigRequest.Unmarshal(m, b)
+}
+func (m *GetConfigRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetConfigRequest.Marshal(b, m, deterministic)
+}
+func (m *GetConfigRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetConfigRequest.Merge(m, src)
+}
+func (m *GetConfigRequest) XXX_Size() int {
+	return xxx_messageInfo_GetConfigRequest.Size(m)
+}
+func (m *GetConfigRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetConfigRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetConfigRequest proto.InternalMessageInfo
+
+type Config struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Config) Reset()         { *m = Config{} }
+func (m *Config) String() string { return proto.CompactTextString(m) }
+func (*Config) ProtoMessage()    {}
+func (*Config) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{6}
+}
+
+func (m *Config) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Config.Unmarshal(m, b)
+}
+func (m *Config) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Config.Marshal(b, m, deterministic)
+}
+func (m *Config) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Config.Merge(m, src)
+}
+func (m *Config) XXX_Size() int {
+	return xxx_messageInfo_Config.Size(m)
+}
+func (m *Config) XXX_DiscardUnknown() {
+	xxx_messageInfo_Config.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Config proto.InternalMessageInfo
+
+func (m *Config) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type SetConfigResponse struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SetConfigResponse) Reset()         { *m = SetConfigResponse{} }
+func (m *SetConfigResponse) String() string { return proto.CompactTextString(m) }
+func (*SetConfigResponse) ProtoMessage()    {}
+func (*SetConfigResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{7}
+}
+
+func (m *SetConfigResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SetConfigResponse.Unmarshal(m, b)
+}
+func (m *SetConfigResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SetConfigResponse.Marshal(b, m, deterministic)
+}
+func (m *SetConfigResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SetConfigResponse.Merge(m, src)
+}
+func (m *SetConfigResponse) XXX_Size() int {
+	return xxx_messageInfo_SetConfigResponse.Size(m)
+}
+func (m *SetConfigResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_SetConfigResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SetConfigResponse proto.InternalMessageInfo
+
+type HealRequest struct {
+	Bucket               string   `protobuf:"bytes,1,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Prefix               string   `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	Recursive            bool     `protobuf:"varint,3,opt,name=recursive,proto3" json:"recursive,omitempty"`
+	DryRun               bool     `protobuf:"varint,4,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	Remove               bool     `protobuf:"varint,5,opt,name=remove,proto3" json:"remove,omitempty"`
+	DeepScan             bool     `protobuf:"varint,6,opt,name=deep_scan,json=deepScan,proto3" json:"deep_scan,omitempty"`
+	ForceStart           bool     `protobuf:"varint,7,opt,name=force_start,json=forceStart,proto3" json:"force_start,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealRequest) Reset()         { *m = HealRequest{} }
+func (m *HealRequest) String() string { return proto.CompactTextString(m) }
+func (*HealRequest) ProtoMessage()    {}
+func (*HealRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{8}
+}
+
+func (m *HealRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealRequest.Unmarshal(m, b)
+}
+func (m *HealRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealRequest.Marshal(b, m, deterministic)
+}
+func (m *HealRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealRequest.Merge(m, src)
+}
+func (m *HealRequest) XXX_Size() int {
+	return xxx_messageInfo_HealRequest.Size(m)
+}
+func (m *HealRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealRequest proto.InternalMessageInfo
+
+func (m *HealRequest) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *HealRequest) GetPrefix() string {
+	if m != nil {
+		return m.Prefix
+	}
+	return ""
+}
+
+func (m *HealRequest) GetRecursive() bool {
+	if m != nil {
+		return m.Recursive
+	}
+	return false
+}
+
+func (m *HealRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *HealRequest) GetRemove() bool {
+	if m != nil {
+		return m.Remove
+	}
+	return false
+}
+
+func (m *HealRequest) GetDeepScan() bool {
+	if m != nil {
+		return m.DeepScan
+	}
+	return false
+}
+
+func (m *HealRequest) GetForceStart() bool {
+	if m != nil {
+		return m.ForceStart
+	}
+	return false
+}
+
+type HealDriveInfo struct {
+	Uuid                 string   `protobuf:"bytes,1,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Endpoint             string   `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
+	State                string   `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	Block                int32    `protobuf:"varint,4,opt,name=block,proto3" json:"block,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HealDriveInfo) Reset()         { *m = HealDriveInfo{} }
+func (m *HealDriveInfo) String() string { return proto.CompactTextString(m) }
+func (*HealDriveInfo) ProtoMessage()    {}
+func (*HealDriveInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{9}
+}
+
+func (m *HealDriveInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealDriveInfo.Unmarshal(m, b)
+}
+func (m *HealDriveInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealDriveInfo.Marshal(b, m, deterministic)
+}
+func (m *HealDriveInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealDriveInfo.Merge(m, src)
+}
+func (m *HealDriveInfo) XXX_Size() int {
+	return xxx_messageInfo_HealDriveInfo.Size(m)
+}
+func (m *HealDriveInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealDriveInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealDriveInfo proto.InternalMessageInfo
+
+func (m *HealDriveInfo) GetUuid() string {
+	if m != nil {
+		return m.Uuid
+	}
+	return ""
+}
+
+func (m *HealDriveInfo) GetEndpoint() string {
+	if m != nil {
+		return m.Endpoint
+	}
+	return ""
+}
+
+func (m *HealDriveInfo) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *HealDriveInfo) GetBlock() int32 {
+	if m != nil {
+		return m.Block
+	}
+	return 0
+}
+
+type HealResultItem struct {
+	ResultIndex          int64            `protobuf:"varint,1,opt,name=result_index,json=resultIndex,proto3" json:"result_index,omitempty"`
+	Type                 string           `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Bucket               string           `protobuf:"bytes,3,opt,name=bucket,proto3" json:"bucket,omitempty"`
+	Object               string           `protobuf:"bytes,4,opt,name=object,proto3" json:"object,omitempty"`
+	Detail               string           `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	ParityBlocks         int32            `protobuf:"varint,6,opt,name=parity_blocks,json=parityBlocks,proto3" json:"parity_blocks,omitempty"`
+	DataBlocks           int32            `protobuf:"varint,7,opt,name=data_blocks,json=dataBlocks,proto3" json:"data_blocks,omitempty"`
+	DiskCount            int32            `protobuf:"varint,8,opt,name=disk_count,json=diskCount,proto3" json:"disk_count,omitempty"`
+	SetCount             int32            `protobuf:"varint,9,opt,name=set_count,json=setCount,proto3" json:"set_count,omitempty"`
+	ObjectSize           int64            `protobuf:"varint,10,opt,name=object_size,json=objectSize,proto3" json:"object_size,omitempty"`
+	Before               []*HealDriveInfo `protobuf:"bytes,11,rep,name=before,proto3" json:"before,omitempty"`
+	After                []*HealDriveInfo `protobuf:"bytes,12,rep,name=after,proto3" json:"after,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *HealResultItem) Reset()         { *m = HealResultItem{} }
+func (m *HealResultItem) String() string { return proto.CompactTextString(m) }
+func (*HealResultItem) ProtoMessage()    {}
+func (*HealResultItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{10}
+}
+
+func (m *HealResultItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HealResultItem.Unmarshal(m, b)
+}
+func (m *HealResultItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HealResultItem.Marshal(b, m, deterministic)
+}
+func (m *HealResultItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HealResultItem.Merge(m, src)
+}
+func (m *HealResultItem) XXX_Size() int {
+	return xxx_messageInfo_HealResultItem.Size(m)
+}
+func (m *HealResultItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_HealResultItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HealResultItem proto.InternalMessageInfo
+
+func (m *HealResultItem) GetResultIndex() int64 {
+	if m != nil {
+		return m.ResultIndex
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *HealResultItem) GetBucket() string {
+	if m != nil {
+		return m.Bucket
+	}
+	return ""
+}
+
+func (m *HealResultItem) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
+}
+
+func (m *HealResultItem) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *HealResultItem) GetParityBlocks() int32 {
+	if m != nil {
+		return m.ParityBlocks
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetDataBlocks() int32 {
+	if m != nil {
+		return m.DataBlocks
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetDiskCount() int32 {
+	if m != nil {
+		return m.DiskCount
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetSetCount() int32 {
+	if m != nil {
+		return m.SetCount
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetObjectSize() int64 {
+	if m != nil {
+		return m.ObjectSize
+	}
+	return 0
+}
+
+func (m *HealResultItem) GetBefore() []*HealDriveInfo {
+	if m != nil {
+		return m.Before
+	}
+	return nil
+}
+
+func (m *HealResultItem) GetAfter() []*HealDriveInfo {
+	if m != nil {
+		return m.After
+	}
+	return nil
+}
+
+type TraceRequest struct {
+	All                  bool     `protobuf:"varint,1,opt,name=all,proto3" json:"all,omitempty"`
+	ErrorsOnly           bool     `protobuf:"varint,2,opt,name=errors_only,json=errorsOnly,proto3" json:"errors_only,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceRequest) Reset()         { *m = TraceRequest{} }
+func (m *TraceRequest) String() string { return proto.CompactTextString(m) }
+func (*TraceRequest) ProtoMessage()    {}
+func (*TraceRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{11}
+}
+
+func (m *TraceRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceRequest.Unmarshal(m, b)
+}
+func (m *TraceRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceRequest.Marshal(b, m, deterministic)
+}
+func (m *TraceRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceRequest.Merge(m, src)
+}
+func (m *TraceRequest) XXX_Size() int {
+	return xxx_messageInfo_TraceRequest.Size(m)
+}
+func (m *TraceRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceRequest proto.InternalMessageInfo
+
+func (m *TraceRequest) GetAll() bool {
+	if m != nil {
+		return m.All
+	}
+	return false
+}
+
+func (m *TraceRequest) GetErrorsOnly() bool {
+	if m != nil {
+		return m.ErrorsOnly
+	}
+	return false
+}
+
+type TraceInfo struct {
+	NodeName             string   `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	FuncName             string   `protobuf:"bytes,2,opt,name=func_name,json=funcName,proto3" json:"func_name,omitempty"`
+	TimeUnixNano         int64    `protobuf:"varint,3,opt,name=time_unix_nano,json=timeUnixNano,proto3" json:"time_unix_nano,omitempty"`
+	Method               string   `protobuf:"bytes,4,opt,name=method,proto3" json:"method,omitempty"`
+	Path                 string   `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	RawQuery             string   `protobuf:"bytes,6,opt,name=raw_query,json=rawQuery,proto3" json:"raw_query,omitempty"`
+	Client               string   `protobuf:"bytes,7,opt,name=client,proto3" json:"client,omitempty"`
+	StatusCode           int32    `protobuf:"varint,8,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
+	InputBytes           int64    `protobuf:"varint,9,opt,name=input_bytes,json=inputBytes,proto3" json:"input_bytes,omitempty"`
+	OutputBytes          int64    `protobuf:"varint,10,opt,name=output_bytes,json=outputBytes,proto3" json:"output_bytes,omitempty"`
+	LatencyNanos         int64    `protobuf:"varint,11,opt,name=latency_nanos,json=latencyNanos,proto3" json:"latency_nanos,omitempty"`
+	TtfbNanos            int64    `protobuf:"varint,12,opt,name=ttfb_nanos,json=ttfbNanos,proto3" json:"ttfb_nanos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TraceInfo) Reset()         { *m = TraceInfo{} }
+func (m *TraceInfo) String() string { return proto.CompactTextString(m) }
+func (*TraceInfo) ProtoMessage()    {}
+func (*TraceInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{12}
+}
+
+func (m *TraceInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TraceInfo.Unmarshal(m, b)
+}
+func (m *TraceInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TraceInfo.Marshal(b, m, deterministic)
+}
+func (m *TraceInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TraceInfo.Merge(m, src)
+}
+func (m *TraceInfo) XXX_Size() int {
+	return xxx_messageInfo_TraceInfo.Size(m)
+}
+func (m *TraceInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TraceInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TraceInfo proto.InternalMessageInfo
+
+func (m *TraceInfo) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetFuncName() string {
+	if m != nil {
+		return m.FuncName
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetTimeUnixNano() int64 {
+	if m != nil {
+		return m.TimeUnixNano
+	}
+	return 0
+}
+
+func (m *TraceInfo) GetMethod() string {
+	if m != nil {
+		return m.Method
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetRawQuery() string {
+	if m != nil {
+		return m.RawQuery
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetClient() string {
+	if m != nil {
+		return m.Client
+	}
+	return ""
+}
+
+func (m *TraceInfo) GetStatusCode() int32 {
+	if m != nil {
+		return m.StatusCode
+	}
+	return 0
+}
+
+func (m *TraceInfo) GetInputBytes() int64 {
+	if m != nil {
+		return m.InputBytes
+	}
+	return 0
+}
+
+func (m *TraceInfo) GetOutputBytes() int64 {
+	if m != nil {
+		return m.OutputBytes
+	}
+	return 0
+}
+
+func (m *TraceInfo) GetLatencyNanos() int64 {
+	if m != nil {
+		return m.LatencyNanos
+	}
+	return 0
+}
+
+func (m *TraceInfo) GetTtfbNanos() int64 {
+	if m != nil {
+		return m.TtfbNanos
+	}
+	return 0
+}
+
+type StartProfilingRequest struct {
+	Profiler             string   `protobuf:"bytes,1,opt,name=profiler,proto3" json:"profiler,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartProfilingRequest) Reset()         { *m = StartProfilingRequest{} }
+func (m *StartProfilingRequest) String() string { return proto.CompactTextString(m) }
+func (*StartProfilingRequest) ProtoMessage()    {}
+func (*StartProfilingRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{13}
+}
+
+func (m *StartProfilingRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartProfilingRequest.Unmarshal(m, b)
+}
+func (m *StartProfilingRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartProfilingRequest.Marshal(b, m, deterministic)
+}
+func (m *StartProfilingRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartProfilingRequest.Merge(m, src)
+}
+func (m *StartProfilingRequest) XXX_Size() int {
+	return xxx_messageInfo_StartProfilingRequest.Size(m)
+}
+func (m *StartProfilingRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartProfilingRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartProfilingRequest proto.InternalMessageInfo
+
+func (m *StartProfilingRequest) GetProfiler() string {
+	if m != nil {
+		return m.Profiler
+	}
+	return ""
+}
+
+type StartProfilingResponse struct {
+	Results              []*StartProfilingResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                         `json:"-"`
+	XXX_unrecognized     []byte                           `json:"-"`
+	XXX_sizecache        int32                            `json:"-"`
+}
+
+func (m *StartProfilingResponse) Reset()         { *m = StartProfilingResponse{} }
+func (m *StartProfilingResponse) String() string { return proto.CompactTextString(m) }
+func (*StartProfilingResponse) ProtoMessage()    {}
+func (*StartProfilingResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{14}
+}
+
+func (m *StartProfilingResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartProfilingResponse.Unmarshal(m, b)
+}
+func (m *StartProfilingResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartProfilingResponse.Marshal(b, m, deterministic)
+}
+func (m *StartProfilingResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartProfilingResponse.Merge(m, src)
+}
+func (m *StartProfilingResponse) XXX_Size() int {
+	return xxx_messageInfo_StartProfilingResponse.Size(m)
+}
+func (m *StartProfilingResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartProfilingResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartProfilingResponse proto.InternalMessageInfo
+
+func (m *StartProfilingResponse) GetResults() []*StartProfilingResponse_Result {
+	if m != nil {
+		return m.Results
+	}
+	return nil
+}
+
+type StartProfilingResponse_Result struct {
+	NodeName             string   `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Success              bool     `protobuf:"varint,2,opt,name=success,proto3" json:"success,omitempty"`
+	Error                string   `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *StartProfilingResponse_Result) Reset()         { *m = StartProfilingResponse_Result{} }
+func (m *StartProfilingResponse_Result) String() string { return proto.CompactTextString(m) }
+func (*StartProfilingResponse_Result) ProtoMessage()    {}
+func (*StartProfilingResponse_Result) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{14, 0}
+}
+
+func (m *StartProfilingResponse_Result) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StartProfilingResponse_Result.Unmarshal(m, b)
+}
+func (m *StartProfilingResponse_Result) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StartProfilingResponse_Result.Marshal(b, m, deterministic)
+}
+func (m *StartProfilingResponse_Result) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StartProfilingResponse_Result.Merge(m, src)
+}
+func (m *StartProfilingResponse_Result) XXX_Size() int {
+	return xxx_messageInfo_StartProfilingResponse_Result.Size(m)
+}
+func (m *StartProfilingResponse_Result) XXX_DiscardUnknown() {
+	xxx_messageInfo_StartProfilingResponse_Result.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_StartProfilingResponse_Result proto.InternalMessageInfo
+
+func (m *StartProfilingResponse_Result) GetNodeName() string {
+	if m != nil {
+		return m.NodeName
+	}
+	return ""
+}
+
+func (m *StartProfilingResponse_Result) GetSuccess() bool {
+	if m != nil {
+		return m.Success
+	}
+	return false
+}
+
+func (m *StartProfilingResponse_Result) GetError() string {
+	if m != nil {
+		return m.Error
+	}
+	return ""
+}
+
+type DownloadProfilingDataRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DownloadProfilingDataRequest) Reset()         { *m = DownloadProfilingDataRequest{} }
+func (m *DownloadProfilingDataRequest) String() string { return proto.CompactTextString(m) }
+func (*DownloadProfilingDataRequest) ProtoMessage()    {}
+func (*DownloadProfilingDataRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{15}
+}
+
+func (m *DownloadProfilingDataRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DownloadProfilingDataRequest.Unmarshal(m, b)
+}
+func (m *DownloadProfilingDataRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DownloadProfilingDataRequest.Marshal(b, m, deterministic)
+}
+func (m *DownloadProfilingDataRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DownloadProfilingDataRequest.Merge(m, src)
+}
+func (m *DownloadProfilingDataRequest) XXX_Size() int {
+	return xxx_messageInfo_DownloadProfilingDataRequest.Size(m)
+}
+func (m *DownloadProfilingDataRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DownloadProfilingDataRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DownloadProfilingDataRequest proto.InternalMessageInfo
+
+type ProfilingData struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ProfilingData) Reset()         { *m = ProfilingData{} }
+func (m *ProfilingData) String() string { return proto.CompactTextString(m) }
+func (*ProfilingData) ProtoMessage()    {}
+func (*ProfilingData) Descriptor() ([]byte, []int) {
+	return fileDescriptor_73a7fc70dcc2027c, []int{16}
+}
+
+func (m *ProfilingData) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ProfilingData.Unmarshal(m, b)
+}
+func (m *ProfilingData) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ProfilingData.Marshal(b, m, deterministic)
+}
+func (m *ProfilingData) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ProfilingData.Merge(m, src)
+}
+func (m *ProfilingData) XXX_Size() int {
+	return xxx_messageInfo_ProfilingData.Size(m)
+}
+func (m *ProfilingData) XXX_DiscardUnknown() {
+	xxx_messageInfo_ProfilingData.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ProfilingData proto.InternalMessageInfo
+
+func (m *ProfilingData) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func init() {
+	proto.RegisterEnum("minio.admin.v1.ServiceActionRequest_Action", ServiceActionRequest_Action_name, ServiceActionRequest_Action_value)
+	proto.RegisterType((*ServerInfoRequest)(nil), "minio.admin.v1.ServerInfoRequest")
+	proto.RegisterType((*ServerInfoResponse)(nil), "minio.admin.v1.ServerInfoResponse")
+	proto.RegisterType((*ServerInfo)(nil), "minio.admin.v1.ServerInfo")
+	proto.RegisterType((*ServiceActionRequest)(nil), "minio.admin.v1.ServiceActionRequest")
+	proto.RegisterType((*ServiceActionResponse)(nil), "minio.admin.v1.ServiceActionResponse")
+	proto.RegisterType((*GetConfigRequest)(nil), "minio.admin.v1.GetConfigRequest")
+	proto.RegisterType((*Config)(nil), "minio.admin.v1.Config")
+	proto.RegisterType((*SetConfigResponse)(nil), "minio.admin.v1.SetConfigResponse")
+	proto.RegisterType((*HealRequest)(nil), "minio.admin.v1.HealRequest")
+	proto.RegisterType((*HealDriveInfo)(nil), "minio.admin.v1.HealDriveInfo")
+	proto.RegisterType((*HealResultItem)(nil), "minio.admin.v1.HealResultItem")
+	proto.RegisterType((*TraceRequest)(nil), "minio.admin.v1.TraceRequest")
+	proto.RegisterType((*TraceInfo)(nil), "minio.admin.v1.TraceInfo")
+	proto.RegisterType((*StartProfilingRequest)(nil), "minio.admin.v1.StartProfilingRequest")
+	proto.RegisterType((*StartProfilingResponse)(nil), "minio.admin.v1.StartProfilingResponse")
+	proto.RegisterType((*StartProfilingResponse_Result)(nil), "minio.admin.v1.StartProfilingResponse.Result")
+	proto.RegisterType((*DownloadProfilingDataRequest)(nil), "minio.admin.v1.DownloadProfilingDataRequest")
+	proto.RegisterType((*ProfilingData)(nil), "minio.admin.v1.ProfilingData")
+}
+
+func init() { proto.RegisterFile("admin.proto", fileDescriptor_73a7fc70dcc2027c) }
+
+var fileDescriptor_73a7fc70dcc2027c = []byte{
+	// 1314 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x8c, 0x56, 0xdd, 0x72, 0x13, 0xc7,
+	0x12, 0x3e, 0x42, 0x96, 0xb4, 0xdb, 0x92, 0x85, 0x99, 0x03, 0x66, 0x11, 0x06, 0xcc, 0x02, 0xe7,
+	0xb8, 0x12, 0xe2, 0x22, 0x90, 0x54, 0xae, 0xfd, 0x07, 0x51, 0x2e, 0x6c, 0xb2, 0x32, 0x37, 0xa9,
+	0x54, 0x6d, 0x8d, 0x76, 0x47, 0x30, 0xf1, 0x6a, 0x46, 0x9e, 0x99, 0x35, 0x16, 0xcf, 0x91, 0xeb,
+	0x5c, 0xe5, 0x21, 0xf2, 0x0c, 0x79, 0x9f, 0x54, 0x6e, 0x53, 0xd3, 0x33, 0x6b, 0x4b, 0x42, 0x31,
+	0xdc, 0x4d, 0x7f, 0xfd, 0xcd, 0x6f, 0x7f, 0xdd, 0x3d, 0xd0, 0xa6, 0xf9, 0x98, 0x8b, 0xed, 0x89,
+	0x92, 0x46, 0x92, 0xee, 0x98, 0x0b, 0x2e, 0xb7, 0x1d, 0x74, 0xf6, 0x75, 0xfc, 0x5f, 0xb8, 0x31,
+	0x60, 0xea, 0x8c, 0xa9, 0xbe, 0x18, 0xc9, 0x84, 0x9d, 0x96, 0x4c, 0x9b, 0xf8, 0x07, 0x20, 0xb3,
+	0xa0, 0x9e, 0x48, 0xa1, 0x19, 0xf9, 0x06, 0x5a, 0x1a, 0x51, 0x1d, 0xd5, 0x36, 0xeb, 0x5b, 0xed,
+	0xe7, 0xbd, 0xed, 0xf9, 0xc5, 0xb6, 0x67, 0x26, 0x55, 0xd4, 0xf8, 0xef, 0x3a, 0xc0, 0x25, 0x4e,
+	0x08, 0xac, 0xd0, 0x3c, 0x57, 0x51, 0x6d, 0xb3, 0xb6, 0x15, 0x26, 0x38, 0x26, 0x37, 0xa1, 0xc1,
+	0x94, 0x92, 0x2a, 0xba, 0x86, 0xa0, 0x33, 0xc8, 0x13, 0xe8, 0x96, 0x13, 0xc3, 0xc7, 0x2c, 0xd5,
+	0x2c, 0x93, 0x22, 0xd7, 0x51, 0x7d, 0xb3, 0xb6, 0x55, 0x4f, 0x56, 0x1d, 0x3a, 0x70, 0x20, 0x89,
+	0xa0, 0x65, 0xf7, 0xe1, 0x52, 0x44, 0x2b, 0x38, 0xbd, 0x32, 0xc9, 0x5d, 0x08, 0x33, 0x39, 0x1e,
+	0x73, 0x93, 0xf2, 0x3c, 0x6a, 0xa0, 0x2f, 0x70, 0x40, 0x3f, 0x27, 0x8f, 0x60, 0x35, 0x67, 0x93,
+	0x42, 0x4e, 0xc7, 0x4c, 0x20, 0xa1, 0x89, 0x84, 0xce, 0x25, 0xd8, 0xcf, 0xc9, 0x3a, 0x34, 0x15,
+	0x7b, 0x6b, 0x97, 0x6e, 0xa1, 0xd7, 0x5b, 0xe4, 0x0e, 0x04, 0xfa, 0x54, 0xa7, 0x54, 0x09, 0x1d,
+	0x05, 0x9b, 0x75, 0xbb, 0xa9, 0x3e, 0xd5, 0x3b, 0x4a, 0x68, 0x72, 0x0f, 0xa0, 0xd4, 0x2c, 0x4f,
+	0x87, 0x53, 0xc3, 0x74, 0x14, 0x6e, 0xd6, 0xb6, 0x56, 0x92, 0xd0, 0x22, 0xbb, 0x16, 0x20, 0x0f,
+	0xa0, 0x6d, 0xa4, 0xa1, 0x85, 0xf7, 0x03, 0xfa, 0x01, 0x21, 0x47, 0xf8, 0x3f, 0x5c, 0xa7, 0x67,
+	0x94, 0x17, 0x74, 0x58, 0x30, 0x4f, 0x6a, 0x23, 0xa9, 0x7b, 0x01, 0x3b, 0xe2, 0x43, 0xe8, 0x48,
+	0x51, 0x70, 0xc1, 0xd2, 0x9c, 0xeb, 0x13, 0x1d, 0x75, 0x36, 0x6b, 0x5b, 0x8d, 0xa4, 0xed, 0xb0,
+	0x7d, 0x0b, 0xd9, 0x3b, 0xca, 0xd1, 0x68, 0x86, 0xb3, 0x8a, 0x9c, 0x8e, 0x07, 0x1d, 0xe9, 0x0b,
+	0xb8, 0xe1, 0x4e, 0xc4, 0xc5, 0xa4, 0x34, 0x7e, 0xcb, 0x2e, 0x6e, 0x79, 0x1d, 0x1d, 0x7d, 0x8b,
+	0xbb, 0x3d, 0x9f, 0x02, 0x71, 0x5c, 0x59, 0x9a, 0x4b, 0xf2, 0x75, 0x24, 0xaf, 0xa1, 0xe7, 0xa8,
+	0x34, 0x15, 0x3b, 0xfe, 0xb5, 0x06, 0x37, 0x6d, 0xe4, 0x79, 0xc6, 0x76, 0x32, 0xc3, 0xa5, 0xf0,
+	0xf2, 0x22, 0x7b, 0xd0, 0xa4, 0x08, 0xa0, 0x0a, 0xba, 0xcf, 0xbf, 0x5c, 0xa6, 0xa3, 0xc5, 0x59,
+	0xdb, 0xde, 0xf2, 0x53, 0xe3, 0xef, 0xa0, 0xe9, 0x10, 0xb2, 0x0e, 0x64, 0x67, 0xef, 0xb8, 0x7f,
+	0x74, 0x98, 0xbe, 0x39, 0x1c, 0xbc, 0x3e, 0xd8, 0xeb, 0xbf, 0xec, 0x1f, 0xec, 0xaf, 0xfd, 0x87,
+	0xb4, 0xa1, 0x95, 0x1c, 0x0c, 0x8e, 0x77, 0x92, 0xe3, 0xb5, 0x1a, 0x09, 0x60, 0x65, 0x70, 0x7c,
+	0xf4, 0x7a, 0xed, 0x5a, 0x7c, 0x1b, 0x6e, 0x2d, 0xac, 0xef, 0xf4, 0x1d, 0x13, 0x58, 0x7b, 0xc5,
+	0xcc, 0x9e, 0x14, 0x23, 0xfe, 0xb6, 0xca, 0x84, 0x0d, 0x68, 0x3a, 0xc0, 0x0a, 0x37, 0xa7, 0x86,
+	0xe2, 0x91, 0x3b, 0x09, 0x8e, 0x5d, 0xf2, 0x5c, 0xcc, 0xf0, 0xcb, 0xfc, 0x59, 0x83, 0xf6, 0xf7,
+	0x8c, 0x16, 0xd5, 0x6d, 0xd7, 0xa1, 0x39, 0x2c, 0xb3, 0x13, 0x66, 0xbc, 0xe6, 0xbd, 0x65, 0xf1,
+	0x89, 0x62, 0x23, 0x7e, 0xee, 0x65, 0xef, 0x2d, 0xb2, 0x01, 0xa1, 0x62, 0x59, 0xa9, 0x34, 0x3f,
+	0x63, 0x28, 0xf9, 0x20, 0xb9, 0x04, 0xc8, 0x6d, 0x68, 0xe5, 0x6a, 0x9a, 0xaa, 0xd2, 0xc9, 0x3d,
+	0x48, 0x9a, 0xb9, 0x9a, 0x26, 0xa5, 0x70, 0x5a, 0x1d, 0xcb, 0x33, 0x86, 0x52, 0x0f, 0x12, 0x6f,
+	0xd9, 0x2c, 0xc8, 0x19, 0x9b, 0xa4, 0x3a, 0xa3, 0x02, 0x45, 0x1e, 0x24, 0x81, 0x05, 0x06, 0x19,
+	0x15, 0x56, 0x8e, 0x23, 0xa9, 0x32, 0x96, 0x6a, 0x43, 0x95, 0x41, 0x95, 0x07, 0x09, 0x20, 0x34,
+	0xb0, 0x48, 0x7c, 0x02, 0xab, 0xf6, 0x2e, 0xfb, 0x8a, 0x9f, 0xb1, 0x2a, 0x7f, 0xcb, 0x92, 0xe7,
+	0x55, 0xfe, 0xda, 0x31, 0xe9, 0x41, 0xc0, 0x44, 0x3e, 0x91, 0x5c, 0x18, 0x7f, 0x97, 0x0b, 0xdb,
+	0xe6, 0xb6, 0x36, 0xd4, 0xb8, 0x9b, 0x84, 0x89, 0x33, 0x2c, 0x3a, 0x2c, 0x64, 0x76, 0x82, 0x77,
+	0x68, 0x24, 0xce, 0x88, 0x7f, 0xab, 0x43, 0xd7, 0xbd, 0x9c, 0x2e, 0x0b, 0xd3, 0x37, 0x6c, 0x6c,
+	0x55, 0xae, 0xd0, 0x4a, 0xb9, 0xc8, 0xd9, 0x39, 0x6e, 0x5b, 0x4f, 0xda, 0x0e, 0xeb, 0x5b, 0xc8,
+	0x9e, 0xc8, 0x4c, 0x27, 0xcc, 0xef, 0x8c, 0xe3, 0x99, 0x37, 0xaf, 0x2f, 0xbe, 0xb9, 0x1c, 0xfe,
+	0xc2, 0x32, 0xe3, 0x6b, 0x85, 0xb7, 0x2c, 0x9e, 0x33, 0x43, 0x79, 0xe1, 0xeb, 0x84, 0xb7, 0x6c,
+	0x06, 0x4d, 0xa8, 0xe2, 0x66, 0x9a, 0xe2, 0x09, 0x35, 0x3e, 0x60, 0x23, 0xe9, 0x38, 0x70, 0x17,
+	0x31, 0xfb, 0x88, 0x56, 0x0d, 0x15, 0xa5, 0x85, 0x14, 0xb0, 0x90, 0x27, 0xdc, 0x03, 0xb0, 0xf9,
+	0x97, 0x66, 0xb2, 0x14, 0x26, 0x0a, 0xd0, 0x1f, 0x5a, 0x64, 0xcf, 0x02, 0x36, 0x42, 0x9a, 0x19,
+	0xef, 0x0d, 0xd1, 0x1b, 0x68, 0x66, 0x9c, 0xf3, 0x01, 0xb4, 0xdd, 0x19, 0x53, 0xcd, 0x3f, 0x30,
+	0x2c, 0x18, 0xf5, 0x04, 0x1c, 0x34, 0xe0, 0x1f, 0x18, 0xf9, 0x16, 0x9a, 0x43, 0x36, 0x92, 0x8a,
+	0x45, 0x6d, 0x2c, 0xca, 0xf7, 0x16, 0x93, 0x69, 0x2e, 0x7e, 0x89, 0x27, 0x93, 0x17, 0xd0, 0xa0,
+	0x23, 0xc3, 0x54, 0xd4, 0xf9, 0x9c, 0x59, 0x8e, 0x1b, 0xef, 0x40, 0xe7, 0x58, 0xd1, 0x8c, 0x55,
+	0xd2, 0x5e, 0x83, 0x3a, 0x2d, 0x0a, 0x0c, 0x4a, 0x90, 0xd8, 0xa1, 0x3d, 0x2e, 0x56, 0x6f, 0x9d,
+	0x4a, 0x51, 0x4c, 0x31, 0x26, 0x41, 0x02, 0x0e, 0x3a, 0x12, 0xc5, 0x34, 0xfe, 0xeb, 0x1a, 0x84,
+	0xb8, 0x06, 0xaa, 0xe9, 0x2e, 0x84, 0x42, 0xe6, 0x2c, 0x15, 0x74, 0xcc, 0xbc, 0xa4, 0x02, 0x0b,
+	0x1c, 0xd2, 0x31, 0x2a, 0x77, 0x54, 0x8a, 0xcc, 0x39, 0xbd, 0xae, 0x2c, 0x80, 0xce, 0xc7, 0xd0,
+	0xc5, 0xde, 0x50, 0x0a, 0x7e, 0x9e, 0x0a, 0x2a, 0xa4, 0xef, 0x0e, 0x1d, 0x8b, 0xbe, 0x11, 0xfc,
+	0xfc, 0x90, 0x0a, 0x69, 0xe3, 0x3a, 0x66, 0xe6, 0x9d, 0xcc, 0xab, 0x78, 0x3b, 0xcb, 0x6a, 0x66,
+	0x42, 0xcd, 0x3b, 0x1f, 0x6d, 0x1c, 0xdb, 0xed, 0x14, 0x7d, 0x9f, 0x9e, 0x96, 0x4c, 0x4d, 0x7d,
+	0x37, 0x08, 0x14, 0x7d, 0xff, 0xa3, 0xb5, 0xed, 0x42, 0x59, 0xc1, 0x99, 0x30, 0x55, 0x27, 0x70,
+	0x96, 0xbd, 0xaf, 0x55, 0x74, 0xa9, 0xd3, 0x4c, 0xe6, 0xcc, 0xc7, 0x16, 0x1c, 0xb4, 0x27, 0x73,
+	0x66, 0x09, 0xb3, 0x85, 0x35, 0x74, 0xf1, 0xe3, 0x97, 0x35, 0xd5, 0xd6, 0xf1, 0xd9, 0x6a, 0xea,
+	0x22, 0xdc, 0x96, 0x97, 0x85, 0xd4, 0xaa, 0xb0, 0xa0, 0x86, 0x89, 0x6c, 0x8a, 0x37, 0x75, 0x1d,
+	0xa1, 0x9e, 0x74, 0x3c, 0x68, 0x6f, 0x8a, 0x22, 0x33, 0x66, 0x34, 0xf4, 0x8c, 0x0e, 0x32, 0x42,
+	0x8b, 0xa0, 0x3b, 0x7e, 0x01, 0xb7, 0x30, 0xa3, 0x5f, 0x2b, 0x39, 0xe2, 0x05, 0x17, 0x55, 0x85,
+	0xb3, 0xc9, 0x3b, 0x41, 0x8c, 0x55, 0x4d, 0xf9, 0xc2, 0x8e, 0xff, 0xa8, 0xc1, 0xfa, 0xe2, 0x2c,
+	0xff, 0x19, 0x78, 0x05, 0x2d, 0x97, 0x84, 0xd5, 0x67, 0xe0, 0xab, 0x8f, 0x8a, 0xf8, 0xd2, 0x89,
+	0xdb, 0x2e, 0xb9, 0x93, 0x6a, 0x76, 0xef, 0x0d, 0x34, 0x1d, 0x74, 0xb5, 0x18, 0x22, 0x68, 0xe9,
+	0x32, 0xcb, 0x98, 0xd6, 0x5e, 0x54, 0x95, 0x79, 0xf9, 0x7b, 0xa8, 0xcf, 0xfc, 0x1e, 0xe2, 0xfb,
+	0xb0, 0xb1, 0x2f, 0xdf, 0x8b, 0x42, 0xd2, 0xfc, 0xe2, 0x0c, 0xfb, 0xd4, 0xd0, 0xaa, 0xb0, 0x3f,
+	0x82, 0xd5, 0x39, 0x7c, 0x59, 0x7d, 0x7f, 0xfe, 0x7b, 0x03, 0x3a, 0x3b, 0xf6, 0x3e, 0xbe, 0x61,
+	0x90, 0xc1, 0xdc, 0x5f, 0xe6, 0xe1, 0x15, 0xff, 0x1f, 0xb7, 0x4d, 0x2f, 0xbe, 0x8a, 0xe2, 0x9f,
+	0xf2, 0x67, 0x58, 0x9d, 0x6b, 0x48, 0xe4, 0xf1, 0xe7, 0xf4, 0xc3, 0xde, 0x93, 0x4f, 0xb0, 0xfc,
+	0xea, 0x07, 0x10, 0x5e, 0x74, 0x35, 0xb2, 0xb9, 0x38, 0x67, 0xb1, 0xe1, 0xf5, 0xd6, 0x17, 0x19,
+	0x7e, 0xe6, 0x4b, 0x08, 0x2f, 0x5a, 0x1d, 0xf9, 0x17, 0x52, 0x6f, 0xc9, 0x83, 0x2c, 0x74, 0x47,
+	0x72, 0x00, 0x2b, 0xb6, 0xb4, 0x90, 0xbb, 0xcb, 0x0a, 0x4e, 0x75, 0x88, 0xfb, 0xcb, 0x9d, 0x55,
+	0x57, 0x78, 0x56, 0x23, 0xbb, 0xd0, 0xc0, 0x2a, 0x42, 0x36, 0x16, 0xa9, 0xb3, 0x05, 0xaa, 0x77,
+	0x67, 0xa9, 0xd7, 0xbe, 0xfe, 0xb3, 0x1a, 0x49, 0xa1, 0x3b, 0xaf, 0x51, 0xf2, 0xe4, 0x53, 0x1a,
+	0x76, 0xab, 0xfe, 0xef, 0xf3, 0xa4, 0x4e, 0x46, 0x70, 0x6b, 0xa9, 0x06, 0xc9, 0xd3, 0xc5, 0x05,
+	0xae, 0x92, 0x6a, 0xef, 0xa3, 0xda, 0x3c, 0xc7, 0x7a, 0x56, 0xdb, 0x0d, 0x7f, 0x6a, 0xa1, 0x6f,
+	0x32, 0x1c, 0x36, 0xf1, 0x97, 0xff, 0xe2, 0x9f, 0x01, 0x00, 0x14, 0x74, 0xb8, 0x3a, 0xf4, 0x0b,
+	0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type AdminServiceClient interface {
+	// ServerInfo returns the information of all the servers.
+	ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error)
+	// ServiceAction restarts or stops all the servers.
+	ServiceAction(ctx context.Context, in *ServiceActionRequest, opts ...grpc.CallOption) (*ServiceActionResponse, error)
+	// GetConfig returns the server configuration as JSON, encrypted
+	// with madmin.EncryptData using the secret key of the caller.
+	GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error)
+	// SetConfig replaces the server configuration, encrypted like the
+	// configuration returned by GetConfig.
+	SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*SetConfigResponse, error)
+	// Heal starts a heal sequence and streams its results until
+	// it has finished.
+	Heal(ctx context.Context, in *HealRequest, opts ...grpc.CallOption) (AdminService_HealClient, error)
+	// Trace streams the HTTP trace of all the servers.
+	Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (AdminService_TraceClient, error)
+	// StartProfiling starts the given profiler on all the servers.
+	StartProfiling(ctx context.Context, in *StartProfilingRequest, opts ...grpc.CallOption) (*StartProfilingResponse, error)
+	// DownloadProfilingData streams the zipped profiling data of
+	// all the servers.
+	DownloadProfilingData(ctx context.Context, in *DownloadProfilingDataRequest, opts ...grpc.CallOption) (AdminService_DownloadProfilingDataClient, error)
+}
+
+type adminServiceClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewAdminServiceClient(cc *grpc.ClientConn) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) ServerInfo(ctx context.Context, in *ServerInfoRequest, opts ...grpc.CallOption) (*ServerInfoResponse, error) {
+	out := new(ServerInfoResponse)
+	err := c.cc.Invoke(ctx, "/minio.admin.v1.AdminService/ServerInfo", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ServiceAction(ctx context.Context, in *ServiceActionRequest, opts ...grpc.CallOption) (*ServiceActionResponse, error) {
+	out := new(ServiceActionResponse)
+	err := c.cc.Invoke(ctx, "/minio.admin.v1.AdminService/ServiceAction", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) GetConfig(ctx context.Context, in *GetConfigRequest, opts ...grpc.CallOption) (*Config, error) {
+	out := new(Config)
+	err := c.cc.Invoke(ctx, "/minio.admin.v1.AdminService/GetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetConfig(ctx context.Context, in *Config, opts ...grpc.CallOption) (*SetConfigResponse, error) {
+	out := new(SetConfigResponse)
+	err := c.cc.Invoke(ctx, "/minio.admin.v1.AdminService/SetConfig", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) Heal(ctx context.Context, in *HealRequest, opts ...grpc.CallOption) (AdminService_HealClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[0], "/minio.admin.v1.AdminService/Heal", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceHealClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_HealClient interface {
+	Recv() (*HealResultItem, error)
+	grpc.ClientStream
+}
+
+type adminServiceHealClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceHealClient) Recv() (*HealResultItem, error) {
+	m := new(HealResultItem)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) Trace(ctx context.Context, in *TraceRequest, opts ...grpc.CallOption) (AdminService_TraceClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[1], "/minio.admin.v1.AdminService/Trace", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceTraceClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_TraceClient interface {
+	Recv() (*TraceInfo, error)
+	grpc.ClientStream
+}
+
+type adminServiceTraceClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceTraceClient) Recv() (*TraceInfo, error) {
+	m := new(TraceInfo)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *adminServiceClient) StartProfiling(ctx context.Context, in *StartProfilingRequest, opts ...grpc.CallOption) (*StartProfilingResponse, error) {
+	out := new(StartProfilingResponse)
+	err := c.cc.Invoke(ctx, "/minio.admin.v1.AdminService/StartProfiling", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DownloadProfilingData(ctx context.Context, in *DownloadProfilingDataRequest, opts ...grpc.CallOption) (AdminService_DownloadProfilingDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_AdminService_serviceDesc.Streams[2], "/minio.admin.v1.AdminService/DownloadProfilingData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &adminServiceDownloadProfilingDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type AdminService_DownloadProfilingDataClient interface {
+	Recv() (*ProfilingData, error)
+	grpc.ClientStream
+}
+
+type adminServiceDownloadProfilingDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *adminServiceDownloadProfilingDataClient) Recv() (*ProfilingData, error) {
+	m := new(ProfilingData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+type AdminServiceServer interface {
+	// ServerInfo returns the information of all the servers.
+	ServerInfo(context.Context, *ServerInfoRequest) (*ServerInfoResponse, error)
+	// ServiceAction restarts or stops all the servers.
+	ServiceAction(context.Context, *ServiceActionRequest) (*ServiceActionResponse, error)
+	// GetConfig returns the server configuration as JSON, encrypted
+	// with madmin.EncryptData using the secret key of the caller.
+	GetConfig(context.Context, *GetConfigRequest) (*Config, error)
+	// SetConfig replaces the server configuration, encrypted like the
+	// configuration returned by GetConfig.
+	SetConfig(context.Context, *Config) (*SetConfigResponse, error)
+	// Heal starts a heal sequence and streams its results until
+	// it has finished.
+	Heal(*HealRequest, AdminService_HealServer) error
+	// Trace streams the HTTP trace of all the servers.
+	Trace(*TraceRequest, AdminService_TraceServer) error
+	// StartProfiling starts the given profiler on all the servers.
+	StartProfiling(context.Context, *StartProfilingRequest) (*StartProfilingResponse, error)
+	// DownloadProfilingData streams the zipped profiling data of
+	// all the servers.
+	DownloadProfilingData(*DownloadProfilingDataRequest, AdminService_DownloadProfilingDataServer) error
+}
+
+func RegisterAdminServiceServer(s *grpc.Server, srv AdminServiceServer) {
+	s.RegisterService(&_AdminService_serviceDesc, srv)
+}
+
+func _AdminService_ServerInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServerInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ServerInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/minio.admin.v1.AdminService/ServerInfo",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ServerInfo(ctx, req.(*ServerInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ServiceAction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ServiceActionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ServiceAction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/minio.admin.v1.AdminService/ServiceAction",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ServiceAction(ctx, req.(*ServiceActionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_GetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).GetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/minio.admin.v1.AdminService/GetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).GetConfig(ctx, req.(*GetConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Config)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/minio.admin.v1.AdminService/SetConfig",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetConfig(ctx, req.(*Config))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_Heal_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(HealRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).Heal(m, &adminServiceHealServer{stream})
+}
+
+type AdminService_HealServer interface {
+	Send(*HealResultItem) error
+	grpc.ServerStream
+}
+
+type adminServiceHealServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceHealServer) Send(m *HealResultItem) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_Trace_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TraceRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).Trace(m, &adminServiceTraceServer{stream})
+}
+
+type AdminService_TraceServer interface {
+	Send(*TraceInfo) error
+	grpc.ServerStream
+}
+
+type adminServiceTraceServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceTraceServer) Send(m *TraceInfo) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _AdminService_StartProfiling_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartProfilingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).StartProfiling(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/minio.admin.v1.AdminService/StartProfiling",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).StartProfiling(ctx, req.(*StartProfilingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DownloadProfilingData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadProfilingDataRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AdminServiceServer).DownloadProfilingData(m, &adminServiceDownloadProfilingDataServer{stream})
+}
+
+type AdminService_DownloadProfilingDataServer interface {
+	Send(*ProfilingData) error
+	grpc.ServerStream
+}
+
+type adminServiceDownloadProfilingDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *adminServiceDownloadProfilingDataServer) Send(m *ProfilingData) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+var _AdminService_serviceDesc = grpc.ServiceDesc{
+	ServiceName: "minio.admin.v1.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ServerInfo",
+			Handler:    _AdminService_ServerInfo_Handler,
+		},
+		{
+			MethodName: "ServiceAction",
+			Handler:    _AdminService_ServiceAction_Handler,
+		},
+		{
+			MethodName: "GetConfig",
+			Handler:    _AdminService_GetConfig_Handler,
+		},
+		{
+			MethodName: "SetConfig",
+			Handler:    _AdminService_SetConfig_Handler,
+		},
+		{
+			MethodName: "StartProfiling",
+			Handler:    _AdminService_StartProfiling_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Heal",
+			Handler:       _AdminService_Heal_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Trace",
+			Handler:       _AdminService_Trace_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DownloadProfilingData",
+			Handler:       _AdminService_DownloadProfilingData_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "admin.proto",
+}
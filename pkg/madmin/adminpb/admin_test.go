@@ -0,0 +1,49 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package adminpb
+
+import (
+	"reflect"
+	"testing"
+
+	proto "github.com/golang/protobuf/proto"
+)
+
+func TestMessagesRoundTrip(t *testing.T) {
+	testCases := []proto.Message{
+		&ServerInfoResponse{Servers: []*ServerInfo{{Addr: "127.0.0.1:9000", SqsArns: []string{"arn:minio:sqs::1:webhook"}, UsedBytes: 1 << 40, OnlineDisks: 4}}},
+		&ServiceActionRequest{Action: ServiceActionRequest_STOP},
+		&Config{Data: []byte(`{"version":"33"}`)},
+		&HealResultItem{ResultIndex: 3, Bucket: "bucket", Object: "object", Before: []*HealDriveInfo{{Uuid: "uuid", State: "missing", Block: 2}}},
+		&TraceInfo{NodeName: "node", StatusCode: 404, LatencyNanos: -1},
+		&StartProfilingResponse{Results: []*StartProfilingResponse_Result{{NodeName: "node", Success: true}}},
+	}
+
+	for i, testCase := range testCases {
+		data, err := proto.Marshal(testCase)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		msg := reflect.New(reflect.TypeOf(testCase).Elem()).Interface().(proto.Message)
+		if err = proto.Unmarshal(data, msg); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !proto.Equal(testCase, msg) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, testCase, msg)
+		}
+	}
+}
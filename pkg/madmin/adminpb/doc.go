@@ -0,0 +1,23 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package adminpb holds the messages and the gRPC client and server
+// of the admin API described in admin.proto. admin.pb.go is generated
+// by protoc-gen-go at the github.com/golang/protobuf version of go.mod,
+// regenerate it with `go generate` after changing admin.proto.
+package adminpb
+
+//go:generate protoc --go_out=plugins=grpc:. admin.proto
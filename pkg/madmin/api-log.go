@@ -17,6 +17,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -41,8 +42,8 @@ func (l LogInfo) SendLog(node, logKind string) bool {
 	return nodeFltr && typeFltr
 }
 
-// GetLogs - listen on console log messages.
-func (adm AdminClient) GetLogs(node string, lineCnt int, logKind string, doneCh <-chan struct{}) <-chan LogInfo {
+// GetLogs - listen on console log messages until the context is canceled.
+func (adm AdminClient) GetLogs(ctx context.Context, node string, lineCnt int, logKind string) <-chan LogInfo {
 	logCh := make(chan LogInfo, 1)
 
 	// Only success, start a routine to start reading line by line.
@@ -58,7 +59,7 @@ func (adm AdminClient) GetLogs(node string, lineCnt int, logKind string, doneCh
 				queryValues: urlValues,
 			}
 			// Execute GET to call log handler
-			resp, err := adm.executeMethod("GET", reqData)
+			resp, err := adm.executeMethod(ctx, "GET", reqData)
 			if err != nil {
 				closeResponse(resp)
 				return
@@ -75,12 +76,13 @@ func (adm AdminClient) GetLogs(node string, lineCnt int, logKind string, doneCh
 					break
 				}
 				select {
-				case <-doneCh:
+				case <-ctx.Done():
+					closeResponse(resp)
 					return
 				case logCh <- info:
 				}
 			}
-
+			closeResponse(resp)
 		}
 	}(logCh)
 
@@ -18,6 +18,7 @@ package madmin
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
 	"fmt"
 	"io"
@@ -61,6 +62,9 @@ type AdminClient struct {
 
 	random *rand.Rand
 
+	// Retry policy of idempotent calls.
+	retryPolicy RetryPolicy
+
 	// Advanced functionality.
 	isTraceEnabled bool
 	traceOutput    io.Writer
@@ -112,13 +116,20 @@ func privateNew(endpoint, accessKeyID, secretAccessKey string, secure bool) (*Ad
 			Transport: http.DefaultTransport,
 		},
 		// Introduce a new locked random seed.
-		random: rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())}),
+		random:      rand.New(&lockedRandSource{src: rand.NewSource(time.Now().UTC().UnixNano())}),
+		retryPolicy: DefaultRetryPolicy(),
 	}
 
 	// Return.
 	return clnt, nil
 }
 
+// SetRetryPolicy - sets how idempotent calls are retried, calls are
+// never retried once their context is canceled.
+func (adm *AdminClient) SetRetryPolicy(policy RetryPolicy) {
+	adm.retryPolicy = policy
+}
+
 // SetAppInfo - add application details to user agent.
 func (adm *AdminClient) SetAppInfo(appName string, appVersion string) {
 	// if app name and version is not set, we do not a new user
@@ -317,22 +328,26 @@ var successStatus = []int{
 	http.StatusPartialContent,
 }
 
-// executeMethod - instantiates a given method, and retries the
-// request upon any error up to maxRetries attempts in a binomially
-// delayed manner using a standard back off algorithm.
-func (adm AdminClient) executeMethod(method string, reqData requestData) (res *http.Response, err error) {
-	var reqRetry = MaxRetry // Indicates how many times we can retry the request
+// executeMethod - instantiates a given method, and retries idempotent
+// requests upon any error up to the maximum attempts of the retry
+// policy in a binomially delayed manner using a standard back off
+// algorithm. The request is abandoned once the context is canceled.
+func (adm AdminClient) executeMethod(ctx context.Context, method string, reqData requestData) (res *http.Response, err error) {
+	reqRetry := adm.retryPolicy.MaxRetry // Indicates how many times we can retry the request
+	if reqRetry < 1 || !isIdempotentMethod(method) {
+		reqRetry = 1
+	}
 
-	// Create a done channel to control 'ListObjects' go routine.
-	doneCh := make(chan struct{}, 1)
+	// Create a context to control the retry timer go routine.
+	retryCtx, cancel := context.WithCancel(ctx)
 
 	// Indicate to our routine to exit cleanly upon return.
-	defer close(doneCh)
+	defer cancel()
 
-	for range adm.newRetryTimer(reqRetry, DefaultRetryUnit, DefaultRetryCap, MaxJitter, doneCh) {
+	for range adm.newRetryTimer(retryCtx, reqRetry, adm.retryPolicy.Unit, adm.retryPolicy.Cap, adm.retryPolicy.Jitter) {
 		// Instantiate a new request.
 		var req *http.Request
-		req, err = adm.newRequest(method, reqData)
+		req, err = adm.newRequest(ctx, method, reqData)
 		if err != nil {
 			return nil, err
 		}
@@ -386,6 +401,10 @@ func (adm AdminClient) executeMethod(method string, reqData requestData) (res *h
 
 		break
 	}
+	if res == nil && err == nil {
+		// The context was canceled before the first attempt.
+		err = ctx.Err()
+	}
 	return res, err
 }
 
@@ -398,7 +417,7 @@ func (adm AdminClient) setUserAgent(req *http.Request) {
 }
 
 // newRequest - instantiate a new HTTP request for a given method.
-func (adm AdminClient) newRequest(method string, reqData requestData) (req *http.Request, err error) {
+func (adm AdminClient) newRequest(ctx context.Context, method string, reqData requestData) (req *http.Request, err error) {
 	// If no method is supplied default to 'POST'.
 	if method == "" {
 		method = "POST"
@@ -418,6 +437,7 @@ func (adm AdminClient) newRequest(method string, reqData requestData) (req *http
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
 	adm.setUserAgent(req)
 	for k, v := range reqData.customHeaders {
@@ -0,0 +1,118 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/minio/minio/pkg/bucketdefaults"
+)
+
+// GetBucketDefaults - returns the default object headers of a bucket.
+func (adm *AdminClient) GetBucketDefaults(ctx context.Context, bucket string) (*bucketdefaults.Config, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-defaults",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/bucket-defaults
+	resp, err := adm.executeMethod(ctx, "GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return bucketdefaults.ParseConfig(bytes.NewReader(respBytes))
+}
+
+// SetBucketDefaults - sets the default object headers of a bucket.
+func (adm *AdminClient) SetBucketDefaults(ctx context.Context, bucket string, config bucketdefaults.Config) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	configBytes, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-defaults",
+		queryValues: queryValues,
+		content:     configBytes,
+	}
+
+	// Execute PUT on /minio/admin/v1/bucket-defaults
+	resp, err := adm.executeMethod(ctx, "PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RemoveBucketDefaults - removes the default object headers of a bucket.
+func (adm *AdminClient) RemoveBucketDefaults(ctx context.Context, bucket string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-defaults",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/bucket-defaults
+	resp, err := adm.executeMethod(ctx, "DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// ExportBucketMetadata - returns the metadata bundle of a bucket, a
+// gzipped tarball of the policy, notification, lifecycle, encryption,
+// website and defaults configurations set on the bucket.
+func (adm *AdminClient) ExportBucketMetadata(ctx context.Context, bucket string) (io.ReadCloser, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-metadata",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/bucket-metadata
+	resp, err := adm.executeMethod(ctx, "GET", reqData)
+	if err != nil {
+		closeResponse(resp)
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer closeResponse(resp)
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	return resp.Body, nil
+}
+
+// ImportBucketMetadata - sets the configurations of a bucket from a
+// bundle returned by ExportBucketMetadata. The configurations absent
+// from the bundle are left unchanged.
+func (adm *AdminClient) ImportBucketMetadata(ctx context.Context, bucket string, bundle io.Reader) error {
+	bundleBytes, err := ioutil.ReadAll(bundle)
+	if err != nil {
+		return err
+	}
+
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-metadata",
+		queryValues: queryValues,
+		content:     bundleBytes,
+	}
+
+	// Execute PUT on /minio/admin/v1/bucket-metadata
+	resp, err := adm.executeMethod(ctx, "PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,140 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// BucketSnapshot - point-in-time copy of the objects of a bucket
+// of an FS backend.
+type BucketSnapshot struct {
+	Name    string    `json:"name"`
+	Created time.Time `json:"created"`
+}
+
+// CreateBucketSnapshot - saves the objects of a bucket as a snapshot.
+func (adm *AdminClient) CreateBucketSnapshot(ctx context.Context, bucket, name string) (BucketSnapshot, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-snapshot",
+		queryValues: queryValues,
+	}
+
+	// Execute PUT on /minio/admin/v1/bucket-snapshot
+	resp, err := adm.executeMethod(ctx, "PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return BucketSnapshot{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return BucketSnapshot{}, httpRespToErrorResponse(resp)
+	}
+
+	var snapshot BucketSnapshot
+	err = json.NewDecoder(resp.Body).Decode(&snapshot)
+	return snapshot, err
+}
+
+// ListBucketSnapshots - returns the snapshots of a bucket sorted by name.
+func (adm *AdminClient) ListBucketSnapshots(ctx context.Context, bucket string) ([]BucketSnapshot, error) {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-snapshots",
+		queryValues: queryValues,
+	}
+
+	// Execute GET on /minio/admin/v1/bucket-snapshots
+	resp, err := adm.executeMethod(ctx, "GET", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var snapshots []BucketSnapshot
+	err = json.NewDecoder(resp.Body).Decode(&snapshots)
+	return snapshots, err
+}
+
+// RemoveBucketSnapshot - removes a snapshot of a bucket.
+func (adm *AdminClient) RemoveBucketSnapshot(ctx context.Context, bucket, name string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-snapshot",
+		queryValues: queryValues,
+	}
+
+	// Execute DELETE on /minio/admin/v1/bucket-snapshot
+	resp, err := adm.executeMethod(ctx, "DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RestoreBucketSnapshot - replaces the objects of a bucket by those
+// of one of its snapshots.
+func (adm *AdminClient) RestoreBucketSnapshot(ctx context.Context, bucket, name string) error {
+	queryValues := url.Values{}
+	queryValues.Set("bucket", bucket)
+	queryValues.Set("name", name)
+
+	reqData := requestData{
+		relPath:     "/v1/bucket-snapshot/restore",
+		queryValues: queryValues,
+	}
+
+	// Execute POST on /minio/admin/v1/bucket-snapshot/restore
+	resp, err := adm.executeMethod(ctx, "POST", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
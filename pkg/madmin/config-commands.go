@@ -19,6 +19,7 @@ package madmin
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -28,9 +29,9 @@ import (
 )
 
 // GetConfig - returns the config.json of a minio setup, incoming data is encrypted.
-func (adm *AdminClient) GetConfig() ([]byte, error) {
+func (adm *AdminClient) GetConfig(ctx context.Context) ([]byte, error) {
 	// Execute GET on /minio/admin/v1/config to get config of a setup.
-	resp, err := adm.executeMethod("GET",
+	resp, err := adm.executeMethod(ctx, "GET",
 		requestData{relPath: "/v1/config"})
 	defer closeResponse(resp)
 	if err != nil {
@@ -46,7 +47,7 @@ func (adm *AdminClient) GetConfig() ([]byte, error) {
 }
 
 // SetConfig - set config supplied as config.json for the setup.
-func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
+func (adm *AdminClient) SetConfig(ctx context.Context, config io.Reader) (err error) {
 	const maxConfigJSONSize = 256 * 1024 // 256KiB
 
 	// Read configuration bytes
@@ -90,7 +91,7 @@ func (adm *AdminClient) SetConfig(config io.Reader) (err error) {
 	}
 
 	// Execute PUT on /minio/admin/v1/config to set config.
-	resp, err := adm.executeMethod("PUT", reqData)
+	resp, err := adm.executeMethod(ctx, "PUT", reqData)
 
 	defer closeResponse(resp)
 	if err != nil {
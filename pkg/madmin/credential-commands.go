@@ -0,0 +1,90 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// OldCredential - previous root credential which remains valid for
+// the given expiry while clients migrate to the new root credential.
+type OldCredential struct {
+	AccessKey string        `json:"accessKey"`
+	SecretKey string        `json:"secretKey"`
+	Expiry    time.Duration `json:"expiry"`
+}
+
+// SetOldCredential - keeps the previous root credential valid for
+// expiry, the request must be signed with the current root credential.
+func (adm *AdminClient) SetOldCredential(ctx context.Context, accessKey, secretKey string, expiry time.Duration) error {
+	data, err := json.Marshal(OldCredential{
+		AccessKey: accessKey,
+		SecretKey: secretKey,
+		Expiry:    expiry,
+	})
+	if err != nil {
+		return err
+	}
+	econfigBytes, err := EncryptData(adm.secretAccessKey, data)
+	if err != nil {
+		return err
+	}
+
+	reqData := requestData{
+		relPath: "/v1/old-credential",
+		content: econfigBytes,
+	}
+
+	// Execute PUT on /minio/admin/v1/old-credential
+	resp, err := adm.executeMethod(ctx, "PUT", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
+
+// RemoveOldCredential - invalidates the previous root credential
+// before it expires.
+func (adm *AdminClient) RemoveOldCredential(ctx context.Context) error {
+	reqData := requestData{
+		relPath: "/v1/old-credential",
+	}
+
+	// Execute DELETE on /minio/admin/v1/old-credential
+	resp, err := adm.executeMethod(ctx, "DELETE", reqData)
+
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// DecommissionState - state of decommissioning a server pool.
+type DecommissionState string
+
+const (
+	// DecommissionDraining - objects are being moved off the server pool.
+	DecommissionDraining DecommissionState = "draining"
+	// DecommissionComplete - all objects are moved, the server pool
+	// can be removed from the command line.
+	DecommissionComplete DecommissionState = "complete"
+	// DecommissionFailed - objects could not be moved off the server pool.
+	DecommissionFailed DecommissionState = "failed"
+	// DecommissionCanceled - decommissioning was canceled.
+	DecommissionCanceled DecommissionState = "canceled"
+)
+
+// PoolDecommissionStatus - progress of decommissioning a server pool.
+type PoolDecommissionStatus struct {
+	// Pool is the position of the server pool on the
+	// command line, starting at 1.
+	Pool int `json:"pool"`
+	// Endpoint is the first endpoint of the server pool.
+	Endpoint      string            `json:"endpoint"`
+	State         DecommissionState `json:"state"`
+	StartTime     time.Time         `json:"startTime"`
+	EndTime       time.Time         `json:"endTime,omitempty"`
+	ObjectsMoved  int64             `json:"objectsMoved"`
+	BytesMoved    int64             `json:"bytesMoved"`
+	ObjectsFailed int64             `json:"objectsFailed"`
+	Error         string            `json:"error,omitempty"`
+}
+
+// DecommissionPool - starts draining a server pool, new objects are no
+// longer written to it and its objects are moved to the other pools.
+func (adm *AdminClient) DecommissionPool(ctx context.Context, pool int) error {
+	return adm.decommissionAction(ctx, "/v1/pools/decommission", pool)
+}
+
+// CancelDecommissionPool - stops draining a server pool, objects
+// already moved are kept on the other pools.
+func (adm *AdminClient) CancelDecommissionPool(ctx context.Context, pool int) error {
+	return adm.decommissionAction(ctx, "/v1/pools/decommission/cancel", pool)
+}
+
+func (adm *AdminClient) decommissionAction(ctx context.Context, relPath string, pool int) error {
+	v := url.Values{}
+	v.Set("pool", strconv.Itoa(pool))
+
+	// Execute POST on /minio/admin/v1/pools/decommission
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
+		relPath:     relPath,
+		queryValues: v,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
+
+// DecommissionStatus - returns the status of all server pools
+// which are or were decommissioned.
+func (adm *AdminClient) DecommissionStatus(ctx context.Context) ([]PoolDecommissionStatus, error) {
+	// Execute GET on /minio/admin/v1/pools/decommission/status
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
+		relPath: "/v1/pools/decommission/status",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var status []PoolDecommissionStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	return status, err
+}
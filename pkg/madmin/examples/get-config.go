@@ -21,6 +21,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"log"
 
@@ -38,7 +39,7 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	configBytes, err := madmClnt.GetConfig()
+	configBytes, err := madmClnt.GetConfig(context.Background())
 	if err != nil {
 		log.Fatalf("failed due to: %v", err)
 	}
@@ -20,6 +20,7 @@
 package main
 
 import (
+	"context"
 	"github.com/minio/minio/pkg/madmin"
 	"log"
 )
@@ -34,7 +35,7 @@ func main() {
 	if err != nil {
 		log.Fatalln(err)
 	}
-	st, err := madmClnt.ServerCPUHardwareInfo()
+	st, err := madmClnt.ServerCPUHardwareInfo(context.Background())
 	if err != nil {
 		log.Fatalln(err)
 	}
@@ -20,6 +20,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -36,14 +37,14 @@ func main() {
 	if err != nil {
 		log.Fatalln(err)
 	}
-	doneCh := make(chan struct{})
-	defer close(doneCh)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
 
 	// Start listening on all http trace activity from all servers
 	// in the minio cluster.
 	allTrace := false
 	errTrace := false
-	traceCh := madmClnt.ServiceTrace(allTrace, errTrace, doneCh)
+	traceCh := madmClnt.ServiceTrace(ctx, allTrace, errTrace)
 	for traceInfo := range traceCh {
 		if traceInfo.Err != nil {
 			fmt.Println(traceInfo.Err)
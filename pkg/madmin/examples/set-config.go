@@ -21,6 +21,7 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -137,7 +138,7 @@ func main() {
 		log.Fatalln(err)
 	}
 
-	result, err := madmClnt.SetConfig(bytes.NewReader(configJSON))
+	result, err := madmClnt.SetConfig(context.Background(), bytes.NewReader(configJSON))
 	if err != nil {
 		log.Fatalln(err)
 	}
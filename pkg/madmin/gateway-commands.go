@@ -0,0 +1,40 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"net/http"
+)
+
+// GatewayCleanup - removes stale multipart uploads from the backend
+// of a gateway without waiting for the next scheduled cleanup.
+func (adm *AdminClient) GatewayCleanup(ctx context.Context) error {
+	// Execute POST on /minio/admin/v1/gateway/cleanup
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
+		relPath: "/v1/gateway/cleanup",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return httpRespToErrorResponse(resp)
+	}
+	return nil
+}
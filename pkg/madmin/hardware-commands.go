@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -44,10 +45,10 @@ type ServerCPUHardwareInfo struct {
 }
 
 // ServerCPUHardwareInfo - Returns cpu hardware information
-func (adm *AdminClient) ServerCPUHardwareInfo() ([]ServerCPUHardwareInfo, error) {
+func (adm *AdminClient) ServerCPUHardwareInfo(ctx context.Context) ([]ServerCPUHardwareInfo, error) {
 	v := url.Values{}
 	v.Set(HARDWARE, string(CPU))
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath:     "/v1/hardware",
 		queryValues: v,
 	})
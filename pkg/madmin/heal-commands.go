@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
@@ -92,6 +93,44 @@ type HealDriveInfo struct {
 	UUID     string `json:"uuid"`
 	Endpoint string `json:"endpoint"`
 	State    string `json:"state"`
+	// Block is the erasure block of an object held by the drive,
+	// numbered from 1, data blocks come before parity blocks.
+	Block int `json:"block,omitempty"`
+	// Metrics of an online drive, only reported by server info.
+	Metrics *DiskMetrics `json:"metrics,omitempty"`
+}
+
+// Erasure block type constants
+const (
+	BlockTypeData   = "data"
+	BlockTypeParity = "parity"
+)
+
+// HealDriveDiff - state of a drive before and after healing.
+type HealDriveDiff struct {
+	Endpoint  string `json:"endpoint"`
+	Block     int    `json:"block,omitempty"`
+	BlockType string `json:"blockType,omitempty"`
+	Before    string `json:"before"`
+	After     string `json:"after"`
+	// Heal is set if healing changes the state of the drive.
+	Heal bool `json:"heal"`
+}
+
+// HealBlockCounts - number of erasure blocks of an object on drives
+// in ok state.
+type HealBlockCounts struct {
+	Data   int `json:"data"`
+	Parity int `json:"parity"`
+}
+
+// HealDiff - structured report of the changes made by healing an
+// item, or which would be made when healing with dry run.
+type HealDiff struct {
+	DryRun bool            `json:"dryRun"`
+	Drives []HealDriveDiff `json:"drives"`
+	Before HealBlockCounts `json:"before"`
+	After  HealBlockCounts `json:"after"`
 }
 
 // HealResultItem - struct for an individual heal result item
@@ -113,6 +152,60 @@ type HealResultItem struct {
 		Drives []HealDriveInfo `json:"drives"`
 	} `json:"after"`
 	ObjectSize int64 `json:"objectSize"`
+	// Diff is the drive by drive comparison of Before and After.
+	Diff *HealDiff `json:"diff,omitempty"`
+}
+
+// GetDiff - returns the drive by drive comparison of the state before
+// and after heal. With dryRun, drives which are missing or corrupt
+// before heal are reported as they would be healed.
+func (hri *HealResultItem) GetDiff(dryRun bool) *HealDiff {
+	if hri == nil {
+		return nil
+	}
+	diff := &HealDiff{
+		DryRun: dryRun,
+		Drives: make([]HealDriveDiff, 0, len(hri.Before.Drives)),
+	}
+	for i, before := range hri.Before.Drives {
+		after := before
+		if i < len(hri.After.Drives) {
+			after = hri.After.Drives[i]
+		}
+		if dryRun && (before.State == DriveStateMissing || before.State == DriveStateCorrupt) {
+			after.State = DriveStateOk
+		}
+
+		d := HealDriveDiff{
+			Endpoint: before.Endpoint,
+			Block:    before.Block,
+			Before:   before.State,
+			After:    after.State,
+			Heal:     before.State != after.State,
+		}
+		if d.Block > 0 && hri.DataBlocks > 0 {
+			d.BlockType = BlockTypeData
+			if d.Block > hri.DataBlocks {
+				d.BlockType = BlockTypeParity
+			}
+			diff.Before.add(d.BlockType, d.Before)
+			diff.After.add(d.BlockType, d.After)
+		}
+		diff.Drives = append(diff.Drives, d)
+	}
+	return diff
+}
+
+func (c *HealBlockCounts) add(blockType, state string) {
+	if state != DriveStateOk {
+		return
+	}
+	switch blockType {
+	case BlockTypeData:
+		c.Data++
+	case BlockTypeParity:
+		c.Parity++
+	}
 }
 
 // GetMissingCounts - returns the number of missing disks before
@@ -195,7 +288,7 @@ func (hri *HealResultItem) GetOnlineCounts() (b, a int) {
 // forceStart and forceStop are mutually exclusive, you can either
 // set one of them to 'true'. If both are set 'forceStart' will be
 // honored.
-func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
+func (adm *AdminClient) Heal(ctx context.Context, bucket, prefix string, healOpts HealOpts,
 	clientToken string, forceStart, forceStop bool) (
 	healStart HealStartSuccess, healTaskStatus HealTaskStatus, err error) {
 
@@ -227,7 +320,7 @@ func (adm *AdminClient) Heal(bucket, prefix string, healOpts HealOpts,
 		queryVals.Set("forceStop", "true")
 	}
 
-	resp, err := adm.executeMethod("POST", requestData{
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
 		relPath:     path,
 		content:     body,
 		queryValues: queryVals,
@@ -278,9 +371,9 @@ type BgHealState struct {
 
 // BackgroundHealStatus returns the background heal status of the
 // current server or cluster.
-func (adm *AdminClient) BackgroundHealStatus() (BgHealState, error) {
+func (adm *AdminClient) BackgroundHealStatus(ctx context.Context) (BgHealState, error) {
 	// Execute POST request to background heal status api
-	resp, err := adm.executeMethod("POST", requestData{relPath: "/v1/background-heal/status"})
+	resp, err := adm.executeMethod(ctx, "POST", requestData{relPath: "/v1/background-heal/status"})
 	if err != nil {
 		return BgHealState{}, err
 	}
@@ -71,3 +71,43 @@ func TestHealDriveCounts(t *testing.T) {
 		t.Errorf("Expected '4', got %d after missing disks", i)
 	}
 }
+
+// Tests the before and after heal diff of an object on 4 data and 2
+// parity drives.
+func TestHealResultItemGetDiff(t *testing.T) {
+	rs := HealResultItem{Type: HealItemObject, DataBlocks: 4, ParityBlocks: 2}
+	states := []string{DriveStateOk, DriveStateMissing, DriveStateOk, DriveStateOk, DriveStateCorrupt, DriveStateOffline}
+	for i, state := range states {
+		rs.Before.Drives = append(rs.Before.Drives, HealDriveInfo{Endpoint: string('a' + rune(i)), State: state, Block: i + 1})
+		rs.After.Drives = append(rs.After.Drives, HealDriveInfo{Endpoint: string('a' + rune(i)), State: state, Block: i + 1})
+	}
+
+	// A dry run reports the missing and corrupt drives as healed.
+	diff := rs.GetDiff(true)
+	if !diff.DryRun || len(diff.Drives) != len(states) {
+		t.Fatalf("Unexpected diff %+v", diff)
+	}
+	if diff.Before != (HealBlockCounts{Data: 3}) || diff.After != (HealBlockCounts{Data: 4, Parity: 1}) {
+		t.Errorf("Unexpected block counts before %+v, after %+v", diff.Before, diff.After)
+	}
+	expected := []HealDriveDiff{
+		{Endpoint: "a", Block: 1, BlockType: BlockTypeData, Before: DriveStateOk, After: DriveStateOk},
+		{Endpoint: "b", Block: 2, BlockType: BlockTypeData, Before: DriveStateMissing, After: DriveStateOk, Heal: true},
+		{Endpoint: "c", Block: 3, BlockType: BlockTypeData, Before: DriveStateOk, After: DriveStateOk},
+		{Endpoint: "d", Block: 4, BlockType: BlockTypeData, Before: DriveStateOk, After: DriveStateOk},
+		{Endpoint: "e", Block: 5, BlockType: BlockTypeParity, Before: DriveStateCorrupt, After: DriveStateOk, Heal: true},
+		{Endpoint: "f", Block: 6, BlockType: BlockTypeParity, Before: DriveStateOffline, After: DriveStateOffline},
+	}
+	for i := range expected {
+		if diff.Drives[i] != expected[i] {
+			t.Errorf("Drive %d: expected %+v, got %+v", i, expected[i], diff.Drives[i])
+		}
+	}
+
+	// Without dry run the diff reports the after state as is.
+	rs.After.Drives[1].State = DriveStateOk
+	diff = rs.GetDiff(false)
+	if diff.After != (HealBlockCounts{Data: 4}) || !diff.Drives[1].Heal || diff.Drives[4].Heal {
+		t.Errorf("Unexpected diff %+v", diff)
+	}
+}
@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"io/ioutil"
@@ -57,6 +58,22 @@ const (
 // status, uuid and endpoint.
 type DriveInfo HealDriveInfo
 
+// DiskLatency - latency percentiles of the recent operations on a drive.
+type DiskLatency struct {
+	P50 time.Duration `json:"p50"`
+	P90 time.Duration `json:"p90"`
+	P99 time.Duration `json:"p99"`
+}
+
+// DiskMetrics - latency of the recent reads and writes on a drive,
+// and the number of IO errors since the server started.
+type DiskMetrics struct {
+	ReadLatency  DiskLatency `json:"readLatency"`
+	WriteLatency DiskLatency `json:"writeLatency"`
+	ReadErrors   uint64      `json:"readErrors"`
+	WriteErrors  uint64      `json:"writeErrors"`
+}
+
 // StorageInfo - represents total capacity of underlying storage.
 type StorageInfo struct {
 	Used uint64 // Total used spaced per tenant.
@@ -80,6 +97,11 @@ type StorageInfo struct {
 
 		// List of all disk status, this is only meaningful if BackendType is Erasure.
 		Sets [][]DriveInfo
+
+		// Following fields are only meaningful if BackendType is FS, as of the last disk usage crawl.
+		ObjectsCount          uint64                     // Number of objects in all buckets.
+		ObjectsSizesHistogram map[string]uint64          // Number of objects per size class.
+		BucketsUsage          map[string]BucketUsageInfo // Objects count, total size and sizes histogram per bucket.
 	}
 }
 
@@ -140,8 +162,8 @@ type ServerInfo struct {
 
 // ServerInfo - Connect to a minio server and call Server Info Management API
 // to fetch server's information represented by ServerInfo structure
-func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
-	resp, err := adm.executeMethod("GET", requestData{relPath: "/v1/info"})
+func (adm *AdminClient) ServerInfo(ctx context.Context) ([]ServerInfo, error) {
+	resp, err := adm.executeMethod(ctx, "GET", requestData{relPath: "/v1/info"})
 	defer closeResponse(resp)
 	if err != nil {
 		return nil, err
@@ -168,6 +190,53 @@ func (adm *AdminClient) ServerInfo() ([]ServerInfo, error) {
 	return serversInfo, nil
 }
 
+// BucketUsageInfo - data usage of a single bucket.
+type BucketUsageInfo struct {
+	ObjectsCount          uint64            `json:"objectsCount"`
+	ObjectsTotalSize      uint64            `json:"objectsTotalSize"`
+	ObjectsSizesHistogram map[string]uint64 `json:"objectsSizesHistogram"`
+}
+
+// DataUsageInfo - data usage of all buckets, as reported by
+// the last run of the background data usage crawler.
+type DataUsageInfo struct {
+	LastUpdate            time.Time                  `json:"lastUpdate"`
+	BucketsCount          uint64                     `json:"bucketsCount"`
+	ObjectsCount          uint64                     `json:"objectsCount"`
+	ObjectsTotalSize      uint64                     `json:"objectsTotalSize"`
+	ObjectsSizesHistogram map[string]uint64          `json:"objectsSizesHistogram"`
+	BucketsUsage          map[string]BucketUsageInfo `json:"bucketsUsage"`
+}
+
+// DataUsageInfo - returns the data usage of the cluster
+func (adm *AdminClient) DataUsageInfo(ctx context.Context) (DataUsageInfo, error) {
+	resp, err := adm.executeMethod(ctx, "GET", requestData{relPath: "/v1/datausageinfo"})
+	defer closeResponse(resp)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	// Check response http status code
+	if resp.StatusCode != http.StatusOK {
+		return DataUsageInfo{}, httpRespToErrorResponse(resp)
+	}
+
+	// Unmarshal the server's json response
+	var dataUsageInfo DataUsageInfo
+
+	respBytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	err = json.Unmarshal(respBytes, &dataUsageInfo)
+	if err != nil {
+		return DataUsageInfo{}, err
+	}
+
+	return dataUsageInfo, nil
+}
+
 // ServerDrivesPerfInfo holds informantion about address and write speed of
 // all drives in a single server node
 type ServerDrivesPerfInfo struct {
@@ -178,13 +247,13 @@ type ServerDrivesPerfInfo struct {
 }
 
 // ServerDrivesPerfInfo - Returns drive's read and write performance information
-func (adm *AdminClient) ServerDrivesPerfInfo(size int64) ([]ServerDrivesPerfInfo, error) {
+func (adm *AdminClient) ServerDrivesPerfInfo(ctx context.Context, size int64) ([]ServerDrivesPerfInfo, error) {
 	v := url.Values{}
 	v.Set("perfType", string("drive"))
 
 	v.Set("size", strconv.FormatInt(size, 10))
 
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath:     "/v1/performance",
 		queryValues: v,
 	})
@@ -225,10 +294,10 @@ type ServerCPULoadInfo struct {
 }
 
 // ServerCPULoadInfo - Returns cpu utilization information
-func (adm *AdminClient) ServerCPULoadInfo() ([]ServerCPULoadInfo, error) {
+func (adm *AdminClient) ServerCPULoadInfo(ctx context.Context) ([]ServerCPULoadInfo, error) {
 	v := url.Values{}
 	v.Set("perfType", string("cpu"))
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath:     "/v1/performance",
 		queryValues: v,
 	})
@@ -269,10 +338,10 @@ type ServerMemUsageInfo struct {
 }
 
 // ServerMemUsageInfo - Returns mem utilization information
-func (adm *AdminClient) ServerMemUsageInfo() ([]ServerMemUsageInfo, error) {
+func (adm *AdminClient) ServerMemUsageInfo(ctx context.Context) ([]ServerMemUsageInfo, error) {
 	v := url.Values{}
 	v.Set("perfType", string("mem"))
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath:     "/v1/performance",
 		queryValues: v,
 	})
@@ -311,13 +380,13 @@ type NetPerfInfo struct {
 }
 
 // NetPerfInfo - Returns network performance information of all cluster nodes.
-func (adm *AdminClient) NetPerfInfo(size int) (map[string][]NetPerfInfo, error) {
+func (adm *AdminClient) NetPerfInfo(ctx context.Context, size int) (map[string][]NetPerfInfo, error) {
 	v := url.Values{}
 	v.Set("perfType", "net")
 	if size > 0 {
 		v.Set("size", strconv.Itoa(size))
 	}
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath:     "/v1/performance",
 		queryValues: v,
 	})
@@ -17,6 +17,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
@@ -25,7 +26,7 @@ import (
 // GetKeyStatus requests status information about the key referenced by keyID
 // from the KMS connected to a MinIO by performing a Admin-API request.
 // It basically hits the `/minio/admin/v1/kms/key/status` API endpoint.
-func (adm *AdminClient) GetKeyStatus(keyID string) (*KMSKeyStatus, error) {
+func (adm *AdminClient) GetKeyStatus(ctx context.Context, keyID string) (*KMSKeyStatus, error) {
 	// GET /minio/admin/v1/kms/key/status?key-id=<keyID>
 	qv := url.Values{}
 	qv.Set("key-id", keyID)
@@ -34,7 +35,7 @@ func (adm *AdminClient) GetKeyStatus(keyID string) (*KMSKeyStatus, error) {
 		queryValues: qv,
 	}
 
-	resp, err := adm.executeMethod("GET", reqData)
+	resp, err := adm.executeMethod(ctx, "GET", reqData)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,66 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"encoding/json"
+	"net/url"
+	"strconv"
+)
+
+// Output formats supported by admin list APIs.
+const (
+	ListFormatJSON = "json"
+	ListFormatCSV  = "csv"
+)
+
+// ListOptions - pagination, filtering and output options
+// accepted by admin list APIs.
+type ListOptions struct {
+	// Marker - return entries after this key.
+	Marker string
+	// MaxItems - maximum number of entries to return.
+	MaxItems int
+	// Filter - return only entries with keys starting with this prefix.
+	Filter string
+	// Format - output format, json (default) or csv.
+	Format string
+}
+
+// queryValues - returns the query parameters for opts.
+func (opts ListOptions) queryValues() url.Values {
+	queryValues := url.Values{}
+	queryValues.Set("marker", opts.Marker)
+	if opts.MaxItems > 0 {
+		queryValues.Set("max-items", strconv.Itoa(opts.MaxItems))
+	}
+	queryValues.Set("filter", opts.Filter)
+	format := opts.Format
+	if format == "" {
+		format = ListFormatJSON
+	}
+	queryValues.Set("format", format)
+	return queryValues
+}
+
+// ListResult - paginated response envelope of admin list APIs
+// when JSON output is requested.
+type ListResult struct {
+	Items       json.RawMessage `json:"items"`
+	IsTruncated bool            `json:"isTruncated"`
+	NextMarker  string          `json:"nextMarker,omitempty"`
+}
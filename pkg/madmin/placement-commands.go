@@ -0,0 +1,58 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// PrefixPlacement - erasure set holding the objects of a bucket
+// whose names start with a prefix.
+type PrefixPlacement struct {
+	Bucket string `json:"bucket"`
+	Prefix string `json:"prefix"`
+	// Pool is the position of the server pool on the
+	// command line, starting at 1.
+	Pool int `json:"pool"`
+	// Set is the erasure set of the server pool, starting at 1.
+	Set int `json:"set"`
+	// Drives are the endpoints of the drives of the set.
+	Drives []string `json:"drives"`
+}
+
+// PlacementInfo - returns the erasure sets the prefixes pinned by
+// the placement rules of the server are stored on.
+func (adm *AdminClient) PlacementInfo(ctx context.Context) ([]PrefixPlacement, error) {
+	// Execute GET on /minio/admin/v1/placement
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
+		relPath: "/v1/placement",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, httpRespToErrorResponse(resp)
+	}
+
+	var placements []PrefixPlacement
+	err = json.NewDecoder(resp.Body).Decode(&placements)
+	return placements, err
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+)
+
+// PresignRequest - describes the presigned URL to generate.
+type PresignRequest struct {
+	// Method is one of GET, PUT or POST, POST returns the
+	// form fields of a browser based upload.
+	Method string `json:"method"`
+	Bucket string `json:"bucket"`
+	Object string `json:"object"`
+	// Expiry in seconds, defaults to 7 days.
+	Expiry int64 `json:"expiry,omitempty"`
+	// ContentType the upload must be sent with, only for PUT and POST.
+	ContentType string `json:"contentType,omitempty"`
+	// MaxSize of the upload in bytes, only for POST.
+	MaxSize int64 `json:"maxSize,omitempty"`
+}
+
+// PresignResponse - presigned URL and, for POST, the form fields
+// to be sent along with the file.
+type PresignResponse struct {
+	URL      string            `json:"url"`
+	FormData map[string]string `json:"formData,omitempty"`
+}
+
+// Presign - returns a presigned URL signed with the credentials of
+// the caller.
+func (adm *AdminClient) Presign(ctx context.Context, req PresignRequest) (PresignResponse, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return PresignResponse{}, err
+	}
+
+	// Execute POST on /minio/admin/v1/presign
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
+		relPath: "/v1/presign",
+		content: data,
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return PresignResponse{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return PresignResponse{}, httpRespToErrorResponse(resp)
+	}
+
+	var presignResp PresignResponse
+	err = json.NewDecoder(resp.Body).Decode(&presignResp)
+	return presignResp, err
+}
@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -50,10 +51,10 @@ type StartProfilingResult struct {
 
 // StartProfiling makes an admin call to remotely start profiling on a standalone
 // server or the whole cluster in  case of a distributed setup.
-func (adm *AdminClient) StartProfiling(profiler ProfilerType) ([]StartProfilingResult, error) {
+func (adm *AdminClient) StartProfiling(ctx context.Context, profiler ProfilerType) ([]StartProfilingResult, error) {
 	v := url.Values{}
 	v.Set("profilerType", string(profiler))
-	resp, err := adm.executeMethod("POST", requestData{
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
 		relPath:     "/v1/profiling/start",
 		queryValues: v,
 	})
@@ -82,9 +83,9 @@ func (adm *AdminClient) StartProfiling(profiler ProfilerType) ([]StartProfilingR
 
 // DownloadProfilingData makes an admin call to download profiling data of a standalone
 // server or of the whole cluster in  case of a distributed setup.
-func (adm *AdminClient) DownloadProfilingData() (io.ReadCloser, error) {
+func (adm *AdminClient) DownloadProfilingData(ctx context.Context) (io.ReadCloser, error) {
 	path := fmt.Sprintf("/v1/profiling/download")
-	resp, err := adm.executeMethod("GET", requestData{
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
 		relPath: path,
 	})
 
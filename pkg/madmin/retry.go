@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"math/rand"
 	"net"
 	"net/http"
@@ -44,6 +45,39 @@ const DefaultRetryUnit = time.Second
 // this maximum time duration.
 const DefaultRetryCap = time.Second * 30
 
+// RetryPolicy - how idempotent calls are retried upon network errors
+// and retryable error responses. Calls which are not idempotent, like
+// restarting the servers or starting a heal, are never retried.
+type RetryPolicy struct {
+	// Maximum number of attempts, one or less disables retries.
+	MaxRetry int
+	// Unit of the exponential backoff between attempts.
+	Unit time.Duration
+	// The backoff between attempts never exceeds Cap.
+	Cap time.Duration
+	// Jitter randomizes the backoff, from NoJitter to MaxJitter.
+	Jitter float64
+}
+
+// DefaultRetryPolicy - returns the retry policy of new clients.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetry: MaxRetry,
+		Unit:     DefaultRetryUnit,
+		Cap:      DefaultRetryCap,
+		Jitter:   MaxJitter,
+	}
+}
+
+// isIdempotentMethod - requests of these methods may be retried.
+func isIdempotentMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
 // lockedRandSource provides protected rand source, implements rand.Source interface.
 type lockedRandSource struct {
 	lk  sync.Mutex
@@ -67,8 +101,9 @@ func (r *lockedRandSource) Seed(seed int64) {
 }
 
 // newRetryTimer creates a timer with exponentially increasing
-// delays until the maximum retry attempts are reached.
-func (adm AdminClient) newRetryTimer(maxRetry int, unit time.Duration, cap time.Duration, jitter float64, doneCh chan struct{}) <-chan int {
+// delays until the maximum retry attempts are reached or the
+// context is canceled.
+func (adm AdminClient) newRetryTimer(ctx context.Context, maxRetry int, unit time.Duration, cap time.Duration, jitter float64) <-chan int {
 	attemptCh := make(chan int)
 
 	// computes the exponential backoff duration according to
@@ -99,11 +134,18 @@ func (adm AdminClient) newRetryTimer(maxRetry int, unit time.Duration, cap time.
 			select {
 			// Attempts start from 1.
 			case attemptCh <- i + 1:
-			case <-doneCh:
+			case <-ctx.Done():
 				// Stop the routine.
 				return
 			}
-			time.Sleep(exponentialBackoffWait(i))
+
+			timer := time.NewTimer(exponentialBackoffWait(i))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				return
+			}
 		}
 	}()
 	return attemptCh
@@ -0,0 +1,109 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package madmin
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestExecuteMethodRetry(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm, err := New(u.Host, "minio", "minio123", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm.SetRetryPolicy(RetryPolicy{MaxRetry: 3, Unit: time.Millisecond, Cap: time.Millisecond, Jitter: MaxJitter})
+
+	testCases := []struct {
+		method   string
+		attempts int32
+	}{
+		// Idempotent calls are retried.
+		{http.MethodGet, 3},
+		{http.MethodPut, 3},
+		{http.MethodDelete, 3},
+		// Other calls are attempted once.
+		{http.MethodPost, 1},
+	}
+	for i, testCase := range testCases {
+		atomic.StoreInt32(&attempts, 0)
+		resp, err := adm.executeMethod(context.Background(), testCase.method, requestData{relPath: "/v1/info"})
+		closeResponse(resp)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("Test %d: expected status %d, got %d", i+1, http.StatusServiceUnavailable, resp.StatusCode)
+		}
+		if n := atomic.LoadInt32(&attempts); n != testCase.attempts {
+			t.Errorf("Test %d: expected %d attempts, got %d", i+1, testCase.attempts, n)
+		}
+	}
+}
+
+func TestExecuteMethodContext(t *testing.T) {
+	// The server never replies, like a partitioned node.
+	unblockCh := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-unblockCh
+	}))
+	defer server.Close()
+	defer close(unblockCh)
+
+	u, err := url.Parse(server.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	adm, err := New(u.Host, "minio", "minio123", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	if _, err = adm.ServerInfo(ctx); err == nil {
+		t.Fatal("Expected the call to fail once its deadline is exceeded")
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Expected the call to be abandoned at its deadline, took %s", elapsed)
+	}
+
+	// Calls with a canceled context fail right away.
+	canceledCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = adm.executeMethod(canceledCtx, http.MethodGet, requestData{relPath: "/v1/info"}); err == nil {
+		t.Fatal("Expected the call to fail with a canceled context")
+	}
+}
@@ -0,0 +1,72 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package madmin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// ScrubDiskStatus - progress of the background scrub of a disk,
+// counters are accumulated since the server started.
+type ScrubDiskStatus struct {
+	Endpoint string `json:"endpoint"`
+	// Passes is the number of completed scrubs of the disk.
+	Passes int64 `json:"passes"`
+	// PassStartTime is when the current or last pass started.
+	PassStartTime time.Time `json:"passStartTime"`
+	// PassEndTime is when the last completed pass ended.
+	PassEndTime time.Time `json:"passEndTime"`
+	// Current is the object being verified, empty between passes.
+	Current string `json:"current,omitempty"`
+
+	ObjectsScanned int64 `json:"objectsScanned"`
+	BytesVerified  int64 `json:"bytesVerified"`
+	CorruptedParts int64 `json:"corruptedParts"`
+	HealsScheduled int64 `json:"healsScheduled"`
+}
+
+// ScrubStatus - progress of the background scrub of all disks
+// of the cluster.
+type ScrubStatus struct {
+	// Enabled is false if no server scrubs its disks.
+	Enabled bool              `json:"enabled"`
+	Disks   []ScrubDiskStatus `json:"disks"`
+}
+
+// ScrubStatus - returns the progress of the background scrub
+// verifying the checksums of the data stored on the disks.
+func (adm *AdminClient) ScrubStatus(ctx context.Context) (ScrubStatus, error) {
+	// Execute GET on /minio/admin/v1/scrub/status
+	resp, err := adm.executeMethod(ctx, "GET", requestData{
+		relPath: "/v1/scrub/status",
+	})
+	defer closeResponse(resp)
+	if err != nil {
+		return ScrubStatus{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return ScrubStatus{}, httpRespToErrorResponse(resp)
+	}
+
+	var status ScrubStatus
+	err = json.NewDecoder(resp.Body).Decode(&status)
+	return status, err
+}
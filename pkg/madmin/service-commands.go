@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -28,14 +29,14 @@ import (
 )
 
 // ServiceRestart - restarts the MinIO cluster
-func (adm *AdminClient) ServiceRestart() error {
-	_, err := adm.serviceCallAction(ServiceActionRestart)
+func (adm *AdminClient) ServiceRestart(ctx context.Context) error {
+	_, err := adm.serviceCallAction(ctx, ServiceActionRestart)
 	return err
 }
 
 // ServiceStop - stops the MinIO cluster
-func (adm *AdminClient) ServiceStop() error {
-	_, err := adm.serviceCallAction(ServiceActionStop)
+func (adm *AdminClient) ServiceStop(ctx context.Context) error {
+	_, err := adm.serviceCallAction(ctx, ServiceActionStop)
 	return err
 }
 
@@ -50,12 +51,12 @@ const (
 )
 
 // serviceCallAction - call service restart/update/stop API.
-func (adm *AdminClient) serviceCallAction(action ServiceAction) ([]byte, error) {
+func (adm *AdminClient) serviceCallAction(ctx context.Context, action ServiceAction) ([]byte, error) {
 	queryValues := url.Values{}
 	queryValues.Set("action", string(action))
 
 	// Request API to Restart server
-	resp, err := adm.executeMethod("POST", requestData{
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
 		relPath:     "/v1/service",
 		queryValues: queryValues,
 	})
@@ -77,8 +78,9 @@ type ServiceTraceInfo struct {
 	Err   error `json:"-"`
 }
 
-// ServiceTrace - listen on http trace notifications.
-func (adm AdminClient) ServiceTrace(allTrace, errTrace bool, doneCh <-chan struct{}) <-chan ServiceTraceInfo {
+// ServiceTrace - listen on http trace notifications until the
+// context is canceled.
+func (adm AdminClient) ServiceTrace(ctx context.Context, allTrace, errTrace bool) <-chan ServiceTraceInfo {
 	traceInfoCh := make(chan ServiceTraceInfo)
 	// Only success, start a routine to start reading line by line.
 	go func(traceInfoCh chan<- ServiceTraceInfo) {
@@ -92,7 +94,7 @@ func (adm AdminClient) ServiceTrace(allTrace, errTrace bool, doneCh <-chan struc
 				queryValues: urlValues,
 			}
 			// Execute GET to call trace handler
-			resp, err := adm.executeMethod("GET", reqData)
+			resp, err := adm.executeMethod(ctx, "GET", reqData)
 			if err != nil {
 				closeResponse(resp)
 				return
@@ -110,11 +112,13 @@ func (adm AdminClient) ServiceTrace(allTrace, errTrace bool, doneCh <-chan struc
 					break
 				}
 				select {
-				case <-doneCh:
+				case <-ctx.Done():
+					closeResponse(resp)
 					return
 				case traceInfoCh <- ServiceTraceInfo{Trace: info}:
 				}
 			}
+			closeResponse(resp)
 		}
 	}(traceInfoCh)
 
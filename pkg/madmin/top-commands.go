@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -53,10 +54,10 @@ func (l LockEntries) Swap(i, j int) {
 }
 
 // TopLocks - returns the oldest locks in a minio setup.
-func (adm *AdminClient) TopLocks() (LockEntries, error) {
+func (adm *AdminClient) TopLocks(ctx context.Context) (LockEntries, error) {
 	// Execute GET on /minio/admin/v1/top/locks
 	// to get the oldest locks in a minio setup.
-	resp, err := adm.executeMethod("GET",
+	resp, err := adm.executeMethod(ctx, "GET",
 		requestData{relPath: "/v1/top/locks"})
 	defer closeResponse(resp)
 	if err != nil {
@@ -76,3 +77,30 @@ func (adm *AdminClient) TopLocks() (LockEntries, error) {
 	err = json.Unmarshal(response, &lockEntries)
 	return lockEntries, err
 }
+
+// ListLocks - returns a page of the locks currently held in a minio
+// setup, use the returned marker to fetch the next page.
+func (adm *AdminClient) ListLocks(ctx context.Context, opts ListOptions) (LockEntries, string, error) {
+	opts.Format = ListFormatJSON
+	resp, err := adm.executeMethod(ctx, "GET",
+		requestData{relPath: "/v1/locks", queryValues: opts.queryValues()})
+	defer closeResponse(resp)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, "", httpRespToErrorResponse(resp)
+	}
+
+	var result ListResult
+	if err = json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, "", err
+	}
+
+	var lockEntries LockEntries
+	if err = json.Unmarshal(result.Items, &lockEntries); err != nil {
+		return nil, "", err
+	}
+	return lockEntries, result.NextMarker, nil
+}
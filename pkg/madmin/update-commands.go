@@ -18,6 +18,7 @@
 package madmin
 
 import (
+	"context"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
@@ -28,16 +29,30 @@ import (
 type ServerUpdateStatus struct {
 	CurrentVersion string `json:"currentVersion"`
 	UpdatedVersion string `json:"updatedVersion"`
+	DryRun         bool   `json:"dryRun,omitempty"`
 }
 
 // ServerUpdate - updates and restarts the MinIO cluster to latest version.
 // optionally takes an input URL to specify a custom update binary link
-func (adm *AdminClient) ServerUpdate(updateURL string) (us ServerUpdateStatus, err error) {
+func (adm *AdminClient) ServerUpdate(ctx context.Context, updateURL string) (us ServerUpdateStatus, err error) {
+	return adm.serverUpdate(ctx, updateURL, false)
+}
+
+// ServerUpdateCheck - returns the version ServerUpdate would update the
+// MinIO cluster to, without updating it.
+func (adm *AdminClient) ServerUpdateCheck(ctx context.Context, updateURL string) (us ServerUpdateStatus, err error) {
+	return adm.serverUpdate(ctx, updateURL, true)
+}
+
+func (adm *AdminClient) serverUpdate(ctx context.Context, updateURL string, dryRun bool) (us ServerUpdateStatus, err error) {
 	queryValues := url.Values{}
 	queryValues.Set("updateURL", updateURL)
+	if dryRun {
+		queryValues.Set("dryRun", "true")
+	}
 
 	// Request API to Restart server
-	resp, err := adm.executeMethod("POST", requestData{
+	resp, err := adm.executeMethod(ctx, "POST", requestData{
 		relPath:     "/v1/update",
 		queryValues: queryValues,
 	})
@@ -89,6 +89,24 @@ const (
 
 	// GetBucketLifecycleAction - GetBucketLifecycle Rest API action.
 	GetBucketLifecycleAction = "s3:GetBucketLifecycle"
+
+	// PutBucketWebsiteAction - PutBucketWebsite Rest API action.
+	PutBucketWebsiteAction = "s3:PutBucketWebsite"
+
+	// GetBucketWebsiteAction - GetBucketWebsite Rest API action.
+	GetBucketWebsiteAction = "s3:GetBucketWebsite"
+
+	// DeleteBucketWebsiteAction - DeleteBucketWebsite Rest API action.
+	DeleteBucketWebsiteAction = "s3:DeleteBucketWebsite"
+
+	// PutBucketEncryptionAction - PutBucketEncryption and DeleteBucketEncryption Rest API action.
+	PutBucketEncryptionAction = "s3:PutEncryptionConfiguration"
+
+	// GetBucketEncryptionAction - GetBucketEncryption Rest API action.
+	GetBucketEncryptionAction = "s3:GetEncryptionConfiguration"
+
+	// RestoreObjectAction - RestoreObject Rest API action.
+	RestoreObjectAction = "s3:RestoreObject"
 )
 
 // isObjectAction - returns whether action is object type or not.
@@ -96,7 +114,7 @@ func (action Action) isObjectAction() bool {
 	switch action {
 	case AbortMultipartUploadAction, DeleteObjectAction, GetObjectAction:
 		fallthrough
-	case ListMultipartUploadPartsAction, PutObjectAction:
+	case ListMultipartUploadPartsAction, PutObjectAction, RestoreObjectAction:
 		return true
 	}
 
@@ -121,6 +139,12 @@ func (action Action) IsValid() bool {
 	case PutBucketPolicyAction, PutObjectAction:
 		fallthrough
 	case PutBucketLifecycleAction, GetBucketLifecycleAction:
+		fallthrough
+	case PutBucketWebsiteAction, GetBucketWebsiteAction, DeleteBucketWebsiteAction:
+		fallthrough
+	case PutBucketEncryptionAction, GetBucketEncryptionAction:
+		fallthrough
+	case RestoreObjectAction:
 		return true
 	}
 
@@ -204,4 +228,6 @@ var actionConditionKeyMap = map[Action]condition.KeySet{
 			condition.S3XAmzMetadataDirective,
 			condition.S3XAmzStorageClass,
 		}, condition.CommonKeys...)...),
+
+	RestoreObjectAction: condition.NewKeySet(condition.CommonKeys...),
 }
@@ -19,8 +19,8 @@ package condition
 import (
 	"fmt"
 	"net"
-	"net/http"
 	"sort"
+	"strings"
 )
 
 func toIPAddressFuncString(n name, key Key, values []*net.IPNet) string {
@@ -47,15 +47,13 @@ type ipAddressFunc struct {
 // falls in one of network or not.
 func (f ipAddressFunc) evaluate(values map[string][]string) bool {
 	IPs := []net.IP{}
-	requestValue, ok := values[http.CanonicalHeaderKey(f.k.Name())]
-	if !ok {
-		requestValue = values[f.k.Name()]
-	}
-
-	for _, s := range requestValue {
-		IP := net.ParseIP(s)
+	// Source IP is never taken from a request header, hence no
+	// lookup by canonical header key is done here.
+	for _, s := range values[f.k.Name()] {
+		IP := net.ParseIP(strings.TrimSpace(s))
 		if IP == nil {
-			panic(fmt.Errorf("invalid IP address '%v'", s))
+			// Invalid addresses never fall in any network.
+			continue
 		}
 
 		IPs = append(IPs, IP)
@@ -136,8 +134,18 @@ func valuesToIPNets(n name, values ValueSet) ([]*net.IPNet, error) {
 		}
 
 		var IPNet *net.IPNet
-		_, IPNet, err = net.ParseCIDR(s)
-		if err != nil {
+		if !strings.Contains(s, "/") {
+			// A plain IP address is treated as a network of that single host.
+			IP := net.ParseIP(s)
+			if IP == nil {
+				return nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
+			}
+			bits := 8 * net.IPv6len
+			if IP.To4() != nil {
+				IP, bits = IP.To4(), 8*net.IPv4len
+			}
+			IPNet = &net.IPNet{IP: IP, Mask: net.CIDRMask(bits, bits)}
+		} else if _, IPNet, err = net.ParseCIDR(s); err != nil {
 			return nil, fmt.Errorf("value %v must be CIDR string for %v condition", s, n)
 		}
 
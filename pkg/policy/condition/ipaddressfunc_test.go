@@ -36,6 +36,10 @@ func TestIPAddressFuncEvaluate(t *testing.T) {
 		{case1Function, map[string][]string{"SourceIp": {"192.168.2.10"}}, false},
 		{case1Function, map[string][]string{}, false},
 		{case1Function, map[string][]string{"delimiter": {"/"}}, false},
+		// Invalid source IP must not match.
+		{case1Function, map[string][]string{"SourceIp": {"192.168.1.10:9000"}}, false},
+		// Source IP is never read from the canonical header key.
+		{case1Function, map[string][]string{"Sourceip": {"192.168.1.10"}, "SourceIp": {"10.1.1.1"}}, false},
 	}
 
 	for i, testCase := range testCases {
@@ -210,6 +214,8 @@ func TestNewIPAddressFunc(t *testing.T) {
 	}{
 		{AWSSourceIP, NewValueSet(NewStringValue("192.168.1.0/24")), case1Function, false},
 		{AWSSourceIP, NewValueSet(NewStringValue("192.168.1.0/24"), NewStringValue("10.1.10.1/32")), case2Function, false},
+		// Plain IP address is a single host network.
+		{AWSSourceIP, NewValueSet(NewStringValue("192.168.1.0/24"), NewStringValue("10.1.10.1")), case2Function, false},
 		// Unsupported key error.
 		{S3Prefix, NewValueSet(NewStringValue("192.168.1.0/24")), nil, true},
 		// Invalid value error.
@@ -0,0 +1,112 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package ratelimit implements a simple token bucket rate limiter.
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Limiter - token bucket which is refilled at a constant rate
+// up to a maximum of burst tokens.
+type Limiter struct {
+	mu     sync.Mutex
+	rate   float64 // tokens added per second.
+	burst  float64 // maximum tokens held by the bucket.
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter - returns a new limiter allowing rate events per
+// second with bursts of at most burst events. The bucket starts
+// full. A burst smaller than one is treated as one.
+func NewLimiter(rate float64, burst int) *Limiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &Limiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// Allow - reports whether one event may happen now.
+func (l *Limiter) Allow() bool {
+	return l.AllowN(time.Now(), 1)
+}
+
+// AllowN - reports whether n events may happen at time now,
+// consuming n tokens if they are available.
+func (l *Limiter) AllowN(now time.Time, n int) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.advance(now)
+	if l.tokens < float64(n) {
+		return false
+	}
+	l.tokens -= float64(n)
+	return true
+}
+
+// Burst - returns the maximum number of tokens held by the bucket.
+func (l *Limiter) Burst() int {
+	return int(l.burst)
+}
+
+// WaitN - blocks until n events may happen or ctx is done, n
+// must not exceed the burst size. Tokens are reserved upfront,
+// hence concurrent waiters are served in order.
+func (l *Limiter) WaitN(ctx context.Context, n int) error {
+	l.mu.Lock()
+	l.advance(time.Now())
+	l.tokens -= float64(n)
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens / l.rate * float64(time.Second))
+	}
+	l.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// advance - refills the bucket for the time elapsed since the
+// last update, caller must hold the lock.
+func (l *Limiter) advance(now time.Time) {
+	if now.Before(l.last) {
+		return
+	}
+	l.tokens += now.Sub(l.last).Seconds() * l.rate
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
@@ -0,0 +1,104 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package ratelimit
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"testing"
+	"time"
+)
+
+func TestLimiterAllowN(t *testing.T) {
+	l := NewLimiter(10, 5)
+	now := l.last
+
+	// The bucket starts full with burst tokens.
+	for i := 0; i < 5; i++ {
+		if !l.AllowN(now, 1) {
+			t.Fatalf("event %d: expected to be allowed", i+1)
+		}
+	}
+	if l.AllowN(now, 1) {
+		t.Fatal("expected event to be denied on an empty bucket")
+	}
+
+	// 100ms at 10 tokens/sec refills exactly one token.
+	now = now.Add(100 * time.Millisecond)
+	if !l.AllowN(now, 1) {
+		t.Fatal("expected event to be allowed after refill")
+	}
+	if l.AllowN(now, 1) {
+		t.Fatal("expected event to be denied after consuming the refill")
+	}
+
+	// Refill never exceeds the burst size.
+	now = now.Add(time.Hour)
+	if l.AllowN(now, 6) {
+		t.Fatal("expected request larger than burst to be denied")
+	}
+	if !l.AllowN(now, 5) {
+		t.Fatal("expected request of burst size to be allowed")
+	}
+}
+
+func TestLimiterWaitN(t *testing.T) {
+	l := NewLimiter(100, 10)
+
+	// Tokens in the bucket are consumed without waiting.
+	start := time.Now()
+	if err := l.WaitN(context.Background(), 10); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 50*time.Millisecond {
+		t.Fatal("expected no wait on a full bucket")
+	}
+
+	// 5 tokens at 100 tokens/sec take 50ms to refill.
+	start = time.Now()
+	if err := l.WaitN(context.Background(), 5); err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) < 40*time.Millisecond {
+		t.Fatal("expected to wait for the bucket to refill")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.WaitN(ctx, 10); err != context.Canceled {
+		t.Fatalf("expected %v, got %v", context.Canceled, err)
+	}
+}
+
+func TestReader(t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 300)
+	l := NewLimiter(1000, 100)
+
+	start := time.Now()
+	got, err := ioutil.ReadAll(NewReader(context.Background(), bytes.NewReader(data), l))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, data) {
+		t.Fatal("unexpected data read")
+	}
+	// 100 bytes are in the bucket, the remaining 200 take 200ms.
+	if elapsed := time.Since(start); elapsed < 150*time.Millisecond {
+		t.Fatalf("expected read to be throttled, took %v", elapsed)
+	}
+}
@@ -0,0 +1,219 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+// Package thumbnail scales down JPEG, PNG and GIF images using only
+// the standard library decoders and encoders.
+package thumbnail
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	// MaxDimension - largest width or height of a thumbnail.
+	MaxDimension = 4096
+
+	// MaxSourcePixels - largest image, in pixels, which is decoded
+	// to protect the server from decompression bombs.
+	MaxSourcePixels = 25 * 1000 * 1000
+
+	// JPEG quality of the encoded thumbnails.
+	jpegQuality = 85
+)
+
+// Errors returned by this package.
+var (
+	ErrInvalidSpec      = errors.New("thumbnail: invalid size, expected <width>x<height>")
+	ErrUnsupportedImage = errors.New("thumbnail: unsupported image format")
+	ErrImageTooLarge    = errors.New("thumbnail: image too large")
+)
+
+// Spec - bounding box a thumbnail is fit into, a zero width or height
+// leaves that side unconstrained.
+type Spec struct {
+	Width  int
+	Height int
+}
+
+// ParseSpec - parses a spec of the form <width>x<height>, either side
+// may be omitted, e.g. "200x150", "200x" or "x150".
+func ParseSpec(s string) (spec Spec, err error) {
+	i := strings.IndexByte(s, 'x')
+	if i < 0 {
+		return spec, ErrInvalidSpec
+	}
+	parse := func(v string) (int, error) {
+		if v == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > MaxDimension {
+			return 0, ErrInvalidSpec
+		}
+		return n, nil
+	}
+	if spec.Width, err = parse(s[:i]); err != nil {
+		return spec, err
+	}
+	if spec.Height, err = parse(s[i+1:]); err != nil {
+		return spec, err
+	}
+	if spec.Width == 0 && spec.Height == 0 {
+		return spec, ErrInvalidSpec
+	}
+	return spec, nil
+}
+
+// String - returns the canonical form of the spec.
+func (s Spec) String() string {
+	var b strings.Builder
+	if s.Width > 0 {
+		b.WriteString(strconv.Itoa(s.Width))
+	}
+	b.WriteByte('x')
+	if s.Height > 0 {
+		b.WriteString(strconv.Itoa(s.Height))
+	}
+	return b.String()
+}
+
+// size - returns the size of the thumbnail of an image of width w and
+// height h, images are only ever scaled down and keep their aspect ratio.
+func (s Spec) size(w, h int) (int, int) {
+	scale := 1.0
+	if s.Width > 0 && w > s.Width {
+		scale = float64(s.Width) / float64(w)
+	}
+	if s.Height > 0 && h > s.Height {
+		if hs := float64(s.Height) / float64(h); hs < scale {
+			scale = hs
+		}
+	}
+	tw, th := int(float64(w)*scale+0.5), int(float64(h)*scale+0.5)
+	if tw < 1 {
+		tw = 1
+	}
+	if th < 1 {
+		th = 1
+	}
+	return tw, th
+}
+
+// IsSupported - returns true if images of contentType can be scaled.
+func IsSupported(contentType string) bool {
+	switch contentType {
+	case "image/jpeg", "image/png", "image/gif":
+		return true
+	}
+	return false
+}
+
+// Resize - reads an image of contentType from r and returns it scaled
+// down to fit into spec, encoded in the same format.
+func Resize(r io.Reader, contentType string, spec Spec) ([]byte, error) {
+	if !IsSupported(contentType) {
+		return nil, ErrUnsupportedImage
+	}
+
+	var buf bytes.Buffer
+	cfg, format, err := image.DecodeConfig(io.TeeReader(r, &buf))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if "image/"+format != contentType {
+		return nil, ErrUnsupportedImage
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || int64(cfg.Width)*int64(cfg.Height) > MaxSourcePixels {
+		return nil, ErrImageTooLarge
+	}
+
+	// Decode the first frame of animated images.
+	src, _, err := image.Decode(io.MultiReader(&buf, r))
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: %v", err)
+	}
+
+	dst := scale(src, spec)
+
+	var out bytes.Buffer
+	switch format {
+	case "jpeg":
+		err = jpeg.Encode(&out, dst, &jpeg.Options{Quality: jpegQuality})
+	case "png":
+		err = png.Encode(&out, dst)
+	case "gif":
+		err = gif.Encode(&out, dst, nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("thumbnail: %v", err)
+	}
+	return out.Bytes(), nil
+}
+
+// scale - scales src down to fit into spec, averaging the source pixels
+// covered by each destination pixel.
+func scale(src image.Image, spec Spec) image.Image {
+	bounds := src.Bounds()
+	sw, sh := bounds.Dx(), bounds.Dy()
+	tw, th := spec.size(sw, sh)
+	if tw == sw && th == sh {
+		return src
+	}
+
+	rgba := image.NewRGBA(image.Rect(0, 0, sw, sh))
+	draw.Draw(rgba, rgba.Bounds(), src, bounds.Min, draw.Src)
+
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	for y := 0; y < th; y++ {
+		y0, y1 := y*sh/th, (y+1)*sh/th
+		if y1 == y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < tw; x++ {
+			x0, x1 := x*sw/tw, (x+1)*sw/tw
+			if x1 == x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				p := rgba.Pix[sy*rgba.Stride+x0*4 : sy*rgba.Stride+x1*4]
+				for i := 0; i < len(p); i += 4 {
+					r += uint64(p[i])
+					g += uint64(p[i+1])
+					b += uint64(p[i+2])
+					a += uint64(p[i+3])
+					n++
+				}
+			}
+			i := y*dst.Stride + x*4
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(b / n)
+			dst.Pix[i+3] = uint8(a / n)
+		}
+	}
+	return dst
+}
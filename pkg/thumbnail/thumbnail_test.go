@@ -0,0 +1,103 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func TestParseSpec(t *testing.T) {
+	testCases := []struct {
+		spec        string
+		expected    Spec
+		expectedErr error
+	}{
+		{"200x150", Spec{200, 150}, nil},
+		{"200x", Spec{200, 0}, nil},
+		{"x150", Spec{0, 150}, nil},
+		{"x", Spec{}, ErrInvalidSpec},
+		{"200", Spec{}, ErrInvalidSpec},
+		{"0x150", Spec{}, ErrInvalidSpec},
+		{"-1x150", Spec{}, ErrInvalidSpec},
+		{"5000x150", Spec{}, ErrInvalidSpec},
+		{"axb", Spec{}, ErrInvalidSpec},
+	}
+	for i, testCase := range testCases {
+		spec, err := ParseSpec(testCase.spec)
+		if err != testCase.expectedErr {
+			t.Fatalf("Test %d: expected error %v, got %v", i+1, testCase.expectedErr, err)
+		}
+		if err == nil && spec != testCase.expected {
+			t.Fatalf("Test %d: expected %v, got %v", i+1, testCase.expected, spec)
+		}
+		if err == nil && spec.String() != testCase.spec {
+			t.Fatalf("Test %d: expected canonical form %s, got %s", i+1, testCase.spec, spec.String())
+		}
+	}
+}
+
+func TestResize(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			src.Set(x, y, color.RGBA{R: 255, A: 255})
+		}
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, src); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		spec           Spec
+		expectedWidth  int
+		expectedHeight int
+	}{
+		{Spec{Width: 100}, 100, 50},
+		{Spec{Height: 100}, 200, 100},
+		{Spec{Width: 100, Height: 100}, 100, 50},
+		// Images are never scaled up.
+		{Spec{Width: 1000, Height: 1000}, 400, 200},
+	}
+	for i, testCase := range testCases {
+		data, err := Resize(bytes.NewReader(buf.Bytes()), "image/png", testCase.spec)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		img, err := png.Decode(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if img.Bounds().Dx() != testCase.expectedWidth || img.Bounds().Dy() != testCase.expectedHeight {
+			t.Fatalf("Test %d: expected %dx%d, got %dx%d", i+1, testCase.expectedWidth, testCase.expectedHeight, img.Bounds().Dx(), img.Bounds().Dy())
+		}
+		if r, _, _, _ := img.At(0, 0).RGBA(); r != 0xffff {
+			t.Fatalf("Test %d: expected a red thumbnail, got %v", i+1, img.At(0, 0))
+		}
+	}
+
+	if _, err := Resize(bytes.NewReader(buf.Bytes()), "image/jpeg", Spec{Width: 100}); err != ErrUnsupportedImage {
+		t.Fatalf("Expected ErrUnsupportedImage for a mismatched content type, got %v", err)
+	}
+	if _, err := Resize(bytes.NewReader([]byte("not an image")), "image/png", Spec{Width: 100}); err != ErrUnsupportedImage {
+		t.Fatalf("Expected ErrUnsupportedImage for invalid data, got %v", err)
+	}
+}
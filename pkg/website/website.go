@@ -0,0 +1,250 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+var (
+	errWebsiteEmpty                = errors.New("Website configuration should have either an index document or redirect all requests to a host")
+	errWebsiteRedirectAllExclusive = errors.New("Website configuration redirecting all requests cannot have other configuration")
+	errWebsiteInvalidIndexSuffix   = errors.New("Website index document suffix should not be empty or contain a slash")
+	errWebsiteInvalidErrorKey      = errors.New("Website error document key should not be empty")
+	errWebsiteMissingHostName      = errors.New("Website redirect all requests should have a host name")
+	errWebsiteInvalidProtocol      = errors.New("Website redirect protocol should be either http or https")
+	errWebsiteTooManyRules         = errors.New("Website configuration allows a maximum of 50 routing rules")
+	errWebsiteInvalidRedirectCode  = errors.New("Website redirect HTTP code should be a 3XX code")
+	errWebsiteInvalidErrorCode     = errors.New("Website condition HTTP error code should be a 4XX or 5XX code")
+	errWebsiteReplaceKeyExclusive  = errors.New("Website redirect cannot have both ReplaceKeyWith and ReplaceKeyPrefixWith")
+)
+
+// maxRoutingRules - maximum number of routing rules per configuration.
+const maxRoutingRules = 50
+
+// IndexDocument - document returned for requests on a directory.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - document returned when an error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects every request on the bucket to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - condition which must be met for a routing rule to apply.
+type Condition struct {
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+	HTTPErrorCodeReturnedEquals string `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+}
+
+// Redirect - redirect information of a routing rule.
+type Redirect struct {
+	HostName             string `xml:"HostName,omitempty"`
+	HTTPRedirectCode     string `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching its condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  Redirect   `xml:"Redirect"`
+}
+
+// RoutingRules - routing rules applied to requests in order.
+type RoutingRules struct {
+	Rules []RoutingRule `xml:"RoutingRule"`
+}
+
+// Website - Configuration for bucket website hosting.
+type Website struct {
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	RoutingRules          *RoutingRules          `xml:"RoutingRules,omitempty"`
+}
+
+// Redirection - location a request is redirected to, an empty
+// HostName means the request is redirected on the same host.
+type Redirection struct {
+	Protocol   string
+	HostName   string
+	Key        string
+	StatusCode int
+}
+
+// ParseWebsiteConfig - parses data in given reader to Website.
+func ParseWebsiteConfig(reader io.Reader) (*Website, error) {
+	var w Website
+	if err := xml.NewDecoder(reader).Decode(&w); err != nil {
+		return nil, err
+	}
+	if err := w.Validate(); err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+func validProtocol(protocol string) bool {
+	return protocol == "" || protocol == "http" || protocol == "https"
+}
+
+// parseCode - parses an HTTP status code between min and max.
+func parseCode(code string, min, max int) bool {
+	c, err := strconv.Atoi(code)
+	return err == nil && c >= min && c <= max
+}
+
+// rules - returns the routing rules of the configuration.
+func (w Website) rules() []RoutingRule {
+	if w.RoutingRules == nil {
+		return nil
+	}
+	return w.RoutingRules.Rules
+}
+
+// Validate - validates the website configuration
+func (w Website) Validate() error {
+	if w.RedirectAllRequestsTo != nil {
+		if w.IndexDocument != nil || w.ErrorDocument != nil || w.RoutingRules != nil {
+			return errWebsiteRedirectAllExclusive
+		}
+		if w.RedirectAllRequestsTo.HostName == "" {
+			return errWebsiteMissingHostName
+		}
+		if !validProtocol(w.RedirectAllRequestsTo.Protocol) {
+			return errWebsiteInvalidProtocol
+		}
+		return nil
+	}
+
+	if w.IndexDocument == nil {
+		return errWebsiteEmpty
+	}
+	if w.IndexDocument.Suffix == "" || strings.Contains(w.IndexDocument.Suffix, "/") {
+		return errWebsiteInvalidIndexSuffix
+	}
+	if w.ErrorDocument != nil && w.ErrorDocument.Key == "" {
+		return errWebsiteInvalidErrorKey
+	}
+	if len(w.rules()) > maxRoutingRules {
+		return errWebsiteTooManyRules
+	}
+	for _, rule := range w.rules() {
+		if rule.Condition != nil && rule.Condition.HTTPErrorCodeReturnedEquals != "" &&
+			!parseCode(rule.Condition.HTTPErrorCodeReturnedEquals, 400, 599) {
+			return errWebsiteInvalidErrorCode
+		}
+		if rule.Redirect.HTTPRedirectCode != "" && !parseCode(rule.Redirect.HTTPRedirectCode, 300, 399) {
+			return errWebsiteInvalidRedirectCode
+		}
+		if !validProtocol(rule.Redirect.Protocol) {
+			return errWebsiteInvalidProtocol
+		}
+		if rule.Redirect.ReplaceKeyWith != "" && rule.Redirect.ReplaceKeyPrefixWith != "" {
+			return errWebsiteReplaceKeyExclusive
+		}
+	}
+	return nil
+}
+
+// IndexKey - returns the object to serve for key, requests on the
+// bucket root or on a directory serve its index document.
+func (w Website) IndexKey(key string) string {
+	if w.IndexDocument == nil {
+		return key
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + w.IndexDocument.Suffix
+	}
+	return key
+}
+
+// ErrorKey - returns the error document, if any.
+func (w Website) ErrorKey() (string, bool) {
+	if w.ErrorDocument == nil {
+		return "", false
+	}
+	return w.ErrorDocument.Key, true
+}
+
+// Redirect - returns where a request for key should be redirected
+// to. errCode is the HTTP error code returned for key, or 0 before
+// the object is looked up; routing rules conditioned on an error
+// code only apply once that error is returned.
+func (w Website) Redirect(key string, errCode int) (Redirection, bool) {
+	if w.RedirectAllRequestsTo != nil {
+		return Redirection{
+			Protocol:   w.RedirectAllRequestsTo.Protocol,
+			HostName:   w.RedirectAllRequestsTo.HostName,
+			Key:        key,
+			StatusCode: http.StatusMovedPermanently,
+		}, true
+	}
+
+	for _, rule := range w.rules() {
+		prefix := ""
+		if rule.Condition != nil {
+			prefix = rule.Condition.KeyPrefixEquals
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if code := rule.Condition.HTTPErrorCodeReturnedEquals; code != "" {
+				if code != strconv.Itoa(errCode) {
+					continue
+				}
+			} else if errCode != 0 {
+				continue
+			}
+		} else if errCode != 0 {
+			continue
+		}
+
+		redirect := Redirection{
+			Protocol:   rule.Redirect.Protocol,
+			HostName:   rule.Redirect.HostName,
+			Key:        key,
+			StatusCode: http.StatusMovedPermanently,
+		}
+		switch {
+		case rule.Redirect.ReplaceKeyWith != "":
+			redirect.Key = rule.Redirect.ReplaceKeyWith
+		case rule.Redirect.ReplaceKeyPrefixWith != "":
+			redirect.Key = rule.Redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+		}
+		if rule.Redirect.HTTPRedirectCode != "" {
+			redirect.StatusCode, _ = strconv.Atoi(rule.Redirect.HTTPRedirectCode)
+		}
+		return redirect, true
+	}
+
+	return Redirection{}, false
+}
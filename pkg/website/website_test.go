@@ -0,0 +1,148 @@
+/*
+ * MinIO Cloud Storage, (C) 2019 MinIO, Inc.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *     http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ */
+
+package website
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestParseWebsiteConfig(t *testing.T) {
+	testCases := []struct {
+		inputConfig string
+		expectedErr error
+	}{
+		{ // Index and error documents
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>404.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Redirect all requests
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: nil,
+		},
+		{ // Empty configuration
+			inputConfig: `<WebsiteConfiguration></WebsiteConfiguration>`,
+			expectedErr: errWebsiteEmpty,
+		},
+		{ // Redirect all requests along with an index document
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo><IndexDocument><Suffix>index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: errWebsiteRedirectAllExclusive,
+		},
+		{ // Redirect all requests without a host name
+			inputConfig: `<WebsiteConfiguration><RedirectAllRequestsTo><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectedErr: errWebsiteMissingHostName,
+		},
+		{ // Index document suffix with a slash
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>dir/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectedErr: errWebsiteInvalidIndexSuffix,
+		},
+		{ // Routing rule with an invalid redirect code
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errWebsiteInvalidRedirectCode,
+		},
+		{ // Routing rule with an invalid error code
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><HttpErrorCodeReturnedEquals>302</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errWebsiteInvalidErrorCode,
+		},
+		{ // Routing rule replacing both key and key prefix
+			inputConfig: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyWith>a</ReplaceKeyWith><ReplaceKeyPrefixWith>b</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectedErr: errWebsiteReplaceKeyExclusive,
+		},
+	}
+
+	for i, tc := range testCases {
+		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
+			if _, err := ParseWebsiteConfig(bytes.NewReader([]byte(tc.inputConfig))); err != tc.expectedErr {
+				t.Fatalf("expected %v, got %v", tc.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestMarshalWebsiteConfig(t *testing.T) {
+	config := `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+	w, err := ParseWebsiteConfig(bytes.NewReader([]byte(config)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != config {
+		t.Fatalf("expected %s, got %s", config, string(data))
+	}
+}
+
+func TestWebsiteIndexKey(t *testing.T) {
+	w := Website{IndexDocument: &IndexDocument{Suffix: "index.html"}}
+	testCases := []struct {
+		key         string
+		expectedKey string
+	}{
+		{"", "index.html"},
+		{"docs/", "docs/index.html"},
+		{"docs/page.html", "docs/page.html"},
+	}
+	for i, tc := range testCases {
+		if key := w.IndexKey(tc.key); key != tc.expectedKey {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expectedKey, key)
+		}
+	}
+}
+
+func TestWebsiteRedirect(t *testing.T) {
+	w := Website{
+		IndexDocument: &IndexDocument{Suffix: "index.html"},
+		RoutingRules: &RoutingRules{Rules: []RoutingRule{
+			{
+				Condition: &Condition{KeyPrefixEquals: "docs/"},
+				Redirect:  Redirect{ReplaceKeyPrefixWith: "documents/"},
+			},
+			{
+				Condition: &Condition{HTTPErrorCodeReturnedEquals: "404"},
+				Redirect:  Redirect{HostName: "example.com", Protocol: "https", ReplaceKeyWith: "missing.html", HTTPRedirectCode: "302"},
+			},
+		}},
+	}
+	testCases := []struct {
+		key              string
+		errCode          int
+		expectedOk       bool
+		expectedRedirect Redirection
+	}{
+		{"docs/a.html", 0, true, Redirection{Key: "documents/a.html", StatusCode: http.StatusMovedPermanently}},
+		{"images/a.png", 0, false, Redirection{}},
+		{"images/a.png", 404, true, Redirection{Protocol: "https", HostName: "example.com", Key: "missing.html", StatusCode: http.StatusFound}},
+		{"images/a.png", 403, false, Redirection{}},
+	}
+	for i, tc := range testCases {
+		redirect, ok := w.Redirect(tc.key, tc.errCode)
+		if ok != tc.expectedOk || redirect != tc.expectedRedirect {
+			t.Errorf("Test %d: expected %v %+v, got %v %+v", i+1, tc.expectedOk, tc.expectedRedirect, ok, redirect)
+		}
+	}
+
+	all := Website{RedirectAllRequestsTo: &RedirectAllRequestsTo{HostName: "example.com"}}
+	redirect, ok := all.Redirect("a.html", 0)
+	if !ok || redirect.HostName != "example.com" || redirect.Key != "a.html" {
+		t.Errorf("expected all requests to be redirected, got %v %+v", ok, redirect)
+	}
+}